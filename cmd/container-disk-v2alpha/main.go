@@ -5,7 +5,9 @@ import (
 	"fmt"
 	"net"
 	"os"
+	"os/signal"
 	"path/filepath"
+	"syscall"
 	"time"
 
 	"kubevirt.io/client-go/log"
@@ -20,13 +22,23 @@ func main() {
 
 	var copyPath string
 	var healthCheck bool
+	var noOp bool
 
 	logger := log.DefaultLogger()
 
 	flag.StringVar(&copyPath, "copy-path", "", "Image target path")
 	flag.BoolVar(&healthCheck, "health-check", false, "Do a health check")
+	flag.BoolVar(&noOp, "no-op", false, "Only keep the container running, used to keep the image cached on a node")
 	flag.Parse()
 
+	if noOp {
+		// Exit on termination, nothing else to do
+		signals := make(chan os.Signal, 1)
+		signal.Notify(signals, syscall.SIGTERM, syscall.SIGINT)
+		<-signals
+		os.Exit(0)
+	}
+
 	if !healthCheck && copyPath == "" {
 		logger.Error("No copy-path provided.")
 		os.Exit(1)
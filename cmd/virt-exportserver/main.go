@@ -0,0 +1,94 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2020 Red Hat, Inc.
+ *
+ */
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
+
+	flag "github.com/spf13/pflag"
+
+	"kubevirt.io/client-go/log"
+	vmexport "kubevirt.io/kubevirt/pkg/vm-export"
+)
+
+const shutdownTimeout = 10 * time.Second
+
+// virt-exportserver serves the volumes of a VirtualMachineExport, and the
+// manifest of its VirtualMachine, over HTTPS to clients presenting the export token.
+func main() {
+	listen := flag.String("listen", fmt.Sprintf(":%d", vmexport.Port), "Address to listen on")
+	certFile := flag.String("cert-file", filepath.Join(vmexport.SecretDir, vmexport.CertKey), "TLS certificate of the server")
+	keyFile := flag.String("key-file", filepath.Join(vmexport.SecretDir, vmexport.KeyKey), "TLS key of the server")
+	pathPrefix := flag.String("path-prefix", "", "Path all links of the export start with")
+	volumes := flag.StringArray("volume", nil, "Volume to export, as name=path of its raw image. Can be repeated")
+	manifestFile := flag.String("manifest-file", "", "Manifest of the exported VirtualMachine")
+	flag.Parse()
+
+	logger := log.DefaultLogger()
+
+	token := os.Getenv(vmexport.TokenEnvVar)
+	if token == "" {
+		logger.Errorf("%s is required.", vmexport.TokenEnvVar)
+		os.Exit(1)
+	}
+
+	server := &vmexport.Server{
+		Token:        token,
+		PathPrefix:   *pathPrefix,
+		Volumes:      map[string]string{},
+		ManifestFile: *manifestFile,
+	}
+	for _, volume := range *volumes {
+		parts := strings.SplitN(volume, "=", 2)
+		if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			logger.Errorf("Invalid volume %s, expected name=path.", volume)
+			os.Exit(1)
+		}
+		server.Volumes[parts[0]] = parts[1]
+	}
+
+	httpServer := &http.Server{
+		Addr:    *listen,
+		Handler: server.Handler(),
+	}
+
+	go func() {
+		signals := make(chan os.Signal, 1)
+		signal.Notify(signals, syscall.SIGTERM, syscall.SIGINT)
+		<-signals
+		ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+		httpServer.Shutdown(ctx)
+	}()
+
+	logger.Infof("Serving %d volumes on %s.", len(server.Volumes), *listen)
+	if err := httpServer.ListenAndServeTLS(*certFile, *keyFile); err != nil && err != http.ErrServerClosed {
+		logger.Reason(err).Error("Failed to serve the export.")
+		os.Exit(1)
+	}
+}
@@ -24,7 +24,6 @@ import (
 	"crypto/x509"
 	"fmt"
 	"io"
-	"io/ioutil"
 	"net/http"
 	"os"
 	"path/filepath"
@@ -33,25 +32,20 @@ import (
 	"github.com/emicklei/go-restful"
 	"github.com/golang/glog"
 	flag "github.com/spf13/pflag"
-	"k8s.io/apimachinery/pkg/api/errors"
-	"k8s.io/client-go/util/certificate"
 
 	k8sv1 "k8s.io/api/core/v1"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/client-go/kubernetes/scheme"
 	k8coresv1 "k8s.io/client-go/kubernetes/typed/core/v1"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/tools/record"
-	"k8s.io/client-go/util/cert"
 
 	v1 "kubevirt.io/client-go/api/v1"
 	"kubevirt.io/client-go/kubecli"
 	"kubevirt.io/client-go/log"
 	clientutil "kubevirt.io/client-go/util"
-	"kubevirt.io/kubevirt/pkg/certificates"
-	"kubevirt.io/kubevirt/pkg/certificates/triple"
+	"kubevirt.io/kubevirt/pkg/certificates/bootstrap"
 	"kubevirt.io/kubevirt/pkg/controller"
 	inotifyinformer "kubevirt.io/kubevirt/pkg/inotify-informer"
 	_ "kubevirt.io/kubevirt/pkg/monitoring/client/prometheus"    // import for prometheus metrics
@@ -63,6 +57,7 @@ import (
 	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
 	virthandler "kubevirt.io/kubevirt/pkg/virt-handler"
 	virtcache "kubevirt.io/kubevirt/pkg/virt-handler/cache"
+	imagecache "kubevirt.io/kubevirt/pkg/virt-handler/image-cache"
 	"kubevirt.io/kubevirt/pkg/virt-handler/isolation"
 	"kubevirt.io/kubevirt/pkg/virt-handler/rest"
 	"kubevirt.io/kubevirt/pkg/virt-handler/selinux"
@@ -86,13 +81,7 @@ const (
 	// This value is derived from default MaxPods in Kubelet Config
 	maxDevices = 110
 
-	clientCertBytesValue  = "client-cert-bytes"
-	clientKeyBytesValue   = "client-key-bytes"
-	signingCertBytesValue = "signing-cert-bytes"
-
-	// selfsigned cert secret name
-	virtHandlerCertSecretName = "kubevirt-virt-handler-certs"
-	maxRequestsInFlight       = 3
+	maxRequestsInFlight = 3
 	// Default port that virt-handler listens to console requests
 	defaultConsoleServerPort = 8186
 )
@@ -107,9 +96,8 @@ type virtHandlerApp struct {
 	MaxDevices              int
 	MaxRequestsInFlight     int
 
-	signingCertBytes []byte
-	clientCertBytes  []byte
-	clientKeyBytes   []byte
+	clientcertmanager bootstrap.CertificateManager
+	servercertmanager bootstrap.CertificateManager
 
 	virtCli   kubecli.KubevirtClient
 	namespace string
@@ -120,55 +108,6 @@ type virtHandlerApp struct {
 
 var _ service.Service = &virtHandlerApp{}
 
-func (app *virtHandlerApp) getSelfSignedCert() error {
-	var ok bool
-
-	caKeyPair, _ := triple.NewCA("kubevirt.io")
-	clientKeyPair, _ := triple.NewClientKeyPair(caKeyPair,
-		"kubevirt.io:system:node:virt-handler",
-		nil,
-	)
-
-	secret := &k8sv1.Secret{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      virtHandlerCertSecretName,
-			Namespace: app.namespace,
-			Labels: map[string]string{
-				v1.AppLabel: "virt-api-aggregator",
-			},
-		},
-		Type: "Opaque",
-		Data: map[string][]byte{
-			clientCertBytesValue:  cert.EncodeCertPEM(clientKeyPair.Cert),
-			clientKeyBytesValue:   cert.EncodePrivateKeyPEM(clientKeyPair.Key),
-			signingCertBytesValue: cert.EncodeCertPEM(caKeyPair.Cert),
-		},
-	}
-	_, err := app.virtCli.CoreV1().Secrets(app.namespace).Create(secret)
-	if errors.IsAlreadyExists(err) {
-		secret, err = app.virtCli.CoreV1().Secrets(app.namespace).Get(virtHandlerCertSecretName, metav1.GetOptions{})
-		if err != nil {
-			return err
-		}
-	} else if err != nil {
-		return err
-	}
-	// retrieve self signed cert info from secret
-	app.clientCertBytes, ok = secret.Data[clientCertBytesValue]
-	if !ok {
-		return fmt.Errorf("%s value not found in %s virt-api secret", clientCertBytesValue, virtHandlerCertSecretName)
-	}
-	app.clientKeyBytes, ok = secret.Data[clientKeyBytesValue]
-	if !ok {
-		return fmt.Errorf("%s value not found in %s virt-api secret", clientKeyBytesValue, virtHandlerCertSecretName)
-	}
-	app.signingCertBytes, ok = secret.Data[signingCertBytesValue]
-	if !ok {
-		return fmt.Errorf("%s value not found in %s virt-api secret", signingCertBytesValue, virtHandlerCertSecretName)
-	}
-	return nil
-}
-
 func (app *virtHandlerApp) Run() {
 	// HostOverride should default to os.Hostname(), to make sure we handle errors ensure it here.
 	if app.HostOverride == "" {
@@ -270,13 +209,15 @@ func (app *virtHandlerApp) Run() {
 		glog.Fatalf("Error searching for namespace: %v", err)
 	}
 
-	if err := app.getSelfSignedCert(); err != nil {
-		glog.Fatalf("Error loading self signed certificates: %v", err)
-	}
+	// Load the certificates which virt-operator issued
+	app.clientcertmanager = bootstrap.NewFileCertificateManager(bootstrap.VirtHandlerCertDir)
+	app.clientcertmanager.Start()
+	defer app.clientcertmanager.Stop()
+	app.servercertmanager = bootstrap.NewFileCertificateManager(bootstrap.VirtHandlerServerCertDir)
+	app.servercertmanager.Start()
+	defer app.servercertmanager.Stop()
 
-	if err := app.setupTLS(); err != nil {
-		glog.Fatalf("Error constructing migration tls config: %v", err)
-	}
+	app.setupTLS()
 
 	factory := controller.NewKubeInformerFactory(app.virtCli.RestClient(), app.virtCli, app.namespace)
 
@@ -287,6 +228,23 @@ func (app *virtHandlerApp) Run() {
 		0,
 		cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
 
+	pullerPodFields, pullerPodLabel, err := imagecache.PullerPodSelectors(app.HostOverride)
+	if err != nil {
+		panic(err)
+	}
+	pullerPodInformer := cache.NewSharedIndexInformer(
+		controller.NewListWatchFromClient(app.virtCli.CoreV1().RESTClient(), "pods", app.namespace, pullerPodFields, pullerPodLabel),
+		&k8sv1.Pod{},
+		0,
+		cache.Indexers{},
+	)
+	imageCacheController := imagecache.NewController(
+		app.virtCli,
+		app.HostOverride,
+		factory.ContainerDiskImageCache(),
+		pullerPodInformer,
+	)
+
 	podIsolationDetector := isolation.NewSocketBasedIsolationDetector(app.VirtShareDir)
 	vmiInformer := factory.VMI()
 
@@ -302,7 +260,7 @@ func (app *virtHandlerApp) Run() {
 		gracefulShutdownInformer,
 		int(app.WatchdogTimeoutDuration.Seconds()),
 		app.MaxDevices,
-		virtconfig.NewClusterConfig(factory.ConfigMap(), factory.CRD(), app.namespace),
+		virtconfig.NewClusterConfig(factory.ConfigMap(), factory.CRD(), factory.KubeVirt(), app.namespace),
 		app.migrationTLSConfig,
 		podIsolationDetector,
 	)
@@ -317,47 +275,59 @@ func (app *virtHandlerApp) Run() {
 		app.VirtShareDir,
 	)
 
-	certsDirectory, err := ioutil.TempDir("", "certsdir")
-	if err != nil {
-		panic(err)
-	}
-	defer os.RemoveAll(certsDirectory)
-
-	certStore, err := certificates.GenerateSelfSignedCert(certsDirectory, "virt-handler", app.namespace)
-	if err != nil {
-		glog.Fatalf("unable to generate certificates: %v", err)
-	}
-
 	promvm.SetupCollector(app.virtCli, app.VirtShareDir, app.HostOverride)
 
 	// Bootstrapping. From here on the startup order matters
 	stop := make(chan struct{})
 	defer close(stop)
 	factory.Start(stop)
-	cache.WaitForCacheSync(stop, factory.ConfigMap().HasSynced, vmiInformer.HasSynced)
+	cache.WaitForCacheSync(stop, factory.ConfigMap().HasSynced, factory.KubeVirt().HasSynced, vmiInformer.HasSynced)
 
 	go vmController.Run(10, stop)
+	go pullerPodInformer.Run(stop)
+	go imageCacheController.Run(1, stop)
 
 	errCh := make(chan error)
-	go app.runPrometheusServer(errCh, certStore)
+	go app.runPrometheusServer(errCh)
 	go app.runServer(errCh, consoleHandler, lifecycleHandler)
 
 	// wait for one of the servers to exit
 	<-errCh
 }
 
-func (app *virtHandlerApp) runPrometheusServer(errCh chan error, certStore certificate.FileStore) {
+func (app *virtHandlerApp) runPrometheusServer(errCh chan error) {
 	log.Log.V(1).Infof("metrics: max concurrent requests=%d", app.MaxRequestsInFlight)
 	http.Handle("/metrics", promvm.Handler(app.MaxRequestsInFlight))
-	errCh <- http.ListenAndServeTLS(app.ServiceListen.Address(), certStore.CurrentPath(), certStore.CurrentPath(), nil)
+	server := &http.Server{
+		Addr: app.ServiceListen.Address(),
+		TLSConfig: &tls.Config{
+			GetCertificate: func(info *tls.ClientHelloInfo) (*tls.Certificate, error) {
+				crt := app.servercertmanager.Current()
+				if crt == nil {
+					return nil, fmt.Errorf("no serving certificate available for virt-handler")
+				}
+				return crt, nil
+			},
+		},
+	}
+	errCh <- server.ListenAndServeTLS("", "")
 }
 
 func (app *virtHandlerApp) runServer(errCh chan error, consoleHandler *rest.ConsoleHandler, lifecycleHandler *rest.LifecycleHandler) {
 	ws := new(restful.WebService)
 	ws.Route(ws.GET("/v1/namespaces/{namespace}/virtualmachineinstances/{name}/console").To(consoleHandler.SerialHandler))
 	ws.Route(ws.GET("/v1/namespaces/{namespace}/virtualmachineinstances/{name}/vnc").To(consoleHandler.VNCHandler))
+	ws.Route(ws.GET("/v1/namespaces/{namespace}/virtualmachineinstances/{name}/usbredir").To(consoleHandler.USBRedirHandler))
+	ws.Route(ws.GET("/v1/namespaces/{namespace}/virtualmachineinstances/{name}/portforward/{port}").To(consoleHandler.PortForwardHandler))
 	ws.Route(ws.PUT("/v1/namespaces/{namespace}/virtualmachineinstances/{name}/pause").To(lifecycleHandler.PauseHandler))
 	ws.Route(ws.PUT("/v1/namespaces/{namespace}/virtualmachineinstances/{name}/unpause").To(lifecycleHandler.UnpauseHandler))
+	ws.Route(ws.PUT("/v1/namespaces/{namespace}/virtualmachineinstances/{name}/softreboot").To(lifecycleHandler.SoftRebootHandler))
+	ws.Route(ws.PUT("/v1/namespaces/{namespace}/virtualmachineinstances/{name}/memorydump").To(lifecycleHandler.MemoryDumpHandler))
+	ws.Route(ws.GET("/v1/namespaces/{namespace}/virtualmachineinstances/{name}/screenshot").To(lifecycleHandler.ScreenshotHandler))
+	ws.Route(ws.GET("/v1/namespaces/{namespace}/virtualmachineinstances/{name}/guestosinfo").To(lifecycleHandler.GetGuestInfo))
+	ws.Route(ws.GET("/v1/namespaces/{namespace}/virtualmachineinstances/{name}/userlist").To(lifecycleHandler.GetUsers))
+	ws.Route(ws.GET("/v1/namespaces/{namespace}/virtualmachineinstances/{name}/filesystemlist").To(lifecycleHandler.GetFilesystems))
+	ws.Route(ws.PUT("/v1/namespaces/{namespace}/virtualmachineinstances/{name}/guest-exec").To(lifecycleHandler.GuestExecHandler))
 	restful.DefaultContainer.Add(ws)
 	server := &http.Server{
 		Addr:    fmt.Sprintf("%s:%d", app.ServiceListen.BindAddress, app.consoleServerPort),
@@ -404,32 +374,25 @@ func (app *virtHandlerApp) AddFlags() {
 		"The port virt-handler listens on for console requests")
 }
 
-func (app *virtHandlerApp) setupTLS() error {
-
-	clientCert, err := tls.X509KeyPair(app.clientCertBytes, app.clientKeyBytes)
-	if err != nil {
-		return err
-	}
-
-	caCert, err := cert.ParseCertsPEM(app.signingCertBytes)
-	if err != nil {
-		return err
-	}
-
-	certPool := x509.NewCertPool()
-
-	for _, crt := range caCert {
-		certPool.AddCert(crt)
+// setupTLS constructs the TLS configuration for migrations and console
+// connections. The client certificate and the CA bundle are looked up on
+// every handshake, so that rotated certificates are picked up.
+func (app *virtHandlerApp) setupTLS() {
+	getCertificate := func() (*tls.Certificate, error) {
+		crt := app.clientcertmanager.Current()
+		if crt == nil {
+			return nil, fmt.Errorf("no client certificate available for virt-handler")
+		}
+		return crt, nil
 	}
 
 	app.migrationTLSConfig = &tls.Config{
 		MinVersion: tls.VersionTLS12,
-		ClientCAs:  certPool,
 		GetClientCertificate: func(info *tls.CertificateRequestInfo) (certificate *tls.Certificate, e error) {
-			return &clientCert, nil
+			return getCertificate()
 		},
 		GetCertificate: func(info *tls.ClientHelloInfo) (i *tls.Certificate, e error) {
-			return &clientCert, nil
+			return getCertificate()
 		},
 		// Neither the client nor the server should validate anything itself, `VerifyPeerCertificate` is still executed
 		InsecureSkipVerify: true,
@@ -446,7 +409,7 @@ func (app *virtHandlerApp) setupTLS() error {
 				return fmt.Errorf("failed to parse peer certificate: %v", err)
 			}
 			_, err = c.Verify(x509.VerifyOptions{
-				Roots:     certPool,
+				Roots:     app.clientcertmanager.CAPool(),
 				KeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
 			})
 
@@ -457,8 +420,6 @@ func (app *virtHandlerApp) setupTLS() error {
 		},
 		ClientAuth: tls.RequireAndVerifyClientCert,
 	}
-
-	return nil
 }
 
 func main() {
@@ -40,6 +40,7 @@ import (
 	"kubevirt.io/client-go/log"
 	cloudinit "kubevirt.io/kubevirt/pkg/cloud-init"
 	"kubevirt.io/kubevirt/pkg/config"
+	consolelog "kubevirt.io/kubevirt/pkg/console-log"
 	containerdisk "kubevirt.io/kubevirt/pkg/container-disk"
 	ephemeraldisk "kubevirt.io/kubevirt/pkg/ephemeral-disk"
 	"kubevirt.io/kubevirt/pkg/hooks"
@@ -133,6 +134,24 @@ func startDomainEventMonitoring(notifier *notifyclient.Notifier, virtShareDir st
 	}
 }
 
+// startConsoleLogForwarding passes the serial console log, which virtlogd
+// writes with root only permissions, to the non-root guest-console-log container
+func startConsoleLogForwarding(stopChan chan struct{}) {
+	pipePath := consolelog.PipePath()
+	if err := consolelog.CreatePipe(pipePath); err != nil {
+		log.Log.Reason(err).Errorf("Failed to create the serial console log pipe %s.", pipePath)
+		return
+	}
+	go func() {
+		writer := consolelog.NewPipeWriter(pipePath)
+		defer writer.Close()
+		err := consolelog.NewTailer(consolelog.LogFilePath(0), writer, time.Second).Follow(stopChan)
+		if err != nil {
+			log.Log.Reason(err).Error("Failed to forward the serial console log.")
+		}
+	}()
+}
+
 func startWatchdogTicker(watchdogFile string, watchdogInterval time.Duration, stopChan chan struct{}, uid string) (done chan struct{}) {
 	err := watchdog.WatchdogFileUpdate(watchdogFile, uid)
 	if err != nil {
@@ -363,6 +382,11 @@ func main() {
 	}
 	util.StartVirtlog(stopChan)
 
+	// The console log volume is only mounted if the VMI requested it
+	if _, err := os.Stat(consolelog.LogDir); err == nil {
+		startConsoleLogForwarding(stopChan)
+	}
+
 	domainConn := createLibvirtConnection()
 	defer domainConn.Close()
 
@@ -0,0 +1,66 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2020 Red Hat, Inc.
+ *
+ */
+
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	flag "github.com/spf13/pflag"
+
+	"kubevirt.io/client-go/log"
+	memorydump "kubevirt.io/kubevirt/pkg/memory-dump"
+)
+
+const pollInterval = time.Second
+
+// virt-memory-dump copies a memory dump, once libvirt finished it, from the
+// emptyDir of virt-launcher into the mounted PVC. It keeps running afterwards, so
+// that the pod turns ready and the dump can be downloaded from it. A failure is
+// reported as the termination message of the container.
+func main() {
+	sourceDir := flag.String("source-dir", memorydump.LauncherDir, "Directory libvirt writes the memory dump to")
+	targetDir := flag.String("target-dir", memorydump.ClaimDir, "Directory the memory dump is copied to")
+	fileName := flag.String("file-name", "", "Name of the memory dump file")
+	timeout := flag.Duration("timeout", time.Hour, "Amount of time to wait for the memory dump to finish")
+	terminationLog := flag.String("termination-log", "/dev/termination-log", "File the reason of a failure is written to")
+	flag.Parse()
+
+	logger := log.DefaultLogger()
+
+	if *fileName == "" {
+		logger.Error("file-name is required.")
+		os.Exit(1)
+	}
+
+	if err := memorydump.WaitAndCopy(*sourceDir, *targetDir, *fileName, *timeout, pollInterval); err != nil {
+		logger.Reason(err).Errorf("Failed to copy the memory dump %s.", *fileName)
+		ioutil.WriteFile(*terminationLog, []byte(err.Error()), 0644)
+		os.Exit(1)
+	}
+	logger.Infof("Copied the memory dump %s.", *fileName)
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGTERM, syscall.SIGINT)
+	<-signals
+}
@@ -0,0 +1,106 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2019 Red Hat, Inc.
+ *
+ */
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	flag "github.com/spf13/pflag"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	cmdclient "kubevirt.io/kubevirt/pkg/virt-handler/cmd-client"
+)
+
+// virt-probe runs a guest agent based liveness or readiness probe of a
+// VirtualMachineInstance. It is executed by the kubelet in the compute
+// container and asks virt-launcher to either ping the guest agent or to run
+// the command given as arguments in the guest. It exits with status 0 if
+// the probe succeeded.
+func main() {
+	virtShareDir := flag.String("kubevirt-share-dir", "/var/run/kubevirt", "Shared directory between virt-handler and virt-launcher")
+	uid := flag.String("uid", "", "UID of the VirtualMachineInstance")
+	namespace := flag.String("namespace", "", "Namespace of the VirtualMachineInstance")
+	name := flag.String("name", "", "Name of the VirtualMachineInstance")
+	timeoutSeconds := flag.Int32("timeout-seconds", 1, "Number of seconds after which the probe fails")
+	guestAgentPing := flag.Bool("guest-agent-ping", false, "Ping the guest agent instead of running a command")
+	flag.Parse()
+
+	if *uid == "" || *namespace == "" || *name == "" {
+		fmt.Fprintln(os.Stderr, "uid, namespace and name are required.")
+		os.Exit(1)
+	}
+	command := flag.Args()
+	if !*guestAgentPing && len(command) == 0 {
+		fmt.Fprintln(os.Stderr, "either guest-agent-ping or a command is required.")
+		os.Exit(1)
+	}
+	if *timeoutSeconds < 1 {
+		*timeoutSeconds = 1
+	}
+
+	client, err := cmdclient.NewClient(cmdclient.SocketFromUID(*virtShareDir, *uid))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to connect to virt-launcher: %v\n", err)
+		os.Exit(1)
+	}
+	defer client.Close()
+
+	vmi := &v1.VirtualMachineInstance{
+		ObjectMeta: metav1.ObjectMeta{Name: *name, Namespace: *namespace},
+	}
+	if err := probe(client, vmi, *guestAgentPing, command, *timeoutSeconds); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		client.Close()
+		os.Exit(1)
+	}
+}
+
+func probe(client cmdclient.LauncherClient, vmi *v1.VirtualMachineInstance, guestAgentPing bool, command []string, timeoutSeconds int32) error {
+	timeout := time.Duration(timeoutSeconds) * time.Second
+	if guestAgentPing {
+		if err := client.GuestPing(vmi, timeout); err != nil {
+			return fmt.Errorf("guest agent ping failed: %v", err)
+		}
+		return nil
+	}
+
+	// The kubelet starts the next probe after timeoutSeconds, so the call
+	// must not take longer. The launcher kills the command when it times out.
+	result, err := client.GuestExec(vmi, &v1.VirtualMachineInstanceGuestExecRequest{
+		Command:        command[0],
+		Args:           command[1:],
+		TimeoutSeconds: timeoutSeconds,
+	}, timeout)
+	if err != nil {
+		return fmt.Errorf("failed to run %q in the guest: %v", command[0], err)
+	}
+	fmt.Print(result.Stdout)
+	fmt.Fprint(os.Stderr, result.Stderr)
+	if result.Signaled {
+		return fmt.Errorf("%q was terminated by signal %d", command[0], result.ExitCode)
+	}
+	if result.ExitCode != 0 {
+		return fmt.Errorf("%q exited with status %d", command[0], result.ExitCode)
+	}
+	return nil
+}
@@ -0,0 +1,71 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2019 Red Hat, Inc.
+ *
+ */
+
+package main
+
+import (
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	flag "github.com/spf13/pflag"
+
+	"kubevirt.io/client-go/log"
+	consolelog "kubevirt.io/kubevirt/pkg/console-log"
+	cmdclient "kubevirt.io/kubevirt/pkg/virt-handler/cmd-client"
+)
+
+const pollInterval = 500 * time.Millisecond
+
+// virt-tail prints the serial console log of a VirtualMachineInstance, which
+// virt-launcher writes to a named pipe, to stdout. It terminates once
+// virt-handler cleaned up the virt-launcher command socket, which happens
+// after the domain is gone.
+func main() {
+	pipe := flag.String("pipe", "", "Named pipe virt-launcher writes the serial console log to")
+	virtShareDir := flag.String("kubevirt-share-dir", "/var/run/kubevirt", "Shared directory between virt-handler and virt-launcher")
+	uid := flag.String("uid", "", "UID of the VirtualMachineInstance")
+	socketTimeout := flag.Duration("socket-timeout", 5*time.Minute, "Amount of time to wait for virt-launcher to come up")
+	flag.Parse()
+
+	logger := log.DefaultLogger()
+
+	if *pipe == "" || *uid == "" {
+		logger.Error("pipe and uid are required.")
+		os.Exit(1)
+	}
+
+	stop := make(chan struct{})
+	socketRemoved := consolelog.WaitForSocketRemoval(cmdclient.SocketFromUID(*virtShareDir, *uid), *socketTimeout, pollInterval)
+	go func() {
+		signals := make(chan os.Signal, 1)
+		signal.Notify(signals, syscall.SIGTERM, syscall.SIGINT)
+		select {
+		case <-socketRemoved:
+		case <-signals:
+		}
+		close(stop)
+	}()
+
+	if err := consolelog.CopyFromPipe(*pipe, os.Stdout, pollInterval, stop); err != nil {
+		logger.Reason(err).Errorf("Failed to read the serial console log from %s.", *pipe)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,216 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2020 Red Hat, Inc.
+ *
+ */
+
+// Package bootstrap loads the certificates which virt-operator issues for
+// the KubeVirt components from their mounted secrets and keeps them up to
+// date when virt-operator rotates them.
+package bootstrap
+
+import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	k8sv1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/util/cert"
+
+	"kubevirt.io/client-go/log"
+)
+
+const (
+	// CABundleKey is the secret key which holds all CA certificates
+	// that peers of a component should currently trust
+	CABundleKey = "ca-bundle"
+
+	// directories the certificate secrets are mounted to
+	VirtApiCertDir           = "/etc/virt-api/certificates"
+	VirtControllerCertDir    = "/etc/virt-controller/certificates"
+	VirtHandlerCertDir       = "/etc/virt-handler/clientcertificates"
+	VirtHandlerServerCertDir = "/etc/virt-handler/servercertificates"
+
+	// defaultResyncInterval is the interval at which the certificates are
+	// reloaded even if no file change was observed
+	defaultResyncInterval = 1 * time.Minute
+)
+
+type CertificateManager interface {
+	Start()
+	Stop()
+	// Current returns the most recently loaded key pair
+	Current() *tls.Certificate
+	// CABundle returns the most recently loaded PEM encoded CA bundle
+	CABundle() []byte
+	// CAPool returns the most recently loaded CA bundle as a pool
+	CAPool() *x509.CertPool
+}
+
+// FileCertificateManager serves the key pair and CA bundle of a certificate
+// secret mounted into the pod. The kubelet updates the mounted files when
+// the secret changes, which is picked up without restarting the component.
+type FileCertificateManager struct {
+	stopCh         chan struct{}
+	stopOnce       sync.Once
+	certAccessLock sync.Mutex
+	cert           *tls.Certificate
+	certBytes      []byte
+	keyBytes       []byte
+	caBundle       []byte
+	caPool         *x509.CertPool
+	certDir        string
+	resyncInterval time.Duration
+}
+
+func NewFileCertificateManager(certDir string) *FileCertificateManager {
+	return &FileCertificateManager{
+		stopCh:         make(chan struct{}),
+		certDir:        certDir,
+		resyncInterval: defaultResyncInterval,
+	}
+}
+
+// Start loads the certificates and watches the certificate directory for
+// updates. The initial load is synchronous, so that Current returns the
+// mounted key pair as soon as Start returns.
+func (f *FileCertificateManager) Start() {
+	if err := f.rotateCerts(); err != nil {
+		log.Log.Reason(err).Errorf("failed to load the certificates from %s", f.certDir)
+	}
+	go f.watch()
+}
+
+func (f *FileCertificateManager) Stop() {
+	f.stopOnce.Do(func() {
+		close(f.stopCh)
+	})
+}
+
+func (f *FileCertificateManager) Current() *tls.Certificate {
+	f.certAccessLock.Lock()
+	defer f.certAccessLock.Unlock()
+	return f.cert
+}
+
+func (f *FileCertificateManager) CABundle() []byte {
+	f.certAccessLock.Lock()
+	defer f.certAccessLock.Unlock()
+	return f.caBundle
+}
+
+func (f *FileCertificateManager) CAPool() *x509.CertPool {
+	f.certAccessLock.Lock()
+	defer f.certAccessLock.Unlock()
+	return f.caPool
+}
+
+func (f *FileCertificateManager) watch() {
+	ticker := time.NewTicker(f.resyncInterval)
+	defer ticker.Stop()
+
+	// the kubelet replaces the whole directory content through a symlink
+	// swap, watching the directory is enough to notice secret updates
+	var events chan fsnotify.Event
+	var errors chan error
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		log.Log.Reason(err).Error("failed to create a fsnotify watcher, falling back to polling")
+	} else {
+		defer watcher.Close()
+		if err := watcher.Add(f.certDir); err != nil {
+			log.Log.Reason(err).Errorf("failed to watch %s, falling back to polling", f.certDir)
+		} else {
+			events = watcher.Events
+			errors = watcher.Errors
+		}
+	}
+
+	for {
+		select {
+		case <-f.stopCh:
+			return
+		case <-events:
+		case err := <-errors:
+			log.Log.Reason(err).Errorf("error while watching %s", f.certDir)
+			continue
+		case <-ticker.C:
+		}
+		if err := f.rotateCerts(); err != nil {
+			log.Log.Reason(err).Errorf("failed to reload the certificates from %s", f.certDir)
+		}
+	}
+}
+
+func (f *FileCertificateManager) rotateCerts() error {
+	certBytes, err := ioutil.ReadFile(filepath.Join(f.certDir, k8sv1.TLSCertKey))
+	if err != nil {
+		return err
+	}
+	keyBytes, err := ioutil.ReadFile(filepath.Join(f.certDir, k8sv1.TLSPrivateKeyKey))
+	if err != nil {
+		return err
+	}
+	caBundle, err := ioutil.ReadFile(filepath.Join(f.certDir, CABundleKey))
+	if err != nil {
+		return err
+	}
+
+	f.certAccessLock.Lock()
+	unchanged := bytes.Equal(f.certBytes, certBytes) && bytes.Equal(f.keyBytes, keyBytes) && bytes.Equal(f.caBundle, caBundle)
+	f.certAccessLock.Unlock()
+	if unchanged {
+		return nil
+	}
+
+	crt, err := tls.X509KeyPair(certBytes, keyBytes)
+	if err != nil {
+		return fmt.Errorf("failed to load the key pair: %v", err)
+	}
+	caPool, err := NewCAPool(caBundle)
+	if err != nil {
+		return err
+	}
+
+	f.certAccessLock.Lock()
+	defer f.certAccessLock.Unlock()
+	f.cert = &crt
+	f.certBytes = certBytes
+	f.keyBytes = keyBytes
+	f.caBundle = caBundle
+	f.caPool = caPool
+	log.Log.Infof("loaded the certificates from %s", f.certDir)
+	return nil
+}
+
+// NewCAPool parses a PEM encoded CA bundle
+func NewCAPool(caBundle []byte) (*x509.CertPool, error) {
+	caCerts, err := cert.ParseCertsPEM(caBundle)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the CA bundle: %v", err)
+	}
+	pool := x509.NewCertPool()
+	for _, crt := range caCerts {
+		pool.AddCert(crt)
+	}
+	return pool, nil
+}
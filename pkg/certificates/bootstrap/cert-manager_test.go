@@ -0,0 +1,87 @@
+package bootstrap
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	k8sv1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/util/cert"
+
+	"kubevirt.io/kubevirt/pkg/certificates/triple"
+)
+
+var _ = Describe("FileCertificateManager", func() {
+
+	var certDir string
+	var certManager *FileCertificateManager
+
+	writeCertificates := func(commonName string) {
+		ca, err := triple.NewCA("kubevirt.io", time.Hour)
+		Expect(err).ToNot(HaveOccurred())
+		keyPair, err := triple.NewServerKeyPair(ca, commonName, "test", "test", "cluster.local", nil, nil, time.Hour)
+		Expect(err).ToNot(HaveOccurred())
+
+		files := map[string][]byte{
+			k8sv1.TLSCertKey:       cert.EncodeCertPEM(keyPair.Cert),
+			k8sv1.TLSPrivateKeyKey: cert.EncodePrivateKeyPEM(keyPair.Key),
+			CABundleKey:            cert.EncodeCertPEM(ca.Cert),
+		}
+		for name, content := range files {
+			Expect(ioutil.WriteFile(filepath.Join(certDir, name), content, 0600)).To(Succeed())
+		}
+	}
+
+	currentCommonName := func() string {
+		crt := certManager.Current()
+		if crt == nil {
+			return ""
+		}
+		leaf, err := x509.ParseCertificate(crt.Certificate[0])
+		Expect(err).ToNot(HaveOccurred())
+		return leaf.Subject.CommonName
+	}
+
+	BeforeEach(func() {
+		var err error
+		certDir, err = ioutil.TempDir("", "certsdir")
+		Expect(err).ToNot(HaveOccurred())
+		certManager = NewFileCertificateManager(certDir)
+		certManager.resyncInterval = 100 * time.Millisecond
+	})
+
+	It("should load the mounted certificates on start", func() {
+		writeCertificates("first")
+		certManager.Start()
+		Expect(certManager.Current()).ToNot(BeNil())
+		Expect(certManager.CABundle()).ToNot(BeEmpty())
+		Expect(certManager.CAPool()).ToNot(BeNil())
+		Expect(currentCommonName()).To(Equal("first"))
+	})
+
+	It("should pick up certificates which appear after start", func() {
+		certManager.Start()
+		Expect(certManager.Current()).To(BeNil())
+		writeCertificates("first")
+		Eventually(func() *tls.Certificate { return certManager.Current() }, 5*time.Second).ShouldNot(BeNil())
+	})
+
+	It("should reload rotated certificates", func() {
+		writeCertificates("first")
+		certManager.Start()
+		Expect(currentCommonName()).To(Equal("first"))
+
+		writeCertificates("second")
+		Eventually(currentCommonName, 5*time.Second).Should(Equal("second"))
+	})
+
+	AfterEach(func() {
+		certManager.Stop()
+		os.RemoveAll(certDir)
+	})
+})
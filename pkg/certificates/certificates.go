@@ -1,14 +1,18 @@
 package certificates
 
 import (
+	"time"
+
 	"k8s.io/client-go/util/cert"
 	"k8s.io/client-go/util/certificate"
 
 	"kubevirt.io/kubevirt/pkg/certificates/triple"
 )
 
+const selfSignedCertDuration = 365 * 24 * time.Hour
+
 func GenerateSelfSignedCert(certsDirectory string, name string, namespace string) (certificate.FileStore, error) {
-	caKeyPair, _ := triple.NewCA("kubevirt.io")
+	caKeyPair, _ := triple.NewCA("kubevirt.io", selfSignedCertDuration)
 	keyPair, _ := triple.NewServerKeyPair(
 		caKeyPair,
 		name+"."+namespace+".pod.cluster.local",
@@ -17,6 +21,7 @@ func GenerateSelfSignedCert(certsDirectory string, name string, namespace string
 		"cluster.local",
 		nil,
 		nil,
+		selfSignedCertDuration,
 	)
 
 	store, err := certificate.NewFileStore(name, certsDirectory, certsDirectory, "", "")
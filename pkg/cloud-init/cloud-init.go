@@ -21,6 +21,7 @@ package cloudinit
 
 import (
 	"encoding/base64"
+	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"os"
@@ -34,6 +35,7 @@ import (
 	"kubevirt.io/client-go/kubecli"
 	"kubevirt.io/client-go/log"
 	"kubevirt.io/client-go/precond"
+	"kubevirt.io/kubevirt/pkg/config"
 	diskutils "kubevirt.io/kubevirt/pkg/ephemeral-disk-utils"
 	"kubevirt.io/kubevirt/pkg/util/net/dns"
 )
@@ -73,6 +75,8 @@ func IsValidCloudInitData(cloudInitData *CloudInitData) bool {
 // ReadCloudInitVolumeDataSource scans the given VMI for CloudInit volumes and
 // reads their content into a CloudInitData struct. Does not resolve secret refs.
 // To ensure that secrets are read correctly, call InjectCloudInitSecrets beforehand.
+// SSH public keys of access credentials with the cloudInit propagation method
+// are read from their Secrets mounted on the pod and added to the metadata.
 func ReadCloudInitVolumeDataSource(vmi *v1.VirtualMachineInstance) (cloudInitData *CloudInitData, err error) {
 	precond.MustNotBeNil(vmi)
 	hostname := dns.SanitizeHostname(vmi)
@@ -80,18 +84,49 @@ func ReadCloudInitVolumeDataSource(vmi *v1.VirtualMachineInstance) (cloudInitDat
 	for _, volume := range vmi.Spec.Volumes {
 		if volume.CloudInitNoCloud != nil {
 			cloudInitData, err = readCloudInitNoCloudSource(volume.CloudInitNoCloud)
-			cloudInitData.MetaData = readCloudInitNoCloudMetaData(vmi.Name, hostname, vmi.Namespace)
+			if err != nil {
+				return cloudInitData, err
+			}
+			keys, err := readSSHPublicKeys(vmi)
+			if err != nil {
+				return cloudInitData, err
+			}
+			cloudInitData.MetaData, err = readCloudInitNoCloudMetaData(vmi.Name, hostname, vmi.Namespace, keys)
 			return cloudInitData, err
 		}
 		if volume.CloudInitConfigDrive != nil {
 			cloudInitData, err = readCloudInitConfigDriveSource(volume.CloudInitConfigDrive)
-			cloudInitData.MetaData = readCloudInitConfigDriveMetaData(string(vmi.UID), vmi.Name, hostname, vmi.Namespace)
+			if err != nil {
+				return cloudInitData, err
+			}
+			keys, err := readSSHPublicKeys(vmi)
+			if err != nil {
+				return cloudInitData, err
+			}
+			cloudInitData.MetaData, err = readCloudInitConfigDriveMetaData(string(vmi.UID), vmi.Name, hostname, vmi.Namespace, keys)
 			return cloudInitData, err
 		}
 	}
 	return nil, nil
 }
 
+// readSSHPublicKeys returns the ssh public keys of all access credentials
+// which are propagated through cloud-init
+func readSSHPublicKeys(vmi *v1.VirtualMachineInstance) ([]string, error) {
+	keys := []string{}
+	for i, credential := range vmi.Spec.AccessCredentials {
+		if credential.SSHPublicKey == nil || credential.SSHPublicKey.PropagationMethod.CloudInit == nil {
+			continue
+		}
+		secretKeys, err := config.ReadSSHPublicKeys(config.GetAccessCredentialSecretName(&vmi.Spec.AccessCredentials[i]))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read ssh public keys: %v", err)
+		}
+		keys = append(keys, secretKeys...)
+	}
+	return keys, nil
+}
+
 func readRawOrBase64Data(rawData, base64Data string) (string, error) {
 	if rawData != "" {
 		return rawData, nil
@@ -149,12 +184,50 @@ func readCloudInitConfigDriveSource(source *v1.CloudInitConfigDriveSource) (*Clo
 	}, nil
 }
 
-func readCloudInitNoCloudMetaData(name, hostname, namespace string) string {
-	return fmt.Sprintf("{ \"instance-id\": \"%s.%s\", \"local-hostname\": \"%s\" }\n", name, namespace, hostname)
+type noCloudMetadata struct {
+	InstanceID    string   `json:"instance-id"`
+	LocalHostname string   `json:"local-hostname"`
+	PublicSSHKeys []string `json:"public-keys,omitempty"`
+}
+
+type configDriveMetadata struct {
+	UUID          string            `json:"uuid"`
+	InstanceID    string            `json:"instance-id"`
+	Hostname      string            `json:"hostname"`
+	PublicSSHKeys map[string]string `json:"public_keys,omitempty"`
 }
 
-func readCloudInitConfigDriveMetaData(uid, name, hostname, namespace string) string {
-	return fmt.Sprintf("{ \"uuid\": \"%s\", \"instance-id\": \"%s.%s\", \"hostname\": \"%s\" }\n", uid, name, namespace, hostname)
+func readCloudInitNoCloudMetaData(name, hostname, namespace string, keys []string) (string, error) {
+	metadata := noCloudMetadata{
+		InstanceID:    fmt.Sprintf("%s.%s", name, namespace),
+		LocalHostname: hostname,
+		PublicSSHKeys: keys,
+	}
+	data, err := json.Marshal(metadata)
+	if err != nil {
+		return "", err
+	}
+	return string(data) + "\n", nil
+}
+
+func readCloudInitConfigDriveMetaData(uid, name, hostname, namespace string, keys []string) (string, error) {
+	metadata := configDriveMetadata{
+		UUID:       uid,
+		InstanceID: fmt.Sprintf("%s.%s", name, namespace),
+		Hostname:   hostname,
+	}
+	// OpenStack names the public keys of an instance
+	if len(keys) > 0 {
+		metadata.PublicSSHKeys = make(map[string]string, len(keys))
+		for i, key := range keys {
+			metadata.PublicSSHKeys[fmt.Sprintf("key-%d", i)] = key
+		}
+	}
+	data, err := json.Marshal(metadata)
+	if err != nil {
+		return "", err
+	}
+	return string(data) + "\n", nil
 }
 
 func defaultIsoFunc(isoOutFile, volumeID string, inDir string) error {
@@ -25,6 +25,7 @@ import (
 	"io/ioutil"
 	"os"
 	"os/exec"
+	"path/filepath"
 	"time"
 
 	"github.com/golang/mock/gomock"
@@ -38,6 +39,7 @@ import (
 	v1 "kubevirt.io/client-go/api/v1"
 	"kubevirt.io/client-go/kubecli"
 	"kubevirt.io/client-go/precond"
+	"kubevirt.io/kubevirt/pkg/config"
 )
 
 var _ = Describe("CloudInit", func() {
@@ -598,5 +600,93 @@ var _ = Describe("CloudInit", func() {
 				})
 			})
 		})
+
+		Describe("A VirtualMachineInstance with access credentials", func() {
+			var vmi *v1.VirtualMachineInstance
+			var origAccessCredentialSourceDir string
+
+			BeforeEach(func() {
+				origAccessCredentialSourceDir = config.AccessCredentialSourceDir
+				var err error
+				config.AccessCredentialSourceDir, err = ioutil.TempDir("", "access-cred")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(os.MkdirAll(filepath.Join(config.AccessCredentialSourceDir, "my-keys"), 0755)).To(Succeed())
+				err = ioutil.WriteFile(filepath.Join(config.AccessCredentialSourceDir, "my-keys", "authorized_keys"), []byte("ssh-rsa AAAA1\nssh-rsa AAAA2\n"), 0644)
+				Expect(err).ToNot(HaveOccurred())
+
+				vmi = v1.NewMinimalVMI("testvmi")
+				vmi.Namespace = "default"
+				vmi.UID = "1234"
+				vmi.Spec.AccessCredentials = []v1.AccessCredential{
+					{
+						SSHPublicKey: &v1.SSHPublicKeyAccessCredential{
+							Source: v1.AccessCredentialSource{
+								Secret: &v1.AccessCredentialSecretSource{SecretName: "my-keys"},
+							},
+							PropagationMethod: v1.SSHPublicKeyAccessCredentialPropagationMethod{
+								CloudInit: &v1.CloudInitSSHPublicKeyAccessCredentialPropagation{},
+							},
+						},
+					},
+					{
+						SSHPublicKey: &v1.SSHPublicKeyAccessCredential{
+							Source: v1.AccessCredentialSource{
+								Secret: &v1.AccessCredentialSecretSource{SecretName: "agent-keys"},
+							},
+							PropagationMethod: v1.SSHPublicKeyAccessCredentialPropagationMethod{
+								QemuGuestAgent: &v1.QemuGuestAgentSSHPublicKeyAccessCredentialPropagation{Users: []string{"fedora"}},
+							},
+						},
+					},
+				}
+			})
+
+			AfterEach(func() {
+				os.RemoveAll(config.AccessCredentialSourceDir)
+				config.AccessCredentialSourceDir = origAccessCredentialSourceDir
+			})
+
+			It("should add the public keys to the NoCloud metadata", func() {
+				vmi.Spec.Volumes = []v1.Volume{
+					{
+						Name: "cloudinit",
+						VolumeSource: v1.VolumeSource{
+							CloudInitNoCloud: &v1.CloudInitNoCloudSource{UserData: "fake"},
+						},
+					},
+				}
+				cloudInitData, err := ReadCloudInitVolumeDataSource(vmi)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(cloudInitData.MetaData).To(MatchJSON(`{"instance-id": "testvmi.default", "local-hostname": "testvmi", "public-keys": ["ssh-rsa AAAA1", "ssh-rsa AAAA2"]}`))
+			})
+
+			It("should add the public keys to the ConfigDrive metadata", func() {
+				vmi.Spec.Volumes = []v1.Volume{
+					{
+						Name: "cloudinit",
+						VolumeSource: v1.VolumeSource{
+							CloudInitConfigDrive: &v1.CloudInitConfigDriveSource{UserData: "fake"},
+						},
+					},
+				}
+				cloudInitData, err := ReadCloudInitVolumeDataSource(vmi)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(cloudInitData.MetaData).To(MatchJSON(`{"uuid": "1234", "instance-id": "testvmi.default", "hostname": "testvmi", "public_keys": {"key-0": "ssh-rsa AAAA1", "key-1": "ssh-rsa AAAA2"}}`))
+			})
+
+			It("should fail if the secret is not mounted", func() {
+				vmi.Spec.AccessCredentials[0].SSHPublicKey.Source.Secret.SecretName = "other-keys"
+				vmi.Spec.Volumes = []v1.Volume{
+					{
+						Name: "cloudinit",
+						VolumeSource: v1.VolumeSource{
+							CloudInitNoCloud: &v1.CloudInitNoCloudSource{UserData: "fake"},
+						},
+					},
+				}
+				_, err := ReadCloudInitVolumeDataSource(vmi)
+				Expect(err).To(HaveOccurred())
+			})
+		})
 	})
 })
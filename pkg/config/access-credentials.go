@@ -0,0 +1,101 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2019 Red Hat, Inc.
+ *
+ */
+
+package config
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	v1 "kubevirt.io/client-go/api/v1"
+)
+
+// GetAccessCredentialSourcePath returns a path to an access credential Secret mounted on a pod
+func GetAccessCredentialSourcePath(secretName string) string {
+	return filepath.Join(AccessCredentialSourceDir, secretName)
+}
+
+// GetAccessCredentialSecretName returns the name of the Secret an access credential is read from
+func GetAccessCredentialSecretName(credential *v1.AccessCredential) string {
+	var source *v1.AccessCredentialSource
+	if credential.SSHPublicKey != nil {
+		source = &credential.SSHPublicKey.Source
+	} else if credential.UserPassword != nil {
+		source = &credential.UserPassword.Source
+	}
+	if source == nil || source.Secret == nil {
+		return ""
+	}
+	return source.Secret.SecretName
+}
+
+// ReadAccessCredentialSecret returns the data of an access credential Secret
+// mounted on a pod, by key. The current data is returned even while the
+// kubelet updates the mount after the Secret changed.
+func ReadAccessCredentialSecret(secretName string) (map[string]string, error) {
+	dir := GetAccessCredentialSourcePath(secretName)
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	data := make(map[string]string, len(files))
+	for _, file := range files {
+		// The kubelet keeps the Secret data in hidden directories and swaps them atomically
+		if strings.HasPrefix(file.Name(), "..") {
+			continue
+		}
+		content, err := ioutil.ReadFile(filepath.Join(dir, file.Name()))
+		if err != nil {
+			return nil, err
+		}
+		data[file.Name()] = string(content)
+	}
+	return data, nil
+}
+
+// ReadSSHPublicKeys returns the ssh public keys of an access credential
+// Secret mounted on a pod. Every value of the Secret may hold several keys
+// in the authorized_keys format.
+func ReadSSHPublicKeys(secretName string) ([]string, error) {
+	data, err := ReadAccessCredentialSecret(secretName)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(data))
+	for name := range data {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	keys := []string{}
+	for _, name := range names {
+		for _, line := range strings.Split(data[name], "\n") {
+			line = strings.TrimSpace(line)
+			if line == "" || strings.HasPrefix(line, "#") {
+				continue
+			}
+			keys = append(keys, line)
+		}
+	}
+	return keys, nil
+}
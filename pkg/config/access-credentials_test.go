@@ -0,0 +1,86 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2019 Red Hat, Inc.
+ *
+ */
+
+package config
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	v1 "kubevirt.io/client-go/api/v1"
+)
+
+var _ = Describe("Access credentials", func() {
+
+	var secretDir string
+
+	BeforeEach(func() {
+		var err error
+
+		AccessCredentialSourceDir, err = ioutil.TempDir("", "access-cred")
+		Expect(err).NotTo(HaveOccurred())
+
+		// Lay out the Secret like the kubelet does
+		secretDir = filepath.Join(AccessCredentialSourceDir, "my-keys")
+		dataDir := filepath.Join(secretDir, "..2019_10_01_12_00_00.123")
+		Expect(os.MkdirAll(dataDir, 0755)).To(Succeed())
+		Expect(ioutil.WriteFile(filepath.Join(dataDir, "key1"), []byte("ssh-rsa AAAA1 user@host\n\n# comment\nssh-ed25519 AAAA2\n"), 0644)).To(Succeed())
+		Expect(ioutil.WriteFile(filepath.Join(dataDir, "key2"), []byte("ssh-rsa AAAA3"), 0644)).To(Succeed())
+		Expect(os.Symlink("..2019_10_01_12_00_00.123", filepath.Join(secretDir, "..data"))).To(Succeed())
+		Expect(os.Symlink("..data/key1", filepath.Join(secretDir, "key1"))).To(Succeed())
+		Expect(os.Symlink("..data/key2", filepath.Join(secretDir, "key2"))).To(Succeed())
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(AccessCredentialSourceDir)
+	})
+
+	It("should read the data of a mounted secret", func() {
+		data, err := ReadAccessCredentialSecret("my-keys")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(data).To(HaveLen(2))
+		Expect(data).To(HaveKeyWithValue("key2", "ssh-rsa AAAA3"))
+	})
+
+	It("should read all public keys of a mounted secret", func() {
+		keys, err := ReadSSHPublicKeys("my-keys")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(keys).To(Equal([]string{"ssh-rsa AAAA1 user@host", "ssh-ed25519 AAAA2", "ssh-rsa AAAA3"}))
+	})
+
+	It("should fail if the secret is not mounted", func() {
+		_, err := ReadSSHPublicKeys("other-keys")
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should return the secret name of a credential", func() {
+		credential := &v1.AccessCredential{
+			UserPassword: &v1.UserPasswordAccessCredential{
+				Source: v1.AccessCredentialSource{
+					Secret: &v1.AccessCredentialSecretSource{SecretName: "my-passwords"},
+				},
+			},
+		}
+		Expect(GetAccessCredentialSecretName(credential)).To(Equal("my-passwords"))
+	})
+})
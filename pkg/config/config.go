@@ -51,6 +51,8 @@ var (
 	ConfigMapSourceDir = mountBaseDir + "/config-map"
 	// SecretSourceDir represents a location where Secrets is attached to the pod
 	SecretSourceDir = mountBaseDir + "/secret"
+	// AccessCredentialSourceDir represents a location where access credential Secrets are attached to the pod
+	AccessCredentialSourceDir = mountBaseDir + "/access-cred"
 	// ServiceAccountSourceDir represents the location where the ServiceAccount token is attached to the pod
 	ServiceAccountSourceDir = "/var/run/secrets/kubernetes.io/serviceaccount/"
 
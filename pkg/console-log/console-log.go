@@ -0,0 +1,114 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2019 Red Hat, Inc.
+ *
+ */
+
+package consolelog
+
+import (
+	"fmt"
+	"path/filepath"
+
+	kubev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	v1 "kubevirt.io/client-go/api/v1"
+)
+
+const (
+	// ContainerName is the name of the virt-launcher pod container which prints the serial console log
+	ContainerName = "guest-console-log"
+	// VolumeName is the name of the pod volume shared between the compute and the guest-console-log container
+	VolumeName = "guest-console-log"
+	// LogDir is the mount path of the shared pod volume in both containers
+	LogDir = "/var/run/kubevirt-console-log"
+
+	// nonRootUserID is the qemu user of the virt-launcher image, the
+	// guest-console-log container runs with it
+	nonRootUserID = 107
+)
+
+// IsEnabled returns true if the serial console output of the vmi is logged.
+// Logging has to be requested explicitly, since the log may contain sensitive guest output.
+func IsEnabled(vmi *v1.VirtualMachineInstance) bool {
+	logSerialConsole := vmi.Spec.Domain.Devices.LogSerialConsole
+	return logSerialConsole != nil && *logSerialConsole
+}
+
+// LogFilePath returns the path of the log file for the given serial port.
+// virtlogd keeps rotated copies next to it, with ".0", ".1", ... appended.
+func LogFilePath(serialPort uint) string {
+	return filepath.Join(LogDir, fmt.Sprintf("virt-serial%d-log", serialPort))
+}
+
+// PipePath returns the path of the pipe through which virt-launcher passes the
+// log of the first serial port to the guest-console-log container. The log files
+// of virtlogd are only readable by root.
+func PipePath() string {
+	return filepath.Join(LogDir, "virt-serial0-pipe")
+}
+
+// The controller uses this function to generate the container which reads the
+// serial console log from the pipe, so that it shows up in the pod logs.
+func GenerateContainer(vmi *v1.VirtualMachineInstance, image string, pullPolicy kubev1.PullPolicy, virtShareDir string, virtShareVolumeName string) kubev1.Container {
+	var userId int64 = nonRootUserID
+	nonRoot := true
+
+	resources := kubev1.ResourceRequirements{
+		Limits: kubev1.ResourceList{
+			kubev1.ResourceCPU:    resource.MustParse("15m"),
+			kubev1.ResourceMemory: resource.MustParse("60M"),
+		},
+	}
+	if vmi.IsCPUDedicated() || vmi.WantsToHaveQOSGuaranteed() {
+		resources.Requests = resources.Limits
+	} else {
+		resources.Requests = kubev1.ResourceList{
+			kubev1.ResourceCPU:    resource.MustParse("5m"),
+			kubev1.ResourceMemory: resource.MustParse("35M"),
+		}
+	}
+
+	return kubev1.Container{
+		Name:            ContainerName,
+		Image:           image,
+		ImagePullPolicy: pullPolicy,
+		SecurityContext: &kubev1.SecurityContext{
+			RunAsUser:    &userId,
+			RunAsNonRoot: &nonRoot,
+		},
+		Command: []string{"/usr/bin/virt-tail"},
+		Args: []string{
+			"--pipe", PipePath(),
+			"--kubevirt-share-dir", virtShareDir,
+			"--uid", string(vmi.UID),
+		},
+		Resources: resources,
+		VolumeMounts: []kubev1.VolumeMount{
+			{
+				Name:      VolumeName,
+				MountPath: LogDir,
+				ReadOnly:  true,
+			},
+			{
+				Name:      virtShareVolumeName,
+				MountPath: virtShareDir,
+				ReadOnly:  true,
+			},
+		},
+	}
+}
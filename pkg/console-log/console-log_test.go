@@ -0,0 +1,73 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2019 Red Hat, Inc.
+ *
+ */
+
+package consolelog
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	kubev1 "k8s.io/api/core/v1"
+
+	v1 "kubevirt.io/client-go/api/v1"
+)
+
+var _ = Describe("ConsoleLog", func() {
+
+	It("should be disabled by default", func() {
+		vmi := v1.NewMinimalVMI("testvmi")
+		Expect(IsEnabled(vmi)).To(BeFalse())
+	})
+
+	It("should be possible to enable it", func() {
+		vmi := v1.NewMinimalVMI("testvmi")
+		enabled := true
+		vmi.Spec.Domain.Devices.LogSerialConsole = &enabled
+		Expect(IsEnabled(vmi)).To(BeTrue())
+	})
+
+	It("should generate a non-root container which reads the log of the first serial port", func() {
+		vmi := v1.NewMinimalVMI("testvmi")
+		vmi.UID = "1234"
+
+		container := GenerateContainer(vmi, "virt-launcher", kubev1.PullIfNotPresent, "/var/run/kubevirt", "virt-share-dir")
+
+		Expect(container.Name).To(Equal("guest-console-log"))
+		Expect(container.Image).To(Equal("virt-launcher"))
+		Expect(container.Command).To(Equal([]string{"/usr/bin/virt-tail"}))
+		Expect(container.Args).To(Equal([]string{
+			"--pipe", "/var/run/kubevirt-console-log/virt-serial0-pipe",
+			"--kubevirt-share-dir", "/var/run/kubevirt",
+			"--uid", "1234",
+		}))
+		Expect(*container.SecurityContext.RunAsUser).To(Equal(int64(107)))
+		Expect(*container.SecurityContext.RunAsNonRoot).To(BeTrue())
+		Expect(container.VolumeMounts).To(HaveLen(2))
+		Expect(container.VolumeMounts[0].Name).To(Equal(VolumeName))
+		Expect(container.VolumeMounts[1].MountPath).To(Equal("/var/run/kubevirt"))
+	})
+
+	It("should request the limits for guaranteed VMIs", func() {
+		vmi := v1.NewMinimalVMI("testvmi")
+		vmi.Spec.Domain.CPU = &v1.CPU{DedicatedCPUPlacement: true}
+
+		container := GenerateContainer(vmi, "virt-launcher", kubev1.PullIfNotPresent, "/var/run/kubevirt", "virt-share-dir")
+
+		Expect(container.Resources.Requests).To(Equal(container.Resources.Limits))
+	})
+})
@@ -0,0 +1,125 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2020 Red Hat, Inc.
+ *
+ */
+
+package consolelog
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"syscall"
+	"time"
+)
+
+// CreatePipe creates the named pipe at the given path, readable and writable
+// only by the user of the guest-console-log container
+func CreatePipe(path string) error {
+	if info, err := os.Stat(path); err == nil {
+		if info.Mode()&os.ModeNamedPipe == 0 {
+			return fmt.Errorf("%s exists and is not a named pipe", path)
+		}
+		return nil
+	}
+	if err := syscall.Mkfifo(path, 0600); err != nil {
+		return err
+	}
+	if os.Geteuid() == 0 {
+		return os.Chown(path, nonRootUserID, nonRootUserID)
+	}
+	return nil
+}
+
+// PipeWriter writes to a named pipe and reopens it when the reader went away.
+// Opening the pipe blocks until a reader shows up.
+type PipeWriter struct {
+	path string
+	pipe *os.File
+}
+
+func NewPipeWriter(path string) *PipeWriter {
+	return &PipeWriter{path: path}
+}
+
+func (w *PipeWriter) Write(p []byte) (int, error) {
+	written := 0
+	for {
+		if w.pipe == nil {
+			pipe, err := os.OpenFile(w.path, os.O_WRONLY, 0)
+			if err != nil {
+				return written, err
+			}
+			w.pipe = pipe
+		}
+		n, err := w.pipe.Write(p[written:])
+		written += n
+		if err == nil {
+			return written, nil
+		}
+		w.pipe.Close()
+		w.pipe = nil
+		// the guest-console-log container restarted, continue with the new reader
+		if pathErr, ok := err.(*os.PathError); !ok || pathErr.Err != syscall.EPIPE {
+			return written, err
+		}
+	}
+}
+
+func (w *PipeWriter) Close() error {
+	if w.pipe == nil {
+		return nil
+	}
+	err := w.pipe.Close()
+	w.pipe = nil
+	return err
+}
+
+// CopyFromPipe copies everything written to the named pipe to the output until
+// the stop channel is closed. The pipe does not have to exist yet. It is opened
+// for reading and writing, so that it stays open while the writer reopens it.
+func CopyFromPipe(path string, out io.Writer, pollInterval time.Duration, stop <-chan struct{}) error {
+	var pipe *os.File
+	for pipe == nil {
+		f, err := os.OpenFile(path, os.O_RDWR, 0)
+		if err == nil {
+			pipe = f
+		} else if !os.IsNotExist(err) {
+			return err
+		} else {
+			select {
+			case <-stop:
+				return nil
+			case <-time.After(pollInterval):
+			}
+		}
+	}
+	defer pipe.Close()
+
+	copyErr := make(chan error, 1)
+	go func() {
+		_, err := io.Copy(out, pipe)
+		copyErr <- err
+	}()
+
+	select {
+	case <-stop:
+		return nil
+	case err := <-copyErr:
+		return err
+	}
+}
@@ -0,0 +1,116 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2020 Red Hat, Inc.
+ *
+ */
+
+package consolelog
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Pipe", func() {
+
+	var tmpDir string
+	var pipePath string
+	var out *syncBuffer
+	var stop chan struct{}
+	var done chan error
+
+	BeforeEach(func() {
+		var err error
+		tmpDir, err = ioutil.TempDir("", "consolelogpipe")
+		Expect(err).ToNot(HaveOccurred())
+		pipePath = filepath.Join(tmpDir, "virt-serial0-pipe")
+		out = &syncBuffer{}
+		stop = make(chan struct{})
+		done = make(chan error, 1)
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(tmpDir)
+	})
+
+	startReader := func() {
+		go func() {
+			done <- CopyFromPipe(pipePath, out, 10*time.Millisecond, stop)
+		}()
+	}
+
+	It("should create a named pipe", func() {
+		Expect(CreatePipe(pipePath)).To(Succeed())
+		info, err := os.Stat(pipePath)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(info.Mode() & os.ModeNamedPipe).ToNot(BeZero())
+		Expect(info.Mode().Perm()).To(Equal(os.FileMode(0600)))
+		Expect(CreatePipe(pipePath)).To(Succeed())
+	})
+
+	It("should refuse to use a regular file as pipe", func() {
+		Expect(ioutil.WriteFile(pipePath, []byte("log"), 0600)).To(Succeed())
+		Expect(CreatePipe(pipePath)).ToNot(Succeed())
+	})
+
+	It("should wait for the pipe and copy everything written to it", func() {
+		startReader()
+		Expect(CreatePipe(pipePath)).To(Succeed())
+
+		writer := NewPipeWriter(pipePath)
+		defer writer.Close()
+		_, err := writer.Write([]byte("login: "))
+		Expect(err).ToNot(HaveOccurred())
+		Eventually(out.String).Should(Equal("login: "))
+
+		close(stop)
+		Eventually(done).Should(Receive(BeNil()))
+	})
+
+	It("should continue writing after the reader restarted", func() {
+		Expect(CreatePipe(pipePath)).To(Succeed())
+		startReader()
+
+		writer := NewPipeWriter(pipePath)
+		defer writer.Close()
+		_, err := writer.Write([]byte("first\n"))
+		Expect(err).ToNot(HaveOccurred())
+		Eventually(out.String).Should(Equal("first\n"))
+		close(stop)
+		Eventually(done).Should(Receive(BeNil()))
+
+		stop = make(chan struct{})
+		startReader()
+		Eventually(func() string {
+			writer.Write([]byte("second\n"))
+			return out.String()
+		}).Should(ContainSubstring("second\n"))
+
+		close(stop)
+		Eventually(done).Should(Receive(BeNil()))
+	})
+
+	It("should stop waiting for the pipe", func() {
+		startReader()
+		close(stop)
+		Eventually(done).Should(Receive(BeNil()))
+	})
+})
@@ -0,0 +1,151 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2019 Red Hat, Inc.
+ *
+ */
+
+package consolelog
+
+import (
+	"io"
+	"os"
+	"time"
+)
+
+// Tailer follows a log file, which is rotated by renaming it, like virtlogd does
+type Tailer struct {
+	path         string
+	out          io.Writer
+	pollInterval time.Duration
+}
+
+func NewTailer(path string, out io.Writer, pollInterval time.Duration) *Tailer {
+	return &Tailer{
+		path:         path,
+		out:          out,
+		pollInterval: pollInterval,
+	}
+}
+
+// Follow copies the log file to the output until the stop channel is closed.
+// The file does not have to exist yet. All content which was written before
+// stop got closed is copied before Follow returns.
+func (t *Tailer) Follow(stop <-chan struct{}) error {
+	var file *os.File
+	defer func() {
+		if file != nil {
+			file.Close()
+		}
+	}()
+
+	for {
+		stopped := isClosed(stop)
+
+		if file == nil {
+			f, err := os.Open(t.path)
+			if err != nil && !os.IsNotExist(err) {
+				return err
+			} else if err == nil {
+				file = f
+			}
+		}
+
+		if file != nil {
+			if _, err := io.Copy(t.out, file); err != nil {
+				return err
+			}
+			rotated, err := t.rotated(file)
+			if err != nil {
+				return err
+			}
+			if rotated {
+				// the old file is drained, continue with the new one right away
+				file.Close()
+				file = nil
+				if _, err := os.Stat(t.path); err == nil {
+					continue
+				}
+			}
+		}
+
+		if stopped {
+			return nil
+		}
+
+		select {
+		case <-stop:
+		case <-time.After(t.pollInterval):
+		}
+	}
+}
+
+// rotated checks if the path points to a different file than the opened one.
+// Files which got truncated are read again from the start.
+func (t *Tailer) rotated(file *os.File) (bool, error) {
+	current, err := os.Stat(t.path)
+	if os.IsNotExist(err) {
+		return true, nil
+	} else if err != nil {
+		return false, err
+	}
+	opened, err := file.Stat()
+	if err != nil {
+		return false, err
+	}
+	if !os.SameFile(opened, current) {
+		return true, nil
+	}
+	offset, err := file.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return false, err
+	}
+	if current.Size() < offset {
+		_, err = file.Seek(0, io.SeekStart)
+		return false, err
+	}
+	return false, nil
+}
+
+// WaitForSocketRemoval returns a channel which is closed once the socket at the given path
+// got removed. If the socket does not show up within the timeout, the channel is closed too.
+func WaitForSocketRemoval(socketPath string, timeout time.Duration, pollInterval time.Duration) <-chan struct{} {
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		seen := false
+		start := time.Now()
+		for {
+			_, err := os.Stat(socketPath)
+			exists := err == nil
+			if exists {
+				seen = true
+			} else if seen || time.Since(start) > timeout {
+				return
+			}
+			time.Sleep(pollInterval)
+		}
+	}()
+	return done
+}
+
+func isClosed(c <-chan struct{}) bool {
+	select {
+	case <-c:
+		return true
+	default:
+		return false
+	}
+}
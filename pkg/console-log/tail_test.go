@@ -0,0 +1,139 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2019 Red Hat, Inc.
+ *
+ */
+
+package consolelog
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+type syncBuffer struct {
+	lock sync.Mutex
+	buf  bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	return b.buf.String()
+}
+
+var _ = Describe("Tailer", func() {
+
+	var tmpDir string
+	var logFile string
+	var out *syncBuffer
+	var stop chan struct{}
+	var done chan error
+
+	BeforeEach(func() {
+		var err error
+		tmpDir, err = ioutil.TempDir("", "console-log")
+		Expect(err).ToNot(HaveOccurred())
+		logFile = filepath.Join(tmpDir, "virt-serial0-log")
+		out = &syncBuffer{}
+		stop = make(chan struct{})
+		done = make(chan error, 1)
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(tmpDir)
+	})
+
+	follow := func() {
+		tailer := NewTailer(logFile, out, 10*time.Millisecond)
+		go func() {
+			done <- tailer.Follow(stop)
+		}()
+	}
+
+	appendLog := func(path string, content string) {
+		f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+		Expect(err).ToNot(HaveOccurred())
+		defer f.Close()
+		_, err = f.WriteString(content)
+		Expect(err).ToNot(HaveOccurred())
+	}
+
+	It("should wait for the log file to appear", func() {
+		follow()
+		Consistently(out.String).Should(BeEmpty())
+		appendLog(logFile, "Booting\n")
+		Eventually(out.String).Should(Equal("Booting\n"))
+	})
+
+	It("should follow appended content", func() {
+		appendLog(logFile, "Booting\n")
+		follow()
+		Eventually(out.String).Should(Equal("Booting\n"))
+		appendLog(logFile, "login:")
+		Eventually(out.String).Should(Equal("Booting\nlogin:"))
+	})
+
+	It("should continue with the new file after a rotation", func() {
+		appendLog(logFile, "first\n")
+		follow()
+		Eventually(out.String).Should(Equal("first\n"))
+
+		appendLog(logFile, "second\n")
+		Expect(os.Rename(logFile, logFile+".0")).To(Succeed())
+		appendLog(logFile, "third\n")
+
+		Eventually(out.String).Should(Equal("first\nsecond\nthird\n"))
+	})
+
+	It("should flush the remaining content when stopped", func() {
+		follow()
+		appendLog(logFile, "Kernel panic\n")
+		close(stop)
+		Eventually(done).Should(Receive(BeNil()))
+		Expect(out.String()).To(Equal("Kernel panic\n"))
+	})
+
+	Context("waiting for the socket removal", func() {
+		It("should notice the removal of the socket", func() {
+			socket := filepath.Join(tmpDir, "sock")
+			appendLog(socket, "")
+
+			removed := WaitForSocketRemoval(socket, time.Minute, 10*time.Millisecond)
+			Consistently(removed).ShouldNot(BeClosed())
+			Expect(os.Remove(socket)).To(Succeed())
+			Eventually(removed).Should(BeClosed())
+		})
+
+		It("should give up if the socket never shows up", func() {
+			removed := WaitForSocketRemoval(filepath.Join(tmpDir, "sock"), 50*time.Millisecond, 10*time.Millisecond)
+			Eventually(removed).Should(BeClosed())
+		})
+	})
+})
@@ -25,6 +25,7 @@ import (
 	"os"
 	"path/filepath"
 	"strconv"
+	"strings"
 
 	kubev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
@@ -38,8 +39,19 @@ import (
 
 var containerDiskOwner = "qemu"
 
+// maxSymlinks limits the number of symlinks followed while resolving a path, like the kernel does
+const maxSymlinks = 40
+
 var mountBaseDir = filepath.Join(util.VirtShareDir, "/container-disks")
 
+const (
+	// KernelBootName is the name of the container which provides the kernel boot artifacts
+	KernelBootName = "kernel-boot"
+	// KernelBootKernel and KernelBootInitrd name the artifacts of a kernel boot container
+	KernelBootKernel = "kernel"
+	KernelBootInitrd = "initrd"
+)
+
 func GenerateVolumeMountDir(vmi *v1.VirtualMachineInstance) string {
 	return filepath.Join(mountBaseDir, string(vmi.UID))
 }
@@ -80,6 +92,26 @@ func GenerateSocketPathFromHostView(vmi *v1.VirtualMachineInstance, volumeIndex
 	return fmt.Sprintf("%s/%s/disk_%d.sock", mountBaseDir, vmi.UID, volumeIndex)
 }
 
+// GenerateKernelBootArtifactPathFromHostView returns the path where the given kernel boot artifact is mounted on the node.
+func GenerateKernelBootArtifactPathFromHostView(vmi *v1.VirtualMachineInstance, artifact string) string {
+	return filepath.Join(GenerateVolumeMountDir(vmi), fmt.Sprintf("%s_%s", KernelBootName, artifact))
+}
+
+// GenerateKernelBootArtifactPathFromLauncherView returns the path where the given kernel boot artifact is visible for qemu.
+func GenerateKernelBootArtifactPathFromLauncherView(artifact string) string {
+	return filepath.Join(mountBaseDir, fmt.Sprintf("%s_%s", KernelBootName, artifact))
+}
+
+func GenerateKernelBootSocketPathFromHostView(vmi *v1.VirtualMachineInstance) string {
+	return filepath.Join(GenerateVolumeMountDir(vmi), KernelBootName+".sock")
+}
+
+// HasKernelBootContainer returns true if the VMI boots a kernel and initrd provided by a container image.
+func HasKernelBootContainer(vmi *v1.VirtualMachineInstance) bool {
+	firmware := vmi.Spec.Domain.Firmware
+	return firmware != nil && firmware.KernelBoot != nil && firmware.KernelBoot.Container != nil
+}
+
 func GetImage(root string, imagePath string) (string, error) {
 	fallbackPath := filepath.Join(root, DiskSourceFallbackPath)
 	if imagePath != "" {
@@ -104,81 +136,150 @@ func GetImage(root string, imagePath string) (string, error) {
 	return imagePath, nil
 }
 
+// ResolveInRoot resolves all symlinks of path as if root was the root of the
+// filesystem. Absolute symlink targets are looked up relative to root and ".."
+// elements can't leave it. The returned path is prefixed with root.
+func ResolveInRoot(root string, path string) (string, error) {
+	resolved := ""
+	remaining := filepath.Clean("/" + path)
+	followed := 0
+
+	for remaining != "" {
+		var component string
+		remaining = strings.TrimPrefix(remaining, "/")
+		if i := strings.Index(remaining, "/"); i >= 0 {
+			component, remaining = remaining[:i], remaining[i:]
+		} else {
+			component, remaining = remaining, ""
+		}
+
+		switch component {
+		case "", ".":
+			continue
+		case "..":
+			resolved = filepath.Dir("/" + resolved)
+			continue
+		}
+
+		next := filepath.Join(resolved, component)
+		info, err := os.Lstat(filepath.Join(root, next))
+		if err != nil {
+			if os.IsNotExist(err) {
+				// Nothing left to resolve, the caller decides what to do with missing files
+				resolved = filepath.Join(next, remaining)
+				break
+			}
+			return "", err
+		}
+		if info.Mode()&os.ModeSymlink == 0 {
+			resolved = next
+			continue
+		}
+
+		followed++
+		if followed > maxSymlinks {
+			return "", fmt.Errorf("too many levels of symbolic links in %s", path)
+		}
+		target, err := os.Readlink(filepath.Join(root, next))
+		if err != nil {
+			return "", err
+		}
+		if filepath.IsAbs(target) {
+			resolved = ""
+		}
+		remaining = target + remaining
+		if !strings.HasPrefix(remaining, "/") {
+			remaining = "/" + remaining
+		}
+	}
+	return filepath.Join(root, filepath.Clean("/"+resolved)), nil
+}
+
 // The controller uses this function to generate the container
 // specs for hosting the container registry disks.
 func GenerateContainers(vmi *v1.VirtualMachineInstance, podVolumeName string, binVolumeName string) []kubev1.Container {
 	var containers []kubev1.Container
 
+	volumeMountDir := GenerateVolumeMountDir(vmi)
+
+	// Make VirtualMachineInstance Image Wrapper Containers
+	for index, volume := range vmi.Spec.Volumes {
+		if volume.ContainerDisk != nil {
+			diskContainerName := fmt.Sprintf("volume%s", volume.Name)
+			copyPath := volumeMountDir + "/disk_" + strconv.Itoa(index)
+			image := GetPinnedImage(vmi, &volume)
+			containers = append(containers, generateContainer(vmi, diskContainerName, image, volume.ContainerDisk.ImagePullPolicy, copyPath, podVolumeName, binVolumeName))
+		}
+	}
+
+	// The kernel boot container is mounted the same way as a containerDisk
+	if HasKernelBootContainer(vmi) {
+		container := vmi.Spec.Domain.Firmware.KernelBoot.Container
+		copyPath := filepath.Join(volumeMountDir, KernelBootName)
+		containers = append(containers, generateContainer(vmi, KernelBootName, container.Image, container.ImagePullPolicy, copyPath, podVolumeName, binVolumeName))
+	}
+	return containers
+}
+
+func generateContainer(vmi *v1.VirtualMachineInstance, name string, image string, pullPolicy kubev1.PullPolicy, copyPath string, podVolumeName string, binVolumeName string) kubev1.Container {
 	initialDelaySeconds := 1
 	timeoutSeconds := 1
 	periodSeconds := 1
 	successThreshold := 1
 	failureThreshold := 5
 
-	// Make VirtualMachineInstance Image Wrapper Containers
-	for index, volume := range vmi.Spec.Volumes {
-		if volume.ContainerDisk != nil {
+	resources := kubev1.ResourceRequirements{}
+	if vmi.IsCPUDedicated() || vmi.WantsToHaveQOSGuaranteed() {
+		resources.Limits = make(kubev1.ResourceList)
+		resources.Limits[kubev1.ResourceCPU] = resource.MustParse("10m")
+		resources.Limits[kubev1.ResourceMemory] = resource.MustParse("40M")
+		resources.Requests = make(kubev1.ResourceList)
+		resources.Requests[kubev1.ResourceCPU] = resource.MustParse("10m")
+		resources.Requests[kubev1.ResourceMemory] = resource.MustParse("40M")
+	} else {
+		resources.Limits = make(kubev1.ResourceList)
+		resources.Limits[kubev1.ResourceCPU] = resource.MustParse("100m")
+		resources.Limits[kubev1.ResourceMemory] = resource.MustParse("40M")
+		resources.Requests = make(kubev1.ResourceList)
+		resources.Requests[kubev1.ResourceCPU] = resource.MustParse("10m")
+		resources.Requests[kubev1.ResourceMemory] = resource.MustParse("1M")
+	}
+	return kubev1.Container{
+		Name:            name,
+		Image:           image,
+		ImagePullPolicy: pullPolicy,
+		Command:         []string{"/usr/bin/container-disk"},
+		Args:            []string{"--copy-path", copyPath},
+		VolumeMounts: []kubev1.VolumeMount{
+			{
+				Name:      podVolumeName,
+				MountPath: GenerateVolumeMountDir(vmi),
+			},
+			{
+				Name:      binVolumeName,
+				MountPath: "/usr/bin",
+			},
+		},
+		Resources: resources,
 
-			volumeMountDir := GenerateVolumeMountDir(vmi)
-			diskContainerName := fmt.Sprintf("volume%s", volume.Name)
-			diskContainerImage := volume.ContainerDisk.Image
-			resources := kubev1.ResourceRequirements{}
-			if vmi.IsCPUDedicated() || vmi.WantsToHaveQOSGuaranteed() {
-				resources.Limits = make(kubev1.ResourceList)
-				resources.Limits[kubev1.ResourceCPU] = resource.MustParse("10m")
-				resources.Limits[kubev1.ResourceMemory] = resource.MustParse("40M")
-				resources.Requests = make(kubev1.ResourceList)
-				resources.Requests[kubev1.ResourceCPU] = resource.MustParse("10m")
-				resources.Requests[kubev1.ResourceMemory] = resource.MustParse("40M")
-			} else {
-				resources.Limits = make(kubev1.ResourceList)
-				resources.Limits[kubev1.ResourceCPU] = resource.MustParse("100m")
-				resources.Limits[kubev1.ResourceMemory] = resource.MustParse("40M")
-				resources.Requests = make(kubev1.ResourceList)
-				resources.Requests[kubev1.ResourceCPU] = resource.MustParse("10m")
-				resources.Requests[kubev1.ResourceMemory] = resource.MustParse("1M")
-			}
-			container := kubev1.Container{
-				Name:            diskContainerName,
-				Image:           diskContainerImage,
-				ImagePullPolicy: volume.ContainerDisk.ImagePullPolicy,
-				Command:         []string{"/usr/bin/container-disk"},
-				Args:            []string{"--copy-path", volumeMountDir + "/disk_" + strconv.Itoa(index)},
-				VolumeMounts: []kubev1.VolumeMount{
-					{
-						Name:      podVolumeName,
-						MountPath: volumeMountDir,
+		// The readiness probes ensure the volume coversion and copy finished
+		// before the container is marked as "Ready: True"
+		ReadinessProbe: &kubev1.Probe{
+			Handler: kubev1.Handler{
+				Exec: &kubev1.ExecAction{
+					Command: []string{
+						"/usr/bin/container-disk",
+						"--health-check",
 					},
-					{
-						Name:      binVolumeName,
-						MountPath: "/usr/bin",
-					},
-				},
-				Resources: resources,
-
-				// The readiness probes ensure the volume coversion and copy finished
-				// before the container is marked as "Ready: True"
-				ReadinessProbe: &kubev1.Probe{
-					Handler: kubev1.Handler{
-						Exec: &kubev1.ExecAction{
-							Command: []string{
-								"/usr/bin/container-disk",
-								"--health-check",
-							},
-						},
-					},
-					InitialDelaySeconds: int32(initialDelaySeconds),
-					PeriodSeconds:       int32(periodSeconds),
-					TimeoutSeconds:      int32(timeoutSeconds),
-					SuccessThreshold:    int32(successThreshold),
-					FailureThreshold:    int32(failureThreshold),
 				},
-			}
-
-			containers = append(containers, container)
-		}
+			},
+			InitialDelaySeconds: int32(initialDelaySeconds),
+			PeriodSeconds:       int32(periodSeconds),
+			TimeoutSeconds:      int32(timeoutSeconds),
+			SuccessThreshold:    int32(successThreshold),
+			FailureThreshold:    int32(failureThreshold),
+		},
 	}
-	return containers
 }
 
 func CreateEphemeralImages(vmi *v1.VirtualMachineInstance) error {
@@ -24,12 +24,14 @@ import (
 	"os"
 	"os/user"
 	"path/filepath"
+	"strings"
 
 	. "github.com/onsi/ginkgo"
 	"github.com/onsi/ginkgo/extensions/table"
 	. "github.com/onsi/gomega"
 	k8sv1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/validation"
 
 	v1 "kubevirt.io/client-go/api/v1"
 )
@@ -110,6 +112,104 @@ var _ = Describe("ContainerDisk", func() {
 				Expect(containers[0].ImagePullPolicy).To(Equal(k8sv1.PullAlways))
 				Expect(containers[1].ImagePullPolicy).To(Equal(k8sv1.PullAlways))
 			})
+			It("by verifying that containerDisks are pinned to their digest", func() {
+				digest := "sha256:" + strings.Repeat("a", 64)
+				vmi := v1.NewMinimalVMI("fake-vmi")
+				appendContainerDisk(vmi, "r0")
+				appendContainerDisk(vmi, "r1")
+				vmi.Status.ContainerDiskStatuses = []v1.ContainerDiskStatus{
+					{Name: "r0", Image: "someimage:v1.2.3.4", ImageDigest: digest},
+					{Name: "r1", Image: "otherimage:v1", ImageDigest: digest},
+				}
+				containers := GenerateContainers(vmi, "libvirt-runtime", "bin-volume")
+
+				Expect(containers).To(HaveLen(2))
+				Expect(containers[0].Image).To(Equal("someimage@" + digest))
+				By("ignoring digests which were recorded for a different image")
+				Expect(containers[1].Image).To(Equal("someimage:v1.2.3.4"))
+			})
+			It("by verifying that the cached image node label is a valid label key", func() {
+				label := CachedImageNodeLabel("registry:5000/kubevirt/fedora-cloud-container-disk-demo@sha256:" + strings.Repeat("a", 64))
+				Expect(validation.IsQualifiedName(label)).To(BeEmpty())
+				Expect(label).To(HavePrefix(v1.ContainerDiskCachedImageLabelPrefix))
+				Expect(CachedImageNodeLabel("other-image")).ToNot(Equal(label))
+			})
+			It("by verifying that all images are collected", func() {
+				vmi := v1.NewMinimalVMI("fake-vmi")
+				appendContainerDisk(vmi, "r0")
+				vmi.Spec.Domain.Firmware = &v1.Firmware{
+					KernelBoot: &v1.KernelBoot{
+						Container: &v1.KernelBootContainer{Image: "kernel-image", KernelPath: "/boot/vmlinuz"},
+					},
+				}
+				Expect(GetImages(vmi)).To(Equal([]string{"someimage:v1.2.3.4", "kernel-image"}))
+			})
+			It("by verifying kernel boot container generation", func() {
+				vmi := v1.NewMinimalVMI("fake-vmi")
+				appendContainerDisk(vmi, "r0")
+				vmi.Spec.Domain.Firmware = &v1.Firmware{
+					KernelBoot: &v1.KernelBoot{
+						Container: &v1.KernelBootContainer{
+							Image:           "kernel-image",
+							ImagePullPolicy: k8sv1.PullIfNotPresent,
+							KernelPath:      "/boot/vmlinuz",
+						},
+					},
+				}
+				containers := GenerateContainers(vmi, "libvirt-runtime", "bin-volume")
+
+				Expect(containers).To(HaveLen(2))
+				Expect(containers[1].Name).To(Equal(KernelBootName))
+				Expect(containers[1].Image).To(Equal("kernel-image"))
+				Expect(containers[1].ImagePullPolicy).To(Equal(k8sv1.PullIfNotPresent))
+				Expect(containers[1].Args).To(Equal([]string{"--copy-path", filepath.Join(GenerateVolumeMountDir(vmi), KernelBootName)}))
+			})
+			It("by verifying the kernel boot artifact paths", func() {
+				vmi := v1.NewMinimalVMI("fake-vmi")
+				Expect(GenerateKernelBootArtifactPathFromHostView(vmi, KernelBootKernel)).To(Equal(filepath.Join(GenerateVolumeMountDir(vmi), "kernel-boot_kernel")))
+				Expect(GenerateKernelBootArtifactPathFromLauncherView(KernelBootInitrd)).To(Equal(filepath.Join(tmpDir, "kernel-boot_initrd")))
+				Expect(GenerateKernelBootSocketPathFromHostView(vmi)).To(Equal(filepath.Join(GenerateVolumeMountDir(vmi), "kernel-boot.sock")))
+			})
+		})
+
+		Context("resolving paths in a root", func() {
+			var root string
+
+			BeforeEach(func() {
+				var err error
+				root, err = ioutil.TempDir("", "containerdiskroot")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(os.MkdirAll(filepath.Join(root, "boot"), 0755)).To(Succeed())
+				_, err = os.Create(filepath.Join(root, "boot", "vmlinuz"))
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			AfterEach(func() {
+				os.RemoveAll(root)
+			})
+
+			table.DescribeTable("should stay inside the root", func(link string, target string, path string, expected string) {
+				if link != "" {
+					Expect(os.Symlink(target, filepath.Join(root, link))).To(Succeed())
+				}
+				resolved, err := ResolveInRoot(root, path)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(resolved).To(Equal(filepath.Join(root, expected)))
+			},
+				table.Entry("with a regular file", "", "", "/boot/vmlinuz", "/boot/vmlinuz"),
+				table.Entry("with a relative symlink", "boot/kernel", "vmlinuz", "/boot/kernel", "/boot/vmlinuz"),
+				table.Entry("with an absolute symlink", "kernel", "/boot/vmlinuz", "/kernel", "/boot/vmlinuz"),
+				table.Entry("with a symlinked directory", "linux", "boot", "/linux/vmlinuz", "/boot/vmlinuz"),
+				table.Entry("with an absolute symlink outside of the root", "shadow", "/etc/shadow", "/shadow", "/etc/shadow"),
+				table.Entry("with a relative symlink outside of the root", "boot/shadow", "../../../etc/shadow", "/boot/shadow", "/etc/shadow"),
+				table.Entry("with '..' elements in the path", "", "", "/../../boot/vmlinuz", "/boot/vmlinuz"),
+			)
+
+			It("should fail on symlink loops", func() {
+				Expect(os.Symlink("loop", filepath.Join(root, "loop"))).To(Succeed())
+				_, err := ResolveInRoot(root, "/loop")
+				Expect(err).To(HaveOccurred())
+			})
 		})
 	})
 })
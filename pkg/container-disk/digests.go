@@ -0,0 +1,61 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2020 Red Hat, Inc.
+ *
+ */
+
+package containerdisk
+
+import (
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/client-go/kubecli"
+	"kubevirt.io/kubevirt/pkg/container-disk/registry"
+)
+
+// GetImageDigest returns the digest the image of the containerDisk volume was pinned to at VMI creation
+func GetImageDigest(vmi *v1.VirtualMachineInstance, volume *v1.Volume) (string, bool) {
+	for _, status := range vmi.Status.ContainerDiskStatuses {
+		if status.Name == volume.Name && status.Image == volume.ContainerDisk.Image {
+			return status.ImageDigest, true
+		}
+	}
+	return "", false
+}
+
+// GetPinnedImage returns the image of the containerDisk volume, pinned to its digest if it has one.
+// All pods of a VMI, including migration targets, run the same image that way.
+func GetPinnedImage(vmi *v1.VirtualMachineInstance, volume *v1.Volume) string {
+	if digest, ok := GetImageDigest(vmi, volume); ok {
+		return registry.PinImage(volume.ContainerDisk.Image, digest)
+	}
+	return volume.ContainerDisk.Image
+}
+
+// GetImagePullCredentials returns the registry credentials of the containerDisk image pull secret, if it has one
+func GetImagePullCredentials(client kubecli.KubevirtClient, namespace string, containerDisk *v1.ContainerDiskSource) (*registry.Credentials, error) {
+	if containerDisk.ImagePullSecret == "" {
+		return nil, nil
+	}
+	secret, err := client.CoreV1().Secrets(namespace).Get(containerDisk.ImagePullSecret, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get image pull secret %s: %v", containerDisk.ImagePullSecret, err)
+	}
+	return registry.CredentialsFromSecret(secret, containerDisk.Image)
+}
@@ -0,0 +1,48 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2020 Red Hat, Inc.
+ *
+ */
+
+package containerdisk
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+
+	v1 "kubevirt.io/client-go/api/v1"
+)
+
+// CachedImageNodeLabel returns the label which marks the nodes holding the image in a ContainerDiskImageCache.
+// The image is hashed since image names are not valid label keys.
+func CachedImageNodeLabel(image string) string {
+	hash := sha256.Sum256([]byte(image))
+	return v1.ContainerDiskCachedImageLabelPrefix + hex.EncodeToString(hash[:16])
+}
+
+// GetImages returns the images of all containerDisk volumes and of the kernel boot container
+func GetImages(vmi *v1.VirtualMachineInstance) []string {
+	var images []string
+	for _, volume := range vmi.Spec.Volumes {
+		if volume.ContainerDisk != nil {
+			images = append(images, volume.ContainerDisk.Image)
+		}
+	}
+	if HasKernelBootContainer(vmi) {
+		images = append(images, vmi.Spec.Domain.Firmware.KernelBoot.Container.Image)
+	}
+	return images
+}
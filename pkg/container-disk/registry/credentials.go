@@ -0,0 +1,105 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2020 Red Hat, Inc.
+ *
+ */
+
+package registry
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	k8sv1 "k8s.io/api/core/v1"
+)
+
+var dockerHubAliases = []string{dockerHub, dockerHubRegistry, "index.docker.io"}
+
+type dockerConfigEntry struct {
+	Username string `json:"username,omitempty"`
+	Password string `json:"password,omitempty"`
+	Auth     string `json:"auth,omitempty"`
+}
+
+type dockerConfigJSON struct {
+	Auths map[string]dockerConfigEntry `json:"auths"`
+}
+
+// CredentialsFromSecret looks up the credentials for the registry of the image in an image pull secret.
+// It returns nil if the secret has no credentials for that registry.
+func CredentialsFromSecret(secret *k8sv1.Secret, image string) (*Credentials, error) {
+	ref, err := ParseReference(image)
+	if err != nil {
+		return nil, err
+	}
+
+	var auths map[string]dockerConfigEntry
+	if data, ok := secret.Data[k8sv1.DockerConfigJsonKey]; ok {
+		config := dockerConfigJSON{}
+		if err := json.Unmarshal(data, &config); err != nil {
+			return nil, fmt.Errorf("failed to parse %s of secret %s: %v", k8sv1.DockerConfigJsonKey, secret.Name, err)
+		}
+		auths = config.Auths
+	} else if data, ok := secret.Data[k8sv1.DockerConfigKey]; ok {
+		if err := json.Unmarshal(data, &auths); err != nil {
+			return nil, fmt.Errorf("failed to parse %s of secret %s: %v", k8sv1.DockerConfigKey, secret.Name, err)
+		}
+	} else {
+		return nil, fmt.Errorf("secret %s is not an image pull secret", secret.Name)
+	}
+
+	for server, entry := range auths {
+		if !matchesRegistry(server, ref.Registry) {
+			continue
+		}
+		if entry.Auth == "" {
+			return &Credentials{Username: entry.Username, Password: entry.Password}, nil
+		}
+		decoded, err := base64.StdEncoding.DecodeString(entry.Auth)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode auth for %s in secret %s: %v", server, secret.Name, err)
+		}
+		parts := strings.SplitN(string(decoded), ":", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("invalid auth for %s in secret %s", server, secret.Name)
+		}
+		return &Credentials{Username: parts[0], Password: parts[1]}, nil
+	}
+	return nil, nil
+}
+
+// matchesRegistry compares a docker config server entry like "https://index.docker.io/v1/" with a registry host
+func matchesRegistry(server string, registry string) bool {
+	server = strings.TrimPrefix(strings.TrimPrefix(server, "https://"), "http://")
+	if i := strings.Index(server, "/"); i >= 0 {
+		server = server[:i]
+	}
+	if server == registry {
+		return true
+	}
+	return isDockerHub(server) && isDockerHub(registry)
+}
+
+func isDockerHub(registry string) bool {
+	for _, alias := range dockerHubAliases {
+		if registry == alias {
+			return true
+		}
+	}
+	return false
+}
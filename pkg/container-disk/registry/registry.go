@@ -0,0 +1,392 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2020 Red Hat, Inc.
+ *
+ */
+
+// Package registry implements the small subset of the docker registry HTTP API v2
+// which is needed to pin containerDisk images to digests and to verify their signatures.
+package registry
+
+import (
+	"crypto/sha256"
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	dockerHub         = "docker.io"
+	dockerHubRegistry = "registry-1.docker.io"
+	defaultTag        = "latest"
+
+	// maxManifestSize limits how much is read from manifests and signature payloads
+	maxManifestSize = 4 * 1024 * 1024
+
+	requestTimeout = 10 * time.Second
+)
+
+var manifestMediaTypes = []string{
+	"application/vnd.docker.distribution.manifest.v2+json",
+	"application/vnd.docker.distribution.manifest.list.v2+json",
+	"application/vnd.oci.image.manifest.v1+json",
+	"application/vnd.oci.image.index.v1+json",
+}
+
+// Reference is a parsed image reference
+type Reference struct {
+	// Registry is the host and optional port of the registry
+	Registry string
+	// Repository is the repository inside the registry
+	Repository string
+	// Tag is set if the image is referenced by tag
+	Tag string
+	// Digest is set if the image is referenced by digest
+	Digest string
+}
+
+// Credentials are used to authenticate against a registry
+type Credentials struct {
+	Username string
+	Password string
+}
+
+// ParseReference parses an image reference like the container runtimes do, images
+// without a registry are taken from docker hub.
+func ParseReference(image string) (*Reference, error) {
+	ref := &Reference{}
+	name := image
+
+	if i := strings.Index(name, "@"); i >= 0 {
+		name, ref.Digest = name[:i], name[i+1:]
+		if !IsDigest(ref.Digest) {
+			return nil, fmt.Errorf("invalid digest %s in image %s", ref.Digest, image)
+		}
+	}
+	if i := strings.LastIndex(name, ":"); i > strings.LastIndex(name, "/") {
+		name, ref.Tag = name[:i], name[i+1:]
+	}
+	if ref.Tag == "" && ref.Digest == "" {
+		ref.Tag = defaultTag
+	}
+
+	parts := strings.SplitN(name, "/", 2)
+	if len(parts) == 2 && (strings.ContainsAny(parts[0], ".:") || parts[0] == "localhost") {
+		ref.Registry, ref.Repository = parts[0], parts[1]
+	} else {
+		ref.Registry, ref.Repository = dockerHub, name
+		if !strings.Contains(name, "/") {
+			ref.Repository = "library/" + name
+		}
+	}
+	if ref.Repository == "" {
+		return nil, fmt.Errorf("invalid image reference %s", image)
+	}
+	return ref, nil
+}
+
+// IsDigest returns true if the given string looks like a sha256 content digest
+func IsDigest(digest string) bool {
+	hex := strings.TrimPrefix(digest, "sha256:")
+	if hex == digest || len(hex) != sha256.Size*2 {
+		return false
+	}
+	return strings.Trim(hex, "0123456789abcdef") == ""
+}
+
+// Digest calculates the content digest of a manifest or blob
+func Digest(content []byte) string {
+	return fmt.Sprintf("sha256:%x", sha256.Sum256(content))
+}
+
+// PinImage returns the image reference with its tag replaced by the given digest
+func PinImage(image string, digest string) string {
+	name := image
+	if i := strings.Index(name, "@"); i >= 0 {
+		name = name[:i]
+	}
+	if i := strings.LastIndex(name, ":"); i > strings.LastIndex(name, "/") {
+		name = name[:i]
+	}
+	return name + "@" + digest
+}
+
+// Client talks to container registries
+type Client struct {
+	insecureRegistries []string
+	secureClient       *http.Client
+	insecureClient     *http.Client
+}
+
+// NewClient creates a registry client. Registries in insecureRegistries are contacted via
+// plain http.
+func NewClient(insecureRegistries []string) *Client {
+	return &Client{
+		insecureRegistries: insecureRegistries,
+		secureClient:       &http.Client{Timeout: requestTimeout},
+		insecureClient: &http.Client{
+			Timeout: requestTimeout,
+			Transport: &http.Transport{
+				TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+			},
+		},
+	}
+}
+
+// ResolveDigest returns the digest of the manifest an image reference points to
+func (c *Client) ResolveDigest(image string, credentials *Credentials) (string, error) {
+	ref, err := ParseReference(image)
+	if err != nil {
+		return "", err
+	}
+	if ref.Digest != "" {
+		return ref.Digest, nil
+	}
+
+	resp, err := c.do(ref, http.MethodHead, "manifests/"+ref.Tag, manifestMediaTypes, credentials)
+	if err != nil {
+		return "", err
+	}
+	resp.Body.Close()
+	if digest := resp.Header.Get("Docker-Content-Digest"); IsDigest(digest) {
+		return digest, nil
+	}
+
+	// Not all registries report the digest on HEAD requests, calculate it from the manifest
+	manifest, err := c.GetManifest(ref, ref.Tag, credentials)
+	if err != nil {
+		return "", err
+	}
+	return Digest(manifest), nil
+}
+
+// GetManifest fetches the manifest with the given tag or digest from the repository of ref
+func (c *Client) GetManifest(ref *Reference, reference string, credentials *Credentials) ([]byte, error) {
+	resp, err := c.do(ref, http.MethodGet, "manifests/"+reference, manifestMediaTypes, credentials)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	return readLimited(resp.Body)
+}
+
+// GetBlob fetches the blob with the given digest from the repository of ref and checks its digest
+func (c *Client) GetBlob(ref *Reference, digest string, credentials *Credentials) ([]byte, error) {
+	resp, err := c.do(ref, http.MethodGet, "blobs/"+digest, nil, credentials)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	blob, err := readLimited(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if Digest(blob) != digest {
+		return nil, fmt.Errorf("blob %s in %s does not match its digest", digest, ref.Repository)
+	}
+	return blob, nil
+}
+
+func (c *Client) isInsecure(registry string) bool {
+	for _, insecure := range c.insecureRegistries {
+		if insecure == registry {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *Client) httpClient(ref *Reference) *http.Client {
+	if c.isInsecure(ref.Registry) {
+		return c.insecureClient
+	}
+	return c.secureClient
+}
+
+func (c *Client) url(ref *Reference, path string) string {
+	scheme := "https"
+	if c.isInsecure(ref.Registry) {
+		scheme = "http"
+	}
+	host := ref.Registry
+	if host == dockerHub {
+		host = dockerHubRegistry
+	}
+	return fmt.Sprintf("%s://%s/v2/%s/%s", scheme, host, ref.Repository, path)
+}
+
+// do sends a request to the registry. If the registry asks for authentication, the request is
+// repeated with a token or basic auth.
+func (c *Client) do(ref *Reference, method string, path string, accept []string, credentials *Credentials) (*http.Response, error) {
+	requestURL := c.url(ref, path)
+
+	resp, err := c.send(ref, method, requestURL, accept, "")
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusUnauthorized {
+		challenge := resp.Header.Get("WWW-Authenticate")
+		resp.Body.Close()
+
+		authorization, err := c.authorize(ref, challenge, credentials)
+		if err != nil {
+			return nil, fmt.Errorf("failed to authenticate against %s: %v", ref.Registry, err)
+		}
+		resp, err = c.send(ref, method, requestURL, accept, authorization)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("%s %s failed with status %s", method, requestURL, resp.Status)
+	}
+	return resp, nil
+}
+
+func (c *Client) send(ref *Reference, method string, requestURL string, accept []string, authorization string) (*http.Response, error) {
+	req, err := http.NewRequest(method, requestURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	if len(accept) > 0 {
+		req.Header.Set("Accept", strings.Join(accept, ", "))
+	}
+	if authorization != "" {
+		req.Header.Set("Authorization", authorization)
+	}
+	return c.httpClient(ref).Do(req)
+}
+
+// authorize answers a basic or bearer token challenge of the registry
+func (c *Client) authorize(ref *Reference, challenge string, credentials *Credentials) (string, error) {
+	scheme, params := parseChallenge(challenge)
+	switch strings.ToLower(scheme) {
+	case "basic":
+		if credentials == nil {
+			return "", fmt.Errorf("registry requires credentials")
+		}
+		return "Basic " + base64.StdEncoding.EncodeToString([]byte(credentials.Username+":"+credentials.Password)), nil
+	case "bearer":
+		return c.fetchToken(ref, params, credentials)
+	default:
+		return "", fmt.Errorf("unsupported authentication challenge %q", challenge)
+	}
+}
+
+func (c *Client) fetchToken(ref *Reference, params map[string]string, credentials *Credentials) (string, error) {
+	realm, err := url.Parse(params["realm"])
+	if err != nil || params["realm"] == "" {
+		return "", fmt.Errorf("invalid token realm %q", params["realm"])
+	}
+	query := realm.Query()
+	if service, ok := params["service"]; ok {
+		query.Set("service", service)
+	}
+	scope := params["scope"]
+	if scope == "" {
+		scope = fmt.Sprintf("repository:%s:pull", ref.Repository)
+	}
+	query.Set("scope", scope)
+	realm.RawQuery = query.Encode()
+
+	req, err := http.NewRequest(http.MethodGet, realm.String(), nil)
+	if err != nil {
+		return "", err
+	}
+	if credentials != nil {
+		req.SetBasicAuth(credentials.Username, credentials.Password)
+	}
+	resp, err := c.httpClient(ref).Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("token request failed with status %s", resp.Status)
+	}
+
+	token := struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}{}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxManifestSize)).Decode(&token); err != nil {
+		return "", fmt.Errorf("failed to decode token: %v", err)
+	}
+	if token.Token == "" {
+		token.Token = token.AccessToken
+	}
+	if token.Token == "" {
+		return "", fmt.Errorf("registry returned an empty token")
+	}
+	return "Bearer " + token.Token, nil
+}
+
+// parseChallenge parses a WWW-Authenticate header like
+// Bearer realm="https://auth.docker.io/token",service="registry.docker.io"
+func parseChallenge(challenge string) (string, map[string]string) {
+	params := map[string]string{}
+	challenge = strings.TrimSpace(challenge)
+	i := strings.Index(challenge, " ")
+	if i < 0 {
+		return challenge, params
+	}
+	scheme, rest := challenge[:i], challenge[i+1:]
+
+	for rest != "" {
+		rest = strings.TrimLeft(rest, ", ")
+		eq := strings.Index(rest, "=")
+		if eq < 0 {
+			break
+		}
+		key := strings.ToLower(strings.TrimSpace(rest[:eq]))
+		rest = rest[eq+1:]
+
+		var value string
+		if strings.HasPrefix(rest, `"`) {
+			end := strings.Index(rest[1:], `"`)
+			if end < 0 {
+				value, rest = rest[1:], ""
+			} else {
+				value, rest = rest[1:end+1], rest[end+2:]
+			}
+		} else if end := strings.Index(rest, ","); end >= 0 {
+			value, rest = rest[:end], rest[end:]
+		} else {
+			value, rest = rest, ""
+		}
+		params[key] = value
+	}
+	return scheme, params
+}
+
+func readLimited(reader io.Reader) ([]byte, error) {
+	content, err := ioutil.ReadAll(io.LimitReader(reader, maxManifestSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(content) > maxManifestSize {
+		return nil, fmt.Errorf("content exceeds %d bytes", maxManifestSize)
+	}
+	return content, nil
+}
@@ -0,0 +1,277 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2020 Red Hat, Inc.
+ *
+ */
+
+package registry
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+	k8sv1 "k8s.io/api/core/v1"
+)
+
+var _ = Describe("Registry", func() {
+
+	const manifest = `{"schemaVersion":2}`
+	manifestDigest := Digest([]byte(manifest))
+
+	table.DescribeTable("should parse the image reference", func(image string, expected Reference) {
+		ref, err := ParseReference(image)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(*ref).To(Equal(expected))
+	},
+		table.Entry("of a docker hub library image", "fedora",
+			Reference{Registry: "docker.io", Repository: "library/fedora", Tag: "latest"}),
+		table.Entry("of a docker hub image with tag", "kubevirt/cirros-container-disk-demo:v0.1",
+			Reference{Registry: "docker.io", Repository: "kubevirt/cirros-container-disk-demo", Tag: "v0.1"}),
+		table.Entry("of an image in a registry with port", "registry:5000/kubevirt/cirros:devel",
+			Reference{Registry: "registry:5000", Repository: "kubevirt/cirros", Tag: "devel"}),
+		table.Entry("of an image pinned to a digest", "quay.io/kubevirt/cirros@"+manifestDigest,
+			Reference{Registry: "quay.io", Repository: "kubevirt/cirros", Digest: manifestDigest}),
+		table.Entry("of an image with tag and digest", "localhost/cirros:v1@"+manifestDigest,
+			Reference{Registry: "localhost", Repository: "cirros", Tag: "v1", Digest: manifestDigest}),
+	)
+
+	It("should reject invalid digests", func() {
+		_, err := ParseReference("fedora@sha256:1234")
+		Expect(err).To(HaveOccurred())
+	})
+
+	table.DescribeTable("should pin the image", func(image string, expected string) {
+		Expect(PinImage(image, manifestDigest)).To(Equal(expected))
+	},
+		table.Entry("without tag", "fedora", "fedora@"+manifestDigest),
+		table.Entry("with tag", "registry:5000/kubevirt/cirros:devel", "registry:5000/kubevirt/cirros@"+manifestDigest),
+		table.Entry("with digest", "quay.io/cirros@sha256:"+strings.Repeat("0", 64), "quay.io/cirros@"+manifestDigest),
+	)
+
+	Context("with a registry", func() {
+		var server *ghttp.Server
+		var registryHost string
+		var client *Client
+
+		BeforeEach(func() {
+			server = ghttp.NewServer()
+			registryHost = strings.TrimPrefix(server.URL(), "http://")
+			client = NewClient([]string{registryHost})
+		})
+
+		AfterEach(func() {
+			server.Close()
+		})
+
+		It("should resolve the digest from the response header", func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodHead, "/v2/kubevirt/cirros/manifests/devel"),
+					ghttp.RespondWith(http.StatusOK, nil, http.Header{"Docker-Content-Digest": []string{manifestDigest}}),
+				),
+			)
+			digest, err := client.ResolveDigest(registryHost+"/kubevirt/cirros:devel", nil)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(digest).To(Equal(manifestDigest))
+		})
+
+		It("should calculate the digest from the manifest if the registry does not report it", func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodHead, "/v2/kubevirt/cirros/manifests/devel"),
+					ghttp.RespondWith(http.StatusOK, nil),
+				),
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodGet, "/v2/kubevirt/cirros/manifests/devel"),
+					ghttp.RespondWith(http.StatusOK, manifest),
+				),
+			)
+			digest, err := client.ResolveDigest(registryHost+"/kubevirt/cirros:devel", nil)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(digest).To(Equal(manifestDigest))
+		})
+
+		It("should authenticate with a token", func() {
+			challenge := fmt.Sprintf(`Bearer realm="%s/token",service="registry",scope="repository:kubevirt/cirros:pull"`, server.URL())
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodHead, "/v2/kubevirt/cirros/manifests/devel"),
+					ghttp.RespondWith(http.StatusUnauthorized, nil, http.Header{"WWW-Authenticate": []string{challenge}}),
+				),
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodGet, "/token", "scope=repository%3Akubevirt%2Fcirros%3Apull&service=registry"),
+					ghttp.VerifyBasicAuth("user", "password"),
+					ghttp.RespondWith(http.StatusOK, `{"token":"secret-token"}`),
+				),
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodHead, "/v2/kubevirt/cirros/manifests/devel"),
+					ghttp.VerifyHeaderKV("Authorization", "Bearer secret-token"),
+					ghttp.RespondWith(http.StatusOK, nil, http.Header{"Docker-Content-Digest": []string{manifestDigest}}),
+				),
+			)
+			digest, err := client.ResolveDigest(registryHost+"/kubevirt/cirros:devel", &Credentials{Username: "user", Password: "password"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(digest).To(Equal(manifestDigest))
+		})
+
+		It("should fail if the image does not exist", func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodHead, "/v2/kubevirt/cirros/manifests/devel"),
+					ghttp.RespondWith(http.StatusNotFound, nil),
+				),
+			)
+			_, err := client.ResolveDigest(registryHost+"/kubevirt/cirros:devel", nil)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("404"))
+		})
+
+		It("should not contact the registry for pinned images", func() {
+			digest, err := client.ResolveDigest(registryHost+"/kubevirt/cirros@"+manifestDigest, nil)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(digest).To(Equal(manifestDigest))
+			Expect(server.ReceivedRequests()).To(BeEmpty())
+		})
+
+		Context("with signatures", func() {
+			var key *ecdsa.PrivateKey
+			var publicKeys []crypto.PublicKey
+
+			sign := func(payload []byte) string {
+				hash := sha256.Sum256(payload)
+				signature, err := key.Sign(rand.Reader, hash[:], crypto.SHA256)
+				Expect(err).ToNot(HaveOccurred())
+				return base64.StdEncoding.EncodeToString(signature)
+			}
+
+			payloadFor := func(digest string) []byte {
+				payload := SignaturePayload{}
+				payload.Critical.Image.DockerManifestDigest = digest
+				payload.Critical.Type = "cosign container image signature"
+				raw, err := json.Marshal(payload)
+				Expect(err).ToNot(HaveOccurred())
+				return raw
+			}
+
+			serveSignature := func(payload []byte, signature string) {
+				signatureManifest := fmt.Sprintf(`{"schemaVersion":2,"layers":[{"digest":"%s","annotations":{"%s":"%s"}}]}`,
+					Digest(payload), SignatureAnnotation, signature)
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodGet, "/v2/kubevirt/cirros/manifests/"+SignatureTag(manifestDigest)),
+						ghttp.RespondWith(http.StatusOK, signatureManifest),
+					),
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodGet, "/v2/kubevirt/cirros/blobs/"+Digest(payload)),
+						ghttp.RespondWith(http.StatusOK, payload),
+					),
+				)
+			}
+
+			BeforeEach(func() {
+				var err error
+				key, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+				Expect(err).ToNot(HaveOccurred())
+				der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+				Expect(err).ToNot(HaveOccurred())
+				publicKeys, err = ParsePublicKeys(string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})))
+				Expect(err).ToNot(HaveOccurred())
+				Expect(publicKeys).To(HaveLen(1))
+			})
+
+			It("should accept a valid signature", func() {
+				payload := payloadFor(manifestDigest)
+				serveSignature(payload, sign(payload))
+				Expect(client.VerifySignature(registryHost+"/kubevirt/cirros:devel", manifestDigest, nil, publicKeys)).To(Succeed())
+			})
+
+			It("should reject a signature of another key", func() {
+				payload := payloadFor(manifestDigest)
+				serveSignature(payload, sign(payload))
+				otherKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+				Expect(err).ToNot(HaveOccurred())
+				err = client.VerifySignature(registryHost+"/kubevirt/cirros:devel", manifestDigest, nil, []crypto.PublicKey{&otherKey.PublicKey})
+				Expect(err).To(HaveOccurred())
+			})
+
+			It("should reject a signature for another digest", func() {
+				payload := payloadFor(Digest([]byte("other")))
+				serveSignature(payload, sign(payload))
+				Expect(client.VerifySignature(registryHost+"/kubevirt/cirros:devel", manifestDigest, nil, publicKeys)).ToNot(Succeed())
+			})
+
+			It("should reject unsigned images", func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodGet, "/v2/kubevirt/cirros/manifests/"+SignatureTag(manifestDigest)),
+						ghttp.RespondWith(http.StatusNotFound, nil),
+					),
+				)
+				Expect(client.VerifySignature(registryHost+"/kubevirt/cirros:devel", manifestDigest, nil, publicKeys)).ToNot(Succeed())
+			})
+
+			It("should reject keys which are not PEM encoded", func() {
+				_, err := ParsePublicKeys("not a key")
+				Expect(err).To(HaveOccurred())
+			})
+		})
+	})
+
+	Context("with an image pull secret", func() {
+		newSecret := func(config string) *k8sv1.Secret {
+			return &k8sv1.Secret{
+				Type: k8sv1.SecretTypeDockerConfigJson,
+				Data: map[string][]byte{k8sv1.DockerConfigJsonKey: []byte(config)},
+			}
+		}
+		auth := base64.StdEncoding.EncodeToString([]byte("user:password"))
+
+		It("should find the credentials of the registry", func() {
+			secret := newSecret(fmt.Sprintf(`{"auths":{"quay.io":{"auth":"%s"},"registry:5000":{"username":"other","password":"secret"}}}`, auth))
+			credentials, err := CredentialsFromSecret(secret, "registry:5000/kubevirt/cirros:devel")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(credentials).To(Equal(&Credentials{Username: "other", Password: "secret"}))
+		})
+
+		It("should match the docker hub aliases", func() {
+			secret := newSecret(fmt.Sprintf(`{"auths":{"https://index.docker.io/v1/":{"auth":"%s"}}}`, auth))
+			credentials, err := CredentialsFromSecret(secret, "kubevirt/cirros")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(credentials).To(Equal(&Credentials{Username: "user", Password: "password"}))
+		})
+
+		It("should return no credentials for other registries", func() {
+			secret := newSecret(fmt.Sprintf(`{"auths":{"quay.io":{"auth":"%s"}}}`, auth))
+			credentials, err := CredentialsFromSecret(secret, "kubevirt/cirros")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(credentials).To(BeNil())
+		})
+	})
+})
@@ -0,0 +1,156 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2020 Red Hat, Inc.
+ *
+ */
+
+package registry
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// SignatureAnnotation holds the base64 encoded signature of a signature layer, like cosign stores it
+const SignatureAnnotation = "dev.cosignproject.cosign/signature"
+
+type signatureManifest struct {
+	Layers []struct {
+		Digest      string            `json:"digest"`
+		Annotations map[string]string `json:"annotations,omitempty"`
+	} `json:"layers"`
+}
+
+// SignaturePayload is the signed document, it binds the signature to a manifest digest
+type SignaturePayload struct {
+	Critical struct {
+		Identity struct {
+			DockerReference string `json:"docker-reference"`
+		} `json:"identity"`
+		Image struct {
+			DockerManifestDigest string `json:"docker-manifest-digest"`
+		} `json:"image"`
+		Type string `json:"type"`
+	} `json:"critical"`
+}
+
+// SignatureTag returns the tag under which the signatures of a digest are stored in the repository
+func SignatureTag(digest string) string {
+	return strings.Replace(digest, ":", "-", 1) + ".sig"
+}
+
+// ParsePublicKeys parses PEM encoded ECDSA and RSA public keys
+func ParsePublicKeys(data string) ([]crypto.PublicKey, error) {
+	var keys []crypto.PublicKey
+	rest := []byte(data)
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		key, err := x509.ParsePKIXPublicKey(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse public key: %v", err)
+		}
+		switch key.(type) {
+		case *ecdsa.PublicKey, *rsa.PublicKey:
+			keys = append(keys, key)
+		default:
+			return nil, fmt.Errorf("unsupported public key type %T", key)
+		}
+	}
+	if strings.TrimSpace(string(rest)) != "" {
+		return nil, fmt.Errorf("public keys contain data which is not PEM encoded")
+	}
+	return keys, nil
+}
+
+// VerifySignature checks that the repository of the image contains a signature for the given
+// manifest digest which was created with one of the keys.
+func (c *Client) VerifySignature(image string, digest string, credentials *Credentials, keys []crypto.PublicKey) error {
+	ref, err := ParseReference(image)
+	if err != nil {
+		return err
+	}
+
+	raw, err := c.GetManifest(ref, SignatureTag(digest), credentials)
+	if err != nil {
+		return fmt.Errorf("failed to fetch signatures of %s: %v", digest, err)
+	}
+	manifest := signatureManifest{}
+	if err := json.Unmarshal(raw, &manifest); err != nil {
+		return fmt.Errorf("failed to parse signatures of %s: %v", digest, err)
+	}
+
+	for _, layer := range manifest.Layers {
+		signature, err := base64.StdEncoding.DecodeString(layer.Annotations[SignatureAnnotation])
+		if err != nil || len(signature) == 0 {
+			continue
+		}
+		payload, err := c.GetBlob(ref, layer.Digest, credentials)
+		if err != nil {
+			return fmt.Errorf("failed to fetch signature payload of %s: %v", digest, err)
+		}
+		if VerifyPayload(payload, signature, digest, keys) == nil {
+			return nil
+		}
+	}
+	return fmt.Errorf("no valid signature found for %s", digest)
+}
+
+// VerifyPayload checks that the payload refers to the digest and that the signature
+// of the payload was created with one of the keys.
+func VerifyPayload(payload []byte, signature []byte, digest string, keys []crypto.PublicKey) error {
+	signed := SignaturePayload{}
+	if err := json.Unmarshal(payload, &signed); err != nil {
+		return fmt.Errorf("failed to parse signature payload: %v", err)
+	}
+	if signed.Critical.Image.DockerManifestDigest != digest {
+		return fmt.Errorf("signature payload is for %s, not %s", signed.Critical.Image.DockerManifestDigest, digest)
+	}
+
+	hash := sha256.Sum256(payload)
+	for _, key := range keys {
+		switch key := key.(type) {
+		case *ecdsa.PublicKey:
+			ecdsaSignature := struct {
+				R, S *big.Int
+			}{}
+			if _, err := asn1.Unmarshal(signature, &ecdsaSignature); err != nil {
+				continue
+			}
+			if ecdsa.Verify(key, hash[:], ecdsaSignature.R, ecdsaSignature.S) {
+				return nil
+			}
+		case *rsa.PublicKey:
+			if rsa.VerifyPKCS1v15(key, crypto.SHA256, hash[:], signature) == nil {
+				return nil
+			}
+		}
+	}
+	return fmt.Errorf("signature does not match any of the public keys")
+}
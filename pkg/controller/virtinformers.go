@@ -81,6 +81,18 @@ type KubeInformerFactory interface {
 	// Watches VirtualMachineInstanceMigration objects
 	VirtualMachineInstanceMigration() cache.SharedIndexInformer
 
+	// Watches the cluster scoped ContainerDiskImageCache objects
+	ContainerDiskImageCache() cache.SharedIndexInformer
+
+	// Watches the DaemonSets which pull the images of ContainerDiskImageCaches
+	ContainerDiskImageCacheDaemonSet() cache.SharedIndexInformer
+
+	// Watches VirtualMachineBackup objects
+	VirtualMachineBackup() cache.SharedIndexInformer
+
+	// Watches VirtualMachineExport objects
+	VirtualMachineExport() cache.SharedIndexInformer
+
 	// Watches for k8s extensions api configmap
 	ApiAuthConfigMap() cache.SharedIndexInformer
 
@@ -159,6 +171,9 @@ type KubeInformerFactory interface {
 	// Fake ServiceMonitor informer used when Prometheus is not installed
 	DummyOperatorServiceMonitor() cache.SharedIndexInformer
 
+	// Certificate secrets created/managed by virt operator
+	OperatorSecrets() cache.SharedIndexInformer
+
 	K8SInformerFactory() informers.SharedInformerFactory
 }
 
@@ -257,6 +272,39 @@ func (f *kubeInformerFactory) VirtualMachineInstanceMigration() cache.SharedInde
 	})
 }
 
+func (f *kubeInformerFactory) ContainerDiskImageCache() cache.SharedIndexInformer {
+	return f.getInformer("containerDiskImageCacheInformer", func() cache.SharedIndexInformer {
+		lw := cache.NewListWatchFromClient(f.restClient, "containerdiskimagecaches", k8sv1.NamespaceAll, fields.Everything())
+		return cache.NewSharedIndexInformer(lw, &kubev1.ContainerDiskImageCache{}, f.defaultResync, cache.Indexers{})
+	})
+}
+
+func (f *kubeInformerFactory) ContainerDiskImageCacheDaemonSet() cache.SharedIndexInformer {
+	return f.getInformer("containerDiskImageCacheDaemonSetInformer", func() cache.SharedIndexInformer {
+		labelSelector, err := labels.Parse(kubev1.ContainerDiskImageCacheLabel)
+		if err != nil {
+			panic(err)
+		}
+
+		lw := NewListWatchFromClient(f.clientSet.AppsV1().RESTClient(), "daemonsets", f.kubevirtNamespace, fields.Everything(), labelSelector)
+		return cache.NewSharedIndexInformer(lw, &appsv1.DaemonSet{}, f.defaultResync, cache.Indexers{})
+	})
+}
+
+func (f *kubeInformerFactory) VirtualMachineBackup() cache.SharedIndexInformer {
+	return f.getInformer("vmBackupInformer", func() cache.SharedIndexInformer {
+		lw := cache.NewListWatchFromClient(f.restClient, "virtualmachinebackups", k8sv1.NamespaceAll, fields.Everything())
+		return cache.NewSharedIndexInformer(lw, &kubev1.VirtualMachineBackup{}, f.defaultResync, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	})
+}
+
+func (f *kubeInformerFactory) VirtualMachineExport() cache.SharedIndexInformer {
+	return f.getInformer("vmExportInformer", func() cache.SharedIndexInformer {
+		lw := cache.NewListWatchFromClient(f.restClient, "virtualmachineexports", k8sv1.NamespaceAll, fields.Everything())
+		return cache.NewSharedIndexInformer(lw, &kubev1.VirtualMachineExport{}, f.defaultResync, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	})
+}
+
 func (f *kubeInformerFactory) KubeVirtPod() cache.SharedIndexInformer {
 	return f.getInformer("kubeVirtPodInformer", func() cache.SharedIndexInformer {
 		// Watch all pods with the kubevirt app label
@@ -544,6 +592,18 @@ func (f *kubeInformerFactory) OperatorServiceMonitor() cache.SharedIndexInformer
 	})
 }
 
+func (f *kubeInformerFactory) OperatorSecrets() cache.SharedIndexInformer {
+	return f.getInformer("operatorSecretsInformer", func() cache.SharedIndexInformer {
+		labelSelector, err := labels.Parse(OperatorLabel)
+		if err != nil {
+			panic(err)
+		}
+
+		lw := NewListWatchFromClient(f.clientSet.CoreV1().RESTClient(), "secrets", k8sv1.NamespaceAll, fields.Everything(), labelSelector)
+		return cache.NewSharedIndexInformer(lw, &k8sv1.Secret{}, f.defaultResync, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	})
+}
+
 func (f *kubeInformerFactory) DummyOperatorServiceMonitor() cache.SharedIndexInformer {
 	return f.getInformer("FakeOperatorServiceMonitor", func() cache.SharedIndexInformer {
 		informer, _ := testutils.NewFakeInformerFor(&promv1.ServiceMonitor{})
@@ -17,6 +17,15 @@ It has these top-level messages:
 	Response
 	DomainResponse
 	DomainStatsResponse
+	ScreenshotResponse
+	GuestExecRequest
+	GuestInfoResponse
+	GuestUserListResponse
+	GuestFilesystemListResponse
+	GuestExecResponse
+	MemoryDumpRequest
+	ResizeDisksResponse
+	BackupRequest
 */
 package v1
 
@@ -251,6 +260,222 @@ func (m *DomainStatsResponse) GetDomainStats() string {
 	return ""
 }
 
+type ScreenshotResponse struct {
+	Response *Response `protobuf:"bytes,1,opt,name=response" json:"response,omitempty"`
+	Png      []byte    `protobuf:"bytes,2,opt,name=png,proto3" json:"png,omitempty"`
+}
+
+func (m *ScreenshotResponse) Reset()                    { *m = ScreenshotResponse{} }
+func (m *ScreenshotResponse) String() string            { return proto.CompactTextString(m) }
+func (*ScreenshotResponse) ProtoMessage()               {}
+func (*ScreenshotResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{9} }
+
+func (m *ScreenshotResponse) GetResponse() *Response {
+	if m != nil {
+		return m.Response
+	}
+	return nil
+}
+
+func (m *ScreenshotResponse) GetPng() []byte {
+	if m != nil {
+		return m.Png
+	}
+	return nil
+}
+
+type GuestExecRequest struct {
+	Vmi         *VMI   `protobuf:"bytes,1,opt,name=vmi" json:"vmi,omitempty"`
+	ExecRequest []byte `protobuf:"bytes,2,opt,name=execRequest,proto3" json:"execRequest,omitempty"`
+}
+
+func (m *GuestExecRequest) Reset()                    { *m = GuestExecRequest{} }
+func (m *GuestExecRequest) String() string            { return proto.CompactTextString(m) }
+func (*GuestExecRequest) ProtoMessage()               {}
+func (*GuestExecRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{10} }
+
+func (m *GuestExecRequest) GetVmi() *VMI {
+	if m != nil {
+		return m.Vmi
+	}
+	return nil
+}
+
+func (m *GuestExecRequest) GetExecRequest() []byte {
+	if m != nil {
+		return m.ExecRequest
+	}
+	return nil
+}
+
+type GuestInfoResponse struct {
+	Response          *Response `protobuf:"bytes,1,opt,name=response" json:"response,omitempty"`
+	GuestInfoResponse string    `protobuf:"bytes,2,opt,name=guestInfoResponse,proto3" json:"guestInfoResponse,omitempty"`
+}
+
+func (m *GuestInfoResponse) Reset()                    { *m = GuestInfoResponse{} }
+func (m *GuestInfoResponse) String() string            { return proto.CompactTextString(m) }
+func (*GuestInfoResponse) ProtoMessage()               {}
+func (*GuestInfoResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{11} }
+
+func (m *GuestInfoResponse) GetResponse() *Response {
+	if m != nil {
+		return m.Response
+	}
+	return nil
+}
+
+func (m *GuestInfoResponse) GetGuestInfoResponse() string {
+	if m != nil {
+		return m.GuestInfoResponse
+	}
+	return ""
+}
+
+type GuestUserListResponse struct {
+	Response              *Response `protobuf:"bytes,1,opt,name=response" json:"response,omitempty"`
+	GuestUserListResponse string    `protobuf:"bytes,2,opt,name=guestUserListResponse,proto3" json:"guestUserListResponse,omitempty"`
+}
+
+func (m *GuestUserListResponse) Reset()                    { *m = GuestUserListResponse{} }
+func (m *GuestUserListResponse) String() string            { return proto.CompactTextString(m) }
+func (*GuestUserListResponse) ProtoMessage()               {}
+func (*GuestUserListResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{12} }
+
+func (m *GuestUserListResponse) GetResponse() *Response {
+	if m != nil {
+		return m.Response
+	}
+	return nil
+}
+
+func (m *GuestUserListResponse) GetGuestUserListResponse() string {
+	if m != nil {
+		return m.GuestUserListResponse
+	}
+	return ""
+}
+
+type GuestFilesystemListResponse struct {
+	Response                    *Response `protobuf:"bytes,1,opt,name=response" json:"response,omitempty"`
+	GuestFilesystemListResponse string    `protobuf:"bytes,2,opt,name=guestFilesystemListResponse,proto3" json:"guestFilesystemListResponse,omitempty"`
+}
+
+func (m *GuestFilesystemListResponse) Reset()                    { *m = GuestFilesystemListResponse{} }
+func (m *GuestFilesystemListResponse) String() string            { return proto.CompactTextString(m) }
+func (*GuestFilesystemListResponse) ProtoMessage()               {}
+func (*GuestFilesystemListResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{13} }
+
+func (m *GuestFilesystemListResponse) GetResponse() *Response {
+	if m != nil {
+		return m.Response
+	}
+	return nil
+}
+
+func (m *GuestFilesystemListResponse) GetGuestFilesystemListResponse() string {
+	if m != nil {
+		return m.GuestFilesystemListResponse
+	}
+	return ""
+}
+
+type GuestExecResponse struct {
+	Response          *Response `protobuf:"bytes,1,opt,name=response" json:"response,omitempty"`
+	GuestExecResponse string    `protobuf:"bytes,2,opt,name=guestExecResponse,proto3" json:"guestExecResponse,omitempty"`
+}
+
+func (m *GuestExecResponse) Reset()                    { *m = GuestExecResponse{} }
+func (m *GuestExecResponse) String() string            { return proto.CompactTextString(m) }
+func (*GuestExecResponse) ProtoMessage()               {}
+func (*GuestExecResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{14} }
+
+func (m *GuestExecResponse) GetResponse() *Response {
+	if m != nil {
+		return m.Response
+	}
+	return nil
+}
+
+func (m *GuestExecResponse) GetGuestExecResponse() string {
+	if m != nil {
+		return m.GuestExecResponse
+	}
+	return ""
+}
+
+type MemoryDumpRequest struct {
+	Vmi      *VMI   `protobuf:"bytes,1,opt,name=vmi" json:"vmi,omitempty"`
+	FileName string `protobuf:"bytes,2,opt,name=fileName,proto3" json:"fileName,omitempty"`
+}
+
+func (m *MemoryDumpRequest) Reset()                    { *m = MemoryDumpRequest{} }
+func (m *MemoryDumpRequest) String() string            { return proto.CompactTextString(m) }
+func (*MemoryDumpRequest) ProtoMessage()               {}
+func (*MemoryDumpRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{15} }
+
+func (m *MemoryDumpRequest) GetVmi() *VMI {
+	if m != nil {
+		return m.Vmi
+	}
+	return nil
+}
+
+func (m *MemoryDumpRequest) GetFileName() string {
+	if m != nil {
+		return m.FileName
+	}
+	return ""
+}
+
+type ResizeDisksResponse struct {
+	Response       *Response `protobuf:"bytes,1,opt,name=response" json:"response,omitempty"`
+	ResizedVolumes []string  `protobuf:"bytes,2,rep,name=resizedVolumes" json:"resizedVolumes,omitempty"`
+}
+
+func (m *ResizeDisksResponse) Reset()                    { *m = ResizeDisksResponse{} }
+func (m *ResizeDisksResponse) String() string            { return proto.CompactTextString(m) }
+func (*ResizeDisksResponse) ProtoMessage()               {}
+func (*ResizeDisksResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{16} }
+
+func (m *ResizeDisksResponse) GetResponse() *Response {
+	if m != nil {
+		return m.Response
+	}
+	return nil
+}
+
+func (m *ResizeDisksResponse) GetResizedVolumes() []string {
+	if m != nil {
+		return m.ResizedVolumes
+	}
+	return nil
+}
+
+type BackupRequest struct {
+	Vmi     *VMI   `protobuf:"bytes,1,opt,name=vmi" json:"vmi,omitempty"`
+	Options []byte `protobuf:"bytes,2,opt,name=options,proto3" json:"options,omitempty"`
+}
+
+func (m *BackupRequest) Reset()                    { *m = BackupRequest{} }
+func (m *BackupRequest) String() string            { return proto.CompactTextString(m) }
+func (*BackupRequest) ProtoMessage()               {}
+func (*BackupRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{17} }
+
+func (m *BackupRequest) GetVmi() *VMI {
+	if m != nil {
+		return m.Vmi
+	}
+	return nil
+}
+
+func (m *BackupRequest) GetOptions() []byte {
+	if m != nil {
+		return m.Options
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*VMI)(nil), "kubevirt.cmd.v1.VMI")
 	proto.RegisterType((*SMBios)(nil), "kubevirt.cmd.v1.SMBios")
@@ -261,6 +486,15 @@ func init() {
 	proto.RegisterType((*Response)(nil), "kubevirt.cmd.v1.Response")
 	proto.RegisterType((*DomainResponse)(nil), "kubevirt.cmd.v1.DomainResponse")
 	proto.RegisterType((*DomainStatsResponse)(nil), "kubevirt.cmd.v1.DomainStatsResponse")
+	proto.RegisterType((*ScreenshotResponse)(nil), "kubevirt.cmd.v1.ScreenshotResponse")
+	proto.RegisterType((*GuestExecRequest)(nil), "kubevirt.cmd.v1.GuestExecRequest")
+	proto.RegisterType((*GuestInfoResponse)(nil), "kubevirt.cmd.v1.GuestInfoResponse")
+	proto.RegisterType((*GuestUserListResponse)(nil), "kubevirt.cmd.v1.GuestUserListResponse")
+	proto.RegisterType((*GuestFilesystemListResponse)(nil), "kubevirt.cmd.v1.GuestFilesystemListResponse")
+	proto.RegisterType((*GuestExecResponse)(nil), "kubevirt.cmd.v1.GuestExecResponse")
+	proto.RegisterType((*MemoryDumpRequest)(nil), "kubevirt.cmd.v1.MemoryDumpRequest")
+	proto.RegisterType((*ResizeDisksResponse)(nil), "kubevirt.cmd.v1.ResizeDisksResponse")
+	proto.RegisterType((*BackupRequest)(nil), "kubevirt.cmd.v1.BackupRequest")
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -285,6 +519,17 @@ type CmdClient interface {
 	CancelVirtualMachineMigration(ctx context.Context, in *VMIRequest, opts ...grpc.CallOption) (*Response, error)
 	GetDomain(ctx context.Context, in *EmptyRequest, opts ...grpc.CallOption) (*DomainResponse, error)
 	GetDomainStats(ctx context.Context, in *EmptyRequest, opts ...grpc.CallOption) (*DomainStatsResponse, error)
+	GetScreenshot(ctx context.Context, in *VMIRequest, opts ...grpc.CallOption) (*ScreenshotResponse, error)
+	GetGuestInfo(ctx context.Context, in *VMIRequest, opts ...grpc.CallOption) (*GuestInfoResponse, error)
+	GetUsers(ctx context.Context, in *VMIRequest, opts ...grpc.CallOption) (*GuestUserListResponse, error)
+	GetFilesystems(ctx context.Context, in *VMIRequest, opts ...grpc.CallOption) (*GuestFilesystemListResponse, error)
+	GuestExec(ctx context.Context, in *GuestExecRequest, opts ...grpc.CallOption) (*GuestExecResponse, error)
+	GuestPing(ctx context.Context, in *VMIRequest, opts ...grpc.CallOption) (*Response, error)
+	SoftRebootVirtualMachine(ctx context.Context, in *VMIRequest, opts ...grpc.CallOption) (*Response, error)
+	MemoryDumpVirtualMachine(ctx context.Context, in *MemoryDumpRequest, opts ...grpc.CallOption) (*Response, error)
+	ResizeVirtualMachineDisks(ctx context.Context, in *VMIRequest, opts ...grpc.CallOption) (*ResizeDisksResponse, error)
+	BackupVirtualMachine(ctx context.Context, in *BackupRequest, opts ...grpc.CallOption) (*Response, error)
+	FinishVirtualMachineBackup(ctx context.Context, in *BackupRequest, opts ...grpc.CallOption) (*Response, error)
 	Ping(ctx context.Context, in *EmptyRequest, opts ...grpc.CallOption) (*Response, error)
 }
 
@@ -395,6 +640,105 @@ func (c *cmdClient) GetDomainStats(ctx context.Context, in *EmptyRequest, opts .
 	return out, nil
 }
 
+func (c *cmdClient) GetScreenshot(ctx context.Context, in *VMIRequest, opts ...grpc.CallOption) (*ScreenshotResponse, error) {
+	out := new(ScreenshotResponse)
+	err := grpc.Invoke(ctx, "/kubevirt.cmd.v1.Cmd/GetScreenshot", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cmdClient) GetGuestInfo(ctx context.Context, in *VMIRequest, opts ...grpc.CallOption) (*GuestInfoResponse, error) {
+	out := new(GuestInfoResponse)
+	err := grpc.Invoke(ctx, "/kubevirt.cmd.v1.Cmd/GetGuestInfo", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cmdClient) GetUsers(ctx context.Context, in *VMIRequest, opts ...grpc.CallOption) (*GuestUserListResponse, error) {
+	out := new(GuestUserListResponse)
+	err := grpc.Invoke(ctx, "/kubevirt.cmd.v1.Cmd/GetUsers", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cmdClient) GetFilesystems(ctx context.Context, in *VMIRequest, opts ...grpc.CallOption) (*GuestFilesystemListResponse, error) {
+	out := new(GuestFilesystemListResponse)
+	err := grpc.Invoke(ctx, "/kubevirt.cmd.v1.Cmd/GetFilesystems", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cmdClient) GuestExec(ctx context.Context, in *GuestExecRequest, opts ...grpc.CallOption) (*GuestExecResponse, error) {
+	out := new(GuestExecResponse)
+	err := grpc.Invoke(ctx, "/kubevirt.cmd.v1.Cmd/GuestExec", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cmdClient) GuestPing(ctx context.Context, in *VMIRequest, opts ...grpc.CallOption) (*Response, error) {
+	out := new(Response)
+	err := grpc.Invoke(ctx, "/kubevirt.cmd.v1.Cmd/GuestPing", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cmdClient) SoftRebootVirtualMachine(ctx context.Context, in *VMIRequest, opts ...grpc.CallOption) (*Response, error) {
+	out := new(Response)
+	err := grpc.Invoke(ctx, "/kubevirt.cmd.v1.Cmd/SoftRebootVirtualMachine", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cmdClient) MemoryDumpVirtualMachine(ctx context.Context, in *MemoryDumpRequest, opts ...grpc.CallOption) (*Response, error) {
+	out := new(Response)
+	err := grpc.Invoke(ctx, "/kubevirt.cmd.v1.Cmd/MemoryDumpVirtualMachine", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cmdClient) ResizeVirtualMachineDisks(ctx context.Context, in *VMIRequest, opts ...grpc.CallOption) (*ResizeDisksResponse, error) {
+	out := new(ResizeDisksResponse)
+	err := grpc.Invoke(ctx, "/kubevirt.cmd.v1.Cmd/ResizeVirtualMachineDisks", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cmdClient) BackupVirtualMachine(ctx context.Context, in *BackupRequest, opts ...grpc.CallOption) (*Response, error) {
+	out := new(Response)
+	err := grpc.Invoke(ctx, "/kubevirt.cmd.v1.Cmd/BackupVirtualMachine", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cmdClient) FinishVirtualMachineBackup(ctx context.Context, in *BackupRequest, opts ...grpc.CallOption) (*Response, error) {
+	out := new(Response)
+	err := grpc.Invoke(ctx, "/kubevirt.cmd.v1.Cmd/FinishVirtualMachineBackup", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *cmdClient) Ping(ctx context.Context, in *EmptyRequest, opts ...grpc.CallOption) (*Response, error) {
 	out := new(Response)
 	err := grpc.Invoke(ctx, "/kubevirt.cmd.v1.Cmd/Ping", in, out, c.cc, opts...)
@@ -418,6 +762,17 @@ type CmdServer interface {
 	CancelVirtualMachineMigration(context.Context, *VMIRequest) (*Response, error)
 	GetDomain(context.Context, *EmptyRequest) (*DomainResponse, error)
 	GetDomainStats(context.Context, *EmptyRequest) (*DomainStatsResponse, error)
+	GetScreenshot(context.Context, *VMIRequest) (*ScreenshotResponse, error)
+	GetGuestInfo(context.Context, *VMIRequest) (*GuestInfoResponse, error)
+	GetUsers(context.Context, *VMIRequest) (*GuestUserListResponse, error)
+	GetFilesystems(context.Context, *VMIRequest) (*GuestFilesystemListResponse, error)
+	GuestExec(context.Context, *GuestExecRequest) (*GuestExecResponse, error)
+	GuestPing(context.Context, *VMIRequest) (*Response, error)
+	SoftRebootVirtualMachine(context.Context, *VMIRequest) (*Response, error)
+	MemoryDumpVirtualMachine(context.Context, *MemoryDumpRequest) (*Response, error)
+	ResizeVirtualMachineDisks(context.Context, *VMIRequest) (*ResizeDisksResponse, error)
+	BackupVirtualMachine(context.Context, *BackupRequest) (*Response, error)
+	FinishVirtualMachineBackup(context.Context, *BackupRequest) (*Response, error)
 	Ping(context.Context, *EmptyRequest) (*Response, error)
 }
 
@@ -623,6 +978,204 @@ func _Cmd_GetDomainStats_Handler(srv interface{}, ctx context.Context, dec func(
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Cmd_GetScreenshot_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VMIRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CmdServer).GetScreenshot(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/kubevirt.cmd.v1.Cmd/GetScreenshot",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CmdServer).GetScreenshot(ctx, req.(*VMIRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Cmd_GetGuestInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VMIRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CmdServer).GetGuestInfo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/kubevirt.cmd.v1.Cmd/GetGuestInfo",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CmdServer).GetGuestInfo(ctx, req.(*VMIRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Cmd_GetUsers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VMIRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CmdServer).GetUsers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/kubevirt.cmd.v1.Cmd/GetUsers",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CmdServer).GetUsers(ctx, req.(*VMIRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Cmd_GetFilesystems_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VMIRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CmdServer).GetFilesystems(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/kubevirt.cmd.v1.Cmd/GetFilesystems",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CmdServer).GetFilesystems(ctx, req.(*VMIRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Cmd_GuestExec_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GuestExecRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CmdServer).GuestExec(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/kubevirt.cmd.v1.Cmd/GuestExec",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CmdServer).GuestExec(ctx, req.(*GuestExecRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Cmd_GuestPing_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VMIRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CmdServer).GuestPing(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/kubevirt.cmd.v1.Cmd/GuestPing",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CmdServer).GuestPing(ctx, req.(*VMIRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Cmd_SoftRebootVirtualMachine_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VMIRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CmdServer).SoftRebootVirtualMachine(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/kubevirt.cmd.v1.Cmd/SoftRebootVirtualMachine",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CmdServer).SoftRebootVirtualMachine(ctx, req.(*VMIRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Cmd_MemoryDumpVirtualMachine_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MemoryDumpRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CmdServer).MemoryDumpVirtualMachine(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/kubevirt.cmd.v1.Cmd/MemoryDumpVirtualMachine",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CmdServer).MemoryDumpVirtualMachine(ctx, req.(*MemoryDumpRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Cmd_ResizeVirtualMachineDisks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VMIRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CmdServer).ResizeVirtualMachineDisks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/kubevirt.cmd.v1.Cmd/ResizeVirtualMachineDisks",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CmdServer).ResizeVirtualMachineDisks(ctx, req.(*VMIRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Cmd_BackupVirtualMachine_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BackupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CmdServer).BackupVirtualMachine(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/kubevirt.cmd.v1.Cmd/BackupVirtualMachine",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CmdServer).BackupVirtualMachine(ctx, req.(*BackupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Cmd_FinishVirtualMachineBackup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BackupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CmdServer).FinishVirtualMachineBackup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/kubevirt.cmd.v1.Cmd/FinishVirtualMachineBackup",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CmdServer).FinishVirtualMachineBackup(ctx, req.(*BackupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Cmd_Ping_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(EmptyRequest)
 	if err := dec(in); err != nil {
@@ -689,6 +1242,50 @@ var _Cmd_serviceDesc = grpc.ServiceDesc{
 			MethodName: "GetDomainStats",
 			Handler:    _Cmd_GetDomainStats_Handler,
 		},
+		{
+			MethodName: "GetScreenshot",
+			Handler:    _Cmd_GetScreenshot_Handler,
+		},
+		{
+			MethodName: "GetGuestInfo",
+			Handler:    _Cmd_GetGuestInfo_Handler,
+		},
+		{
+			MethodName: "GetUsers",
+			Handler:    _Cmd_GetUsers_Handler,
+		},
+		{
+			MethodName: "GetFilesystems",
+			Handler:    _Cmd_GetFilesystems_Handler,
+		},
+		{
+			MethodName: "GuestExec",
+			Handler:    _Cmd_GuestExec_Handler,
+		},
+		{
+			MethodName: "GuestPing",
+			Handler:    _Cmd_GuestPing_Handler,
+		},
+		{
+			MethodName: "SoftRebootVirtualMachine",
+			Handler:    _Cmd_SoftRebootVirtualMachine_Handler,
+		},
+		{
+			MethodName: "MemoryDumpVirtualMachine",
+			Handler:    _Cmd_MemoryDumpVirtualMachine_Handler,
+		},
+		{
+			MethodName: "ResizeVirtualMachineDisks",
+			Handler:    _Cmd_ResizeVirtualMachineDisks_Handler,
+		},
+		{
+			MethodName: "BackupVirtualMachine",
+			Handler:    _Cmd_BackupVirtualMachine_Handler,
+		},
+		{
+			MethodName: "FinishVirtualMachineBackup",
+			Handler:    _Cmd_FinishVirtualMachineBackup_Handler,
+		},
 		{
 			MethodName: "Ping",
 			Handler:    _Cmd_Ping_Handler,
@@ -701,42 +1298,63 @@ var _Cmd_serviceDesc = grpc.ServiceDesc{
 func init() { proto.RegisterFile("pkg/handler-launcher-com/cmd/v1/cmd.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 578 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x95, 0x5d, 0x6f, 0xda, 0x3c,
-	0x14, 0xc7, 0x4b, 0xe1, 0x01, 0x7a, 0x40, 0x3c, 0x95, 0x0b, 0x5d, 0xd6, 0xa9, 0x6a, 0x17, 0x4d,
-	0xd5, 0x76, 0x51, 0x10, 0x4c, 0xbb, 0x9d, 0x26, 0xda, 0x69, 0xea, 0xaa, 0xb4, 0x2c, 0x74, 0x4c,
-	0xdb, 0xcd, 0xe4, 0x26, 0x2e, 0x58, 0x24, 0x76, 0x66, 0x3b, 0x99, 0xf8, 0x0a, 0xfb, 0x94, 0xfb,
-	0x28, 0x53, 0xde, 0x68, 0x43, 0xe8, 0xd0, 0x04, 0x57, 0xe4, 0xbc, 0xfd, 0xce, 0xdf, 0xc7, 0x9c,
-	0x04, 0x5e, 0x79, 0xd3, 0x71, 0x67, 0x82, 0x99, 0xed, 0x10, 0x71, 0xea, 0x60, 0x9f, 0x59, 0x13,
-	0x22, 0x4e, 0x2d, 0xee, 0x76, 0x2c, 0xd7, 0xee, 0x04, 0xdd, 0xf0, 0xa7, 0xed, 0x09, 0xae, 0x38,
-	0xfa, 0x7f, 0xea, 0xdf, 0x92, 0x80, 0x0a, 0xd5, 0x0e, 0x7d, 0x41, 0x57, 0x3f, 0x82, 0xe2, 0xc8,
-	0xb8, 0x40, 0x1a, 0x54, 0x02, 0x97, 0x7e, 0x94, 0x9c, 0x69, 0x85, 0xe3, 0xc2, 0xcb, 0xba, 0x99,
-	0x9a, 0xfa, 0xaf, 0x02, 0x94, 0x87, 0x46, 0x9f, 0x72, 0x89, 0x74, 0xa8, 0xbb, 0x98, 0xf9, 0x77,
-	0xd8, 0x52, 0xbe, 0x20, 0x22, 0xca, 0xdc, 0x31, 0x33, 0xbe, 0x10, 0xe4, 0x09, 0x6e, 0xfb, 0x96,
-	0xd2, 0xb6, 0xa3, 0x70, 0x6a, 0x46, 0x2d, 0x88, 0x90, 0x94, 0x33, 0xad, 0x18, 0x47, 0x12, 0x13,
-	0xed, 0x42, 0x51, 0x4e, 0x7d, 0xad, 0x14, 0x79, 0xc3, 0x47, 0xb4, 0x0f, 0xe5, 0x3b, 0xec, 0x52,
-	0x67, 0xa6, 0xfd, 0x17, 0x39, 0x13, 0x4b, 0xb7, 0xa1, 0x35, 0xa2, 0x42, 0xf9, 0xd8, 0x31, 0xb0,
-	0x35, 0xa1, 0x8c, 0x5c, 0x7b, 0x8a, 0x72, 0x26, 0xd1, 0x25, 0x34, 0xb3, 0x81, 0x58, 0x72, 0x24,
-	0xb1, 0xd6, 0x7b, 0xd2, 0x5e, 0x38, 0x76, 0x3b, 0x0e, 0x9b, 0x4b, 0x8b, 0xf4, 0x00, 0x60, 0x64,
-	0x5c, 0x98, 0xe4, 0x87, 0x4f, 0xa4, 0x42, 0x27, 0x50, 0x0c, 0x5c, 0x9a, 0x90, 0x9a, 0x39, 0x52,
-	0x98, 0x19, 0x26, 0xa0, 0x77, 0x50, 0xe1, 0xb1, 0x9a, 0xe8, 0xe4, 0xb5, 0xde, 0x49, 0x3e, 0x77,
-	0x99, 0x76, 0x33, 0x2d, 0xd3, 0x6f, 0x60, 0xd7, 0xa0, 0x63, 0x81, 0x43, 0xeb, 0x5f, 0xbb, 0x6b,
-	0xd9, 0xee, 0xf5, 0x7b, 0x6a, 0x03, 0xea, 0xef, 0x5d, 0x4f, 0xcd, 0x12, 0xa2, 0xfe, 0x16, 0xaa,
-	0x26, 0x91, 0x1e, 0x67, 0x92, 0x84, 0x55, 0xd2, 0xb7, 0x2c, 0x22, 0xe3, 0x49, 0x55, 0xcd, 0xd4,
-	0x0c, 0x23, 0x2e, 0x91, 0x12, 0x8f, 0x49, 0x7a, 0x8f, 0x89, 0xa9, 0x7f, 0x87, 0xc6, 0x39, 0x77,
-	0x31, 0x65, 0x73, 0xca, 0x1b, 0xa8, 0x8a, 0xe4, 0x39, 0x11, 0xfa, 0x34, 0x27, 0x34, 0x4d, 0x36,
-	0xe7, 0xa9, 0xe1, 0x25, 0xdb, 0x11, 0x28, 0xe9, 0x90, 0x58, 0x3a, 0x83, 0xbd, 0xb8, 0xc1, 0x50,
-	0x61, 0x25, 0xd7, 0xed, 0x72, 0x0c, 0x35, 0xfb, 0x9e, 0x96, 0xb4, 0x7a, 0xe8, 0xea, 0xfd, 0xae,
-	0x40, 0xf1, 0xcc, 0xb5, 0xd1, 0x15, 0xa0, 0xe1, 0x8c, 0x59, 0xd9, 0x4b, 0x42, 0xcf, 0x96, 0xce,
-	0x3c, 0x9e, 0xe5, 0xc1, 0xe3, 0x0a, 0xf4, 0x2d, 0x74, 0x0d, 0x7b, 0x03, 0xec, 0x4b, 0xb2, 0x31,
-	0xe0, 0x27, 0x68, 0x7d, 0x66, 0xde, 0x46, 0x91, 0x26, 0xec, 0x0f, 0x27, 0xbe, 0xb2, 0xf9, 0x4f,
-	0xb6, 0x31, 0xe6, 0x15, 0xa0, 0x4b, 0xea, 0x38, 0x1b, 0xe3, 0x0d, 0xa0, 0x79, 0x4e, 0x1c, 0xa2,
-	0x36, 0x77, 0xea, 0x2f, 0xd0, 0x8a, 0x17, 0x6d, 0x11, 0xf9, 0x3c, 0x57, 0xb5, 0xb8, 0x90, 0x2b,
-	0xaf, 0x3c, 0xfc, 0x0b, 0xcd, 0x8b, 0x6e, 0xb0, 0x18, 0x13, 0xb5, 0x86, 0xd2, 0xaf, 0x70, 0x78,
-	0x86, 0x99, 0x45, 0x16, 0xa6, 0x39, 0x6f, 0xb0, 0x06, 0xda, 0x80, 0x9d, 0x0f, 0x44, 0xc5, 0x9b,
-	0x86, 0x0e, 0x73, 0x99, 0x0f, 0xdf, 0x19, 0x07, 0x47, 0xb9, 0x70, 0xf6, 0x15, 0x10, 0xcd, 0xb4,
-	0x31, 0xc7, 0x45, 0x7b, 0xb5, 0x8a, 0xf9, 0xe2, 0x11, 0x66, 0x66, 0xeb, 0xf5, 0x2d, 0xd4, 0x87,
-	0xd2, 0x80, 0xb2, 0xf1, 0x2a, 0xdc, 0xdf, 0xce, 0xda, 0x2f, 0x7d, 0xdb, 0x0e, 0xba, 0xb7, 0xe5,
-	0xe8, 0x1b, 0xf8, 0xfa, 0x4f, 0x00, 0x00, 0x00, 0xff, 0xff, 0xf5, 0x51, 0xe0, 0x2c, 0x30, 0x07,
-	0x00, 0x00,
+	// 927 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xad, 0x97, 0x5b, 0x53, 0xd3, 0x40,
+	0x14, 0xc7, 0x5b, 0x8b, 0x50, 0x0e, 0xb5, 0x42, 0xa0, 0x58, 0xcb, 0xe0, 0x65, 0x65, 0x18, 0x9d,
+	0x41, 0x18, 0x50, 0x5f, 0x1d, 0x06, 0x8b, 0x0e, 0x6a, 0x15, 0x52, 0xc4, 0xf1, 0xc2, 0x68, 0x48,
+	0xb6, 0x69, 0xa6, 0xb9, 0x99, 0xdd, 0x54, 0xea, 0xb3, 0x4f, 0x7e, 0x00, 0x9f, 0xfc, 0xb0, 0x6e,
+	0x36, 0xe9, 0x25, 0x97, 0xb6, 0xc3, 0x24, 0x4f, 0xdd, 0xdd, 0xb3, 0xfb, 0x3b, 0x67, 0xcf, 0xd9,
+	0xdd, 0x7f, 0x0a, 0x8f, 0xec, 0x8e, 0xba, 0xd3, 0x96, 0x4c, 0x45, 0xc7, 0xce, 0x63, 0x5d, 0x72,
+	0x4d, 0xb9, 0xcd, 0x1a, 0xb2, 0x65, 0xec, 0xc8, 0x86, 0xb2, 0xd3, 0xdd, 0xf5, 0x7e, 0xb6, 0x6d,
+	0xc7, 0xa2, 0x96, 0x70, 0xb3, 0xe3, 0x5e, 0xe0, 0xae, 0xe6, 0xd0, 0x6d, 0x6f, 0xac, 0xbb, 0x8b,
+	0xee, 0x42, 0xe1, 0xac, 0x71, 0x24, 0x54, 0x61, 0xae, 0x6b, 0x68, 0xaf, 0x89, 0x65, 0x56, 0xf3,
+	0xf7, 0xf2, 0x0f, 0x4b, 0x62, 0xbf, 0x8b, 0xfe, 0xe4, 0x61, 0xb6, 0xd9, 0x38, 0xd0, 0x2c, 0x22,
+	0x20, 0x28, 0x19, 0x92, 0xe9, 0xb6, 0x24, 0x99, 0xba, 0x0e, 0x76, 0xf8, 0xcc, 0x79, 0x31, 0x34,
+	0xe6, 0x81, 0x98, 0x27, 0xc5, 0x95, 0x69, 0xf5, 0x1a, 0x37, 0xf7, 0xbb, 0xdc, 0x05, 0x76, 0x88,
+	0xc6, 0x5c, 0x14, 0x7c, 0x4b, 0xd0, 0x15, 0x16, 0xa1, 0x40, 0x3a, 0x6e, 0x75, 0x86, 0x8f, 0x7a,
+	0x4d, 0x61, 0x15, 0x66, 0x5b, 0x92, 0xa1, 0xe9, 0xbd, 0xea, 0x75, 0x3e, 0x18, 0xf4, 0x90, 0x02,
+	0x95, 0x33, 0x16, 0xbc, 0x2b, 0xe9, 0x0d, 0x49, 0x6e, 0x6b, 0x26, 0x7e, 0x6f, 0x53, 0x46, 0x20,
+	0xc2, 0x1b, 0x58, 0x09, 0x1b, 0xfc, 0x90, 0x79, 0x88, 0x0b, 0x7b, 0xb7, 0xb6, 0x23, 0xdb, 0xde,
+	0xf6, 0xcd, 0x62, 0xe2, 0x22, 0xd4, 0x05, 0x60, 0x39, 0x11, 0xf1, 0x0f, 0x17, 0x13, 0x2a, 0x6c,
+	0x42, 0x81, 0xe5, 0x22, 0x20, 0xad, 0xc4, 0x48, 0xde, 0x4c, 0x6f, 0x82, 0xb0, 0x0f, 0x73, 0x96,
+	0x1f, 0x0d, 0xdf, 0xf9, 0xc2, 0xde, 0x66, 0x7c, 0x6e, 0x52, 0xec, 0x62, 0x7f, 0x19, 0x3a, 0x85,
+	0xc5, 0x86, 0xa6, 0x3a, 0x92, 0xd7, 0xbb, 0xaa, 0xf7, 0x6a, 0xd8, 0x7b, 0x69, 0x48, 0x2d, 0x43,
+	0xe9, 0xd0, 0xb0, 0x69, 0x2f, 0x20, 0xa2, 0xe7, 0x50, 0x14, 0x31, 0xb1, 0x99, 0x09, 0x7b, 0xab,
+	0x88, 0x2b, 0xcb, 0x98, 0xf8, 0x99, 0x2a, 0x8a, 0xfd, 0xae, 0x67, 0x31, 0xd8, 0xaf, 0xa4, 0xe2,
+	0x7e, 0x1d, 0x83, 0x2e, 0xfa, 0x06, 0xe5, 0xba, 0x65, 0x48, 0x9a, 0x39, 0xa0, 0x3c, 0x83, 0xa2,
+	0x13, 0xb4, 0x83, 0x40, 0x6f, 0xc7, 0x02, 0xed, 0x4f, 0x16, 0x07, 0x53, 0xbd, 0x22, 0x2b, 0x1c,
+	0x14, 0x78, 0x08, 0x7a, 0xc8, 0x84, 0x65, 0xdf, 0x41, 0x93, 0x4a, 0x94, 0xa4, 0xf5, 0x72, 0x0f,
+	0x16, 0x94, 0x21, 0x2d, 0x70, 0x35, 0x3a, 0x84, 0xce, 0x41, 0x68, 0xca, 0x0e, 0xc6, 0x26, 0x69,
+	0x5b, 0x34, 0xad, 0x3b, 0x76, 0x96, 0x6d, 0x53, 0x0d, 0x6a, 0xe0, 0x35, 0xd1, 0x57, 0x58, 0x7c,
+	0xe5, 0x25, 0xfe, 0xf0, 0x12, 0xcb, 0x57, 0xad, 0x2a, 0x0b, 0x1e, 0x0f, 0x97, 0x05, 0xd4, 0xd1,
+	0x21, 0x74, 0x09, 0x4b, 0x9c, 0x7e, 0x64, 0xb6, 0xac, 0xb4, 0xb1, 0x6f, 0xc1, 0x92, 0x1a, 0x65,
+	0x05, 0x09, 0x8b, 0x1b, 0xd0, 0xef, 0x3c, 0x54, 0xb8, 0xeb, 0x0f, 0x04, 0x3b, 0x6f, 0x35, 0x92,
+	0x3a, 0x75, 0x4f, 0xa1, 0xa2, 0x26, 0xf1, 0x82, 0x10, 0x92, 0x8d, 0xe8, 0x6f, 0x1e, 0xd6, 0x78,
+	0x18, 0x2f, 0x35, 0x1d, 0x93, 0x1e, 0xa1, 0xd8, 0xc8, 0x22, 0x98, 0x7d, 0x58, 0x53, 0xc7, 0x53,
+	0x83, 0x90, 0x26, 0x4d, 0x19, 0x54, 0xc6, 0xaf, 0x7b, 0x36, 0x95, 0x19, 0x65, 0x85, 0x2a, 0x33,
+	0x6a, 0x40, 0x1f, 0x61, 0xa9, 0x81, 0x0d, 0xcb, 0xe9, 0xd5, 0x5d, 0xc3, 0xbe, 0xea, 0x91, 0xab,
+	0x41, 0xb1, 0xc5, 0x36, 0xf4, 0x4e, 0x32, 0xfa, 0x1e, 0x06, 0x7d, 0x44, 0x61, 0x99, 0x39, 0xd1,
+	0x7e, 0xe1, 0xba, 0x46, 0x3a, 0xa9, 0x6f, 0xe6, 0x26, 0x94, 0x1d, 0x4e, 0x53, 0xce, 0x2c, 0xdd,
+	0x65, 0xcf, 0x0b, 0xf3, 0x57, 0x60, 0xfe, 0x22, 0xa3, 0xe8, 0x04, 0x6e, 0x1c, 0x48, 0x72, 0xc7,
+	0xb5, 0x33, 0x7b, 0x13, 0xf7, 0xfe, 0xdd, 0x84, 0xc2, 0x0b, 0x43, 0x11, 0xde, 0xb1, 0xab, 0xdf,
+	0x33, 0xe5, 0xf0, 0xbb, 0x2c, 0xac, 0x25, 0x22, 0x7d, 0xe7, 0xb5, 0xf1, 0x5b, 0x43, 0x39, 0xe1,
+	0x3d, 0x2c, 0x1f, 0x4b, 0x2e, 0xc1, 0x99, 0x01, 0x4f, 0xa0, 0xf2, 0xc1, 0xb4, 0x33, 0x45, 0x8a,
+	0xb0, 0xda, 0x6c, 0xbb, 0x54, 0xb1, 0x7e, 0x9a, 0x99, 0x31, 0x59, 0x1e, 0xdf, 0x68, 0xba, 0x9e,
+	0x19, 0xef, 0x18, 0x56, 0xea, 0x58, 0xc7, 0x34, 0xbb, 0x5d, 0x7f, 0x84, 0x8a, 0xaf, 0xad, 0x51,
+	0xe4, 0xfd, 0xd8, 0xaa, 0xa8, 0x06, 0x4f, 0x2d, 0xb9, 0x77, 0x84, 0x06, 0x8b, 0x4e, 0x25, 0x47,
+	0xc5, 0x34, 0x45, 0xa4, 0x9f, 0x60, 0xfd, 0x85, 0x64, 0xca, 0x38, 0x92, 0xcd, 0x81, 0x83, 0x14,
+	0xe8, 0x06, 0xcc, 0xbf, 0xc2, 0xd4, 0x17, 0x57, 0x61, 0x3d, 0x36, 0x73, 0xf4, 0x33, 0xa1, 0x76,
+	0x37, 0x66, 0x0e, 0xab, 0x3e, 0xcf, 0x69, 0x79, 0x80, 0xe3, 0x52, 0x3a, 0x8d, 0xb9, 0x31, 0x86,
+	0x19, 0x12, 0x7a, 0x06, 0x6e, 0xc2, 0x0d, 0x06, 0x1e, 0x8a, 0xf2, 0xe4, 0x2d, 0x3f, 0x88, 0x7f,
+	0xdd, 0xc5, 0xe4, 0x9c, 0x5f, 0xa5, 0x12, 0x83, 0x0e, 0xc4, 0x72, 0x32, 0x13, 0xc5, 0x8c, 0x31,
+	0x95, 0xe5, 0xc8, 0x22, 0x43, 0x7a, 0x92, 0x44, 0x26, 0xe3, 0x36, 0x93, 0x71, 0x31, 0x31, 0xcb,
+	0x09, 0xe7, 0x3c, 0xa7, 0x43, 0x49, 0x99, 0x02, 0xde, 0x4a, 0x06, 0x8f, 0x91, 0xa4, 0x9c, 0x70,
+	0xca, 0x4e, 0x40, 0x5f, 0x2f, 0x12, 0x8e, 0x7e, 0xf4, 0x43, 0x65, 0x5c, 0x1e, 0x42, 0x72, 0x93,
+	0x13, 0x0e, 0x03, 0xea, 0xb1, 0x66, 0xaa, 0x29, 0x8e, 0xe7, 0x29, 0x54, 0x9b, 0x56, 0x8b, 0x85,
+	0x7b, 0x61, 0x59, 0x34, 0xb3, 0x9b, 0xff, 0x05, 0xaa, 0x43, 0x35, 0x8c, 0x50, 0xe3, 0xdb, 0x8b,
+	0x09, 0xe7, 0x64, 0xf8, 0x77, 0xb8, 0xed, 0x2b, 0x62, 0x18, 0xcc, 0xf5, 0x71, 0x72, 0xcc, 0x1b,
+	0x49, 0xd8, 0xa8, 0xb4, 0xf2, 0xbb, 0xb0, 0xe2, 0xab, 0x5f, 0x24, 0xf4, 0x3b, 0xb1, 0xf5, 0x21,
+	0x91, 0x9c, 0xf6, 0xc6, 0xd4, 0x5e, 0x6a, 0xa6, 0x46, 0xda, 0x61, 0xa8, 0x4f, 0x48, 0x87, 0x3e,
+	0x80, 0x19, 0x7e, 0x0c, 0xa6, 0x3c, 0x05, 0x93, 0x18, 0x07, 0x33, 0x9f, 0xaf, 0x75, 0x77, 0x2f,
+	0x66, 0xf9, 0x5f, 0xd6, 0x27, 0xff, 0x01, 0xef, 0xbf, 0x9c, 0x9a, 0xdf, 0x0e, 0x00, 0x00,
 }
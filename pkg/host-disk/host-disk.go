@@ -20,10 +20,13 @@
 package hostdisk
 
 import (
+	"encoding/json"
 	"fmt"
 	"os"
+	"os/exec"
 	"path"
 	"path/filepath"
+	"strconv"
 	"syscall"
 
 	"kubevirt.io/client-go/log"
@@ -65,12 +68,19 @@ func ReplacePVCByHostDisk(vmi *v1.VirtualMachineInstance, clientset kubecli.Kube
 			}
 			isSharedPvc := types.IsPVCShared(pvc)
 
+			format, preallocation, err := getDiskImageOptions(pvc)
+			if err != nil {
+				return err
+			}
+
 			file := getPVCDiskImgPath(vmi.Spec.Volumes[i].Name, "disk.img")
 			volumeSource.HostDisk = &v1.HostDisk{
-				Path:     file,
-				Type:     v1.HostDiskExistsOrCreate,
-				Capacity: pvc.Status.Capacity[k8sv1.ResourceStorage],
-				Shared:   &isSharedPvc,
+				Path:          file,
+				Type:          v1.HostDiskExistsOrCreate,
+				Capacity:      pvc.Status.Capacity[k8sv1.ResourceStorage],
+				Shared:        &isSharedPvc,
+				Format:        format,
+				Preallocation: preallocation,
 			}
 			// PersistenVolumeClaim is replaced by HostDisk
 			volumeSource.PersistentVolumeClaim = nil
@@ -79,6 +89,28 @@ func ReplacePVCByHostDisk(vmi *v1.VirtualMachineInstance, clientset kubecli.Kube
 	return nil
 }
 
+// Returns the format and preallocation mode of the disk.img on a filesystem PVC
+func getDiskImageOptions(pvc *k8sv1.PersistentVolumeClaim) (v1.HostDiskFormat, v1.HostDiskPreallocation, error) {
+	format := v1.HostDiskFormat(pvc.Annotations[v1.DiskImageFormatAnnotation])
+	switch format {
+	case "", v1.HostDiskFormatRaw, v1.HostDiskFormatQcow2:
+	default:
+		return "", "", fmt.Errorf("persistentvolumeclaim %v has an unsupported disk image format '%s'", pvc.Name, format)
+	}
+
+	preallocation := v1.HostDiskPreallocation(pvc.Annotations[v1.DiskImagePreallocationAnnotation])
+	switch preallocation {
+	case "", v1.HostDiskPreallocationOff, v1.HostDiskPreallocationFalloc, v1.HostDiskPreallocationFull:
+	case v1.HostDiskPreallocationMetadata:
+		if format != v1.HostDiskFormatQcow2 {
+			return "", "", fmt.Errorf("persistentvolumeclaim %v can only preallocate metadata of a qcow2 disk image", pvc.Name)
+		}
+	default:
+		return "", "", fmt.Errorf("persistentvolumeclaim %v has an unsupported disk image preallocation '%s'", pvc.Name, preallocation)
+	}
+	return format, preallocation, nil
+}
+
 func dirBytesAvailable(path string) (uint64, error) {
 	var stat syscall.Statfs_t
 	err := syscall.Statfs(path, &stat)
@@ -88,6 +120,26 @@ func dirBytesAvailable(path string) (uint64, error) {
 	return stat.Bavail * uint64(stat.Bsize), nil
 }
 
+// Returns the bytes which are actually allocated by a possibly sparse file
+func fileBytesAllocated(path string) (uint64, error) {
+	var stat syscall.Stat_t
+	err := syscall.Stat(path, &stat)
+	if err != nil {
+		return 0, err
+	}
+	return uint64(stat.Blocks) * 512, nil
+}
+
+func runQemuImg(args ...string) ([]byte, error) {
+	out, err := exec.Command("qemu-img", args...).Output()
+	if exitErr, ok := err.(*exec.ExitError); ok {
+		return nil, fmt.Errorf("qemu-img failed with output '%s': %v", string(exitErr.Stderr), err)
+	} else if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func createSparseRaw(fullPath string, size int64) error {
 	offset := size - 1
 	f, _ := os.Create(fullPath)
@@ -111,8 +163,29 @@ func GetMountedHostDiskDir(volumeName string) string {
 	return getPVCDiskImgPath(volumeName, "")
 }
 
+// GetHostDiskFormat returns the format of the HostDisk image, which defaults to raw
+func GetHostDiskFormat(hostDisk *v1.HostDisk) v1.HostDiskFormat {
+	if hostDisk.Format == "" {
+		return v1.HostDiskFormatRaw
+	}
+	return hostDisk.Format
+}
+
+func isPreallocated(hostDisk *v1.HostDisk) bool {
+	return hostDisk.Preallocation != "" && hostDisk.Preallocation != v1.HostDiskPreallocationOff
+}
+
+// A DiskImgResizer resizes the disk images which are in use by a running domain
+type DiskImgResizer interface {
+	// GetSize returns the virtual size of a disk image in bytes
+	GetSize(diskPath string) (int64, error)
+	// Resize grows a disk image to the given size in bytes
+	Resize(diskPath string, size int64) error
+}
+
 type DiskImgCreator struct {
 	dirBytesAvailableFunc  func(path string) (uint64, error)
+	qemuImgFunc            func(args ...string) ([]byte, error)
 	notifier               k8sNotifier
 	lessPVCSpaceToleration int
 }
@@ -124,6 +197,7 @@ type k8sNotifier interface {
 func NewHostDiskCreator(notifier k8sNotifier, lessPVCSpaceToleration int) DiskImgCreator {
 	return DiskImgCreator{
 		dirBytesAvailableFunc:  dirBytesAvailable,
+		qemuImgFunc:            runQemuImg,
 		notifier:               notifier,
 		lessPVCSpaceToleration: lessPVCSpaceToleration,
 	}
@@ -143,33 +217,33 @@ func (hdc DiskImgCreator) Create(vmi *v1.VirtualMachineInstance) error {
 				if err != nil {
 					return err
 				}
-				requestedSize, _ := hostDisk.Capacity.AsInt64()
-				diskSize := requestedSize
-				if uint64(requestedSize) > availableSize {
-					// Some storage provisioners provision less space than requested, due to filesystem overhead etc.
-					// We tolerate some difference in requested and available capacity up to some degree.
-					// This can be configured with the "pvc-tolerate-less-space-up-to-percent" parameter in the kubevirt-config ConfigMap.
-					// It is provided as argument to virt-launcher.
-					toleratedSize := requestedSize * (100 - int64(hdc.lessPVCSpaceToleration)) / 100
-					if uint64(toleratedSize) > availableSize {
-						return fmt.Errorf("unable to create %s, not enough space, demanded size %d B is bigger than available space %d B, also after taking %v %% toleration into account",
-							hostDisk.Path, uint64(requestedSize), availableSize, hdc.lessPVCSpaceToleration)
-					}
-					diskSize = int64(availableSize)
-
-					msg := fmt.Sprintf("PV size too small: expected %v B, found %v B. Using it anyway, it is within %v %% toleration", requestedSize, availableSize, hdc.lessPVCSpaceToleration)
-					log.Log.Info(msg)
-					err = hdc.notifier.SendK8sEvent(vmi, EventTypeToleratedSmallPV, EventReasonToleratedSmallPV, msg)
-					if err != nil {
-						log.Log.Reason(err).Warningf("Couldn't send k8s event for tolerated PV size: %v", err)
-					}
+				diskSize, err := hdc.sizeWithinToleration(vmi, hostDisk, "create", availableSize)
+				if err != nil {
+					return err
 				}
-				err = createSparseRaw(diskPath, int64(diskSize))
+				hdc.notifyToleratedSize(vmi, hostDisk, diskSize)
+				err = hdc.createImage(diskPath, hostDisk, diskSize)
 				if err != nil {
 					return err
 				}
 			} else if err != nil {
 				return err
+			} else {
+				// The domain is not running yet, so the image can be grown offline
+				currentSize, err := hdc.getImageSize(diskPath, hostDisk)
+				if err != nil {
+					return err
+				}
+				newSize, err := hdc.expandedSize(vmi, hostDisk, diskPath, currentSize)
+				if err != nil {
+					return err
+				}
+				if newSize > currentSize {
+					err = hdc.resizeImage(diskPath, hostDisk, newSize)
+					if err != nil {
+						return err
+					}
+				}
 			}
 			if err := ephemeraldiskutils.DefaultOwnershipManager.SetFileOwnership(diskPath); err != nil {
 				return err
@@ -178,3 +252,134 @@ func (hdc DiskImgCreator) Create(vmi *v1.VirtualMachineInstance) error {
 	}
 	return nil
 }
+
+// Expand grows the images of HostDisk volumes which are in use by a running domain,
+// when their capacity exceeds their current size. It returns the names of the grown volumes.
+func (hdc DiskImgCreator) Expand(vmi *v1.VirtualMachineInstance, resizer DiskImgResizer) ([]string, error) {
+	var expanded []string
+	for _, volume := range vmi.Spec.Volumes {
+		if hostDisk := volume.VolumeSource.HostDisk; hostDisk != nil && hostDisk.Type == v1.HostDiskExistsOrCreate && hostDisk.Path != "" {
+			diskPath := GetMountedHostDiskPath(volume.Name, hostDisk.Path)
+			currentSize, err := resizer.GetSize(diskPath)
+			if err != nil {
+				return expanded, err
+			}
+			newSize, err := hdc.expandedSize(vmi, hostDisk, diskPath, currentSize)
+			if err != nil {
+				return expanded, err
+			}
+			if newSize > currentSize {
+				if err := resizer.Resize(diskPath, newSize); err != nil {
+					return expanded, err
+				}
+				expanded = append(expanded, volume.Name)
+			}
+		}
+	}
+	return expanded, nil
+}
+
+// Returns the size a disk image can get within the available space, which
+// may be less than the requested capacity of the HostDisk.
+func (hdc DiskImgCreator) sizeWithinToleration(vmi *v1.VirtualMachineInstance, hostDisk *v1.HostDisk, action string, availableSize uint64) (int64, error) {
+	requestedSize, _ := hostDisk.Capacity.AsInt64()
+	if uint64(requestedSize) <= availableSize {
+		return requestedSize, nil
+	}
+	// Some storage provisioners provision less space than requested, due to filesystem overhead etc.
+	// We tolerate some difference in requested and available capacity up to some degree.
+	// This can be configured with the "pvc-tolerate-less-space-up-to-percent" parameter in the kubevirt-config ConfigMap.
+	// It is provided as argument to virt-launcher.
+	toleratedSize := requestedSize * (100 - int64(hdc.lessPVCSpaceToleration)) / 100
+	if uint64(toleratedSize) > availableSize {
+		return 0, fmt.Errorf("unable to %s %s, not enough space, demanded size %d B is bigger than available space %d B, also after taking %v %% toleration into account",
+			action, hostDisk.Path, uint64(requestedSize), availableSize, hdc.lessPVCSpaceToleration)
+	}
+	return int64(availableSize), nil
+}
+
+func (hdc DiskImgCreator) notifyToleratedSize(vmi *v1.VirtualMachineInstance, hostDisk *v1.HostDisk, size int64) {
+	requestedSize, _ := hostDisk.Capacity.AsInt64()
+	if size >= requestedSize {
+		return
+	}
+	msg := fmt.Sprintf("PV size too small: expected %v B, found %v B. Using it anyway, it is within %v %% toleration", requestedSize, size, hdc.lessPVCSpaceToleration)
+	log.Log.Info(msg)
+	err := hdc.notifier.SendK8sEvent(vmi, EventTypeToleratedSmallPV, EventReasonToleratedSmallPV, msg)
+	if err != nil {
+		log.Log.Reason(err).Warningf("Couldn't send k8s event for tolerated PV size: %v", err)
+	}
+}
+
+// Returns the size an existing disk image has to grow to, in order to match
+// the capacity of the HostDisk. The space already allocated by the image is
+// available to it as well.
+func (hdc DiskImgCreator) expandedSize(vmi *v1.VirtualMachineInstance, hostDisk *v1.HostDisk, diskPath string, currentSize int64) (int64, error) {
+	requestedSize, _ := hostDisk.Capacity.AsInt64()
+	if requestedSize <= currentSize {
+		return currentSize, nil
+	}
+	availableSize, err := hdc.dirBytesAvailableFunc(filepath.Dir(diskPath))
+	if err != nil {
+		return 0, err
+	}
+	allocatedSize, err := fileBytesAllocated(diskPath)
+	if err != nil {
+		return 0, err
+	}
+	newSize, err := hdc.sizeWithinToleration(vmi, hostDisk, "grow", availableSize+allocatedSize)
+	if err != nil {
+		return 0, err
+	}
+	if newSize <= currentSize {
+		return currentSize, nil
+	}
+	hdc.notifyToleratedSize(vmi, hostDisk, newSize)
+	return newSize, nil
+}
+
+func (hdc DiskImgCreator) createImage(diskPath string, hostDisk *v1.HostDisk, size int64) error {
+	format := GetHostDiskFormat(hostDisk)
+	if format == v1.HostDiskFormatRaw && !isPreallocated(hostDisk) {
+		return createSparseRaw(diskPath, size)
+	}
+	args := []string{"create", "-f", string(format)}
+	if isPreallocated(hostDisk) {
+		args = append(args, "-o", "preallocation="+string(hostDisk.Preallocation))
+	}
+	args = append(args, diskPath, strconv.FormatInt(size, 10))
+	_, err := hdc.qemuImgFunc(args...)
+	return err
+}
+
+func (hdc DiskImgCreator) resizeImage(diskPath string, hostDisk *v1.HostDisk, size int64) error {
+	args := []string{"resize", "-f", string(GetHostDiskFormat(hostDisk))}
+	if isPreallocated(hostDisk) {
+		args = append(args, "--preallocation="+string(hostDisk.Preallocation))
+	}
+	args = append(args, diskPath, strconv.FormatInt(size, 10))
+	_, err := hdc.qemuImgFunc(args...)
+	return err
+}
+
+// Returns the virtual size of a disk image which is not in use
+func (hdc DiskImgCreator) getImageSize(diskPath string, hostDisk *v1.HostDisk) (int64, error) {
+	if GetHostDiskFormat(hostDisk) == v1.HostDiskFormatRaw {
+		info, err := os.Stat(diskPath)
+		if err != nil {
+			return 0, err
+		}
+		return info.Size(), nil
+	}
+	out, err := hdc.qemuImgFunc("info", "-f", string(GetHostDiskFormat(hostDisk)), "--output", "json", diskPath)
+	if err != nil {
+		return 0, err
+	}
+	info := struct {
+		VirtualSize int64 `json:"virtual-size"`
+	}{}
+	if err := json.Unmarshal(out, &info); err != nil {
+		return 0, fmt.Errorf("failed to parse disk info of %s: %v", diskPath, err)
+	}
+	return info.VirtualSize, nil
+}
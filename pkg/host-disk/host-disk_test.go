@@ -24,6 +24,7 @@ import (
 	"io/ioutil"
 	"os"
 	"path"
+	"strconv"
 	"strings"
 
 	"github.com/golang/mock/gomock"
@@ -39,6 +40,23 @@ import (
 	"kubevirt.io/client-go/kubecli"
 )
 
+type fakeDiskImgResizer struct {
+	resized map[string]int64
+}
+
+func (r *fakeDiskImgResizer) GetSize(diskPath string) (int64, error) {
+	info, err := os.Stat(diskPath)
+	if err != nil {
+		return 0, err
+	}
+	return info.Size(), nil
+}
+
+func (r *fakeDiskImgResizer) Resize(diskPath string, size int64) error {
+	r.resized[diskPath] = size
+	return os.Truncate(diskPath, size)
+}
+
 type MockNotifier struct {
 	Events chan k8sv1.Event
 }
@@ -100,22 +118,52 @@ var _ = Describe("HostDisk", func() {
 		return file
 	}
 
+	notifier := MockNotifier{
+		Events: make(chan k8sv1.Event, 10),
+	}
+	var hostDiskCreator DiskImgCreator
+	var qemuImgCalls [][]string
+
+	// fakeQemuImg records the qemu-img invocations and emulates them on plain files
+	fakeQemuImg := func(args ...string) ([]byte, error) {
+		qemuImgCalls = append(qemuImgCalls, args)
+		diskPath := args[len(args)-1]
+		switch args[0] {
+		case "create", "resize":
+			size, err := strconv.ParseInt(args[len(args)-1], 10, 64)
+			Expect(err).NotTo(HaveOccurred())
+			diskPath = args[len(args)-2]
+			if args[0] == "create" {
+				Expect(createSparseRaw(diskPath, size)).To(Succeed())
+			} else {
+				Expect(os.Truncate(diskPath, size)).To(Succeed())
+			}
+			return nil, nil
+		case "info":
+			info, err := os.Stat(diskPath)
+			if err != nil {
+				return nil, err
+			}
+			return []byte(fmt.Sprintf(`{"virtual-size": %d, "format": "qcow2"}`, info.Size())), nil
+		}
+		return nil, fmt.Errorf("unexpected qemu-img command %v", args)
+	}
+
 	BeforeEach(func() {
 		var err error
 		tempDir, err = ioutil.TempDir("", "host-disk-images")
 		setDiskDirectory(tempDir)
 		Expect(err).NotTo(HaveOccurred())
+
+		hostDiskCreator = NewHostDiskCreator(notifier, 0)
+		hostDiskCreator.qemuImgFunc = fakeQemuImg
+		qemuImgCalls = nil
 	})
 
 	AfterEach(func() {
 		os.RemoveAll(tempDir)
 	})
 
-	notifier := MockNotifier{
-		Events: make(chan k8sv1.Event, 10),
-	}
-	hostDiskCreator := NewHostDiskCreator(notifier, 0)
-
 	Describe("HostDisk with 'Disk' type", func() {
 		It("Should not create a disk.img when it exists", func() {
 			By("Creating a disk.img before adding a HostDisk volume")
@@ -271,9 +319,15 @@ var _ = Describe("HostDisk", func() {
 			})
 		})
 		Context("With existing disk.img", func() {
-			It("Should not re-create disk.img", func() {
+			It("Should grow disk.img to the requested capacity without re-creating it", func() {
 				By("Creating a disk.img before adding a HostDisk volume")
-				tmpDiskImg := createTempDiskImg("volume1")
+				createTempDiskImg("volume1")
+				imgPath := path.Join(tempDir, "volume1", "disk.img")
+				f, err := os.OpenFile(imgPath, os.O_WRONLY, 0)
+				Expect(err).NotTo(HaveOccurred())
+				_, err = f.WriteAt([]byte("data"), 0)
+				Expect(err).NotTo(HaveOccurred())
+				f.Close()
 
 				By("Creating a new minimal vmi")
 				vmi := v1.NewMinimalVMI("fake-vmi")
@@ -281,21 +335,166 @@ var _ = Describe("HostDisk", func() {
 				By("Adding a HostDisk volume")
 				addHostDisk(vmi, "volume1", v1.HostDiskExistsOrCreate, "128Mi")
 
-				By("Executing CreateHostDisks which should not create a disk.img")
+				By("Executing CreateHostDisks which should grow the disk.img")
+				err = hostDiskCreator.Create(vmi)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(qemuImgCalls).To(Equal([][]string{
+					{"resize", "-f", "raw", imgPath, "134217728"},
+				}))
+				hostDiskImg, err := os.Stat(imgPath)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(hostDiskImg.Size()).To(Equal(int64(134217728))) // 128Mi
+
+				// check if the content of disk.img was kept
+				data := make([]byte, 4)
+				f, err = os.Open(imgPath)
+				Expect(err).NotTo(HaveOccurred())
+				defer f.Close()
+				_, err = f.ReadAt(data, 0)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(data)).To(Equal("data"))
+			})
+
+			It("Should not shrink disk.img", func() {
+				By("Creating a disk.img before adding a HostDisk volume")
+				tmpDiskImg := createTempDiskImg("volume1")
+
+				By("Creating a new minimal vmi")
+				vmi := v1.NewMinimalVMI("fake-vmi")
+
+				By("Adding a HostDisk volume with a smaller capacity")
+				addHostDisk(vmi, "volume1", v1.HostDiskExistsOrCreate, "32Mi")
+
+				By("Executing CreateHostDisks which should not touch the disk.img")
 				err := hostDiskCreator.Create(vmi)
 				Expect(err).NotTo(HaveOccurred())
 
-				// check if disk.img has the same modification time
-				// which means that CreateHostDisks function did not create a new disk.img
-				capacity := vmi.Spec.Volumes[0].HostDisk.Capacity
-				specSize, _ := capacity.AsInt64()
+				Expect(qemuImgCalls).To(BeEmpty())
 				hostDiskImg, _ := os.Stat(vmi.Spec.Volumes[0].HostDisk.Path)
 				Expect(tmpDiskImg.ModTime()).To(Equal(hostDiskImg.ModTime()))
-				// check if img has the same size as before
-				Expect(tmpDiskImg.Size()).NotTo(Equal(specSize))
-				Expect(tmpDiskImg.Size()).To(Equal(int64(67108864)))
+				Expect(hostDiskImg.Size()).To(Equal(int64(67108864)))
+			})
+
+			It("Should grow a qcow2 disk.img with the requested preallocation", func() {
+				createTempDiskImg("volume1")
+				imgPath := path.Join(tempDir, "volume1", "disk.img")
+
+				vmi := v1.NewMinimalVMI("fake-vmi")
+				addHostDisk(vmi, "volume1", v1.HostDiskExistsOrCreate, "128Mi")
+				vmi.Spec.Volumes[0].HostDisk.Format = v1.HostDiskFormatQcow2
+				vmi.Spec.Volumes[0].HostDisk.Preallocation = v1.HostDiskPreallocationFalloc
+
+				err := hostDiskCreator.Create(vmi)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(qemuImgCalls).To(Equal([][]string{
+					{"info", "-f", "qcow2", "--output", "json", imgPath},
+					{"resize", "-f", "qcow2", "--preallocation=falloc", imgPath, "134217728"},
+				}))
+			})
+
+			It("Should take lessPVCSpaceToleration into account when growing disk.img", func() {
+				createTempDiskImg("volume1")
+				createTempDiskImg("volume2")
+
+				size128Mi := uint64(134217728)
+				hostDiskCreator.setlessPVCSpaceToleration(10)
+				hostDiskCreator.dirBytesAvailableFunc = func(path string) (uint64, error) {
+					allocated, err := fileBytesAllocated(path + "/disk.img")
+					Expect(err).NotTo(HaveOccurred())
+					if strings.Contains(path, "volume1") {
+						// within toleration
+						return size128Mi*95/100 - allocated, nil
+					}
+					// beyond toleration
+					return size128Mi*85/100 - allocated, nil
+				}
+
+				vmi := v1.NewMinimalVMI("fake-vmi")
+				addHostDisk(vmi, "volume1", v1.HostDiskExistsOrCreate, "128Mi")
+				addHostDisk(vmi, "volume2", v1.HostDiskExistsOrCreate, "128Mi")
+
+				err := hostDiskCreator.Create(vmi)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("unable to grow"))
+
+				img1, err := os.Stat(vmi.Spec.Volumes[0].HostDisk.Path)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(uint64(img1.Size())).To(Equal(size128Mi * 95 / 100))
+
+				img2, err := os.Stat(vmi.Spec.Volumes[1].HostDisk.Path)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(img2.Size()).To(Equal(int64(67108864)))
+
+				event := <-notifier.Events
+				Expect(event.Reason).To(Equal(EventReasonToleratedSmallPV))
 			})
 		})
+
+		table.DescribeTable("Should create disk.img with", func(format v1.HostDiskFormat, preallocation v1.HostDiskPreallocation, expectedArgs []string) {
+			vmi := v1.NewMinimalVMI("fake-vmi")
+			addHostDisk(vmi, "volume1", v1.HostDiskExistsOrCreate, "64Mi")
+			vmi.Spec.Volumes[0].HostDisk.Format = format
+			vmi.Spec.Volumes[0].HostDisk.Preallocation = preallocation
+
+			err := hostDiskCreator.Create(vmi)
+			Expect(err).NotTo(HaveOccurred())
+
+			imgPath := path.Join(tempDir, "volume1", "disk.img")
+			if expectedArgs == nil {
+				Expect(qemuImgCalls).To(BeEmpty())
+			} else {
+				Expect(qemuImgCalls).To(Equal([][]string{append(expectedArgs, imgPath, "67108864")}))
+			}
+			img, err := os.Stat(imgPath)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(img.Size()).To(Equal(int64(67108864)))
+		},
+			table.Entry("a sparse raw image by default", v1.HostDiskFormat(""), v1.HostDiskPreallocation(""), nil),
+			table.Entry("a sparse raw image without preallocation", v1.HostDiskFormatRaw, v1.HostDiskPreallocationOff, nil),
+			table.Entry("a preallocated raw image", v1.HostDiskFormatRaw, v1.HostDiskPreallocationFull,
+				[]string{"create", "-f", "raw", "-o", "preallocation=full"}),
+			table.Entry("a qcow2 image", v1.HostDiskFormatQcow2, v1.HostDiskPreallocation(""),
+				[]string{"create", "-f", "qcow2"}),
+			table.Entry("a qcow2 image with preallocated metadata", v1.HostDiskFormatQcow2, v1.HostDiskPreallocationMetadata,
+				[]string{"create", "-f", "qcow2", "-o", "preallocation=metadata"}),
+		)
+	})
+
+	Describe("HostDisk of a running domain", func() {
+		It("Should only grow disk images which are smaller than the requested capacity", func() {
+			vmi := v1.NewMinimalVMI("fake-vmi")
+			addHostDisk(vmi, "volume1", v1.HostDiskExistsOrCreate, "64Mi")
+			addHostDisk(vmi, "volume2", v1.HostDiskExistsOrCreate, "128Mi")
+			addHostDisk(vmi, "volume3", v1.HostDiskExists, "")
+			Expect(hostDiskCreator.Create(vmi)).To(Succeed())
+
+			By("Increasing the capacity of the first volume")
+			vmi.Spec.Volumes[0].HostDisk.Capacity = resource.MustParse("96Mi")
+
+			resizer := &fakeDiskImgResizer{resized: map[string]int64{}}
+			expanded, err := hostDiskCreator.Expand(vmi, resizer)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(expanded).To(Equal([]string{"volume1"}))
+			Expect(resizer.resized).To(Equal(map[string]int64{
+				vmi.Spec.Volumes[0].HostDisk.Path: 100663296, // 96Mi
+			}))
+		})
+
+		It("Should fail if there is not enough space to grow a disk image", func() {
+			vmi := v1.NewMinimalVMI("fake-vmi")
+			addHostDisk(vmi, "volume1", v1.HostDiskExistsOrCreate, "64Mi")
+			Expect(hostDiskCreator.Create(vmi)).To(Succeed())
+
+			vmi.Spec.Volumes[0].HostDisk.Capacity = resource.MustParse("1E")
+
+			resizer := &fakeDiskImgResizer{resized: map[string]int64{}}
+			expanded, err := hostDiskCreator.Expand(vmi, resizer)
+			Expect(err).To(HaveOccurred())
+			Expect(expanded).To(BeEmpty())
+			Expect(resizer.resized).To(BeEmpty())
+		})
 	})
 
 	Describe("HostDisk with unkown type", func() {
@@ -383,6 +582,53 @@ var _ = Describe("HostDisk", func() {
 			table.Entry("filemode", k8sv1.PersistentVolumeFilesystem),
 			table.Entry("blockmode", k8sv1.PersistentVolumeBlock),
 		)
+
+		table.DescribeTable("PVC with disk image annotations", func(annotations map[string]string, expectedFormat v1.HostDiskFormat, expectedPreallocation v1.HostDiskPreallocation, expectErr bool) {
+			namespace := "testns"
+			pvcName := "pvcDisk"
+			mode := k8sv1.PersistentVolumeFilesystem
+			pvc := &k8sv1.PersistentVolumeClaim{
+				ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: pvcName, Annotations: annotations},
+				Spec: k8sv1.PersistentVolumeClaimSpec{
+					VolumeMode: &mode,
+				},
+			}
+			virtClient.CoreV1().PersistentVolumeClaims(namespace).Create(pvc)
+
+			vmi := v1.NewMinimalVMIWithNS(namespace, "testvmi")
+			vmi.Spec.Volumes = []v1.Volume{
+				{
+					Name: "pvc-volume",
+					VolumeSource: v1.VolumeSource{
+						PersistentVolumeClaim: &k8sv1.PersistentVolumeClaimVolumeSource{ClaimName: pvcName},
+					},
+				},
+			}
+
+			err := ReplacePVCByHostDisk(vmi, virtClient)
+			if expectErr {
+				Expect(err).To(HaveOccurred())
+				return
+			}
+			Expect(err).NotTo(HaveOccurred())
+			Expect(vmi.Spec.Volumes[0].HostDisk.Format).To(Equal(expectedFormat))
+			Expect(vmi.Spec.Volumes[0].HostDisk.Preallocation).To(Equal(expectedPreallocation))
+		},
+			table.Entry("without annotations", nil, v1.HostDiskFormat(""), v1.HostDiskPreallocation(""), false),
+			table.Entry("with qcow2 format and preallocation", map[string]string{
+				v1.DiskImageFormatAnnotation:        "qcow2",
+				v1.DiskImagePreallocationAnnotation: "metadata",
+			}, v1.HostDiskFormatQcow2, v1.HostDiskPreallocationMetadata, false),
+			table.Entry("with an unsupported format", map[string]string{
+				v1.DiskImageFormatAnnotation: "vmdk",
+			}, v1.HostDiskFormat(""), v1.HostDiskPreallocation(""), true),
+			table.Entry("with an unsupported preallocation", map[string]string{
+				v1.DiskImagePreallocationAnnotation: "sometimes",
+			}, v1.HostDiskFormat(""), v1.HostDiskPreallocation(""), true),
+			table.Entry("with metadata preallocation of a raw image", map[string]string{
+				v1.DiskImagePreallocationAnnotation: "metadata",
+			}, v1.HostDiskFormat(""), v1.HostDiskPreallocation(""), true),
+		)
 	})
 
 })
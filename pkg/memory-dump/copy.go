@@ -0,0 +1,99 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2020 Red Hat, Inc.
+ *
+ */
+
+package memorydump
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+// WaitAndCopy waits until libvirt finished the memory dump fileName in sourceDir
+// and copies it into targetDir. The copy only shows up under its final name once
+// it is complete. The guest memory must not stay on the node, so the source is
+// removed afterwards, no matter if the copy succeeded.
+func WaitAndCopy(sourceDir string, targetDir string, fileName string, timeout time.Duration, pollInterval time.Duration) error {
+	err := wait.PollImmediate(pollInterval, timeout, func() (bool, error) {
+		reason, err := ioutil.ReadFile(FailedFilePath(sourceDir, fileName))
+		if err == nil {
+			os.Remove(FailedFilePath(sourceDir, fileName))
+			return false, fmt.Errorf("failed to dump the memory: %s", strings.TrimSpace(string(reason)))
+		} else if !os.IsNotExist(err) {
+			return false, err
+		}
+
+		if _, err := os.Stat(FilePath(sourceDir, fileName)); err == nil {
+			return true, nil
+		} else if !os.IsNotExist(err) {
+			return false, err
+		}
+		return false, nil
+	})
+	if err == wait.ErrWaitTimeout {
+		// nobody picks up the dump anymore, drop what libvirt wrote so far
+		os.Remove(PartialFilePath(sourceDir, fileName))
+		os.Remove(FilePath(sourceDir, fileName))
+		return fmt.Errorf("timed out waiting for the memory dump %s", fileName)
+	} else if err != nil {
+		return err
+	}
+
+	err = copyDump(sourceDir, targetDir, fileName)
+	if removeErr := os.Remove(FilePath(sourceDir, fileName)); removeErr != nil && err == nil {
+		err = removeErr
+	}
+	return err
+}
+
+func copyDump(sourceDir string, targetDir string, fileName string) error {
+	if err := copyFile(FilePath(sourceDir, fileName), PartialFilePath(targetDir, fileName)); err != nil {
+		os.Remove(PartialFilePath(targetDir, fileName))
+		return fmt.Errorf("failed to copy the memory dump: %v", err)
+	}
+	if err := os.Rename(PartialFilePath(targetDir, fileName), FilePath(targetDir, fileName)); err != nil {
+		os.Remove(PartialFilePath(targetDir, fileName))
+		return fmt.Errorf("failed to copy the memory dump: %v", err)
+	}
+	return nil
+}
+
+func copyFile(source string, target string) error {
+	in, err := os.Open(source)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.Create(target)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	if _, err := io.Copy(out, in); err != nil {
+		return err
+	}
+	return out.Sync()
+}
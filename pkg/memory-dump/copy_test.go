@@ -0,0 +1,108 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2020 Red Hat, Inc.
+ *
+ */
+
+package memorydump
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("WaitAndCopy", func() {
+
+	const fileName = "testvm-dump-claim-20200101-000000.memory.dump"
+
+	var sourceDir string
+	var targetDir string
+
+	BeforeEach(func() {
+		var err error
+		sourceDir, err = ioutil.TempDir("", "memory-dump-source")
+		Expect(err).ToNot(HaveOccurred())
+		targetDir, err = ioutil.TempDir("", "memory-dump-target")
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(sourceDir)
+		os.RemoveAll(targetDir)
+	})
+
+	waitAndCopy := func(timeout time.Duration) chan error {
+		done := make(chan error, 1)
+		go func() {
+			done <- WaitAndCopy(sourceDir, targetDir, fileName, timeout, 10*time.Millisecond)
+		}()
+		return done
+	}
+
+	It("should wait for the dump to finish and copy it", func() {
+		Expect(ioutil.WriteFile(PartialFilePath(sourceDir, fileName), []byte("memory"), 0644)).To(Succeed())
+		done := waitAndCopy(5 * time.Second)
+
+		Consistently(done, 100*time.Millisecond).ShouldNot(Receive())
+		Expect(os.Rename(PartialFilePath(sourceDir, fileName), FilePath(sourceDir, fileName))).To(Succeed())
+		Eventually(done).Should(Receive(BeNil()))
+
+		content, err := ioutil.ReadFile(filepath.Join(targetDir, fileName))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(content)).To(Equal("memory"))
+		Expect(PartialFilePath(targetDir, fileName)).ToNot(BeAnExistingFile())
+		Expect(FilePath(sourceDir, fileName)).ToNot(BeAnExistingFile())
+	})
+
+	It("should report a failed dump", func() {
+		Expect(ioutil.WriteFile(FailedFilePath(sourceDir, fileName), []byte("no space left on device\n"), 0644)).To(Succeed())
+
+		var err error
+		Eventually(waitAndCopy(5 * time.Second)).Should(Receive(&err))
+		Expect(err).To(MatchError("failed to dump the memory: no space left on device"))
+		Expect(FilePath(targetDir, fileName)).ToNot(BeAnExistingFile())
+	})
+
+	It("should time out if the dump does not finish", func() {
+		var err error
+		Eventually(waitAndCopy(50 * time.Millisecond)).Should(Receive(&err))
+		Expect(err).To(MatchError("timed out waiting for the memory dump " + fileName))
+	})
+
+	It("should remove a partial dump on timeout", func() {
+		Expect(ioutil.WriteFile(PartialFilePath(sourceDir, fileName), []byte("memory"), 0644)).To(Succeed())
+
+		var err error
+		Eventually(waitAndCopy(50 * time.Millisecond)).Should(Receive(&err))
+		Expect(err).To(HaveOccurred())
+		Expect(PartialFilePath(sourceDir, fileName)).ToNot(BeAnExistingFile())
+	})
+
+	It("should remove the dump from the node if the copy fails", func() {
+		Expect(ioutil.WriteFile(FilePath(sourceDir, fileName), []byte("memory"), 0644)).To(Succeed())
+		Expect(os.RemoveAll(targetDir)).To(Succeed())
+
+		var err error
+		Eventually(waitAndCopy(5 * time.Second)).Should(Receive(&err))
+		Expect(err).To(MatchError(ContainSubstring("failed to copy the memory dump")))
+		Expect(FilePath(sourceDir, fileName)).ToNot(BeAnExistingFile())
+	})
+})
@@ -0,0 +1,108 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2020 Red Hat, Inc.
+ *
+ */
+
+package memorydump
+
+import (
+	"fmt"
+	"path/filepath"
+	"syscall"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+
+	v1 "kubevirt.io/client-go/api/v1"
+)
+
+// The memory of a VirtualMachineInstance is dumped by libvirt inside the
+// virt-launcher pod into an emptyDir volume of the pod. Only the memory dump
+// pod of an actual dump request mounts that volume from the node, copies the
+// finished dump into the requested PVC and keeps running, so that the PVC stays
+// bound and the dump can be downloaded. The dump is removed from the node as
+// soon as the copy finished or failed.
+const (
+	// VolumeName is the name of the emptyDir volume of virt-launcher holding the dumps
+	VolumeName = "memory-dump"
+	// LauncherDir is the mount path of the dump volume in the virt-launcher and the memory dump pod
+	LauncherDir = "/var/run/kubevirt-memory-dump"
+	// ClaimVolumeName is the name of the memory dump pod volume for the target PVC
+	ClaimVolumeName = "memory-dump-claim"
+	// ClaimDir is the mount path of the target PVC in the memory dump pod
+	ClaimDir = "/dump"
+	// ContainerName is the name of the memory dump pod container
+	ContainerName = "memory-dump"
+	// PodNamePrefix is prepended to the VirtualMachine name to form the memory dump pod name
+	PodNamePrefix = "virt-memory-dump-"
+	// VirtualMachineLabel carries the name of the VirtualMachine on its memory dump pod
+	VirtualMachineLabel = "kubevirt.io/memory-dump"
+	// FileNameAnnotation carries the name of the dump a memory dump pod copies
+	FileNameAnnotation = "kubevirt.io/memory-dump-file"
+
+	// CopyUserID is the unprivileged qemu user of the virt-launcher image the memory dump pod runs as
+	CopyUserID int64 = 107
+	// FileMode lets the memory dump pod read finished dumps, which libvirt only creates readable for root
+	FileMode = 0644
+
+	kubeletPodsDir = "/var/lib/kubelet/pods"
+	partialSuffix  = ".partial"
+	failedSuffix   = ".failed"
+)
+
+// LauncherVolumeHostDir returns the node directory of the dump volume of a virt-launcher pod
+func LauncherVolumeHostDir(podUID types.UID) string {
+	return filepath.Join(kubeletPodsDir, string(podUID), "volumes", "kubernetes.io~empty-dir", VolumeName)
+}
+
+// GenerateFileName returns a unique name for a memory dump of the VirtualMachine into the claim
+func GenerateFileName(vmName string, claimName string, now time.Time) string {
+	return fmt.Sprintf("%s-%s-%s.memory.dump", vmName, claimName, now.UTC().Format("20060102-150405"))
+}
+
+// PodName returns the name of the memory dump pod of a VirtualMachine
+func PodName(vm *v1.VirtualMachine) string {
+	return PodNamePrefix + vm.Name
+}
+
+// FilePath returns the path of a finished memory dump in dir
+func FilePath(dir string, fileName string) string {
+	return filepath.Join(dir, fileName)
+}
+
+// PartialFilePath returns the path libvirt writes a memory dump to until it is finished
+func PartialFilePath(dir string, fileName string) string {
+	return filepath.Join(dir, fileName+partialSuffix)
+}
+
+// FailedFilePath returns the path of the file which holds the reason of a failed memory dump
+func FailedFilePath(dir string, fileName string) string {
+	return filepath.Join(dir, fileName+failedSuffix)
+}
+
+// CheckFreeSpace returns an error if dir can not hold a memory dump of size bytes
+func CheckFreeSpace(dir string, size int64) error {
+	var stat syscall.Statfs_t
+	if err := syscall.Statfs(dir, &stat); err != nil {
+		return fmt.Errorf("failed to determine the free space for the memory dump: %v", err)
+	}
+	available := stat.Bavail * uint64(stat.Bsize)
+	if size > 0 && uint64(size) > available {
+		return fmt.Errorf("not enough free space for the memory dump: the guest memory needs %d bytes, but only %d bytes are available", size, available)
+	}
+	return nil
+}
@@ -0,0 +1,71 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2020 Red Hat, Inc.
+ *
+ */
+
+package memorydump
+
+import (
+	"io/ioutil"
+	"os"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "kubevirt.io/client-go/api/v1"
+)
+
+var _ = Describe("MemoryDump", func() {
+
+	It("should generate the node directory of the dump volume from the virt-launcher pod UID", func() {
+		Expect(LauncherVolumeHostDir("1234")).To(Equal("/var/lib/kubelet/pods/1234/volumes/kubernetes.io~empty-dir/memory-dump"))
+	})
+
+	It("should generate unique file names", func() {
+		now := time.Date(2020, 3, 4, 5, 6, 7, 0, time.UTC)
+		Expect(GenerateFileName("testvm", "dump-claim", now)).To(Equal("testvm-dump-claim-20200304-050607.memory.dump"))
+	})
+
+	It("should derive the pod name from the VM name", func() {
+		vm := &v1.VirtualMachine{ObjectMeta: metav1.ObjectMeta{Name: "testvm"}}
+		Expect(PodName(vm)).To(Equal("virt-memory-dump-testvm"))
+	})
+
+	Context("free space", func() {
+		var dir string
+
+		BeforeEach(func() {
+			var err error
+			dir, err = ioutil.TempDir("", "memory-dump")
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		AfterEach(func() {
+			os.RemoveAll(dir)
+		})
+
+		It("should accept a dump which fits", func() {
+			Expect(CheckFreeSpace(dir, 1024)).To(Succeed())
+		})
+
+		It("should reject a dump larger than the free space", func() {
+			Expect(CheckFreeSpace(dir, 1<<62)).To(MatchError(ContainSubstring("not enough free space for the memory dump")))
+		})
+	})
+})
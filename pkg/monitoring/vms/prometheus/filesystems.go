@@ -0,0 +1,117 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2020 Red Hat, Inc.
+ *
+ */
+
+package prometheus
+
+import (
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+
+	k6tv1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/client-go/log"
+	cmdclient "kubevirt.io/kubevirt/pkg/virt-handler/cmd-client"
+)
+
+// filesystemsRefreshInterval is how long the cached guest filesystems of a VMI are reported before they are fetched again
+const filesystemsRefreshInterval = 1 * time.Minute
+
+type filesystemsFetcher func(socketFile string, vmi *k6tv1.VirtualMachineInstance) (*k6tv1.VirtualMachineInstanceFileSystemList, error)
+
+type filesystemsEntry struct {
+	filesystems *k6tv1.VirtualMachineInstanceFileSystemList
+	fetchedAt   time.Time
+	fetching    bool
+}
+
+// filesystemsCache keeps the last known guest filesystems of the VMIs.
+// The guest agent may take long to answer, so the filesystems are fetched in
+// the background and scrapes only report what is in the cache.
+type filesystemsCache struct {
+	lock            sync.Mutex
+	entries         map[types.UID]*filesystemsEntry
+	fetch           filesystemsFetcher
+	refreshInterval time.Duration
+}
+
+func newFilesystemsCache(fetch filesystemsFetcher, refreshInterval time.Duration) *filesystemsCache {
+	return &filesystemsCache{
+		entries:         map[types.UID]*filesystemsEntry{},
+		fetch:           fetch,
+		refreshInterval: refreshInterval,
+	}
+}
+
+// Get returns the cached filesystems of the VMI, which may be nil, and fetches
+// them in the background if they are outdated. It never waits for the guest agent.
+func (c *filesystemsCache) Get(socketFile string, vmi *k6tv1.VirtualMachineInstance) *k6tv1.VirtualMachineInstanceFileSystemList {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	entry, exists := c.entries[vmi.UID]
+	if !exists {
+		entry = &filesystemsEntry{}
+		c.entries[vmi.UID] = entry
+	}
+	if !entry.fetching && time.Since(entry.fetchedAt) >= c.refreshInterval {
+		entry.fetching = true
+		go c.refresh(socketFile, vmi, entry)
+	}
+	return entry.filesystems
+}
+
+func (c *filesystemsCache) refresh(socketFile string, vmi *k6tv1.VirtualMachineInstance, entry *filesystemsEntry) {
+	filesystems, err := c.fetch(socketFile, vmi)
+	if err != nil {
+		log.Log.Reason(err).V(2).Infof("failed to get the guest filesystems from socket %s", socketFile)
+		filesystems = nil
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	entry.filesystems = filesystems
+	entry.fetchedAt = time.Now()
+	entry.fetching = false
+}
+
+// Prune drops the cached filesystems of all VMIs which are not in the given list
+func (c *filesystemsCache) Prune(vmis []*k6tv1.VirtualMachineInstance) {
+	current := map[types.UID]bool{}
+	for _, vmi := range vmis {
+		current[vmi.UID] = true
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	for uid := range c.entries {
+		if !current[uid] {
+			delete(c.entries, uid)
+		}
+	}
+}
+
+func fetchFilesystems(socketFile string, vmi *k6tv1.VirtualMachineInstance) (*k6tv1.VirtualMachineInstanceFileSystemList, error) {
+	cli, err := cmdclient.NewClient(socketFile)
+	if err != nil {
+		return nil, err
+	}
+	defer cli.Close()
+	return cli.GetFilesystems(vmi)
+}
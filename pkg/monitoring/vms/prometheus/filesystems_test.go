@@ -0,0 +1,120 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2020 Red Hat, Inc.
+ *
+ */
+
+package prometheus
+
+import (
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	k6tv1 "kubevirt.io/client-go/api/v1"
+)
+
+var _ = Describe("Guest filesystems cache", func() {
+	var vmi *k6tv1.VirtualMachineInstance
+	var filesystems *k6tv1.VirtualMachineInstanceFileSystemList
+	var fetches chan string
+	var release chan error
+	var fetch filesystemsFetcher
+
+	BeforeEach(func() {
+		vmi = &k6tv1.VirtualMachineInstance{ObjectMeta: metav1.ObjectMeta{UID: "1234"}}
+		filesystems = &k6tv1.VirtualMachineInstanceFileSystemList{
+			Items: []k6tv1.VirtualMachineInstanceFileSystem{
+				{DiskName: "vda1", MountPoint: "/", UsedBytes: 1024, TotalBytes: 4096},
+			},
+		}
+
+		// fetches of former tests may still be running, only hand out locals to them
+		fetched, released, result := make(chan string, 10), make(chan error, 10), filesystems
+		fetches, release = fetched, released
+		fetch = func(socketFile string, vmi *k6tv1.VirtualMachineInstance) (*k6tv1.VirtualMachineInstanceFileSystemList, error) {
+			fetched <- socketFile
+			if err := <-released; err != nil {
+				return nil, err
+			}
+			return result, nil
+		}
+	})
+
+	It("should fetch the filesystems in the background without waiting for them", func() {
+		cache := newFilesystemsCache(fetch, time.Hour)
+
+		Expect(cache.Get("test", vmi)).To(BeNil())
+		Eventually(fetches).Should(Receive(Equal("test")))
+
+		// the guest agent has not answered yet
+		Expect(cache.Get("test", vmi)).To(BeNil())
+		Consistently(fetches).ShouldNot(Receive())
+
+		release <- nil
+		Eventually(func() *k6tv1.VirtualMachineInstanceFileSystemList {
+			return cache.Get("test", vmi)
+		}).Should(Equal(filesystems))
+		Consistently(fetches).ShouldNot(Receive())
+	})
+
+	It("should fetch the filesystems again once they are outdated", func() {
+		cache := newFilesystemsCache(fetch, 0)
+
+		cache.Get("test", vmi)
+		Eventually(fetches).Should(Receive())
+		release <- nil
+		Eventually(func() *k6tv1.VirtualMachineInstanceFileSystemList {
+			return cache.Get("test", vmi)
+		}).Should(Equal(filesystems))
+		Eventually(fetches).Should(Receive())
+	})
+
+	It("should drop the filesystems if fetching them failed", func() {
+		cache := newFilesystemsCache(fetch, 0)
+
+		cache.Get("test", vmi)
+		Eventually(fetches).Should(Receive())
+		release <- nil
+		Eventually(func() *k6tv1.VirtualMachineInstanceFileSystemList {
+			return cache.Get("test", vmi)
+		}).Should(Equal(filesystems))
+
+		Eventually(fetches).Should(Receive())
+		release <- fmt.Errorf("guest agent disconnected")
+		Eventually(func() *k6tv1.VirtualMachineInstanceFileSystemList {
+			return cache.Get("test", vmi)
+		}).Should(BeNil())
+	})
+
+	It("should forget VMIs which are gone", func() {
+		cache := newFilesystemsCache(fetch, time.Hour)
+
+		cache.Get("test", vmi)
+		Eventually(fetches).Should(Receive())
+		release <- nil
+		Eventually(func() *k6tv1.VirtualMachineInstanceFileSystemList {
+			return cache.Get("test", vmi)
+		}).Should(Equal(filesystems))
+
+		cache.Prune([]*k6tv1.VirtualMachineInstance{})
+		Expect(cache.Get("test", vmi)).To(BeNil())
+		Eventually(fetches).Should(Receive())
+	})
+})
@@ -27,6 +27,7 @@ import (
 
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
+	k8sv1 "k8s.io/api/core/v1"
 
 	k6tv1 "kubevirt.io/client-go/api/v1"
 	"kubevirt.io/client-go/kubecli"
@@ -150,6 +151,25 @@ var (
 		},
 		nil,
 	)
+
+	filesystemUsedBytesDesc = prometheus.NewDesc(
+		"kubevirt_vmi_filesystem_used_bytes",
+		"used space of a filesystem mounted in the guest, as reported by the guest agent.",
+		[]string{
+			"node", "namespace", "name",
+			"disk_name", "mount_point", "file_system_type",
+		},
+		nil,
+	)
+	filesystemCapacityBytesDesc = prometheus.NewDesc(
+		"kubevirt_vmi_filesystem_capacity_bytes",
+		"total space of a filesystem mounted in the guest, as reported by the guest agent.",
+		[]string{
+			"node", "namespace", "name",
+			"disk_name", "mount_point", "file_system_type",
+		},
+		nil,
+	)
 )
 
 func tryToPushMetric(desc *prometheus.Desc, mv prometheus.Metric, err error, ch chan<- prometheus.Metric) {
@@ -349,6 +369,38 @@ func updateNetwork(vmi *k6tv1.VirtualMachineInstance, vmStats *stats.DomainStats
 	}
 }
 
+func updateFilesystems(vmi *k6tv1.VirtualMachineInstance, filesystems *k6tv1.VirtualMachineInstanceFileSystemList, ch chan<- prometheus.Metric) {
+	if filesystems == nil {
+		return
+	}
+	for _, fs := range filesystems.Items {
+		mv, err := prometheus.NewConstMetric(
+			filesystemUsedBytesDesc, prometheus.GaugeValue,
+			float64(fs.UsedBytes),
+			vmi.Status.NodeName, vmi.Namespace, vmi.Name,
+			fs.DiskName, fs.MountPoint, fs.FileSystemType,
+		)
+		tryToPushMetric(filesystemUsedBytesDesc, mv, err, ch)
+
+		mv, err = prometheus.NewConstMetric(
+			filesystemCapacityBytesDesc, prometheus.GaugeValue,
+			float64(fs.TotalBytes),
+			vmi.Status.NodeName, vmi.Namespace, vmi.Name,
+			fs.DiskName, fs.MountPoint, fs.FileSystemType,
+		)
+		tryToPushMetric(filesystemCapacityBytesDesc, mv, err, ch)
+	}
+}
+
+func isGuestAgentConnected(vmi *k6tv1.VirtualMachineInstance) bool {
+	for _, cond := range vmi.Status.Conditions {
+		if cond.Type == k6tv1.VirtualMachineInstanceAgentConnected && cond.Status == k8sv1.ConditionTrue {
+			return true
+		}
+	}
+	return false
+}
+
 func makeVMIsPhasesMap(vmis []*k6tv1.VirtualMachineInstance) map[string]uint64 {
 	phasesMap := make(map[string]uint64)
 
@@ -389,6 +441,7 @@ type Collector struct {
 	virtShareDir  string
 	nodeName      string
 	concCollector *concurrentCollector
+	filesystems   *filesystemsCache
 }
 
 func SetupCollector(virtCli kubecli.KubevirtClient, virtShareDir, nodeName string) *Collector {
@@ -398,6 +451,7 @@ func SetupCollector(virtCli kubecli.KubevirtClient, virtShareDir, nodeName strin
 		virtShareDir:  virtShareDir,
 		nodeName:      nodeName,
 		concCollector: NewConcurrentCollector(),
+		filesystems:   newFilesystemsCache(fetchFilesystems, filesystemsRefreshInterval),
 	}
 	prometheus.MustRegister(co)
 	return co
@@ -415,6 +469,8 @@ func (co *Collector) Describe(ch chan<- *prometheus.Desc) {
 	ch <- networkErrorsDesc
 	ch <- memoryAvailableDesc
 	ch <- memoryResidentDesc
+	ch <- filesystemUsedBytesDesc
+	ch <- filesystemCapacityBytesDesc
 }
 
 func newvmiSocketMapFromVMIs(baseDir string, vmis []*k6tv1.VirtualMachineInstance) vmiSocketMap {
@@ -445,8 +501,9 @@ func (co *Collector) Collect(ch chan<- prometheus.Metric) {
 		return
 	}
 
+	co.filesystems.Prune(vmis)
 	socketToVMIs := newvmiSocketMapFromVMIs(co.virtShareDir, vmis)
-	scraper := &prometheusScraper{ch: ch}
+	scraper := &prometheusScraper{ch: ch, filesystems: co.filesystems}
 	co.concCollector.Collect(socketToVMIs, scraper, collectionTimeout)
 
 	updateVMIsPhase(co.nodeName, vmis, ch)
@@ -454,7 +511,8 @@ func (co *Collector) Collect(ch chan<- prometheus.Metric) {
 }
 
 type prometheusScraper struct {
-	ch chan<- prometheus.Metric
+	ch          chan<- prometheus.Metric
+	filesystems *filesystemsCache
 }
 
 type vmiStatsInfo struct {
@@ -485,6 +543,12 @@ func (ps *prometheusScraper) Scrape(socketFile string, vmi *k6tv1.VirtualMachine
 		return
 	}
 
+	// The filesystems are only known to the guest agent, a VM without one is still reported
+	var filesystems *k6tv1.VirtualMachineInstanceFileSystemList
+	if isGuestAgentConnected(vmi) {
+		filesystems = ps.filesystems.Get(socketFile, vmi)
+	}
+
 	// GetDomainStats() may hang for a long time.
 	// If it wakes up past the timeout, there is no point in send back any metric.
 	// In the best case the information is stale, in the worst case the information is stale *and*
@@ -495,10 +559,10 @@ func (ps *prometheusScraper) Scrape(socketFile string, vmi *k6tv1.VirtualMachine
 		return
 	}
 
-	ps.Report(socketFile, vmi, vmStats)
+	ps.Report(socketFile, vmi, vmStats, filesystems)
 }
 
-func (ps *prometheusScraper) Report(socketFile string, vmi *k6tv1.VirtualMachineInstance, vmStats *stats.DomainStats) {
+func (ps *prometheusScraper) Report(socketFile string, vmi *k6tv1.VirtualMachineInstance, vmStats *stats.DomainStats, filesystems *k6tv1.VirtualMachineInstanceFileSystemList) {
 	// statsMaxAge is an estimation - and there is not better way to do that. So it is possible that
 	// GetDomainStats() takes enough time to lag behind, but not enough to trigger the statsMaxAge check.
 	// In this case the next functions will end up writing on a closed channel. This will panic.
@@ -515,6 +579,7 @@ func (ps *prometheusScraper) Report(socketFile string, vmi *k6tv1.VirtualMachine
 	updateVcpu(vmi, vmStats, ps.ch)
 	updateBlock(vmi, vmStats, ps.ch)
 	updateNetwork(vmi, vmStats, ps.ch)
+	updateFilesystems(vmi, filesystems, ps.ch)
 }
 
 func Handler(MaxRequestsInFlight int) http.Handler {
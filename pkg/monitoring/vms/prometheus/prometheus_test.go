@@ -23,6 +23,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/prometheus/client_golang/prometheus"
+	io_prometheus_client "github.com/prometheus/client_model/go"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -54,13 +55,51 @@ var _ = Describe("Prometheus", func() {
 					},
 				}
 				vmi := k6tv1.VirtualMachineInstance{}
-				ps.Report("test", &vmi, vmStats)
+				ps.Report("test", &vmi, vmStats, nil)
 			}
 			Expect(testReportPanic).ToNot(Panic())
 		})
 	})
 })
 
+var _ = Describe("Guest filesystems", func() {
+	It("should report the used and total space of every filesystem", func() {
+		ch := make(chan prometheus.Metric, 4)
+
+		vmi := &k6tv1.VirtualMachineInstance{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "testvmi",
+				Namespace: "default",
+			},
+		}
+		filesystems := &k6tv1.VirtualMachineInstanceFileSystemList{
+			Items: []k6tv1.VirtualMachineInstanceFileSystem{
+				{DiskName: "vda1", MountPoint: "/", FileSystemType: "ext4", UsedBytes: 1024, TotalBytes: 4096},
+			},
+		}
+		updateFilesystems(vmi, filesystems, ch)
+		close(ch)
+
+		metrics := []prometheus.Metric{}
+		for metric := range ch {
+			metrics = append(metrics, metric)
+		}
+		Expect(metrics).To(HaveLen(2))
+		Expect(metrics[0].Desc()).To(Equal(filesystemUsedBytesDesc))
+		Expect(metrics[1].Desc()).To(Equal(filesystemCapacityBytesDesc))
+
+		dto := &io_prometheus_client.Metric{}
+		Expect(metrics[1].Write(dto)).To(Succeed())
+		Expect(dto.GetGauge().GetValue()).To(Equal(float64(4096)))
+	})
+
+	It("should not report anything without filesystems", func() {
+		ch := make(chan prometheus.Metric, 1)
+		updateFilesystems(&k6tv1.VirtualMachineInstance{}, nil, ch)
+		Expect(ch).To(BeEmpty())
+	})
+})
+
 var _ = Describe("Utility functions", func() {
 	Context("VMI Phases map reporting", func() {
 		It("should handle missing VMs", func() {
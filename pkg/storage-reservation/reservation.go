@@ -0,0 +1,54 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2020 Red Hat, Inc.
+ *
+ */
+package reservation
+
+import (
+	"path/filepath"
+
+	v1 "kubevirt.io/client-go/api/v1"
+)
+
+// SCSI persistent reservations of LUNs are handled by the qemu-pr-helper, which
+// runs on every node next to virt-handler. QEMU talks to it over a unix socket
+// in the shared kubevirt directory, which is mounted into every virt-launcher pod.
+const (
+	// SocketDir is the node directory holding the qemu-pr-helper socket
+	SocketDir = "/var/run/kubevirt/daemons/pr"
+	// SocketName is the name of the qemu-pr-helper socket
+	SocketName = "pr-helper.sock"
+	// ContainerName is the name of the qemu-pr-helper container of virt-handler
+	ContainerName = "pr-helper"
+	// VolumeName is the name of the virt-handler pod volume holding the socket directory
+	VolumeName = "pr-helper-socket-vol"
+)
+
+// SocketPath returns the path of the qemu-pr-helper socket
+func SocketPath() string {
+	return filepath.Join(SocketDir, SocketName)
+}
+
+// HasVMIPersistentReservation returns true if one of the LUNs of the VirtualMachineInstance requests SCSI persistent reservations
+func HasVMIPersistentReservation(vmi *v1.VirtualMachineInstance) bool {
+	for _, disk := range vmi.Spec.Domain.Devices.Disks {
+		if disk.LUN != nil && disk.LUN.Reservations {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,52 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2020 Red Hat, Inc.
+ *
+ */
+package reservation
+
+import (
+	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+
+	v1 "kubevirt.io/client-go/api/v1"
+)
+
+var _ = Describe("Reservation", func() {
+
+	It("should place the socket in the socket directory", func() {
+		Expect(SocketPath()).To(Equal("/var/run/kubevirt/daemons/pr/pr-helper.sock"))
+	})
+
+	table.DescribeTable("should detect persistent reservations", func(disks []v1.Disk, expected bool) {
+		vmi := v1.NewMinimalVMI("testvmi")
+		vmi.Spec.Domain.Devices.Disks = disks
+		Expect(HasVMIPersistentReservation(vmi)).To(Equal(expected))
+	},
+		table.Entry("without disks", nil, false),
+		table.Entry("with a disk", []v1.Disk{
+			{Name: "disk", DiskDevice: v1.DiskDevice{Disk: &v1.DiskTarget{Bus: "scsi"}}},
+		}, false),
+		table.Entry("with a LUN without reservations", []v1.Disk{
+			{Name: "lun", DiskDevice: v1.DiskDevice{LUN: &v1.LunTarget{Bus: "scsi"}}},
+		}, false),
+		table.Entry("with a LUN with reservations", []v1.Disk{
+			{Name: "disk", DiskDevice: v1.DiskDevice{Disk: &v1.DiskTarget{Bus: "virtio"}}},
+			{Name: "lun", DiskDevice: v1.DiskDevice{LUN: &v1.LunTarget{Bus: "scsi", Reservations: true}}},
+		}, true),
+	)
+})
@@ -7,6 +7,7 @@ import (
 	"k8s.io/apimachinery/pkg/util/rand"
 	"k8s.io/client-go/tools/cache"
 
+	virtv1 "kubevirt.io/client-go/api/v1"
 	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
 )
 
@@ -24,7 +25,21 @@ func NewFakeClusterConfig(cfgMap *v1.ConfigMap) (*virtconfig.ClusterConfig, cach
 
 	AddDataVolumeAPI(crdInformer)
 
-	return virtconfig.NewClusterConfig(configMapInformer, crdInformer, namespace), configMapInformer, crdInformer
+	kubeVirtInformer, _ := NewFakeInformerFor(&virtv1.KubeVirt{})
+
+	return virtconfig.NewClusterConfig(configMapInformer, crdInformer, kubeVirtInformer, namespace), configMapInformer, crdInformer
+}
+
+func NewFakeClusterConfigUsingKV(kv *virtv1.KubeVirt) (*virtconfig.ClusterConfig, cache.SharedIndexInformer, cache.SharedIndexInformer, cache.SharedIndexInformer) {
+	configMapInformer, _ := NewFakeInformerFor(&v1.ConfigMap{})
+	crdInformer, _ := NewFakeInformerFor(&extv1beta1.CustomResourceDefinition{})
+	kubeVirtInformer, _ := NewFakeInformerFor(&virtv1.KubeVirt{})
+
+	kubeVirtInformer.GetStore().Add(copyKubeVirt(kv))
+
+	AddDataVolumeAPI(crdInformer)
+
+	return virtconfig.NewClusterConfig(configMapInformer, crdInformer, kubeVirtInformer, namespace), configMapInformer, crdInformer, kubeVirtInformer
 }
 
 func RemoveDataVolumeAPI(crdInformer cache.SharedIndexInformer) {
@@ -46,6 +61,21 @@ func UpdateFakeClusterConfig(configMapInformer cache.SharedIndexInformer, cfgMap
 	configMapInformer.GetStore().Update(copy)
 }
 
+func UpdateFakeKubeVirtClusterConfig(kubeVirtInformer cache.SharedIndexInformer, kv *virtv1.KubeVirt) {
+	kubeVirtInformer.GetStore().Update(copyKubeVirt(kv))
+}
+
+func copyKubeVirt(kv *virtv1.KubeVirt) *virtv1.KubeVirt {
+	copy := kv.DeepCopy()
+	copy.ObjectMeta = v12.ObjectMeta{
+		Namespace: namespace,
+		Name:      "kubevirt",
+		// Change the resource version, like the API server does
+		ResourceVersion: rand.String(10),
+	}
+	return copy
+}
+
 func copy(cfgMap *v1.ConfigMap) *v1.ConfigMap {
 	copy := cfgMap.DeepCopy()
 	copy.ObjectMeta = v12.ObjectMeta{
@@ -0,0 +1,58 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2019 Red Hat, Inc.
+ *
+ */
+
+package usbredir
+
+import (
+	"fmt"
+
+	v1 "kubevirt.io/client-go/api/v1"
+)
+
+const (
+	// DefaultSlots is the number of USB redirection channels if the vmi does not ask for a specific number
+	DefaultSlots = 4
+	// MaxSlots is the maximum number of USB redirection channels, the USB controller
+	// needs to provide a port for each of them
+	MaxSlots = 8
+	// ControllerPorts is the number of ports of the USB controller of vmis with USB redirection
+	ControllerPorts = 15
+)
+
+// IsEnabled returns true if the vmi has USB redirection channels
+func IsEnabled(vmi *v1.VirtualMachineInstance) bool {
+	return vmi.Spec.Domain.Devices.USBRedirect != nil
+}
+
+// Slots returns the number of USB redirection channels of the vmi
+func Slots(vmi *v1.VirtualMachineInstance) int {
+	if !IsEnabled(vmi) {
+		return 0
+	}
+	if slots := vmi.Spec.Domain.Devices.USBRedirect.Slots; slots != nil {
+		return int(*slots)
+	}
+	return DefaultSlots
+}
+
+// SocketName returns the name of the unix socket of a USB redirection channel,
+// which QEMU creates in the private directory of the vmi
+func SocketName(slot int) string {
+	return fmt.Sprintf("virt-usbredir%d", slot)
+}
@@ -0,0 +1,55 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2019 Red Hat, Inc.
+ *
+ */
+
+package usbredir
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	v1 "kubevirt.io/client-go/api/v1"
+)
+
+var _ = Describe("USBRedir", func() {
+
+	It("should be disabled by default", func() {
+		vmi := v1.NewMinimalVMI("testvmi")
+		Expect(IsEnabled(vmi)).To(BeFalse())
+		Expect(Slots(vmi)).To(BeZero())
+	})
+
+	It("should provide the default number of slots if enabled", func() {
+		vmi := v1.NewMinimalVMI("testvmi")
+		vmi.Spec.Domain.Devices.USBRedirect = &v1.USBRedirect{}
+		Expect(IsEnabled(vmi)).To(BeTrue())
+		Expect(Slots(vmi)).To(Equal(DefaultSlots))
+	})
+
+	It("should provide the requested number of slots", func() {
+		vmi := v1.NewMinimalVMI("testvmi")
+		slots := int32(2)
+		vmi.Spec.Domain.Devices.USBRedirect = &v1.USBRedirect{Slots: &slots}
+		Expect(Slots(vmi)).To(Equal(2))
+	})
+
+	It("should name the sockets after the slot", func() {
+		Expect(SocketName(0)).To(Equal("virt-usbredir0"))
+		Expect(SocketName(3)).To(Equal("virt-usbredir3"))
+	})
+})
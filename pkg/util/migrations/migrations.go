@@ -1,6 +1,7 @@
 package migrations
 
 import (
+	k8sv1 "k8s.io/api/core/v1"
 	"k8s.io/client-go/tools/cache"
 
 	v1 "kubevirt.io/client-go/api/v1"
@@ -27,3 +28,36 @@ func FilterRunningMigrations(migrations []v1.VirtualMachineInstanceMigration) []
 	}
 	return runningMigrations
 }
+
+// ApplyMigratedVolumes returns a copy of the volumes where the volumes which are migrated
+// to other PVCs point to their destination claims. DataVolumes become plain PVC volumes,
+// since the destination PVC is not owned by a DataVolume.
+func ApplyMigratedVolumes(volumes []v1.Volume, migratedVolumes []v1.StorageMigratedVolume) []v1.Volume {
+	destinations := map[string]string{}
+	for _, migratedVolume := range migratedVolumes {
+		destinations[migratedVolume.VolumeName] = migratedVolume.DestinationClaimName
+	}
+
+	result := []v1.Volume{}
+	for _, volume := range volumes {
+		volume := *volume.DeepCopy()
+		if claimName, ok := destinations[volume.Name]; ok {
+			volume.VolumeSource = v1.VolumeSource{
+				PersistentVolumeClaim: &k8sv1.PersistentVolumeClaimVolumeSource{ClaimName: claimName},
+			}
+		}
+		result = append(result, volume)
+	}
+	return result
+}
+
+// GetVolumeClaimName returns the name of the PVC backing a PVC or DataVolume volume
+func GetVolumeClaimName(volume *v1.Volume) (string, bool) {
+	switch {
+	case volume.PersistentVolumeClaim != nil:
+		return volume.PersistentVolumeClaim.ClaimName, true
+	case volume.DataVolume != nil:
+		return volume.DataVolume.Name, true
+	}
+	return "", false
+}
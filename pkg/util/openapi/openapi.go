@@ -2,6 +2,7 @@ package openapi
 
 import (
 	"encoding/json"
+	"reflect"
 	"strings"
 
 	"github.com/emicklei/go-restful"
@@ -140,6 +141,15 @@ func CreateOpenAPIValidator(webServices []*restful.WebService) *Validator {
 	if err != nil {
 		panic(err)
 	}
+	// Named types like v1.EvictionStrategy and empty structs like
+	// v1.InterfaceBridge result in empty definitions, references to them
+	// can't be resolved while expanding
+	for k, v := range statusSchema.Definitions {
+		if reflect.DeepEqual(v, spec.Schema{}) {
+			v.AdditionalProperties = &spec.SchemaOrBool{Allows: true}
+			statusSchema.Definitions[k] = v
+		}
+	}
 	// Expand the statusSchemes
 	err = spec.ExpandSchema(statusSchema, statusSchema, nil)
 	if err != nil {
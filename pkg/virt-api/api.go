@@ -20,12 +20,15 @@
 package virt_api
 
 import (
+	"bytes"
 	"crypto/tls"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"net/http"
+	"path/filepath"
 	"sync"
+	"time"
 
 	restful "github.com/emicklei/go-restful"
 	restfulspec "github.com/emicklei/go-restful-openapi"
@@ -33,13 +36,11 @@ import (
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	flag "github.com/spf13/pflag"
 	admissionregistrationv1beta1 "k8s.io/api/admissionregistration/v1beta1"
-	k8sv1 "k8s.io/api/core/v1"
-	"k8s.io/apimachinery/pkg/api/errors"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/tools/cache"
-	"k8s.io/client-go/util/cert"
 	apiregistrationv1beta1 "k8s.io/kube-aggregator/pkg/apis/apiregistration/v1beta1"
 	aggregatorclient "k8s.io/kube-aggregator/pkg/client/clientset_generated/clientset"
 
@@ -48,7 +49,7 @@ import (
 	"kubevirt.io/client-go/log"
 	clientutil "kubevirt.io/client-go/util"
 	virtversion "kubevirt.io/client-go/version"
-	"kubevirt.io/kubevirt/pkg/certificates/triple"
+	"kubevirt.io/kubevirt/pkg/certificates/bootstrap"
 	"kubevirt.io/kubevirt/pkg/controller"
 	"kubevirt.io/kubevirt/pkg/healthz"
 	"kubevirt.io/kubevirt/pkg/rest/filter"
@@ -60,6 +61,7 @@ import (
 	mutating_webhook "kubevirt.io/kubevirt/pkg/virt-api/webhooks/mutating-webhook"
 	validating_webhook "kubevirt.io/kubevirt/pkg/virt-api/webhooks/validating-webhook"
 	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
+	"kubevirt.io/kubevirt/pkg/virt-operator/creation/components"
 )
 
 const (
@@ -69,9 +71,6 @@ const (
 	// Default address that virt-api listens on.
 	defaultHost = "0.0.0.0"
 
-	// selfsigned cert secret name
-	virtApiCertSecretName = "kubevirt-virt-api-certs"
-
 	virtWebhookValidator = "virt-api-validator"
 	virtWebhookMutator   = "virt-api-mutator"
 
@@ -84,16 +83,20 @@ const (
 	vmipresetValidatePath       = "/vmipreset-validate"
 	migrationCreateValidatePath = "/migration-validate-create"
 	migrationUpdateValidatePath = "/migration-validate-update"
+	cdicValidatePath            = "/containerdiskimagecache-validate"
+	backupValidatePath          = "/virtualmachinebackup-validate"
+	exportValidatePath          = "/virtualmachineexport-validate"
+	kubeVirtValidatePath        = "/kubevirt-validate"
 
 	vmMutatePath        = "/virtualmachines-mutate"
 	vmiMutatePath       = "/virtualmachineinstances-mutate"
 	migrationMutatePath = "/migration-mutate-create"
 
-	certBytesValue        = "cert-bytes"
-	keyBytesValue         = "key-bytes"
-	signingCertBytesValue = "signing-cert-bytes"
-
 	defaultConsoleServerPort = 8186
+
+	// interval at which the CA bundle of the webhooks and the
+	// APIService is compared to the mounted one
+	caBundleSyncInterval = 30 * time.Second
 )
 
 type VirtApi interface {
@@ -114,12 +117,12 @@ type virtAPIApp struct {
 	certsDirectory   string
 	clusterConfig    *virtconfig.ClusterConfig
 
-	signingCertBytes  []byte
-	certBytes         []byte
-	keyBytes          []byte
-	namespace         string
-	tlsConfig         *tls.Config
-	consoleServerPort int
+	signingCertBytes   []byte
+	certmanager        bootstrap.CertificateManager
+	handlerCertManager bootstrap.CertificateManager
+	namespace          string
+	tlsConfig          *tls.Config
+	consoleServerPort  int
 }
 
 var _ service.Service = &virtAPIApp{}
@@ -164,6 +167,9 @@ func (app *virtAPIApp) Execute() {
 		panic(err)
 	}
 
+	app.certmanager = bootstrap.NewFileCertificateManager(bootstrap.VirtApiCertDir)
+	app.handlerCertManager = bootstrap.NewFileCertificateManager(bootstrap.VirtHandlerCertDir)
+
 	app.Compose()
 	app.ConfigureOpenAPIService()
 	app.Run()
@@ -204,7 +210,8 @@ func (app *virtAPIApp) composeSubresources() {
 		subws.Doc(fmt.Sprintf("KubeVirt \"%s\" Subresource API.", version.Version))
 		subws.Path(rest.GroupVersionBasePath(version))
 
-		subresourceApp := rest.NewSubresourceAPIApp(app.virtCli, app.consoleServerPort)
+		subresourceApp := rest.NewSubresourceAPIApp(app.virtCli, app.consoleServerPort, app.handlerCertManager,
+			filepath.Join(components.VNCTokenKeyDir, components.VNCTokenKeyKey))
 
 		subws.Route(subws.PUT(rest.ResourcePath(subresourcesvmGVR)+rest.SubResourcePath("restart")).
 			To(subresourceApp.RestartVMRequestHandler).
@@ -224,6 +231,35 @@ func (app *virtAPIApp) composeSubresources() {
 			Returns(http.StatusNotFound, "Not Found", nil).
 			Returns(http.StatusBadRequest, "Bad Request", nil))
 
+		subws.Route(subws.PUT(rest.ResourcePath(subresourcesvmGVR)+rest.SubResourcePath("migratevolumes")).
+			To(subresourceApp.MigrateVolumesVMRequestHandler).
+			Param(rest.NamespaceParam(subws)).Param(rest.NameParam(subws)).
+			Reads(v1.VirtualMachineMigrateVolumesRequest{}).
+			Operation("migrateVolumes").
+			Doc("Migrate a running VirtualMachine to another node while copying volumes to other PersistentVolumeClaims.").
+			Returns(http.StatusOK, "OK", nil).
+			Returns(http.StatusNotFound, "Not Found", nil).
+			Returns(http.StatusBadRequest, "Bad Request", nil))
+
+		subws.Route(subws.PUT(rest.ResourcePath(subresourcesvmGVR)+rest.SubResourcePath("memorydump")).
+			To(subresourceApp.MemoryDumpVMRequestHandler).
+			Param(rest.NamespaceParam(subws)).Param(rest.NameParam(subws)).
+			Reads(v1.VirtualMachineMemoryDumpRequest{}).
+			Operation("memoryDump").
+			Doc("Dump the memory of a running VirtualMachine into a PersistentVolumeClaim.").
+			Returns(http.StatusOK, "OK", nil).
+			Returns(http.StatusNotFound, "Not Found", nil).
+			Returns(http.StatusBadRequest, "Bad Request", nil))
+
+		subws.Route(subws.PUT(rest.ResourcePath(subresourcesvmGVR)+rest.SubResourcePath("removememorydump")).
+			To(subresourceApp.RemoveMemoryDumpVMRequestHandler).
+			Param(rest.NamespaceParam(subws)).Param(rest.NameParam(subws)).
+			Operation("removeMemoryDump").
+			Doc("Dissociate the memory dump PersistentVolumeClaim from a VirtualMachine.").
+			Returns(http.StatusOK, "OK", nil).
+			Returns(http.StatusNotFound, "Not Found", nil).
+			Returns(http.StatusBadRequest, "Bad Request", nil))
+
 		subws.Route(subws.PUT(rest.ResourcePath(subresourcesvmGVR)+rest.SubResourcePath("start")).
 			To(subresourceApp.StartVMRequestHandler).
 			Param(rest.NamespaceParam(subws)).Param(rest.NameParam(subws)).
@@ -260,18 +296,125 @@ func (app *virtAPIApp) composeSubresources() {
 			Returns(http.StatusNotFound, "Not Found", nil).
 			Returns(http.StatusBadRequest, "Bad Request", nil))
 
+		subws.Route(subws.PUT(rest.ResourcePath(subresourcesvmiGVR)+rest.SubResourcePath("softreboot")).
+			To(subresourceApp.SoftRebootVMIRequestHandler).
+			Param(rest.NamespaceParam(subws)).Param(rest.NameParam(subws)).
+			Operation("softreboot").
+			Doc("Soft reboot a VirtualMachineInstance object.").
+			Returns(http.StatusOK, "OK", nil).
+			Returns(http.StatusNotFound, "Not Found", nil).
+			Returns(http.StatusBadRequest, "Bad Request", nil))
+
 		subws.Route(subws.GET(rest.ResourcePath(subresourcesvmiGVR) + rest.SubResourcePath("console")).
 			To(subresourceApp.ConsoleRequestHandler).
 			Param(rest.NamespaceParam(subws)).Param(rest.NameParam(subws)).
+			Param(rest.ReadOnlyParam(subws)).
 			Operation("console").
 			Doc("Open a websocket connection to a serial console on the specified VirtualMachineInstance."))
 
 		subws.Route(subws.GET(rest.ResourcePath(subresourcesvmiGVR) + rest.SubResourcePath("vnc")).
 			To(subresourceApp.VNCRequestHandler).
 			Param(rest.NamespaceParam(subws)).Param(rest.NameParam(subws)).
+			Param(rest.ReadOnlyParam(subws)).
 			Operation("vnc").
 			Doc("Open a websocket connection to connect to VNC on the specified VirtualMachineInstance."))
 
+		subws.Route(subws.GET(rest.ResourcePath(subresourcesvmiGVR)+rest.SubResourcePath("vnc/token")).
+			To(subresourceApp.VNCTokenRequestHandler).
+			Param(rest.NamespaceParam(subws)).Param(rest.NameParam(subws)).
+			Param(rest.ReadOnlyParam(subws)).
+			Produces(restful.MIME_JSON).
+			Operation("vncToken").
+			Doc("Issue a short-lived token for the novnc subresource of the specified VirtualMachineInstance.").
+			Returns(http.StatusOK, "OK", v1.VNCToken{}).
+			Returns(http.StatusNotFound, "Not Found", nil).
+			Returns(http.StatusBadRequest, "Bad Request", nil))
+
+		subws.Route(subws.GET(rest.ResourcePath(subresourcesvmiGVR) + rest.SubResourcePath("novnc")).
+			To(subresourceApp.NoVNCRequestHandler).
+			Param(rest.NamespaceParam(subws)).Param(rest.NameParam(subws)).
+			Operation("novnc").
+			Doc("Open a websocket connection for noVNC clients to VNC on the specified VirtualMachineInstance, authorized by a token from the vnc/token subresource."))
+
+		subws.Route(subws.GET(rest.ResourcePath(subresourcesvmiGVR) + rest.SubResourcePath("usbredir")).
+			To(subresourceApp.USBRedirRequestHandler).
+			Param(rest.NamespaceParam(subws)).Param(rest.NameParam(subws)).
+			Operation("usbredir").
+			Doc("Open a websocket connection to a free USB redirection channel on the specified VirtualMachineInstance."))
+
+		subws.Route(subws.GET(rest.ResourcePath(subresourcesvmiGVR) + rest.SubResourcePath("portforward") + rest.PortPath).
+			To(subresourceApp.PortForwardRequestHandler).
+			Param(rest.NamespaceParam(subws)).Param(rest.NameParam(subws)).
+			Param(rest.PortParam(subws)).
+			Operation("portforward").
+			Doc("Open a websocket connection forwarding a tcp connection to the specified port of the VirtualMachineInstance."))
+
+		subws.Route(subws.GET(rest.ResourcePath(subresourcesvmiGVR)+rest.SubResourcePath("consolelog")).
+			To(subresourceApp.ConsoleLogRequestHandler).
+			Param(rest.NamespaceParam(subws)).Param(rest.NameParam(subws)).
+			Param(rest.SinceSecondsParam(subws)).Param(rest.TailLinesParam(subws)).
+			Produces("text/plain").
+			Operation("consolelog").
+			Doc("Get the serial console log of the specified VirtualMachineInstance.").
+			Returns(http.StatusOK, "OK", nil).
+			Returns(http.StatusNotFound, "Not Found", nil).
+			Returns(http.StatusBadRequest, "Bad Request", nil))
+
+		subws.Route(subws.GET(rest.ResourcePath(subresourcesvmiGVR)+rest.SubResourcePath("screenshot")).
+			To(subresourceApp.ScreenshotVMIRequestHandler).
+			Param(rest.NamespaceParam(subws)).Param(rest.NameParam(subws)).
+			Produces("image/png").
+			Operation("screenshot").
+			Doc("Get a PNG screenshot of the graphical console of the specified VirtualMachineInstance.").
+			Returns(http.StatusOK, "OK", nil).
+			Returns(http.StatusNotFound, "Not Found", nil).
+			Returns(http.StatusBadRequest, "Bad Request", nil))
+
+		subws.Route(subws.GET(rest.ResourcePath(subresourcesvmiGVR)+rest.SubResourcePath("guestosinfo")).
+			To(subresourceApp.GuestOSInfo).
+			Param(rest.NamespaceParam(subws)).Param(rest.NameParam(subws)).
+			Produces(restful.MIME_JSON).
+			Operation("guestosinfo").
+			Doc("Get guest agent os information").
+			Writes(v1.VirtualMachineInstanceGuestAgentInfo{}).
+			Returns(http.StatusOK, "OK", v1.VirtualMachineInstanceGuestAgentInfo{}).
+			Returns(http.StatusNotFound, "Not Found", nil).
+			Returns(http.StatusBadRequest, "Bad Request", nil))
+
+		subws.Route(subws.GET(rest.ResourcePath(subresourcesvmiGVR)+rest.SubResourcePath("userlist")).
+			To(subresourceApp.UserList).
+			Param(rest.NamespaceParam(subws)).Param(rest.NameParam(subws)).
+			Produces(restful.MIME_JSON).
+			Operation("userlist").
+			Doc("Get list of active users via guest agent").
+			Writes(v1.VirtualMachineInstanceGuestOSUserList{}).
+			Returns(http.StatusOK, "OK", v1.VirtualMachineInstanceGuestOSUserList{}).
+			Returns(http.StatusNotFound, "Not Found", nil).
+			Returns(http.StatusBadRequest, "Bad Request", nil))
+
+		subws.Route(subws.GET(rest.ResourcePath(subresourcesvmiGVR)+rest.SubResourcePath("filesystemlist")).
+			To(subresourceApp.FilesystemList).
+			Param(rest.NamespaceParam(subws)).Param(rest.NameParam(subws)).
+			Produces(restful.MIME_JSON).
+			Operation("filesystemlist").
+			Doc("Get list of active filesystems on guest machine via guest agent").
+			Writes(v1.VirtualMachineInstanceFileSystemList{}).
+			Returns(http.StatusOK, "OK", v1.VirtualMachineInstanceFileSystemList{}).
+			Returns(http.StatusNotFound, "Not Found", nil).
+			Returns(http.StatusBadRequest, "Bad Request", nil))
+
+		subws.Route(subws.PUT(rest.ResourcePath(subresourcesvmiGVR)+rest.SubResourcePath("guest-exec")).
+			To(subresourceApp.GuestExecRequestHandler).
+			Param(rest.NamespaceParam(subws)).Param(rest.NameParam(subws)).
+			Reads(v1.VirtualMachineInstanceGuestExecRequest{}).
+			Produces(restful.MIME_JSON).
+			Operation("guestExec").
+			Doc("Run a command in the guest of the specified VirtualMachineInstance via guest agent and return its output.").
+			Writes(v1.VirtualMachineInstanceGuestExecResult{}).
+			Returns(http.StatusOK, "OK", v1.VirtualMachineInstanceGuestExecResult{}).
+			Returns(http.StatusNotFound, "Not Found", nil).
+			Returns(http.StatusBadRequest, "Bad Request", nil))
+
 		subws.Route(subws.GET(rest.ResourcePath(subresourcesvmiGVR) + rest.SubResourcePath("test")).
 			To(func(request *restful.Request, response *restful.Response) {
 				response.WriteHeader(http.StatusOK)
@@ -312,10 +455,30 @@ func (app *virtAPIApp) composeSubresources() {
 						Name:       "virtualmachineinstances/vnc",
 						Namespaced: true,
 					},
+					{
+						Name:       "virtualmachineinstances/novnc",
+						Namespaced: true,
+					},
 					{
 						Name:       "virtualmachineinstances/console",
 						Namespaced: true,
 					},
+					{
+						Name:       "virtualmachineinstances/consolelog",
+						Namespaced: true,
+					},
+					{
+						Name:       "virtualmachineinstances/screenshot",
+						Namespaced: true,
+					},
+					{
+						Name:       "virtualmachineinstances/usbredir",
+						Namespaced: true,
+					},
+					{
+						Name:       "virtualmachineinstances/portforward",
+						Namespaced: true,
+					},
 					{
 						Name:       "virtualmachineinstances/pause",
 						Namespaced: true,
@@ -324,6 +487,26 @@ func (app *virtAPIApp) composeSubresources() {
 						Name:       "virtualmachineinstances/unpause",
 						Namespaced: true,
 					},
+					{
+						Name:       "virtualmachineinstances/softreboot",
+						Namespaced: true,
+					},
+					{
+						Name:       "virtualmachineinstances/guestosinfo",
+						Namespaced: true,
+					},
+					{
+						Name:       "virtualmachineinstances/userlist",
+						Namespaced: true,
+					},
+					{
+						Name:       "virtualmachineinstances/filesystemlist",
+						Namespaced: true,
+					},
+					{
+						Name:       "virtualmachineinstances/guest-exec",
+						Namespaced: true,
+					},
 					{
 						Name:       "virtualmachines/start",
 						Namespaced: true,
@@ -340,6 +523,18 @@ func (app *virtAPIApp) composeSubresources() {
 						Name:       "virtualmachines/migrate",
 						Namespaced: true,
 					},
+					{
+						Name:       "virtualmachines/migratevolumes",
+						Namespaced: true,
+					},
+					{
+						Name:       "virtualmachines/memorydump",
+						Namespaced: true,
+					},
+					{
+						Name:       "virtualmachines/removememorydump",
+						Namespaced: true,
+					},
 				}
 
 				response.WriteAsJson(list)
@@ -502,61 +697,6 @@ func (app *virtAPIApp) readRequestHeader() error {
 	return nil
 }
 
-func (app *virtAPIApp) getSelfSignedCert() error {
-	var ok bool
-
-	caKeyPair, _ := triple.NewCA("kubevirt.io")
-	keyPair, _ := triple.NewServerKeyPair(
-		caKeyPair,
-		"virt-api."+app.namespace+".pod.cluster.local",
-		"virt-api",
-		app.namespace,
-		"cluster.local",
-		nil,
-		nil,
-	)
-
-	secret := &k8sv1.Secret{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      virtApiCertSecretName,
-			Namespace: app.namespace,
-			Labels: map[string]string{
-				v1.AppLabel: "virt-api-aggregator",
-			},
-		},
-		Type: "Opaque",
-		Data: map[string][]byte{
-			certBytesValue:        cert.EncodeCertPEM(keyPair.Cert),
-			keyBytesValue:         cert.EncodePrivateKeyPEM(keyPair.Key),
-			signingCertBytesValue: cert.EncodeCertPEM(caKeyPair.Cert),
-		},
-	}
-	_, err := app.virtCli.CoreV1().Secrets(app.namespace).Create(secret)
-	if errors.IsAlreadyExists(err) {
-		secret, err = app.virtCli.CoreV1().Secrets(app.namespace).Get(virtApiCertSecretName, metav1.GetOptions{})
-		if err != nil {
-			return err
-		}
-	} else if err != nil {
-		return err
-	}
-	// retrieve self signed cert info from secret
-
-	app.certBytes, ok = secret.Data[certBytesValue]
-	if !ok {
-		return fmt.Errorf("%s value not found in %s virt-api secret", certBytesValue, virtApiCertSecretName)
-	}
-	app.keyBytes, ok = secret.Data[keyBytesValue]
-	if !ok {
-		return fmt.Errorf("%s value not found in %s virt-api secret", keyBytesValue, virtApiCertSecretName)
-	}
-	app.signingCertBytes, ok = secret.Data[signingCertBytesValue]
-	if !ok {
-		return fmt.Errorf("%s value not found in %s virt-api secret", signingCertBytesValue, virtApiCertSecretName)
-	}
-	return nil
-}
-
 func (app *virtAPIApp) createWebhook() error {
 	err := app.createValidatingWebhook()
 	if err != nil {
@@ -578,7 +718,14 @@ func (app *virtAPIApp) validatingWebhooks() []admissionregistrationv1beta1.Webho
 	vmipresetPath := vmipresetValidatePath
 	migrationCreatePath := migrationCreateValidatePath
 	migrationUpdatePath := migrationUpdateValidatePath
+	cdicPath := cdicValidatePath
+	backupPath := backupValidatePath
+	exportPath := exportValidatePath
+	kubeVirtPath := kubeVirtValidatePath
 	failurePolicy := admissionregistrationv1beta1.Fail
+	// virt-operator has to be able to update and remove the KubeVirt CR
+	// while virt-api is unavailable
+	ignorePolicy := admissionregistrationv1beta1.Ignore
 
 	webHooks := []admissionregistrationv1beta1.Webhook{
 		{
@@ -738,6 +885,98 @@ func (app *virtAPIApp) validatingWebhooks() []admissionregistrationv1beta1.Webho
 				CABundle: app.signingCertBytes,
 			},
 		},
+		{
+			Name:          "containerdiskimagecache-validator.kubevirt.io",
+			FailurePolicy: &failurePolicy,
+			Rules: []admissionregistrationv1beta1.RuleWithOperations{{
+				Operations: []admissionregistrationv1beta1.OperationType{
+					admissionregistrationv1beta1.Create,
+					admissionregistrationv1beta1.Update,
+				},
+				Rule: admissionregistrationv1beta1.Rule{
+					APIGroups:   []string{v1.GroupName},
+					APIVersions: v1.ApiSupportedWebhookVersions,
+					Resources:   []string{"containerdiskimagecaches"},
+				},
+			}},
+			ClientConfig: admissionregistrationv1beta1.WebhookClientConfig{
+				Service: &admissionregistrationv1beta1.ServiceReference{
+					Namespace: app.namespace,
+					Name:      virtApiServiceName,
+					Path:      &cdicPath,
+				},
+				CABundle: app.signingCertBytes,
+			},
+		},
+		{
+			Name:          "virtualmachinebackup-validator.kubevirt.io",
+			FailurePolicy: &failurePolicy,
+			Rules: []admissionregistrationv1beta1.RuleWithOperations{{
+				Operations: []admissionregistrationv1beta1.OperationType{
+					admissionregistrationv1beta1.Create,
+					admissionregistrationv1beta1.Update,
+				},
+				Rule: admissionregistrationv1beta1.Rule{
+					APIGroups:   []string{v1.GroupName},
+					APIVersions: v1.ApiSupportedWebhookVersions,
+					Resources:   []string{"virtualmachinebackups"},
+				},
+			}},
+			ClientConfig: admissionregistrationv1beta1.WebhookClientConfig{
+				Service: &admissionregistrationv1beta1.ServiceReference{
+					Namespace: app.namespace,
+					Name:      virtApiServiceName,
+					Path:      &backupPath,
+				},
+				CABundle: app.signingCertBytes,
+			},
+		},
+		{
+			Name:          "virtualmachineexport-validator.kubevirt.io",
+			FailurePolicy: &failurePolicy,
+			Rules: []admissionregistrationv1beta1.RuleWithOperations{{
+				Operations: []admissionregistrationv1beta1.OperationType{
+					admissionregistrationv1beta1.Create,
+					admissionregistrationv1beta1.Update,
+				},
+				Rule: admissionregistrationv1beta1.Rule{
+					APIGroups:   []string{v1.GroupName},
+					APIVersions: v1.ApiSupportedWebhookVersions,
+					Resources:   []string{"virtualmachineexports"},
+				},
+			}},
+			ClientConfig: admissionregistrationv1beta1.WebhookClientConfig{
+				Service: &admissionregistrationv1beta1.ServiceReference{
+					Namespace: app.namespace,
+					Name:      virtApiServiceName,
+					Path:      &exportPath,
+				},
+				CABundle: app.signingCertBytes,
+			},
+		},
+		{
+			Name:          "kubevirt-validator.kubevirt.io",
+			FailurePolicy: &ignorePolicy,
+			Rules: []admissionregistrationv1beta1.RuleWithOperations{{
+				Operations: []admissionregistrationv1beta1.OperationType{
+					admissionregistrationv1beta1.Create,
+					admissionregistrationv1beta1.Update,
+				},
+				Rule: admissionregistrationv1beta1.Rule{
+					APIGroups:   []string{v1.GroupName},
+					APIVersions: v1.ApiSupportedWebhookVersions,
+					Resources:   []string{"kubevirts"},
+				},
+			}},
+			ClientConfig: admissionregistrationv1beta1.WebhookClientConfig{
+				Service: &admissionregistrationv1beta1.ServiceReference{
+					Namespace: app.namespace,
+					Name:      virtApiServiceName,
+					Path:      &kubeVirtPath,
+				},
+				CABundle: app.signingCertBytes,
+			},
+		},
 	}
 
 	return webHooks
@@ -779,7 +1018,10 @@ func (app *virtAPIApp) createValidatingWebhook() error {
 			return err
 		}
 	}
+	return nil
+}
 
+func (app *virtAPIApp) registerValidatingWebhookHandlers() {
 	http.HandleFunc(vmiCreateValidatePath, func(w http.ResponseWriter, r *http.Request) {
 		validating_webhook.ServeVMICreate(w, r, app.clusterConfig)
 	})
@@ -796,13 +1038,23 @@ func (app *virtAPIApp) createValidatingWebhook() error {
 		validating_webhook.ServeVMIPreset(w, r)
 	})
 	http.HandleFunc(migrationCreateValidatePath, func(w http.ResponseWriter, r *http.Request) {
-		validating_webhook.ServeMigrationCreate(w, r, app.clusterConfig)
+		validating_webhook.ServeMigrationCreate(w, r, app.clusterConfig, app.virtCli)
 	})
 	http.HandleFunc(migrationUpdateValidatePath, func(w http.ResponseWriter, r *http.Request) {
 		validating_webhook.ServeMigrationUpdate(w, r)
 	})
-
-	return nil
+	http.HandleFunc(cdicValidatePath, func(w http.ResponseWriter, r *http.Request) {
+		validating_webhook.ServeContainerDiskImageCache(w, r)
+	})
+	http.HandleFunc(backupValidatePath, func(w http.ResponseWriter, r *http.Request) {
+		validating_webhook.ServeVirtualMachineBackup(w, r, app.clusterConfig)
+	})
+	http.HandleFunc(exportValidatePath, func(w http.ResponseWriter, r *http.Request) {
+		validating_webhook.ServeVirtualMachineExport(w, r)
+	})
+	http.HandleFunc(kubeVirtValidatePath, func(w http.ResponseWriter, r *http.Request) {
+		validating_webhook.ServeKubeVirt(w, r, app.virtCli)
+	})
 }
 
 func (app *virtAPIApp) mutatingWebhooks() []admissionregistrationv1beta1.Webhook {
@@ -914,17 +1166,19 @@ func (app *virtAPIApp) createMutatingWebhook() error {
 			return err
 		}
 	}
+	return nil
+}
 
+func (app *virtAPIApp) registerMutatingWebhookHandlers() {
 	http.HandleFunc(vmMutatePath, func(w http.ResponseWriter, r *http.Request) {
 		mutating_webhook.ServeVMs(w, r, app.clusterConfig)
 	})
 	http.HandleFunc(vmiMutatePath, func(w http.ResponseWriter, r *http.Request) {
-		mutating_webhook.ServeVMIs(w, r, app.clusterConfig)
+		mutating_webhook.ServeVMIs(w, r, app.clusterConfig, app.virtCli)
 	})
 	http.HandleFunc(migrationMutatePath, func(w http.ResponseWriter, r *http.Request) {
 		mutating_webhook.ServeMigrationCreate(w, r)
 	})
-	return nil
 }
 
 func (app *virtAPIApp) subresourceApiservice(version schema.GroupVersion) *apiregistrationv1beta1.APIService {
@@ -985,15 +1239,44 @@ func (app *virtAPIApp) createSubresourceApiservice(version schema.GroupVersion)
 	return nil
 }
 
-func (app *virtAPIApp) setupTLS(caManager ClientCAManager) error {
+// syncCABundle updates the CA bundle of the webhooks and the APIService
+// after virt-operator rotated the KubeVirt CA
+func (app *virtAPIApp) syncCABundle() {
+	caBundle := app.certmanager.CABundle()
+	if len(caBundle) == 0 || bytes.Equal(caBundle, app.signingCertBytes) {
+		return
+	}
+	app.signingCertBytes = caBundle
 
-	certPair, err := tls.X509KeyPair(app.certBytes, app.keyBytes)
-	if err != nil {
-		return fmt.Errorf("some special error: %b", err)
+	for _, version := range v1.SubresourceGroupVersions {
+		if err := app.createSubresourceApiservice(version); err != nil {
+			log.Log.Reason(err).Error("Failed to update the CA bundle of the APIService")
+			app.signingCertBytes = nil
+			return
+		}
+	}
+	if err := app.createWebhook(); err != nil {
+		log.Log.Reason(err).Error("Failed to update the CA bundle of the webhooks")
+		app.signingCertBytes = nil
+		return
+	}
+	log.Log.Info("Updated the CA bundle of the webhooks and the APIService")
+}
+
+func (app *virtAPIApp) setupTLS(caManager ClientCAManager, certManager bootstrap.CertificateManager) error {
+
+	// the certificate is looked up on every handshake, so that the
+	// certificates which virt-operator rotates are picked up
+	getCertificate := func(info *tls.ClientHelloInfo) (*tls.Certificate, error) {
+		crt := certManager.Current()
+		if crt == nil {
+			return nil, fmt.Errorf("no serving certificate available for virt-api")
+		}
+		return crt, nil
 	}
 
 	app.tlsConfig = &tls.Config{
-		Certificates: []tls.Certificate{certPair},
+		GetCertificate: getCertificate,
 		GetConfigForClient: func(hi *tls.ClientHelloInfo) (*tls.Config, error) {
 
 			pool, err := caManager.GetCurrent()
@@ -1002,8 +1285,8 @@ func (app *virtAPIApp) setupTLS(caManager ClientCAManager) error {
 				return nil, err
 			}
 			config := &tls.Config{
-				Certificates: []tls.Certificate{certPair},
-				ClientCAs:    pool,
+				GetCertificate: getCertificate,
+				ClientCAs:      pool,
 				// A VerifyClientCertIfGiven request means we're not guaranteed
 				// a client has been authenticated unless they provide a peer
 				// cert.
@@ -1022,11 +1305,9 @@ func (app *virtAPIApp) setupTLS(caManager ClientCAManager) error {
 				ClientAuth: tls.VerifyClientCertIfGiven,
 			}
 
-			config.BuildNameToCertificate()
 			return config, nil
 		},
 	}
-	app.tlsConfig.BuildNameToCertificate()
 	return nil
 }
 
@@ -1043,7 +1324,7 @@ func (app *virtAPIApp) startTLS(stopCh <-chan struct{}) error {
 
 	caManager := NewClientCAManager(authConfigMapInformer.GetStore())
 
-	err := app.setupTLS(caManager)
+	err := app.setupTLS(caManager, app.certmanager)
 	if err != nil {
 		return err
 	}
@@ -1071,11 +1352,12 @@ func (app *virtAPIApp) Run() {
 		panic(err)
 	}
 
-	// Get/Set selfsigned cert
-	err = app.getSelfSignedCert()
-	if err != nil {
-		panic(err)
-	}
+	// Load the certificates which virt-operator issued
+	app.certmanager.Start()
+	defer app.certmanager.Stop()
+	app.handlerCertManager.Start()
+	defer app.handlerCertManager.Stop()
+	app.signingCertBytes = app.certmanager.CABundle()
 
 	// Verify/create aggregator endpoint.
 	for _, version := range v1.SubresourceGroupVersions {
@@ -1090,6 +1372,7 @@ func (app *virtAPIApp) Run() {
 	kubeInformerFactory := controller.NewKubeInformerFactory(app.virtCli.RestClient(), app.virtCli, app.namespace)
 	configMapInformer := kubeInformerFactory.ConfigMap()
 	crdInformer := kubeInformerFactory.CRD()
+	kubeVirtInformer := kubeInformerFactory.KubeVirt()
 
 	stopChan := make(chan struct{}, 1)
 	defer close(stopChan)
@@ -1098,20 +1381,26 @@ func (app *virtAPIApp) Run() {
 	go webhookInformers.NamespaceLimitsInformer.Run(stopChan)
 	go configMapInformer.Run(stopChan)
 	go crdInformer.Run(stopChan)
+	go kubeVirtInformer.Run(stopChan)
 
 	cache.WaitForCacheSync(stopChan,
 		webhookInformers.VMIInformer.HasSynced,
 		webhookInformers.VMIPresetInformer.HasSynced,
 		webhookInformers.NamespaceLimitsInformer.HasSynced,
-		configMapInformer.HasSynced)
+		configMapInformer.HasSynced,
+		kubeVirtInformer.HasSynced)
 
-	app.clusterConfig = virtconfig.NewClusterConfig(configMapInformer, crdInformer, app.namespace)
+	app.clusterConfig = virtconfig.NewClusterConfig(configMapInformer, crdInformer, kubeVirtInformer, app.namespace)
 
 	// Verify/create webhook endpoint.
 	err = app.createWebhook()
 	if err != nil {
 		panic(err)
 	}
+	app.registerValidatingWebhookHandlers()
+	app.registerMutatingWebhookHandlers()
+
+	go wait.Until(app.syncCABundle, caBundleSyncInterval, stopChan)
 
 	// start TLS server
 	err = app.startTLS(stopChan)
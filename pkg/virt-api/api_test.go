@@ -25,6 +25,8 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"path/filepath"
+	"time"
 
 	restful "github.com/emicklei/go-restful"
 	"github.com/golang/mock/gomock"
@@ -39,6 +41,7 @@ import (
 	"k8s.io/client-go/util/cert"
 	aggregatorclient "k8s.io/kube-aggregator/pkg/client/clientset_generated/clientset"
 
+	"kubevirt.io/kubevirt/pkg/certificates/bootstrap"
 	"kubevirt.io/kubevirt/pkg/certificates/triple"
 	"kubevirt.io/kubevirt/pkg/util"
 
@@ -115,69 +118,6 @@ var _ = Describe("Virt-api", func() {
 	})
 
 	Context("Virt api server", func() {
-		It("should generate certs the first time it is run", func() {
-			server.AppendHandlers(
-				ghttp.CombineHandlers(
-					ghttp.VerifyRequest("POST", "/api/v1/namespaces/kubevirt/secrets"),
-					ghttp.RespondWithJSONEncoded(http.StatusOK, nil),
-				),
-			)
-
-			err := app.getSelfSignedCert()
-			Expect(err).ToNot(HaveOccurred())
-			Expect(len(app.signingCertBytes)).ToNot(Equal(0))
-			Expect(len(app.certBytes)).ToNot(Equal(0))
-			Expect(len(app.keyBytes)).ToNot(Equal(0))
-		}, 5)
-
-		It("should not generate certs if secret already exists", func() {
-			caKeyPair, _ := triple.NewCA("kubevirt.io")
-			keyPair, _ := triple.NewServerKeyPair(
-				caKeyPair,
-				"virt-api.kubevirt.pod.cluster.local",
-				"virt-api",
-				namespaceKubevirt,
-				"cluster.local",
-				nil,
-				nil,
-			)
-			keyBytes := cert.EncodePrivateKeyPEM(keyPair.Key)
-			certBytes := cert.EncodeCertPEM(keyPair.Cert)
-			signingCertBytes := cert.EncodeCertPEM(caKeyPair.Cert)
-			secret := k8sv1.Secret{
-				ObjectMeta: metav1.ObjectMeta{
-					Name:      virtApiCertSecretName,
-					Namespace: namespaceKubevirt,
-					Labels: map[string]string{
-						v1.AppLabel: "virt-api-aggregator",
-					},
-				},
-				Type: "Opaque",
-				Data: map[string][]byte{
-					certBytesValue:        certBytes,
-					keyBytesValue:         keyBytes,
-					signingCertBytesValue: signingCertBytes,
-				},
-			}
-
-			server.AppendHandlers(
-				ghttp.CombineHandlers(
-					ghttp.VerifyRequest("POST", "/api/v1/namespaces/kubevirt/secrets"),
-					ghttp.RespondWithJSONEncoded(http.StatusConflict, nil),
-				),
-				ghttp.CombineHandlers(
-					ghttp.VerifyRequest("GET", "/api/v1/namespaces/kubevirt/secrets/"+virtApiCertSecretName),
-					ghttp.RespondWithJSONEncoded(http.StatusOK, secret),
-				),
-			)
-
-			err := app.getSelfSignedCert()
-			Expect(err).ToNot(HaveOccurred())
-			Expect(app.signingCertBytes).To(Equal(signingCertBytes))
-			Expect(app.certBytes).To(Equal(certBytes))
-			Expect(app.keyBytes).To(Equal(keyBytes))
-		}, 5)
-
 		It("should return error if extension-apiserver-authentication ConfigMap doesn't exist", func() {
 			server.AppendHandlers(
 				ghttp.CombineHandlers(
@@ -207,11 +147,15 @@ var _ = Describe("Virt-api", func() {
 		}, 5)
 
 		It("should create a tls config which uses the CA Manager", func() {
-			ca, err := triple.NewCA("first")
-			// Just provide any cert
-			app.certBytes = cert.EncodeCertPEM(ca.Cert)
-			app.keyBytes = cert.EncodePrivateKeyPEM(ca.Key)
+			ca, err := triple.NewCA("first", time.Hour)
 			Expect(err).ToNot(HaveOccurred())
+			// Just provide any cert
+			Expect(ioutil.WriteFile(filepath.Join(app.certsDirectory, k8sv1.TLSCertKey), cert.EncodeCertPEM(ca.Cert), 0600)).To(Succeed())
+			Expect(ioutil.WriteFile(filepath.Join(app.certsDirectory, k8sv1.TLSPrivateKeyKey), cert.EncodePrivateKeyPEM(ca.Key), 0600)).To(Succeed())
+			Expect(ioutil.WriteFile(filepath.Join(app.certsDirectory, bootstrap.CABundleKey), cert.EncodeCertPEM(ca.Cert), 0600)).To(Succeed())
+			certManager := bootstrap.NewFileCertificateManager(app.certsDirectory)
+			certManager.Start()
+			defer certManager.Stop()
 			configMap := &k8sv1.ConfigMap{
 				ObjectMeta: metav1.ObjectMeta{
 					Name:            util.ExtensionAPIServerAuthenticationConfigMap,
@@ -225,15 +169,20 @@ var _ = Describe("Virt-api", func() {
 			store := cache.NewStore(cache.DeletionHandlingMetaNamespaceKeyFunc)
 			Expect(store.Add(configMap)).To(Succeed())
 			manager := NewClientCAManager(store)
-			Expect(app.setupTLS(manager)).To(Succeed())
+			Expect(app.setupTLS(manager, certManager)).To(Succeed())
 
 			By("checking if the initial certificate is used in the tlsConfig")
 			config, err := app.tlsConfig.GetConfigForClient(nil)
 			Expect(err).ToNot(HaveOccurred())
 			Expect(config.ClientCAs.Subjects()[0]).To(ContainSubstring("first"))
 
+			By("checking if the serving certificate is taken from the certificate manager")
+			crt, err := config.GetCertificate(nil)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(crt).To(Equal(certManager.Current()))
+
 			By("checking if the new certificate is used in the tlsConfig")
-			newCA, err := triple.NewCA("new")
+			newCA, err := triple.NewCA("new", time.Hour)
 			Expect(err).ToNot(HaveOccurred())
 			configMap.Data[util.RequestHeaderClientCAFileKey] = string(cert.EncodeCertPEM(newCA.Cert))
 			configMap.ObjectMeta.ResourceVersion = "2"
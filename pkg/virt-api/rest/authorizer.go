@@ -40,6 +40,10 @@ const (
 	userExtraHeaderPrefix = "X-Remote-Extra-"
 	clientQPS             = 200
 	clientBurst           = 400
+
+	// readOnlyConsoleVerb is the RBAC verb required to attach to a console
+	// in shared, read-only mode
+	readOnlyConsoleVerb = "view"
 )
 
 type VirtApiAuthorizor interface {
@@ -140,6 +144,14 @@ func (a *authorizor) generateAccessReview(req *restful.Request) (*authorization.
 	// URL example
 	// /apis/subresources.kubevirt.io/v1alpha3/namespaces/default/virtualmachineinstances/testvmi/console
 	pathSplit := strings.Split(url.Path, "/")
+	// Issuing a VNC token is authorized like opening a VNC connection
+	if len(pathSplit) == 10 && pathSplit[8] == "vnc" && pathSplit[9] == "token" {
+		pathSplit = pathSplit[:9]
+	}
+	// Forwarding a port is authorized independent of the port number
+	if len(pathSplit) == 10 && pathSplit[8] == "portforward" {
+		pathSplit = pathSplit[:9]
+	}
 	if len(pathSplit) != 9 {
 		return nil, fmt.Errorf("unknown api endpoint %s", url.Path)
 	}
@@ -170,6 +182,14 @@ func (a *authorizor) generateAccessReview(req *restful.Request) (*authorization.
 	if err != nil {
 		return nil, err
 	}
+	// Attaching to a console in shared, read-only mode requires its own verb
+	if verb == "get" && isConsoleSubresource(subresource) && isReadOnlyRequest(httpRequest) {
+		verb = readOnlyConsoleVerb
+	}
+	// Redirecting a USB device into the guest or connecting to one of its ports always requires "update"
+	if verb == "get" && (subresource == "usbredir" || subresource == "portforward") {
+		verb = "update"
+	}
 
 	r := &authorization.SubjectAccessReview{}
 	r.Spec = authorization.SubjectAccessReviewSpec{
@@ -218,6 +238,10 @@ func mapHttpVerbToRbacVerb(httpVerb string, name string) (string, error) {
 	}
 }
 
+func isConsoleSubresource(subresource string) bool {
+	return subresource == "console" || subresource == "vnc"
+}
+
 func isInfoOrHealthEndpoint(req *restful.Request) bool {
 
 	httpRequest := req.Request
@@ -235,6 +259,19 @@ func isInfoOrHealthEndpoint(req *restful.Request) bool {
 	return false
 }
 
+// isTokenAuthorizedEndpoint returns true for endpoints which authorize
+// requests with a token from the request itself, like the novnc endpoint
+func isTokenAuthorizedEndpoint(req *restful.Request) bool {
+	httpRequest := req.Request
+	if httpRequest == nil || httpRequest.URL == nil {
+		return false
+	}
+	// URL example
+	// /apis/subresources.kubevirt.io/v1alpha3/namespaces/default/virtualmachineinstances/testvmi/novnc
+	pathSplit := strings.Split(httpRequest.URL.Path, "/")
+	return len(pathSplit) == 9 && pathSplit[6] == "virtualmachineinstances" && pathSplit[8] == "novnc"
+}
+
 func isAuthenticated(req *restful.Request) bool {
 	// Peer cert is required for authentication.
 	// If the peer's cert is provided, we are guaranteed
@@ -258,6 +295,11 @@ func (a *authorizor) Authorize(req *restful.Request) (bool, string, error) {
 		return false, "request is not authenticated", nil
 	}
 
+	// The single-use token is verified by the endpoint, the user may even be anonymous
+	if isTokenAuthorizedEndpoint(req) {
+		return true, "", nil
+	}
+
 	r, err := a.generateAccessReview(req)
 	if err != nil {
 		// only internal service errors are returned
@@ -147,6 +147,64 @@ var _ = Describe("Authorizer", func() {
 				close(done)
 			}, 5)
 
+			table.DescribeTable("should require a separate verb for read-only console connections", func(path string, query string, expectedVerb string) {
+				req.Request.URL.Path = path
+				req.Request.URL.RawQuery = query
+
+				result, err := app.generateAccessReview(req)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(result.Spec.ResourceAttributes.Verb).To(Equal(expectedVerb))
+			},
+				table.Entry("interactive console", "/apis/subresources.kubevirt.io/v1alpha3/namespaces/default/virtualmachineinstances/testvmi/console", "", "get"),
+				table.Entry("read-only console", "/apis/subresources.kubevirt.io/v1alpha3/namespaces/default/virtualmachineinstances/testvmi/console", "readonly=true", "view"),
+				table.Entry("interactive vnc", "/apis/subresources.kubevirt.io/v1alpha3/namespaces/default/virtualmachineinstances/testvmi/vnc", "readonly=false", "get"),
+				table.Entry("read-only vnc", "/apis/subresources.kubevirt.io/v1alpha3/namespaces/default/virtualmachineinstances/testvmi/vnc", "readonly=true", "view"),
+				table.Entry("other subresources", "/apis/subresources.kubevirt.io/v1alpha3/namespaces/default/virtualmachineinstances/testvmi/test", "", "get"),
+				table.Entry("interactive vnc token", "/apis/subresources.kubevirt.io/v1alpha3/namespaces/default/virtualmachineinstances/testvmi/vnc/token", "", "get"),
+				table.Entry("read-only vnc token", "/apis/subresources.kubevirt.io/v1alpha3/namespaces/default/virtualmachineinstances/testvmi/vnc/token", "readonly=true", "view"),
+				table.Entry("usb redirection", "/apis/subresources.kubevirt.io/v1alpha3/namespaces/default/virtualmachineinstances/testvmi/usbredir", "", "update"),
+				table.Entry("usb redirection ignoring readonly", "/apis/subresources.kubevirt.io/v1alpha3/namespaces/default/virtualmachineinstances/testvmi/usbredir", "readonly=true", "update"),
+				table.Entry("port forwarding", "/apis/subresources.kubevirt.io/v1alpha3/namespaces/default/virtualmachineinstances/testvmi/portforward/22", "", "update"),
+			)
+
+			It("should authorize port forwarding independent of the port", func() {
+				req.Request.URL.Path = "/apis/subresources.kubevirt.io/v1alpha3/namespaces/default/virtualmachineinstances/testvmi/portforward/8080"
+
+				result, err := app.generateAccessReview(req)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(result.Spec.ResourceAttributes.Subresource).To(Equal("portforward"))
+				Expect(result.Spec.ResourceAttributes.Name).To(Equal("testvmi"))
+			})
+
+			It("should authorize vnc tokens like vnc connections", func() {
+				req.Request.URL.Path = "/apis/subresources.kubevirt.io/v1alpha3/namespaces/default/virtualmachineinstances/testvmi/vnc/token"
+
+				result, err := app.generateAccessReview(req)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(result.Spec.ResourceAttributes.Subresource).To(Equal("vnc"))
+				Expect(result.Spec.ResourceAttributes.Name).To(Equal("testvmi"))
+			})
+
+			It("should leave the authorization of novnc connections to the token", func() {
+				req.Request.TLS = &tls.ConnectionState{}
+				req.Request.TLS.PeerCertificates = append(req.Request.TLS.PeerCertificates, fakecert)
+				req.Request.URL.Path = "/apis/subresources.kubevirt.io/v1alpha3/namespaces/default/virtualmachineinstances/testvmi/novnc"
+				req.Request.Header[userHeader] = []string{"system:anonymous"}
+
+				allowed, _, err := app.Authorize(req)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(allowed).To(BeTrue())
+			})
+
+			It("should reject unauthenticated novnc connections", func() {
+				req.Request.URL.Path = "/apis/subresources.kubevirt.io/v1alpha3/namespaces/default/virtualmachineinstances/testvmi/novnc"
+
+				allowed, reason, err := app.Authorize(req)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(allowed).To(BeFalse())
+				Expect(reason).To(Equal("request is not authenticated"))
+			})
+
 			table.DescribeTable("should allow all users for info endpoints", func(path string) {
 				req.Request.URL.Path = path
 				allowed, _, err := app.Authorize(req)
@@ -173,6 +231,7 @@ var _ = Describe("Authorizer", func() {
 				table.Entry("random2", "/1/2/3/4/5/6/7/8/9/0/1/2/3/4/5/6/7/8/9"),
 				table.Entry("no subresource provided", "/apis/subresources.kubevirt.io/v1alpha3/namespaces/default/virtualmachineinstances/testvmi"),
 				table.Entry("invalid resource type", "/apis/subresources.kubevirt.io/v1alpha3/namespaces/default/madeupresource/testvmi/console"),
+				table.Entry("unknown vnc endpoint", "/apis/subresources.kubevirt.io/v1alpha3/namespaces/default/virtualmachineinstances/testvmi/vnc/madeup"),
 			)
 		})
 
@@ -293,6 +293,22 @@ func NamespaceParam(ws *restful.WebService) *restful.Parameter {
 	return ws.PathParameter("namespace", "Object name and auth scope, such as for teams and projects").Required(true)
 }
 
+func ReadOnlyParam(ws *restful.WebService) *restful.Parameter {
+	return ws.QueryParameter(ReadOnlyParamName, "If true, the connection only receives the console output and all input is discarded. Read-only connections do not disconnect other clients.").DataType("boolean")
+}
+
+func SinceSecondsParam(ws *restful.WebService) *restful.Parameter {
+	return ws.QueryParameter(SinceSecondsParamName, "A relative time in seconds before the current time from which to show logs.").DataType("integer")
+}
+
+func TailLinesParam(ws *restful.WebService) *restful.Parameter {
+	return ws.QueryParameter(TailLinesParamName, "If set, the number of lines from the end of the logs to show.").DataType("integer")
+}
+
+func PortParam(ws *restful.WebService) *restful.Parameter {
+	return ws.PathParameter(PortParamName, "The target port of the connection to the VirtualMachineInstance.").DataType("integer").Required(true)
+}
+
 func labelSelectorParam(ws *restful.WebService) *restful.Parameter {
 	return ws.QueryParameter("labelSelector", "A selector to restrict the list of returned objects by their labels. Defaults to everything")
 }
@@ -361,6 +377,9 @@ func ResourcePath(gvr schema.GroupVersionResource) string {
 	return fmt.Sprintf("/namespaces/{namespace:[a-z0-9][a-z0-9\\-]*}/%s/{name:[a-z0-9][a-z0-9\\-]*}", gvr.Resource)
 }
 
+// PortPath is the path parameter of subresources connecting to a port of the vmi
+const PortPath = "/{port:[0-9]+}"
+
 func SubResourcePath(subResource string) string {
 	if !strings.HasPrefix(subResource, "/") {
 		return "/" + subResource
@@ -20,44 +20,59 @@
 package rest
 
 import (
+	"bytes"
 	"crypto/tls"
 	"crypto/x509"
 	goerror "errors"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"net/http"
+	"net/url"
 	"reflect"
+	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/emicklei/go-restful"
 
 	v12 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
 	k8smetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/json"
-	"k8s.io/client-go/util/cert"
 
 	v1 "kubevirt.io/client-go/api/v1"
 	"kubevirt.io/client-go/kubecli"
 	"kubevirt.io/client-go/log"
-	clientutil "kubevirt.io/client-go/util"
+	"kubevirt.io/kubevirt/pkg/certificates/bootstrap"
+	consolelog "kubevirt.io/kubevirt/pkg/console-log"
 	"kubevirt.io/kubevirt/pkg/controller"
+	memorydump "kubevirt.io/kubevirt/pkg/memory-dump"
+	"kubevirt.io/kubevirt/pkg/usbredir"
 )
 
 type SubresourceAPIApp struct {
 	virtCli                 kubecli.KubevirtClient
 	consoleServerPort       int
 	handlerTLSConfiguration *tls.Config
+	handlerCertManager      bootstrap.CertificateManager
+	vncTokenKeyFile         string
+	vncTokenKey             []byte
+	vncTokenSigner          *vncTokenSigner
 	credentialsLock         *sync.Mutex
 }
 
-func NewSubresourceAPIApp(virtCli kubecli.KubevirtClient, consoleServerPort int) *SubresourceAPIApp {
+func NewSubresourceAPIApp(virtCli kubecli.KubevirtClient, consoleServerPort int, handlerCertManager bootstrap.CertificateManager, vncTokenKeyFile string) *SubresourceAPIApp {
 	return &SubresourceAPIApp{
-		virtCli:           virtCli,
-		consoleServerPort: consoleServerPort,
-		credentialsLock:   &sync.Mutex{},
+		virtCli:                 virtCli,
+		consoleServerPort:       consoleServerPort,
+		handlerCertManager:      handlerCertManager,
+		handlerTLSConfiguration: newHandlerTLSConfig(handlerCertManager),
+		vncTokenKeyFile:         vncTokenKeyFile,
+		credentialsLock:         &sync.Mutex{},
 	}
 }
 
@@ -66,10 +81,10 @@ type requestType struct {
 }
 
 const (
-	clientCertBytesValue      = "client-cert-bytes"
-	clientKeyBytesValue       = "client-key-bytes"
-	signingCertBytesValue     = "signing-cert-bytes"
-	virtHandlerCertSecretName = "kubevirt-virt-handler-certs"
+	ReadOnlyParamName     = "readonly"
+	SinceSecondsParamName = "sinceSeconds"
+	TailLinesParamName    = "tailLines"
+	PortParamName         = "port"
 )
 
 type validation func(*v1.VirtualMachineInstance) (err error, statusCode int)
@@ -106,26 +121,6 @@ func (app *SubresourceAPIApp) prepareConnection(request *restful.Request, respon
 		return
 	}
 
-	if app.handlerTLSConfiguration == nil {
-		setTLSConfiguration := func() error {
-			app.credentialsLock.Lock()
-			defer app.credentialsLock.Unlock()
-			if app.handlerTLSConfiguration == nil {
-				tlsConfig, err := app.getHandlerTLSConfig()
-				if err != nil {
-					return err
-				}
-				app.handlerTLSConfiguration = tlsConfig
-			}
-			return nil
-		}
-		if err = setTLSConfiguration(); err != nil {
-			log.Log.Object(vmi).Reason(err).Error("Failed to set TLS configuration for virt-handler connection")
-			response.WriteHeader(http.StatusInternalServerError)
-			return
-		}
-	}
-
 	return
 }
 
@@ -190,51 +185,26 @@ func (app *SubresourceAPIApp) putRequestHandler(request *restful.Request, respon
 	return nil
 }
 
-func (app *SubresourceAPIApp) getHandlerTLSConfig() (*tls.Config, error) {
-	ns, err := clientutil.GetNamespace()
-	if err != nil {
-		return nil, err
-	}
-	secret, err := app.virtCli.CoreV1().Secrets(ns).Get(virtHandlerCertSecretName, k8smetav1.GetOptions{})
-	if err != nil {
-		return nil, err
-	}
-
-	var clientCertBytes, clientKeyBytes, signingCertBytes []byte
-	var ok bool
-	// retrieve self signed cert info from secret
-	if clientCertBytes, ok = secret.Data[clientCertBytesValue]; !ok {
-		return nil, fmt.Errorf("%s value not found in %s virt-api secret", clientCertBytesValue, virtHandlerCertSecretName)
-	}
-	if clientKeyBytes, ok = secret.Data[clientKeyBytesValue]; !ok {
-		return nil, fmt.Errorf("%s value not found in %s virt-api secret", clientKeyBytesValue, virtHandlerCertSecretName)
-	}
-	if signingCertBytes, ok = secret.Data[signingCertBytesValue]; !ok {
-		return nil, fmt.Errorf("%s value not found in %s virt-api secret", signingCertBytesValue, virtHandlerCertSecretName)
-	}
-	clientCert, err := tls.X509KeyPair(clientCertBytes, clientKeyBytes)
-	if err != nil {
-		return nil, err
-	}
-	caCert, err := cert.ParseCertsPEM(signingCertBytes)
-	if err != nil {
-		return nil, err
-	}
-
-	certPool := x509.NewCertPool()
-	for _, crt := range caCert {
-		certPool.AddCert(crt)
-	}
-
+// newHandlerTLSConfig returns the TLS configuration for connections to
+// virt-handler. The virt-handler client certificate and the CA bundle are
+// looked up on every handshake, so that rotated certificates are picked up.
+func newHandlerTLSConfig(certManager bootstrap.CertificateManager) *tls.Config {
 	// we use the same TLS configuration that is used for live migrations
-	handlerTLSConfig := &tls.Config{
+	return &tls.Config{
 		MinVersion: tls.VersionTLS12,
-		ClientCAs:  certPool,
 		GetClientCertificate: func(info *tls.CertificateRequestInfo) (certificate *tls.Certificate, e error) {
-			return &clientCert, nil
+			crt := certManager.Current()
+			if crt == nil {
+				return nil, fmt.Errorf("no virt-handler client certificate loaded")
+			}
+			return crt, nil
 		},
 		GetCertificate: func(info *tls.ClientHelloInfo) (i *tls.Certificate, e error) {
-			return &clientCert, nil
+			crt := certManager.Current()
+			if crt == nil {
+				return nil, fmt.Errorf("no virt-handler client certificate loaded")
+			}
+			return crt, nil
 		},
 		// Neither the client nor the server should validate anything itself, `VerifyPeerCertificate` is still executed
 		InsecureSkipVerify: true,
@@ -251,7 +221,7 @@ func (app *SubresourceAPIApp) getHandlerTLSConfig() (*tls.Config, error) {
 				return fmt.Errorf("failed to parse peer certificate: %v", err)
 			}
 			_, err = c.Verify(x509.VerifyOptions{
-				Roots:     certPool,
+				Roots:     certManager.CAPool(),
 				KeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
 			})
 
@@ -262,29 +232,191 @@ func (app *SubresourceAPIApp) getHandlerTLSConfig() (*tls.Config, error) {
 		},
 		ClientAuth: tls.RequireAndVerifyClientCert,
 	}
-	return handlerTLSConfig, nil
+}
+
+func validateVNCConnection(vmi *v1.VirtualMachineInstance) (error, int) {
+	// If there are no graphics devices present, we can't proceed
+	if vmi.Spec.Domain.Devices.AutoattachGraphicsDevice != nil && *vmi.Spec.Domain.Devices.AutoattachGraphicsDevice == false {
+		err := fmt.Errorf("No graphics devices are present.")
+		log.Log.Object(vmi).Reason(err).Error("Can't establish VNC connection.")
+		return err, http.StatusBadRequest
+	}
+	condManager := controller.NewVirtualMachineInstanceConditionManager()
+	if condManager.HasCondition(vmi, v1.VirtualMachineInstancePaused) {
+		return fmt.Errorf("VMI is paused"), http.StatusForbidden
+	}
+	return nil, 0
 }
 
 func (app *SubresourceAPIApp) VNCRequestHandler(request *restful.Request, response *restful.Response) {
+	getConsoleURL := func(vmi *v1.VirtualMachineInstance, conn kubecli.VirtHandlerConn) (string, error) {
+		url, err := conn.VNCURI(vmi)
+		if err != nil {
+			return "", err
+		}
+		return withReadOnlyQuery(request, url), nil
+	}
+	app.streamRequestHandler(request, response, validateVNCConnection, getConsoleURL)
+}
+
+func (app *SubresourceAPIApp) USBRedirRequestHandler(request *restful.Request, response *restful.Response) {
 	validate := func(vmi *v1.VirtualMachineInstance) (error, int) {
-		// If there are no graphics devices present, we can't proceed
-		if vmi.Spec.Domain.Devices.AutoattachGraphicsDevice != nil && *vmi.Spec.Domain.Devices.AutoattachGraphicsDevice == false {
-			err := fmt.Errorf("No graphics devices are present.")
-			log.Log.Object(vmi).Reason(err).Error("Can't establish VNC connection.")
-			return err, http.StatusBadRequest
+		if !vmi.IsRunning() {
+			return fmt.Errorf("VMI is not running"), http.StatusBadRequest
 		}
-		condManager := controller.NewVirtualMachineInstanceConditionManager()
-		if condManager.HasCondition(vmi, v1.VirtualMachineInstancePaused) {
-			return fmt.Errorf("VMI is paused"), http.StatusForbidden
+		if !usbredir.IsEnabled(vmi) {
+			return fmt.Errorf("USB redirection is not enabled"), http.StatusBadRequest
+		}
+		return nil, 0
+	}
+	getConsoleURL := func(vmi *v1.VirtualMachineInstance, conn kubecli.VirtHandlerConn) (string, error) {
+		return conn.USBRedirURI(vmi)
+	}
+	app.streamRequestHandler(request, response, validate, getConsoleURL)
+}
+
+func (app *SubresourceAPIApp) PortForwardRequestHandler(request *restful.Request, response *restful.Response) {
+	port, err := strconv.Atoi(request.PathParameter(PortParamName))
+	if err != nil || port < 1 || port > 65535 {
+		response.WriteError(http.StatusBadRequest, fmt.Errorf("invalid port %q", request.PathParameter(PortParamName)))
+		return
+	}
+	validate := func(vmi *v1.VirtualMachineInstance) (error, int) {
+		if !vmi.IsRunning() {
+			return fmt.Errorf("VMI is not running"), http.StatusBadRequest
 		}
 		return nil, 0
 	}
 	getConsoleURL := func(vmi *v1.VirtualMachineInstance, conn kubecli.VirtHandlerConn) (string, error) {
-		return conn.VNCURI(vmi)
+		return conn.PortForwardURI(vmi, port)
 	}
 	app.streamRequestHandler(request, response, validate, getConsoleURL)
 }
 
+// VNCTokenRequestHandler issues a short-lived token for the novnc subresource. Read-only
+// tokens are issued for read-only requests, which only need "view" on the vnc subresource.
+func (app *SubresourceAPIApp) VNCTokenRequestHandler(request *restful.Request, response *restful.Response) {
+	name := request.PathParameter("name")
+	namespace := request.PathParameter("namespace")
+
+	vmi, code, err := app.fetchVirtualMachineInstance(name, namespace)
+	if err != nil {
+		response.WriteError(code, err)
+		return
+	}
+	if !vmi.IsRunning() {
+		response.WriteError(http.StatusBadRequest, fmt.Errorf("VMI is not running"))
+		return
+	}
+	if err, code := validateVNCConnection(vmi); err != nil {
+		response.WriteError(code, err)
+		return
+	}
+
+	signer, err := app.getVNCTokenSigner()
+	if err != nil {
+		log.Log.Object(vmi).Reason(err).Error("Failed to load the VNC token signing key")
+		response.WriteError(http.StatusInternalServerError, err)
+		return
+	}
+
+	id, err := newVNCTokenID()
+	if err != nil {
+		response.WriteError(http.StatusInternalServerError, err)
+		return
+	}
+	expires := time.Now().Add(vncTokenLifetime).Truncate(time.Second)
+	token, err := signer.sign(&vncTokenClaims{
+		ID:        id,
+		Namespace: vmi.Namespace,
+		Name:      vmi.Name,
+		UID:       vmi.UID,
+		ReadOnly:  isReadOnlyRequest(request.Request),
+		Expires:   expires.Unix(),
+	})
+	if err != nil {
+		response.WriteError(http.StatusInternalServerError, err)
+		return
+	}
+
+	response.WriteAsJson(&v1.VNCToken{
+		Token:               token,
+		ExpirationTimestamp: k8smetav1.NewTime(expires),
+	})
+}
+
+// NoVNCRequestHandler opens a VNC websocket for browser based noVNC clients. The request
+// is authorized by a token from the vnc/token subresource instead of the requesting user,
+// which is passed as websocket subprotocol and can only be used once.
+func (app *SubresourceAPIApp) NoVNCRequestHandler(request *restful.Request, response *restful.Response) {
+	signer, err := app.getVNCTokenSigner()
+	if err != nil {
+		log.Log.Reason(err).Error("Failed to load the VNC token signing key")
+		response.WriteError(http.StatusInternalServerError, err)
+		return
+	}
+
+	claims, err := signer.verify(vncTokenFromRequest(request.Request), time.Now())
+	if err != nil {
+		response.WriteError(http.StatusUnauthorized, err)
+		return
+	}
+	if claims.Namespace != request.PathParameter("namespace") || claims.Name != request.PathParameter("name") {
+		response.WriteError(http.StatusUnauthorized, fmt.Errorf("VNC token is not valid for this VMI"))
+		return
+	}
+
+	validate := func(vmi *v1.VirtualMachineInstance) (error, int) {
+		// A VMI recreated with the same name is a different VMI
+		if vmi.UID != claims.UID {
+			return fmt.Errorf("VNC token is not valid for this VMI"), http.StatusUnauthorized
+		}
+		return validateVNCConnection(vmi)
+	}
+	getConsoleURL := func(vmi *v1.VirtualMachineInstance, conn kubecli.VirtHandlerConn) (string, error) {
+		vncURL, err := conn.VNCURI(vmi)
+		if err != nil {
+			return "", err
+		}
+		// virt-handler rejects tokens which were already used, also on other virt-api replicas
+		params := url.Values{}
+		params.Set(vncTokenIDParamName, claims.ID)
+		params.Set(vncTokenExpiresParamName, strconv.FormatInt(claims.Expires, 10))
+		if claims.ReadOnly {
+			params.Set(ReadOnlyParamName, "true")
+		}
+		return vncURL + "?" + params.Encode(), nil
+	}
+	app.streamRequestHandler(request, response, validate, getConsoleURL)
+}
+
+func (app *SubresourceAPIApp) getVNCTokenSigner() (*vncTokenSigner, error) {
+	app.credentialsLock.Lock()
+	defer app.credentialsLock.Unlock()
+
+	if app.vncTokenKeyFile == "" {
+		if app.vncTokenSigner == nil {
+			return nil, fmt.Errorf("no VNC token signer available")
+		}
+		return app.vncTokenSigner, nil
+	}
+
+	// every virt-api replica mounts the key which virt-operator generated,
+	// which makes tokens valid on all of them
+	key, err := ioutil.ReadFile(app.vncTokenKeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the VNC token signing key: %v", err)
+	}
+	if len(key) == 0 {
+		return nil, fmt.Errorf("the VNC token signing key is empty")
+	}
+	if !bytes.Equal(key, app.vncTokenKey) {
+		app.vncTokenSigner = newVNCTokenSigner(key)
+		app.vncTokenKey = key
+	}
+	return app.vncTokenSigner, nil
+}
+
 func (app *SubresourceAPIApp) getVirtHandlerConnForVMI(vmi *v1.VirtualMachineInstance) (kubecli.VirtHandlerConn, error) {
 	if !vmi.IsRunning() {
 		return nil, goerror.New(fmt.Sprintf("Unable to connect to VirtualMachineInstance because phase is %s instead of %s", vmi.Status.Phase, v1.Running))
@@ -301,11 +433,115 @@ func (app *SubresourceAPIApp) ConsoleRequestHandler(request *restful.Request, re
 		return nil, 0
 	}
 	getConsoleURL := func(vmi *v1.VirtualMachineInstance, conn kubecli.VirtHandlerConn) (string, error) {
-		return conn.ConsoleURI(vmi)
+		url, err := conn.ConsoleURI(vmi)
+		if err != nil {
+			return "", err
+		}
+		return withReadOnlyQuery(request, url), nil
 	}
 	app.streamRequestHandler(request, response, validate, getConsoleURL)
 }
 
+// withReadOnlyQuery passes the readonly flag of a console request on to virt-handler
+func withReadOnlyQuery(request *restful.Request, url string) string {
+	if isReadOnlyRequest(request.Request) {
+		return url + "?" + ReadOnlyParamName + "=true"
+	}
+	return url
+}
+
+func isReadOnlyRequest(req *http.Request) bool {
+	return req.URL.Query().Get(ReadOnlyParamName) == "true"
+}
+
+// ConsoleLogRequestHandler returns the serial console log of a VirtualMachineInstance,
+// which is printed by the guest-console-log container of the virt-launcher pod
+func (app *SubresourceAPIApp) ConsoleLogRequestHandler(request *restful.Request, response *restful.Response) {
+	name := request.PathParameter("name")
+	namespace := request.PathParameter("namespace")
+
+	logOptions := &v12.PodLogOptions{
+		Container: consolelog.ContainerName,
+	}
+	var err error
+	if logOptions.SinceSeconds, err = int64QueryParam(request, SinceSecondsParamName, 1); err != nil {
+		response.WriteError(http.StatusBadRequest, err)
+		return
+	}
+	if logOptions.TailLines, err = int64QueryParam(request, TailLinesParamName, 0); err != nil {
+		response.WriteError(http.StatusBadRequest, err)
+		return
+	}
+
+	vmi, code, err := app.fetchVirtualMachineInstance(name, namespace)
+	if err != nil {
+		response.WriteError(code, err)
+		return
+	}
+	if !consolelog.IsEnabled(vmi) {
+		response.WriteError(http.StatusBadRequest, fmt.Errorf("Serial console logging is disabled for VirtualMachineInstance %s", name))
+		return
+	}
+
+	pod, err := app.findLauncherPod(vmi)
+	if err != nil {
+		log.Log.Object(vmi).Reason(err).Error("Failed to find the virt-launcher pod")
+		response.WriteError(http.StatusInternalServerError, err)
+		return
+	} else if pod == nil {
+		response.WriteError(http.StatusNotFound, fmt.Errorf("No virt-launcher pod found for VirtualMachineInstance %s", name))
+		return
+	}
+
+	logs, err := app.virtCli.CoreV1().Pods(pod.Namespace).GetLogs(pod.Name, logOptions).Stream()
+	if err != nil {
+		log.Log.Object(vmi).Reason(err).Error("Failed to retrieve the serial console log")
+		response.WriteError(http.StatusInternalServerError, err)
+		return
+	}
+	defer logs.Close()
+
+	response.AddHeader("Content-Type", "text/plain")
+	response.WriteHeader(http.StatusOK)
+	if _, err := io.Copy(response, logs); err != nil {
+		log.Log.Object(vmi).Reason(err).Error("Failed to stream the serial console log")
+	}
+}
+
+// findLauncherPod returns the virt-launcher pod of the vmi. While migrating, the
+// pod on the node currently running the vmi is preferred.
+func (app *SubresourceAPIApp) findLauncherPod(vmi *v1.VirtualMachineInstance) (*v12.Pod, error) {
+	pods, err := app.virtCli.CoreV1().Pods(vmi.Namespace).List(k8smetav1.ListOptions{
+		LabelSelector: fmt.Sprintf("%s=%s", v1.CreatedByLabel, string(vmi.UID)),
+	})
+	if err != nil {
+		return nil, err
+	}
+	var launcherPod *v12.Pod
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		if pod.Spec.NodeName == vmi.Status.NodeName {
+			return pod, nil
+		}
+		if launcherPod == nil || pod.CreationTimestamp.After(launcherPod.CreationTimestamp.Time) {
+			launcherPod = pod
+		}
+	}
+	return launcherPod, nil
+}
+
+func int64QueryParam(request *restful.Request, name string, min int64) (*int64, error) {
+	param := request.QueryParameter(name)
+	if param == "" {
+		return nil, nil
+	}
+	value, err := strconv.ParseInt(param, 10, 64)
+	if err != nil || value < min {
+		return nil, fmt.Errorf("%s must be an integer of at least %d, got %q", name, min, param)
+	}
+	return &value, nil
+}
+
 func getChangeRequestJson(vm *v1.VirtualMachine, changes ...v1.VirtualMachineStateChangeRequest) (string, error) {
 	verb := "add"
 	// Special case: if there's no status field at all, add one.
@@ -373,6 +609,38 @@ func (app *SubresourceAPIApp) MigrateVMRequestHandler(request *restful.Request,
 	name := request.PathParameter("name")
 	namespace := request.PathParameter("namespace")
 
+	app.migrateVM(name, namespace, nil, response)
+}
+
+func (app *SubresourceAPIApp) MigrateVolumesVMRequestHandler(request *restful.Request, response *restful.Response) {
+	name := request.PathParameter("name")
+	namespace := request.PathParameter("namespace")
+
+	migrateRequest := &v1.VirtualMachineMigrateVolumesRequest{}
+	if request.Request.Body == nil {
+		response.WriteError(http.StatusBadRequest, fmt.Errorf("Request with no body, a migrate volumes request is required"))
+		return
+	}
+	data, err := ioutil.ReadAll(request.Request.Body)
+	if err != nil {
+		response.WriteError(http.StatusBadRequest, err)
+		return
+	}
+	if err := json.Unmarshal(data, migrateRequest); err != nil {
+		response.WriteError(http.StatusBadRequest, fmt.Errorf("Can not unmarshal Request body to struct, error: %v", err))
+		return
+	}
+	if len(migrateRequest.MigratedVolumes) == 0 {
+		response.WriteError(http.StatusBadRequest, fmt.Errorf("At least one migrated volume is required"))
+		return
+	}
+
+	app.migrateVM(name, namespace, migrateRequest.MigratedVolumes, response)
+}
+
+// migrateVM creates a migration of a running VirtualMachine, the volumes are
+// validated by the admission webhook of the migration
+func (app *SubresourceAPIApp) migrateVM(name string, namespace string, migratedVolumes []v1.StorageMigratedVolume, response *restful.Response) {
 	vm, code, err := app.fetchVirtualMachine(name, namespace)
 	if err != nil {
 		response.WriteError(code, err)
@@ -397,7 +665,8 @@ func (app *SubresourceAPIApp) MigrateVMRequestHandler(request *restful.Request,
 				GenerateName: "kubevirt-migrate-vm-",
 			},
 			Spec: v1.VirtualMachineInstanceMigrationSpec{
-				VMIName: name,
+				VMIName:         name,
+				MigratedVolumes: migratedVolumes,
 			},
 		})
 		return err
@@ -658,6 +927,350 @@ func (app *SubresourceAPIApp) UnpauseVMIRequestHandler(request *restful.Request,
 
 }
 
+func (app *SubresourceAPIApp) SoftRebootVMIRequestHandler(request *restful.Request, response *restful.Response) {
+
+	validate := func(vmi *v1.VirtualMachineInstance) (error, int) {
+		if vmi == nil || vmi.Status.Phase != v1.Running {
+			return fmt.Errorf("VMI is not running"), http.StatusForbidden
+		}
+		condManager := controller.NewVirtualMachineInstanceConditionManager()
+		if condManager.HasCondition(vmi, v1.VirtualMachineInstancePaused) {
+			return fmt.Errorf("VMI is paused"), http.StatusForbidden
+		}
+		if !condManager.HasCondition(vmi, v1.VirtualMachineInstanceAgentConnected) && !isACPIEnabled(vmi) {
+			return fmt.Errorf("VMI neither has the guest agent connected nor the ACPI feature enabled"), http.StatusForbidden
+		}
+		return nil, 0
+	}
+	getURL := func(vmi *v1.VirtualMachineInstance, conn kubecli.VirtHandlerConn) (string, error) {
+		return conn.SoftRebootURI(vmi)
+	}
+	app.putRequestHandler(request, response, validate, getURL)
+}
+
+// isACPIEnabled checks if the ACPI feature will be present in the domain of the VMI
+func isACPIEnabled(vmi *v1.VirtualMachineInstance) bool {
+	features := vmi.Spec.Domain.Features
+	return features != nil && (features.ACPI.Enabled == nil || *features.ACPI.Enabled)
+}
+
+func (app *SubresourceAPIApp) ScreenshotVMIRequestHandler(request *restful.Request, response *restful.Response) {
+
+	validate := func(vmi *v1.VirtualMachineInstance) (error, int) {
+		if vmi == nil || vmi.Status.Phase != v1.Running {
+			return fmt.Errorf("VMI is not running"), http.StatusForbidden
+		}
+		// Without a graphics device there is no screen to capture
+		if vmi.Spec.Domain.Devices.AutoattachGraphicsDevice != nil && *vmi.Spec.Domain.Devices.AutoattachGraphicsDevice == false {
+			return fmt.Errorf("No graphics devices are present."), http.StatusBadRequest
+		}
+		return nil, 0
+	}
+	getURL := func(vmi *v1.VirtualMachineInstance, conn kubecli.VirtHandlerConn) (string, error) {
+		return conn.ScreenshotURI(vmi)
+	}
+
+	vmi, url, conn, err := app.prepareConnection(request, response, validate, getURL)
+	if err != nil {
+		return
+	}
+
+	png, err := conn.Get(url, app.handlerTLSConfiguration)
+	if err != nil {
+		log.Log.Object(vmi).Reason(err).Error("Failed to retrieve the screenshot from virt-handler")
+		response.WriteError(http.StatusInternalServerError, err)
+		return
+	}
+
+	response.AddHeader("Content-Type", "image/png")
+	response.WriteHeader(http.StatusOK)
+	response.Write(png)
+}
+
+// validateGuestAgentConnected makes sure that requests can be served by the guest agent of the VMI
+func validateGuestAgentConnected(vmi *v1.VirtualMachineInstance) (error, int) {
+	if vmi == nil || vmi.Status.Phase != v1.Running {
+		return fmt.Errorf("VMI is not running"), http.StatusForbidden
+	}
+	condManager := controller.NewVirtualMachineInstanceConditionManager()
+	if !condManager.HasCondition(vmi, v1.VirtualMachineInstanceAgentConnected) {
+		return fmt.Errorf("VMI does not have guest agent connected"), http.StatusForbidden
+	}
+	return nil, 0
+}
+
+// getGuestAgentRequestHandler proxies a GET request which is served by the guest agent to virt-handler
+func (app *SubresourceAPIApp) getGuestAgentRequestHandler(request *restful.Request, response *restful.Response, getURL URLResolver) {
+	vmi, url, conn, err := app.prepareConnection(request, response, validateGuestAgentConnected, getURL)
+	if err != nil {
+		return
+	}
+
+	body, err := conn.Get(url, app.handlerTLSConfiguration)
+	if err != nil {
+		log.Log.Object(vmi).Reason(err).Error("Failed to retrieve the guest agent information from virt-handler")
+		response.WriteError(http.StatusInternalServerError, err)
+		return
+	}
+
+	response.AddHeader("Content-Type", restful.MIME_JSON)
+	response.WriteHeader(http.StatusOK)
+	response.Write(body)
+}
+
+func (app *SubresourceAPIApp) GuestOSInfo(request *restful.Request, response *restful.Response) {
+	getURL := func(vmi *v1.VirtualMachineInstance, conn kubecli.VirtHandlerConn) (string, error) {
+		return conn.GuestInfoURI(vmi)
+	}
+	app.getGuestAgentRequestHandler(request, response, getURL)
+}
+
+func (app *SubresourceAPIApp) UserList(request *restful.Request, response *restful.Response) {
+	getURL := func(vmi *v1.VirtualMachineInstance, conn kubecli.VirtHandlerConn) (string, error) {
+		return conn.UserListURI(vmi)
+	}
+	app.getGuestAgentRequestHandler(request, response, getURL)
+}
+
+func (app *SubresourceAPIApp) FilesystemList(request *restful.Request, response *restful.Response) {
+	getURL := func(vmi *v1.VirtualMachineInstance, conn kubecli.VirtHandlerConn) (string, error) {
+		return conn.FilesystemListURI(vmi)
+	}
+	app.getGuestAgentRequestHandler(request, response, getURL)
+}
+
+func (app *SubresourceAPIApp) GuestExecRequestHandler(request *restful.Request, response *restful.Response) {
+	execRequest := &v1.VirtualMachineInstanceGuestExecRequest{}
+	if request.Request.Body == nil {
+		response.WriteError(http.StatusBadRequest, fmt.Errorf("Request with no body, a guest exec request is required"))
+		return
+	}
+	data, err := ioutil.ReadAll(request.Request.Body)
+	if err != nil {
+		response.WriteError(http.StatusBadRequest, err)
+		return
+	}
+	if err := json.Unmarshal(data, execRequest); err != nil {
+		response.WriteError(http.StatusBadRequest, fmt.Errorf("Can not unmarshal Request body to struct, error: %v", err))
+		return
+	}
+	if execRequest.Command == "" {
+		response.WriteError(http.StatusBadRequest, fmt.Errorf("A command is required"))
+		return
+	}
+	if execRequest.TimeoutSeconds < 0 || execRequest.TimeoutSeconds > v1.GuestExecMaxTimeoutSeconds {
+		response.WriteError(http.StatusBadRequest, fmt.Errorf("The timeout has to be between 0 and %d seconds", v1.GuestExecMaxTimeoutSeconds))
+		return
+	}
+	if execRequest.TimeoutSeconds == 0 {
+		execRequest.TimeoutSeconds = v1.GuestExecDefaultTimeoutSeconds
+	}
+
+	getURL := func(vmi *v1.VirtualMachineInstance, conn kubecli.VirtHandlerConn) (string, error) {
+		return conn.GuestExecURI(vmi)
+	}
+	vmi, url, conn, err := app.prepareConnection(request, response, validateGuestAgentConnected, getURL)
+	if err != nil {
+		return
+	}
+
+	body, err := json.Marshal(execRequest)
+	if err != nil {
+		response.WriteError(http.StatusInternalServerError, err)
+		return
+	}
+
+	// virt-handler answers once the command exited in the guest
+	timeout := time.Duration(execRequest.TimeoutSeconds)*time.Second + 30*time.Second
+	result, err := conn.PutWithBody(url, app.handlerTLSConfiguration, body, timeout)
+	if err != nil {
+		log.Log.Object(vmi).Reason(err).Error("Failed to execute a command in the guest through virt-handler")
+		response.WriteError(http.StatusInternalServerError, err)
+		return
+	}
+
+	response.AddHeader("Content-Type", restful.MIME_JSON)
+	response.WriteHeader(http.StatusOK)
+	response.Write(result)
+}
+
+func (app *SubresourceAPIApp) MemoryDumpVMRequestHandler(request *restful.Request, response *restful.Response) {
+	name := request.PathParameter("name")
+	namespace := request.PathParameter("namespace")
+
+	dumpRequest := &v1.VirtualMachineMemoryDumpRequest{}
+	if request.Request.Body == nil {
+		response.WriteError(http.StatusBadRequest, fmt.Errorf("Request with no body, a memory dump request is required"))
+		return
+	}
+	data, err := ioutil.ReadAll(request.Request.Body)
+	if err != nil {
+		response.WriteError(http.StatusBadRequest, err)
+		return
+	}
+	if err := json.Unmarshal(data, dumpRequest); err != nil {
+		response.WriteError(http.StatusBadRequest, fmt.Errorf("Can not unmarshal Request body to struct, error: %v", err))
+		return
+	}
+	if dumpRequest.ClaimName == "" {
+		response.WriteError(http.StatusBadRequest, fmt.Errorf("A claim name is required"))
+		return
+	}
+
+	vm, code, err := app.fetchVirtualMachine(name, namespace)
+	if err != nil {
+		response.WriteError(code, err)
+		return
+	}
+	if previous := vm.Status.MemoryDumpRequest; previous != nil {
+		if previous.Phase == v1.MemoryDumpInProgress || previous.Phase == v1.MemoryDumpDissociating {
+			response.WriteError(http.StatusConflict, fmt.Errorf("memory dump is %s", strings.ToLower(string(previous.Phase))))
+			return
+		}
+		if previous.ClaimName != dumpRequest.ClaimName {
+			response.WriteError(http.StatusConflict, fmt.Errorf("PVC %s is associated with the VM, remove the memory dump first", previous.ClaimName))
+			return
+		}
+	}
+
+	validate := func(vmi *v1.VirtualMachineInstance) (error, int) {
+		if vmi == nil || vmi.Status.Phase != v1.Running {
+			return fmt.Errorf("VM is not running"), http.StatusForbidden
+		}
+		return app.validateMemoryDumpClaim(vmi, dumpRequest.ClaimName)
+	}
+	getURL := func(vmi *v1.VirtualMachineInstance, conn kubecli.VirtHandlerConn) (string, error) {
+		return conn.MemoryDumpURI(vmi)
+	}
+	vmi, url, conn, err := app.prepareConnection(request, response, validate, getURL)
+	if err != nil {
+		return
+	}
+
+	now := k8smetav1.Now()
+	fileName := memorydump.GenerateFileName(vm.Name, dumpRequest.ClaimName, now.Time)
+	memoryDumpRequest := &v1.VirtualMachineMemoryDumpRequest{
+		ClaimName:      dumpRequest.ClaimName,
+		Phase:          v1.MemoryDumpInProgress,
+		StartTimestamp: &now,
+		FileName:       &fileName,
+	}
+	body, err := json.Marshal(memoryDumpRequest)
+	if err != nil {
+		response.WriteError(http.StatusInternalServerError, err)
+		return
+	}
+	if _, err := conn.PutWithBody(url, app.handlerTLSConfiguration, body, 30*time.Second); err != nil {
+		log.Log.Object(vmi).Reason(err).Error("Failed to start the memory dump through virt-handler")
+		response.WriteError(http.StatusInternalServerError, err)
+		return
+	}
+
+	app.patchMemoryDumpRequest(vm, memoryDumpRequest, response)
+}
+
+func (app *SubresourceAPIApp) RemoveMemoryDumpVMRequestHandler(request *restful.Request, response *restful.Response) {
+	name := request.PathParameter("name")
+	namespace := request.PathParameter("namespace")
+
+	vm, code, err := app.fetchVirtualMachine(name, namespace)
+	if err != nil {
+		response.WriteError(code, err)
+		return
+	}
+	if vm.Status.MemoryDumpRequest == nil {
+		response.WriteError(http.StatusBadRequest, fmt.Errorf("no memory dump is associated with the VM"))
+		return
+	}
+	if vm.Status.MemoryDumpRequest.Remove {
+		response.WriteError(http.StatusConflict, fmt.Errorf("memory dump is already being removed"))
+		return
+	}
+
+	memoryDumpRequest := vm.Status.MemoryDumpRequest.DeepCopy()
+	memoryDumpRequest.Remove = true
+	memoryDumpRequest.Phase = v1.MemoryDumpDissociating
+	app.patchMemoryDumpRequest(vm, memoryDumpRequest, response)
+}
+
+// validateMemoryDumpClaim checks that the PVC exists and can hold the memory of the VMI
+func (app *SubresourceAPIApp) validateMemoryDumpClaim(vmi *v1.VirtualMachineInstance, claimName string) (error, int) {
+	pvc, err := app.virtCli.CoreV1().PersistentVolumeClaims(vmi.Namespace).Get(claimName, k8smetav1.GetOptions{})
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return fmt.Errorf("PVC %s in namespace %s not found", claimName, vmi.Namespace), http.StatusNotFound
+		}
+		return err, http.StatusInternalServerError
+	}
+	capacity, ok := pvc.Status.Capacity[v12.ResourceStorage]
+	if !ok {
+		return fmt.Errorf("PVC %s is not bound", claimName), http.StatusForbidden
+	}
+	if memory := guestMemory(vmi); capacity.Cmp(memory) < 0 {
+		return fmt.Errorf("PVC %s of size %s is too small for the %s of guest memory", claimName, capacity.String(), memory.String()), http.StatusForbidden
+	}
+	return nil, 0
+}
+
+func (app *SubresourceAPIApp) patchMemoryDumpRequest(vm *v1.VirtualMachine, memoryDumpRequest *v1.VirtualMachineMemoryDumpRequest, response *restful.Response) {
+	bodyString, err := getMemoryDumpRequestJson(vm, memoryDumpRequest)
+	if err != nil {
+		response.WriteError(http.StatusInternalServerError, err)
+		return
+	}
+
+	log.Log.Object(vm).V(4).Infof("Patching VM: %s", bodyString)
+	_, err = app.virtCli.VirtualMachine(vm.Namespace).Patch(vm.GetName(), types.JSONPatchType, []byte(bodyString))
+	if err != nil {
+		errCode := http.StatusInternalServerError
+		if strings.Contains(err.Error(), "jsonpatch test operation does not apply") {
+			errCode = http.StatusConflict
+		}
+		response.WriteError(errCode, fmt.Errorf("%v: %s", err, bodyString))
+		return
+	}
+
+	response.WriteHeader(http.StatusAccepted)
+}
+
+func getMemoryDumpRequestJson(vm *v1.VirtualMachine, memoryDumpRequest *v1.VirtualMachineMemoryDumpRequest) (string, error) {
+	// Special case: if there's no status field at all, add one.
+	if reflect.DeepEqual(vm.Status, v1.VirtualMachineStatus{}) {
+		statusJson, err := json.Marshal(v1.VirtualMachineStatus{MemoryDumpRequest: memoryDumpRequest})
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf(`[{ "op": "add", "path": "/status", "value": %s}]`, string(statusJson)), nil
+	}
+
+	newRequestJson, err := json.Marshal(memoryDumpRequest)
+	if err != nil {
+		return "", err
+	}
+	update := fmt.Sprintf(`{ "op": "add", "path": "/status/memoryDumpRequest", "value": %s}`, string(newRequestJson))
+	if vm.Status.MemoryDumpRequest == nil {
+		return fmt.Sprintf("[%s]", update), nil
+	}
+
+	oldRequestJson, err := json.Marshal(vm.Status.MemoryDumpRequest)
+	if err != nil {
+		return "", err
+	}
+	test := fmt.Sprintf(`{ "op": "test", "path": "/status/memoryDumpRequest", "value": %s}`, string(oldRequestJson))
+	return fmt.Sprintf("[%s, %s]", test, update), nil
+}
+
+// guestMemory returns the amount of memory the guest of the VMI sees
+func guestMemory(vmi *v1.VirtualMachineInstance) resource.Quantity {
+	if vmi.Spec.Domain.Memory != nil && vmi.Spec.Domain.Memory.Guest != nil {
+		return *vmi.Spec.Domain.Memory.Guest
+	}
+	if memory, ok := vmi.Spec.Domain.Resources.Limits[v12.ResourceMemory]; ok {
+		return memory
+	}
+	return vmi.Spec.Domain.Resources.Requests[v12.ResourceMemory]
+}
+
 func (app *SubresourceAPIApp) fetchVirtualMachine(name string, namespace string) (*v1.VirtualMachine, int, error) {
 
 	vm, err := app.virtCli.VirtualMachine(namespace).Get(name, &k8smetav1.GetOptions{})
@@ -21,11 +21,17 @@ package rest
 
 import (
 	"crypto/tls"
+	"encoding/json"
 	"flag"
 	"fmt"
+	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
+	"os"
+	"strings"
 	"sync"
+	"time"
 
 	"github.com/onsi/ginkgo/extensions/table"
 
@@ -34,12 +40,16 @@ import (
 	. "github.com/onsi/gomega"
 	"github.com/onsi/gomega/ghttp"
 	k8sv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	k8smetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/uuid"
 
 	v1 "kubevirt.io/client-go/api/v1"
 	"kubevirt.io/client-go/kubecli"
 	"kubevirt.io/client-go/log"
+	"kubevirt.io/client-go/subresources"
+	consolelog "kubevirt.io/kubevirt/pkg/console-log"
 )
 
 var _ = Describe("VirtualMachineInstance Subresources", func() {
@@ -759,6 +769,58 @@ var _ = Describe("VirtualMachineInstance Subresources", func() {
 		})
 	})
 
+	Context("Subresource api - MigrateVolumesVMRequestHandler", func() {
+		table.DescribeTable("should reject an invalid request", func(body string, message string) {
+			request.PathParameters()["name"] = "testvm"
+			request.PathParameters()["namespace"] = "default"
+			request.Request.Body = ioutil.NopCloser(strings.NewReader(body))
+
+			app.MigrateVolumesVMRequestHandler(request, response)
+
+			Expect(response.StatusCode()).To(Equal(http.StatusBadRequest))
+			Expect(response.Error().Error()).To(ContainSubstring(message))
+		},
+			table.Entry("without migrated volumes", `{"migratedVolumes": []}`, "At least one migrated volume is required"),
+			table.Entry("with a malformed body", `{"migratedVolumes": "rootdisk"}`, "Can not unmarshal Request body"),
+		)
+
+		It("should create a migration with the migrated volumes", func() {
+			request.PathParameters()["name"] = "testvm"
+			request.PathParameters()["namespace"] = "default"
+			request.Request.Body = ioutil.NopCloser(strings.NewReader(`{"migratedVolumes": [{"volumeName": "rootdisk", "destinationClaimName": "newclaim"}]}`))
+
+			vm := v1.VirtualMachine{
+				Status: v1.VirtualMachineStatus{
+					Ready: true,
+				},
+			}
+
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/apis/kubevirt.io/v1alpha3/namespaces/default/virtualmachines/testvm"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, vm),
+				),
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("POST", "/apis/kubevirt.io/v1alpha3/namespaces/default/virtualmachineinstancemigrations"),
+					func(w http.ResponseWriter, r *http.Request) {
+						migration := &v1.VirtualMachineInstanceMigration{}
+						Expect(json.NewDecoder(r.Body).Decode(migration)).To(Succeed())
+						Expect(migration.Spec.VMIName).To(Equal("testvm"))
+						Expect(migration.Spec.MigratedVolumes).To(Equal([]v1.StorageMigratedVolume{
+							{VolumeName: "rootdisk", DestinationClaimName: "newclaim"},
+						}))
+					},
+					ghttp.RespondWithJSONEncoded(http.StatusOK, v1.VirtualMachineInstanceMigration{}),
+				),
+			)
+
+			app.MigrateVolumesVMRequestHandler(request, response)
+
+			Expect(response.Error()).ToNot(HaveOccurred())
+			Expect(response.StatusCode()).To(Equal(http.StatusAccepted))
+		})
+	})
+
 	Context("StateChange JSON", func() {
 		It("should create a stop request if status exists", func() {
 			uid := uuid.NewUUID()
@@ -1012,6 +1074,659 @@ var _ = Describe("VirtualMachineInstance Subresources", func() {
 		})
 	})
 
+	Context("Soft reboot", func() {
+
+		expectSoftRebootVMI := func(agentConnected, acpi bool) {
+			request.PathParameters()["name"] = "testvmi"
+			request.PathParameters()["namespace"] = "default"
+
+			vmi := v1.NewMinimalVMI("testvmi")
+			vmi.Status.Phase = v1.Running
+			if agentConnected {
+				vmi.Status.Conditions = []v1.VirtualMachineInstanceCondition{
+					{
+						Type:   v1.VirtualMachineInstanceAgentConnected,
+						Status: k8sv1.ConditionTrue,
+					},
+				}
+			}
+			if acpi {
+				vmi.Spec.Domain.Features = &v1.Features{}
+			}
+
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/apis/kubevirt.io/v1alpha3/namespaces/default/virtualmachineinstances/testvmi"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, vmi),
+				),
+			)
+
+			expectHandlerPod()
+		}
+
+		table.DescribeTable("Should soft reboot a running VMI", func(agentConnected, acpi bool) {
+			expectSoftRebootVMI(agentConnected, acpi)
+
+			app.SoftRebootVMIRequestHandler(request, response)
+
+			Expect(response.Error()).ToNot(HaveOccurred())
+			Expect(response.StatusCode()).To(Equal(http.StatusOK))
+		},
+			table.Entry("with a connected guest agent", true, false),
+			table.Entry("with ACPI enabled", false, true),
+		)
+
+		It("Should fail soft rebooting a VMI without guest agent and ACPI", func() {
+			expectSoftRebootVMI(false, false)
+
+			app.SoftRebootVMIRequestHandler(request, response)
+
+			Expect(response.Error()).To(HaveOccurred())
+			Expect(response.StatusCode()).To(Equal(http.StatusForbidden))
+		})
+
+		It("Should fail soft rebooting a not running VMI", func() {
+			expectVMI(false, false)
+
+			app.SoftRebootVMIRequestHandler(request, response)
+
+			Expect(response.Error()).To(HaveOccurred())
+			Expect(response.StatusCode()).To(Equal(http.StatusForbidden))
+		})
+
+		It("Should fail soft rebooting a paused VMI", func() {
+			expectVMI(true, true)
+
+			app.SoftRebootVMIRequestHandler(request, response)
+
+			Expect(response.Error()).To(HaveOccurred())
+			Expect(response.StatusCode()).To(Equal(http.StatusForbidden))
+		})
+	})
+
+	Context("Screenshot", func() {
+		It("Should fail taking a screenshot of a not running VMI", func() {
+
+			expectVMI(false, false)
+
+			app.ScreenshotVMIRequestHandler(request, response)
+
+			Expect(response.Error()).To(HaveOccurred())
+			Expect(response.StatusCode()).To(Equal(http.StatusForbidden))
+
+		})
+
+		It("Should fail taking a screenshot of a VMI without graphics devices", func() {
+			request.PathParameters()["name"] = "testvmi"
+			request.PathParameters()["namespace"] = "default"
+
+			flag := false
+			vmi := v1.NewMinimalVMI("testvmi")
+			vmi.Status.Phase = v1.Running
+			vmi.Spec.Domain.Devices.AutoattachGraphicsDevice = &flag
+
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/apis/kubevirt.io/v1alpha3/namespaces/default/virtualmachineinstances/testvmi"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, vmi),
+				),
+			)
+
+			app.ScreenshotVMIRequestHandler(request, response)
+
+			Expect(response.Error()).To(HaveOccurred())
+			Expect(response.StatusCode()).To(Equal(http.StatusBadRequest))
+
+		})
+	})
+
+	Context("Guest agent", func() {
+
+		expectGuestAgentVMI := func(agentConnected bool) {
+			request.PathParameters()["name"] = "testvmi"
+			request.PathParameters()["namespace"] = "default"
+
+			vmi := v1.NewMinimalVMI("testvmi")
+			vmi.Status.Phase = v1.Running
+			if agentConnected {
+				vmi.Status.Conditions = []v1.VirtualMachineInstanceCondition{
+					{
+						Type:   v1.VirtualMachineInstanceAgentConnected,
+						Status: k8sv1.ConditionTrue,
+					},
+				}
+			}
+
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/apis/kubevirt.io/v1alpha3/namespaces/default/virtualmachineinstances/testvmi"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, vmi),
+				),
+			)
+		}
+
+		table.DescribeTable("should fail to query the guest agent of a not running VMI", func(handler func(*SubresourceAPIApp, *restful.Request, *restful.Response)) {
+			expectVMI(false, false)
+
+			handler(&app, request, response)
+
+			Expect(response.Error()).To(HaveOccurred())
+			Expect(response.StatusCode()).To(Equal(http.StatusForbidden))
+		},
+			table.Entry("for guestosinfo", (*SubresourceAPIApp).GuestOSInfo),
+			table.Entry("for userlist", (*SubresourceAPIApp).UserList),
+			table.Entry("for filesystemlist", (*SubresourceAPIApp).FilesystemList),
+		)
+
+		table.DescribeTable("should fail to query a VMI without connected guest agent", func(handler func(*SubresourceAPIApp, *restful.Request, *restful.Response)) {
+			expectGuestAgentVMI(false)
+
+			handler(&app, request, response)
+
+			Expect(response.Error()).To(HaveOccurred())
+			Expect(response.Error().Error()).To(ContainSubstring("guest agent"))
+			Expect(response.StatusCode()).To(Equal(http.StatusForbidden))
+		},
+			table.Entry("for guestosinfo", (*SubresourceAPIApp).GuestOSInfo),
+			table.Entry("for userlist", (*SubresourceAPIApp).UserList),
+			table.Entry("for filesystemlist", (*SubresourceAPIApp).FilesystemList),
+		)
+
+		table.DescribeTable("should reject invalid guest exec requests", func(body string, message string) {
+			request.Request.Body = ioutil.NopCloser(strings.NewReader(body))
+
+			app.GuestExecRequestHandler(request, response)
+
+			Expect(response.Error()).To(HaveOccurred())
+			Expect(response.Error().Error()).To(ContainSubstring(message))
+			Expect(response.StatusCode()).To(Equal(http.StatusBadRequest))
+		},
+			table.Entry("without a command", `{"args": ["-a"]}`, "A command is required"),
+			table.Entry("with a negative timeout", `{"command": "/usr/bin/uname", "timeoutSeconds": -1}`, "timeout"),
+			table.Entry("with a too long timeout", `{"command": "/usr/bin/uname", "timeoutSeconds": 301}`, "timeout"),
+			table.Entry("with malformed json", `{"command": `, "Can not unmarshal"),
+		)
+
+		It("should fail to execute a command in a VMI without connected guest agent", func() {
+			request.Request.Body = ioutil.NopCloser(strings.NewReader(`{"command": "/usr/bin/uname"}`))
+			expectGuestAgentVMI(false)
+
+			app.GuestExecRequestHandler(request, response)
+
+			Expect(response.Error()).To(HaveOccurred())
+			Expect(response.StatusCode()).To(Equal(http.StatusForbidden))
+		})
+	})
+
+	Context("Memory dump", func() {
+
+		BeforeEach(func() {
+			request.PathParameters()["name"] = "testvm"
+			request.PathParameters()["namespace"] = "default"
+		})
+
+		expectVM := func(memoryDumpRequest *v1.VirtualMachineMemoryDumpRequest) {
+			vm := newMinimalVM("testvm")
+			vm.Namespace = "default"
+			vm.Status.MemoryDumpRequest = memoryDumpRequest
+
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/apis/kubevirt.io/v1alpha3/namespaces/default/virtualmachines/testvm"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, vm),
+				),
+			)
+		}
+
+		table.DescribeTable("should reject invalid memory dump requests", func(body string, message string) {
+			request.Request.Body = ioutil.NopCloser(strings.NewReader(body))
+
+			app.MemoryDumpVMRequestHandler(request, response)
+
+			Expect(response.Error()).To(HaveOccurred())
+			Expect(response.Error().Error()).To(ContainSubstring(message))
+			Expect(response.StatusCode()).To(Equal(http.StatusBadRequest))
+		},
+			table.Entry("without a claim name", `{}`, "A claim name is required"),
+			table.Entry("with malformed json", `{"claimName": `, "Can not unmarshal"),
+		)
+
+		table.DescribeTable("should refuse a memory dump while another one is associated", func(memoryDumpRequest *v1.VirtualMachineMemoryDumpRequest) {
+			request.Request.Body = ioutil.NopCloser(strings.NewReader(`{"claimName": "dump-claim"}`))
+			expectVM(memoryDumpRequest)
+
+			app.MemoryDumpVMRequestHandler(request, response)
+
+			Expect(response.Error()).To(HaveOccurred())
+			Expect(response.StatusCode()).To(Equal(http.StatusConflict))
+		},
+			table.Entry("which is in progress", &v1.VirtualMachineMemoryDumpRequest{ClaimName: "dump-claim", Phase: v1.MemoryDumpInProgress}),
+			table.Entry("which is being removed", &v1.VirtualMachineMemoryDumpRequest{ClaimName: "dump-claim", Phase: v1.MemoryDumpDissociating, Remove: true}),
+			table.Entry("which targets another claim", &v1.VirtualMachineMemoryDumpRequest{ClaimName: "other-claim", Phase: v1.MemoryDumpCompleted}),
+		)
+
+		It("should refuse a memory dump into a claim smaller than the guest memory", func() {
+			request.Request.Body = ioutil.NopCloser(strings.NewReader(`{"claimName": "dump-claim"}`))
+			expectVM(nil)
+
+			vmi := v1.NewMinimalVMI("testvm")
+			vmi.Namespace = "default"
+			vmi.Status.Phase = v1.Running
+			vmi.Spec.Domain.Resources.Requests = k8sv1.ResourceList{
+				k8sv1.ResourceMemory: resource.MustParse("2Gi"),
+			}
+			pvc := &k8sv1.PersistentVolumeClaim{
+				ObjectMeta: k8smetav1.ObjectMeta{Name: "dump-claim", Namespace: "default"},
+				Status: k8sv1.PersistentVolumeClaimStatus{
+					Phase: k8sv1.ClaimBound,
+					Capacity: k8sv1.ResourceList{
+						k8sv1.ResourceStorage: resource.MustParse("1Gi"),
+					},
+				},
+			}
+
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/apis/kubevirt.io/v1alpha3/namespaces/default/virtualmachineinstances/testvm"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, vmi),
+				),
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/api/v1/namespaces/default/persistentvolumeclaims/dump-claim"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, pvc),
+				),
+			)
+
+			app.MemoryDumpVMRequestHandler(request, response)
+
+			Expect(response.Error()).To(HaveOccurred())
+			Expect(response.Error().Error()).To(ContainSubstring("too small"))
+			Expect(response.StatusCode()).To(Equal(http.StatusForbidden))
+		})
+
+		It("should fail to remove a memory dump if none is associated", func() {
+			expectVM(nil)
+
+			app.RemoveMemoryDumpVMRequestHandler(request, response)
+
+			Expect(response.Error()).To(HaveOccurred())
+			Expect(response.StatusCode()).To(Equal(http.StatusBadRequest))
+		})
+
+		It("should mark an associated memory dump for removal", func() {
+			expectVM(&v1.VirtualMachineMemoryDumpRequest{ClaimName: "dump-claim", Phase: v1.MemoryDumpCompleted})
+
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("PATCH", "/apis/kubevirt.io/v1alpha3/namespaces/default/virtualmachines/testvm"),
+					ghttp.VerifyBody([]byte(`[{ "op": "test", "path": "/status/memoryDumpRequest", "value": {"claimName":"dump-claim","phase":"Completed"}}, { "op": "add", "path": "/status/memoryDumpRequest", "value": {"claimName":"dump-claim","phase":"Dissociating","remove":true}}]`)),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, newMinimalVM("testvm")),
+				),
+			)
+
+			app.RemoveMemoryDumpVMRequestHandler(request, response)
+
+			Expect(response.Error()).ToNot(HaveOccurred())
+			Expect(response.StatusCode()).To(Equal(http.StatusAccepted))
+		})
+
+		It("should add the memory dump request to a VM without status", func() {
+			vm := newMinimalVM("testvm")
+			memoryDumpRequest := &v1.VirtualMachineMemoryDumpRequest{ClaimName: "dump-claim", Phase: v1.MemoryDumpInProgress}
+
+			res, err := getMemoryDumpRequestJson(vm, memoryDumpRequest)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(res).To(Equal(`[{ "op": "add", "path": "/status", "value": {"memoryDumpRequest":{"claimName":"dump-claim","phase":"InProgress"}}}]`))
+		})
+	})
+
+	Context("USB redirection", func() {
+
+		BeforeEach(func() {
+			request.PathParameters()["name"] = "testvmi"
+			request.PathParameters()["namespace"] = "default"
+		})
+
+		It("should fail if usb redirection is not enabled", func(done Done) {
+			vmi := v1.NewMinimalVMI("testvmi")
+			vmi.Status.Phase = v1.Running
+			vmi.ObjectMeta.SetUID(uuid.NewUUID())
+
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/apis/kubevirt.io/v1alpha3/namespaces/default/virtualmachineinstances/testvmi"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, vmi),
+				),
+			)
+
+			app.USBRedirRequestHandler(request, response)
+			Expect(response.Error()).To(HaveOccurred())
+			Expect(response.StatusCode()).To(Equal(http.StatusBadRequest))
+			close(done)
+		}, 5)
+
+		It("should fail if the vmi is not running", func(done Done) {
+			vmi := v1.NewMinimalVMI("testvmi")
+			vmi.Status.Phase = v1.Scheduled
+			vmi.ObjectMeta.SetUID(uuid.NewUUID())
+			vmi.Spec.Domain.Devices.USBRedirect = &v1.USBRedirect{}
+
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/apis/kubevirt.io/v1alpha3/namespaces/default/virtualmachineinstances/testvmi"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, vmi),
+				),
+			)
+
+			app.USBRedirRequestHandler(request, response)
+			Expect(response.Error()).To(HaveOccurred())
+			Expect(response.StatusCode()).To(Equal(http.StatusBadRequest))
+			close(done)
+		}, 5)
+	})
+
+	Context("Port forwarding", func() {
+
+		BeforeEach(func() {
+			request.PathParameters()["name"] = "testvmi"
+			request.PathParameters()["namespace"] = "default"
+		})
+
+		It("should fail with an invalid port", func(done Done) {
+			request.PathParameters()["port"] = "65536"
+
+			app.PortForwardRequestHandler(request, response)
+			Expect(response.Error()).To(HaveOccurred())
+			Expect(response.StatusCode()).To(Equal(http.StatusBadRequest))
+			close(done)
+		}, 5)
+
+		It("should fail if the vmi is not running", func(done Done) {
+			request.PathParameters()["port"] = "22"
+			vmi := v1.NewMinimalVMI("testvmi")
+			vmi.Status.Phase = v1.Scheduled
+			vmi.ObjectMeta.SetUID(uuid.NewUUID())
+
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/apis/kubevirt.io/v1alpha3/namespaces/default/virtualmachineinstances/testvmi"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, vmi),
+				),
+			)
+
+			app.PortForwardRequestHandler(request, response)
+			Expect(response.Error()).To(HaveOccurred())
+			Expect(response.StatusCode()).To(Equal(http.StatusBadRequest))
+			close(done)
+		}, 5)
+	})
+
+	Context("VNC token", func() {
+		var recorder *httptest.ResponseRecorder
+		var signer *vncTokenSigner
+
+		BeforeEach(func() {
+			recorder = httptest.NewRecorder()
+			response = restful.NewResponse(recorder)
+			request.PathParameters()["name"] = "testvmi"
+			request.PathParameters()["namespace"] = "default"
+			signer = newVNCTokenSigner([]byte("secret"))
+			app.vncTokenSigner = signer
+		})
+
+		AfterEach(func() {
+			app.vncTokenSigner = nil
+		})
+
+		withQuery := func(query string) {
+			request.Request.URL = &url.URL{RawQuery: query}
+		}
+
+		withTokenProtocol := func(token string) {
+			request.Request.Header = http.Header{}
+			request.Request.Header.Set("Sec-WebSocket-Protocol", "binary, "+subresources.VNCTokenProtocolPrefix+token)
+		}
+
+		expectRunningVMI := func(uid types.UID) {
+			vmi := v1.NewMinimalVMI("testvmi")
+			vmi.Namespace = "default"
+			vmi.UID = uid
+			vmi.Status.Phase = v1.Running
+			vmi.Status.NodeName = "mynode"
+
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/apis/kubevirt.io/v1alpha3/namespaces/default/virtualmachineinstances/testvmi"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, vmi),
+				),
+			)
+		}
+
+		table.DescribeTable("should issue a token for the VMI", func(query string, readOnly bool) {
+			withQuery(query)
+			expectRunningVMI("1234")
+
+			app.VNCTokenRequestHandler(request, response)
+
+			Expect(response.StatusCode()).To(Equal(http.StatusOK))
+			token := &v1.VNCToken{}
+			Expect(json.Unmarshal(recorder.Body.Bytes(), token)).To(Succeed())
+			Expect(token.ExpirationTimestamp.Time).To(BeTemporally("~", time.Now().Add(vncTokenLifetime), 2*time.Second))
+
+			claims, err := signer.verify(token.Token, time.Now())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(claims.ID).ToNot(BeEmpty())
+			Expect(claims.Namespace).To(Equal("default"))
+			Expect(claims.Name).To(Equal("testvmi"))
+			Expect(claims.UID).To(Equal(types.UID("1234")))
+			Expect(claims.ReadOnly).To(Equal(readOnly))
+		},
+			table.Entry("which allows input", "", false),
+			table.Entry("which is read-only", "readonly=true", true),
+		)
+
+		It("should issue tokens with unique ids", func() {
+			ids := map[string]bool{}
+			for i := 0; i < 3; i++ {
+				recorder = httptest.NewRecorder()
+				response = restful.NewResponse(recorder)
+				withQuery("")
+				expectRunningVMI("1234")
+
+				app.VNCTokenRequestHandler(request, response)
+
+				Expect(response.StatusCode()).To(Equal(http.StatusOK))
+				token := &v1.VNCToken{}
+				Expect(json.Unmarshal(recorder.Body.Bytes(), token)).To(Succeed())
+				claims, err := signer.verify(token.Token, time.Now())
+				Expect(err).ToNot(HaveOccurred())
+				ids[claims.ID] = true
+			}
+			Expect(ids).To(HaveLen(3))
+		})
+
+		It("should not issue a token for a VMI which is not running", func() {
+			withQuery("")
+			expectVMI(false, false)
+
+			app.VNCTokenRequestHandler(request, response)
+
+			Expect(response.Error()).To(HaveOccurred())
+			Expect(response.StatusCode()).To(Equal(http.StatusBadRequest))
+		})
+
+		table.DescribeTable("should reject novnc connections", func(claims *vncTokenClaims, fetchesVMI bool) {
+			token, err := signer.sign(claims)
+			Expect(err).ToNot(HaveOccurred())
+			withQuery("")
+			withTokenProtocol(token)
+			if fetchesVMI {
+				expectRunningVMI("1234")
+			}
+
+			app.NoVNCRequestHandler(request, response)
+
+			Expect(response.Error()).To(HaveOccurred())
+			Expect(response.StatusCode()).To(Equal(http.StatusUnauthorized))
+		},
+			table.Entry("with an expired token", &vncTokenClaims{ID: "id", Namespace: "default", Name: "testvmi", UID: "1234", Expires: time.Now().Unix() - 1}, false),
+			table.Entry("with a token for another VMI", &vncTokenClaims{ID: "id", Namespace: "default", Name: "othervmi", UID: "1234", Expires: time.Now().Add(time.Hour).Unix()}, false),
+			table.Entry("with a token for another namespace", &vncTokenClaims{ID: "id", Namespace: "other", Name: "testvmi", UID: "1234", Expires: time.Now().Add(time.Hour).Unix()}, false),
+			table.Entry("with a token for a previous VMI with the same name", &vncTokenClaims{ID: "id", Namespace: "default", Name: "testvmi", UID: "5678", Expires: time.Now().Add(time.Hour).Unix()}, true),
+			table.Entry("with a token without an id", &vncTokenClaims{Namespace: "default", Name: "testvmi", UID: "1234", Expires: time.Now().Add(time.Hour).Unix()}, false),
+		)
+
+		It("should reject novnc connections without a token", func() {
+			withQuery("")
+
+			app.NoVNCRequestHandler(request, response)
+
+			Expect(response.Error()).To(HaveOccurred())
+			Expect(response.StatusCode()).To(Equal(http.StatusUnauthorized))
+		})
+
+		It("should not accept tokens from the query", func() {
+			token, err := signer.sign(&vncTokenClaims{ID: "id", Namespace: "default", Name: "testvmi", UID: "1234", Expires: time.Now().Add(time.Hour).Unix()})
+			Expect(err).ToNot(HaveOccurred())
+			withQuery("token=" + url.QueryEscape(token))
+
+			app.NoVNCRequestHandler(request, response)
+
+			Expect(response.Error()).To(HaveOccurred())
+			Expect(response.StatusCode()).To(Equal(http.StatusUnauthorized))
+		})
+
+		It("should sign tokens with the mounted key and pick up key changes", func() {
+			keyFile, err := ioutil.TempFile("", "vnctokenkey")
+			Expect(err).ToNot(HaveOccurred())
+			defer os.Remove(keyFile.Name())
+			keyFile.Close()
+			app.vncTokenKeyFile = keyFile.Name()
+			defer func() {
+				app.vncTokenKeyFile = ""
+				app.vncTokenKey = nil
+			}()
+
+			_, err = app.getVNCTokenSigner()
+			Expect(err).To(HaveOccurred())
+
+			Expect(ioutil.WriteFile(keyFile.Name(), []byte("first-key"), 0600)).To(Succeed())
+			first, err := app.getVNCTokenSigner()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(first.key).To(Equal(newVNCTokenSigner([]byte("first-key")).key))
+			again, err := app.getVNCTokenSigner()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(again).To(BeIdenticalTo(first))
+
+			Expect(ioutil.WriteFile(keyFile.Name(), []byte("second-key"), 0600)).To(Succeed())
+			second, err := app.getVNCTokenSigner()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(second.key).To(Equal(newVNCTokenSigner([]byte("second-key")).key))
+		})
+	})
+
+	Context("Console log", func() {
+		var recorder *httptest.ResponseRecorder
+
+		BeforeEach(func() {
+			recorder = httptest.NewRecorder()
+			response = restful.NewResponse(recorder)
+			request.PathParameters()["name"] = "testvmi"
+			request.PathParameters()["namespace"] = "default"
+		})
+
+		withQuery := func(query string) {
+			request.Request.URL = &url.URL{RawQuery: query}
+		}
+
+		expectVMIWithConsoleLog := func(enabled bool) {
+			vmi := v1.NewMinimalVMI("testvmi")
+			vmi.Namespace = "default"
+			vmi.UID = "1234"
+			vmi.Status.Phase = v1.Running
+			vmi.Status.NodeName = "mynode"
+			vmi.Spec.Domain.Devices.LogSerialConsole = &enabled
+
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/apis/kubevirt.io/v1alpha3/namespaces/default/virtualmachineinstances/testvmi"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, vmi),
+				),
+			)
+		}
+
+		expectLauncherPods := func(nodeNames ...string) {
+			podList := k8sv1.PodList{}
+			for _, nodeName := range nodeNames {
+				pod := k8sv1.Pod{}
+				pod.Name = "virt-launcher-" + nodeName
+				pod.Namespace = "default"
+				pod.Spec.NodeName = nodeName
+				podList.Items = append(podList.Items, pod)
+			}
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/api/v1/namespaces/default/pods", "labelSelector="+v1.CreatedByLabel+"%3D1234"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, podList),
+				),
+			)
+		}
+
+		It("should return the log of the guest-console-log container", func() {
+			withQuery("sinceSeconds=60&tailLines=10")
+			expectVMIWithConsoleLog(true)
+			expectLauncherPods("othernode", "mynode")
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/api/v1/namespaces/default/pods/virt-launcher-mynode/log"),
+					func(w http.ResponseWriter, r *http.Request) {
+						Expect(r.URL.Query().Get("container")).To(Equal(consolelog.ContainerName))
+						Expect(r.URL.Query().Get("sinceSeconds")).To(Equal("60"))
+						Expect(r.URL.Query().Get("tailLines")).To(Equal("10"))
+					},
+					ghttp.RespondWith(http.StatusOK, "login:"),
+				),
+			)
+
+			app.ConsoleLogRequestHandler(request, response)
+
+			Expect(response.StatusCode()).To(Equal(http.StatusOK))
+			Expect(recorder.Body.String()).To(Equal("login:"))
+		})
+
+		It("should fail if serial console logging is disabled", func() {
+			withQuery("")
+			expectVMIWithConsoleLog(false)
+
+			app.ConsoleLogRequestHandler(request, response)
+
+			Expect(response.Error()).To(HaveOccurred())
+			Expect(response.StatusCode()).To(Equal(http.StatusBadRequest))
+		})
+
+		It("should fail if no virt-launcher pod exists", func() {
+			withQuery("")
+			expectVMIWithConsoleLog(true)
+			expectLauncherPods()
+
+			app.ConsoleLogRequestHandler(request, response)
+
+			Expect(response.Error()).To(HaveOccurred())
+			Expect(response.StatusCode()).To(Equal(http.StatusNotFound))
+		})
+
+		table.DescribeTable("should reject invalid query parameters", func(query string) {
+			withQuery(query)
+
+			app.ConsoleLogRequestHandler(request, response)
+
+			Expect(response.Error()).To(HaveOccurred())
+			Expect(response.StatusCode()).To(Equal(http.StatusBadRequest))
+		},
+			table.Entry("with a negative sinceSeconds", "sinceSeconds=-1"),
+			table.Entry("with a non numeric tailLines", "tailLines=abc"),
+		)
+	})
+
 	AfterEach(func() {
 		server.Close()
 		backend.Close()
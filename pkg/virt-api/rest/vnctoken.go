@@ -0,0 +1,129 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2019 Red Hat, Inc.
+ *
+ */
+
+package rest
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"k8s.io/apimachinery/pkg/types"
+
+	"kubevirt.io/client-go/subresources"
+)
+
+const (
+	// vncTokenIDParamName and vncTokenExpiresParamName pass the token to
+	// virt-handler, which makes sure that every token is only used once
+	vncTokenIDParamName      = "tokenid"
+	vncTokenExpiresParamName = "tokenexpires"
+
+	// vncTokenLifetime only limits how long a token can be used to open a
+	// connection, established connections stay open
+	vncTokenLifetime = 2 * time.Minute
+)
+
+type vncTokenClaims struct {
+	ID        string    `json:"id"`
+	Namespace string    `json:"namespace"`
+	Name      string    `json:"name"`
+	UID       types.UID `json:"uid"`
+	ReadOnly  bool      `json:"readOnly,omitempty"`
+	Expires   int64     `json:"expires"`
+}
+
+// vncTokenSigner issues and verifies HMAC signed VNC access tokens. All
+// virt-api replicas derive the same key from a shared secret, so a token
+// issued by one replica is accepted by all of them.
+type vncTokenSigner struct {
+	key []byte
+}
+
+func newVNCTokenSigner(secret []byte) *vncTokenSigner {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte("kubevirt.io/vnc-token"))
+	return &vncTokenSigner{key: mac.Sum(nil)}
+}
+
+func (s *vncTokenSigner) sign(claims *vncTokenClaims) (string, error) {
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		return "", err
+	}
+	encoded := base64.RawURLEncoding.EncodeToString(payload)
+	return encoded + "." + base64.RawURLEncoding.EncodeToString(s.signature(encoded)), nil
+}
+
+func (s *vncTokenSigner) verify(token string, now time.Time) (*vncTokenClaims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("malformed VNC token")
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil || !hmac.Equal(signature, s.signature(parts[0])) {
+		return nil, fmt.Errorf("invalid VNC token signature")
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return nil, fmt.Errorf("malformed VNC token")
+	}
+	claims := &vncTokenClaims{}
+	if err := json.Unmarshal(payload, claims); err != nil {
+		return nil, fmt.Errorf("malformed VNC token")
+	}
+	if now.Unix() >= claims.Expires {
+		return nil, fmt.Errorf("VNC token expired")
+	}
+	if claims.ID == "" {
+		return nil, fmt.Errorf("malformed VNC token")
+	}
+	return claims, nil
+}
+
+func newVNCTokenID() (string, error) {
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(id), nil
+}
+
+// vncTokenFromRequest returns the token from the websocket subprotocols of the request
+func vncTokenFromRequest(request *http.Request) string {
+	for _, protocol := range websocket.Subprotocols(request) {
+		if strings.HasPrefix(protocol, subresources.VNCTokenProtocolPrefix) {
+			return strings.TrimPrefix(protocol, subresources.VNCTokenProtocolPrefix)
+		}
+	}
+	return ""
+}
+
+func (s *vncTokenSigner) signature(payload string) []byte {
+	mac := hmac.New(sha256.New, s.key)
+	mac.Write([]byte(payload))
+	return mac.Sum(nil)
+}
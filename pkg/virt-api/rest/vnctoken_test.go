@@ -0,0 +1,100 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2019 Red Hat, Inc.
+ *
+ */
+
+package rest
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"kubevirt.io/client-go/subresources"
+)
+
+var _ = Describe("VNC token", func() {
+
+	now := time.Unix(1000, 0)
+	claims := &vncTokenClaims{
+		ID:        "id",
+		Namespace: "default",
+		Name:      "testvmi",
+		UID:       "1234",
+		ReadOnly:  true,
+		Expires:   now.Add(vncTokenLifetime).Unix(),
+	}
+
+	It("should verify a token it signed", func() {
+		signer := newVNCTokenSigner([]byte("secret"))
+		token, err := signer.sign(claims)
+		Expect(err).ToNot(HaveOccurred())
+
+		verified, err := newVNCTokenSigner([]byte("secret")).verify(token, now)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(verified).To(Equal(claims))
+	})
+
+	It("should reject a token signed with a different key", func() {
+		token, err := newVNCTokenSigner([]byte("other")).sign(claims)
+		Expect(err).ToNot(HaveOccurred())
+
+		_, err = newVNCTokenSigner([]byte("secret")).verify(token, now)
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should reject an expired token", func() {
+		signer := newVNCTokenSigner([]byte("secret"))
+		token, err := signer.sign(claims)
+		Expect(err).ToNot(HaveOccurred())
+
+		_, err = signer.verify(token, now.Add(vncTokenLifetime))
+		Expect(err).To(MatchError("VNC token expired"))
+	})
+
+	It("should reject a token with modified claims", func() {
+		signer := newVNCTokenSigner([]byte("secret"))
+		token, err := signer.sign(claims)
+		Expect(err).ToNot(HaveOccurred())
+		other, err := signer.sign(&vncTokenClaims{Namespace: "default", Name: "othervmi", Expires: claims.Expires})
+		Expect(err).ToNot(HaveOccurred())
+
+		forged := strings.Split(other, ".")[0] + "." + strings.Split(token, ".")[1]
+		_, err = signer.verify(forged, now)
+		Expect(err).To(MatchError("invalid VNC token signature"))
+	})
+
+	It("should reject malformed tokens", func() {
+		signer := newVNCTokenSigner([]byte("secret"))
+		for _, token := range []string{"", "abc", "a.b.c", "!!!.???"} {
+			_, err := signer.verify(token, now)
+			Expect(err).To(HaveOccurred())
+		}
+	})
+
+	It("should take the token from the websocket subprotocols", func() {
+		request := &http.Request{Header: http.Header{}}
+		request.Header.Set("Sec-WebSocket-Protocol", "binary, "+subresources.VNCTokenProtocolPrefix+"abc.def")
+		Expect(vncTokenFromRequest(request)).To(Equal("abc.def"))
+
+		request.Header.Set("Sec-WebSocket-Protocol", "binary")
+		Expect(vncTokenFromRequest(request)).To(BeEmpty())
+	})
+})
@@ -26,6 +26,7 @@ import (
 	"k8s.io/api/admission/v1beta1"
 	"k8s.io/apimachinery/pkg/runtime"
 
+	"kubevirt.io/client-go/kubecli"
 	"kubevirt.io/client-go/log"
 	"kubevirt.io/kubevirt/pkg/virt-api/webhooks"
 	"kubevirt.io/kubevirt/pkg/virt-api/webhooks/mutating-webhook/mutators"
@@ -72,8 +73,8 @@ func ServeVMs(resp http.ResponseWriter, req *http.Request, clusterConfig *virtco
 	serve(resp, req, &mutators.VMsMutator{ClusterConfig: clusterConfig})
 }
 
-func ServeVMIs(resp http.ResponseWriter, req *http.Request, clusterConfig *virtconfig.ClusterConfig) {
-	serve(resp, req, &mutators.VMIsMutator{ClusterConfig: clusterConfig})
+func ServeVMIs(resp http.ResponseWriter, req *http.Request, clusterConfig *virtconfig.ClusterConfig, virtCli kubecli.KubevirtClient) {
+	serve(resp, req, &mutators.VMIsMutator{ClusterConfig: clusterConfig, VirtClient: virtCli})
 }
 
 func ServeMigrationCreate(resp http.ResponseWriter, req *http.Request) {
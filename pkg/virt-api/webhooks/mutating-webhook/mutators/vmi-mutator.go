@@ -31,13 +31,17 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	v1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/client-go/kubecli"
 	"kubevirt.io/client-go/log"
+	containerdisk "kubevirt.io/kubevirt/pkg/container-disk"
+	"kubevirt.io/kubevirt/pkg/container-disk/registry"
 	"kubevirt.io/kubevirt/pkg/virt-api/webhooks"
 	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
 )
 
 type VMIsMutator struct {
 	ClusterConfig *virtconfig.ClusterConfig
+	VirtClient    kubecli.KubevirtClient
 }
 
 func (mutator *VMIsMutator) Mutate(ar *v1beta1.AdmissionReview) *v1beta1.AdmissionResponse {
@@ -99,6 +103,13 @@ func (mutator *VMIsMutator) Mutate(ar *v1beta1.AdmissionReview) *v1beta1.Admissi
 		log.Log.V(2).Infof("Failed to set HyperV dependencies: %s", err)
 	}
 
+	// Pin the containerDisks to the digests their images currently resolve to
+	if mutator.ClusterConfig.ContainerDiskDigestsEnabled() {
+		if err := mutator.pinContainerDiskDigests(&vmi, ar.Request.Namespace); err != nil {
+			return webhooks.ToAdmissionResponseError(err)
+		}
+	}
+
 	// Add foreground finalizer
 	vmi.Finalizers = append(vmi.Finalizers, v1.VirtualMachineInstanceFinalizer)
 
@@ -118,6 +129,15 @@ func (mutator *VMIsMutator) Mutate(ar *v1beta1.AdmissionReview) *v1beta1.Admissi
 		Value: value,
 	})
 
+	if len(vmi.Status.ContainerDiskStatuses) > 0 {
+		value = vmi.Status
+		patch = append(patch, patchOperation{
+			Op:    "add",
+			Path:  "/status",
+			Value: value,
+		})
+	}
+
 	patchBytes, err := json.Marshal(patch)
 	if err != nil {
 		return webhooks.ToAdmissionResponseError(err)
@@ -184,15 +204,48 @@ func (mutator *VMIsMutator) setDefaultMachineType(vmi *v1.VirtualMachineInstance
 func (mutator *VMIsMutator) setDefaultPullPoliciesOnContainerDisks(vmi *v1.VirtualMachineInstance) {
 	for _, volume := range vmi.Spec.Volumes {
 		if volume.ContainerDisk != nil && volume.ContainerDisk.ImagePullPolicy == "" {
-			if strings.HasSuffix(volume.ContainerDisk.Image, ":latest") || !strings.ContainsAny(volume.ContainerDisk.Image, ":@") {
-				volume.ContainerDisk.ImagePullPolicy = k8sv1.PullAlways
-			} else {
-				volume.ContainerDisk.ImagePullPolicy = k8sv1.PullIfNotPresent
-			}
+			volume.ContainerDisk.ImagePullPolicy = defaultPullPolicy(volume.ContainerDisk.Image)
+		}
+	}
+	if firmware := vmi.Spec.Domain.Firmware; firmware != nil && firmware.KernelBoot != nil {
+		if container := firmware.KernelBoot.Container; container != nil && container.ImagePullPolicy == "" {
+			container.ImagePullPolicy = defaultPullPolicy(container.Image)
 		}
 	}
 }
 
+func defaultPullPolicy(image string) k8sv1.PullPolicy {
+	if strings.HasSuffix(image, ":latest") || !strings.ContainsAny(image, ":@") {
+		return k8sv1.PullAlways
+	}
+	return k8sv1.PullIfNotPresent
+}
+
+func (mutator *VMIsMutator) pinContainerDiskDigests(vmi *v1.VirtualMachineInstance, namespace string) error {
+	client := registry.NewClient(mutator.ClusterConfig.GetInsecureRegistries())
+
+	vmi.Status.ContainerDiskStatuses = nil
+	for _, volume := range vmi.Spec.Volumes {
+		if volume.ContainerDisk == nil {
+			continue
+		}
+		credentials, err := containerdisk.GetImagePullCredentials(mutator.VirtClient, namespace, volume.ContainerDisk)
+		if err != nil {
+			return fmt.Errorf("failed to get the registry credentials of containerDisk %s: %v", volume.Name, err)
+		}
+		digest, err := client.ResolveDigest(volume.ContainerDisk.Image, credentials)
+		if err != nil {
+			return fmt.Errorf("failed to resolve the digest of containerDisk %s: %v", volume.Name, err)
+		}
+		vmi.Status.ContainerDiskStatuses = append(vmi.Status.ContainerDiskStatuses, v1.ContainerDiskStatus{
+			Name:        volume.Name,
+			Image:       volume.ContainerDisk.Image,
+			ImageDigest: digest,
+		})
+	}
+	return nil
+}
+
 func (mutator *VMIsMutator) setDefaultResourceRequests(vmi *v1.VirtualMachineInstance) {
 
 	resources := &vmi.Spec.Domain.Resources
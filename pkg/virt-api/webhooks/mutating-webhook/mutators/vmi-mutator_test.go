@@ -20,21 +20,28 @@
 package mutators
 
 import (
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
+	"net/http"
 	"reflect"
+	"strings"
 
+	"github.com/golang/mock/gomock"
 	. "github.com/onsi/ginkgo"
 	"github.com/onsi/ginkgo/extensions/table"
 	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
 	"k8s.io/api/admission/v1beta1"
 	k8sv1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	k8smetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
 	"k8s.io/client-go/tools/cache"
 
 	v1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/client-go/kubecli"
 	"kubevirt.io/kubevirt/pkg/testutils"
 	"kubevirt.io/kubevirt/pkg/virt-api/webhooks"
 	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
@@ -358,6 +365,25 @@ var _ = Describe("VirtualMachineInstance Mutator", func() {
 		),
 	)
 
+	table.DescribeTable("it should set the ImagePullPolicy of the kernel boot container", func(image string, given k8sv1.PullPolicy, expected k8sv1.PullPolicy) {
+		vmi.Spec.Domain.Firmware = &v1.Firmware{
+			KernelBoot: &v1.KernelBoot{
+				Container: &v1.KernelBootContainer{
+					Image:           image,
+					ImagePullPolicy: given,
+					KernelPath:      "/boot/vmlinuz",
+				},
+			},
+		}
+		vmiSpec, _ := getVMISpecMetaFromResponse()
+		Expect(vmiSpec.Domain.Firmware.KernelBoot.Container.ImagePullPolicy).To(Equal(expected))
+	},
+		table.Entry("to Always if :latest is specified", "test:latest", k8sv1.PullPolicy(""), k8sv1.PullAlways),
+		table.Entry("to Always if no tag or shasum is specified", "test", k8sv1.PullPolicy(""), k8sv1.PullAlways),
+		table.Entry("to IfNotPresent if arbitrary tags are specified", "test:notlatest", k8sv1.PullPolicy(""), k8sv1.PullIfNotPresent),
+		table.Entry("to the given policy if one is specified", "test:latest", k8sv1.PullNever, k8sv1.PullNever),
+	)
+
 	table.DescribeTable("should add the default network interface",
 		func(iface string) {
 			expectedIface := "bridge"
@@ -664,4 +690,134 @@ var _ = Describe("VirtualMachineInstance Mutator", func() {
 		}
 		Expect(ok).To(BeTrue())
 	})
+
+	Context("with the ContainerDiskDigests feature gate", func() {
+		const namespace = "default"
+		digest := "sha256:" + strings.Repeat("a", 64)
+
+		var server *ghttp.Server
+		var registryHost string
+		var kubeClient *fake.Clientset
+
+		mutate := func() *v1beta1.AdmissionResponse {
+			vmiBytes, err := json.Marshal(vmi)
+			Expect(err).ToNot(HaveOccurred())
+			ar := &v1beta1.AdmissionReview{
+				Request: &v1beta1.AdmissionRequest{
+					Namespace: namespace,
+					Resource:  k8smetav1.GroupVersionResource{Group: v1.VirtualMachineInstanceGroupVersionKind.Group, Version: v1.VirtualMachineInstanceGroupVersionKind.Version, Resource: "virtualmachineinstances"},
+					Object: runtime.RawExtension{
+						Raw: vmiBytes,
+					},
+				},
+			}
+			return mutator.Mutate(ar)
+		}
+
+		getStatusFromResponse := func(resp *v1beta1.AdmissionResponse) *v1.VirtualMachineInstanceStatus {
+			Expect(resp.Allowed).To(BeTrue())
+			status := &v1.VirtualMachineInstanceStatus{}
+			patch := []patchOperation{
+				{Value: &v1.VirtualMachineInstanceSpec{}},
+				{Value: &k8smetav1.ObjectMeta{}},
+				{Value: status},
+			}
+			Expect(json.Unmarshal(resp.Patch, &patch)).To(Succeed())
+			Expect(patch).To(HaveLen(3))
+			Expect(patch[2].Path).To(Equal("/status"))
+			return status
+		}
+
+		BeforeEach(func() {
+			server = ghttp.NewServer()
+			registryHost = strings.TrimPrefix(server.URL(), "http://")
+
+			ctrl := gomock.NewController(GinkgoT())
+			virtClient := kubecli.NewMockKubevirtClient(ctrl)
+			kubeClient = fake.NewSimpleClientset()
+			virtClient.EXPECT().CoreV1().Return(kubeClient.CoreV1()).AnyTimes()
+			mutator.VirtClient = virtClient
+
+			testutils.UpdateFakeClusterConfig(configMapInformer, &k8sv1.ConfigMap{
+				Data: map[string]string{
+					virtconfig.FeatureGatesKey:       virtconfig.ContainerDiskDigestsGate,
+					virtconfig.InsecureRegistriesKey: registryHost,
+				},
+			})
+
+			vmi.Spec.Volumes = []v1.Volume{
+				{
+					Name: "disk0",
+					VolumeSource: v1.VolumeSource{
+						ContainerDisk: &v1.ContainerDiskSource{
+							Image: registryHost + "/kubevirt/cirros:devel",
+						},
+					},
+				},
+			}
+		})
+
+		AfterEach(func() {
+			server.Close()
+		})
+
+		It("should record the digest of the containerDisk image in the status", func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodHead, "/v2/kubevirt/cirros/manifests/devel"),
+					ghttp.RespondWith(http.StatusOK, nil, http.Header{"Docker-Content-Digest": []string{digest}}),
+				),
+			)
+			status := getStatusFromResponse(mutate())
+			Expect(status.ContainerDiskStatuses).To(Equal([]v1.ContainerDiskStatus{
+				{Name: "disk0", Image: registryHost + "/kubevirt/cirros:devel", ImageDigest: digest},
+			}))
+		})
+
+		It("should authenticate with the image pull secret", func() {
+			vmi.Spec.Volumes[0].ContainerDisk.ImagePullSecret = "pull-secret"
+			auth := base64.StdEncoding.EncodeToString([]byte("user:password"))
+			_, err := kubeClient.CoreV1().Secrets(namespace).Create(&k8sv1.Secret{
+				ObjectMeta: k8smetav1.ObjectMeta{Name: "pull-secret", Namespace: namespace},
+				Type:       k8sv1.SecretTypeDockerConfigJson,
+				Data: map[string][]byte{
+					k8sv1.DockerConfigJsonKey: []byte(fmt.Sprintf(`{"auths":{"%s":{"auth":"%s"}}}`, registryHost, auth)),
+				},
+			})
+			Expect(err).ToNot(HaveOccurred())
+
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodHead, "/v2/kubevirt/cirros/manifests/devel"),
+					ghttp.RespondWith(http.StatusUnauthorized, nil, http.Header{"WWW-Authenticate": []string{`Basic realm="registry"`}}),
+				),
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodHead, "/v2/kubevirt/cirros/manifests/devel"),
+					ghttp.VerifyBasicAuth("user", "password"),
+					ghttp.RespondWith(http.StatusOK, nil, http.Header{"Docker-Content-Digest": []string{digest}}),
+				),
+			)
+			status := getStatusFromResponse(mutate())
+			Expect(status.ContainerDiskStatuses).To(HaveLen(1))
+			Expect(status.ContainerDiskStatuses[0].ImageDigest).To(Equal(digest))
+		})
+
+		It("should reject the VMI if the digest can't be resolved", func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodHead, "/v2/kubevirt/cirros/manifests/devel"),
+					ghttp.RespondWith(http.StatusNotFound, nil),
+				),
+			)
+			resp := mutate()
+			Expect(resp.Allowed).To(BeFalse())
+			Expect(resp.Result.Message).To(ContainSubstring("failed to resolve the digest of containerDisk disk0"))
+		})
+
+		It("should not pin the containerDisks if the feature gate is disabled", func() {
+			testutils.UpdateFakeClusterConfig(configMapInformer, &k8sv1.ConfigMap{})
+			_, _ = getVMISpecMetaFromResponse()
+			Expect(server.ReceivedRequests()).To(BeEmpty())
+		})
+	})
 })
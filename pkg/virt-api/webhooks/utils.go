@@ -76,6 +76,30 @@ var MigrationGroupVersionResource = metav1.GroupVersionResource{
 	Resource: "virtualmachineinstancemigrations",
 }
 
+var ContainerDiskImageCacheGroupVersionResource = metav1.GroupVersionResource{
+	Group:    v1.ContainerDiskImageCacheGroupVersionKind.Group,
+	Version:  v1.ContainerDiskImageCacheGroupVersionKind.Version,
+	Resource: "containerdiskimagecaches",
+}
+
+var VirtualMachineBackupGroupVersionResource = metav1.GroupVersionResource{
+	Group:    v1.VirtualMachineBackupGroupVersionKind.Group,
+	Version:  v1.VirtualMachineBackupGroupVersionKind.Version,
+	Resource: "virtualmachinebackups",
+}
+
+var VirtualMachineExportGroupVersionResource = metav1.GroupVersionResource{
+	Group:    v1.VirtualMachineExportGroupVersionKind.Group,
+	Version:  v1.VirtualMachineExportGroupVersionKind.Version,
+	Resource: "virtualmachineexports",
+}
+
+var KubeVirtGroupVersionResource = metav1.GroupVersionResource{
+	Group:    v1.KubeVirtGroupVersionKind.Group,
+	Version:  v1.KubeVirtGroupVersionKind.Version,
+	Resource: "kubevirts",
+}
+
 func ValidateRequestResource(request metav1.GroupVersionResource, group string, resource string) bool {
 	gvr := metav1.GroupVersionResource{Group: group, Resource: resource}
 
@@ -0,0 +1,181 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2020 Red Hat, Inc.
+ *
+ */
+
+package admitters
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"k8s.io/api/admission/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sfield "k8s.io/apimachinery/pkg/util/validation/field"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/kubevirt/pkg/virt-api/webhooks"
+	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
+)
+
+type VirtualMachineBackupAdmitter struct {
+	ClusterConfig *virtconfig.ClusterConfig
+}
+
+func (admitter *VirtualMachineBackupAdmitter) Admit(ar *v1beta1.AdmissionReview) *v1beta1.AdmissionResponse {
+	newBackup, oldBackup, err := getAdmissionReviewBackup(ar)
+	if err != nil {
+		return webhooks.ToAdmissionResponseError(err)
+	}
+
+	// Updates are still allowed, so that started backups can be finished after the gate got disabled
+	if oldBackup == nil && !admitter.ClusterConfig.VMBackupEnabled() {
+		return webhooks.ToAdmissionResponseError(fmt.Errorf("%s feature gate is not enabled in kubevirt-config", virtconfig.VMBackupGate))
+	}
+
+	var causes []metav1.StatusCause
+	if oldBackup != nil {
+		causes = validateBackupSpecUpdate(&newBackup.Spec, &oldBackup.Spec)
+	} else {
+		causes = ValidateVirtualMachineBackupSpec(k8sfield.NewPath("spec"), &newBackup.Spec)
+	}
+	if len(causes) > 0 {
+		return webhooks.ToAdmissionResponse(causes)
+	}
+
+	reviewResponse := v1beta1.AdmissionResponse{}
+	reviewResponse.Allowed = true
+	return &reviewResponse
+}
+
+func getAdmissionReviewBackup(ar *v1beta1.AdmissionReview) (new *v1.VirtualMachineBackup, old *v1.VirtualMachineBackup, err error) {
+	if !webhooks.ValidateRequestResource(ar.Request.Resource, webhooks.VirtualMachineBackupGroupVersionResource.Group, webhooks.VirtualMachineBackupGroupVersionResource.Resource) {
+		return nil, nil, fmt.Errorf("expect resource to be '%s'", webhooks.VirtualMachineBackupGroupVersionResource.Resource)
+	}
+
+	newBackup := v1.VirtualMachineBackup{}
+	err = json.Unmarshal(ar.Request.Object.Raw, &newBackup)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if ar.Request.Operation == v1beta1.Update {
+		oldBackup := v1.VirtualMachineBackup{}
+		err = json.Unmarshal(ar.Request.OldObject.Raw, &oldBackup)
+		if err != nil {
+			return nil, nil, err
+		}
+		return &newBackup, &oldBackup, nil
+	}
+
+	return &newBackup, nil, nil
+}
+
+func ValidateVirtualMachineBackupSpec(field *k8sfield.Path, spec *v1.VirtualMachineBackupSpec) []metav1.StatusCause {
+	var causes []metav1.StatusCause
+
+	if spec.VMIName == "" {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueRequired,
+			Message: fmt.Sprintf("%s is required", field.Child("vmiName").String()),
+			Field:   field.Child("vmiName").String(),
+		})
+	}
+
+	switch spec.Mode {
+	case "", v1.BackupModeFull:
+		if spec.BaseBackupName != "" {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueNotSupported,
+				Message: fmt.Sprintf("%s is only supported for %s backups", field.Child("baseBackupName").String(), v1.BackupModeIncremental),
+				Field:   field.Child("baseBackupName").String(),
+			})
+		}
+	case v1.BackupModeIncremental:
+		// The changed blocks are only exposed as NBD meta context, a plain copy would contain all blocks
+		if spec.Target.PersistentVolumeClaim != nil {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueNotSupported,
+				Message: fmt.Sprintf("%s backups are only supported with a nbd target", v1.BackupModeIncremental),
+				Field:   field.Child("target", "persistentVolumeClaim").String(),
+			})
+		}
+	default:
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueNotSupported,
+			Message: fmt.Sprintf("%s must be %s or %s", field.Child("mode").String(), v1.BackupModeFull, v1.BackupModeIncremental),
+			Field:   field.Child("mode").String(),
+		})
+	}
+
+	targets := 0
+	if spec.Target.NBD != nil {
+		targets++
+		if spec.Target.NBD.Done {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueNotSupported,
+				Message: fmt.Sprintf("%s can only be set once the backup is running", field.Child("target", "nbd", "done").String()),
+				Field:   field.Child("target", "nbd", "done").String(),
+			})
+		}
+	}
+	if spec.Target.PersistentVolumeClaim != nil {
+		targets++
+		if spec.Target.PersistentVolumeClaim.ClaimName == "" {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueRequired,
+				Message: fmt.Sprintf("%s is required", field.Child("target", "persistentVolumeClaim", "claimName").String()),
+				Field:   field.Child("target", "persistentVolumeClaim", "claimName").String(),
+			})
+		}
+		if spec.Target.PersistentVolumeClaim.ReadOnly {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueNotSupported,
+				Message: fmt.Sprintf("%s must be writable", field.Child("target", "persistentVolumeClaim").String()),
+				Field:   field.Child("target", "persistentVolumeClaim", "readOnly").String(),
+			})
+		}
+	}
+	if targets != 1 {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("%s must contain exactly one target", field.Child("target").String()),
+			Field:   field.Child("target").String(),
+		})
+	}
+
+	return causes
+}
+
+// validateBackupSpecUpdate only allows the backup application to mark the NBD exports as read
+func validateBackupSpecUpdate(newSpec *v1.VirtualMachineBackupSpec, oldSpec *v1.VirtualMachineBackupSpec) []metav1.StatusCause {
+	newSpecCopy := newSpec.DeepCopy()
+	if newSpecCopy.Target.NBD != nil && oldSpec.Target.NBD != nil && !oldSpec.Target.NBD.Done {
+		newSpecCopy.Target.NBD.Done = false
+	}
+
+	if !reflect.DeepEqual(newSpecCopy, oldSpec) {
+		return []metav1.StatusCause{
+			{
+				Type:    metav1.CauseTypeFieldValueNotSupported,
+				Message: "update of VirtualMachineBackup object's spec is restricted to target.nbd.done",
+			},
+		}
+	}
+	return nil
+}
@@ -0,0 +1,191 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2020 Red Hat, Inc.
+ *
+ */
+
+package admitters
+
+import (
+	"encoding/json"
+
+	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	"k8s.io/api/admission/v1beta1"
+	k8sv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/kubevirt/pkg/testutils"
+	"kubevirt.io/kubevirt/pkg/virt-api/webhooks"
+	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
+)
+
+var _ = Describe("Validating VirtualMachineBackup Admitter", func() {
+	config, configMapInformer, _ := testutils.NewFakeClusterConfig(&k8sv1.ConfigMap{})
+	backupAdmitter := &VirtualMachineBackupAdmitter{ClusterConfig: config}
+
+	enableFeatureGate := func(featureGate string) {
+		testutils.UpdateFakeClusterConfig(configMapInformer, &k8sv1.ConfigMap{
+			Data: map[string]string{virtconfig.FeatureGatesKey: featureGate},
+		})
+	}
+	disableFeatureGates := func() {
+		testutils.UpdateFakeClusterConfig(configMapInformer, &k8sv1.ConfigMap{})
+	}
+
+	BeforeEach(func() {
+		enableFeatureGate(virtconfig.VMBackupGate)
+	})
+
+	AfterEach(func() {
+		disableFeatureGates()
+	})
+
+	admit := func(backup *v1.VirtualMachineBackup, oldBackup *v1.VirtualMachineBackup) *v1beta1.AdmissionResponse {
+		backupBytes, _ := json.Marshal(backup)
+		ar := &v1beta1.AdmissionReview{
+			Request: &v1beta1.AdmissionRequest{
+				Operation: v1beta1.Create,
+				Resource:  webhooks.VirtualMachineBackupGroupVersionResource,
+				Object: runtime.RawExtension{
+					Raw: backupBytes,
+				},
+			},
+		}
+		if oldBackup != nil {
+			oldBackupBytes, _ := json.Marshal(oldBackup)
+			ar.Request.Operation = v1beta1.Update
+			ar.Request.OldObject = runtime.RawExtension{Raw: oldBackupBytes}
+		}
+		return backupAdmitter.Admit(ar)
+	}
+
+	nbdSpec := func() v1.VirtualMachineBackupSpec {
+		return v1.VirtualMachineBackupSpec{
+			VMIName: "testvmi",
+			Mode:    v1.BackupModeIncremental,
+			Target: v1.VirtualMachineBackupTarget{
+				NBD: &v1.NBDBackupTarget{},
+			},
+		}
+	}
+
+	It("should reject requests for other resources", func() {
+		ar := &v1beta1.AdmissionReview{
+			Request: &v1beta1.AdmissionRequest{
+				Resource: webhooks.MigrationGroupVersionResource,
+			},
+		}
+		resp := backupAdmitter.Admit(ar)
+		Expect(resp.Allowed).To(BeFalse())
+	})
+
+	table.DescribeTable("should accept a valid spec", func(spec v1.VirtualMachineBackupSpec) {
+		resp := admit(&v1.VirtualMachineBackup{Spec: spec}, nil)
+		Expect(resp.Allowed).To(BeTrue())
+	},
+		table.Entry("with an incremental nbd backup", nbdSpec()),
+		table.Entry("with a full pvc backup", v1.VirtualMachineBackupSpec{
+			VMIName: "testvmi",
+			Target: v1.VirtualMachineBackupTarget{
+				PersistentVolumeClaim: &k8sv1.PersistentVolumeClaimVolumeSource{ClaimName: "backups"},
+			},
+		}),
+	)
+
+	table.DescribeTable("should reject an invalid spec", func(spec v1.VirtualMachineBackupSpec, field string) {
+		resp := admit(&v1.VirtualMachineBackup{Spec: spec}, nil)
+		Expect(resp.Allowed).To(BeFalse())
+		Expect(resp.Result.Details.Causes).To(HaveLen(1))
+		Expect(resp.Result.Details.Causes[0].Field).To(Equal(field))
+	},
+		table.Entry("without a vmi name", v1.VirtualMachineBackupSpec{
+			Target: v1.VirtualMachineBackupTarget{NBD: &v1.NBDBackupTarget{}},
+		}, "spec.vmiName"),
+		table.Entry("with an unknown mode", v1.VirtualMachineBackupSpec{
+			VMIName: "testvmi",
+			Mode:    "Differential",
+			Target:  v1.VirtualMachineBackupTarget{NBD: &v1.NBDBackupTarget{}},
+		}, "spec.mode"),
+		table.Entry("with a base backup for a full backup", v1.VirtualMachineBackupSpec{
+			VMIName:        "testvmi",
+			BaseBackupName: "base",
+			Target:         v1.VirtualMachineBackupTarget{NBD: &v1.NBDBackupTarget{}},
+		}, "spec.baseBackupName"),
+		table.Entry("without a target", v1.VirtualMachineBackupSpec{
+			VMIName: "testvmi",
+		}, "spec.target"),
+		table.Entry("with two targets", v1.VirtualMachineBackupSpec{
+			VMIName: "testvmi",
+			Target: v1.VirtualMachineBackupTarget{
+				NBD:                   &v1.NBDBackupTarget{},
+				PersistentVolumeClaim: &k8sv1.PersistentVolumeClaimVolumeSource{ClaimName: "backups"},
+			},
+		}, "spec.target"),
+		table.Entry("with a pvc target without a claim name", v1.VirtualMachineBackupSpec{
+			VMIName: "testvmi",
+			Target: v1.VirtualMachineBackupTarget{
+				PersistentVolumeClaim: &k8sv1.PersistentVolumeClaimVolumeSource{},
+			},
+		}, "spec.target.persistentVolumeClaim.claimName"),
+		table.Entry("with an incremental backup to a pvc", v1.VirtualMachineBackupSpec{
+			VMIName: "testvmi",
+			Mode:    v1.BackupModeIncremental,
+			Target: v1.VirtualMachineBackupTarget{
+				PersistentVolumeClaim: &k8sv1.PersistentVolumeClaimVolumeSource{ClaimName: "backups"},
+			},
+		}, "spec.target.persistentVolumeClaim"),
+		table.Entry("with a nbd target which is already done", v1.VirtualMachineBackupSpec{
+			VMIName: "testvmi",
+			Target:  v1.VirtualMachineBackupTarget{NBD: &v1.NBDBackupTarget{Done: true}},
+		}, "spec.target.nbd.done"),
+	)
+
+	It("should reject backups if the feature gate is not enabled", func() {
+		disableFeatureGates()
+		resp := admit(&v1.VirtualMachineBackup{Spec: nbdSpec()}, nil)
+		Expect(resp.Allowed).To(BeFalse())
+		Expect(resp.Result.Message).To(ContainSubstring(virtconfig.VMBackupGate))
+	})
+
+	It("should allow to finish started backups if the feature gate is not enabled", func() {
+		disableFeatureGates()
+		oldBackup := &v1.VirtualMachineBackup{Spec: nbdSpec()}
+		newBackup := oldBackup.DeepCopy()
+		newBackup.Spec.Target.NBD.Done = true
+		resp := admit(newBackup, oldBackup)
+		Expect(resp.Allowed).To(BeTrue())
+	})
+
+	It("should allow to mark the nbd exports as done", func() {
+		oldBackup := &v1.VirtualMachineBackup{Spec: nbdSpec()}
+		newBackup := oldBackup.DeepCopy()
+		newBackup.Spec.Target.NBD.Done = true
+		resp := admit(newBackup, oldBackup)
+		Expect(resp.Allowed).To(BeTrue())
+	})
+
+	It("should reject other spec updates", func() {
+		oldBackup := &v1.VirtualMachineBackup{Spec: nbdSpec()}
+		newBackup := oldBackup.DeepCopy()
+		newBackup.Spec.VMIName = "othervmi"
+		newBackup.Spec.Target.NBD.Done = true
+		resp := admit(newBackup, oldBackup)
+		Expect(resp.Allowed).To(BeFalse())
+	})
+})
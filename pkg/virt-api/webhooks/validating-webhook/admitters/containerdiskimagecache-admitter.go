@@ -0,0 +1,98 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2019 Red Hat, Inc.
+ *
+ */
+
+package admitters
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"k8s.io/api/admission/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sfield "k8s.io/apimachinery/pkg/util/validation/field"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/kubevirt/pkg/virt-api/webhooks"
+)
+
+type ContainerDiskImageCacheAdmitter struct {
+}
+
+func (admitter *ContainerDiskImageCacheAdmitter) Admit(ar *v1beta1.AdmissionReview) *v1beta1.AdmissionResponse {
+	if !webhooks.ValidateRequestResource(ar.Request.Resource, webhooks.ContainerDiskImageCacheGroupVersionResource.Group, webhooks.ContainerDiskImageCacheGroupVersionResource.Resource) {
+		err := fmt.Errorf("expect resource to be '%s'", webhooks.ContainerDiskImageCacheGroupVersionResource.Resource)
+		return webhooks.ToAdmissionResponseError(err)
+	}
+
+	imageCache := v1.ContainerDiskImageCache{}
+	err := json.Unmarshal(ar.Request.Object.Raw, &imageCache)
+	if err != nil {
+		return webhooks.ToAdmissionResponseError(err)
+	}
+
+	causes := ValidateContainerDiskImageCacheSpec(k8sfield.NewPath("spec"), &imageCache.Spec)
+	if len(causes) > 0 {
+		return webhooks.ToAdmissionResponse(causes)
+	}
+
+	reviewResponse := v1beta1.AdmissionResponse{}
+	reviewResponse.Allowed = true
+	return &reviewResponse
+}
+
+func ValidateContainerDiskImageCacheSpec(field *k8sfield.Path, spec *v1.ContainerDiskImageCacheSpec) []metav1.StatusCause {
+	var causes []metav1.StatusCause
+
+	if len(spec.Images) == 0 {
+		return append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueRequired,
+			Message: fmt.Sprintf("%s must contain at least one image", field.Child("images").String()),
+			Field:   field.Child("images").String(),
+		})
+	}
+
+	seen := map[string]bool{}
+	for idx, image := range spec.Images {
+		if image == "" {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueRequired,
+				Message: fmt.Sprintf("%s must not be empty", field.Child("images").Index(idx).String()),
+				Field:   field.Child("images").Index(idx).String(),
+			})
+		} else if seen[image] {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueDuplicate,
+				Message: fmt.Sprintf("%s image %s is listed more than once", field.Child("images").Index(idx).String(), image),
+				Field:   field.Child("images").Index(idx).String(),
+			})
+		}
+		seen[image] = true
+	}
+
+	for idx, secret := range spec.ImagePullSecrets {
+		if secret.Name == "" {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueRequired,
+				Message: fmt.Sprintf("%s must not be empty", field.Child("imagePullSecrets").Index(idx).Child("name").String()),
+				Field:   field.Child("imagePullSecrets").Index(idx).Child("name").String(),
+			})
+		}
+	}
+	return causes
+}
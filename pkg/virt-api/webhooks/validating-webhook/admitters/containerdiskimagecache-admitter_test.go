@@ -0,0 +1,95 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2019 Red Hat, Inc.
+ *
+ */
+
+package admitters
+
+import (
+	"encoding/json"
+
+	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	"k8s.io/api/admission/v1beta1"
+	k8sv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8sfield "k8s.io/apimachinery/pkg/util/validation/field"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/kubevirt/pkg/virt-api/webhooks"
+)
+
+var _ = Describe("Validating ContainerDiskImageCache Admitter", func() {
+	imageCacheAdmitter := &ContainerDiskImageCacheAdmitter{}
+
+	admit := func(imageCache *v1.ContainerDiskImageCache) *v1beta1.AdmissionResponse {
+		imageCacheBytes, _ := json.Marshal(imageCache)
+		ar := &v1beta1.AdmissionReview{
+			Request: &v1beta1.AdmissionRequest{
+				Resource: webhooks.ContainerDiskImageCacheGroupVersionResource,
+				Object: runtime.RawExtension{
+					Raw: imageCacheBytes,
+				},
+			},
+		}
+		return imageCacheAdmitter.Admit(ar)
+	}
+
+	It("should accept a valid image cache", func() {
+		imageCache := &v1.ContainerDiskImageCache{
+			Spec: v1.ContainerDiskImageCacheSpec{
+				Images:           []string{"registry:5000/kubevirt/cirros-container-disk-demo:devel"},
+				ImagePullSecrets: []k8sv1.LocalObjectReference{{Name: "pull-secret"}},
+			},
+		}
+		resp := admit(imageCache)
+		Expect(resp.Allowed).To(BeTrue())
+	})
+
+	It("should reject requests for other resources", func() {
+		ar := &v1beta1.AdmissionReview{
+			Request: &v1beta1.AdmissionRequest{
+				Resource: webhooks.VirtualMachineInstancePresetGroupVersionResource,
+			},
+		}
+		resp := imageCacheAdmitter.Admit(ar)
+		Expect(resp.Allowed).To(BeFalse())
+	})
+
+	table.DescribeTable("should reject an invalid spec", func(spec v1.ContainerDiskImageCacheSpec, field string) {
+		resp := admit(&v1.ContainerDiskImageCache{Spec: spec})
+		Expect(resp.Allowed).To(BeFalse())
+		Expect(resp.Result.Details.Causes).To(HaveLen(1))
+		Expect(resp.Result.Details.Causes[0].Field).To(Equal(field))
+
+		causes := ValidateContainerDiskImageCacheSpec(k8sfield.NewPath("spec"), &spec)
+		Expect(causes).To(HaveLen(1))
+	},
+		table.Entry("without images", v1.ContainerDiskImageCacheSpec{}, "spec.images"),
+		table.Entry("with an empty image", v1.ContainerDiskImageCacheSpec{
+			Images: []string{"image-a", ""},
+		}, "spec.images[1]"),
+		table.Entry("with a duplicate image", v1.ContainerDiskImageCacheSpec{
+			Images: []string{"image-a", "image-b", "image-a"},
+		}, "spec.images[2]"),
+		table.Entry("with an unnamed pull secret", v1.ContainerDiskImageCacheSpec{
+			Images:           []string{"image-a"},
+			ImagePullSecrets: []k8sv1.LocalObjectReference{{}},
+		}, "spec.imagePullSecrets[0].name"),
+	)
+})
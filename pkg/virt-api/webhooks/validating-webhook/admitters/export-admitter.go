@@ -0,0 +1,122 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2020 Red Hat, Inc.
+ *
+ */
+
+package admitters
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"k8s.io/api/admission/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sfield "k8s.io/apimachinery/pkg/util/validation/field"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/kubevirt/pkg/virt-api/webhooks"
+)
+
+type VirtualMachineExportAdmitter struct {
+}
+
+func (admitter *VirtualMachineExportAdmitter) Admit(ar *v1beta1.AdmissionReview) *v1beta1.AdmissionResponse {
+	newExport, oldExport, err := getAdmissionReviewExport(ar)
+	if err != nil {
+		return webhooks.ToAdmissionResponseError(err)
+	}
+
+	var causes []metav1.StatusCause
+	if oldExport != nil {
+		if !reflect.DeepEqual(newExport.Spec, oldExport.Spec) {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueNotSupported,
+				Message: "update of VirtualMachineExport object's spec is restricted",
+			})
+		}
+	} else {
+		causes = ValidateVirtualMachineExportSpec(k8sfield.NewPath("spec"), &newExport.Spec)
+	}
+	if len(causes) > 0 {
+		return webhooks.ToAdmissionResponse(causes)
+	}
+
+	reviewResponse := v1beta1.AdmissionResponse{}
+	reviewResponse.Allowed = true
+	return &reviewResponse
+}
+
+func getAdmissionReviewExport(ar *v1beta1.AdmissionReview) (new *v1.VirtualMachineExport, old *v1.VirtualMachineExport, err error) {
+	if !webhooks.ValidateRequestResource(ar.Request.Resource, webhooks.VirtualMachineExportGroupVersionResource.Group, webhooks.VirtualMachineExportGroupVersionResource.Resource) {
+		return nil, nil, fmt.Errorf("expect resource to be '%s'", webhooks.VirtualMachineExportGroupVersionResource.Resource)
+	}
+
+	newExport := v1.VirtualMachineExport{}
+	err = json.Unmarshal(ar.Request.Object.Raw, &newExport)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if ar.Request.Operation == v1beta1.Update {
+		oldExport := v1.VirtualMachineExport{}
+		err = json.Unmarshal(ar.Request.OldObject.Raw, &oldExport)
+		if err != nil {
+			return nil, nil, err
+		}
+		return &newExport, &oldExport, nil
+	}
+
+	return &newExport, nil, nil
+}
+
+func ValidateVirtualMachineExportSpec(field *k8sfield.Path, spec *v1.VirtualMachineExportSpec) []metav1.StatusCause {
+	var causes []metav1.StatusCause
+
+	apiGroup := ""
+	if spec.Source.APIGroup != nil {
+		apiGroup = *spec.Source.APIGroup
+	}
+	switch {
+	case spec.Source.Kind == v1.VirtualMachineGroupVersionKind.Kind && apiGroup == v1.GroupName:
+	case spec.Source.Kind == "PersistentVolumeClaim" && apiGroup == "":
+	default:
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueNotSupported,
+			Message: fmt.Sprintf("%s must be a %s.%s or a PersistentVolumeClaim", field.Child("source").String(), v1.VirtualMachineGroupVersionKind.Kind, v1.GroupName),
+			Field:   field.Child("source", "kind").String(),
+		})
+	}
+
+	if spec.Source.Name == "" {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueRequired,
+			Message: fmt.Sprintf("%s is required", field.Child("source", "name").String()),
+			Field:   field.Child("source", "name").String(),
+		})
+	}
+
+	if spec.TokenSecretRef == "" {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueRequired,
+			Message: fmt.Sprintf("%s is required", field.Child("tokenSecretRef").String()),
+			Field:   field.Child("tokenSecretRef").String(),
+		})
+	}
+
+	return causes
+}
@@ -0,0 +1,144 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2020 Red Hat, Inc.
+ *
+ */
+
+package admitters
+
+import (
+	"encoding/json"
+
+	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	"k8s.io/api/admission/v1beta1"
+	k8sv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/kubevirt/pkg/virt-api/webhooks"
+)
+
+var _ = Describe("Validating VirtualMachineExport Admitter", func() {
+	exportAdmitter := &VirtualMachineExportAdmitter{}
+	kubevirtGroup := v1.GroupName
+
+	admit := func(export *v1.VirtualMachineExport, oldExport *v1.VirtualMachineExport) *v1beta1.AdmissionResponse {
+		exportBytes, _ := json.Marshal(export)
+		ar := &v1beta1.AdmissionReview{
+			Request: &v1beta1.AdmissionRequest{
+				Operation: v1beta1.Create,
+				Resource:  webhooks.VirtualMachineExportGroupVersionResource,
+				Object: runtime.RawExtension{
+					Raw: exportBytes,
+				},
+			},
+		}
+		if oldExport != nil {
+			oldExportBytes, _ := json.Marshal(oldExport)
+			ar.Request.Operation = v1beta1.Update
+			ar.Request.OldObject = runtime.RawExtension{Raw: oldExportBytes}
+		}
+		return exportAdmitter.Admit(ar)
+	}
+
+	vmSpec := func() v1.VirtualMachineExportSpec {
+		return v1.VirtualMachineExportSpec{
+			Source: k8sv1.TypedLocalObjectReference{
+				APIGroup: &kubevirtGroup,
+				Kind:     "VirtualMachine",
+				Name:     "testvm",
+			},
+			TokenSecretRef: "token",
+		}
+	}
+
+	It("should reject requests for other resources", func() {
+		ar := &v1beta1.AdmissionReview{
+			Request: &v1beta1.AdmissionRequest{
+				Resource: webhooks.VirtualMachineBackupGroupVersionResource,
+			},
+		}
+		resp := exportAdmitter.Admit(ar)
+		Expect(resp.Allowed).To(BeFalse())
+	})
+
+	table.DescribeTable("should accept a valid spec", func(spec v1.VirtualMachineExportSpec) {
+		resp := admit(&v1.VirtualMachineExport{Spec: spec}, nil)
+		Expect(resp.Allowed).To(BeTrue())
+	},
+		table.Entry("with a VirtualMachine source", vmSpec()),
+		table.Entry("with a PersistentVolumeClaim source", v1.VirtualMachineExportSpec{
+			Source: k8sv1.TypedLocalObjectReference{
+				Kind: "PersistentVolumeClaim",
+				Name: "testpvc",
+			},
+			TokenSecretRef: "token",
+		}),
+	)
+
+	table.DescribeTable("should reject an invalid spec", func(spec v1.VirtualMachineExportSpec, field string) {
+		resp := admit(&v1.VirtualMachineExport{Spec: spec}, nil)
+		Expect(resp.Allowed).To(BeFalse())
+		Expect(resp.Result.Details.Causes).To(HaveLen(1))
+		Expect(resp.Result.Details.Causes[0].Field).To(Equal(field))
+	},
+		table.Entry("with an unsupported source kind", v1.VirtualMachineExportSpec{
+			Source: k8sv1.TypedLocalObjectReference{
+				APIGroup: &kubevirtGroup,
+				Kind:     "VirtualMachineInstance",
+				Name:     "testvmi",
+			},
+			TokenSecretRef: "token",
+		}, "spec.source.kind"),
+		table.Entry("with a VirtualMachine source without api group", v1.VirtualMachineExportSpec{
+			Source: k8sv1.TypedLocalObjectReference{
+				Kind: "VirtualMachine",
+				Name: "testvm",
+			},
+			TokenSecretRef: "token",
+		}, "spec.source.kind"),
+		table.Entry("without a source name", v1.VirtualMachineExportSpec{
+			Source: k8sv1.TypedLocalObjectReference{
+				Kind: "PersistentVolumeClaim",
+			},
+			TokenSecretRef: "token",
+		}, "spec.source.name"),
+		table.Entry("without a token secret", v1.VirtualMachineExportSpec{
+			Source: k8sv1.TypedLocalObjectReference{
+				Kind: "PersistentVolumeClaim",
+				Name: "testpvc",
+			},
+		}, "spec.tokenSecretRef"),
+	)
+
+	It("should reject spec updates", func() {
+		oldExport := &v1.VirtualMachineExport{Spec: vmSpec()}
+		newExport := oldExport.DeepCopy()
+		newExport.Spec.TokenSecretRef = "othertoken"
+		resp := admit(newExport, oldExport)
+		Expect(resp.Allowed).To(BeFalse())
+	})
+
+	It("should allow metadata updates", func() {
+		oldExport := &v1.VirtualMachineExport{Spec: vmSpec()}
+		newExport := oldExport.DeepCopy()
+		newExport.Labels = map[string]string{"app": "dr"}
+		resp := admit(newExport, oldExport)
+		Expect(resp.Allowed).To(BeTrue())
+	})
+})
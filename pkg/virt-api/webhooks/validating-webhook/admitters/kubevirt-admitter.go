@@ -0,0 +1,302 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2020 Red Hat, Inc.
+ *
+ */
+
+package admitters
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	jsonpatch "github.com/evanphx/json-patch"
+	"k8s.io/api/admission/v1beta1"
+	k8sv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+	k8sfield "k8s.io/apimachinery/pkg/util/validation/field"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/client-go/kubecli"
+	"kubevirt.io/kubevirt/pkg/virt-api/webhooks"
+	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
+)
+
+type KubeVirtAdmitter struct {
+	Client kubecli.KubevirtClient
+}
+
+func (admitter *KubeVirtAdmitter) Admit(ar *v1beta1.AdmissionReview) *v1beta1.AdmissionResponse {
+	newKV, oldKV, err := getAdmissionReviewKubeVirt(ar)
+	if err != nil {
+		return webhooks.ToAdmissionResponseError(err)
+	}
+
+	// virt-operator keeps updating the status and the finalizers of a
+	// KubeVirt CR, only validate the configuration if it changes
+	if oldKV == nil || !reflect.DeepEqual(newKV.Spec.Configuration, oldKV.Spec.Configuration) {
+		causes := virtconfig.ValidateConfiguration(k8sfield.NewPath("spec", "configuration"), &newKV.Spec.Configuration)
+		if len(causes) > 0 {
+			return webhooks.ToAdmissionResponse(causes)
+		}
+	}
+
+	if oldKV == nil || !reflect.DeepEqual(newKV.Spec.Infra, oldKV.Spec.Infra) {
+		causes := validateInfraReplicas(k8sfield.NewPath("spec", "infra", "replicas"), newKV.Spec.Infra)
+		if len(causes) > 0 {
+			return webhooks.ToAdmissionResponse(causes)
+		}
+	}
+
+	// virt-handler leaves nodes which don't match the new placement, the
+	// VMIs running there would lose their handler
+	if oldKV != nil && !reflect.DeepEqual(newKV.Spec.Workloads, oldKV.Spec.Workloads) {
+		causes, err := admitter.validateWorkloadsPlacement(k8sfield.NewPath("spec", "workloads"), newKV.Spec.Workloads)
+		if err != nil {
+			return webhooks.ToAdmissionResponseError(err)
+		}
+		if len(causes) > 0 {
+			return webhooks.ToAdmissionResponse(causes)
+		}
+	}
+
+	if oldKV == nil || !reflect.DeepEqual(newKV.Spec.CustomizeComponents, oldKV.Spec.CustomizeComponents) {
+		causes := validateCustomizeComponents(k8sfield.NewPath("spec", "customizeComponents", "patches"), newKV.Spec.CustomizeComponents)
+		if len(causes) > 0 {
+			return webhooks.ToAdmissionResponse(causes)
+		}
+	}
+
+	reviewResponse := v1beta1.AdmissionResponse{}
+	reviewResponse.Allowed = true
+	return &reviewResponse
+}
+
+// validateInfraReplicas makes sure that virt-api and virt-controller keep running
+func validateInfraReplicas(field *k8sfield.Path, infra *v1.ComponentConfig) []metav1.StatusCause {
+	if infra == nil || infra.Replicas == nil || *infra.Replicas > 0 {
+		return nil
+	}
+	return []metav1.StatusCause{{
+		Type:    metav1.CauseTypeFieldValueInvalid,
+		Message: fmt.Sprintf("%s must be greater than zero", field.String()),
+		Field:   field.String(),
+	}}
+}
+
+// validateWorkloadsPlacement rejects a placement for virt-handler which
+// excludes nodes with running VMIs. Those VMIs have to be migrated away or
+// stopped before the placement can be changed.
+func (admitter *KubeVirtAdmitter) validateWorkloadsPlacement(field *k8sfield.Path, workloads *v1.ComponentConfig) ([]metav1.StatusCause, error) {
+	if workloads == nil || workloads.NodePlacement == nil {
+		return nil, nil
+	}
+
+	vmis, err := admitter.Client.VirtualMachineInstance(k8sv1.NamespaceAll).List(&metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the VMIs running on the cluster: %v", err)
+	}
+
+	nodeNames := map[string]bool{}
+	for _, vmi := range vmis.Items {
+		if vmi.Status.NodeName != "" && !vmi.IsFinal() {
+			nodeNames[vmi.Status.NodeName] = true
+		}
+		if vmi.Status.MigrationState != nil && vmi.Status.MigrationState.TargetNode != "" && !vmi.Status.MigrationState.Completed {
+			nodeNames[vmi.Status.MigrationState.TargetNode] = true
+		}
+	}
+
+	var excluded []string
+	for nodeName := range nodeNames {
+		node, err := admitter.Client.CoreV1().Nodes().Get(nodeName, metav1.GetOptions{})
+		if errors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return nil, fmt.Errorf("failed to get node %s: %v", nodeName, err)
+		}
+		if !nodeMatchesPlacement(node, workloads.NodePlacement) {
+			excluded = append(excluded, nodeName)
+		}
+	}
+	if len(excluded) == 0 {
+		return nil, nil
+	}
+
+	sort.Strings(excluded)
+	return []metav1.StatusCause{{
+		Type:    metav1.CauseTypeFieldValueInvalid,
+		Message: fmt.Sprintf("%s excludes the nodes %s which run VMIs, migrate or stop them first", field.String(), strings.Join(excluded, ", ")),
+		Field:   field.String(),
+	}}, nil
+}
+
+// nodeMatchesPlacement returns true if virt-handler keeps running on the node
+// with the given placement. Only node selectors, required node affinities and
+// NoExecute taints evict DaemonSet pods which already run on a node.
+func nodeMatchesPlacement(node *k8sv1.Node, placement *v1.NodePlacement) bool {
+	for key, value := range placement.NodeSelector {
+		if node.Labels[key] != value {
+			return false
+		}
+	}
+
+	if placement.Affinity != nil && placement.Affinity.NodeAffinity != nil {
+		if required := placement.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution; required != nil {
+			matches := false
+			for _, term := range required.NodeSelectorTerms {
+				if nodeMatchesSelectorTerm(node, term) {
+					matches = true
+					break
+				}
+			}
+			if !matches {
+				return false
+			}
+		}
+	}
+
+	// virt-handler always tolerates CriticalAddonsOnly
+	tolerations := append([]k8sv1.Toleration{{Key: "CriticalAddonsOnly", Operator: k8sv1.TolerationOpExists}}, placement.Tolerations...)
+	for i := range node.Spec.Taints {
+		taint := &node.Spec.Taints[i]
+		if taint.Effect != k8sv1.TaintEffectNoExecute {
+			continue
+		}
+		tolerated := false
+		for j := range tolerations {
+			if tolerations[j].ToleratesTaint(taint) {
+				tolerated = true
+				break
+			}
+		}
+		if !tolerated {
+			return false
+		}
+	}
+	return true
+}
+
+func nodeMatchesSelectorTerm(node *k8sv1.Node, term k8sv1.NodeSelectorTerm) bool {
+	if len(term.MatchExpressions) == 0 && len(term.MatchFields) == 0 {
+		return false
+	}
+	for _, requirement := range term.MatchExpressions {
+		if !selectorRequirementMatches(requirement, node.Labels) {
+			return false
+		}
+	}
+	for _, requirement := range term.MatchFields {
+		if !selectorRequirementMatches(requirement, map[string]string{"metadata.name": node.Name}) {
+			return false
+		}
+	}
+	return true
+}
+
+func selectorRequirementMatches(requirement k8sv1.NodeSelectorRequirement, set map[string]string) bool {
+	var op selection.Operator
+	switch requirement.Operator {
+	case k8sv1.NodeSelectorOpIn:
+		op = selection.In
+	case k8sv1.NodeSelectorOpNotIn:
+		op = selection.NotIn
+	case k8sv1.NodeSelectorOpExists:
+		op = selection.Exists
+	case k8sv1.NodeSelectorOpDoesNotExist:
+		op = selection.DoesNotExist
+	case k8sv1.NodeSelectorOpGt:
+		op = selection.GreaterThan
+	case k8sv1.NodeSelectorOpLt:
+		op = selection.LessThan
+	default:
+		return false
+	}
+	r, err := labels.NewRequirement(requirement.Key, op, requirement.Values)
+	if err != nil {
+		return false
+	}
+	return r.Matches(labels.Set(set))
+}
+
+// validateCustomizeComponents checks the patches for the resources generated
+// by virt-operator, whether they apply is only known when virt-operator syncs
+func validateCustomizeComponents(field *k8sfield.Path, customizations v1.CustomizeComponents) []metav1.StatusCause {
+	var causes []metav1.StatusCause
+
+	invalid := func(field *k8sfield.Path, message string) {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("%s %s", field.String(), message),
+			Field:   field.String(),
+		})
+	}
+
+	for i, patch := range customizations.Patches {
+		patchField := field.Index(i)
+		if patch.ResourceType == "" {
+			invalid(patchField.Child("resourceType"), "must not be empty")
+		}
+		if patch.ResourceName == "" {
+			invalid(patchField.Child("resourceName"), "must not be empty")
+		}
+
+		switch patch.Type {
+		case v1.JSONPatchType:
+			if _, err := jsonpatch.DecodePatch([]byte(patch.Patch)); err != nil {
+				invalid(patchField.Child("patch"), fmt.Sprintf("is not a valid json patch: %v", err))
+			}
+		case v1.MergePatchType, v1.StrategicMergePatchType:
+			var body map[string]interface{}
+			if err := json.Unmarshal([]byte(patch.Patch), &body); err != nil {
+				invalid(patchField.Child("patch"), fmt.Sprintf("is not a valid json object: %v", err))
+			}
+		default:
+			invalid(patchField.Child("type"), fmt.Sprintf("must be one of %s, %s or %s", v1.JSONPatchType, v1.MergePatchType, v1.StrategicMergePatchType))
+		}
+	}
+
+	return causes
+}
+
+func getAdmissionReviewKubeVirt(ar *v1beta1.AdmissionReview) (new *v1.KubeVirt, old *v1.KubeVirt, err error) {
+	if !webhooks.ValidateRequestResource(ar.Request.Resource, webhooks.KubeVirtGroupVersionResource.Group, webhooks.KubeVirtGroupVersionResource.Resource) {
+		return nil, nil, fmt.Errorf("expect resource to be '%s'", webhooks.KubeVirtGroupVersionResource.Resource)
+	}
+
+	newKV := v1.KubeVirt{}
+	err = json.Unmarshal(ar.Request.Object.Raw, &newKV)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if ar.Request.Operation == v1beta1.Update {
+		oldKV := v1.KubeVirt{}
+		err = json.Unmarshal(ar.Request.OldObject.Raw, &oldKV)
+		if err != nil {
+			return nil, nil, err
+		}
+		return &newKV, &oldKV, nil
+	}
+
+	return &newKV, nil, nil
+}
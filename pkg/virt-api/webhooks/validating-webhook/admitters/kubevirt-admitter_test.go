@@ -0,0 +1,307 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2020 Red Hat, Inc.
+ *
+ */
+
+package admitters
+
+import (
+	"encoding/json"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	"k8s.io/api/admission/v1beta1"
+	k8sv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/client-go/kubecli"
+	"kubevirt.io/kubevirt/pkg/virt-api/webhooks"
+)
+
+var _ = Describe("Validating KubeVirt Admitter", func() {
+	kubeVirtAdmitter := &KubeVirtAdmitter{}
+
+	admit := func(kv *v1.KubeVirt, oldKV *v1.KubeVirt) *v1beta1.AdmissionResponse {
+		kvBytes, _ := json.Marshal(kv)
+		ar := &v1beta1.AdmissionReview{
+			Request: &v1beta1.AdmissionRequest{
+				Operation: v1beta1.Create,
+				Resource:  webhooks.KubeVirtGroupVersionResource,
+				Object: runtime.RawExtension{
+					Raw: kvBytes,
+				},
+			},
+		}
+		if oldKV != nil {
+			oldKVBytes, _ := json.Marshal(oldKV)
+			ar.Request.Operation = v1beta1.Update
+			ar.Request.OldObject = runtime.RawExtension{Raw: oldKVBytes}
+		}
+		return kubeVirtAdmitter.Admit(ar)
+	}
+
+	newKubeVirt := func(configuration v1.KubeVirtConfiguration) *v1.KubeVirt {
+		return &v1.KubeVirt{
+			Spec: v1.KubeVirtSpec{
+				Configuration: configuration,
+			},
+		}
+	}
+
+	uint32Ptr := func(i uint32) *uint32 { return &i }
+	int64Ptr := func(i int64) *int64 { return &i }
+	quantityPtr := func(q string) *resource.Quantity {
+		quantity := resource.MustParse(q)
+		return &quantity
+	}
+
+	It("should reject requests for other resources", func() {
+		ar := &v1beta1.AdmissionReview{
+			Request: &v1beta1.AdmissionRequest{
+				Resource: webhooks.VirtualMachineExportGroupVersionResource,
+			},
+		}
+		resp := kubeVirtAdmitter.Admit(ar)
+		Expect(resp.Allowed).To(BeFalse())
+	})
+
+	table.DescribeTable("should accept a valid configuration", func(configuration v1.KubeVirtConfiguration) {
+		resp := admit(newKubeVirt(configuration), nil)
+		Expect(resp.Allowed).To(BeTrue())
+	},
+		table.Entry("when it is empty", v1.KubeVirtConfiguration{}),
+		table.Entry("with feature gates", v1.KubeVirtConfiguration{
+			DeveloperConfiguration: &v1.DeveloperConfiguration{
+				FeatureGates: []string{"LiveMigration", "GPU"},
+			},
+		}),
+		table.Entry("with migration settings", v1.KubeVirtConfiguration{
+			MigrationConfiguration: &v1.MigrationConfiguration{
+				ParallelMigrationsPerCluster: uint32Ptr(10),
+				BandwidthPerMigration:        quantityPtr("128Mi"),
+				ProgressTimeout:              int64Ptr(300),
+			},
+		}),
+		table.Entry("with network settings", v1.KubeVirtConfiguration{
+			NetworkConfiguration: &v1.NetworkConfiguration{
+				NetworkInterface: string(v1.MasqueradeInterface),
+			},
+		}),
+		table.Entry("with an image pull policy", v1.KubeVirtConfiguration{
+			ImagePullPolicy: k8sv1.PullAlways,
+		}),
+	)
+
+	table.DescribeTable("should reject an invalid configuration", func(configuration v1.KubeVirtConfiguration, field string) {
+		resp := admit(newKubeVirt(configuration), nil)
+		Expect(resp.Allowed).To(BeFalse())
+		Expect(resp.Result.Details.Causes).To(HaveLen(1))
+		Expect(resp.Result.Details.Causes[0].Field).To(Equal(field))
+	},
+		table.Entry("with a comma separated feature gate", v1.KubeVirtConfiguration{
+			DeveloperConfiguration: &v1.DeveloperConfiguration{
+				FeatureGates: []string{"LiveMigration,GPU"},
+			},
+		}, "spec.configuration.developerConfiguration.featureGates[0]"),
+		table.Entry("with an empty feature gate", v1.KubeVirtConfiguration{
+			DeveloperConfiguration: &v1.DeveloperConfiguration{
+				FeatureGates: []string{"LiveMigration", ""},
+			},
+		}, "spec.configuration.developerConfiguration.featureGates[1]"),
+		table.Entry("with an out of range PVC space toleration", v1.KubeVirtConfiguration{
+			DeveloperConfiguration: &v1.DeveloperConfiguration{
+				LessPVCSpaceToleration: 101,
+			},
+		}, "spec.configuration.developerConfiguration.pvcTolerateLessSpaceUpToPercent"),
+		table.Entry("with no parallel migrations", v1.KubeVirtConfiguration{
+			MigrationConfiguration: &v1.MigrationConfiguration{
+				ParallelMigrationsPerCluster: uint32Ptr(0),
+			},
+		}, "spec.configuration.migrations.parallelMigrationsPerCluster"),
+		table.Entry("with a negative migration bandwidth", v1.KubeVirtConfiguration{
+			MigrationConfiguration: &v1.MigrationConfiguration{
+				BandwidthPerMigration: quantityPtr("-1Mi"),
+			},
+		}, "spec.configuration.migrations.bandwidthPerMigration"),
+		table.Entry("with an unknown network interface", v1.KubeVirtConfiguration{
+			NetworkConfiguration: &v1.NetworkConfiguration{
+				NetworkInterface: "macvtap",
+			},
+		}, "spec.configuration.network.defaultNetworkInterface"),
+		table.Entry("with an unknown image pull policy", v1.KubeVirtConfiguration{
+			ImagePullPolicy: "Sometimes",
+		}, "spec.configuration.imagePullPolicy"),
+		table.Entry("with a zero CPU request", v1.KubeVirtConfiguration{
+			CPURequest: quantityPtr("0"),
+		}, "spec.configuration.cpuRequest"),
+	)
+
+	It("should not validate an unchanged configuration on update", func() {
+		kv := newKubeVirt(v1.KubeVirtConfiguration{ImagePullPolicy: "Sometimes"})
+		updatedKV := kv.DeepCopy()
+		updatedKV.Status.Phase = v1.KubeVirtPhaseDeployed
+		resp := admit(updatedKV, kv)
+		Expect(resp.Allowed).To(BeTrue())
+	})
+
+	It("should validate a changed configuration on update", func() {
+		kv := newKubeVirt(v1.KubeVirtConfiguration{})
+		updatedKV := newKubeVirt(v1.KubeVirtConfiguration{ImagePullPolicy: "Sometimes"})
+		resp := admit(updatedKV, kv)
+		Expect(resp.Allowed).To(BeFalse())
+	})
+
+	table.DescribeTable("should validate the infra replicas", func(replicas uint8, allowed bool) {
+		kv := newKubeVirt(v1.KubeVirtConfiguration{})
+		kv.Spec.Infra = &v1.ComponentConfig{Replicas: &replicas}
+		resp := admit(kv, nil)
+		Expect(resp.Allowed).To(Equal(allowed))
+		if !allowed {
+			Expect(resp.Result.Details.Causes[0].Field).To(Equal("spec.infra.replicas"))
+		}
+	},
+		table.Entry("and accept a single replica", uint8(1), true),
+		table.Entry("and reject zero replicas", uint8(0), false),
+	)
+
+	table.DescribeTable("should validate the component patches", func(patch v1.CustomizeComponentsPatch, field string) {
+		kv := newKubeVirt(v1.KubeVirtConfiguration{})
+		kv.Spec.CustomizeComponents.Patches = []v1.CustomizeComponentsPatch{patch}
+		resp := admit(kv, nil)
+		if field == "" {
+			Expect(resp.Allowed).To(BeTrue())
+			return
+		}
+		Expect(resp.Allowed).To(BeFalse())
+		Expect(resp.Result.Details.Causes).To(HaveLen(1))
+		Expect(resp.Result.Details.Causes[0].Field).To(Equal(field))
+	},
+		table.Entry("and accept a json patch", v1.CustomizeComponentsPatch{
+			ResourceType: "Deployment", ResourceName: "virt-api", Type: v1.JSONPatchType,
+			Patch: `[{"op": "replace", "path": "/spec/replicas", "value": 3}]`,
+		}, ""),
+		table.Entry("and accept a strategic merge patch for all resources", v1.CustomizeComponentsPatch{
+			ResourceType: "Deployment", ResourceName: "*", Type: v1.StrategicMergePatchType,
+			Patch: `{"metadata": {"labels": {"test": "true"}}}`,
+		}, ""),
+		table.Entry("and reject an invalid json patch", v1.CustomizeComponentsPatch{
+			ResourceType: "Deployment", ResourceName: "virt-api", Type: v1.JSONPatchType,
+			Patch: `{"op": "replace"}`,
+		}, "spec.customizeComponents.patches[0].patch"),
+		table.Entry("and reject an invalid merge patch", v1.CustomizeComponentsPatch{
+			ResourceType: "Service", ResourceName: "virt-api", Type: v1.MergePatchType,
+			Patch: `{"metadata":`,
+		}, "spec.customizeComponents.patches[0].patch"),
+		table.Entry("and reject an unknown patch type", v1.CustomizeComponentsPatch{
+			ResourceType: "Service", ResourceName: "virt-api", Type: "replace",
+			Patch: `{}`,
+		}, "spec.customizeComponents.patches[0].type"),
+		table.Entry("and reject a patch without resource name", v1.CustomizeComponentsPatch{
+			ResourceType: "Service", Type: v1.MergePatchType,
+			Patch: `{}`,
+		}, "spec.customizeComponents.patches[0].resourceName"),
+	)
+
+	Context("with running VMIs", func() {
+		var ctrl *gomock.Controller
+		var vmiInterface *kubecli.MockVirtualMachineInstanceInterface
+		var kubeClient *fake.Clientset
+
+		newNode := func(name string, labels map[string]string, taints ...k8sv1.Taint) *k8sv1.Node {
+			return &k8sv1.Node{
+				ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels},
+				Spec:       k8sv1.NodeSpec{Taints: taints},
+			}
+		}
+
+		newRunningVMI := func(name string, nodeName string) v1.VirtualMachineInstance {
+			vmi := v1.NewMinimalVMI(name)
+			vmi.Status.Phase = v1.Running
+			vmi.Status.NodeName = nodeName
+			return *vmi
+		}
+
+		BeforeEach(func() {
+			ctrl = gomock.NewController(GinkgoT())
+			virtClient := kubecli.NewMockKubevirtClient(ctrl)
+			vmiInterface = kubecli.NewMockVirtualMachineInstanceInterface(ctrl)
+			kubeClient = fake.NewSimpleClientset(
+				newNode("node01", map[string]string{"kubevirt": "true", "zone": "a"}),
+				newNode("node02", map[string]string{"zone": "b"}),
+				newNode("node03", map[string]string{"kubevirt": "true", "zone": "b"}, k8sv1.Taint{Key: "dedicated", Effect: k8sv1.TaintEffectNoExecute}),
+			)
+			virtClient.EXPECT().VirtualMachineInstance(k8sv1.NamespaceAll).Return(vmiInterface).AnyTimes()
+			virtClient.EXPECT().CoreV1().Return(kubeClient.CoreV1()).AnyTimes()
+			vmiInterface.EXPECT().List(gomock.Any()).Return(&v1.VirtualMachineInstanceList{
+				Items: []v1.VirtualMachineInstance{
+					newRunningVMI("vmi01", "node01"),
+					newRunningVMI("vmi03", "node03"),
+				},
+			}, nil).AnyTimes()
+			kubeVirtAdmitter.Client = virtClient
+		})
+
+		AfterEach(func() {
+			kubeVirtAdmitter.Client = nil
+			ctrl.Finish()
+		})
+
+		table.DescribeTable("should validate the workloads placement", func(placement *v1.NodePlacement, allowed bool) {
+			kv := newKubeVirt(v1.KubeVirtConfiguration{})
+			kv.Spec.Workloads = &v1.ComponentConfig{NodePlacement: placement}
+			resp := admit(kv, newKubeVirt(v1.KubeVirtConfiguration{}))
+			Expect(resp.Allowed).To(Equal(allowed))
+			if !allowed {
+				Expect(resp.Result.Details.Causes[0].Field).To(Equal("spec.workloads"))
+			}
+		},
+			table.Entry("and accept a node selector and toleration matching all nodes with VMIs",
+				&v1.NodePlacement{
+					NodeSelector: map[string]string{"kubevirt": "true"},
+					Tolerations:  []k8sv1.Toleration{{Key: "dedicated", Operator: k8sv1.TolerationOpExists}},
+				}, true),
+			table.Entry("and reject a node selector excluding nodes with VMIs",
+				&v1.NodePlacement{
+					NodeSelector: map[string]string{"zone": "a"},
+					Tolerations:  []k8sv1.Toleration{{Key: "dedicated", Operator: k8sv1.TolerationOpExists}},
+				}, false),
+			table.Entry("and reject a missing toleration for a NoExecute taint on nodes with VMIs",
+				&v1.NodePlacement{
+					NodeSelector: map[string]string{"kubevirt": "true"},
+				}, false),
+			table.Entry("and reject a node affinity excluding nodes with VMIs",
+				&v1.NodePlacement{
+					Affinity: &k8sv1.Affinity{
+						NodeAffinity: &k8sv1.NodeAffinity{
+							RequiredDuringSchedulingIgnoredDuringExecution: &k8sv1.NodeSelector{
+								NodeSelectorTerms: []k8sv1.NodeSelectorTerm{{
+									MatchExpressions: []k8sv1.NodeSelectorRequirement{{Key: "zone", Operator: k8sv1.NodeSelectorOpNotIn, Values: []string{"b"}}},
+								}},
+							},
+						},
+					},
+					Tolerations: []k8sv1.Toleration{{Key: "dedicated", Operator: k8sv1.TolerationOpExists}},
+				}, false),
+		)
+	})
+})
@@ -29,12 +29,16 @@ import (
 	k8sfield "k8s.io/apimachinery/pkg/util/validation/field"
 
 	v1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/client-go/kubecli"
+	"kubevirt.io/kubevirt/pkg/util/migrations"
+	pvcutils "kubevirt.io/kubevirt/pkg/util/types"
 	"kubevirt.io/kubevirt/pkg/virt-api/webhooks"
 	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
 )
 
 type MigrationCreateAdmitter struct {
 	ClusterConfig *virtconfig.ClusterConfig
+	Client        kubecli.KubevirtClient
 }
 
 func (admitter *MigrationCreateAdmitter) Admit(ar *v1beta1.AdmissionReview) *v1beta1.AdmissionResponse {
@@ -74,10 +78,25 @@ func (admitter *MigrationCreateAdmitter) Admit(ar *v1beta1.AdmissionReview) *v1b
 		return webhooks.ToAdmissionResponseError(fmt.Errorf("Cannot migrated VMI in finalized state."))
 	}
 
+	causes = validateMigratedVolumes(k8sfield.NewPath("spec", "migratedVolumes"), migration.Spec.MigratedVolumes, vmi)
+	if len(causes) > 0 {
+		return webhooks.ToAdmissionResponse(causes)
+	}
+
 	// Reject migration jobs for non-migratable VMIs
 	for _, c := range vmi.Status.Conditions {
 		if c.Type == v1.VirtualMachineInstanceIsMigratable &&
 			c.Status == k8sv1.ConditionFalse {
+			// Disks which can't be shared are fine when all of them are migrated to other PVCs
+			if c.Reason == v1.VirtualMachineInstanceReasonDisksNotMigratable && len(migration.Spec.MigratedVolumes) > 0 {
+				migrated, err := admitter.nonSharedVolumesMigrated(vmi, migration.Spec.MigratedVolumes)
+				if err != nil {
+					return webhooks.ToAdmissionResponseError(err)
+				}
+				if migrated {
+					continue
+				}
+			}
 			errMsg := fmt.Errorf("Cannot migrate VMI, Reason: %s, Message: %s",
 				c.Reason, c.Message)
 			return webhooks.ToAdmissionResponseError(errMsg)
@@ -137,5 +156,105 @@ func ValidateVirtualMachineInstanceMigrationSpec(field *k8sfield.Path, spec *v1.
 		})
 	}
 
+	volumeNames := map[string]bool{}
+	for i, migratedVolume := range spec.MigratedVolumes {
+		volumeField := field.Child("migratedVolumes").Index(i)
+		if migratedVolume.VolumeName == "" {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueRequired,
+				Message: fmt.Sprintf("%s is missing", volumeField.Child("volumeName").String()),
+				Field:   volumeField.Child("volumeName").String(),
+			})
+		} else if volumeNames[migratedVolume.VolumeName] {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueDuplicate,
+				Message: fmt.Sprintf("volume %s is migrated more than once", migratedVolume.VolumeName),
+				Field:   volumeField.Child("volumeName").String(),
+			})
+		}
+		volumeNames[migratedVolume.VolumeName] = true
+
+		if migratedVolume.DestinationClaimName == "" {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueRequired,
+				Message: fmt.Sprintf("%s is missing", volumeField.Child("destinationClaimName").String()),
+				Field:   volumeField.Child("destinationClaimName").String(),
+			})
+		}
+	}
+
+	return causes
+}
+
+// validateMigratedVolumes ensures that the migrated volumes are PVC or DataVolume volumes of the VMI
+// and that their destination PVCs are not used by the VMI yet
+func validateMigratedVolumes(field *k8sfield.Path, migratedVolumes []v1.StorageMigratedVolume, vmi *v1.VirtualMachineInstance) []metav1.StatusCause {
+	var causes []metav1.StatusCause
+
+	claims := map[string]string{}
+	for i := range vmi.Spec.Volumes {
+		if claimName, ok := migrations.GetVolumeClaimName(&vmi.Spec.Volumes[i]); ok {
+			claims[vmi.Spec.Volumes[i].Name] = claimName
+		}
+	}
+	usedClaims := map[string]bool{}
+	for _, claimName := range claims {
+		usedClaims[claimName] = true
+	}
+
+	destinations := map[string]bool{}
+	for i, migratedVolume := range migratedVolumes {
+		volumeField := field.Index(i)
+		if _, ok := claims[migratedVolume.VolumeName]; !ok {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("VMI %s has no PersistentVolumeClaim or DataVolume volume %s", vmi.Name, migratedVolume.VolumeName),
+				Field:   volumeField.Child("volumeName").String(),
+			})
+		}
+		if usedClaims[migratedVolume.DestinationClaimName] {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("PersistentVolumeClaim %s is already used by VMI %s", migratedVolume.DestinationClaimName, vmi.Name),
+				Field:   volumeField.Child("destinationClaimName").String(),
+			})
+		} else if destinations[migratedVolume.DestinationClaimName] {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueDuplicate,
+				Message: fmt.Sprintf("PersistentVolumeClaim %s is the destination of more than one volume", migratedVolume.DestinationClaimName),
+				Field:   volumeField.Child("destinationClaimName").String(),
+			})
+		}
+		destinations[migratedVolume.DestinationClaimName] = true
+	}
+
 	return causes
 }
+
+// nonSharedVolumesMigrated checks whether every volume of the VMI which can't be shared
+// between the source and the target of the migration is migrated to another PVC
+func (admitter *MigrationCreateAdmitter) nonSharedVolumesMigrated(vmi *v1.VirtualMachineInstance, migratedVolumes []v1.StorageMigratedVolume) (bool, error) {
+	migrated := map[string]bool{}
+	for _, migratedVolume := range migratedVolumes {
+		migrated[migratedVolume.VolumeName] = true
+	}
+
+	for i := range vmi.Spec.Volumes {
+		volume := &vmi.Spec.Volumes[i]
+		if volume.HostDisk != nil && (volume.HostDisk.Shared == nil || !*volume.HostDisk.Shared) {
+			return false, nil
+		}
+		claimName, ok := migrations.GetVolumeClaimName(volume)
+		if !ok || migrated[volume.Name] {
+			continue
+		}
+		_, shared, err := pvcutils.IsSharedPVCFromClient(admitter.Client, vmi.Namespace, claimName)
+		if err != nil {
+			return false, err
+		}
+		if !shared {
+			return false, nil
+		}
+	}
+	return true, nil
+}
@@ -22,6 +22,7 @@ package admitters
 import (
 	"encoding/json"
 
+	"github.com/golang/mock/gomock"
 	. "github.com/onsi/ginkgo"
 	"github.com/onsi/ginkgo/extensions/table"
 	. "github.com/onsi/gomega"
@@ -29,8 +30,10 @@ import (
 	k8sv1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
 
 	v1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/client-go/kubecli"
 	"kubevirt.io/kubevirt/pkg/testutils"
 	"kubevirt.io/kubevirt/pkg/virt-api/webhooks"
 	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
@@ -292,6 +295,174 @@ var _ = Describe("Validating MigrationCreate Admitter", func() {
 		Expect(resp.Result.Message).To(ContainSubstring("DisksNotLiveMigratable"))
 	})
 
+	Context("with migrated volumes", func() {
+		var ctrl *gomock.Controller
+		var kubeClient *fake.Clientset
+
+		newClaim := func(name string, accessMode k8sv1.PersistentVolumeAccessMode) *k8sv1.PersistentVolumeClaim {
+			return &k8sv1.PersistentVolumeClaim{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      name,
+					Namespace: "default",
+				},
+				Spec: k8sv1.PersistentVolumeClaimSpec{
+					AccessModes: []k8sv1.PersistentVolumeAccessMode{accessMode},
+				},
+			}
+		}
+
+		BeforeEach(func() {
+			ctrl = gomock.NewController(GinkgoT())
+			virtClient := kubecli.NewMockKubevirtClient(ctrl)
+			kubeClient = fake.NewSimpleClientset(
+				newClaim("rootclaim", k8sv1.ReadWriteOnce),
+				newClaim("datadv", k8sv1.ReadWriteOnce),
+			)
+			virtClient.EXPECT().CoreV1().Return(kubeClient.CoreV1()).AnyTimes()
+			migrationCreateAdmitter.Client = virtClient
+		})
+
+		AfterEach(func() {
+			ctrl.Finish()
+			migrationCreateAdmitter.Client = nil
+		})
+
+		newVMIWithClaims := func(name string) *v1.VirtualMachineInstance {
+			vmi := v1.NewMinimalVMI(name)
+			vmi.Status.Phase = v1.Running
+			vmi.Status.Conditions = []v1.VirtualMachineInstanceCondition{
+				{
+					Type:    v1.VirtualMachineInstanceIsMigratable,
+					Status:  k8sv1.ConditionFalse,
+					Reason:  v1.VirtualMachineInstanceReasonDisksNotMigratable,
+					Message: "cannot migrate VMI with non-shared PVCs",
+				},
+			}
+			vmi.Spec.Volumes = []v1.Volume{
+				{
+					Name: "rootdisk",
+					VolumeSource: v1.VolumeSource{
+						PersistentVolumeClaim: &k8sv1.PersistentVolumeClaimVolumeSource{ClaimName: "rootclaim"},
+					},
+				},
+				{
+					Name: "datadisk",
+					VolumeSource: v1.VolumeSource{
+						DataVolume: &v1.DataVolumeSource{Name: "datadv"},
+					},
+				},
+				{
+					Name: "cloudinit",
+					VolumeSource: v1.VolumeSource{
+						CloudInitNoCloud: &v1.CloudInitNoCloudSource{UserData: "#cloud-config"},
+					},
+				},
+			}
+			return vmi
+		}
+
+		admitMigration := func(vmiName string, migratedVolumes ...v1.StorageMigratedVolume) *v1beta1.AdmissionResponse {
+			migration := v1.VirtualMachineInstanceMigration{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "default",
+				},
+				Spec: v1.VirtualMachineInstanceMigrationSpec{
+					VMIName:         vmiName,
+					MigratedVolumes: migratedVolumes,
+				},
+			}
+			migrationBytes, _ := json.Marshal(&migration)
+
+			enableFeatureGate("LiveMigration")
+
+			ar := &v1beta1.AdmissionReview{
+				Request: &v1beta1.AdmissionRequest{
+					Resource: webhooks.MigrationGroupVersionResource,
+					Object: runtime.RawExtension{
+						Raw: migrationBytes,
+					},
+				},
+			}
+			return migrationCreateAdmitter.Admit(ar)
+		}
+
+		It("should accept a VMI with non-shared PVCs if they are migrated", func() {
+			informers := webhooks.GetInformers()
+			informers.VMIInformer.GetIndexer().Add(newVMIWithClaims("testmigratevolumes1"))
+
+			resp := admitMigration("testmigratevolumes1",
+				v1.StorageMigratedVolume{VolumeName: "rootdisk", DestinationClaimName: "newrootclaim"},
+				v1.StorageMigratedVolume{VolumeName: "datadisk", DestinationClaimName: "newdataclaim"},
+			)
+			Expect(resp.Allowed).To(BeTrue())
+		})
+
+		It("should reject a VMI with non-shared PVCs if no volume is migrated", func() {
+			informers := webhooks.GetInformers()
+			informers.VMIInformer.GetIndexer().Add(newVMIWithClaims("testmigratevolumes2"))
+
+			resp := admitMigration("testmigratevolumes2")
+			Expect(resp.Allowed).To(BeFalse())
+			Expect(resp.Result.Message).To(ContainSubstring(v1.VirtualMachineInstanceReasonDisksNotMigratable))
+		})
+
+		It("should reject a VMI with non-shared PVCs if only some of them are migrated", func() {
+			informers := webhooks.GetInformers()
+			informers.VMIInformer.GetIndexer().Add(newVMIWithClaims("testmigratevolumes4"))
+
+			resp := admitMigration("testmigratevolumes4",
+				v1.StorageMigratedVolume{VolumeName: "rootdisk", DestinationClaimName: "newrootclaim"},
+			)
+			Expect(resp.Allowed).To(BeFalse())
+			Expect(resp.Result.Message).To(ContainSubstring(v1.VirtualMachineInstanceReasonDisksNotMigratable))
+		})
+
+		It("should accept a VMI if the volumes which are not migrated are shared", func() {
+			informers := webhooks.GetInformers()
+			informers.VMIInformer.GetIndexer().Add(newVMIWithClaims("testmigratevolumes5"))
+			kubeClient.CoreV1().PersistentVolumeClaims("default").Update(newClaim("datadv", k8sv1.ReadWriteMany))
+
+			resp := admitMigration("testmigratevolumes5",
+				v1.StorageMigratedVolume{VolumeName: "rootdisk", DestinationClaimName: "newrootclaim"},
+			)
+			Expect(resp.Allowed).To(BeTrue())
+		})
+
+		table.DescribeTable("should reject invalid migrated volumes", func(field string, migratedVolumes ...v1.StorageMigratedVolume) {
+			informers := webhooks.GetInformers()
+			informers.VMIInformer.GetIndexer().Add(newVMIWithClaims("testmigratevolumes3"))
+
+			resp := admitMigration("testmigratevolumes3", migratedVolumes...)
+			Expect(resp.Allowed).To(BeFalse())
+			Expect(resp.Result.Details.Causes).To(HaveLen(1))
+			Expect(resp.Result.Details.Causes[0].Field).To(Equal(field))
+		},
+			table.Entry("without a volume name", "spec.migratedVolumes[0].volumeName",
+				v1.StorageMigratedVolume{DestinationClaimName: "newclaim"},
+			),
+			table.Entry("without a destination claim", "spec.migratedVolumes[0].destinationClaimName",
+				v1.StorageMigratedVolume{VolumeName: "rootdisk"},
+			),
+			table.Entry("with a volume migrated twice", "spec.migratedVolumes[1].volumeName",
+				v1.StorageMigratedVolume{VolumeName: "rootdisk", DestinationClaimName: "newclaim1"},
+				v1.StorageMigratedVolume{VolumeName: "rootdisk", DestinationClaimName: "newclaim2"},
+			),
+			table.Entry("with an unknown volume", "spec.migratedVolumes[0].volumeName",
+				v1.StorageMigratedVolume{VolumeName: "unknown", DestinationClaimName: "newclaim"},
+			),
+			table.Entry("with a volume which is no PVC", "spec.migratedVolumes[0].volumeName",
+				v1.StorageMigratedVolume{VolumeName: "cloudinit", DestinationClaimName: "newclaim"},
+			),
+			table.Entry("with a destination claim used by the VMI", "spec.migratedVolumes[0].destinationClaimName",
+				v1.StorageMigratedVolume{VolumeName: "rootdisk", DestinationClaimName: "datadv"},
+			),
+			table.Entry("with a destination claim used twice", "spec.migratedVolumes[1].destinationClaimName",
+				v1.StorageMigratedVolume{VolumeName: "rootdisk", DestinationClaimName: "newclaim"},
+				v1.StorageMigratedVolume{VolumeName: "datadisk", DestinationClaimName: "newclaim"},
+			),
+		)
+	})
+
 	table.DescribeTable("should reject documents containing unknown or missing fields for", func(data string, validationResult string, gvr metav1.GroupVersionResource, review func(ar *v1beta1.AdmissionReview) *v1beta1.AdmissionResponse) {
 		input := map[string]interface{}{}
 		json.Unmarshal([]byte(data), &input)
@@ -24,6 +24,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net"
+	"path/filepath"
 	"regexp"
 	"strings"
 
@@ -36,6 +37,7 @@ import (
 
 	v1 "kubevirt.io/client-go/api/v1"
 	"kubevirt.io/kubevirt/pkg/hooks"
+	"kubevirt.io/kubevirt/pkg/usbredir"
 	"kubevirt.io/kubevirt/pkg/util"
 	"kubevirt.io/kubevirt/pkg/util/hardware"
 	"kubevirt.io/kubevirt/pkg/virt-api/webhooks"
@@ -849,6 +851,16 @@ func ValidateVirtualMachineInstanceSpec(field *k8sfield.Path, spec *v1.VirtualMa
 			})
 		}
 	}
+
+	if usbRedirect := spec.Domain.Devices.USBRedirect; usbRedirect != nil && usbRedirect.Slots != nil {
+		if *usbRedirect.Slots < 1 || *usbRedirect.Slots > usbredir.MaxSlots {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("%s must be between 1 and %d.", field.Child("domain", "devices", "usbRedirect", "slots").String(), usbredir.MaxSlots),
+				Field:   field.Child("domain", "devices", "usbRedirect", "slots").String(),
+			})
+		}
+	}
 	if spec.Domain.IOThreadsPolicy != nil {
 		isValidPolicy := func(policy v1.IOThreadsPolicy) bool {
 			for _, p := range validIOThreadsPolicies {
@@ -867,55 +879,18 @@ func ValidateVirtualMachineInstanceSpec(field *k8sfield.Path, spec *v1.VirtualMa
 		}
 	}
 
-	if spec.ReadinessProbe != nil {
-		if spec.ReadinessProbe.HTTPGet != nil && spec.ReadinessProbe.TCPSocket != nil {
-			causes = append(causes, metav1.StatusCause{
-				Type:    metav1.CauseTypeFieldValueInvalid,
-				Message: fmt.Sprintf("%s must have exactly one probe type set", field.Child("readinessProbe").String()),
-				Field:   field.Child("readinessProbe").String(),
-			})
-		} else if spec.ReadinessProbe.HTTPGet == nil && spec.ReadinessProbe.TCPSocket == nil {
-			causes = append(causes, metav1.StatusCause{
-				Type: metav1.CauseTypeFieldValueRequired,
-				Message: fmt.Sprintf("either %s or %s must be set if a %s is specified",
-					field.Child("readinessProbe", "tcpSocket").String(),
-					field.Child("readinessProbe", "httpGet").String(),
-					field.Child("readinessProbe").String(),
-				),
-				Field: field.Child("readinessProbe").String(),
-			})
-		}
-	}
-
-	if spec.LivenessProbe != nil {
-		if spec.LivenessProbe.HTTPGet != nil && spec.LivenessProbe.TCPSocket != nil {
-			causes = append(causes, metav1.StatusCause{
-				Type:    metav1.CauseTypeFieldValueInvalid,
-				Message: fmt.Sprintf("%s must have exactly one probe type set", field.Child("livenessProbe").String()),
-				Field:   field.Child("livenessProbe").String(),
-			})
-		} else if spec.LivenessProbe.HTTPGet == nil && spec.LivenessProbe.TCPSocket == nil {
-			causes = append(causes, metav1.StatusCause{
-				Type: metav1.CauseTypeFieldValueRequired,
-				Message: fmt.Sprintf("either %s or %s must be set if a %s is specified",
-					field.Child("livenessProbe", "tcpSocket").String(),
-					field.Child("livenessProbe", "httpGet").String(),
-					field.Child("livenessProbe").String(),
-				),
-				Field: field.Child("livenessProbe").String(),
-			})
-		}
-	}
+	causes = append(causes, validateProbe(field.Child("readinessProbe"), spec.ReadinessProbe)...)
+	causes = append(causes, validateProbe(field.Child("livenessProbe"), spec.LivenessProbe)...)
 
 	if !podNetworkInterfacePresent {
-		if spec.LivenessProbe != nil {
+		if probeNeedsPodNetwork(spec.LivenessProbe) {
 			causes = append(causes, metav1.StatusCause{
 				Type:    metav1.CauseTypeFieldValueInvalid,
 				Message: fmt.Sprintf("%s is only allowed if the Pod Network is attached", field.Child("livenessProbe").String()),
 				Field:   field.Child("livenessProbe").String(),
 			})
 		}
-		if spec.ReadinessProbe != nil {
+		if probeNeedsPodNetwork(spec.ReadinessProbe) {
 			causes = append(causes, metav1.StatusCause{
 				Type:    metav1.CauseTypeFieldValueInvalid,
 				Message: fmt.Sprintf("%s is only allowed if the Pod Network is attached", field.Child("readinessProbe").String()),
@@ -930,6 +905,7 @@ func ValidateVirtualMachineInstanceSpec(field *k8sfield.Path, spec *v1.VirtualMa
 		causes = append(causes, validateDNSPolicy(&spec.DNSPolicy, field.Child("dnsPolicy"))...)
 	}
 	causes = append(causes, validatePodDNSConfig(spec.DNSConfig, &spec.DNSPolicy, field.Child("dnsConfig"))...)
+	causes = append(causes, validateAccessCredentials(field.Child("accessCredentials"), spec.AccessCredentials, spec.Volumes)...)
 
 	if !config.LiveMigrationEnabled() && spec.EvictionStrategy != nil {
 		causes = append(causes, metav1.StatusCause{
@@ -956,6 +932,18 @@ func ValidateVirtualMachineInstanceSpec(field *k8sfield.Path, spec *v1.VirtualMa
 		})
 	}
 
+	// The qemu-pr-helper handling the reservations is only deployed with the feature gate
+	for idx, disk := range spec.Domain.Devices.Disks {
+		if disk.LUN != nil && disk.LUN.Reservations && !config.PersistentReservationEnabled() {
+			reservationsField := field.Child("domain", "devices", "disks").Index(idx).Child("lun", "reservations")
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("%s requires the %s feature gate", reservationsField.String(), virtconfig.PersistentReservationGate),
+				Field:   reservationsField.String(),
+			})
+		}
+	}
+
 	return causes
 }
 
@@ -1143,6 +1131,89 @@ func validateDNSPolicy(dnsPolicy *k8sv1.DNSPolicy, field *k8sfield.Path) []metav
 	return causes
 }
 
+func validateAccessCredentials(field *k8sfield.Path, accessCredentials []v1.AccessCredential, volumes []v1.Volume) []metav1.StatusCause {
+	var causes []metav1.StatusCause
+
+	hasCloudInitVolume := false
+	for _, volume := range volumes {
+		if volume.CloudInitNoCloud != nil || volume.CloudInitConfigDrive != nil {
+			hasCloudInitVolume = true
+			break
+		}
+	}
+
+	for idx, credential := range accessCredentials {
+		credentialField := field.Index(idx)
+
+		var source *v1.AccessCredentialSource
+		propagationMethods := 0
+		switch {
+		case credential.SSHPublicKey != nil && credential.UserPassword != nil:
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("%s must have exactly one access credential type set", credentialField.String()),
+				Field:   credentialField.String(),
+			})
+			continue
+		case credential.SSHPublicKey != nil:
+			credentialField = credentialField.Child("sshPublicKey")
+			source = &credential.SSHPublicKey.Source
+			propagationMethod := credential.SSHPublicKey.PropagationMethod
+			if propagationMethod.CloudInit != nil {
+				propagationMethods++
+				if !hasCloudInitVolume {
+					causes = append(causes, metav1.StatusCause{
+						Type:    metav1.CauseTypeFieldValueInvalid,
+						Message: fmt.Sprintf("%s requires a cloudInitNoCloud or cloudInitConfigDrive volume", credentialField.Child("propagationMethod", "cloudInit").String()),
+						Field:   credentialField.Child("propagationMethod", "cloudInit").String(),
+					})
+				}
+			}
+			if propagationMethod.QemuGuestAgent != nil {
+				propagationMethods++
+				if len(propagationMethod.QemuGuestAgent.Users) == 0 {
+					causes = append(causes, metav1.StatusCause{
+						Type:    metav1.CauseTypeFieldValueRequired,
+						Message: fmt.Sprintf("%s must list at least one user", credentialField.Child("propagationMethod", "qemuGuestAgent", "users").String()),
+						Field:   credentialField.Child("propagationMethod", "qemuGuestAgent", "users").String(),
+					})
+				}
+			}
+		case credential.UserPassword != nil:
+			credentialField = credentialField.Child("userPassword")
+			source = &credential.UserPassword.Source
+			if credential.UserPassword.PropagationMethod.QemuGuestAgent != nil {
+				propagationMethods++
+			}
+		default:
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueRequired,
+				Message: fmt.Sprintf("%s must have exactly one access credential type set", credentialField.String()),
+				Field:   credentialField.String(),
+			})
+			continue
+		}
+
+		if propagationMethods != 1 {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("%s must have exactly one propagation method set", credentialField.Child("propagationMethod").String()),
+				Field:   credentialField.Child("propagationMethod").String(),
+			})
+		}
+
+		if source.Secret == nil || source.Secret.SecretName == "" {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueRequired,
+				Message: fmt.Sprintf("%s must reference a Secret", credentialField.Child("source").String()),
+				Field:   credentialField.Child("source", "secret", "secretName").String(),
+			})
+		}
+	}
+
+	return causes
+}
+
 func validateBootloader(field *k8sfield.Path, bootloader *v1.Bootloader) []metav1.StatusCause {
 	var causes []metav1.StatusCause
 
@@ -1162,6 +1233,71 @@ func validateFirmware(field *k8sfield.Path, firmware *v1.Firmware) []metav1.Stat
 
 	if firmware != nil {
 		causes = append(causes, validateBootloader(field.Child("bootloader"), firmware.Bootloader)...)
+		causes = append(causes, validateKernelBoot(field.Child("kernelBoot"), firmware.KernelBoot)...)
+	}
+
+	return causes
+}
+
+func validateKernelBoot(field *k8sfield.Path, kernelBoot *v1.KernelBoot) []metav1.StatusCause {
+	var causes []metav1.StatusCause
+
+	if kernelBoot == nil {
+		return causes
+	}
+
+	container := kernelBoot.Container
+	if container == nil {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueRequired,
+			Message: fmt.Sprintf("%s must be set to boot a kernel directly.", field.Child("container").String()),
+			Field:   field.Child("container").String(),
+		})
+		return causes
+	}
+
+	containerField := field.Child("container")
+	if container.Image == "" {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueRequired,
+			Message: fmt.Sprintf("%s is a required field.", containerField.Child("image").String()),
+			Field:   containerField.Child("image").String(),
+		})
+	}
+
+	if container.KernelPath == "" && container.InitrdPath == "" {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueRequired,
+			Message: fmt.Sprintf("%s must define at least one of kernelPath or initrdPath.", containerField.String()),
+			Field:   containerField.String(),
+		})
+	}
+
+	for _, path := range []struct {
+		name  string
+		value string
+	}{{"kernelPath", container.KernelPath}, {"initrdPath", container.InitrdPath}} {
+		if path.value != "" && !filepath.IsAbs(path.value) {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("%s must be an absolute path.", containerField.Child(path.name).String()),
+				Field:   containerField.Child(path.name).String(),
+			})
+		} else if path.value != "" && (filepath.Clean(path.value) != path.value || strings.Contains(path.value, "..")) {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("%s must be a clean path without '..' elements.", containerField.Child(path.name).String()),
+				Field:   containerField.Child(path.name).String(),
+			})
+		}
+	}
+
+	if kernelBoot.KernelArgs != "" && container.KernelPath == "" {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("%s can only be set together with %s.", field.Child("kernelArgs").String(), containerField.Child("kernelPath").String()),
+			Field:   field.Child("kernelArgs").String(),
+		})
 	}
 
 	return causes
@@ -1390,6 +1526,43 @@ func validateVolumes(field *k8sfield.Path, volumes []v1.Volume, config *virtconf
 					Field:   field.Index(idx).Child("hostDisk", "capacity").String(),
 				})
 			}
+
+			switch hostDisk.Format {
+			case "", v1.HostDiskFormatRaw, v1.HostDiskFormatQcow2:
+			default:
+				causes = append(causes, metav1.StatusCause{
+					Type:    metav1.CauseTypeFieldValueInvalid,
+					Message: fmt.Sprintf("%s has invalid value '%s', allowed are '%s' or '%s'", field.Index(idx).Child("hostDisk", "format").String(), hostDisk.Format, v1.HostDiskFormatRaw, v1.HostDiskFormatQcow2),
+					Field:   field.Index(idx).Child("hostDisk", "format").String(),
+				})
+			}
+
+			switch hostDisk.Preallocation {
+			case "", v1.HostDiskPreallocationOff, v1.HostDiskPreallocationFalloc, v1.HostDiskPreallocationFull:
+			case v1.HostDiskPreallocationMetadata:
+				if hostDisk.Format != v1.HostDiskFormatQcow2 {
+					causes = append(causes, metav1.StatusCause{
+						Type:    metav1.CauseTypeFieldValueInvalid,
+						Message: fmt.Sprintf("%s '%s' is allowed only with %s equal to '%s'", field.Index(idx).Child("hostDisk", "preallocation").String(), hostDisk.Preallocation, field.Index(idx).Child("hostDisk", "format").String(), v1.HostDiskFormatQcow2),
+						Field:   field.Index(idx).Child("hostDisk", "preallocation").String(),
+					})
+				}
+			default:
+				causes = append(causes, metav1.StatusCause{
+					Type:    metav1.CauseTypeFieldValueInvalid,
+					Message: fmt.Sprintf("%s has invalid value '%s', allowed are '%s', '%s', '%s' or '%s'", field.Index(idx).Child("hostDisk", "preallocation").String(), hostDisk.Preallocation, v1.HostDiskPreallocationOff, v1.HostDiskPreallocationMetadata, v1.HostDiskPreallocationFalloc, v1.HostDiskPreallocationFull),
+					Field:   field.Index(idx).Child("hostDisk", "preallocation").String(),
+				})
+			}
+
+			// preallocation only applies to disk images created or grown by KubeVirt
+			if hostDisk.Type == v1.HostDiskExists && hostDisk.Preallocation != "" {
+				causes = append(causes, metav1.StatusCause{
+					Type:    metav1.CauseTypeFieldValueInvalid,
+					Message: fmt.Sprintf("%s is allowed to pass only with %s equal to '%s'", field.Index(idx).Child("hostDisk", "preallocation").String(), field.Index(idx).Child("hostDisk", "type").String(), v1.HostDiskExistsOrCreate),
+					Field:   field.Index(idx).Child("hostDisk", "preallocation").String(),
+				})
+			}
 		}
 
 		if volume.ConfigMap != nil {
@@ -1606,6 +1779,35 @@ func validateDisks(field *k8sfield.Path, disks []v1.Disk) []metav1.StatusCause {
 			})
 		}
 
+		// Verify if error policy is valid
+		switch disk.ErrorPolicy {
+		case "", v1.DiskErrorPolicyStop, v1.DiskErrorPolicyReport, v1.DiskErrorPolicyIgnore, v1.DiskErrorPolicyEnospace:
+		default:
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("%s has invalid value %s", field.Index(idx).Child("errorPolicy").String(), disk.ErrorPolicy),
+				Field:   field.Index(idx).Child("errorPolicy").String(),
+			})
+		}
+
+		// Verify shareable disks are not cached on the host
+		if disk.Shareable != nil && *disk.Shareable && disk.Cache != "" && disk.Cache != v1.CacheNone {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("%s requires %s to be %s", field.Index(idx).Child("shareable").String(), field.Index(idx).Child("cache").String(), v1.CacheNone),
+				Field:   field.Index(idx).Child("cache").String(),
+			})
+		}
+
+		// Verify persistent reservations are only requested for SCSI LUNs
+		if disk.LUN != nil && disk.LUN.Reservations && bus != "scsi" {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("%s requires the scsi bus", field.Index(idx).Child("lun", "reservations").String()),
+				Field:   field.Index(idx).Child("lun", "reservations").String(),
+			})
+		}
+
 		// Verify disk and volume name can be a valid container name since disk
 		// name can become a container name which will fail to schedule if invalid
 		errs := validation.IsDNS1123Label(disk.Name)
@@ -1621,3 +1823,56 @@ func validateDisks(field *k8sfield.Path, disks []v1.Disk) []metav1.StatusCause {
 
 	return causes
 }
+
+func validateProbe(field *k8sfield.Path, probe *v1.Probe) (causes []metav1.StatusCause) {
+	if probe == nil {
+		return causes
+	}
+
+	numHandlers := 0
+	if probe.HTTPGet != nil {
+		numHandlers++
+	}
+	if probe.TCPSocket != nil {
+		numHandlers++
+	}
+	if probe.Exec != nil {
+		numHandlers++
+	}
+	if probe.GuestAgentPing != nil {
+		numHandlers++
+	}
+
+	if numHandlers > 1 {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("%s must have exactly one probe type set", field.String()),
+			Field:   field.String(),
+		})
+	} else if numHandlers == 0 {
+		causes = append(causes, metav1.StatusCause{
+			Type: metav1.CauseTypeFieldValueRequired,
+			Message: fmt.Sprintf("either %s, %s, %s or %s must be set if a %s is specified",
+				field.Child("tcpSocket").String(),
+				field.Child("httpGet").String(),
+				field.Child("exec").String(),
+				field.Child("guestAgentPing").String(),
+				field.String(),
+			),
+			Field: field.String(),
+		})
+	} else if probe.Exec != nil && len(probe.Exec.Command) == 0 {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueRequired,
+			Message: fmt.Sprintf("%s must not be empty", field.Child("exec", "command").String()),
+			Field:   field.Child("exec", "command").String(),
+		})
+	}
+	return causes
+}
+
+// probeNeedsPodNetwork returns true for probes which the kubelet runs against
+// the pod IP. Guest agent based probes are executed through virt-launcher.
+func probeNeedsPodNetwork(probe *v1.Probe) bool {
+	return probe != nil && probe.Exec == nil && probe.GuestAgentPing == nil
+}
@@ -171,7 +171,7 @@ var _ = Describe("Validating VMICreate Admitter", func() {
 			}
 			resp := vmiCreateAdmitter.Admit(ar)
 			Expect(resp.Allowed).To(BeFalse())
-			Expect(resp.Result.Message).To(Equal(`either spec.readinessProbe.tcpSocket or spec.readinessProbe.httpGet must be set if a spec.readinessProbe is specified, either spec.livenessProbe.tcpSocket or spec.livenessProbe.httpGet must be set if a spec.livenessProbe is specified`))
+			Expect(resp.Result.Message).To(Equal(`either spec.readinessProbe.tcpSocket, spec.readinessProbe.httpGet, spec.readinessProbe.exec or spec.readinessProbe.guestAgentPing must be set if a spec.readinessProbe is specified, either spec.livenessProbe.tcpSocket, spec.livenessProbe.httpGet, spec.livenessProbe.exec or spec.livenessProbe.guestAgentPing must be set if a spec.livenessProbe is specified`))
 		})
 		It("should reject probes with more than one action per probe configured", func() {
 			vmi := v1.NewMinimalVMI("testvmi")
@@ -265,6 +265,56 @@ var _ = Describe("Validating VMICreate Admitter", func() {
 			Expect(resp.Allowed).To(BeFalse())
 			Expect(resp.Result.Message).To(Equal(`spec.livenessProbe is only allowed if the Pod Network is attached, spec.readinessProbe is only allowed if the Pod Network is attached`))
 		})
+		It("should accept guest agent probes if no Pod Network is present", func() {
+			vmi := v1.NewMinimalVMI("testvmi")
+			vmi.Spec.ReadinessProbe = &v1.Probe{
+				InitialDelaySeconds: 2,
+				Handler: v1.Handler{
+					GuestAgentPing: &v1.GuestAgentPing{},
+				},
+			}
+			vmi.Spec.LivenessProbe = &v1.Probe{
+				InitialDelaySeconds: 2,
+				Handler: v1.Handler{
+					Exec: &k8sv1.ExecAction{Command: []string{"cat", "/tmp/healthy"}},
+				},
+			}
+
+			vmiBytes, _ := json.Marshal(&vmi)
+
+			ar := &v1beta1.AdmissionReview{
+				Request: &v1beta1.AdmissionRequest{
+					Resource: webhooks.VirtualMachineInstanceGroupVersionResource,
+					Object: runtime.RawExtension{
+						Raw: vmiBytes,
+					},
+				},
+			}
+			resp := vmiCreateAdmitter.Admit(ar)
+			Expect(resp.Allowed).To(BeTrue())
+		})
+		It("should reject exec probes without a command", func() {
+			vmi := v1.NewMinimalVMI("testvmi")
+			vmi.Spec.LivenessProbe = &v1.Probe{
+				Handler: v1.Handler{
+					Exec: &k8sv1.ExecAction{},
+				},
+			}
+
+			vmiBytes, _ := json.Marshal(&vmi)
+
+			ar := &v1beta1.AdmissionReview{
+				Request: &v1beta1.AdmissionRequest{
+					Resource: webhooks.VirtualMachineInstanceGroupVersionResource,
+					Object: runtime.RawExtension{
+						Raw: vmiBytes,
+					},
+				},
+			}
+			resp := vmiCreateAdmitter.Admit(ar)
+			Expect(resp.Allowed).To(BeFalse())
+			Expect(resp.Result.Message).To(Equal(`spec.livenessProbe.exec.command must not be empty`))
+		})
 	})
 
 	It("should accept valid vmi spec on create", func() {
@@ -1809,6 +1859,47 @@ var _ = Describe("Validating VMICreate Admitter", func() {
 			Expect(causes[0].Field).To(Equal("fake.GPUs"))
 		})
 
+		table.DescribeTable("should validate persistent reservations against the feature gate", func(enabled bool, expectedErrors int) {
+			if enabled {
+				enableFeatureGate(virtconfig.PersistentReservationGate)
+			}
+			vmi := v1.NewMinimalVMI("testvm")
+			vmi.Spec.Domain.Devices.Disks = append(vmi.Spec.Domain.Devices.Disks, v1.Disk{
+				Name: "testdisk",
+				DiskDevice: v1.DiskDevice{
+					LUN: &v1.LunTarget{Bus: "scsi", Reservations: true}}})
+			vmi.Spec.Volumes = append(vmi.Spec.Volumes, v1.Volume{
+				Name: "testdisk",
+				VolumeSource: v1.VolumeSource{
+					PersistentVolumeClaim: &k8sv1.PersistentVolumeClaimVolumeSource{ClaimName: "testclaim"}}})
+
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			Expect(causes).To(HaveLen(expectedErrors))
+			for _, cause := range causes {
+				Expect(cause.Field).To(Equal("fake.domain.devices.disks[0].lun.reservations"))
+			}
+		},
+			table.Entry("and reject reservations if it is disabled", false, 1),
+			table.Entry("and accept reservations if it is enabled", true, 0),
+		)
+
+		table.DescribeTable("should verify the number of usb redirection slots", func(slots *int32, expectedErrors int) {
+			vmi := v1.NewMinimalVMI("testvmi")
+			vmi.Spec.Domain.Devices.USBRedirect = &v1.USBRedirect{Slots: slots}
+
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			Expect(causes).To(HaveLen(expectedErrors))
+			for _, cause := range causes {
+				Expect(cause.Field).To(Equal("fake.domain.devices.usbRedirect.slots"))
+			}
+		},
+			table.Entry("and accept the default", nil, 0),
+			table.Entry("and accept one slot", int32Ptr(1), 0),
+			table.Entry("and accept the maximum", int32Ptr(8), 0),
+			table.Entry("and reject zero slots", int32Ptr(0), 1),
+			table.Entry("and reject more than the maximum", int32Ptr(9), 1),
+		)
+
 		table.DescribeTable("Should accept valid DNSPolicy and DNSConfig",
 			func(dnsPolicy k8sv1.DNSPolicy, dnsConfig *k8sv1.PodDNSConfig) {
 				vmi := v1.NewMinimalVMI("testvmi")
@@ -2222,6 +2313,74 @@ var _ = Describe("Validating VMICreate Admitter", func() {
 			Expect(causes[0].Message).To(Equal("fake[0].cache has invalid value unspported"))
 		})
 
+		table.DescribeTable("should validate the cache mode of shareable disks", func(disk v1.Disk, shareable bool, expectedField string) {
+			vmi := v1.NewMinimalVMI("testvmi")
+			if shareable {
+				disk.Shareable = &shareable
+			}
+			vmi.Spec.Domain.Devices.Disks = append(vmi.Spec.Domain.Devices.Disks, disk)
+
+			causes := validateDisks(k8sfield.NewPath("fake"), vmi.Spec.Domain.Devices.Disks)
+			if expectedField == "" {
+				Expect(causes).To(BeEmpty())
+			} else {
+				Expect(causes).To(HaveLen(1))
+				Expect(causes[0].Field).To(Equal(expectedField))
+			}
+		},
+			table.Entry("accept a non-shareable disk with cache writethrough",
+				v1.Disk{Name: "testdisk", Cache: v1.CacheWriteThrough, DiskDevice: v1.DiskDevice{Disk: &v1.DiskTarget{}}}, false, ""),
+			table.Entry("accept a shareable disk without a cache mode",
+				v1.Disk{Name: "testdisk", DiskDevice: v1.DiskDevice{Disk: &v1.DiskTarget{}}}, true, ""),
+			table.Entry("accept a shareable disk with cache none",
+				v1.Disk{Name: "testdisk", Cache: v1.CacheNone, DiskDevice: v1.DiskDevice{Disk: &v1.DiskTarget{}}}, true, ""),
+			table.Entry("reject a shareable disk with cache writethrough",
+				v1.Disk{Name: "testdisk", Cache: v1.CacheWriteThrough, DiskDevice: v1.DiskDevice{Disk: &v1.DiskTarget{}}}, true, "fake[0].cache"),
+		)
+
+		It("should reject disk with invalid error policy", func() {
+			vmi := v1.NewMinimalVMI("testvmi")
+			vmi.Spec.Domain.Devices.Disks = append(vmi.Spec.Domain.Devices.Disks, v1.Disk{
+				Name: "testdisk", ErrorPolicy: "unsupported", DiskDevice: v1.DiskDevice{
+					Disk: &v1.DiskTarget{}}})
+
+			causes := validateDisks(k8sfield.NewPath("fake"), vmi.Spec.Domain.Devices.Disks)
+			Expect(causes).To(HaveLen(1))
+			Expect(causes[0].Field).To(Equal("fake[0].errorPolicy"))
+			Expect(causes[0].Message).To(Equal("fake[0].errorPolicy has invalid value unsupported"))
+		})
+
+		It("should accept disk with supported error policies", func() {
+			vmi := v1.NewMinimalVMI("testvmi")
+			for _, policy := range []v1.DiskErrorPolicy{v1.DiskErrorPolicyStop, v1.DiskErrorPolicyReport, v1.DiskErrorPolicyIgnore, v1.DiskErrorPolicyEnospace} {
+				vmi.Spec.Domain.Devices.Disks = append(vmi.Spec.Domain.Devices.Disks, v1.Disk{
+					Name: "testdisk-" + string(policy), ErrorPolicy: policy, DiskDevice: v1.DiskDevice{
+						Disk: &v1.DiskTarget{}}})
+			}
+
+			causes := validateDisks(k8sfield.NewPath("fake"), vmi.Spec.Domain.Devices.Disks)
+			Expect(causes).To(BeEmpty())
+		})
+
+		table.DescribeTable("should validate persistent reservations", func(bus string, expectedField string) {
+			vmi := v1.NewMinimalVMI("testvmi")
+			vmi.Spec.Domain.Devices.Disks = append(vmi.Spec.Domain.Devices.Disks, v1.Disk{
+				Name: "testlun", DiskDevice: v1.DiskDevice{
+					LUN: &v1.LunTarget{Bus: bus, Reservations: true}}})
+
+			causes := validateDisks(k8sfield.NewPath("fake"), vmi.Spec.Domain.Devices.Disks)
+			if expectedField == "" {
+				Expect(causes).To(BeEmpty())
+			} else {
+				Expect(causes).To(HaveLen(1))
+				Expect(causes[0].Field).To(Equal(expectedField))
+			}
+		},
+			table.Entry("accept a scsi LUN", "scsi", ""),
+			table.Entry("reject a virtio LUN", "virtio", "fake[0].lun.reservations"),
+			table.Entry("reject a sata LUN", "sata", "fake[0].lun.reservations"),
+		)
+
 		It("should reject disk count > arrayLenMax", func() {
 			vmi := v1.NewMinimalVMI("testvmi")
 			for i := 0; i <= arrayLenMax; i++ {
@@ -2351,6 +2510,48 @@ var _ = Describe("Validating VMICreate Admitter", func() {
 			Expect(len(causes)).To(Equal(1))
 		})
 
+		table.DescribeTable("should validate kernel boot", func(kernelBoot *v1.KernelBoot, expectedFields ...string) {
+			vmi := v1.NewMinimalVMI("testvmi")
+			vmi.Spec.Domain.Firmware = &v1.Firmware{
+				KernelBoot: kernelBoot,
+			}
+
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			Expect(causes).To(HaveLen(len(expectedFields)))
+			for i, field := range expectedFields {
+				Expect(causes[i].Field).To(Equal(field))
+			}
+		},
+			table.Entry("and accept a kernel with an initrd and kernel args", &v1.KernelBoot{
+				KernelArgs: "console=ttyS0",
+				Container:  &v1.KernelBootContainer{Image: "kernel-image", KernelPath: "/boot/vmlinuz", InitrdPath: "/boot/initramfs.img"},
+			}),
+			table.Entry("and accept only an initrd", &v1.KernelBoot{
+				Container: &v1.KernelBootContainer{Image: "kernel-image", InitrdPath: "/boot/initramfs.img"},
+			}),
+			table.Entry("and reject a missing container", &v1.KernelBoot{KernelArgs: "console=ttyS0"},
+				"fake.domain.firmware.kernelBoot.container"),
+			table.Entry("and reject a missing image", &v1.KernelBoot{
+				Container: &v1.KernelBootContainer{KernelPath: "/boot/vmlinuz"},
+			}, "fake.domain.firmware.kernelBoot.container.image"),
+			table.Entry("and reject a container without kernel and initrd", &v1.KernelBoot{
+				Container: &v1.KernelBootContainer{Image: "kernel-image"},
+			}, "fake.domain.firmware.kernelBoot.container"),
+			table.Entry("and reject relative paths", &v1.KernelBoot{
+				Container: &v1.KernelBootContainer{Image: "kernel-image", KernelPath: "boot/vmlinuz", InitrdPath: "initramfs.img"},
+			}, "fake.domain.firmware.kernelBoot.container.kernelPath", "fake.domain.firmware.kernelBoot.container.initrdPath"),
+			table.Entry("and reject paths with '..' elements", &v1.KernelBoot{
+				Container: &v1.KernelBootContainer{Image: "kernel-image", KernelPath: "/boot/../etc/shadow", InitrdPath: "/boot/..initramfs.img"},
+			}, "fake.domain.firmware.kernelBoot.container.kernelPath", "fake.domain.firmware.kernelBoot.container.initrdPath"),
+			table.Entry("and reject unclean paths", &v1.KernelBoot{
+				Container: &v1.KernelBootContainer{Image: "kernel-image", KernelPath: "/boot//vmlinuz", InitrdPath: "/boot/initramfs.img/"},
+			}, "fake.domain.firmware.kernelBoot.container.kernelPath", "fake.domain.firmware.kernelBoot.container.initrdPath"),
+			table.Entry("and reject kernel args without a kernel", &v1.KernelBoot{
+				KernelArgs: "console=ttyS0",
+				Container:  &v1.KernelBootContainer{Image: "kernel-image", InitrdPath: "/boot/initramfs.img"},
+			}, "fake.domain.firmware.kernelBoot.kernelArgs"),
+		)
+
 		It("should reject disk without a valid DNS-1123 name", func() {
 			vmi := v1.NewMinimalVMI("testvmi")
 
@@ -2365,6 +2566,143 @@ var _ = Describe("Validating VMICreate Admitter", func() {
 			Expect(len(causes)).To(Equal(1))
 		})
 	})
+
+	Context("with access credentials", func() {
+		var vmi *v1.VirtualMachineInstance
+		source := v1.AccessCredentialSource{
+			Secret: &v1.AccessCredentialSecretSource{
+				SecretName: "my-keys",
+			},
+		}
+
+		BeforeEach(func() {
+			vmi = v1.NewMinimalVMI("testvmi")
+		})
+
+		addCloudInitVolume := func() {
+			vmi.Spec.Volumes = append(vmi.Spec.Volumes, v1.Volume{
+				Name: "cloudinit",
+				VolumeSource: v1.VolumeSource{
+					CloudInitNoCloud: &v1.CloudInitNoCloudSource{UserData: "#cloud-config"},
+				},
+			})
+			vmi.Spec.Domain.Devices.Disks = append(vmi.Spec.Domain.Devices.Disks, v1.Disk{Name: "cloudinit"})
+		}
+
+		It("should accept ssh public keys propagated by cloud-init", func() {
+			addCloudInitVolume()
+			vmi.Spec.AccessCredentials = []v1.AccessCredential{
+				{
+					SSHPublicKey: &v1.SSHPublicKeyAccessCredential{
+						Source: source,
+						PropagationMethod: v1.SSHPublicKeyAccessCredentialPropagationMethod{
+							CloudInit: &v1.CloudInitSSHPublicKeyAccessCredentialPropagation{},
+						},
+					},
+				},
+			}
+
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			Expect(causes).To(BeEmpty())
+		})
+
+		It("should reject ssh public keys propagated by cloud-init without a cloud-init volume", func() {
+			vmi.Spec.AccessCredentials = []v1.AccessCredential{
+				{
+					SSHPublicKey: &v1.SSHPublicKeyAccessCredential{
+						Source: source,
+						PropagationMethod: v1.SSHPublicKeyAccessCredentialPropagationMethod{
+							CloudInit: &v1.CloudInitSSHPublicKeyAccessCredentialPropagation{},
+						},
+					},
+				},
+			}
+
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			Expect(causes).To(HaveLen(1))
+			Expect(causes[0].Field).To(Equal("fake.accessCredentials[0].sshPublicKey.propagationMethod.cloudInit"))
+		})
+
+		It("should reject ssh public keys propagated by the guest agent without users", func() {
+			vmi.Spec.AccessCredentials = []v1.AccessCredential{
+				{
+					SSHPublicKey: &v1.SSHPublicKeyAccessCredential{
+						Source: source,
+						PropagationMethod: v1.SSHPublicKeyAccessCredentialPropagationMethod{
+							QemuGuestAgent: &v1.QemuGuestAgentSSHPublicKeyAccessCredentialPropagation{},
+						},
+					},
+				},
+			}
+
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			Expect(causes).To(HaveLen(1))
+			Expect(causes[0].Field).To(Equal("fake.accessCredentials[0].sshPublicKey.propagationMethod.qemuGuestAgent.users"))
+		})
+
+		It("should reject several propagation methods", func() {
+			addCloudInitVolume()
+			vmi.Spec.AccessCredentials = []v1.AccessCredential{
+				{
+					SSHPublicKey: &v1.SSHPublicKeyAccessCredential{
+						Source: source,
+						PropagationMethod: v1.SSHPublicKeyAccessCredentialPropagationMethod{
+							CloudInit:      &v1.CloudInitSSHPublicKeyAccessCredentialPropagation{},
+							QemuGuestAgent: &v1.QemuGuestAgentSSHPublicKeyAccessCredentialPropagation{Users: []string{"fedora"}},
+						},
+					},
+				},
+			}
+
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			Expect(causes).To(HaveLen(1))
+			Expect(causes[0].Field).To(Equal("fake.accessCredentials[0].sshPublicKey.propagationMethod"))
+		})
+
+		It("should accept user passwords propagated by the guest agent", func() {
+			vmi.Spec.AccessCredentials = []v1.AccessCredential{
+				{
+					UserPassword: &v1.UserPasswordAccessCredential{
+						Source: source,
+						PropagationMethod: v1.UserPasswordAccessCredentialPropagationMethod{
+							QemuGuestAgent: &v1.QemuGuestAgentUserPasswordAccessCredentialPropagation{},
+						},
+					},
+				},
+			}
+
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			Expect(causes).To(BeEmpty())
+		})
+
+		It("should reject user passwords without a propagation method and a Secret", func() {
+			vmi.Spec.AccessCredentials = []v1.AccessCredential{
+				{
+					UserPassword: &v1.UserPasswordAccessCredential{},
+				},
+			}
+
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			Expect(causes).To(HaveLen(2))
+			Expect(causes[0].Field).To(Equal("fake.accessCredentials[0].userPassword.propagationMethod"))
+			Expect(causes[1].Field).To(Equal("fake.accessCredentials[0].userPassword.source.secret.secretName"))
+		})
+
+		It("should reject access credentials without or with several types", func() {
+			vmi.Spec.AccessCredentials = []v1.AccessCredential{
+				{},
+				{
+					SSHPublicKey: &v1.SSHPublicKeyAccessCredential{},
+					UserPassword: &v1.UserPasswordAccessCredential{},
+				},
+			}
+
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			Expect(causes).To(HaveLen(2))
+			Expect(causes[0].Field).To(Equal("fake.accessCredentials[0]"))
+			Expect(causes[1].Field).To(Equal("fake.accessCredentials[1]"))
+		})
+	})
 })
 
 var _ = Describe("Function getNumberOfPodInterfaces()", func() {
@@ -2667,3 +3005,7 @@ var _ = Describe("Function getNumberOfPodInterfaces()", func() {
 	})
 
 })
+
+func int32Ptr(i int32) *int32 {
+	return &i
+}
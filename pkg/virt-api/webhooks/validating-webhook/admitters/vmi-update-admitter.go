@@ -30,6 +30,7 @@ import (
 	v1 "kubevirt.io/client-go/api/v1"
 	"kubevirt.io/client-go/log"
 	clientutil "kubevirt.io/client-go/util"
+	"kubevirt.io/kubevirt/pkg/util/migrations"
 	"kubevirt.io/kubevirt/pkg/virt-api/webhooks"
 	"kubevirt.io/kubevirt/pkg/virt-operator/creation/rbac"
 )
@@ -48,8 +49,11 @@ func (admitter *VMIUpdateAdmitter) Admit(ar *v1beta1.AdmissionReview) *v1beta1.A
 		return webhooks.ToAdmissionResponseError(err)
 	}
 
-	// Reject VMI update if VMI spec changed
-	if !reflect.DeepEqual(newVMI.Spec, oldVMI.Spec) {
+	// Reject VMI update if VMI spec changed, growing hostDisk images and switching to the PVCs
+	// of a completed storage migration are the only allowed changes
+	allowedSpec := withGrownHostDisks(oldVMI.Spec, newVMI.Spec)
+	if !reflect.DeepEqual(newVMI.Spec, allowedSpec) &&
+		!(isAllowedServiceAccount(ar) && reflect.DeepEqual(newVMI.Spec, withMigratedVolumes(oldVMI, allowedSpec))) {
 		return webhooks.ToAdmissionResponse([]metav1.StatusCause{
 			{
 				Type:    metav1.CauseTypeFieldValueNotSupported,
@@ -67,14 +71,47 @@ func (admitter *VMIUpdateAdmitter) Admit(ar *v1beta1.AdmissionReview) *v1beta1.A
 	return &reviewResponse
 }
 
+// withGrownHostDisks returns a copy of the old spec where the capacity of
+// hostDisk volumes is taken over from the new spec if it increased
+func withGrownHostDisks(oldSpec v1.VirtualMachineInstanceSpec, newSpec v1.VirtualMachineInstanceSpec) v1.VirtualMachineInstanceSpec {
+	spec := oldSpec.DeepCopy()
+	newVolumes := map[string]*v1.HostDisk{}
+	for _, volume := range newSpec.Volumes {
+		if volume.HostDisk != nil {
+			newVolumes[volume.Name] = volume.HostDisk
+		}
+	}
+	for _, volume := range spec.Volumes {
+		newHostDisk, ok := newVolumes[volume.Name]
+		if volume.HostDisk == nil || !ok {
+			continue
+		}
+		if newHostDisk.Capacity.Cmp(volume.HostDisk.Capacity) > 0 {
+			volume.HostDisk.Capacity = newHostDisk.Capacity
+		}
+	}
+	return *spec
+}
+
+// withMigratedVolumes returns a copy of the spec where the volumes point to the
+// PVCs they were copied to by the last migration, if it completed
+func withMigratedVolumes(oldVMI *v1.VirtualMachineInstance, spec v1.VirtualMachineInstanceSpec) v1.VirtualMachineInstanceSpec {
+	migrationState := oldVMI.Status.MigrationState
+	if migrationState == nil || !migrationState.Completed || migrationState.Failed {
+		return spec
+	}
+	spec = *spec.DeepCopy()
+	spec.Volumes = migrations.ApplyMigratedVolumes(spec.Volumes, migrationState.MigratedVolumes)
+	return spec
+}
+
 func admitVMILabelsUpdate(
 	newVMI *v1.VirtualMachineInstance,
 	oldVMI *v1.VirtualMachineInstance,
 	ar *v1beta1.AdmissionReview) *v1beta1.AdmissionResponse {
 
 	// Skip admission for internal components
-	allowed := getAllowedServiceAccounts()
-	if _, ok := allowed[ar.Request.UserInfo.Username]; ok {
+	if isAllowedServiceAccount(ar) {
 		return nil
 	}
 
@@ -107,6 +144,11 @@ func filterKubevirtLabels(vmi *v1.VirtualMachineInstance) map[string]string {
 	return m
 }
 
+func isAllowedServiceAccount(ar *v1beta1.AdmissionReview) bool {
+	_, ok := getAllowedServiceAccounts()[ar.Request.UserInfo.Username]
+	return ok
+}
+
 func getAllowedServiceAccounts() map[string]struct{} {
 	ns, err := clientutil.GetNamespace()
 	logger := log.DefaultLogger()
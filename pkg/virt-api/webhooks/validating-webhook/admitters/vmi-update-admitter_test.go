@@ -27,6 +27,8 @@ import (
 	. "github.com/onsi/gomega"
 	"k8s.io/api/admission/v1beta1"
 	authv1 "k8s.io/api/authentication/v1"
+	k8sv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 
@@ -97,6 +99,86 @@ var _ = Describe("Validating VMIUpdate Admitter", func() {
 		Expect(resp.Result.Details.Causes[0].Message).To(Equal("update of VMI object is restricted"))
 	})
 
+	table.DescribeTable("should handle a hostDisk capacity change on update", func(oldCapacity string, newCapacity string, allowed bool) {
+		vmi := v1.NewMinimalVMI("testvmi")
+		vmi.Spec.Volumes = append(vmi.Spec.Volumes, v1.Volume{
+			Name: "hostdisk",
+			VolumeSource: v1.VolumeSource{
+				HostDisk: &v1.HostDisk{
+					Path:     "/var/data/disk.img",
+					Type:     v1.HostDiskExistsOrCreate,
+					Capacity: resource.MustParse(oldCapacity),
+				},
+			},
+		})
+		updateVmi := vmi.DeepCopy()
+		updateVmi.Spec.Volumes[0].HostDisk.Capacity = resource.MustParse(newCapacity)
+		newVMIBytes, _ := json.Marshal(&updateVmi)
+		oldVMIBytes, _ := json.Marshal(&vmi)
+
+		ar := &v1beta1.AdmissionReview{
+			Request: &v1beta1.AdmissionRequest{
+				Resource: webhooks.VirtualMachineInstanceGroupVersionResource,
+				Object: runtime.RawExtension{
+					Raw: newVMIBytes,
+				},
+				OldObject: runtime.RawExtension{
+					Raw: oldVMIBytes,
+				},
+				Operation: v1beta1.Update,
+			},
+		}
+
+		resp := vmiUpdateAdmitter.Admit(ar)
+		Expect(resp.Allowed).To(Equal(allowed))
+	},
+		table.Entry("allow growing the disk image", "1Gi", "2Gi", true),
+		table.Entry("reject shrinking the disk image", "2Gi", "1Gi", false),
+	)
+
+	table.DescribeTable("should handle switching to migrated volumes on update", func(username string, completed bool, failed bool, allowed bool) {
+		vmi := v1.NewMinimalVMI("testvmi")
+		vmi.Spec.Volumes = append(vmi.Spec.Volumes, v1.Volume{
+			Name: "rootdisk",
+			VolumeSource: v1.VolumeSource{
+				PersistentVolumeClaim: &k8sv1.PersistentVolumeClaimVolumeSource{ClaimName: "rootclaim"},
+			},
+		})
+		vmi.Status.MigrationState = &v1.VirtualMachineInstanceMigrationState{
+			Completed: completed,
+			Failed:    failed,
+			MigratedVolumes: []v1.StorageMigratedVolume{
+				{VolumeName: "rootdisk", DestinationClaimName: "newrootclaim"},
+			},
+		}
+		updateVmi := vmi.DeepCopy()
+		updateVmi.Spec.Volumes[0].PersistentVolumeClaim.ClaimName = "newrootclaim"
+		newVMIBytes, _ := json.Marshal(&updateVmi)
+		oldVMIBytes, _ := json.Marshal(&vmi)
+
+		ar := &v1beta1.AdmissionReview{
+			Request: &v1beta1.AdmissionRequest{
+				UserInfo: authv1.UserInfo{Username: username},
+				Resource: webhooks.VirtualMachineInstanceGroupVersionResource,
+				Object: runtime.RawExtension{
+					Raw: newVMIBytes,
+				},
+				OldObject: runtime.RawExtension{
+					Raw: oldVMIBytes,
+				},
+				Operation: v1beta1.Update,
+			},
+		}
+
+		resp := vmiUpdateAdmitter.Admit(ar)
+		Expect(resp.Allowed).To(Equal(allowed))
+	},
+		table.Entry("allow the controller after a completed migration", "system:serviceaccount:kubevirt:"+rbac.ControllerServiceAccountName, true, false, true),
+		table.Entry("reject the controller during the migration", "system:serviceaccount:kubevirt:"+rbac.ControllerServiceAccountName, false, false, false),
+		table.Entry("reject the controller after a failed migration", "system:serviceaccount:kubevirt:"+rbac.ControllerServiceAccountName, true, true, false),
+		table.Entry("reject other users", "system:serviceaccount:someNamespace:someUser", true, false, false),
+	)
+
 	table.DescribeTable(
 		"Should allow VMI upon modification of non kubevirt.io/ labels by non kubevirt user or service account",
 		func(originalVmiLabels map[string]string, updateVmiLabels map[string]string) {
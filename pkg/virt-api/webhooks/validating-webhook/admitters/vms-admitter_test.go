@@ -528,6 +528,38 @@ var _ = Describe("Validating VM Admitter", func() {
 			Expect(causes[0].Field).To(Equal("fake[0].hostDisk.capacity"))
 		})
 
+		table.DescribeTable("should validate hostDisk format and preallocation", func(hostDisk *v1.HostDisk, expectedField string) {
+			vmi := v1.NewMinimalVMI("testvmi")
+			vmi.Spec.Volumes = append(vmi.Spec.Volumes, v1.Volume{
+				VolumeSource: v1.VolumeSource{
+					HostDisk: hostDisk,
+				},
+			})
+
+			causes := validateVolumes(k8sfield.NewPath("fake"), vmi.Spec.Volumes, config)
+			if expectedField == "" {
+				Expect(causes).To(BeEmpty())
+			} else {
+				Expect(len(causes)).To(Equal(1))
+				Expect(causes[0].Field).To(Equal(expectedField))
+			}
+		},
+			table.Entry("accept a qcow2 image",
+				&v1.HostDisk{Path: "fakePath", Type: v1.HostDiskExistsOrCreate, Format: v1.HostDiskFormatQcow2}, ""),
+			table.Entry("accept a fully preallocated raw image",
+				&v1.HostDisk{Path: "fakePath", Type: v1.HostDiskExistsOrCreate, Format: v1.HostDiskFormatRaw, Preallocation: v1.HostDiskPreallocationFull}, ""),
+			table.Entry("accept a qcow2 image with metadata preallocation",
+				&v1.HostDisk{Path: "fakePath", Type: v1.HostDiskExistsOrCreate, Format: v1.HostDiskFormatQcow2, Preallocation: v1.HostDiskPreallocationMetadata}, ""),
+			table.Entry("reject an unknown format",
+				&v1.HostDisk{Path: "fakePath", Type: v1.HostDiskExistsOrCreate, Format: "vmdk"}, "fake[0].hostDisk.format"),
+			table.Entry("reject an unknown preallocation",
+				&v1.HostDisk{Path: "fakePath", Type: v1.HostDiskExistsOrCreate, Preallocation: "fake"}, "fake[0].hostDisk.preallocation"),
+			table.Entry("reject metadata preallocation for a raw image",
+				&v1.HostDisk{Path: "fakePath", Type: v1.HostDiskExistsOrCreate, Preallocation: v1.HostDiskPreallocationMetadata}, "fake[0].hostDisk.preallocation"),
+			table.Entry("reject preallocation with a `Disk` type",
+				&v1.HostDisk{Path: "fakePath", Type: v1.HostDiskExists, Preallocation: v1.HostDiskPreallocationFull}, "fake[0].hostDisk.preallocation"),
+		)
+
 		It("should reject a configMap without the configMapName field", func() {
 			vmi := v1.NewMinimalVMI("testvmi")
 
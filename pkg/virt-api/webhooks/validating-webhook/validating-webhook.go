@@ -89,8 +89,24 @@ func ServeVMIPreset(resp http.ResponseWriter, req *http.Request) {
 	serve(resp, req, &admitters.VMIPresetAdmitter{})
 }
 
-func ServeMigrationCreate(resp http.ResponseWriter, req *http.Request, clusterConfig *virtconfig.ClusterConfig) {
-	serve(resp, req, &admitters.MigrationCreateAdmitter{ClusterConfig: clusterConfig})
+func ServeContainerDiskImageCache(resp http.ResponseWriter, req *http.Request) {
+	serve(resp, req, &admitters.ContainerDiskImageCacheAdmitter{})
+}
+
+func ServeVirtualMachineBackup(resp http.ResponseWriter, req *http.Request, clusterConfig *virtconfig.ClusterConfig) {
+	serve(resp, req, &admitters.VirtualMachineBackupAdmitter{ClusterConfig: clusterConfig})
+}
+
+func ServeVirtualMachineExport(resp http.ResponseWriter, req *http.Request) {
+	serve(resp, req, &admitters.VirtualMachineExportAdmitter{})
+}
+
+func ServeKubeVirt(resp http.ResponseWriter, req *http.Request, virtCli kubecli.KubevirtClient) {
+	serve(resp, req, &admitters.KubeVirtAdmitter{Client: virtCli})
+}
+
+func ServeMigrationCreate(resp http.ResponseWriter, req *http.Request, clusterConfig *virtconfig.ClusterConfig, virtCli kubecli.KubevirtClient) {
+	serve(resp, req, &admitters.MigrationCreateAdmitter{ClusterConfig: clusterConfig, Client: virtCli})
 }
 
 func ServeMigrationUpdate(resp http.ResponseWriter, req *http.Request) {
@@ -20,6 +20,10 @@
 package virtconfig
 
 import (
+	"crypto"
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"strconv"
 	"strings"
@@ -39,6 +43,7 @@ import (
 	"kubevirt.io/client-go/kubecli"
 	"kubevirt.io/client-go/log"
 	clientutil "kubevirt.io/client-go/util"
+	"kubevirt.io/kubevirt/pkg/container-disk/registry"
 	cmdv1 "kubevirt.io/kubevirt/pkg/handler-launcher-com/cmd/v1"
 )
 
@@ -60,6 +65,9 @@ const (
 	PermitBridgeInterfaceOnPodNetwork = "permitBridgeInterfaceOnPodNetwork"
 	NodeDrainTaintDefaultKey          = "kubevirt.io/drain"
 	SmbiosConfigKey                   = "smbios"
+	InsecureRegistriesKey             = "insecure-registries"
+	ContainerDiskPublicKeysKey        = "container-disk-public-keys"
+	ExportIngressHostKey              = "vmexport-ingress-host"
 )
 
 type ConfigModifiedFn func()
@@ -100,16 +108,19 @@ func getConfigMap() *k8sv1.ConfigMap {
 	return cfgMap
 }
 
-// NewClusterConfig represents the `kubevirt-config` config map. It can be used to live-update
+// NewClusterConfig represents the `kubevirt-config` config map and the
+// spec.configuration of the KubeVirt CR. It can be used to live-update
 // values if the config changes. The config update works like this:
 // 1. Check if the config exists. If it does not exist, return the default config
 // 2. Check if the config got updated. If so, try to parse and return it
 // 3. In case of errors or no updates (resource version stays the same), it returns the values from the last good config
-func NewClusterConfig(configMapInformer cache.SharedIndexInformer, crdInformer cache.SharedIndexInformer, namespace string) *ClusterConfig {
+// Settings of the KubeVirt CR take precedence over the deprecated config map.
+func NewClusterConfig(configMapInformer cache.SharedIndexInformer, crdInformer cache.SharedIndexInformer, kubeVirtInformer cache.SharedIndexInformer, namespace string) *ClusterConfig {
 
 	c := &ClusterConfig{
 		configMapInformer: configMapInformer,
 		crdInformer:       crdInformer,
+		kubeVirtInformer:  kubeVirtInformer,
 		lock:              &sync.Mutex{},
 		namespace:         namespace,
 		lastValidConfig:   defaultClusterConfig(),
@@ -122,6 +133,12 @@ func NewClusterConfig(configMapInformer cache.SharedIndexInformer, crdInformer c
 		UpdateFunc: c.configUpdated,
 	})
 
+	c.kubeVirtInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    c.configAddedDeleted,
+		DeleteFunc: c.configAddedDeleted,
+		UpdateFunc: c.configUpdated,
+	})
+
 	c.crdInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
 		AddFunc:    c.crdAddedDeleted,
 		DeleteFunc: c.crdAddedDeleted,
@@ -226,13 +243,16 @@ type Config struct {
 	CPURequest                        resource.Quantity
 	MemoryOvercommit                  int
 	EmulatedMachines                  []string
-	FeatureGates                      string
+	FeatureGates                      []string
 	LessPVCSpaceToleration            int
 	NodeSelectors                     map[string]string
 	NetworkInterface                  string
 	PermitSlirpInterface              bool
 	PermitBridgeInterfaceOnPodNetwork bool
 	SmbiosConfig                      *cmdv1.SMBios
+	InsecureRegistries                []string
+	ContainerDiskPublicKeys           []crypto.PublicKey
+	ExportIngressHost                 string
 }
 
 type MigrationConfig struct {
@@ -249,6 +269,7 @@ type MigrationConfig struct {
 type ClusterConfig struct {
 	configMapInformer                cache.SharedIndexInformer
 	crdInformer                      cache.SharedIndexInformer
+	kubeVirtInformer                 cache.SharedIndexInformer
 	namespace                        string
 	lock                             *sync.Mutex
 	lastValidConfig                  *Config
@@ -349,7 +370,12 @@ func setConfig(config *Config, configMap *k8sv1.ConfigMap) error {
 	}
 
 	if featureGates := strings.TrimSpace(configMap.Data[FeatureGatesKey]); featureGates != "" {
-		config.FeatureGates = featureGates
+		config.FeatureGates = nil
+		for _, featureGate := range strings.Split(featureGates, ",") {
+			if featureGate = strings.TrimSpace(featureGate); featureGate != "" {
+				config.FeatureGates = append(config.FeatureGates, featureGate)
+			}
+		}
 	}
 
 	if toleration := strings.TrimSpace(configMap.Data[LessPVCSpaceTolerationKey]); toleration != "" {
@@ -394,6 +420,26 @@ func setConfig(config *Config, configMap *k8sv1.ConfigMap) error {
 		return fmt.Errorf("invalid value for permitBridgeInterfaceOnPodNetwork in config: %v", permitBridge)
 	}
 
+	if insecureRegistries := strings.TrimSpace(configMap.Data[InsecureRegistriesKey]); insecureRegistries != "" {
+		vals := strings.Split(insecureRegistries, ",")
+		for i := range vals {
+			vals[i] = strings.TrimSpace(vals[i])
+		}
+		config.InsecureRegistries = vals
+	}
+
+	// containerDisks are only mounted if their pinned digest is signed by one of the keys
+	if publicKeys := strings.TrimSpace(configMap.Data[ContainerDiskPublicKeysKey]); publicKeys != "" {
+		keys, err := registry.ParsePublicKeys(publicKeys)
+		if err != nil {
+			return fmt.Errorf("invalid %s in config: %v", ContainerDiskPublicKeysKey, err)
+		}
+		config.ContainerDiskPublicKeys = keys
+	}
+
+	// VirtualMachineExports are reachable from outside of the cluster through an ingress on this host
+	config.ExportIngressHost = strings.TrimSpace(configMap.Data[ExportIngressHostKey])
+
 	// set default network interface
 	iface := strings.TrimSpace(configMap.Data[NetworkInterfaceKey])
 	switch iface {
@@ -415,27 +461,70 @@ func (c *ClusterConfig) getConfig() (config *Config) {
 	c.lock.Lock()
 	defer c.lock.Unlock()
 
+	var configMap *k8sv1.ConfigMap
 	if obj, exists, err := c.configMapInformer.GetStore().GetByKey(c.namespace + "/" + configMapName); err != nil {
 		log.DefaultLogger().Reason(err).Errorf("Error loading the cluster config from cache, falling back to last good resource version '%s'", c.lastValidConfig.ResourceVersion)
 		return c.lastValidConfig
-	} else if !exists {
+	} else if exists {
+		configMap = obj.(*k8sv1.ConfigMap)
+	}
+
+	kv, err := c.getKubeVirt()
+	if err != nil {
+		log.DefaultLogger().Reason(err).Errorf("Error loading the KubeVirt CR from cache, falling back to last good resource version '%s'", c.lastValidConfig.ResourceVersion)
+		return c.lastValidConfig
+	}
+
+	if configMap == nil && kv == nil {
 		return c.defaultConfig
-	} else {
-		configMap := obj.(*k8sv1.ConfigMap)
-		if c.lastValidConfig.ResourceVersion == configMap.ResourceVersion ||
-			c.lastInvalidConfigResourceVersion == configMap.ResourceVersion {
+	}
+
+	resourceVersion := configResourceVersion(configMap, kv)
+	if c.lastValidConfig.ResourceVersion == resourceVersion ||
+		c.lastInvalidConfigResourceVersion == resourceVersion {
+		return c.lastValidConfig
+	}
+	config = defaultClusterConfig()
+	if configMap != nil {
+		if err := setConfig(config, configMap); err != nil {
+			c.lastInvalidConfigResourceVersion = resourceVersion
+			log.DefaultLogger().Reason(err).Errorf("Invalid cluster config with resource version '%s', falling back to last good resource version '%s'", resourceVersion, c.lastValidConfig.ResourceVersion)
 			return c.lastValidConfig
 		}
-		config := defaultClusterConfig()
-		if err := setConfig(config, configMap); err != nil {
-			c.lastInvalidConfigResourceVersion = configMap.ResourceVersion
-			log.DefaultLogger().Reason(err).Errorf("Invalid cluster config with resource version '%s', falling back to last good resource version '%s'", configMap.ResourceVersion, c.lastValidConfig.ResourceVersion)
+	}
+	if kv != nil {
+		if err := setConfigFromKubeVirt(config, &kv.Spec.Configuration); err != nil {
+			c.lastInvalidConfigResourceVersion = resourceVersion
+			log.DefaultLogger().Reason(err).Errorf("Invalid cluster config with resource version '%s', falling back to last good resource version '%s'", resourceVersion, c.lastValidConfig.ResourceVersion)
 			return c.lastValidConfig
 		}
-		log.DefaultLogger().Infof("Updating cluster config to resource version '%s'", configMap.ResourceVersion)
-		c.lastValidConfig = config
-		return c.lastValidConfig
 	}
+	config.ResourceVersion = resourceVersion
+	log.DefaultLogger().Infof("Updating cluster config to resource version '%s'", resourceVersion)
+	c.lastValidConfig = config
+	return c.lastValidConfig
+}
+
+// configResourceVersion combines the versions of the config sources, so that
+// a change of either of them triggers a reload. The KubeVirt CR is updated on
+// every status change of the operator, so only its configuration is tracked.
+func configResourceVersion(configMap *k8sv1.ConfigMap, kv *v1.KubeVirt) string {
+	resourceVersion := ""
+	if configMap != nil {
+		resourceVersion = configMap.ResourceVersion
+	}
+	if kv != nil {
+		resourceVersion = fmt.Sprintf("%s-%s", resourceVersion, kubeVirtConfigurationHash(&kv.Spec.Configuration))
+	}
+	return resourceVersion
+}
+
+func kubeVirtConfigurationHash(configuration *v1.KubeVirtConfiguration) string {
+	// marshalling the API struct never fails, and map keys are sorted
+	value, _ := json.Marshal(configuration)
+	hasher := sha1.New()
+	hasher.Write(value)
+	return hex.EncodeToString(hasher.Sum(nil))
 }
 
 func (c *ClusterConfig) HasDataVolumeAPI() bool {
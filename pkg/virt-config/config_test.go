@@ -170,6 +170,40 @@ var _ = Describe("ConfigMap", func() {
 		table.Entry("when unset, GetEmulatedMachines should return the defaults", "", strings.Split(virtconfig.DefaultEmulatedMachines, ",")),
 	)
 
+	table.DescribeTable(" when insecureRegistries", func(value string, result []string) {
+		clusterConfig, _, _ := testutils.NewFakeClusterConfig(&kubev1.ConfigMap{
+			Data: map[string]string{virtconfig.InsecureRegistriesKey: value},
+		})
+		Expect(clusterConfig.GetInsecureRegistries()).To(Equal(result))
+	},
+		table.Entry("when set, GetInsecureRegistries should return the value", "registry:5000, localhost:5000", []string{"registry:5000", "localhost:5000"}),
+		table.Entry("when unset, GetInsecureRegistries should return nothing", "", nil),
+	)
+
+	table.DescribeTable(" when containerDiskPublicKeys", func(value string, keys int) {
+		clusterConfig, _, _ := testutils.NewFakeClusterConfig(&kubev1.ConfigMap{
+			Data: map[string]string{virtconfig.ContainerDiskPublicKeysKey: value},
+		})
+		Expect(clusterConfig.GetContainerDiskPublicKeys()).To(HaveLen(keys))
+	},
+		table.Entry("when set, GetContainerDiskPublicKeys should return the keys", `-----BEGIN PUBLIC KEY-----
+MFkwEwYHKoZIzj0CAQYIKoZIzj0DAQcDQgAEc3Z7wv+pluTCqey5080LxzIR1vNu
+nYVrWHFPiPjE8dyBDMzJgMYtcEacAFdR+Z8kuGsNZHrSS/r9o+KYpookww==
+-----END PUBLIC KEY-----`, 1),
+		table.Entry("when invalid, GetContainerDiskPublicKeys should return the defaults", "invalid", 0),
+		table.Entry("when unset, GetContainerDiskPublicKeys should return nothing", "", 0),
+	)
+
+	table.DescribeTable(" when vmexportIngressHost", func(value string, result string) {
+		clusterConfig, _, _ := testutils.NewFakeClusterConfig(&kubev1.ConfigMap{
+			Data: map[string]string{virtconfig.ExportIngressHostKey: value},
+		})
+		Expect(clusterConfig.GetExportIngressHost()).To(Equal(result))
+	},
+		table.Entry("when set, GetExportIngressHost should return the value", " export.example.com ", "export.example.com"),
+		table.Entry("when unset, GetExportIngressHost should return nothing", "", ""),
+	)
+
 	It("Should return migration config values if specified as json", func() {
 		clusterConfig, _, _ := testutils.NewFakeClusterConfig(&kubev1.ConfigMap{
 			Data: map[string]string{virtconfig.MigrationsConfigKey: `{"parallelOutboundMigrationsPerNode" : 10, "parallelMigrationsPerCluster": 20, "bandwidthPerMigration": "110Mi", "progressTimeout" : 5, "completionTimeoutPerGiB": 5, "unsafeMigrationOverride": true, "allowAutoConverge": true}`},
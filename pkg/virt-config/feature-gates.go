@@ -23,10 +23,6 @@ package virtconfig
  This module is intended for determining whether an optional feature is enabled or not at the cluster-level.
 */
 
-import (
-	"strings"
-)
-
 const (
 	cpuManager            = "CPUManager"
 	IgnitionGate          = "ExperimentalIgnitionSupport"
@@ -35,10 +31,21 @@ const (
 	HypervStrictCheckGate = "HypervStrictCheck"
 	SidecarGate           = "Sidecar"
 	GPUGate               = "GPU"
+	// ContainerDiskDigestsGate pins containerDisk images to the digest they resolve to at VMI creation
+	ContainerDiskDigestsGate = "ContainerDiskDigests"
+	// VMBackupGate allows VirtualMachineBackups, whose jobs are driven through QMP behind the back of libvirt
+	VMBackupGate = "VMBackup"
+	// PersistentReservationGate deploys the privileged qemu-pr-helper and allows LUNs to request SCSI persistent reservations
+	PersistentReservationGate = "PersistentReservation"
 )
 
 func (c *ClusterConfig) isFeatureGateEnabled(featureGate string) bool {
-	return strings.Contains(c.getConfig().FeatureGates, featureGate)
+	for _, fg := range c.getConfig().FeatureGates {
+		if fg == featureGate {
+			return true
+		}
+	}
+	return false
 }
 
 func (config *ClusterConfig) CPUManagerEnabled() bool {
@@ -68,3 +75,15 @@ func (config *ClusterConfig) SidecarEnabled() bool {
 func (config *ClusterConfig) GPUPassthroughEnabled() bool {
 	return config.isFeatureGateEnabled(GPUGate)
 }
+
+func (config *ClusterConfig) ContainerDiskDigestsEnabled() bool {
+	return config.isFeatureGateEnabled(ContainerDiskDigestsGate)
+}
+
+func (config *ClusterConfig) VMBackupEnabled() bool {
+	return config.isFeatureGateEnabled(VMBackupGate)
+}
+
+func (config *ClusterConfig) PersistentReservationEnabled() bool {
+	return config.isFeatureGateEnabled(PersistentReservationGate)
+}
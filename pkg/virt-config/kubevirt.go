@@ -0,0 +1,254 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2020 Red Hat, Inc.
+ *
+ */
+
+package virtconfig
+
+/*
+ This module reads the cluster configuration from the spec.configuration of the KubeVirt CR.
+*/
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	k8sv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sfield "k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/client-go/tools/cache"
+
+	v1 "kubevirt.io/client-go/api/v1"
+)
+
+// getKubeVirt returns the KubeVirt CR of the install namespace. If more
+// than one exists, the oldest one is the active deployment.
+func (c *ClusterConfig) getKubeVirt() (*v1.KubeVirt, error) {
+	objs, err := c.kubeVirtInformer.GetIndexer().ByIndex(cache.NamespaceIndex, c.namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	var active *v1.KubeVirt
+	for _, obj := range objs {
+		kv, ok := obj.(*v1.KubeVirt)
+		if !ok {
+			continue
+		}
+		if active == nil || kv.CreationTimestamp.Before(&active.CreationTimestamp) {
+			active = kv
+		}
+	}
+	return active, nil
+}
+
+// setConfigFromKubeVirt overrides the provided config with all settings of
+// the KubeVirt CR which are set. Default values in the provided config stay in tact.
+func setConfigFromKubeVirt(config *Config, configuration *v1.KubeVirtConfiguration) error {
+	if err := ConfigurationError(configuration); err != nil {
+		return err
+	}
+
+	if configuration.CPUModel != "" {
+		config.CPUModel = configuration.CPUModel
+	}
+	if configuration.CPURequest != nil {
+		config.CPURequest = *configuration.CPURequest
+	}
+	if configuration.MachineType != "" {
+		config.MachineType = configuration.MachineType
+	}
+	if len(configuration.EmulatedMachines) > 0 {
+		config.EmulatedMachines = configuration.EmulatedMachines
+	}
+	if configuration.ImagePullPolicy != "" {
+		config.ImagePullPolicy = configuration.ImagePullPolicy
+	}
+
+	if migrations := configuration.MigrationConfiguration; migrations != nil {
+		if migrations.ParallelOutboundMigrationsPerNode != nil {
+			config.MigrationConfig.ParallelOutboundMigrationsPerNode = migrations.ParallelOutboundMigrationsPerNode
+		}
+		if migrations.ParallelMigrationsPerCluster != nil {
+			config.MigrationConfig.ParallelMigrationsPerCluster = migrations.ParallelMigrationsPerCluster
+		}
+		if migrations.BandwidthPerMigration != nil {
+			config.MigrationConfig.BandwidthPerMigration = migrations.BandwidthPerMigration
+		}
+		if migrations.NodeDrainTaintKey != nil {
+			config.MigrationConfig.NodeDrainTaintKey = migrations.NodeDrainTaintKey
+		}
+		if migrations.ProgressTimeout != nil {
+			config.MigrationConfig.ProgressTimeout = migrations.ProgressTimeout
+		}
+		if migrations.CompletionTimeoutPerGiB != nil {
+			config.MigrationConfig.CompletionTimeoutPerGiB = migrations.CompletionTimeoutPerGiB
+		}
+		if migrations.UnsafeMigrationOverride != nil {
+			config.MigrationConfig.UnsafeMigrationOverride = *migrations.UnsafeMigrationOverride
+		}
+		if migrations.AllowAutoConverge != nil {
+			config.MigrationConfig.AllowAutoConverge = *migrations.AllowAutoConverge
+		}
+	}
+
+	if network := configuration.NetworkConfiguration; network != nil {
+		if network.NetworkInterface != "" {
+			config.NetworkInterface = network.NetworkInterface
+		}
+		if network.PermitSlirpInterface != nil {
+			config.PermitSlirpInterface = *network.PermitSlirpInterface
+		}
+		if network.PermitBridgeInterfaceOnPodNetwork != nil {
+			config.PermitBridgeInterfaceOnPodNetwork = *network.PermitBridgeInterfaceOnPodNetwork
+		}
+	}
+
+	if smbios := configuration.SMBIOSConfig; smbios != nil {
+		if smbios.Manufacturer != "" {
+			config.SmbiosConfig.Manufacturer = smbios.Manufacturer
+		}
+		if smbios.Product != "" {
+			config.SmbiosConfig.Product = smbios.Product
+		}
+		if smbios.Version != "" {
+			config.SmbiosConfig.Version = smbios.Version
+		}
+		if smbios.Sku != "" {
+			config.SmbiosConfig.Sku = smbios.Sku
+		}
+		if smbios.Family != "" {
+			config.SmbiosConfig.Family = smbios.Family
+		}
+	}
+
+	if developer := configuration.DeveloperConfiguration; developer != nil {
+		if developer.FeatureGates != nil {
+			config.FeatureGates = developer.FeatureGates
+		}
+		if developer.UseEmulation {
+			config.UseEmulation = true
+		}
+		if developer.MemoryOvercommit != 0 {
+			config.MemoryOvercommit = developer.MemoryOvercommit
+		}
+		if developer.LessPVCSpaceToleration != 0 {
+			config.LessPVCSpaceToleration = developer.LessPVCSpaceToleration
+		}
+		if developer.NodeSelectors != nil {
+			config.NodeSelectors = developer.NodeSelectors
+		}
+	}
+
+	return nil
+}
+
+// ConfigurationError summarizes the validation errors of the spec.configuration
+// of a KubeVirt CR, it returns nil if the configuration is valid
+func ConfigurationError(configuration *v1.KubeVirtConfiguration) error {
+	causes := ValidateConfiguration(k8sfield.NewPath("spec", "configuration"), configuration)
+	if len(causes) == 0 {
+		return nil
+	}
+	messages := []string{}
+	for _, cause := range causes {
+		messages = append(messages, cause.Message)
+	}
+	return fmt.Errorf("invalid KubeVirt configuration: %s", strings.Join(messages, ", "))
+}
+
+// ValidateConfiguration validates the spec.configuration of a KubeVirt CR
+func ValidateConfiguration(field *k8sfield.Path, configuration *v1.KubeVirtConfiguration) []metav1.StatusCause {
+	var causes []metav1.StatusCause
+
+	invalid := func(field *k8sfield.Path, format string, args ...interface{}) {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("%s "+format, append([]interface{}{field.String()}, args...)...),
+			Field:   field.String(),
+		})
+	}
+
+	if configuration.CPURequest != nil && configuration.CPURequest.Sign() <= 0 {
+		invalid(field.Child("cpuRequest"), "must be greater than zero")
+	}
+
+	for i, machine := range configuration.EmulatedMachines {
+		if strings.TrimSpace(machine) == "" {
+			invalid(field.Child("emulatedMachines").Index(i), "must not be empty")
+		}
+	}
+
+	switch configuration.ImagePullPolicy {
+	case "", k8sv1.PullAlways, k8sv1.PullNever, k8sv1.PullIfNotPresent:
+	default:
+		invalid(field.Child("imagePullPolicy"), "must be one of %s, %s or %s", k8sv1.PullAlways, k8sv1.PullNever, k8sv1.PullIfNotPresent)
+	}
+
+	if migrations := configuration.MigrationConfiguration; migrations != nil {
+		migrationsField := field.Child("migrations")
+		if migrations.ParallelOutboundMigrationsPerNode != nil && *migrations.ParallelOutboundMigrationsPerNode == 0 {
+			invalid(migrationsField.Child("parallelOutboundMigrationsPerNode"), "must be greater than zero")
+		}
+		if migrations.ParallelMigrationsPerCluster != nil && *migrations.ParallelMigrationsPerCluster == 0 {
+			invalid(migrationsField.Child("parallelMigrationsPerCluster"), "must be greater than zero")
+		}
+		if migrations.BandwidthPerMigration != nil && migrations.BandwidthPerMigration.Sign() < 0 {
+			invalid(migrationsField.Child("bandwidthPerMigration"), "must not be negative")
+		}
+		if migrations.NodeDrainTaintKey != nil && *migrations.NodeDrainTaintKey == "" {
+			invalid(migrationsField.Child("nodeDrainTaintKey"), "must not be empty")
+		}
+		if migrations.ProgressTimeout != nil && *migrations.ProgressTimeout <= 0 {
+			invalid(migrationsField.Child("progressTimeout"), "must be greater than zero")
+		}
+		if migrations.CompletionTimeoutPerGiB != nil && *migrations.CompletionTimeoutPerGiB <= 0 {
+			invalid(migrationsField.Child("completionTimeoutPerGiB"), "must be greater than zero")
+		}
+	}
+
+	if network := configuration.NetworkConfiguration; network != nil {
+		switch network.NetworkInterface {
+		case "", string(v1.BridgeInterface), string(v1.SlirpInterface), string(v1.MasqueradeInterface):
+		default:
+			invalid(field.Child("network", "defaultNetworkInterface"), "must be one of %s, %s or %s", v1.BridgeInterface, v1.SlirpInterface, v1.MasqueradeInterface)
+		}
+	}
+
+	if developer := configuration.DeveloperConfiguration; developer != nil {
+		developerField := field.Child("developerConfiguration")
+		for i, featureGate := range developer.FeatureGates {
+			if featureGate == "" || strings.IndexFunc(featureGate, func(r rune) bool { return r == ',' || unicode.IsSpace(r) }) >= 0 {
+				invalid(developerField.Child("featureGates").Index(i), "must name exactly one feature gate")
+			}
+		}
+		if developer.MemoryOvercommit < 0 {
+			invalid(developerField.Child("memoryOvercommit"), "must be greater than zero")
+		}
+		if developer.LessPVCSpaceToleration < 0 || developer.LessPVCSpaceToleration > 100 {
+			invalid(developerField.Child("pvcTolerateLessSpaceUpToPercent"), "must be between 0 and 100")
+		}
+		for key := range developer.NodeSelectors {
+			if key == "" {
+				invalid(developerField.Child("nodeSelectors"), "must not contain an empty key")
+			}
+		}
+	}
+
+	return causes
+}
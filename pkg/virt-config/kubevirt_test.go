@@ -0,0 +1,152 @@
+package virtconfig_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	kubev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/client-go/log"
+	testutils "kubevirt.io/kubevirt/pkg/testutils"
+	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
+)
+
+var _ = Describe("KubeVirt CR configuration", func() {
+
+	log.Log.SetIOWriter(GinkgoWriter)
+
+	newKubeVirt := func(configuration v1.KubeVirtConfiguration) *v1.KubeVirt {
+		return &v1.KubeVirt{
+			Spec: v1.KubeVirtSpec{
+				Configuration: configuration,
+			},
+		}
+	}
+
+	table.DescribeTable("feature gates", func(featureGates []string, enabled bool) {
+		clusterConfig, _, _, _ := testutils.NewFakeClusterConfigUsingKV(newKubeVirt(v1.KubeVirtConfiguration{
+			DeveloperConfiguration: &v1.DeveloperConfiguration{
+				FeatureGates: featureGates,
+			},
+		}))
+		Expect(clusterConfig.GPUPassthroughEnabled()).To(Equal(enabled))
+	},
+		table.Entry("should be enabled when listed", []string{"LiveMigration", virtconfig.GPUGate}, true),
+		table.Entry("should not be enabled when unset", nil, false),
+		table.Entry("should not be enabled by a gate with the same prefix", []string{virtconfig.GPUGate + "Passthrough"}, false),
+	)
+
+	It("should not enable feature gates of the config map by substrings", func() {
+		clusterConfig, _, _ := testutils.NewFakeClusterConfig(&kubev1.ConfigMap{
+			Data: map[string]string{virtconfig.FeatureGatesKey: "GPUPassthrough, LiveMigration"},
+		})
+		Expect(clusterConfig.GPUPassthroughEnabled()).To(BeFalse())
+		Expect(clusterConfig.LiveMigrationEnabled()).To(BeTrue())
+	})
+
+	It("should not reload the config on status changes of the KubeVirt CR", func() {
+		kv := newKubeVirt(v1.KubeVirtConfiguration{MachineType: "pc-q35-3.0"})
+		clusterConfig, _, _, kubeVirtInformer := testutils.NewFakeClusterConfigUsingKV(kv)
+		migrations := clusterConfig.GetMigrationConfig()
+
+		kv.Status.Phase = v1.KubeVirtPhaseDeployed
+		testutils.UpdateFakeKubeVirtClusterConfig(kubeVirtInformer, kv)
+		Expect(clusterConfig.GetMigrationConfig()).To(BeIdenticalTo(migrations))
+	})
+
+	It("should return the configured values", func() {
+		parallelMigrations := uint32(10)
+		bandwidth := resource.MustParse("128Mi")
+		cpuRequest := resource.MustParse("200m")
+		allowAutoConverge := true
+		permitSlirp := true
+		clusterConfig, _, _, _ := testutils.NewFakeClusterConfigUsingKV(newKubeVirt(v1.KubeVirtConfiguration{
+			CPUModel:         "Haswell",
+			CPURequest:       &cpuRequest,
+			MachineType:      "pc-q35-3.0",
+			EmulatedMachines: []string{"pc-q35*"},
+			ImagePullPolicy:  kubev1.PullAlways,
+			MigrationConfiguration: &v1.MigrationConfiguration{
+				ParallelMigrationsPerCluster: &parallelMigrations,
+				BandwidthPerMigration:        &bandwidth,
+				AllowAutoConverge:            &allowAutoConverge,
+			},
+			NetworkConfiguration: &v1.NetworkConfiguration{
+				NetworkInterface:     string(v1.SlirpInterface),
+				PermitSlirpInterface: &permitSlirp,
+			},
+			SMBIOSConfig: &v1.SMBiosConfiguration{
+				Product: "test",
+			},
+			DeveloperConfiguration: &v1.DeveloperConfiguration{
+				UseEmulation:           true,
+				MemoryOvercommit:       150,
+				LessPVCSpaceToleration: 5,
+				NodeSelectors:          map[string]string{"kubevirt.io/schedulable": "true"},
+			},
+		}))
+		Expect(clusterConfig.GetCPUModel()).To(Equal("Haswell"))
+		Expect(clusterConfig.GetCPURequest()).To(Equal(cpuRequest))
+		Expect(clusterConfig.GetMachineType()).To(Equal("pc-q35-3.0"))
+		Expect(clusterConfig.GetEmulatedMachines()).To(ConsistOf("pc-q35*"))
+		Expect(clusterConfig.GetImagePullPolicy()).To(Equal(kubev1.PullAlways))
+
+		migrations := clusterConfig.GetMigrationConfig()
+		Expect(*migrations.ParallelMigrationsPerCluster).To(BeNumerically("==", 10))
+		Expect(*migrations.ParallelOutboundMigrationsPerNode).To(BeNumerically("==", virtconfig.ParallelOutboundMigrationsPerNodeDefault))
+		Expect(migrations.BandwidthPerMigration.String()).To(Equal("128Mi"))
+		Expect(migrations.AllowAutoConverge).To(BeTrue())
+
+		Expect(clusterConfig.GetDefaultNetworkInterface()).To(Equal(string(v1.SlirpInterface)))
+		Expect(clusterConfig.IsSlirpInterfaceEnabled()).To(BeTrue())
+		Expect(clusterConfig.IsBridgeInterfaceOnPodNetworkEnabled()).To(BeTrue())
+
+		Expect(clusterConfig.GetSMBIOS().Product).To(Equal("test"))
+		Expect(clusterConfig.GetSMBIOS().Manufacturer).To(Equal(virtconfig.SmbiosConfigDefaultManufacturer))
+
+		Expect(clusterConfig.IsUseEmulation()).To(BeTrue())
+		Expect(clusterConfig.GetMemoryOvercommit()).To(Equal(150))
+		Expect(clusterConfig.GetLessPVCSpaceToleration()).To(Equal(5))
+		Expect(clusterConfig.GetNodeSelectors()).To(HaveKeyWithValue("kubevirt.io/schedulable", "true"))
+	})
+
+	It("should take precedence over the config map", func() {
+		clusterConfig, configMapInformer, _, _ := testutils.NewFakeClusterConfigUsingKV(newKubeVirt(v1.KubeVirtConfiguration{
+			MachineType: "pc-q35-3.0",
+		}))
+		testutils.UpdateFakeClusterConfig(configMapInformer, &kubev1.ConfigMap{
+			Data: map[string]string{
+				virtconfig.MachineTypeKey: "pc-q35-2.0",
+				virtconfig.CpuModelKey:    "Haswell",
+			},
+		})
+		Expect(clusterConfig.GetMachineType()).To(Equal("pc-q35-3.0"))
+		Expect(clusterConfig.GetCPUModel()).To(Equal("Haswell"))
+	})
+
+	It("should pick up changes of the KubeVirt CR", func() {
+		clusterConfig, _, _, kubeVirtInformer := testutils.NewFakeClusterConfigUsingKV(newKubeVirt(v1.KubeVirtConfiguration{}))
+		Expect(clusterConfig.LiveMigrationEnabled()).To(BeFalse())
+
+		testutils.UpdateFakeKubeVirtClusterConfig(kubeVirtInformer, newKubeVirt(v1.KubeVirtConfiguration{
+			DeveloperConfiguration: &v1.DeveloperConfiguration{
+				FeatureGates: []string{"LiveMigration"},
+			},
+		}))
+		Expect(clusterConfig.LiveMigrationEnabled()).To(BeTrue())
+	})
+
+	It("should stick with the last good config", func() {
+		clusterConfig, _, _, kubeVirtInformer := testutils.NewFakeClusterConfigUsingKV(newKubeVirt(v1.KubeVirtConfiguration{
+			ImagePullPolicy: kubev1.PullAlways,
+		}))
+		Expect(clusterConfig.GetImagePullPolicy()).To(Equal(kubev1.PullAlways))
+
+		testutils.UpdateFakeKubeVirtClusterConfig(kubeVirtInformer, newKubeVirt(v1.KubeVirtConfiguration{
+			ImagePullPolicy: "invalid",
+		}))
+		Expect(clusterConfig.GetImagePullPolicy()).To(Equal(kubev1.PullAlways))
+	})
+})
@@ -24,6 +24,8 @@ package virtconfig
 */
 
 import (
+	"crypto"
+
 	k8sv1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 
@@ -109,3 +111,15 @@ func (c *ClusterConfig) GetSMBIOS() *cmdv1.SMBios {
 func (c *ClusterConfig) IsBridgeInterfaceOnPodNetworkEnabled() bool {
 	return c.getConfig().PermitBridgeInterfaceOnPodNetwork
 }
+
+func (c *ClusterConfig) GetInsecureRegistries() []string {
+	return c.getConfig().InsecureRegistries
+}
+
+func (c *ClusterConfig) GetContainerDiskPublicKeys() []crypto.PublicKey {
+	return c.getConfig().ContainerDiskPublicKeys
+}
+
+func (c *ClusterConfig) GetExportIngressHost() string {
+	return c.getConfig().ExportIngressHost
+}
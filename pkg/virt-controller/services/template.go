@@ -29,6 +29,7 @@ import (
 	k8sv1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/client-go/tools/cache"
 
 	hostdisk "kubevirt.io/kubevirt/pkg/host-disk"
@@ -40,13 +41,17 @@ import (
 	"kubevirt.io/client-go/log"
 	"kubevirt.io/client-go/precond"
 	"kubevirt.io/kubevirt/pkg/config"
+	consolelog "kubevirt.io/kubevirt/pkg/console-log"
 	containerdisk "kubevirt.io/kubevirt/pkg/container-disk"
 	"kubevirt.io/kubevirt/pkg/hooks"
+	memorydump "kubevirt.io/kubevirt/pkg/memory-dump"
 	"kubevirt.io/kubevirt/pkg/util"
 	"kubevirt.io/kubevirt/pkg/util/hardware"
 	"kubevirt.io/kubevirt/pkg/util/net/dns"
 	"kubevirt.io/kubevirt/pkg/util/types"
 	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
+	vmbackup "kubevirt.io/kubevirt/pkg/vm-backup"
+	vmexport "kubevirt.io/kubevirt/pkg/vm-export"
 )
 
 const configMapName = "kubevirt-config"
@@ -72,6 +77,9 @@ const NFD_CPU_MODEL_PREFIX = "feature.node.kubernetes.io/cpu-model-"
 const NFD_CPU_FEATURE_PREFIX = "feature.node.kubernetes.io/cpu-feature-"
 const NFD_KVM_INFO_PREFIX = "feature.node.kubernetes.io/kvm-info-cap-hyperv-"
 
+// The weight of the preference for nodes which hold a containerDisk image in a ContainerDiskImageCache
+const cachedContainerDiskAffinityWeight = 10
+
 const MULTUS_RESOURCE_NAME_ANNOTATION = "k8s.v1.cni.cncf.io/resourceName"
 const MULTUS_DEFAULT_NETWORK_CNI_ANNOTATION = "v1.multus-cni.io/default-network"
 
@@ -82,6 +90,9 @@ const ENV_VAR_LIBVIRT_DEBUG_LOGS = "LIBVIRT_DEBUG_LOGS"
 
 type TemplateService interface {
 	RenderLaunchManifest(*v1.VirtualMachineInstance) (*k8sv1.Pod, error)
+	RenderMemoryDumpManifest(*v1.VirtualMachine, *v1.VirtualMachineInstance, *k8sv1.Pod) (*k8sv1.Pod, error)
+	RenderBackupManifest(*v1.VirtualMachineBackup, *v1.VirtualMachineInstance) (*k8sv1.Pod, error)
+	RenderExportManifest(*v1.VirtualMachineExport, []vmexport.Volume, bool) (*k8sv1.Pod, error)
 }
 
 type templateService struct {
@@ -251,6 +262,37 @@ func SetNodeAffinityForForbiddenFeaturePolicy(vmi *v1.VirtualMachineInstance, po
 	}
 }
 
+// SetNodeAffinityForCachedContainerDisks prefers the nodes which already hold the containerDisk
+// images of the VMI in a ContainerDiskImageCache, so that the pod can start without pulling them.
+func SetNodeAffinityForCachedContainerDisks(vmi *v1.VirtualMachineInstance, pod *k8sv1.Pod) {
+	var terms []k8sv1.PreferredSchedulingTerm
+	for _, image := range containerdisk.GetImages(vmi) {
+		terms = append(terms, k8sv1.PreferredSchedulingTerm{
+			Weight: cachedContainerDiskAffinityWeight,
+			Preference: k8sv1.NodeSelectorTerm{
+				MatchExpressions: []k8sv1.NodeSelectorRequirement{
+					{
+						Key:      containerdisk.CachedImageNodeLabel(image),
+						Operator: k8sv1.NodeSelectorOpExists,
+					},
+				},
+			},
+		})
+	}
+	if len(terms) == 0 {
+		return
+	}
+
+	if pod.Spec.Affinity == nil {
+		pod.Spec.Affinity = &k8sv1.Affinity{}
+	}
+	if pod.Spec.Affinity.NodeAffinity == nil {
+		pod.Spec.Affinity.NodeAffinity = &k8sv1.NodeAffinity{}
+	}
+	nodeAffinity := pod.Spec.Affinity.NodeAffinity
+	nodeAffinity.PreferredDuringSchedulingIgnoredDuringExecution = append(nodeAffinity.PreferredDuringSchedulingIgnoredDuringExecution, terms...)
+}
+
 // Request a resource by name. This function bumps the number of resources,
 // both its limits and requests attributes.
 //
@@ -506,6 +548,37 @@ func (t *templateService) RenderLaunchManifest(vmi *v1.VirtualMachineInstance) (
 		}
 	}
 
+	// attach the access credential Secrets to the pod, the mounts are
+	// updated by the kubelet when the Secrets change
+	accessCredentialSecrets := map[string]bool{}
+	for i := range vmi.Spec.AccessCredentials {
+		secretName := config.GetAccessCredentialSecretName(&vmi.Spec.AccessCredentials[i])
+		if secretName == "" || accessCredentialSecrets[secretName] {
+			continue
+		}
+		accessCredentialSecrets[secretName] = true
+		volumeName := secretName + "-access-cred"
+		volumeMounts = append(volumeMounts, k8sv1.VolumeMount{
+			Name:      volumeName,
+			MountPath: config.GetAccessCredentialSourcePath(secretName),
+			ReadOnly:  true,
+		})
+		volumes = append(volumes, k8sv1.Volume{
+			Name: volumeName,
+			VolumeSource: k8sv1.VolumeSource{
+				Secret: &k8sv1.SecretVolumeSource{
+					SecretName: secretName,
+				},
+			},
+		})
+	}
+
+	if containerdisk.HasKernelBootContainer(vmi) && vmi.Spec.Domain.Firmware.KernelBoot.Container.ImagePullSecret != "" {
+		imagePullSecrets = appendUniqueImagePullSecret(imagePullSecrets, k8sv1.LocalObjectReference{
+			Name: vmi.Spec.Domain.Firmware.KernelBoot.Container.ImagePullSecret,
+		})
+	}
+
 	if t.imagePullSecret != "" {
 		imagePullSecrets = appendUniqueImagePullSecret(imagePullSecrets, k8sv1.LocalObjectReference{
 			Name: t.imagePullSecret,
@@ -660,6 +733,25 @@ func (t *templateService) RenderLaunchManifest(vmi *v1.VirtualMachineInstance) (
 		MountPath: "/var/run/kubevirt-infra",
 	})
 
+	if consolelog.IsEnabled(vmi) {
+		volumeMounts = append(volumeMounts, k8sv1.VolumeMount{
+			Name:      consolelog.VolumeName,
+			MountPath: consolelog.LogDir,
+		})
+	}
+
+	volumeMounts = append(volumeMounts, k8sv1.VolumeMount{
+		Name:      memorydump.VolumeName,
+		MountPath: memorydump.LauncherDir,
+	})
+
+	if t.clusterConfig.VMBackupEnabled() {
+		volumeMounts = append(volumeMounts, k8sv1.VolumeMount{
+			Name:      vmbackup.VolumeName,
+			MountPath: vmbackup.LauncherDir,
+		})
+	}
+
 	defaultReadinessProbe := &k8sv1.Probe{
 		Handler: k8sv1.Handler{
 			Exec: &k8sv1.ExecAction{
@@ -718,12 +810,12 @@ func (t *templateService) RenderLaunchManifest(vmi *v1.VirtualMachineInstance) (
 	}
 
 	if vmi.Spec.ReadinessProbe != nil {
-		compute.ReadinessProbe = copyProbe(vmi.Spec.ReadinessProbe)
+		compute.ReadinessProbe = copyProbe(vmi, vmi.Spec.ReadinessProbe, t.virtShareDir)
 		compute.ReadinessProbe.InitialDelaySeconds = compute.ReadinessProbe.InitialDelaySeconds + LibvirtStartupDelay
 	}
 
 	if vmi.Spec.LivenessProbe != nil {
-		compute.LivenessProbe = copyProbe(vmi.Spec.LivenessProbe)
+		compute.LivenessProbe = copyProbe(vmi, vmi.Spec.LivenessProbe, t.virtShareDir)
 		compute.LivenessProbe.InitialDelaySeconds = compute.LivenessProbe.InitialDelaySeconds + LibvirtStartupDelay
 	}
 
@@ -780,6 +872,24 @@ func (t *templateService) RenderLaunchManifest(vmi *v1.VirtualMachineInstance) (
 			},
 		},
 	})
+	volumes = append(volumes, k8sv1.Volume{
+		Name: memorydump.VolumeName,
+		VolumeSource: k8sv1.VolumeSource{
+			EmptyDir: &k8sv1.EmptyDirVolumeSource{},
+		},
+	})
+	if t.clusterConfig.VMBackupEnabled() {
+		backupHostPathType := k8sv1.HostPathDirectoryOrCreate
+		volumes = append(volumes, k8sv1.Volume{
+			Name: vmbackup.VolumeName,
+			VolumeSource: k8sv1.VolumeSource{
+				HostPath: &k8sv1.HostPathVolumeSource{
+					Path: vmbackup.GenerateHostDir(t.virtLibDir, vmi.UID),
+					Type: &backupHostPathType,
+				},
+			},
+		})
+	}
 
 	for k, v := range vmi.Spec.NodeSelector {
 		nodeSelector[k] = v
@@ -843,6 +953,16 @@ func (t *templateService) RenderLaunchManifest(vmi *v1.VirtualMachineInstance) (
 		containers = append(containers, sidecar)
 	}
 
+	if consolelog.IsEnabled(vmi) {
+		volumes = append(volumes, k8sv1.Volume{
+			Name: consolelog.VolumeName,
+			VolumeSource: k8sv1.VolumeSource{
+				EmptyDir: &k8sv1.EmptyDirVolumeSource{},
+			},
+		})
+		containers = append(containers, consolelog.GenerateContainer(vmi, t.launcherImage, imagePullPolicy, t.virtShareDir, "virt-share-dir"))
+	}
+
 	// XXX: reduce test time. Adding one more container delays the start.
 	// First stdci has issues with that and second we don't want to increase the startup time even more.
 	// At the end the infra container needs to be always there, to allow better default readiness checks.
@@ -939,6 +1059,8 @@ func (t *templateService) RenderLaunchManifest(vmi *v1.VirtualMachineInstance) (
 		SetNodeAffinityForForbiddenFeaturePolicy(vmi, &pod)
 	}
 
+	SetNodeAffinityForCachedContainerDisks(vmi, &pod)
+
 	pod.Spec.Tolerations = vmi.Spec.Tolerations
 
 	if len(serviceAccountName) > 0 {
@@ -1157,6 +1279,400 @@ func getCniAnnotations(vmi *v1.VirtualMachineInstance) (cniAnnotations map[strin
 	return
 }
 
+// RenderMemoryDumpManifest renders the pod which copies the memory dump requested
+// in the VM status from the dump volume of the virt-launcher pod into the requested PVC.
+func (t *templateService) RenderMemoryDumpManifest(vm *v1.VirtualMachine, vmi *v1.VirtualMachineInstance, launcherPod *k8sv1.Pod) (*k8sv1.Pod, error) {
+	precond.MustNotBeNil(vm)
+	precond.MustNotBeNil(vmi)
+	precond.MustNotBeNil(launcherPod)
+	request := vm.Status.MemoryDumpRequest
+	if request == nil || request.FileName == nil {
+		return nil, fmt.Errorf("no memory dump requested for VM %s", vm.Name)
+	}
+	if vmi.Status.NodeName == "" {
+		return nil, fmt.Errorf("VMI %s is not scheduled to a node", vmi.Name)
+	}
+
+	userId := memorydump.CopyUserID
+	runAsNonRoot := true
+	var gracePeriodSeconds int64 = 5
+	hostPathType := k8sv1.HostPathDirectory
+	claimPath := filepath.Join(memorydump.ClaimDir, *request.FileName)
+
+	pod := &k8sv1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      memorydump.PodName(vm),
+			Namespace: vm.Namespace,
+			Labels: map[string]string{
+				v1.AppLabel:                    "virt-memory-dump",
+				memorydump.VirtualMachineLabel: vm.Name,
+			},
+			Annotations: map[string]string{
+				memorydump.FileNameAnnotation: *request.FileName,
+			},
+			OwnerReferences: []metav1.OwnerReference{
+				*metav1.NewControllerRef(vm, v1.VirtualMachineGroupVersionKind),
+			},
+		},
+		Spec: k8sv1.PodSpec{
+			SecurityContext: &k8sv1.PodSecurityContext{
+				RunAsUser:    &userId,
+				RunAsNonRoot: &runAsNonRoot,
+				// Makes the PVC writable for the unprivileged user
+				FSGroup: &userId,
+				SELinuxOptions: &k8sv1.SELinuxOptions{
+					Type: "virt_launcher.process",
+				},
+			},
+			// The dump is only available on the node of the VMI
+			Affinity: &k8sv1.Affinity{
+				NodeAffinity: &k8sv1.NodeAffinity{
+					RequiredDuringSchedulingIgnoredDuringExecution: &k8sv1.NodeSelector{
+						NodeSelectorTerms: []k8sv1.NodeSelectorTerm{
+							{
+								MatchFields: []k8sv1.NodeSelectorRequirement{
+									{
+										Key:      "metadata.name",
+										Operator: k8sv1.NodeSelectorOpIn,
+										Values:   []string{vmi.Status.NodeName},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			Tolerations:                   vmi.Spec.Tolerations,
+			TerminationGracePeriodSeconds: &gracePeriodSeconds,
+			RestartPolicy:                 k8sv1.RestartPolicyNever,
+			Containers: []k8sv1.Container{
+				{
+					Name:            memorydump.ContainerName,
+					Image:           t.launcherImage,
+					ImagePullPolicy: t.clusterConfig.GetImagePullPolicy(),
+					Command:         []string{"/usr/bin/virt-memory-dump"},
+					Args: []string{
+						"--source-dir", memorydump.LauncherDir,
+						"--target-dir", memorydump.ClaimDir,
+						"--file-name", *request.FileName,
+					},
+					Resources: k8sv1.ResourceRequirements{
+						Limits: k8sv1.ResourceList{
+							k8sv1.ResourceCPU:    resource.MustParse("100m"),
+							k8sv1.ResourceMemory: resource.MustParse("60M"),
+						},
+						Requests: k8sv1.ResourceList{
+							k8sv1.ResourceCPU:    resource.MustParse("10m"),
+							k8sv1.ResourceMemory: resource.MustParse("35M"),
+						},
+					},
+					// The pod turns ready once the dump was copied into the PVC
+					ReadinessProbe: &k8sv1.Probe{
+						Handler: k8sv1.Handler{
+							Exec: &k8sv1.ExecAction{
+								Command: []string{"/usr/bin/test", "-f", claimPath},
+							},
+						},
+						PeriodSeconds: 2,
+					},
+					VolumeMounts: []k8sv1.VolumeMount{
+						{
+							Name:      memorydump.VolumeName,
+							MountPath: memorydump.LauncherDir,
+						},
+						{
+							Name:      memorydump.ClaimVolumeName,
+							MountPath: memorydump.ClaimDir,
+						},
+					},
+				},
+			},
+			Volumes: []k8sv1.Volume{
+				{
+					Name: memorydump.VolumeName,
+					VolumeSource: k8sv1.VolumeSource{
+						HostPath: &k8sv1.HostPathVolumeSource{
+							Path: memorydump.LauncherVolumeHostDir(launcherPod.UID),
+							Type: &hostPathType,
+						},
+					},
+				},
+				{
+					Name: memorydump.ClaimVolumeName,
+					VolumeSource: k8sv1.VolumeSource{
+						PersistentVolumeClaim: &k8sv1.PersistentVolumeClaimVolumeSource{
+							ClaimName: request.ClaimName,
+						},
+					},
+				},
+			},
+		},
+	}
+
+	return pod, nil
+}
+
+// RenderBackupManifest renders the pod which copies the NBD exports of a running
+// backup from the node of the VMI into the target PVC of the backup.
+func (t *templateService) RenderBackupManifest(backup *v1.VirtualMachineBackup, vmi *v1.VirtualMachineInstance) (*k8sv1.Pod, error) {
+	precond.MustNotBeNil(backup)
+	precond.MustNotBeNil(vmi)
+	target := backup.Spec.Target.PersistentVolumeClaim
+	if target == nil {
+		return nil, fmt.Errorf("backup %s has no persistentVolumeClaim target", backup.Name)
+	}
+	if vmi.Status.NodeName == "" {
+		return nil, fmt.Errorf("VMI %s is not scheduled to a node", vmi.Name)
+	}
+	state := vmi.Status.BackupState
+	if state == nil || state.BackupName != backup.Name || len(state.Volumes) == 0 {
+		return nil, fmt.Errorf("backup %s exports no volumes of VMI %s", backup.Name, vmi.Name)
+	}
+
+	var userId int64 = 0
+	var gracePeriodSeconds int64 = 5
+	hostPathType := k8sv1.HostPathDirectory
+	socketPath := vmbackup.SocketPath(vmbackup.LauncherDir)
+
+	// Every volume is copied by its own container, so that they are copied in parallel
+	var containers []k8sv1.Container
+	for _, volumeName := range state.Volumes {
+		containers = append(containers, k8sv1.Container{
+			Name:            volumeName,
+			Image:           t.launcherImage,
+			ImagePullPolicy: t.clusterConfig.GetImagePullPolicy(),
+			Command:         []string{"/usr/bin/qemu-img"},
+			Args: []string{
+				"convert", "-O", "qcow2",
+				fmt.Sprintf("nbd+unix:///%s?socket=%s", volumeName, socketPath),
+				filepath.Join(vmbackup.ClaimDir, vmbackup.TargetFileName(backup, volumeName)),
+			},
+			Resources: k8sv1.ResourceRequirements{
+				Limits: k8sv1.ResourceList{
+					k8sv1.ResourceCPU:    resource.MustParse("1"),
+					k8sv1.ResourceMemory: resource.MustParse("200M"),
+				},
+				Requests: k8sv1.ResourceList{
+					k8sv1.ResourceCPU:    resource.MustParse("10m"),
+					k8sv1.ResourceMemory: resource.MustParse("35M"),
+				},
+			},
+			VolumeMounts: []k8sv1.VolumeMount{
+				{
+					Name:      vmbackup.VolumeName,
+					MountPath: vmbackup.LauncherDir,
+				},
+				{
+					Name:      vmbackup.ClaimVolumeName,
+					MountPath: vmbackup.ClaimDir,
+				},
+			},
+		})
+	}
+
+	pod := &k8sv1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      vmbackup.PodName(backup),
+			Namespace: backup.Namespace,
+			Labels: map[string]string{
+				v1.AppLabel:          "virt-backup",
+				vmbackup.BackupLabel: backup.Name,
+			},
+			OwnerReferences: []metav1.OwnerReference{
+				*metav1.NewControllerRef(backup, v1.VirtualMachineBackupGroupVersionKind),
+			},
+		},
+		Spec: k8sv1.PodSpec{
+			SecurityContext: &k8sv1.PodSecurityContext{
+				RunAsUser: &userId,
+				SELinuxOptions: &k8sv1.SELinuxOptions{
+					Type: "virt_launcher.process",
+				},
+			},
+			// The NBD socket is only reachable on the node of the VMI
+			Affinity: &k8sv1.Affinity{
+				NodeAffinity: &k8sv1.NodeAffinity{
+					RequiredDuringSchedulingIgnoredDuringExecution: &k8sv1.NodeSelector{
+						NodeSelectorTerms: []k8sv1.NodeSelectorTerm{
+							{
+								MatchFields: []k8sv1.NodeSelectorRequirement{
+									{
+										Key:      "metadata.name",
+										Operator: k8sv1.NodeSelectorOpIn,
+										Values:   []string{vmi.Status.NodeName},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			Tolerations:                   vmi.Spec.Tolerations,
+			TerminationGracePeriodSeconds: &gracePeriodSeconds,
+			RestartPolicy:                 k8sv1.RestartPolicyNever,
+			Containers:                    containers,
+			Volumes: []k8sv1.Volume{
+				{
+					Name: vmbackup.VolumeName,
+					VolumeSource: k8sv1.VolumeSource{
+						HostPath: &k8sv1.HostPathVolumeSource{
+							Path: vmbackup.GenerateHostDir(t.virtLibDir, vmi.UID),
+							Type: &hostPathType,
+						},
+					},
+				},
+				{
+					Name: vmbackup.ClaimVolumeName,
+					VolumeSource: k8sv1.VolumeSource{
+						PersistentVolumeClaim: &k8sv1.PersistentVolumeClaimVolumeSource{
+							ClaimName: target.ClaimName,
+						},
+					},
+				},
+			},
+		},
+	}
+
+	return pod, nil
+}
+
+// RenderExportManifest renders the exporter pod of a VirtualMachineExport, which
+// serves the given volumes read-only and, if withManifest is set, the manifest
+// of the exported VirtualMachine.
+func (t *templateService) RenderExportManifest(export *v1.VirtualMachineExport, volumes []vmexport.Volume, withManifest bool) (*k8sv1.Pod, error) {
+	precond.MustNotBeNil(export)
+
+	var userId int64 = 0
+	var gracePeriodSeconds int64 = 15
+
+	args := []string{"--path-prefix", vmexport.PathPrefix(export)}
+	if withManifest {
+		args = append(args, "--manifest-file", filepath.Join(vmexport.SecretDir, vmexport.ManifestKey))
+	}
+
+	var volumeMounts []k8sv1.VolumeMount
+	var volumeDevices []k8sv1.VolumeDevice
+	var podVolumes []k8sv1.Volume
+	for _, volume := range volumes {
+		_, exists, isBlock, err := types.IsPVCBlockFromStore(t.persistentVolumeClaimStore, export.Namespace, volume.ClaimName)
+		if err != nil {
+			return nil, err
+		} else if !exists {
+			return nil, PvcNotFoundError(fmt.Errorf("didn't find PVC %v", volume.ClaimName))
+		}
+
+		if isBlock {
+			volumeDevices = append(volumeDevices, k8sv1.VolumeDevice{
+				Name:       volume.Name,
+				DevicePath: vmexport.VolumeImagePath(volume.Name, true),
+			})
+		} else {
+			volumeMounts = append(volumeMounts, k8sv1.VolumeMount{
+				Name:      volume.Name,
+				MountPath: vmexport.VolumeMountPath(volume.Name),
+				ReadOnly:  true,
+			})
+		}
+		podVolumes = append(podVolumes, k8sv1.Volume{
+			Name: volume.Name,
+			VolumeSource: k8sv1.VolumeSource{
+				PersistentVolumeClaim: &k8sv1.PersistentVolumeClaimVolumeSource{
+					ClaimName: volume.ClaimName,
+					ReadOnly:  true,
+				},
+			},
+		})
+		args = append(args, "--volume", fmt.Sprintf("%s=%s", volume.Name, vmexport.VolumeImagePath(volume.Name, isBlock)))
+	}
+
+	volumeMounts = append(volumeMounts, k8sv1.VolumeMount{
+		Name:      vmexport.SecretVolumeName,
+		MountPath: vmexport.SecretDir,
+		ReadOnly:  true,
+	})
+	podVolumes = append(podVolumes, k8sv1.Volume{
+		Name: vmexport.SecretVolumeName,
+		VolumeSource: k8sv1.VolumeSource{
+			Secret: &k8sv1.SecretVolumeSource{
+				SecretName: vmexport.Name(export),
+			},
+		},
+	})
+
+	pod := &k8sv1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      vmexport.Name(export),
+			Namespace: export.Namespace,
+			Labels: map[string]string{
+				v1.AppLabel:          "virt-exportserver",
+				vmexport.ExportLabel: export.Name,
+			},
+			OwnerReferences: []metav1.OwnerReference{
+				*metav1.NewControllerRef(export, v1.VirtualMachineExportGroupVersionKind),
+			},
+		},
+		Spec: k8sv1.PodSpec{
+			SecurityContext: &k8sv1.PodSecurityContext{
+				RunAsUser: &userId,
+			},
+			TerminationGracePeriodSeconds: &gracePeriodSeconds,
+			RestartPolicy:                 k8sv1.RestartPolicyAlways,
+			Containers: []k8sv1.Container{
+				{
+					Name:            vmexport.ContainerName,
+					Image:           t.launcherImage,
+					ImagePullPolicy: t.clusterConfig.GetImagePullPolicy(),
+					Command:         []string{"/usr/bin/virt-exportserver"},
+					Args:            args,
+					Env: []k8sv1.EnvVar{
+						{
+							Name: vmexport.TokenEnvVar,
+							ValueFrom: &k8sv1.EnvVarSource{
+								SecretKeyRef: &k8sv1.SecretKeySelector{
+									LocalObjectReference: k8sv1.LocalObjectReference{
+										Name: export.Spec.TokenSecretRef,
+									},
+									Key: vmexport.TokenSecretKey,
+								},
+							},
+						},
+					},
+					Ports: []k8sv1.ContainerPort{
+						{
+							Name:          "export",
+							ContainerPort: vmexport.Port,
+							Protocol:      k8sv1.ProtocolTCP,
+						},
+					},
+					ReadinessProbe: &k8sv1.Probe{
+						Handler: k8sv1.Handler{
+							TCPSocket: &k8sv1.TCPSocketAction{
+								Port: intstr.FromInt(vmexport.Port),
+							},
+						},
+						PeriodSeconds: 2,
+					},
+					Resources: k8sv1.ResourceRequirements{
+						Limits: k8sv1.ResourceList{
+							k8sv1.ResourceCPU:    resource.MustParse("1"),
+							k8sv1.ResourceMemory: resource.MustParse("200M"),
+						},
+						Requests: k8sv1.ResourceList{
+							k8sv1.ResourceCPU:    resource.MustParse("10m"),
+							k8sv1.ResourceMemory: resource.MustParse("35M"),
+						},
+					},
+					VolumeMounts:  volumeMounts,
+					VolumeDevices: volumeDevices,
+				},
+			},
+			Volumes: podVolumes,
+		},
+	}
+
+	return pod, nil
+}
+
 func NewTemplateService(launcherImage string,
 	virtShareDir string,
 	virtLibDir string,
@@ -1182,7 +1698,7 @@ func NewTemplateService(launcherImage string,
 	return &svc
 }
 
-func copyProbe(probe *v1.Probe) *k8sv1.Probe {
+func copyProbe(vmi *v1.VirtualMachineInstance, probe *v1.Probe, virtShareDir string) *k8sv1.Probe {
 	if probe == nil {
 		return nil
 	}
@@ -1195,6 +1711,35 @@ func copyProbe(probe *v1.Probe) *k8sv1.Probe {
 		Handler: k8sv1.Handler{
 			HTTPGet:   probe.HTTPGet,
 			TCPSocket: probe.TCPSocket,
+			Exec:      guestAgentProbeAction(vmi, probe, virtShareDir),
 		},
 	}
 }
+
+// guestAgentProbeAction returns the command which lets virt-probe run a guest
+// agent based probe in the compute container, or nil for other probes
+func guestAgentProbeAction(vmi *v1.VirtualMachineInstance, probe *v1.Probe, virtShareDir string) *k8sv1.ExecAction {
+	if probe.Exec == nil && probe.GuestAgentPing == nil {
+		return nil
+	}
+
+	timeoutSeconds := probe.TimeoutSeconds
+	if timeoutSeconds < 1 {
+		timeoutSeconds = 1
+	}
+	command := []string{
+		"/usr/bin/virt-probe",
+		"--kubevirt-share-dir", virtShareDir,
+		"--uid", string(vmi.UID),
+		"--namespace", vmi.Namespace,
+		"--name", vmi.Name,
+		"--timeout-seconds", strconv.Itoa(int(timeoutSeconds)),
+	}
+	if probe.GuestAgentPing != nil {
+		command = append(command, "--guest-agent-ping")
+	} else {
+		command = append(command, "--")
+		command = append(command, probe.Exec.Command...)
+	}
+	return &k8sv1.ExecAction{Command: command}
+}
@@ -39,9 +39,14 @@ import (
 	v1 "kubevirt.io/client-go/api/v1"
 	"kubevirt.io/client-go/kubecli"
 	"kubevirt.io/client-go/log"
+	consolelog "kubevirt.io/kubevirt/pkg/console-log"
+	containerdisk "kubevirt.io/kubevirt/pkg/container-disk"
 	"kubevirt.io/kubevirt/pkg/hooks"
+	memorydump "kubevirt.io/kubevirt/pkg/memory-dump"
 	"kubevirt.io/kubevirt/pkg/testutils"
 	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
+	vmbackup "kubevirt.io/kubevirt/pkg/vm-backup"
+	vmexport "kubevirt.io/kubevirt/pkg/vm-export"
 )
 
 const namespaceKubevirt = "kubevirt"
@@ -944,11 +949,11 @@ var _ = Describe("Template", func() {
 				Expect(hugepagesRequest.ToDec().ScaledValue(resource.Mega)).To(Equal(int64(64)))
 				Expect(hugepagesLimit.ToDec().ScaledValue(resource.Mega)).To(Equal(int64(64)))
 
-				Expect(len(pod.Spec.Volumes)).To(Equal(7))
+				Expect(len(pod.Spec.Volumes)).To(Equal(8))
 				Expect(pod.Spec.Volumes[0].EmptyDir).ToNot(BeNil())
 				Expect(pod.Spec.Volumes[0].EmptyDir.Medium).To(Equal(kubev1.StorageMediumHugePages))
 
-				Expect(len(pod.Spec.Containers[0].VolumeMounts)).To(Equal(6))
+				Expect(len(pod.Spec.Containers[0].VolumeMounts)).To(Equal(7))
 				Expect(pod.Spec.Containers[0].VolumeMounts[4].MountPath).To(Equal("/dev/hugepages"))
 			},
 				table.Entry("hugepages-2Mi", "2Mi"),
@@ -989,11 +994,11 @@ var _ = Describe("Template", func() {
 				Expect(pod.Spec.Containers[0].VolumeDevices).To(BeEmpty(), "No devices in manifest for 1st container")
 
 				Expect(pod.Spec.Containers[0].VolumeMounts).ToNot(BeEmpty(), "Some mounts in manifest for 1st container")
-				Expect(len(pod.Spec.Containers[0].VolumeMounts)).To(Equal(6), "4 mounts in manifest for 1st container")
+				Expect(len(pod.Spec.Containers[0].VolumeMounts)).To(Equal(7), "7 mounts in manifest for 1st container")
 				Expect(pod.Spec.Containers[0].VolumeMounts[4].Name).To(Equal(volumeName), "1st mount in manifest for 1st container has correct name")
 
 				Expect(pod.Spec.Volumes).ToNot(BeEmpty(), "Found some volumes in manifest")
-				Expect(len(pod.Spec.Volumes)).To(Equal(7), "Found 4 volumes in manifest")
+				Expect(len(pod.Spec.Volumes)).To(Equal(8), "Found 8 volumes in manifest")
 				Expect(pod.Spec.Volumes[0].PersistentVolumeClaim).ToNot(BeNil(), "Found PVC volume")
 				Expect(pod.Spec.Volumes[0].PersistentVolumeClaim.ClaimName).To(Equal(pvcName), "Found PVC volume with correct name")
 			})
@@ -1037,10 +1042,10 @@ var _ = Describe("Template", func() {
 				Expect(pod.Spec.Containers[0].VolumeDevices[0].Name).To(Equal(volumeName), "Found device for 1st container with correct name")
 
 				Expect(pod.Spec.Containers[0].VolumeMounts).ToNot(BeEmpty(), "Found some mounts in manifest for 1st container")
-				Expect(len(pod.Spec.Containers[0].VolumeMounts)).To(Equal(5), "Found 5 mounts in manifest for 1st container")
+				Expect(len(pod.Spec.Containers[0].VolumeMounts)).To(Equal(6), "Found 6 mounts in manifest for 1st container")
 
 				Expect(pod.Spec.Volumes).ToNot(BeEmpty(), "Found some volumes in manifest")
-				Expect(len(pod.Spec.Volumes)).To(Equal(7), "Found 4 volumes in manifest")
+				Expect(len(pod.Spec.Volumes)).To(Equal(8), "Found 8 volumes in manifest")
 				Expect(pod.Spec.Volumes[0].PersistentVolumeClaim).ToNot(BeNil(), "Found PVC volume")
 				Expect(pod.Spec.Volumes[0].PersistentVolumeClaim.ClaimName).To(Equal(pvcName), "Found PVC volume with correct name")
 			})
@@ -1151,6 +1156,92 @@ var _ = Describe("Template", func() {
 			})
 		})
 
+		Context("with kernel boot", func() {
+			vmi := v1.VirtualMachineInstance{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "testvmi", Namespace: "default", UID: "1234",
+				},
+				Spec: v1.VirtualMachineInstanceSpec{Domain: v1.DomainSpec{
+					Firmware: &v1.Firmware{
+						KernelBoot: &v1.KernelBoot{
+							Container: &v1.KernelBootContainer{
+								Image:           "kernel-image",
+								ImagePullSecret: "pull-secret-3",
+								KernelPath:      "/boot/vmlinuz",
+							},
+						},
+					},
+				}},
+			}
+
+			It("should add the kernel boot container and its secret to the pod spec", func() {
+				pod, err := svc.RenderLaunchManifest(&vmi)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(pod.Spec.Containers[1].Name).To(Equal("kernel-boot"))
+				Expect(pod.Spec.Containers[1].Image).To(Equal("kernel-image"))
+
+				Expect(len(pod.Spec.ImagePullSecrets)).To(Equal(2))
+				Expect(pod.Spec.ImagePullSecrets[0].Name).To(Equal("pull-secret-3"))
+				Expect(pod.Spec.ImagePullSecrets[1].Name).To(Equal("pull-secret-1"))
+			})
+		})
+
+		Context("with containerDisks", func() {
+			newVMI := func() *v1.VirtualMachineInstance {
+				return &v1.VirtualMachineInstance{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "testvmi", Namespace: "default", UID: "1234",
+					},
+					Spec: v1.VirtualMachineInstanceSpec{
+						Volumes: []v1.Volume{
+							{
+								Name: "disk0",
+								VolumeSource: v1.VolumeSource{
+									ContainerDisk: &v1.ContainerDiskSource{Image: "disk-image"},
+								},
+							},
+						},
+					},
+				}
+			}
+
+			It("should prefer nodes which hold the image in a ContainerDiskImageCache", func() {
+				pod, err := svc.RenderLaunchManifest(newVMI())
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(pod.Spec.Affinity).ToNot(BeNil())
+				Expect(pod.Spec.Affinity.NodeAffinity.PreferredDuringSchedulingIgnoredDuringExecution).To(Equal([]kubev1.PreferredSchedulingTerm{
+					{
+						Weight: 10,
+						Preference: kubev1.NodeSelectorTerm{
+							MatchExpressions: []kubev1.NodeSelectorRequirement{
+								{Key: containerdisk.CachedImageNodeLabel("disk-image"), Operator: kubev1.NodeSelectorOpExists},
+							},
+						},
+					},
+				}))
+			})
+
+			It("should keep the affinity of the VMI", func() {
+				vmi := newVMI()
+				required := &kubev1.NodeSelector{
+					NodeSelectorTerms: []kubev1.NodeSelectorTerm{
+						{MatchExpressions: []kubev1.NodeSelectorRequirement{{Key: "zone", Operator: kubev1.NodeSelectorOpIn, Values: []string{"a"}}}},
+					},
+				}
+				vmi.Spec.Affinity = &kubev1.Affinity{
+					NodeAffinity: &kubev1.NodeAffinity{RequiredDuringSchedulingIgnoredDuringExecution: required},
+				}
+				pod, err := svc.RenderLaunchManifest(vmi)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(pod.Spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution).To(Equal(required))
+				Expect(pod.Spec.Affinity.NodeAffinity.PreferredDuringSchedulingIgnoredDuringExecution).To(HaveLen(1))
+				Expect(vmi.Spec.Affinity.NodeAffinity.PreferredDuringSchedulingIgnoredDuringExecution).To(BeEmpty())
+			})
+		})
+
 		Context("with sriov interface", func() {
 			It("should not run privileged", func() {
 				sriovInterface := v1.InterfaceSRIOV{}
@@ -1377,7 +1468,7 @@ var _ = Describe("Template", func() {
 				Expect(err).ToNot(HaveOccurred())
 
 				Expect(pod.Spec.Volumes).ToNot(BeEmpty())
-				Expect(len(pod.Spec.Volumes)).To(Equal(7))
+				Expect(len(pod.Spec.Volumes)).To(Equal(8))
 				Expect(pod.Spec.Volumes[0].ConfigMap).ToNot(BeNil())
 				Expect(pod.Spec.Volumes[0].ConfigMap.LocalObjectReference.Name).To(Equal("test-configmap"))
 			})
@@ -1406,11 +1497,62 @@ var _ = Describe("Template", func() {
 				Expect(err).ToNot(HaveOccurred())
 
 				Expect(pod.Spec.Volumes).ToNot(BeEmpty())
-				Expect(len(pod.Spec.Volumes)).To(Equal(7))
+				Expect(len(pod.Spec.Volumes)).To(Equal(8))
 				Expect(pod.Spec.Volumes[0].Secret).ToNot(BeNil())
 				Expect(pod.Spec.Volumes[0].Secret.SecretName).To(Equal("test-secret"))
 			})
 		})
+
+		Context("with access credentials", func() {
+			It("should mount every access credential Secret once", func() {
+				source := v1.AccessCredentialSource{
+					Secret: &v1.AccessCredentialSecretSource{
+						SecretName: "my-keys",
+					},
+				}
+				vmi := v1.VirtualMachineInstance{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "testvmi", Namespace: "default", UID: "1234",
+					},
+					Spec: v1.VirtualMachineInstanceSpec{
+						Domain: v1.DomainSpec{},
+						AccessCredentials: []v1.AccessCredential{
+							{
+								SSHPublicKey: &v1.SSHPublicKeyAccessCredential{
+									Source: source,
+									PropagationMethod: v1.SSHPublicKeyAccessCredentialPropagationMethod{
+										CloudInit: &v1.CloudInitSSHPublicKeyAccessCredentialPropagation{},
+									},
+								},
+							},
+							{
+								SSHPublicKey: &v1.SSHPublicKeyAccessCredential{
+									Source: source,
+									PropagationMethod: v1.SSHPublicKeyAccessCredentialPropagationMethod{
+										QemuGuestAgent: &v1.QemuGuestAgentSSHPublicKeyAccessCredentialPropagation{
+											Users: []string{"fedora"},
+										},
+									},
+								},
+							},
+						},
+					},
+				}
+
+				pod, err := svc.RenderLaunchManifest(&vmi)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(len(pod.Spec.Volumes)).To(Equal(8))
+				Expect(pod.Spec.Volumes[0].Name).To(Equal("my-keys-access-cred"))
+				Expect(pod.Spec.Volumes[0].Secret).ToNot(BeNil())
+				Expect(pod.Spec.Volumes[0].Secret.SecretName).To(Equal("my-keys"))
+				Expect(pod.Spec.Containers[0].VolumeMounts).To(ContainElement(kubev1.VolumeMount{
+					Name:      "my-keys-access-cred",
+					MountPath: "/var/run/kubevirt-private/access-cred/my-keys",
+					ReadOnly:  true,
+				}))
+			})
+		})
 		Context("with probes", func() {
 			var vmi *v1.VirtualMachineInstance
 			BeforeEach(func() {
@@ -1484,6 +1626,38 @@ var _ = Describe("Template", func() {
 				Expect(err).ToNot(HaveOccurred())
 				Expect(pod.Spec.Containers[0].ReadinessProbe).To(Not(BeNil()))
 			})
+
+			It("should run guest agent probes through virt-probe", func() {
+				vmi.Spec.ReadinessProbe.Handler = v1.Handler{
+					GuestAgentPing: &v1.GuestAgentPing{},
+				}
+				vmi.Spec.LivenessProbe.Handler = v1.Handler{
+					Exec: &kubev1.ExecAction{Command: []string{"cat", "/tmp/healthy"}},
+				}
+				pod, err := svc.RenderLaunchManifest(vmi)
+				Expect(err).ToNot(HaveOccurred())
+				readinessProbe := pod.Spec.Containers[0].ReadinessProbe
+				livenessProbe := pod.Spec.Containers[0].LivenessProbe
+
+				Expect(readinessProbe.Handler.HTTPGet).To(BeNil())
+				Expect(readinessProbe.Handler.TCPSocket).To(BeNil())
+				Expect(readinessProbe.Handler.Exec.Command).To(Equal([]string{"/usr/bin/virt-probe",
+					"--kubevirt-share-dir", "/var/run/kubevirt",
+					"--uid", "1234",
+					"--namespace", "default",
+					"--name", "testvmi",
+					"--timeout-seconds", "3",
+					"--guest-agent-ping",
+				}))
+				Expect(livenessProbe.Handler.Exec.Command).To(Equal([]string{"/usr/bin/virt-probe",
+					"--kubevirt-share-dir", "/var/run/kubevirt",
+					"--uid", "1234",
+					"--namespace", "default",
+					"--name", "testvmi",
+					"--timeout-seconds", "13",
+					"--", "cat", "/tmp/healthy",
+				}))
+			})
 		})
 
 		Context("with GPU device interface", func() {
@@ -1548,6 +1722,79 @@ var _ = Describe("Template", func() {
 			})
 		})
 
+		Context("with serial console log", func() {
+			It("should not add the guest-console-log container by default", func() {
+				vmi := v1.VirtualMachineInstance{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "testvmi", Namespace: "default", UID: "1234",
+					},
+				}
+
+				pod, err := svc.RenderLaunchManifest(&vmi)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(len(pod.Spec.Containers)).To(Equal(1))
+				for _, volume := range pod.Spec.Volumes {
+					Expect(volume.Name).ToNot(Equal(consolelog.VolumeName))
+				}
+			})
+
+			It("should add the guest-console-log container if enabled", func() {
+				logSerialConsole := true
+				vmi := v1.VirtualMachineInstance{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "testvmi", Namespace: "default", UID: "1234",
+					},
+					Spec: v1.VirtualMachineInstanceSpec{
+						Domain: v1.DomainSpec{
+							Devices: v1.Devices{
+								LogSerialConsole: &logSerialConsole,
+							},
+						},
+					},
+				}
+
+				pod, err := svc.RenderLaunchManifest(&vmi)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(len(pod.Spec.Containers)).To(Equal(2))
+				Expect(pod.Spec.Containers[1].Name).To(Equal(consolelog.ContainerName))
+				Expect(pod.Spec.Containers[1].Image).To(Equal("kubevirt/virt-launcher"))
+				Expect(pod.Spec.Containers[1].Args).To(ContainElement(consolelog.PipePath()))
+				Expect(*pod.Spec.Containers[1].SecurityContext.RunAsNonRoot).To(BeTrue())
+
+				Expect(pod.Spec.Containers[0].VolumeMounts).To(ContainElement(kubev1.VolumeMount{
+					Name:      consolelog.VolumeName,
+					MountPath: consolelog.LogDir,
+				}))
+				Expect(pod.Spec.Volumes).To(ContainElement(kubev1.Volume{
+					Name: consolelog.VolumeName,
+					VolumeSource: kubev1.VolumeSource{
+						EmptyDir: &kubev1.EmptyDirVolumeSource{},
+					},
+				}))
+			})
+		})
+
+		It("should mount an emptyDir for memory dumps instead of a node directory", func() {
+			vmi := v1.VirtualMachineInstance{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "testvmi", Namespace: "default", UID: "1234",
+				},
+			}
+
+			pod, err := svc.RenderLaunchManifest(&vmi)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(pod.Spec.Containers[0].VolumeMounts).To(ContainElement(kubev1.VolumeMount{
+				Name:      memorydump.VolumeName,
+				MountPath: memorydump.LauncherDir,
+			}))
+			Expect(pod.Spec.Volumes).To(ContainElement(kubev1.Volume{
+				Name: memorydump.VolumeName,
+				VolumeSource: kubev1.VolumeSource{
+					EmptyDir: &kubev1.EmptyDirVolumeSource{},
+				},
+			}))
+		})
+
 		It("should add the lessPVCSpaceToleration argument to the template", func() {
 			expectedToleration := "42"
 			testutils.UpdateFakeClusterConfig(configMapInformer, &kubev1.ConfigMap{
@@ -1569,6 +1816,268 @@ var _ = Describe("Template", func() {
 
 	})
 
+	Describe("Memory dump", func() {
+		var vm *v1.VirtualMachine
+		var vmi *v1.VirtualMachineInstance
+		var launcherPod *kubev1.Pod
+
+		BeforeEach(func() {
+			fileName := "testvm-dump-claim-20200101-000000.memory.dump"
+			vm = &v1.VirtualMachine{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "testvm", Namespace: "default", UID: "5678",
+				},
+				Status: v1.VirtualMachineStatus{
+					MemoryDumpRequest: &v1.VirtualMachineMemoryDumpRequest{
+						ClaimName: "dump-claim",
+						Phase:     v1.MemoryDumpInProgress,
+						FileName:  &fileName,
+					},
+				},
+			}
+			vmi = &v1.VirtualMachineInstance{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "testvm", Namespace: "default", UID: "1234",
+				},
+				Status: v1.VirtualMachineInstanceStatus{
+					NodeName: "node01",
+				},
+			}
+			launcherPod = &kubev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "virt-launcher-testvm-abcde", Namespace: "default", UID: "9012",
+				},
+			}
+		})
+
+		It("should render a pod which copies the dump on the node of the VMI into the PVC", func() {
+			pod, err := svc.RenderMemoryDumpManifest(vm, vmi, launcherPod)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(pod.Name).To(Equal("virt-memory-dump-testvm"))
+			Expect(pod.Namespace).To(Equal("default"))
+			Expect(pod.Labels).To(HaveKeyWithValue(memorydump.VirtualMachineLabel, "testvm"))
+			Expect(pod.Annotations).To(HaveKeyWithValue(memorydump.FileNameAnnotation, "testvm-dump-claim-20200101-000000.memory.dump"))
+			Expect(*metav1.GetControllerOf(pod)).To(Equal(*metav1.NewControllerRef(vm, v1.VirtualMachineGroupVersionKind)))
+			Expect(pod.Spec.RestartPolicy).To(Equal(kubev1.RestartPolicyNever))
+			Expect(pod.Spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms[0].MatchFields[0].Values).To(ConsistOf("node01"))
+
+			Expect(pod.Spec.Containers).To(HaveLen(1))
+			container := pod.Spec.Containers[0]
+			Expect(container.Image).To(Equal("kubevirt/virt-launcher"))
+			Expect(container.Command).To(Equal([]string{"/usr/bin/virt-memory-dump"}))
+			Expect(container.Args).To(ContainElement("testvm-dump-claim-20200101-000000.memory.dump"))
+			Expect(container.ReadinessProbe.Exec.Command).To(Equal([]string{"/usr/bin/test", "-f", "/dump/testvm-dump-claim-20200101-000000.memory.dump"}))
+
+			Expect(pod.Spec.Volumes).To(HaveLen(2))
+			Expect(pod.Spec.Volumes[0].HostPath.Path).To(Equal("/var/lib/kubelet/pods/9012/volumes/kubernetes.io~empty-dir/memory-dump"))
+			Expect(pod.Spec.Volumes[1].PersistentVolumeClaim.ClaimName).To(Equal("dump-claim"))
+		})
+
+		It("should not run the pod as root", func() {
+			pod, err := svc.RenderMemoryDumpManifest(vm, vmi, launcherPod)
+			Expect(err).ToNot(HaveOccurred())
+
+			securityContext := pod.Spec.SecurityContext
+			Expect(*securityContext.RunAsNonRoot).To(BeTrue())
+			Expect(*securityContext.RunAsUser).To(Equal(memorydump.CopyUserID))
+			Expect(*securityContext.FSGroup).To(Equal(memorydump.CopyUserID))
+		})
+
+		It("should fail if no memory dump is requested", func() {
+			vm.Status.MemoryDumpRequest = nil
+			_, err := svc.RenderMemoryDumpManifest(vm, vmi, launcherPod)
+			Expect(err).To(MatchError("no memory dump requested for VM testvm"))
+		})
+
+		It("should fail if the VMI is not scheduled", func() {
+			vmi.Status.NodeName = ""
+			_, err := svc.RenderMemoryDumpManifest(vm, vmi, launcherPod)
+			Expect(err).To(MatchError("VMI testvm is not scheduled to a node"))
+		})
+	})
+
+	Describe("Backup", func() {
+		var backup *v1.VirtualMachineBackup
+		var vmi *v1.VirtualMachineInstance
+
+		BeforeEach(func() {
+			backup = &v1.VirtualMachineBackup{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "testbackup", Namespace: "default", UID: "5678",
+				},
+				Spec: v1.VirtualMachineBackupSpec{
+					VMIName: "testvmi",
+					Target: v1.VirtualMachineBackupTarget{
+						PersistentVolumeClaim: &kubev1.PersistentVolumeClaimVolumeSource{ClaimName: "backup-claim"},
+					},
+				},
+			}
+			vmi = &v1.VirtualMachineInstance{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "testvmi", Namespace: "default", UID: "1234",
+				},
+				Status: v1.VirtualMachineInstanceStatus{
+					NodeName: "node01",
+					BackupState: &v1.VirtualMachineInstanceBackupState{
+						BackupName: "testbackup",
+						Volumes:    []string{"rootdisk", "datadisk"},
+					},
+				},
+			}
+		})
+
+		It("should mount the backup directory of the node into virt-launcher", func() {
+			enableFeatureGate(virtconfig.VMBackupGate)
+			defer disableFeatureGates()
+			pod, err := svc.RenderLaunchManifest(vmi)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(pod.Spec.Containers[0].VolumeMounts).To(ContainElement(kubev1.VolumeMount{
+				Name:      vmbackup.VolumeName,
+				MountPath: vmbackup.LauncherDir,
+			}))
+			hostPathType := kubev1.HostPathDirectoryOrCreate
+			Expect(pod.Spec.Volumes).To(ContainElement(kubev1.Volume{
+				Name: vmbackup.VolumeName,
+				VolumeSource: kubev1.VolumeSource{
+					HostPath: &kubev1.HostPathVolumeSource{
+						Path: "/var/lib/kubevirt/backups/1234",
+						Type: &hostPathType,
+					},
+				},
+			}))
+		})
+
+		It("should not mount the backup directory of the node without the VMBackup feature gate", func() {
+			pod, err := svc.RenderLaunchManifest(vmi)
+			Expect(err).ToNot(HaveOccurred())
+			for _, volume := range pod.Spec.Volumes {
+				Expect(volume.Name).ToNot(Equal(vmbackup.VolumeName))
+			}
+			for _, volumeMount := range pod.Spec.Containers[0].VolumeMounts {
+				Expect(volumeMount.Name).ToNot(Equal(vmbackup.VolumeName))
+			}
+		})
+
+		It("should render a pod which copies every exported volume on the node of the VMI into the PVC", func() {
+			pod, err := svc.RenderBackupManifest(backup, vmi)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(pod.Name).To(Equal("virt-backup-testbackup"))
+			Expect(pod.Namespace).To(Equal("default"))
+			Expect(pod.Labels).To(HaveKeyWithValue(vmbackup.BackupLabel, "testbackup"))
+			Expect(*metav1.GetControllerOf(pod)).To(Equal(*metav1.NewControllerRef(backup, v1.VirtualMachineBackupGroupVersionKind)))
+			Expect(pod.Spec.RestartPolicy).To(Equal(kubev1.RestartPolicyNever))
+			Expect(pod.Spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms[0].MatchFields[0].Values).To(ConsistOf("node01"))
+
+			Expect(pod.Spec.Containers).To(HaveLen(2))
+			container := pod.Spec.Containers[1]
+			Expect(container.Name).To(Equal("datadisk"))
+			Expect(container.Image).To(Equal("kubevirt/virt-launcher"))
+			Expect(container.Command).To(Equal([]string{"/usr/bin/qemu-img"}))
+			Expect(container.Args).To(Equal([]string{
+				"convert", "-O", "qcow2",
+				"nbd+unix:///datadisk?socket=/var/run/kubevirt-backup/nbd.sock",
+				"/backup/testbackup-datadisk.qcow2",
+			}))
+
+			Expect(pod.Spec.Volumes).To(HaveLen(2))
+			Expect(pod.Spec.Volumes[0].HostPath.Path).To(Equal("/var/lib/kubevirt/backups/1234"))
+			Expect(pod.Spec.Volumes[1].PersistentVolumeClaim.ClaimName).To(Equal("backup-claim"))
+		})
+
+		It("should fail if the backup has no PVC target", func() {
+			backup.Spec.Target = v1.VirtualMachineBackupTarget{NBD: &v1.NBDBackupTarget{}}
+			_, err := svc.RenderBackupManifest(backup, vmi)
+			Expect(err).To(MatchError("backup testbackup has no persistentVolumeClaim target"))
+		})
+
+		It("should fail if the VMI exports no volumes of the backup", func() {
+			vmi.Status.BackupState = nil
+			_, err := svc.RenderBackupManifest(backup, vmi)
+			Expect(err).To(MatchError("backup testbackup exports no volumes of VMI testvmi"))
+		})
+	})
+
+	Describe("Export", func() {
+		var export *v1.VirtualMachineExport
+
+		BeforeEach(func() {
+			export = &v1.VirtualMachineExport{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "testexport", Namespace: "exportns", UID: "5678",
+				},
+				Spec: v1.VirtualMachineExportSpec{
+					Source: kubev1.TypedLocalObjectReference{
+						Kind: "PersistentVolumeClaim",
+						Name: "rootdisk-pvc",
+					},
+					TokenSecretRef: "export-token",
+				},
+			}
+			blockMode := kubev1.PersistentVolumeBlock
+			for _, pvc := range []*kubev1.PersistentVolumeClaim{
+				{ObjectMeta: metav1.ObjectMeta{Namespace: "exportns", Name: "rootdisk-pvc"}},
+				{ObjectMeta: metav1.ObjectMeta{Namespace: "exportns", Name: "data-pvc"}, Spec: kubev1.PersistentVolumeClaimSpec{VolumeMode: &blockMode}},
+			} {
+				Expect(pvcCache.Add(pvc)).To(Succeed())
+			}
+		})
+
+		It("should render an exporter pod which serves the volumes read-only", func() {
+			volumes := []vmexport.Volume{
+				{Name: "rootdisk", ClaimName: "rootdisk-pvc"},
+				{Name: "data", ClaimName: "data-pvc"},
+			}
+			pod, err := svc.RenderExportManifest(export, volumes, true)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(pod.Name).To(Equal("virt-export-testexport"))
+			Expect(pod.Namespace).To(Equal("exportns"))
+			Expect(pod.Labels).To(HaveKeyWithValue(vmexport.ExportLabel, "testexport"))
+			Expect(*metav1.GetControllerOf(pod)).To(Equal(*metav1.NewControllerRef(export, v1.VirtualMachineExportGroupVersionKind)))
+
+			Expect(pod.Spec.Containers).To(HaveLen(1))
+			container := pod.Spec.Containers[0]
+			Expect(container.Image).To(Equal("kubevirt/virt-launcher"))
+			Expect(container.Command).To(Equal([]string{"/usr/bin/virt-exportserver"}))
+			Expect(container.Args).To(Equal([]string{
+				"--path-prefix", "/export/exportns/testexport",
+				"--manifest-file", "/etc/virt-exportserver/manifest.yaml",
+				"--volume", "rootdisk=/export-volumes/rootdisk/disk.img",
+				"--volume", "data=/export-volumes/data.img",
+			}))
+			Expect(container.Env).To(HaveLen(1))
+			Expect(container.Env[0].Name).To(Equal(vmexport.TokenEnvVar))
+			Expect(container.Env[0].ValueFrom.SecretKeyRef.Name).To(Equal("export-token"))
+			Expect(container.Env[0].ValueFrom.SecretKeyRef.Key).To(Equal("token"))
+
+			Expect(container.VolumeMounts).To(ConsistOf(
+				kubev1.VolumeMount{Name: "rootdisk", MountPath: "/export-volumes/rootdisk", ReadOnly: true},
+				kubev1.VolumeMount{Name: vmexport.SecretVolumeName, MountPath: "/etc/virt-exportserver", ReadOnly: true},
+			))
+			Expect(container.VolumeDevices).To(ConsistOf(
+				kubev1.VolumeDevice{Name: "data", DevicePath: "/export-volumes/data.img"},
+			))
+
+			Expect(pod.Spec.Volumes).To(HaveLen(3))
+			Expect(pod.Spec.Volumes[0].PersistentVolumeClaim).To(Equal(&kubev1.PersistentVolumeClaimVolumeSource{ClaimName: "rootdisk-pvc", ReadOnly: true}))
+			Expect(pod.Spec.Volumes[1].PersistentVolumeClaim).To(Equal(&kubev1.PersistentVolumeClaimVolumeSource{ClaimName: "data-pvc", ReadOnly: true}))
+			Expect(pod.Spec.Volumes[2].Secret.SecretName).To(Equal("virt-export-testexport"))
+		})
+
+		It("should not pass a manifest for PVC sources", func() {
+			pod, err := svc.RenderExportManifest(export, []vmexport.Volume{{Name: "rootdisk-pvc", ClaimName: "rootdisk-pvc"}}, false)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(pod.Spec.Containers[0].Args).ToNot(ContainElement("--manifest-file"))
+		})
+
+		It("should fail if a PVC does not exist", func() {
+			_, err := svc.RenderExportManifest(export, []vmexport.Volume{{Name: "other", ClaimName: "other-pvc"}}, false)
+			Expect(err).To(MatchError("didn't find PVC other-pvc"))
+		})
+	})
+
 	Describe("ServiceAccountName", func() {
 
 		It("Should add service account if present", func() {
@@ -21,14 +21,14 @@ package watch
 
 import (
 	"context"
-	"io/ioutil"
+	"crypto/tls"
+	"fmt"
 	golog "log"
 	"net/http"
 	"os"
 	"path/filepath"
 
 	"github.com/emicklei/go-restful"
-	"github.com/golang/glog"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	flag "github.com/spf13/pflag"
 	k8sv1 "k8s.io/api/core/v1"
@@ -43,7 +43,7 @@ import (
 	"kubevirt.io/client-go/kubecli"
 	"kubevirt.io/client-go/log"
 	clientutil "kubevirt.io/client-go/util"
-	"kubevirt.io/kubevirt/pkg/certificates"
+	"kubevirt.io/kubevirt/pkg/certificates/bootstrap"
 	containerdisk "kubevirt.io/kubevirt/pkg/container-disk"
 	"kubevirt.io/kubevirt/pkg/controller"
 	"kubevirt.io/kubevirt/pkg/service"
@@ -109,6 +109,16 @@ type VirtControllerApp struct {
 	migrationController *MigrationController
 	migrationInformer   cache.SharedIndexInformer
 
+	backupController *BackupController
+	backupInformer   cache.SharedIndexInformer
+
+	exportController *ExportController
+	exportInformer   cache.SharedIndexInformer
+
+	imageCacheController        *ImageCacheController
+	imageCacheInformer          cache.SharedIndexInformer
+	imageCacheDaemonSetInformer cache.SharedIndexInformer
+
 	LeaderElection leaderelectionconfig.Configuration
 
 	launcherImage              string
@@ -135,6 +145,9 @@ type VirtControllerApp struct {
 	rsControllerThreads               int
 	vmControllerThreads               int
 	migrationControllerThreads        int
+	backupControllerThreads           int
+	exportControllerThreads           int
+	imageCacheControllerThreads       int
 	evacuationControllerThreads       int
 	disruptionBudgetControllerThreads int
 }
@@ -179,10 +192,11 @@ func Execute() {
 
 	configMapInformer := app.informerFactory.ConfigMap()
 	crdInformer := app.informerFactory.CRD()
+	kubeVirtInformer := app.informerFactory.KubeVirt()
 	app.informerFactory.Start(stopChan)
 
-	cache.WaitForCacheSync(stopChan, configMapInformer.HasSynced, crdInformer.HasSynced)
-	app.clusterConfig = virtconfig.NewClusterConfig(configMapInformer, crdInformer, app.kubevirtNamespace)
+	cache.WaitForCacheSync(stopChan, configMapInformer.HasSynced, crdInformer.HasSynced, kubeVirtInformer.HasSynced)
+	app.clusterConfig = virtconfig.NewClusterConfig(configMapInformer, crdInformer, kubeVirtInformer, app.kubevirtNamespace)
 
 	app.reInitChan = make(chan string, 10)
 	app.hasCDI = app.clusterConfig.HasDataVolumeAPI()
@@ -206,6 +220,13 @@ func Execute() {
 
 	app.migrationInformer = app.informerFactory.VirtualMachineInstanceMigration()
 
+	app.backupInformer = app.informerFactory.VirtualMachineBackup()
+
+	app.exportInformer = app.informerFactory.VirtualMachineExport()
+
+	app.imageCacheInformer = app.informerFactory.ContainerDiskImageCache()
+	app.imageCacheDaemonSetInformer = app.informerFactory.ContainerDiskImageCacheDaemonSet()
+
 	if app.hasCDI {
 		app.dataVolumeInformer = app.informerFactory.DataVolume()
 		log.Log.Infof("CDI detected, DataVolume integration enabled")
@@ -249,21 +270,28 @@ func (vca *VirtControllerApp) Run() {
 
 	stop := vca.ctx.Done()
 
-	certsDirectory, err := ioutil.TempDir("", "certsdir")
-	if err != nil {
-		panic(err)
-	}
-	defer os.RemoveAll(certsDirectory)
+	// Load the certificate which virt-operator issued
+	certManager := bootstrap.NewFileCertificateManager(bootstrap.VirtControllerCertDir)
+	certManager.Start()
+	defer certManager.Stop()
 
-	certStore, err := certificates.GenerateSelfSignedCert(certsDirectory, "virt-controller", vca.kubevirtNamespace)
-	if err != nil {
-		glog.Fatalf("unable to generate certificates: %v", err)
-	}
 	go func() {
 		httpLogger := logger.With("service", "http")
 		httpLogger.Level(log.INFO).Log("action", "listening", "interface", vca.BindAddress, "port", vca.Port)
 		http.Handle("/metrics", promhttp.Handler())
-		if err := http.ListenAndServeTLS(vca.Address(), certStore.CurrentPath(), certStore.CurrentPath(), nil); err != nil {
+		server := &http.Server{
+			Addr: vca.Address(),
+			TLSConfig: &tls.Config{
+				GetCertificate: func(info *tls.ClientHelloInfo) (*tls.Certificate, error) {
+					crt := certManager.Current()
+					if crt == nil {
+						return nil, fmt.Errorf("no serving certificate available for virt-controller")
+					}
+					return crt, nil
+				},
+			},
+		}
+		if err := server.ListenAndServeTLS("", ""); err != nil {
 			golog.Fatal(err)
 		}
 	}()
@@ -298,10 +326,10 @@ func (vca *VirtControllerApp) Run() {
 					vca.informerFactory.Start(stop)
 
 					golog.Printf("STARTING controllers with following threads : "+
-						"node %d, vmi %d, replicaset %d, vm %d, migration %d, evacuation %d, disruptionBudget %d",
+						"node %d, vmi %d, replicaset %d, vm %d, migration %d, backup %d, export %d, imageCache %d, evacuation %d, disruptionBudget %d",
 						vca.nodeControllerThreads, vca.vmiControllerThreads, vca.rsControllerThreads,
-						vca.vmControllerThreads, vca.migrationControllerThreads, vca.evacuationControllerThreads,
-						vca.disruptionBudgetControllerThreads)
+						vca.vmControllerThreads, vca.migrationControllerThreads, vca.backupControllerThreads, vca.exportControllerThreads,
+						vca.imageCacheControllerThreads, vca.evacuationControllerThreads, vca.disruptionBudgetControllerThreads)
 
 					go vca.evacuationController.Run(vca.evacuationControllerThreads, stop)
 					go vca.disruptionBudgetController.Run(vca.disruptionBudgetControllerThreads, stop)
@@ -310,6 +338,9 @@ func (vca *VirtControllerApp) Run() {
 					go vca.rsController.Run(vca.rsControllerThreads, stop)
 					go vca.vmController.Run(vca.vmControllerThreads, stop)
 					go vca.migrationController.Run(vca.migrationControllerThreads, stop)
+					go vca.backupController.Run(vca.backupControllerThreads, stop)
+					go vca.exportController.Run(vca.exportControllerThreads, stop)
+					go vca.imageCacheController.Run(vca.imageCacheControllerThreads, stop)
 					cache.WaitForCacheSync(stop, vca.persistentVolumeClaimInformer.HasSynced)
 					close(vca.readyChan)
 				},
@@ -352,10 +383,14 @@ func (vca *VirtControllerApp) initCommon() {
 		vca.clusterConfig,
 	)
 
-	vca.vmiController = NewVMIController(vca.templateService, vca.vmiInformer, vca.podInformer, vca.vmiRecorder, vca.clientSet, vca.dataVolumeInformer)
+	vca.vmiController = NewVMIController(vca.templateService, vca.vmiInformer, vca.podInformer, vca.vmiRecorder, vca.clientSet, vca.dataVolumeInformer, vca.persistentVolumeClaimInformer)
 	recorder := vca.getNewRecorder(k8sv1.NamespaceAll, "node-controller")
 	vca.nodeController = NewNodeController(vca.clientSet, vca.nodeInformer, vca.vmiInformer, recorder)
 	vca.migrationController = NewMigrationController(vca.templateService, vca.vmiInformer, vca.podInformer, vca.migrationInformer, vca.vmiRecorder, vca.clientSet, vca.clusterConfig)
+	vca.backupController = NewBackupController(vca.templateService, vca.backupInformer, vca.vmiInformer, vca.podInformer, vca.vmiRecorder, vca.clientSet, vca.virtLibDir)
+	vca.exportController = NewExportController(vca.templateService, vca.exportInformer, vca.vmInformer, vca.vmiInformer,
+		vca.persistentVolumeClaimInformer, vca.podInformer, vca.vmiRecorder, vca.clientSet, vca.clusterConfig)
+	vca.imageCacheController = NewImageCacheController(vca.imageCacheInformer, vca.imageCacheDaemonSetInformer, vca.clientSet, vca.kubevirtNamespace, vca.virtLibDir)
 }
 
 func (vca *VirtControllerApp) initReplicaSet() {
@@ -367,9 +402,11 @@ func (vca *VirtControllerApp) initVirtualMachines() {
 	recorder := vca.getNewRecorder(k8sv1.NamespaceAll, "virtualmachine-controller")
 
 	vca.vmController = NewVMController(
+		vca.templateService,
 		vca.vmiInformer,
 		vca.vmInformer,
 		vca.dataVolumeInformer,
+		vca.podInformer,
 		recorder,
 		vca.clientSet)
 }
@@ -457,6 +494,15 @@ func (vca *VirtControllerApp) AddFlags() {
 	flag.IntVar(&vca.migrationControllerThreads, "migration-controller-threads", defaultControllerThreads,
 		"Number of goroutines to run for migration controller")
 
+	flag.IntVar(&vca.backupControllerThreads, "backup-controller-threads", defaultControllerThreads,
+		"Number of goroutines to run for backup controller")
+
+	flag.IntVar(&vca.exportControllerThreads, "export-controller-threads", defaultControllerThreads,
+		"Number of goroutines to run for export controller")
+
+	flag.IntVar(&vca.imageCacheControllerThreads, "image-cache-controller-threads", defaultControllerThreads,
+		"Number of goroutines to run for containerDisk image cache controller")
+
 	flag.IntVar(&vca.evacuationControllerThreads, "evacuation-controller-threads", defaultControllerThreads,
 		"Number of goroutines to run for evacuation controller")
 
@@ -0,0 +1,557 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2020 Red Hat, Inc.
+ *
+ */
+
+package watch
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"time"
+
+	k8sv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
+
+	virtv1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/client-go/kubecli"
+	"kubevirt.io/client-go/log"
+	"kubevirt.io/kubevirt/pkg/controller"
+	"kubevirt.io/kubevirt/pkg/virt-controller/services"
+	vmbackup "kubevirt.io/kubevirt/pkg/vm-backup"
+)
+
+// BackupController drives VirtualMachineBackups. It hands a backup to
+// virt-handler through the BackupState of the VMI, publishes the NBD exports
+// or copies them into a PVC once the backup job runs, and asks virt-handler to
+// finish the job once the exports were read.
+type BackupController struct {
+	templateService services.TemplateService
+	clientset       kubecli.KubevirtClient
+	Queue           workqueue.RateLimitingInterface
+	backupInformer  cache.SharedIndexInformer
+	vmiInformer     cache.SharedIndexInformer
+	podInformer     cache.SharedIndexInformer
+	recorder        record.EventRecorder
+	virtLibDir      string
+}
+
+func NewBackupController(templateService services.TemplateService,
+	backupInformer cache.SharedIndexInformer,
+	vmiInformer cache.SharedIndexInformer,
+	podInformer cache.SharedIndexInformer,
+	recorder record.EventRecorder,
+	clientset kubecli.KubevirtClient,
+	virtLibDir string,
+) *BackupController {
+
+	c := &BackupController{
+		templateService: templateService,
+		Queue:           workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter()),
+		backupInformer:  backupInformer,
+		vmiInformer:     vmiInformer,
+		podInformer:     podInformer,
+		recorder:        recorder,
+		clientset:       clientset,
+		virtLibDir:      virtLibDir,
+	}
+
+	c.backupInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    c.addBackup,
+		DeleteFunc: c.deleteBackup,
+		UpdateFunc: c.updateBackup,
+	})
+
+	c.vmiInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    c.addVMI,
+		DeleteFunc: c.deleteVMI,
+		UpdateFunc: c.updateVMI,
+	})
+
+	c.podInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    c.addPod,
+		DeleteFunc: c.deletePod,
+		UpdateFunc: c.updatePod,
+	})
+
+	return c
+}
+
+func (c *BackupController) Run(threadiness int, stopCh <-chan struct{}) {
+	defer controller.HandlePanic()
+	defer c.Queue.ShutDown()
+	log.Log.Info("Starting backup controller.")
+
+	// Wait for cache sync before we start the backup controller
+	cache.WaitForCacheSync(stopCh, c.backupInformer.HasSynced, c.vmiInformer.HasSynced, c.podInformer.HasSynced)
+
+	// Start the actual work
+	for i := 0; i < threadiness; i++ {
+		go wait.Until(c.runWorker, time.Second, stopCh)
+	}
+
+	<-stopCh
+	log.Log.Info("Stopping backup controller.")
+}
+
+func (c *BackupController) runWorker() {
+	for c.Execute() {
+	}
+}
+
+func (c *BackupController) Execute() bool {
+	key, quit := c.Queue.Get()
+	if quit {
+		return false
+	}
+	defer c.Queue.Done(key)
+	err := c.execute(key.(string))
+
+	if err != nil {
+		log.Log.Reason(err).Infof("reenqueuing Backup %v", key)
+		c.Queue.AddRateLimited(key)
+	} else {
+		log.Log.V(4).Infof("processed Backup %v", key)
+		c.Queue.Forget(key)
+	}
+	return true
+}
+
+func (c *BackupController) execute(key string) error {
+	obj, exists, err := c.backupInformer.GetStore().GetByKey(key)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		namespace, name, err := cache.SplitMetaNamespaceKey(key)
+		if err != nil {
+			return err
+		}
+		return c.releaseDeletedBackup(namespace, name)
+	}
+	backup := obj.(*virtv1.VirtualMachineBackup)
+
+	var vmi *virtv1.VirtualMachineInstance
+	vmiObj, vmiExists, err := c.vmiInformer.GetStore().GetByKey(backup.Namespace + "/" + backup.Spec.VMIName)
+	if err != nil {
+		return err
+	}
+	if vmiExists {
+		vmi = vmiObj.(*virtv1.VirtualMachineInstance)
+	}
+
+	backupCopy := backup.DeepCopy()
+	var syncErr error
+	if !backup.IsFinal() {
+		syncErr = c.sync(backupCopy, vmi)
+	}
+
+	if !reflect.DeepEqual(backup.Status, backupCopy.Status) {
+		if _, err := c.clientset.VirtualMachineBackup(backup.Namespace).Update(backupCopy); err != nil {
+			return err
+		}
+	}
+	if syncErr != nil {
+		return syncErr
+	}
+
+	// The backup job is done and recorded, free the VMI for the next backup
+	if backupCopy.IsFinal() && vmi != nil && ownsBackupState(backupCopy, vmi) && isBackupJobDone(vmi.Status.BackupState) {
+		return c.patchBackupState(vmi, nil)
+	}
+	return nil
+}
+
+// ownsBackupState returns true if the BackupState of the VMI belongs to the backup
+func ownsBackupState(backup *virtv1.VirtualMachineBackup, vmi *virtv1.VirtualMachineInstance) bool {
+	state := vmi.Status.BackupState
+	return state != nil && state.BackupName == backup.Name && state.Checkpoint == string(backup.UID)
+}
+
+func isBackupJobDone(state *virtv1.VirtualMachineInstanceBackupState) bool {
+	return state.Completed || state.Failed
+}
+
+func (c *BackupController) sync(backup *virtv1.VirtualMachineBackup, vmi *virtv1.VirtualMachineInstance) error {
+	if backup.Status.Phase == virtv1.BackupPhaseUnset {
+		backup.Status.Phase = virtv1.BackupPending
+	}
+
+	if vmi == nil {
+		c.failBackup(backup, fmt.Sprintf("VMI %s does not exist", backup.Spec.VMIName))
+		return nil
+	}
+	if vmi.IsFinal() || vmi.DeletionTimestamp != nil {
+		c.failBackup(backup, fmt.Sprintf("VMI %s is not running", vmi.Name))
+		return nil
+	}
+
+	if !ownsBackupState(backup, vmi) {
+		if backup.Status.Phase == virtv1.BackupRunning {
+			c.failBackup(backup, fmt.Sprintf("the backup state was removed from VMI %s", vmi.Name))
+			return nil
+		}
+		if vmi.Status.BackupState != nil || !vmi.IsRunning() {
+			// Wait for the earlier backup of the VMI or for the VMI to start
+			return nil
+		}
+		return c.startBackup(backup, vmi)
+	}
+
+	state := vmi.Status.BackupState
+	switch {
+	case state.Completed:
+		now := v1.Now()
+		backup.Status.Phase = virtv1.BackupSucceeded
+		backup.Status.CompletionTimestamp = &now
+		backup.Status.NBD = nil
+		c.recorder.Eventf(backup, k8sv1.EventTypeNormal, SuccessfulBackupReason, "Backup of VMI %s succeeded", vmi.Name)
+		return nil
+	case state.Failed:
+		message := state.Message
+		if backup.Status.Message != "" {
+			// Keep the reason the backup was aborted for
+			message = backup.Status.Message
+		}
+		c.failBackup(backup, message)
+		return nil
+	case state.StartTimestamp == nil:
+		// virt-handler did not start the backup job yet
+		return nil
+	}
+
+	if backup.Spec.Target.NBD != nil {
+		return c.syncNBDTarget(backup, vmi)
+	}
+	return c.syncPVCTarget(backup, vmi)
+}
+
+func (c *BackupController) failBackup(backup *virtv1.VirtualMachineBackup, message string) {
+	now := v1.Now()
+	backup.Status.Phase = virtv1.BackupFailed
+	backup.Status.CompletionTimestamp = &now
+	backup.Status.Message = message
+	backup.Status.NBD = nil
+	c.recorder.Eventf(backup, k8sv1.EventTypeWarning, FailedBackupReason, "Backup failed: %s", message)
+	log.Log.Object(backup).Errorf("Backup failed: %s", message)
+}
+
+// findBaseBackup returns the backup an incremental backup is based on
+func (c *BackupController) findBaseBackup(backup *virtv1.VirtualMachineBackup) (*virtv1.VirtualMachineBackup, error) {
+	objs, err := c.backupInformer.GetIndexer().ByIndex(cache.NamespaceIndex, backup.Namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	var base *virtv1.VirtualMachineBackup
+	for _, obj := range objs {
+		candidate := obj.(*virtv1.VirtualMachineBackup)
+		if candidate.Spec.VMIName != backup.Spec.VMIName ||
+			candidate.Status.Phase != virtv1.BackupSucceeded ||
+			candidate.Status.Checkpoint == "" ||
+			candidate.Status.CompletionTimestamp == nil {
+			continue
+		}
+		if backup.Spec.BaseBackupName != "" {
+			if candidate.Name == backup.Spec.BaseBackupName {
+				return candidate, nil
+			}
+			continue
+		}
+		if base == nil || base.Status.CompletionTimestamp.Before(candidate.Status.CompletionTimestamp) {
+			base = candidate
+		}
+	}
+	return base, nil
+}
+
+func (c *BackupController) startBackup(backup *virtv1.VirtualMachineBackup, vmi *virtv1.VirtualMachineInstance) error {
+	baseCheckpoint := ""
+	if backup.Spec.Mode == virtv1.BackupModeIncremental {
+		base, err := c.findBaseBackup(backup)
+		if err != nil {
+			return err
+		}
+		if base == nil {
+			c.failBackup(backup, fmt.Sprintf("no succeeded backup of VMI %s to base the incremental backup on", vmi.Name))
+			return nil
+		}
+		baseCheckpoint = base.Status.Checkpoint
+	}
+
+	state := &virtv1.VirtualMachineInstanceBackupState{
+		BackupName:     backup.Name,
+		Checkpoint:     string(backup.UID),
+		BaseCheckpoint: baseCheckpoint,
+	}
+	if err := c.patchBackupState(vmi, state); err != nil {
+		c.recorder.Eventf(backup, k8sv1.EventTypeWarning, FailedBackupReason, "Failed to hand the backup over to VMI %s: %v", vmi.Name, err)
+		return err
+	}
+
+	now := v1.Now()
+	backup.Status.Phase = virtv1.BackupRunning
+	backup.Status.Checkpoint = state.Checkpoint
+	backup.Status.BaseCheckpoint = state.BaseCheckpoint
+	backup.Status.StartTimestamp = &now
+	log.Log.Object(backup).Infof("Handed backup over to VMI %s", vmi.Name)
+	return nil
+}
+
+// syncNBDTarget publishes the exports of the backup job and finishes it once the backup application is done
+func (c *BackupController) syncNBDTarget(backup *virtv1.VirtualMachineBackup, vmi *virtv1.VirtualMachineInstance) error {
+	state := vmi.Status.BackupState
+	nbd := &virtv1.NBDBackupStatus{
+		Node:    vmi.Status.NodeName,
+		Socket:  vmbackup.SocketPath(vmbackup.GenerateHostDir(c.virtLibDir, vmi.UID)),
+		Exports: state.Volumes,
+	}
+	if state.BaseCheckpoint != "" {
+		nbd.DirtyBitmapContext = vmbackup.DirtyBitmapContext(state.Checkpoint)
+	}
+	backup.Status.NBD = nbd
+
+	if backup.Spec.Target.NBD.Done && !state.FinishRequested {
+		stateCopy := state.DeepCopy()
+		stateCopy.FinishRequested = true
+		return c.patchBackupState(vmi, stateCopy)
+	}
+	return nil
+}
+
+// syncPVCTarget copies the exports of the backup job into the PVC and finishes the job once they are copied
+func (c *BackupController) syncPVCTarget(backup *virtv1.VirtualMachineBackup, vmi *virtv1.VirtualMachineInstance) error {
+	state := vmi.Status.BackupState
+	if state.FinishRequested || state.AbortRequested {
+		return nil
+	}
+
+	pod, err := c.getBackupPod(backup)
+	if err != nil {
+		return err
+	}
+
+	if pod == nil {
+		pod, err = c.templateService.RenderBackupManifest(backup, vmi)
+		if err != nil {
+			return err
+		}
+		log.Log.Object(backup).Infof("Creating backup pod %s", pod.Name)
+		_, err = c.clientset.CoreV1().Pods(backup.Namespace).Create(pod)
+		if err != nil && !errors.IsAlreadyExists(err) {
+			c.recorder.Eventf(backup, k8sv1.EventTypeWarning, FailedCreatePodReason, "Error creating backup pod: %v", err)
+			return err
+		}
+		return nil
+	}
+
+	stateCopy := state.DeepCopy()
+	switch pod.Status.Phase {
+	case k8sv1.PodSucceeded:
+		stateCopy.FinishRequested = true
+	case k8sv1.PodFailed:
+		stateCopy.AbortRequested = true
+		backup.Status.Message = backupPodFailureMessage(pod)
+	default:
+		return nil
+	}
+	return c.patchBackupState(vmi, stateCopy)
+}
+
+// getBackupPod returns the backup pod of the VirtualMachineBackup from the cache, or nil if there is none
+func (c *BackupController) getBackupPod(backup *virtv1.VirtualMachineBackup) (*k8sv1.Pod, error) {
+	obj, exists, err := c.podInformer.GetStore().GetByKey(backup.Namespace + "/" + vmbackup.PodName(backup))
+	if err != nil || !exists {
+		return nil, err
+	}
+	pod := obj.(*k8sv1.Pod)
+	if !v1.IsControlledBy(pod, backup) {
+		return nil, nil
+	}
+	return pod, nil
+}
+
+func backupPodFailureMessage(pod *k8sv1.Pod) string {
+	for _, status := range pod.Status.ContainerStatuses {
+		if status.State.Terminated != nil && status.State.Terminated.ExitCode != 0 {
+			if status.State.Terminated.Message != "" {
+				return fmt.Sprintf("copying volume %s failed: %s", status.Name, status.State.Terminated.Message)
+			}
+			return fmt.Sprintf("copying volume %s failed", status.Name)
+		}
+	}
+	if pod.Status.Message != "" {
+		return pod.Status.Message
+	}
+	return "backup pod failed"
+}
+
+// releaseDeletedBackup aborts the backup job of a deleted backup and frees the VMI once the job is done
+func (c *BackupController) releaseDeletedBackup(namespace string, name string) error {
+	objs, err := c.vmiInformer.GetIndexer().ByIndex(cache.NamespaceIndex, namespace)
+	if err != nil {
+		return err
+	}
+	for _, obj := range objs {
+		vmi := obj.(*virtv1.VirtualMachineInstance)
+		state := vmi.Status.BackupState
+		if state == nil || state.BackupName != name {
+			continue
+		}
+		if isBackupJobDone(state) || state.StartTimestamp == nil {
+			// Nothing runs in virt-launcher which would have to be cleaned up
+			if err := c.patchBackupState(vmi, nil); err != nil {
+				return err
+			}
+			continue
+		}
+		if !state.AbortRequested && !state.FinishRequested {
+			stateCopy := state.DeepCopy()
+			stateCopy.AbortRequested = true
+			if err := c.patchBackupState(vmi, stateCopy); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// patchBackupState replaces the BackupState in the status of the VMI, as long as the status didn't change in the meantime
+func (c *BackupController) patchBackupState(vmi *virtv1.VirtualMachineInstance, state *virtv1.VirtualMachineInstanceBackupState) error {
+	vmiCopy := vmi.DeepCopy()
+	vmiCopy.Status.BackupState = state
+	if reflect.DeepEqual(vmi.Status, vmiCopy.Status) {
+		return nil
+	}
+
+	newStatus, err := json.Marshal(vmiCopy.Status)
+	if err != nil {
+		return err
+	}
+	oldStatus, err := json.Marshal(vmi.Status)
+	if err != nil {
+		return err
+	}
+	test := fmt.Sprintf(`{ "op": "test", "path": "/status", "value": %s }`, string(oldStatus))
+	patch := fmt.Sprintf(`{ "op": "replace", "path": "/status", "value": %s }`, string(newStatus))
+	_, err = c.clientset.VirtualMachineInstance(vmi.Namespace).Patch(vmi.Name, types.JSONPatchType, []byte(fmt.Sprintf("[ %s, %s ]", test, patch)))
+	if err != nil {
+		return fmt.Errorf("failed to set BackupState in VMI status: %v", err)
+	}
+	return nil
+}
+
+func (c *BackupController) addBackup(obj interface{}) {
+	c.enqueueBackup(obj)
+}
+
+func (c *BackupController) deleteBackup(obj interface{}) {
+	c.enqueueBackup(obj)
+}
+
+func (c *BackupController) updateBackup(old, curr interface{}) {
+	c.enqueueBackup(curr)
+}
+
+func (c *BackupController) enqueueBackup(obj interface{}) {
+	logger := log.Log
+	key, err := controller.KeyFunc(obj)
+	if err != nil {
+		logger.Reason(err).Error("Failed to extract key from backup.")
+		return
+	}
+	c.Queue.Add(key)
+}
+
+func (c *BackupController) addVMI(obj interface{}) {
+	c.enqueueBackupsOfVMI(obj.(*virtv1.VirtualMachineInstance))
+}
+
+func (c *BackupController) deleteVMI(obj interface{}) {
+	vmi, ok := obj.(*virtv1.VirtualMachineInstance)
+	if !ok {
+		tombstone, ok := obj.(cache.DeletedFinalStateUnknown)
+		if !ok {
+			return
+		}
+		vmi, ok = tombstone.Obj.(*virtv1.VirtualMachineInstance)
+		if !ok {
+			return
+		}
+	}
+	c.enqueueBackupsOfVMI(vmi)
+}
+
+func (c *BackupController) updateVMI(old, curr interface{}) {
+	c.enqueueBackupsOfVMI(curr.(*virtv1.VirtualMachineInstance))
+}
+
+// enqueueBackupsOfVMI wakes up the backup which runs on the VMI and the backups which wait for it
+func (c *BackupController) enqueueBackupsOfVMI(vmi *virtv1.VirtualMachineInstance) {
+	if state := vmi.Status.BackupState; state != nil {
+		c.Queue.Add(vmi.Namespace + "/" + state.BackupName)
+	}
+
+	objs, err := c.backupInformer.GetIndexer().ByIndex(cache.NamespaceIndex, vmi.Namespace)
+	if err != nil {
+		log.Log.Object(vmi).Reason(err).Error("Failed to list the backups of the VMI.")
+		return
+	}
+	for _, obj := range objs {
+		backup := obj.(*virtv1.VirtualMachineBackup)
+		if backup.Spec.VMIName == vmi.Name && !backup.IsFinal() {
+			c.enqueueBackup(backup)
+		}
+	}
+}
+
+func (c *BackupController) addPod(obj interface{}) {
+	c.enqueuePodBackup(obj)
+}
+
+func (c *BackupController) deletePod(obj interface{}) {
+	pod, ok := obj.(*k8sv1.Pod)
+	if !ok {
+		tombstone, ok := obj.(cache.DeletedFinalStateUnknown)
+		if !ok {
+			return
+		}
+		pod, ok = tombstone.Obj.(*k8sv1.Pod)
+		if !ok {
+			return
+		}
+	}
+	c.enqueuePodBackup(pod)
+}
+
+func (c *BackupController) updatePod(old, curr interface{}) {
+	c.enqueuePodBackup(curr)
+}
+
+func (c *BackupController) enqueuePodBackup(obj interface{}) {
+	pod := obj.(*k8sv1.Pod)
+	if name, ok := pod.Labels[vmbackup.BackupLabel]; ok {
+		c.Queue.Add(pod.Namespace + "/" + name)
+	}
+}
@@ -0,0 +1,360 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2020 Red Hat, Inc.
+ *
+ */
+
+package watch
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	k8sv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/client-go/kubecli"
+	"kubevirt.io/client-go/log"
+	"kubevirt.io/kubevirt/pkg/testutils"
+	"kubevirt.io/kubevirt/pkg/virt-controller/services"
+)
+
+var _ = Describe("Backup watcher", func() {
+	log.Log.SetIOWriter(GinkgoWriter)
+
+	var ctrl *gomock.Controller
+	var vmiInterface *kubecli.MockVirtualMachineInstanceInterface
+	var backupInterface *kubecli.MockVirtualMachineBackupInterface
+	var vmiInformer cache.SharedIndexInformer
+	var podInformer cache.SharedIndexInformer
+	var backupInformer cache.SharedIndexInformer
+	var controller *BackupController
+	var recorder *record.FakeRecorder
+	var virtClient *kubecli.MockKubevirtClient
+	var kubeClient *fake.Clientset
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		virtClient = kubecli.NewMockKubevirtClient(ctrl)
+		backupInterface = kubecli.NewMockVirtualMachineBackupInterface(ctrl)
+		vmiInterface = kubecli.NewMockVirtualMachineInstanceInterface(ctrl)
+
+		vmiInformer, _ = testutils.NewFakeInformerFor(&v1.VirtualMachineInstance{})
+		backupInformer, _ = testutils.NewFakeInformerFor(&v1.VirtualMachineBackup{})
+		podInformer, _ = testutils.NewFakeInformerFor(&k8sv1.Pod{})
+		recorder = record.NewFakeRecorder(100)
+
+		pvcInformer, _ := testutils.NewFakeInformerFor(&k8sv1.PersistentVolumeClaim{})
+		config, _, _ := testutils.NewFakeClusterConfig(&k8sv1.ConfigMap{})
+
+		controller = NewBackupController(
+			services.NewTemplateService("a", "b", "c", "d", "e", "f", pvcInformer.GetStore(), virtClient, config),
+			backupInformer,
+			vmiInformer,
+			podInformer,
+			recorder,
+			virtClient,
+			"/var/lib/kubevirt",
+		)
+
+		kubeClient = fake.NewSimpleClientset()
+		virtClient.EXPECT().VirtualMachineBackup(k8sv1.NamespaceDefault).Return(backupInterface).AnyTimes()
+		virtClient.EXPECT().VirtualMachineInstance(k8sv1.NamespaceDefault).Return(vmiInterface).AnyTimes()
+		virtClient.EXPECT().CoreV1().Return(kubeClient.CoreV1()).AnyTimes()
+
+		// Make sure that all unexpected calls to kubeClient will fail
+		kubeClient.Fake.PrependReactor("*", "*", func(action testing.Action) (handled bool, obj runtime.Object, err error) {
+			Expect(action).To(BeNil())
+			return true, nil, nil
+		})
+	})
+
+	AfterEach(func() {
+		// Ensure that we add checks for expected events to every test
+		Expect(recorder.Events).To(BeEmpty())
+		ctrl.Finish()
+	})
+
+	newBackup := func(name string, vmiName string, phase v1.VirtualMachineBackupPhase) *v1.VirtualMachineBackup {
+		return &v1.VirtualMachineBackup{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: k8sv1.NamespaceDefault,
+				UID:       types.UID(name + "-uid"),
+			},
+			Spec: v1.VirtualMachineBackupSpec{
+				VMIName: vmiName,
+				Target:  v1.VirtualMachineBackupTarget{NBD: &v1.NBDBackupTarget{}},
+			},
+			Status: v1.VirtualMachineBackupStatus{Phase: phase},
+		}
+	}
+
+	newRunningBackupState := func(backup *v1.VirtualMachineBackup) *v1.VirtualMachineInstanceBackupState {
+		now := metav1.Now()
+		return &v1.VirtualMachineInstanceBackupState{
+			BackupName:     backup.Name,
+			Checkpoint:     string(backup.UID),
+			StartTimestamp: &now,
+			Volumes:        []string{"rootdisk"},
+		}
+	}
+
+	execute := func(backup *v1.VirtualMachineBackup, vmi *v1.VirtualMachineInstance) {
+		Expect(backupInformer.GetStore().Add(backup)).To(Succeed())
+		if vmi != nil {
+			Expect(vmiInformer.GetStore().Add(vmi)).To(Succeed())
+		}
+		key, err := cache.MetaNamespaceKeyFunc(backup)
+		Expect(err).ToNot(HaveOccurred())
+		controller.Queue.Add(key)
+		controller.Execute()
+	}
+
+	expectStatusPatch := func(vmi *v1.VirtualMachineInstance, check func(status *v1.VirtualMachineInstanceStatus)) {
+		vmiInterface.EXPECT().Patch(vmi.Name, types.JSONPatchType, gomock.Any()).DoAndReturn(func(name string, _ types.PatchType, data []byte) (*v1.VirtualMachineInstance, error) {
+			var patch []struct {
+				Op    string                          `json:"op"`
+				Value v1.VirtualMachineInstanceStatus `json:"value"`
+			}
+			Expect(json.Unmarshal(data, &patch)).To(Succeed())
+			Expect(patch).To(HaveLen(2))
+			Expect(patch[0].Op).To(Equal("test"))
+			check(&patch[1].Value)
+			return vmi, nil
+		})
+	}
+
+	// expectBackupState expects the BackupState of the VMI to be patched and returns the patched state
+	expectBackupState := func(vmi *v1.VirtualMachineInstance) *v1.VirtualMachineInstanceBackupState {
+		state := &v1.VirtualMachineInstanceBackupState{}
+		expectStatusPatch(vmi, func(status *v1.VirtualMachineInstanceStatus) {
+			Expect(status.BackupState).ToNot(BeNil())
+			*state = *status.BackupState
+		})
+		return state
+	}
+
+	expectBackupStateCleared := func(vmi *v1.VirtualMachineInstance) {
+		expectStatusPatch(vmi, func(status *v1.VirtualMachineInstanceStatus) {
+			Expect(status.BackupState).To(BeNil())
+		})
+	}
+
+	expectBackupUpdate := func(phase v1.VirtualMachineBackupPhase) *v1.VirtualMachineBackup {
+		updated := &v1.VirtualMachineBackup{}
+		backupInterface.EXPECT().Update(gomock.Any()).DoAndReturn(func(backup *v1.VirtualMachineBackup) (*v1.VirtualMachineBackup, error) {
+			Expect(backup.Status.Phase).To(Equal(phase))
+			backup.DeepCopyInto(updated)
+			return backup, nil
+		})
+		return updated
+	}
+
+	Context("with a pending backup", func() {
+		It("should hand the backup over to the VMI", func() {
+			vmi := newVirtualMachine("testvmi", v1.Running)
+			backup := newBackup("testbackup", vmi.Name, v1.BackupPhaseUnset)
+
+			state := expectBackupState(vmi)
+			updated := expectBackupUpdate(v1.BackupRunning)
+			execute(backup, vmi)
+
+			Expect(state.BackupName).To(Equal("testbackup"))
+			Expect(state.Checkpoint).To(Equal("testbackup-uid"))
+			Expect(state.BaseCheckpoint).To(BeEmpty())
+			Expect(updated.Status.Checkpoint).To(Equal("testbackup-uid"))
+			Expect(updated.Status.StartTimestamp).ToNot(BeNil())
+		})
+
+		It("should base an incremental backup on the latest succeeded backup of the VMI", func() {
+			vmi := newVirtualMachine("testvmi", v1.Running)
+			for i, name := range []string{"older", "latest"} {
+				base := newBackup(name, vmi.Name, v1.BackupSucceeded)
+				base.Status.Checkpoint = name + "-uid"
+				completed := metav1.NewTime(time.Now().Add(time.Duration(i) * time.Minute))
+				base.Status.CompletionTimestamp = &completed
+				Expect(backupInformer.GetStore().Add(base)).To(Succeed())
+			}
+			backup := newBackup("testbackup", vmi.Name, v1.BackupPending)
+			backup.Spec.Mode = v1.BackupModeIncremental
+
+			state := expectBackupState(vmi)
+			updated := expectBackupUpdate(v1.BackupRunning)
+			execute(backup, vmi)
+
+			Expect(state.BaseCheckpoint).To(Equal("latest-uid"))
+			Expect(updated.Status.BaseCheckpoint).To(Equal("latest-uid"))
+		})
+
+		It("should fail an incremental backup without a base backup", func() {
+			vmi := newVirtualMachine("testvmi", v1.Running)
+			backup := newBackup("testbackup", vmi.Name, v1.BackupPending)
+			backup.Spec.Mode = v1.BackupModeIncremental
+
+			updated := expectBackupUpdate(v1.BackupFailed)
+			execute(backup, vmi)
+
+			Expect(updated.Status.Message).To(ContainSubstring("no succeeded backup"))
+			testutils.ExpectEvent(recorder, FailedBackupReason)
+		})
+
+		It("should wait for another backup of the VMI to finish", func() {
+			vmi := newVirtualMachine("testvmi", v1.Running)
+			vmi.Status.BackupState = newRunningBackupState(newBackup("other", vmi.Name, v1.BackupRunning))
+			backup := newBackup("testbackup", vmi.Name, v1.BackupPending)
+
+			execute(backup, vmi)
+		})
+
+		It("should fail if the VMI does not exist", func() {
+			backup := newBackup("testbackup", "testvmi", v1.BackupPending)
+
+			updated := expectBackupUpdate(v1.BackupFailed)
+			execute(backup, nil)
+
+			Expect(updated.Status.Message).To(Equal("VMI testvmi does not exist"))
+			testutils.ExpectEvent(recorder, FailedBackupReason)
+		})
+	})
+
+	Context("with a running backup", func() {
+		It("should publish the NBD exports", func() {
+			vmi := newVirtualMachine("testvmi", v1.Running)
+			backup := newBackup("testbackup", vmi.Name, v1.BackupRunning)
+			vmi.Status.BackupState = newRunningBackupState(backup)
+			vmi.Status.BackupState.BaseCheckpoint = "base"
+
+			updated := expectBackupUpdate(v1.BackupRunning)
+			execute(backup, vmi)
+
+			Expect(updated.Status.NBD).To(Equal(&v1.NBDBackupStatus{
+				Node:               vmi.Status.NodeName,
+				Socket:             "/var/lib/kubevirt/backups/testvmi/nbd.sock",
+				Exports:            []string{"rootdisk"},
+				DirtyBitmapContext: "qemu:dirty-bitmap:backup-testbackup-uid",
+			}))
+		})
+
+		It("should finish the backup job once the NBD exports are read", func() {
+			vmi := newVirtualMachine("testvmi", v1.Running)
+			backup := newBackup("testbackup", vmi.Name, v1.BackupRunning)
+			backup.Spec.Target.NBD.Done = true
+			vmi.Status.BackupState = newRunningBackupState(backup)
+
+			state := expectBackupState(vmi)
+			expectBackupUpdate(v1.BackupRunning)
+			execute(backup, vmi)
+
+			Expect(state.FinishRequested).To(BeTrue())
+		})
+
+		It("should create the backup pod for a PVC target", func() {
+			vmi := newVirtualMachine("testvmi", v1.Running)
+			backup := newBackup("testbackup", vmi.Name, v1.BackupRunning)
+			backup.Spec.Target = v1.VirtualMachineBackupTarget{
+				PersistentVolumeClaim: &k8sv1.PersistentVolumeClaimVolumeSource{ClaimName: "backup-claim"},
+			}
+			vmi.Status.BackupState = newRunningBackupState(backup)
+
+			created := false
+			kubeClient.Fake.PrependReactor("create", "pods", func(action testing.Action) (handled bool, obj runtime.Object, err error) {
+				pod := action.(testing.CreateAction).GetObject().(*k8sv1.Pod)
+				Expect(pod.Name).To(Equal("virt-backup-testbackup"))
+				created = true
+				return true, pod, nil
+			})
+			execute(backup, vmi)
+
+			Expect(created).To(BeTrue())
+		})
+
+		It("should abort the backup job if the backup pod failed", func() {
+			vmi := newVirtualMachine("testvmi", v1.Running)
+			backup := newBackup("testbackup", vmi.Name, v1.BackupRunning)
+			backup.Spec.Target = v1.VirtualMachineBackupTarget{
+				PersistentVolumeClaim: &k8sv1.PersistentVolumeClaimVolumeSource{ClaimName: "backup-claim"},
+			}
+			vmi.Status.BackupState = newRunningBackupState(backup)
+			pod, err := controller.templateService.RenderBackupManifest(backup, vmi)
+			Expect(err).ToNot(HaveOccurred())
+			pod.Status.Phase = k8sv1.PodFailed
+			pod.Status.ContainerStatuses = []k8sv1.ContainerStatus{{
+				Name:  "rootdisk",
+				State: k8sv1.ContainerState{Terminated: &k8sv1.ContainerStateTerminated{ExitCode: 1}},
+			}}
+			Expect(podInformer.GetStore().Add(pod)).To(Succeed())
+
+			state := expectBackupState(vmi)
+			updated := expectBackupUpdate(v1.BackupRunning)
+			execute(backup, vmi)
+
+			Expect(state.AbortRequested).To(BeTrue())
+			Expect(updated.Status.Message).To(Equal("copying volume rootdisk failed"))
+		})
+
+		It("should succeed and free the VMI once the backup job completed", func() {
+			vmi := newVirtualMachine("testvmi", v1.Running)
+			backup := newBackup("testbackup", vmi.Name, v1.BackupRunning)
+			vmi.Status.BackupState = newRunningBackupState(backup)
+			vmi.Status.BackupState.Completed = true
+
+			updated := expectBackupUpdate(v1.BackupSucceeded)
+			expectBackupStateCleared(vmi)
+			execute(backup, vmi)
+
+			Expect(updated.Status.CompletionTimestamp).ToNot(BeNil())
+			testutils.ExpectEvent(recorder, SuccessfulBackupReason)
+		})
+
+		It("should fail with the reason reported by the VMI", func() {
+			vmi := newVirtualMachine("testvmi", v1.Running)
+			backup := newBackup("testbackup", vmi.Name, v1.BackupRunning)
+			vmi.Status.BackupState = newRunningBackupState(backup)
+			vmi.Status.BackupState.Failed = true
+			vmi.Status.BackupState.Message = "bitmap not found"
+
+			updated := expectBackupUpdate(v1.BackupFailed)
+			expectBackupStateCleared(vmi)
+			execute(backup, vmi)
+
+			Expect(updated.Status.Message).To(Equal("bitmap not found"))
+			testutils.ExpectEvent(recorder, FailedBackupReason)
+		})
+	})
+
+	It("should abort the backup job of a deleted backup", func() {
+		vmi := newVirtualMachine("testvmi", v1.Running)
+		vmi.Status.BackupState = newRunningBackupState(newBackup("testbackup", vmi.Name, v1.BackupRunning))
+		Expect(vmiInformer.GetStore().Add(vmi)).To(Succeed())
+
+		state := expectBackupState(vmi)
+		controller.Queue.Add(k8sv1.NamespaceDefault + "/testbackup")
+		controller.Execute()
+
+		Expect(state.AbortRequested).To(BeTrue())
+	})
+})
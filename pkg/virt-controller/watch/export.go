@@ -0,0 +1,648 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2020 Red Hat, Inc.
+ *
+ */
+
+package watch
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/ghodss/yaml"
+	k8sv1 "k8s.io/api/core/v1"
+	extv1beta1 "k8s.io/api/extensions/v1beta1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/cert"
+	"k8s.io/client-go/util/workqueue"
+
+	virtv1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/client-go/kubecli"
+	"kubevirt.io/client-go/log"
+	"kubevirt.io/kubevirt/pkg/certificates/triple"
+	"kubevirt.io/kubevirt/pkg/controller"
+	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
+	"kubevirt.io/kubevirt/pkg/virt-controller/services"
+	vmexport "kubevirt.io/kubevirt/pkg/vm-export"
+)
+
+const (
+	exportCAName = "export.kubevirt.io"
+	// exportCertDuration is the validity of the exporter CA and server certificate
+	exportCertDuration = 365 * 24 * time.Hour
+	// ingressBackendProtocolAnnotation makes the nginx ingress controller talk HTTPS to the exporter
+	ingressBackendProtocolAnnotation = "nginx.ingress.kubernetes.io/backend-protocol"
+)
+
+// ExportController drives VirtualMachineExports. For every export it creates
+// an exporter pod serving the volumes of the source, a service in front of it,
+// a secret with the TLS certificate of the exporter and, if an ingress host is
+// configured, an ingress. All of them are owned by the export and go away with it.
+type ExportController struct {
+	templateService services.TemplateService
+	clientset       kubecli.KubevirtClient
+	clusterConfig   *virtconfig.ClusterConfig
+	Queue           workqueue.RateLimitingInterface
+	exportInformer  cache.SharedIndexInformer
+	vmInformer      cache.SharedIndexInformer
+	vmiInformer     cache.SharedIndexInformer
+	pvcInformer     cache.SharedIndexInformer
+	podInformer     cache.SharedIndexInformer
+	recorder        record.EventRecorder
+}
+
+func NewExportController(templateService services.TemplateService,
+	exportInformer cache.SharedIndexInformer,
+	vmInformer cache.SharedIndexInformer,
+	vmiInformer cache.SharedIndexInformer,
+	pvcInformer cache.SharedIndexInformer,
+	podInformer cache.SharedIndexInformer,
+	recorder record.EventRecorder,
+	clientset kubecli.KubevirtClient,
+	clusterConfig *virtconfig.ClusterConfig,
+) *ExportController {
+
+	c := &ExportController{
+		templateService: templateService,
+		Queue:           workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter()),
+		exportInformer:  exportInformer,
+		vmInformer:      vmInformer,
+		vmiInformer:     vmiInformer,
+		pvcInformer:     pvcInformer,
+		podInformer:     podInformer,
+		recorder:        recorder,
+		clientset:       clientset,
+		clusterConfig:   clusterConfig,
+	}
+
+	c.exportInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    c.addExport,
+		DeleteFunc: c.deleteExport,
+		UpdateFunc: c.updateExport,
+	})
+
+	sourceHandler := cache.ResourceEventHandlerFuncs{
+		AddFunc:    c.addSource,
+		DeleteFunc: c.deleteSource,
+		UpdateFunc: c.updateSource,
+	}
+	c.vmInformer.AddEventHandler(sourceHandler)
+	c.vmiInformer.AddEventHandler(sourceHandler)
+	c.pvcInformer.AddEventHandler(sourceHandler)
+
+	c.podInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    c.addPod,
+		DeleteFunc: c.deletePod,
+		UpdateFunc: c.updatePod,
+	})
+
+	return c
+}
+
+func (c *ExportController) Run(threadiness int, stopCh <-chan struct{}) {
+	defer controller.HandlePanic()
+	defer c.Queue.ShutDown()
+	log.Log.Info("Starting export controller.")
+
+	// Wait for cache sync before we start the export controller
+	cache.WaitForCacheSync(stopCh, c.exportInformer.HasSynced, c.vmInformer.HasSynced, c.vmiInformer.HasSynced,
+		c.pvcInformer.HasSynced, c.podInformer.HasSynced)
+
+	// Start the actual work
+	for i := 0; i < threadiness; i++ {
+		go wait.Until(c.runWorker, time.Second, stopCh)
+	}
+
+	<-stopCh
+	log.Log.Info("Stopping export controller.")
+}
+
+func (c *ExportController) runWorker() {
+	for c.Execute() {
+	}
+}
+
+func (c *ExportController) Execute() bool {
+	key, quit := c.Queue.Get()
+	if quit {
+		return false
+	}
+	defer c.Queue.Done(key)
+	err := c.execute(key.(string))
+
+	if err != nil {
+		log.Log.Reason(err).Infof("reenqueuing Export %v", key)
+		c.Queue.AddRateLimited(key)
+	} else {
+		log.Log.V(4).Infof("processed Export %v", key)
+		c.Queue.Forget(key)
+	}
+	return true
+}
+
+func (c *ExportController) execute(key string) error {
+	obj, exists, err := c.exportInformer.GetStore().GetByKey(key)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		// The exporter is garbage collected through its owner references
+		return nil
+	}
+	export := obj.(*virtv1.VirtualMachineExport)
+	if export.DeletionTimestamp != nil {
+		return nil
+	}
+
+	exportCopy := export.DeepCopy()
+	syncErr := c.sync(exportCopy)
+
+	if !reflect.DeepEqual(export.Status, exportCopy.Status) {
+		if _, err := c.clientset.VirtualMachineExport(export.Namespace).Update(exportCopy); err != nil {
+			return err
+		}
+	}
+	return syncErr
+}
+
+func (c *ExportController) sync(export *virtv1.VirtualMachineExport) error {
+	if export.Status.Phase == virtv1.ExportPhaseUnset {
+		export.Status.Phase = virtv1.ExportPending
+	}
+
+	volumes, manifest, notReadyMessage, err := c.getSourceVolumes(export)
+	if err != nil {
+		return err
+	}
+
+	pod, err := c.getExporterPod(export)
+	if err != nil {
+		return err
+	}
+
+	if notReadyMessage != "" {
+		// The source may have become busy after the exporter was created, e.g. because
+		// the VirtualMachine was started. Its volumes are no longer consistent then and
+		// the exporter must not keep them mounted.
+		if pod != nil {
+			if err := c.deleteExporterPod(export, pod); err != nil {
+				return err
+			}
+		}
+		setExportPending(export, notReadyMessage)
+		return nil
+	}
+
+	if pod == nil {
+		caCert, err := c.createExporter(export, volumes, manifest)
+		if err != nil {
+			return err
+		}
+		export.Status.ServiceName = vmexport.Name(export)
+		export.Status.Links = c.generateLinks(export, volumes, manifest != nil, caCert)
+		setExportPending(export, "waiting for the exporter pod to become ready")
+		return nil
+	}
+
+	if export.Status.Links == nil {
+		// The links were lost, e.g. because the status update after creating the exporter failed
+		caCert, err := c.getExporterCACert(export)
+		if err != nil {
+			return err
+		}
+		export.Status.ServiceName = vmexport.Name(export)
+		export.Status.Links = c.generateLinks(export, volumes, manifest != nil, caCert)
+	}
+
+	if !isExporterReady(pod) {
+		setExportPending(export, "waiting for the exporter pod to become ready")
+		return nil
+	}
+	if export.Status.Phase != virtv1.ExportReady {
+		c.recorder.Eventf(export, k8sv1.EventTypeNormal, ExportReadyReason, "Exporter %s is ready", pod.Name)
+	}
+	export.Status.Phase = virtv1.ExportReady
+	export.Status.Message = ""
+	return nil
+}
+
+func setExportPending(export *virtv1.VirtualMachineExport, message string) {
+	export.Status.Phase = virtv1.ExportPending
+	export.Status.Message = message
+}
+
+func isExporterReady(pod *k8sv1.Pod) bool {
+	if pod.DeletionTimestamp != nil || pod.Status.Phase != k8sv1.PodRunning {
+		return false
+	}
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == k8sv1.PodReady {
+			return condition.Status == k8sv1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// getSourceVolumes returns the volumes to export and, for VirtualMachine sources, the manifest of the
+// VirtualMachine. If the source can't be exported right now, a message explaining why is returned.
+func (c *ExportController) getSourceVolumes(export *virtv1.VirtualMachineExport) ([]vmexport.Volume, []byte, string, error) {
+	source := export.Spec.Source
+	switch source.Kind {
+	case "VirtualMachine":
+		return c.getVMVolumes(export.Namespace, source.Name)
+	case "PersistentVolumeClaim":
+		volumes := []vmexport.Volume{{Name: source.Name, ClaimName: source.Name}}
+		message, err := c.checkPVCs(export.Namespace, volumes)
+		return volumes, nil, message, err
+	}
+	return nil, nil, fmt.Sprintf("unsupported source kind %s", source.Kind), nil
+}
+
+func (c *ExportController) getVMVolumes(namespace string, name string) ([]vmexport.Volume, []byte, string, error) {
+	obj, exists, err := c.vmInformer.GetStore().GetByKey(namespace + "/" + name)
+	if err != nil {
+		return nil, nil, "", err
+	}
+	if !exists {
+		return nil, nil, fmt.Sprintf("VirtualMachine %s does not exist", name), nil
+	}
+	vm := obj.(*virtv1.VirtualMachine)
+
+	var volumes []vmexport.Volume
+	for _, volume := range vm.Spec.Template.Spec.Volumes {
+		switch {
+		case volume.PersistentVolumeClaim != nil:
+			volumes = append(volumes, vmexport.Volume{Name: volume.Name, ClaimName: volume.PersistentVolumeClaim.ClaimName})
+		case volume.DataVolume != nil:
+			volumes = append(volumes, vmexport.Volume{Name: volume.Name, ClaimName: volume.DataVolume.Name})
+		}
+	}
+
+	manifest, err := vmManifest(vm)
+	if err != nil {
+		return nil, nil, "", err
+	}
+
+	_, running, err := c.vmiInformer.GetStore().GetByKey(namespace + "/" + name)
+	if err != nil {
+		return nil, nil, "", err
+	}
+	if running {
+		// The volumes of a running VirtualMachine are in use and not consistent
+		return volumes, manifest, fmt.Sprintf("VirtualMachine %s is running", name), nil
+	}
+
+	message, err := c.checkPVCs(namespace, volumes)
+	return volumes, manifest, message, err
+}
+
+func (c *ExportController) checkPVCs(namespace string, volumes []vmexport.Volume) (string, error) {
+	for _, volume := range volumes {
+		_, exists, err := c.pvcInformer.GetStore().GetByKey(namespace + "/" + volume.ClaimName)
+		if err != nil {
+			return "", err
+		}
+		if !exists {
+			return fmt.Sprintf("PersistentVolumeClaim %s does not exist", volume.ClaimName), nil
+		}
+	}
+	return "", nil
+}
+
+// vmManifest returns the manifest to recreate the VirtualMachine from, without any cluster specific metadata and status
+func vmManifest(vm *virtv1.VirtualMachine) ([]byte, error) {
+	manifest := &virtv1.VirtualMachine{
+		TypeMeta: v1.TypeMeta{
+			APIVersion: virtv1.VirtualMachineGroupVersionKind.GroupVersion().String(),
+			Kind:       virtv1.VirtualMachineGroupVersionKind.Kind,
+		},
+		ObjectMeta: v1.ObjectMeta{
+			Name:        vm.Name,
+			Labels:      vm.Labels,
+			Annotations: vm.Annotations,
+		},
+		Spec: vm.Spec,
+	}
+	return yaml.Marshal(manifest)
+}
+
+// createExporter creates the secret, service, ingress and pod of the exporter and returns the CA certificate clients verify the exporter with
+func (c *ExportController) createExporter(export *virtv1.VirtualMachineExport, volumes []vmexport.Volume, manifest []byte) (string, error) {
+	caCert, err := c.ensureExporterSecret(export, manifest)
+	if err != nil {
+		c.recorder.Eventf(export, k8sv1.EventTypeWarning, FailedExportReason, "Error creating exporter secret: %v", err)
+		return "", err
+	}
+
+	if err := c.createExporterService(export); err != nil {
+		c.recorder.Eventf(export, k8sv1.EventTypeWarning, FailedExportReason, "Error creating exporter service: %v", err)
+		return "", err
+	}
+
+	if host := c.clusterConfig.GetExportIngressHost(); host != "" {
+		if err := c.createExporterIngress(export, host); err != nil {
+			c.recorder.Eventf(export, k8sv1.EventTypeWarning, FailedExportReason, "Error creating exporter ingress: %v", err)
+			return "", err
+		}
+	}
+
+	pod, err := c.templateService.RenderExportManifest(export, volumes, manifest != nil)
+	if err != nil {
+		return "", err
+	}
+	log.Log.Object(export).Infof("Creating exporter pod %s", pod.Name)
+	if _, err := c.clientset.CoreV1().Pods(export.Namespace).Create(pod); err != nil && !errors.IsAlreadyExists(err) {
+		c.recorder.Eventf(export, k8sv1.EventTypeWarning, FailedCreatePodReason, "Error creating exporter pod: %v", err)
+		return "", err
+	}
+	c.recorder.Eventf(export, k8sv1.EventTypeNormal, SuccessfulCreatePodReason, "Created exporter pod %s", pod.Name)
+	return caCert, nil
+}
+
+// deleteExporterPod deletes the exporter pod, the export recreates it once the source can be exported again
+func (c *ExportController) deleteExporterPod(export *virtv1.VirtualMachineExport, pod *k8sv1.Pod) error {
+	if pod.DeletionTimestamp != nil {
+		return nil
+	}
+	log.Log.Object(export).Infof("Deleting exporter pod %s", pod.Name)
+	if err := c.clientset.CoreV1().Pods(export.Namespace).Delete(pod.Name, &v1.DeleteOptions{}); err != nil && !errors.IsNotFound(err) {
+		c.recorder.Eventf(export, k8sv1.EventTypeWarning, FailedDeletePodReason, "Error deleting exporter pod: %v", err)
+		return err
+	}
+	c.recorder.Eventf(export, k8sv1.EventTypeNormal, SuccessfulDeletePodReason, "Deleted exporter pod %s", pod.Name)
+	return nil
+}
+
+func exporterObjectMeta(export *virtv1.VirtualMachineExport) v1.ObjectMeta {
+	return v1.ObjectMeta{
+		Name:      vmexport.Name(export),
+		Namespace: export.Namespace,
+		Labels: map[string]string{
+			vmexport.ExportLabel: export.Name,
+		},
+		OwnerReferences: []v1.OwnerReference{
+			*v1.NewControllerRef(export, virtv1.VirtualMachineExportGroupVersionKind),
+		},
+	}
+}
+
+// ensureExporterSecret creates the secret with the TLS certificate of the exporter, unless it exists already
+func (c *ExportController) ensureExporterSecret(export *virtv1.VirtualMachineExport, manifest []byte) (string, error) {
+	caCert, err := c.getExporterCACert(export)
+	if err == nil || !errors.IsNotFound(err) {
+		return caCert, err
+	}
+
+	ca, err := triple.NewCA(exportCAName, exportCertDuration)
+	if err != nil {
+		return "", err
+	}
+	var hostnames []string
+	if host := c.clusterConfig.GetExportIngressHost(); host != "" {
+		hostnames = append(hostnames, host)
+	}
+	keyPair, err := triple.NewServerKeyPair(ca, vmexport.ServiceHost(export), vmexport.Name(export), export.Namespace, "cluster.local", nil, hostnames, exportCertDuration)
+	if err != nil {
+		return "", err
+	}
+
+	secret := &k8sv1.Secret{
+		ObjectMeta: exporterObjectMeta(export),
+		Type:       k8sv1.SecretTypeTLS,
+		Data: map[string][]byte{
+			vmexport.CertKey: cert.EncodeCertPEM(keyPair.Cert),
+			vmexport.KeyKey:  cert.EncodePrivateKeyPEM(keyPair.Key),
+			vmexport.CAKey:   cert.EncodeCertPEM(ca.Cert),
+		},
+	}
+	if manifest != nil {
+		secret.Data[vmexport.ManifestKey] = manifest
+	}
+
+	_, err = c.clientset.CoreV1().Secrets(export.Namespace).Create(secret)
+	if errors.IsAlreadyExists(err) {
+		return c.getExporterCACert(export)
+	}
+	if err != nil {
+		return "", err
+	}
+	return string(secret.Data[vmexport.CAKey]), nil
+}
+
+// getExporterCACert returns the CA certificate from the secret of the exporter
+func (c *ExportController) getExporterCACert(export *virtv1.VirtualMachineExport) (string, error) {
+	secret, err := c.clientset.CoreV1().Secrets(export.Namespace).Get(vmexport.Name(export), v1.GetOptions{})
+	if err != nil {
+		return "", err
+	}
+	return string(secret.Data[vmexport.CAKey]), nil
+}
+
+func (c *ExportController) createExporterService(export *virtv1.VirtualMachineExport) error {
+	service := &k8sv1.Service{
+		ObjectMeta: exporterObjectMeta(export),
+		Spec: k8sv1.ServiceSpec{
+			Selector: map[string]string{
+				vmexport.ExportLabel: export.Name,
+			},
+			Ports: []k8sv1.ServicePort{
+				{
+					Name:       "export",
+					Port:       vmexport.ServicePort,
+					TargetPort: intstr.FromInt(vmexport.Port),
+					Protocol:   k8sv1.ProtocolTCP,
+				},
+			},
+		},
+	}
+	_, err := c.clientset.CoreV1().Services(export.Namespace).Create(service)
+	if err != nil && !errors.IsAlreadyExists(err) {
+		return err
+	}
+	return nil
+}
+
+func (c *ExportController) createExporterIngress(export *virtv1.VirtualMachineExport, host string) error {
+	ingress := &extv1beta1.Ingress{
+		ObjectMeta: exporterObjectMeta(export),
+		Spec: extv1beta1.IngressSpec{
+			Rules: []extv1beta1.IngressRule{
+				{
+					Host: host,
+					IngressRuleValue: extv1beta1.IngressRuleValue{
+						HTTP: &extv1beta1.HTTPIngressRuleValue{
+							Paths: []extv1beta1.HTTPIngressPath{
+								{
+									Path: vmexport.PathPrefix(export),
+									Backend: extv1beta1.IngressBackend{
+										ServiceName: vmexport.Name(export),
+										ServicePort: intstr.FromInt(vmexport.ServicePort),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	ingress.Annotations = map[string]string{
+		ingressBackendProtocolAnnotation: "HTTPS",
+	}
+	_, err := c.clientset.ExtensionsV1beta1().Ingresses(export.Namespace).Create(ingress)
+	if err != nil && !errors.IsAlreadyExists(err) {
+		return err
+	}
+	return nil
+}
+
+// generateLinks returns the links of the exporter inside the cluster and, if an ingress host is configured, from outside of the cluster
+func (c *ExportController) generateLinks(export *virtv1.VirtualMachineExport, volumes []vmexport.Volume, withManifest bool, caCert string) *virtv1.VirtualMachineExportLinks {
+	links := &virtv1.VirtualMachineExportLinks{
+		Internal: vmexport.GenerateLink(export, "https://"+vmexport.ServiceHost(export), caCert, volumes, withManifest),
+	}
+	if host := c.clusterConfig.GetExportIngressHost(); host != "" {
+		// The ingress terminates TLS with its own certificate
+		links.External = vmexport.GenerateLink(export, "https://"+host, "", volumes, withManifest)
+	}
+	return links
+}
+
+// getExporterPod returns the exporter pod of the VirtualMachineExport from the cache, or nil if there is none
+func (c *ExportController) getExporterPod(export *virtv1.VirtualMachineExport) (*k8sv1.Pod, error) {
+	obj, exists, err := c.podInformer.GetStore().GetByKey(export.Namespace + "/" + vmexport.Name(export))
+	if err != nil || !exists {
+		return nil, err
+	}
+	pod := obj.(*k8sv1.Pod)
+	if !v1.IsControlledBy(pod, export) {
+		return nil, nil
+	}
+	return pod, nil
+}
+
+func (c *ExportController) addExport(obj interface{}) {
+	c.enqueueExport(obj)
+}
+
+func (c *ExportController) deleteExport(obj interface{}) {
+	c.enqueueExport(obj)
+}
+
+func (c *ExportController) updateExport(old, curr interface{}) {
+	c.enqueueExport(curr)
+}
+
+func (c *ExportController) enqueueExport(obj interface{}) {
+	logger := log.Log
+	key, err := controller.KeyFunc(obj)
+	if err != nil {
+		logger.Reason(err).Error("Failed to extract key from export.")
+		return
+	}
+	c.Queue.Add(key)
+}
+
+func (c *ExportController) addSource(obj interface{}) {
+	c.enqueueExportsOfSource(obj)
+}
+
+func (c *ExportController) deleteSource(obj interface{}) {
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+	c.enqueueExportsOfSource(obj)
+}
+
+func (c *ExportController) updateSource(old, curr interface{}) {
+	c.enqueueExportsOfSource(curr)
+}
+
+// enqueueExportsOfSource wakes up the exports of a VirtualMachine, its VMI or a PVC. Pending exports
+// may be able to proceed and ready exports may have to stop, e.g. when the VirtualMachine was started.
+func (c *ExportController) enqueueExportsOfSource(obj interface{}) {
+	var namespace string
+	var matches func(source k8sv1.TypedLocalObjectReference) bool
+	switch s := obj.(type) {
+	case *virtv1.VirtualMachine:
+		namespace = s.Namespace
+		matches = func(source k8sv1.TypedLocalObjectReference) bool {
+			return source.Kind == "VirtualMachine" && source.Name == s.Name
+		}
+	case *virtv1.VirtualMachineInstance:
+		namespace = s.Namespace
+		matches = func(source k8sv1.TypedLocalObjectReference) bool {
+			return source.Kind == "VirtualMachine" && source.Name == s.Name
+		}
+	case *k8sv1.PersistentVolumeClaim:
+		// The PVC may be a volume of any exported VirtualMachine as well
+		namespace = s.Namespace
+		matches = func(source k8sv1.TypedLocalObjectReference) bool {
+			return source.Kind == "VirtualMachine" || source.Name == s.Name
+		}
+	default:
+		return
+	}
+
+	objs, err := c.exportInformer.GetIndexer().ByIndex(cache.NamespaceIndex, namespace)
+	if err != nil {
+		log.Log.Reason(err).Errorf("Failed to list the exports of namespace %s.", namespace)
+		return
+	}
+	for _, obj := range objs {
+		export := obj.(*virtv1.VirtualMachineExport)
+		if matches(export.Spec.Source) {
+			c.enqueueExport(export)
+		}
+	}
+}
+
+func (c *ExportController) addPod(obj interface{}) {
+	c.enqueuePodExport(obj)
+}
+
+func (c *ExportController) deletePod(obj interface{}) {
+	pod, ok := obj.(*k8sv1.Pod)
+	if !ok {
+		tombstone, ok := obj.(cache.DeletedFinalStateUnknown)
+		if !ok {
+			return
+		}
+		pod, ok = tombstone.Obj.(*k8sv1.Pod)
+		if !ok {
+			return
+		}
+	}
+	c.enqueuePodExport(pod)
+}
+
+func (c *ExportController) updatePod(old, curr interface{}) {
+	c.enqueuePodExport(curr)
+}
+
+func (c *ExportController) enqueuePodExport(obj interface{}) {
+	pod := obj.(*k8sv1.Pod)
+	if name, ok := pod.Labels[vmexport.ExportLabel]; ok {
+		c.Queue.Add(pod.Namespace + "/" + name)
+	}
+}
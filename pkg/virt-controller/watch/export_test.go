@@ -0,0 +1,454 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2020 Red Hat, Inc.
+ *
+ */
+
+package watch
+
+import (
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	k8sv1 "k8s.io/api/core/v1"
+	extv1beta1 "k8s.io/api/extensions/v1beta1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/client-go/kubecli"
+	"kubevirt.io/client-go/log"
+	"kubevirt.io/kubevirt/pkg/testutils"
+	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
+	"kubevirt.io/kubevirt/pkg/virt-controller/services"
+	vmexport "kubevirt.io/kubevirt/pkg/vm-export"
+)
+
+var _ = Describe("Export watcher", func() {
+	log.Log.SetIOWriter(GinkgoWriter)
+
+	var ctrl *gomock.Controller
+	var exportInterface *kubecli.MockVirtualMachineExportInterface
+	var exportInformer cache.SharedIndexInformer
+	var vmInformer cache.SharedIndexInformer
+	var vmiInformer cache.SharedIndexInformer
+	var pvcInformer cache.SharedIndexInformer
+	var podInformer cache.SharedIndexInformer
+	var controller *ExportController
+	var recorder *record.FakeRecorder
+	var virtClient *kubecli.MockKubevirtClient
+	var kubeClient *fake.Clientset
+
+	initController := func(configMap *k8sv1.ConfigMap) {
+		config, _, _ := testutils.NewFakeClusterConfig(configMap)
+		controller = NewExportController(
+			services.NewTemplateService("a", "b", "c", "d", "e", "f", pvcInformer.GetStore(), virtClient, config),
+			exportInformer,
+			vmInformer,
+			vmiInformer,
+			pvcInformer,
+			podInformer,
+			recorder,
+			virtClient,
+			config,
+		)
+	}
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		virtClient = kubecli.NewMockKubevirtClient(ctrl)
+		exportInterface = kubecli.NewMockVirtualMachineExportInterface(ctrl)
+
+		exportInformer, _ = testutils.NewFakeInformerFor(&v1.VirtualMachineExport{})
+		vmInformer, _ = testutils.NewFakeInformerFor(&v1.VirtualMachine{})
+		vmiInformer, _ = testutils.NewFakeInformerFor(&v1.VirtualMachineInstance{})
+		pvcInformer, _ = testutils.NewFakeInformerFor(&k8sv1.PersistentVolumeClaim{})
+		podInformer, _ = testutils.NewFakeInformerFor(&k8sv1.Pod{})
+		recorder = record.NewFakeRecorder(100)
+
+		initController(&k8sv1.ConfigMap{})
+
+		kubeClient = fake.NewSimpleClientset()
+		virtClient.EXPECT().VirtualMachineExport(k8sv1.NamespaceDefault).Return(exportInterface).AnyTimes()
+		virtClient.EXPECT().CoreV1().Return(kubeClient.CoreV1()).AnyTimes()
+		virtClient.EXPECT().ExtensionsV1beta1().Return(kubeClient.ExtensionsV1beta1()).AnyTimes()
+
+		// Make sure that all unexpected calls to kubeClient will fail
+		kubeClient.Fake.PrependReactor("*", "*", func(action testing.Action) (handled bool, obj runtime.Object, err error) {
+			Expect(action).To(BeNil())
+			return true, nil, nil
+		})
+	})
+
+	AfterEach(func() {
+		// Ensure that we add checks for expected events to every test
+		Expect(recorder.Events).To(BeEmpty())
+		ctrl.Finish()
+	})
+
+	newExport := func(kind string, name string) *v1.VirtualMachineExport {
+		export := &v1.VirtualMachineExport{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "export",
+				Namespace: k8sv1.NamespaceDefault,
+				UID:       types.UID("export-uid"),
+			},
+			Spec: v1.VirtualMachineExportSpec{
+				Source: k8sv1.TypedLocalObjectReference{
+					Kind: kind,
+					Name: name,
+				},
+				TokenSecretRef: "token",
+			},
+		}
+		if kind == "VirtualMachine" {
+			apiGroup := v1.GroupName
+			export.Spec.Source.APIGroup = &apiGroup
+		}
+		return export
+	}
+
+	newPVC := func(name string) *k8sv1.PersistentVolumeClaim {
+		return &k8sv1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: k8sv1.NamespaceDefault,
+			},
+		}
+	}
+
+	newVM := func(name string) *v1.VirtualMachine {
+		vm := &v1.VirtualMachine{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: k8sv1.NamespaceDefault,
+				Labels:    map[string]string{"app": "test"},
+				UID:       types.UID("testvm-uid"),
+			},
+			Spec: v1.VirtualMachineSpec{
+				Template: &v1.VirtualMachineInstanceTemplateSpec{},
+			},
+		}
+		vm.Spec.Template.Spec.Volumes = []v1.Volume{
+			{
+				Name: "rootdisk",
+				VolumeSource: v1.VolumeSource{
+					PersistentVolumeClaim: &k8sv1.PersistentVolumeClaimVolumeSource{ClaimName: "root-pvc"},
+				},
+			},
+			{
+				Name: "datadisk",
+				VolumeSource: v1.VolumeSource{
+					DataVolume: &v1.DataVolumeSource{Name: "data-dv"},
+				},
+			},
+			{
+				Name: "cloudinit",
+				VolumeSource: v1.VolumeSource{
+					CloudInitNoCloud: &v1.CloudInitNoCloudSource{UserData: "#cloud-config"},
+				},
+			},
+		}
+		return vm
+	}
+
+	newExporterPod := func(export *v1.VirtualMachineExport, ready bool) *k8sv1.Pod {
+		status := k8sv1.ConditionFalse
+		if ready {
+			status = k8sv1.ConditionTrue
+		}
+		return &k8sv1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      vmexport.Name(export),
+				Namespace: export.Namespace,
+				Labels:    map[string]string{vmexport.ExportLabel: export.Name},
+				OwnerReferences: []metav1.OwnerReference{
+					*metav1.NewControllerRef(export, v1.VirtualMachineExportGroupVersionKind),
+				},
+			},
+			Status: k8sv1.PodStatus{
+				Phase:      k8sv1.PodRunning,
+				Conditions: []k8sv1.PodCondition{{Type: k8sv1.PodReady, Status: status}},
+			},
+		}
+	}
+
+	execute := func(export *v1.VirtualMachineExport) {
+		Expect(exportInformer.GetStore().Add(export)).To(Succeed())
+		key, err := cache.MetaNamespaceKeyFunc(export)
+		Expect(err).ToNot(HaveOccurred())
+		controller.Queue.Add(key)
+		controller.Execute()
+	}
+
+	expectStatusUpdate := func(check func(status *v1.VirtualMachineExportStatus)) {
+		exportInterface.EXPECT().Update(gomock.Any()).DoAndReturn(func(export *v1.VirtualMachineExport) (*v1.VirtualMachineExport, error) {
+			check(&export.Status)
+			return export, nil
+		})
+	}
+
+	// expectExporterCreation makes the fake client accept the exporter objects and returns what was created
+	expectExporterCreation := func() (*k8sv1.Secret, *k8sv1.Service, *k8sv1.Pod, *extv1beta1.Ingress) {
+		secret := &k8sv1.Secret{}
+		service := &k8sv1.Service{}
+		pod := &k8sv1.Pod{}
+		ingress := &extv1beta1.Ingress{}
+		kubeClient.Fake.PrependReactor("get", "secrets", func(action testing.Action) (handled bool, obj runtime.Object, err error) {
+			return true, nil, errors.NewNotFound(k8sv1.Resource("secrets"), action.(testing.GetAction).GetName())
+		})
+		kubeClient.Fake.PrependReactor("create", "secrets", func(action testing.Action) (handled bool, obj runtime.Object, err error) {
+			action.(testing.CreateAction).GetObject().(*k8sv1.Secret).DeepCopyInto(secret)
+			return true, secret, nil
+		})
+		kubeClient.Fake.PrependReactor("create", "services", func(action testing.Action) (handled bool, obj runtime.Object, err error) {
+			action.(testing.CreateAction).GetObject().(*k8sv1.Service).DeepCopyInto(service)
+			return true, service, nil
+		})
+		kubeClient.Fake.PrependReactor("create", "pods", func(action testing.Action) (handled bool, obj runtime.Object, err error) {
+			action.(testing.CreateAction).GetObject().(*k8sv1.Pod).DeepCopyInto(pod)
+			return true, pod, nil
+		})
+		kubeClient.Fake.PrependReactor("create", "ingresses", func(action testing.Action) (handled bool, obj runtime.Object, err error) {
+			action.(testing.CreateAction).GetObject().(*extv1beta1.Ingress).DeepCopyInto(ingress)
+			return true, ingress, nil
+		})
+		return secret, service, pod, ingress
+	}
+
+	Context("with a PersistentVolumeClaim source", func() {
+		It("should wait for the PVC to exist", func() {
+			export := newExport("PersistentVolumeClaim", "disk")
+			expectStatusUpdate(func(status *v1.VirtualMachineExportStatus) {
+				Expect(status.Phase).To(Equal(v1.ExportPending))
+				Expect(status.Message).To(Equal("PersistentVolumeClaim disk does not exist"))
+				Expect(status.Links).To(BeNil())
+			})
+
+			execute(export)
+		})
+
+		It("should create the exporter and publish the internal links", func() {
+			export := newExport("PersistentVolumeClaim", "disk")
+			Expect(pvcInformer.GetStore().Add(newPVC("disk"))).To(Succeed())
+			secret, service, pod, _ := expectExporterCreation()
+			expectStatusUpdate(func(status *v1.VirtualMachineExportStatus) {
+				Expect(status.Phase).To(Equal(v1.ExportPending))
+				Expect(status.ServiceName).To(Equal("virt-export-export"))
+				Expect(status.Links.External).To(BeNil())
+				internal := status.Links.Internal
+				Expect(internal.Cert).To(Equal(string(secret.Data[vmexport.CAKey])))
+				Expect(internal.Manifests).To(BeEmpty())
+				Expect(internal.Volumes).To(HaveLen(1))
+				Expect(internal.Volumes[0].Name).To(Equal("disk"))
+				Expect(internal.Volumes[0].Formats[0].Url).To(Equal("https://virt-export-export.default.svc/export/default/export/volumes/disk/disk.img"))
+				Expect(internal.Volumes[0].Formats[1].Url).To(Equal("https://virt-export-export.default.svc/export/default/export/volumes/disk/disk.img.gz"))
+			})
+
+			execute(export)
+
+			testutils.ExpectEvent(recorder, SuccessfulCreatePodReason)
+			Expect(secret.Name).To(Equal("virt-export-export"))
+			Expect(secret.Data).To(HaveKey(vmexport.CertKey))
+			Expect(secret.Data).To(HaveKey(vmexport.KeyKey))
+			Expect(secret.Data).ToNot(HaveKey(vmexport.ManifestKey))
+			Expect(metav1.IsControlledBy(secret, export)).To(BeTrue())
+			Expect(service.Spec.Selector).To(Equal(map[string]string{vmexport.ExportLabel: "export"}))
+			Expect(service.Spec.Ports[0].Port).To(Equal(int32(vmexport.ServicePort)))
+			Expect(pod.Name).To(Equal("virt-export-export"))
+			Expect(pod.Spec.Containers[0].Args).To(ContainElement("disk=" + vmexport.VolumeImagePath("disk", false)))
+		})
+
+		It("should create an ingress and publish the external links if an ingress host is configured", func() {
+			initController(&k8sv1.ConfigMap{
+				Data: map[string]string{virtconfig.ExportIngressHostKey: "export.example.com"},
+			})
+			export := newExport("PersistentVolumeClaim", "disk")
+			Expect(pvcInformer.GetStore().Add(newPVC("disk"))).To(Succeed())
+			_, _, _, ingress := expectExporterCreation()
+			expectStatusUpdate(func(status *v1.VirtualMachineExportStatus) {
+				external := status.Links.External
+				Expect(external).ToNot(BeNil())
+				Expect(external.Cert).To(BeEmpty())
+				Expect(external.Volumes[0].Formats[0].Url).To(Equal("https://export.example.com/export/default/export/volumes/disk/disk.img"))
+			})
+
+			execute(export)
+
+			testutils.ExpectEvent(recorder, SuccessfulCreatePodReason)
+			Expect(ingress.Spec.Rules).To(HaveLen(1))
+			Expect(ingress.Spec.Rules[0].Host).To(Equal("export.example.com"))
+			path := ingress.Spec.Rules[0].HTTP.Paths[0]
+			Expect(path.Path).To(Equal("/export/default/export"))
+			Expect(path.Backend.ServiceName).To(Equal("virt-export-export"))
+			Expect(ingress.Annotations).To(HaveKeyWithValue(ingressBackendProtocolAnnotation, "HTTPS"))
+		})
+	})
+
+	Context("with a VirtualMachine source", func() {
+		It("should wait for the VirtualMachine to exist", func() {
+			export := newExport("VirtualMachine", "testvm")
+			expectStatusUpdate(func(status *v1.VirtualMachineExportStatus) {
+				Expect(status.Phase).To(Equal(v1.ExportPending))
+				Expect(status.Message).To(Equal("VirtualMachine testvm does not exist"))
+			})
+
+			execute(export)
+		})
+
+		It("should not export a running VirtualMachine", func() {
+			export := newExport("VirtualMachine", "testvm")
+			Expect(vmInformer.GetStore().Add(newVM("testvm"))).To(Succeed())
+			Expect(vmiInformer.GetStore().Add(v1.NewMinimalVMI("testvm"))).To(Succeed())
+			expectStatusUpdate(func(status *v1.VirtualMachineExportStatus) {
+				Expect(status.Phase).To(Equal(v1.ExportPending))
+				Expect(status.Message).To(Equal("VirtualMachine testvm is running"))
+			})
+
+			execute(export)
+		})
+
+		It("should export the PVC and DataVolume volumes and the manifest", func() {
+			export := newExport("VirtualMachine", "testvm")
+			Expect(vmInformer.GetStore().Add(newVM("testvm"))).To(Succeed())
+			Expect(pvcInformer.GetStore().Add(newPVC("root-pvc"))).To(Succeed())
+			Expect(pvcInformer.GetStore().Add(newPVC("data-dv"))).To(Succeed())
+			secret, _, pod, _ := expectExporterCreation()
+			expectStatusUpdate(func(status *v1.VirtualMachineExportStatus) {
+				internal := status.Links.Internal
+				Expect(internal.Volumes).To(HaveLen(2))
+				Expect(internal.Volumes[0].Name).To(Equal("rootdisk"))
+				Expect(internal.Volumes[1].Name).To(Equal("datadisk"))
+				Expect(internal.Manifests).To(HaveLen(1))
+				Expect(internal.Manifests[0].Url).To(Equal("https://virt-export-export.default.svc/export/default/export/manifests/all"))
+			})
+
+			execute(export)
+
+			testutils.ExpectEvent(recorder, SuccessfulCreatePodReason)
+			Expect(string(secret.Data[vmexport.ManifestKey])).To(ContainSubstring("kind: VirtualMachine"))
+			Expect(string(secret.Data[vmexport.ManifestKey])).To(ContainSubstring("name: testvm"))
+			Expect(string(secret.Data[vmexport.ManifestKey])).ToNot(ContainSubstring("testvm-uid"))
+			var claims []string
+			for _, volume := range pod.Spec.Volumes {
+				if volume.PersistentVolumeClaim != nil {
+					claims = append(claims, volume.PersistentVolumeClaim.ClaimName)
+				}
+			}
+			Expect(claims).To(ConsistOf("root-pvc", "data-dv"))
+		})
+	})
+
+	Context("with an exporter pod", func() {
+		It("should become ready once the exporter pod is ready", func() {
+			export := newExport("PersistentVolumeClaim", "disk")
+			export.Status.Phase = v1.ExportPending
+			export.Status.Links = &v1.VirtualMachineExportLinks{Internal: &v1.VirtualMachineExportLink{}}
+			Expect(pvcInformer.GetStore().Add(newPVC("disk"))).To(Succeed())
+			Expect(podInformer.GetStore().Add(newExporterPod(export, true))).To(Succeed())
+			expectStatusUpdate(func(status *v1.VirtualMachineExportStatus) {
+				Expect(status.Phase).To(Equal(v1.ExportReady))
+				Expect(status.Message).To(BeEmpty())
+			})
+
+			execute(export)
+
+			testutils.ExpectEvent(recorder, ExportReadyReason)
+		})
+
+		It("should regenerate lost links from the exporter secret", func() {
+			export := newExport("PersistentVolumeClaim", "disk")
+			export.Status.Phase = v1.ExportPending
+			Expect(pvcInformer.GetStore().Add(newPVC("disk"))).To(Succeed())
+			Expect(podInformer.GetStore().Add(newExporterPod(export, false))).To(Succeed())
+			kubeClient.Fake.PrependReactor("get", "secrets", func(action testing.Action) (handled bool, obj runtime.Object, err error) {
+				return true, &k8sv1.Secret{Data: map[string][]byte{vmexport.CAKey: []byte("ca")}}, nil
+			})
+			expectStatusUpdate(func(status *v1.VirtualMachineExportStatus) {
+				Expect(status.Phase).To(Equal(v1.ExportPending))
+				Expect(status.ServiceName).To(Equal("virt-export-export"))
+				Expect(status.Links.Internal.Cert).To(Equal("ca"))
+				Expect(status.Links.Internal.Volumes).To(HaveLen(1))
+			})
+
+			execute(export)
+		})
+
+		It("should keep serving a ready export", func() {
+			export := newExport("PersistentVolumeClaim", "disk")
+			export.Status.Phase = v1.ExportReady
+			export.Status.ServiceName = vmexport.Name(export)
+			export.Status.Links = &v1.VirtualMachineExportLinks{Internal: &v1.VirtualMachineExportLink{}}
+			Expect(pvcInformer.GetStore().Add(newPVC("disk"))).To(Succeed())
+			Expect(podInformer.GetStore().Add(newExporterPod(export, true))).To(Succeed())
+
+			execute(export)
+		})
+
+		It("should delete the exporter pod and become pending when the VirtualMachine is started", func() {
+			export := newExport("VirtualMachine", "testvm")
+			export.Status.Phase = v1.ExportReady
+			export.Status.ServiceName = vmexport.Name(export)
+			export.Status.Links = &v1.VirtualMachineExportLinks{Internal: &v1.VirtualMachineExportLink{}}
+			Expect(vmInformer.GetStore().Add(newVM("testvm"))).To(Succeed())
+			Expect(pvcInformer.GetStore().Add(newPVC("root-pvc"))).To(Succeed())
+			Expect(pvcInformer.GetStore().Add(newPVC("data-dv"))).To(Succeed())
+			Expect(podInformer.GetStore().Add(newExporterPod(export, true))).To(Succeed())
+			vmi := v1.NewMinimalVMI("testvm")
+			Expect(vmiInformer.GetStore().Add(vmi)).To(Succeed())
+
+			By("waking up the ready export")
+			Expect(exportInformer.GetStore().Add(export)).To(Succeed())
+			controller.addSource(vmi)
+			Expect(controller.Queue.Len()).To(Equal(1))
+
+			deleted := ""
+			kubeClient.Fake.PrependReactor("delete", "pods", func(action testing.Action) (handled bool, obj runtime.Object, err error) {
+				deleted = action.(testing.DeleteAction).GetName()
+				return true, nil, nil
+			})
+			expectStatusUpdate(func(status *v1.VirtualMachineExportStatus) {
+				Expect(status.Phase).To(Equal(v1.ExportPending))
+				Expect(status.Message).To(Equal("VirtualMachine testvm is running"))
+			})
+
+			execute(export)
+
+			testutils.ExpectEvent(recorder, SuccessfulDeletePodReason)
+			Expect(deleted).To(Equal("virt-export-export"))
+		})
+
+		It("should not delete a terminating exporter pod again", func() {
+			export := newExport("VirtualMachine", "testvm")
+			export.Status.Phase = v1.ExportPending
+			export.Status.Message = "VirtualMachine testvm is running"
+			Expect(vmInformer.GetStore().Add(newVM("testvm"))).To(Succeed())
+			Expect(vmiInformer.GetStore().Add(v1.NewMinimalVMI("testvm"))).To(Succeed())
+			pod := newExporterPod(export, true)
+			now := metav1.Now()
+			pod.DeletionTimestamp = &now
+			Expect(podInformer.GetStore().Add(pod)).To(Succeed())
+
+			execute(export)
+		})
+	})
+})
@@ -0,0 +1,301 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2020 Red Hat, Inc.
+ *
+ */
+
+package watch
+
+import (
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	k8sv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+
+	virtv1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/client-go/kubecli"
+	"kubevirt.io/client-go/log"
+	"kubevirt.io/kubevirt/pkg/controller"
+)
+
+const (
+	imageCachePullerPrefix        = "virt-containerdisk-cache-"
+	imageCachePullerAppLabel      = "container-disk-cache"
+	imageCachePullerBinVolume     = "virt-bin-share-dir"
+	imageCachePullerContainerName = "image-%d"
+)
+
+// ImageCacheController runs a puller DaemonSet for every ContainerDiskImageCache.
+// The DaemonSet runs one no-op container per image on all selected nodes, so that
+// the kubelet pulls the images and does not garbage collect them. virt-handler
+// reports the images pulled on its node in the status of the cache.
+type ImageCacheController struct {
+	clientset          kubecli.KubevirtClient
+	Queue              workqueue.RateLimitingInterface
+	imageCacheInformer cache.SharedIndexInformer
+	daemonSetInformer  cache.SharedIndexInformer
+	namespace          string
+	virtLibDir         string
+}
+
+func NewImageCacheController(imageCacheInformer cache.SharedIndexInformer,
+	daemonSetInformer cache.SharedIndexInformer,
+	clientset kubecli.KubevirtClient,
+	namespace string,
+	virtLibDir string,
+) *ImageCacheController {
+
+	c := &ImageCacheController{
+		clientset:          clientset,
+		Queue:              workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter()),
+		imageCacheInformer: imageCacheInformer,
+		daemonSetInformer:  daemonSetInformer,
+		namespace:          namespace,
+		virtLibDir:         virtLibDir,
+	}
+
+	c.imageCacheInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    c.enqueueImageCache,
+		DeleteFunc: c.enqueueImageCache,
+		UpdateFunc: func(_, curr interface{}) { c.enqueueImageCache(curr) },
+	})
+
+	c.daemonSetInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    c.enqueueDaemonSet,
+		DeleteFunc: c.enqueueDaemonSet,
+		UpdateFunc: func(_, curr interface{}) { c.enqueueDaemonSet(curr) },
+	})
+
+	return c
+}
+
+func (c *ImageCacheController) enqueueImageCache(obj interface{}) {
+	key, err := controller.KeyFunc(obj)
+	if err != nil {
+		log.Log.Reason(err).Error("Failed to extract key from ContainerDiskImageCache.")
+		return
+	}
+	c.Queue.Add(key)
+}
+
+func (c *ImageCacheController) enqueueDaemonSet(obj interface{}) {
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+	daemonSet, ok := obj.(*appsv1.DaemonSet)
+	if !ok {
+		return
+	}
+	if name, exists := daemonSet.Labels[virtv1.ContainerDiskImageCacheLabel]; exists {
+		c.Queue.Add(name)
+	}
+}
+
+func (c *ImageCacheController) Run(threadiness int, stopCh <-chan struct{}) {
+	defer controller.HandlePanic()
+	defer c.Queue.ShutDown()
+	log.Log.Info("Starting containerDisk image cache controller.")
+
+	// Wait for cache sync before we start the image cache controller
+	cache.WaitForCacheSync(stopCh, c.imageCacheInformer.HasSynced, c.daemonSetInformer.HasSynced)
+
+	// Start the actual work
+	for i := 0; i < threadiness; i++ {
+		go wait.Until(c.runWorker, time.Second, stopCh)
+	}
+
+	<-stopCh
+	log.Log.Info("Stopping containerDisk image cache controller.")
+}
+
+func (c *ImageCacheController) runWorker() {
+	for c.Execute() {
+	}
+}
+
+func (c *ImageCacheController) Execute() bool {
+	key, quit := c.Queue.Get()
+	if quit {
+		return false
+	}
+	defer c.Queue.Done(key)
+	err := c.execute(key.(string))
+
+	if err != nil {
+		log.Log.Reason(err).Infof("reenqueuing ContainerDiskImageCache %v", key)
+		c.Queue.AddRateLimited(key)
+	} else {
+		log.Log.V(4).Infof("processed ContainerDiskImageCache %v", key)
+		c.Queue.Forget(key)
+	}
+	return true
+}
+
+func (c *ImageCacheController) execute(key string) error {
+	obj, exists, err := c.imageCacheInformer.GetStore().GetByKey(key)
+	if err != nil {
+		return err
+	}
+
+	name := imageCachePullerPrefix + key
+	dsObj, dsExists, err := c.daemonSetInformer.GetStore().GetByKey(c.namespace + "/" + name)
+	if err != nil {
+		return err
+	}
+
+	if !exists {
+		if !dsExists {
+			return nil
+		}
+		err := c.clientset.AppsV1().DaemonSets(c.namespace).Delete(name, &v1.DeleteOptions{})
+		if err != nil && !errors.IsNotFound(err) {
+			return fmt.Errorf("failed to delete the puller DaemonSet of ContainerDiskImageCache %s: %v", key, err)
+		}
+		return nil
+	}
+	imageCache := obj.(*virtv1.ContainerDiskImageCache)
+	desired := c.newPullerDaemonSet(imageCache)
+
+	if !dsExists {
+		if _, err := c.clientset.AppsV1().DaemonSets(c.namespace).Create(desired); err != nil {
+			return fmt.Errorf("failed to create the puller DaemonSet of ContainerDiskImageCache %s: %v", key, err)
+		}
+		return nil
+	}
+
+	daemonSet := dsObj.(*appsv1.DaemonSet)
+	if !isPullerOutdated(&daemonSet.Spec.Template.Spec, &desired.Spec.Template.Spec) {
+		return nil
+	}
+	daemonSetCopy := daemonSet.DeepCopy()
+	daemonSetCopy.Spec.Template = desired.Spec.Template
+	if _, err := c.clientset.AppsV1().DaemonSets(c.namespace).Update(daemonSetCopy); err != nil {
+		return fmt.Errorf("failed to update the puller DaemonSet of ContainerDiskImageCache %s: %v", key, err)
+	}
+	return nil
+}
+
+// isPullerOutdated compares the fields of the puller pods which are derived from the cache
+func isPullerOutdated(spec *k8sv1.PodSpec, desired *k8sv1.PodSpec) bool {
+	if len(spec.Containers) != len(desired.Containers) {
+		return true
+	}
+	for i := range spec.Containers {
+		if spec.Containers[i].Image != desired.Containers[i].Image {
+			return true
+		}
+	}
+	if !reflect.DeepEqual(spec.NodeSelector, desired.NodeSelector) {
+		return true
+	}
+	if len(spec.ImagePullSecrets) == 0 && len(desired.ImagePullSecrets) == 0 {
+		return false
+	}
+	return !reflect.DeepEqual(spec.ImagePullSecrets, desired.ImagePullSecrets)
+}
+
+func (c *ImageCacheController) newPullerDaemonSet(imageCache *virtv1.ContainerDiskImageCache) *appsv1.DaemonSet {
+	gracePeriod := int64(1)
+	automount := false
+
+	podLabels := map[string]string{
+		virtv1.AppLabel:                     imageCachePullerAppLabel,
+		virtv1.ContainerDiskImageCacheLabel: imageCache.Name,
+	}
+
+	// The puller runs the container-disk binary which virt-handler copies to the node
+	nodeSelector := map[string]string{
+		virtv1.NodeSchedulable: "true",
+	}
+	for key, value := range imageCache.Spec.NodeSelector {
+		nodeSelector[key] = value
+	}
+
+	daemonSet := &appsv1.DaemonSet{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      imageCachePullerPrefix + imageCache.Name,
+			Namespace: c.namespace,
+			Labels:    podLabels,
+			OwnerReferences: []v1.OwnerReference{
+				*v1.NewControllerRef(imageCache, virtv1.ContainerDiskImageCacheGroupVersionKind),
+			},
+		},
+		Spec: appsv1.DaemonSetSpec{
+			Selector: &v1.LabelSelector{
+				MatchLabels: map[string]string{
+					virtv1.ContainerDiskImageCacheLabel: imageCache.Name,
+				},
+			},
+			Template: k8sv1.PodTemplateSpec{
+				ObjectMeta: v1.ObjectMeta{
+					Labels: podLabels,
+				},
+				Spec: k8sv1.PodSpec{
+					NodeSelector:                  nodeSelector,
+					TerminationGracePeriodSeconds: &gracePeriod,
+					AutomountServiceAccountToken:  &automount,
+					ImagePullSecrets:              imageCache.Spec.ImagePullSecrets,
+					Volumes: []k8sv1.Volume{
+						{
+							Name: imageCachePullerBinVolume,
+							VolumeSource: k8sv1.VolumeSource{
+								HostPath: &k8sv1.HostPathVolumeSource{
+									Path: filepath.Join(c.virtLibDir, "/init/usr/bin"),
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	for i, image := range imageCache.Spec.Images {
+		daemonSet.Spec.Template.Spec.Containers = append(daemonSet.Spec.Template.Spec.Containers, k8sv1.Container{
+			Name:            fmt.Sprintf(imageCachePullerContainerName, i),
+			Image:           image,
+			ImagePullPolicy: k8sv1.PullIfNotPresent,
+			Command:         []string{"/usr/bin/container-disk"},
+			Args:            []string{"--no-op"},
+			VolumeMounts: []k8sv1.VolumeMount{
+				{
+					Name:      imageCachePullerBinVolume,
+					MountPath: "/usr/bin",
+				},
+			},
+			Resources: k8sv1.ResourceRequirements{
+				Limits: k8sv1.ResourceList{
+					k8sv1.ResourceCPU:    resource.MustParse("10m"),
+					k8sv1.ResourceMemory: resource.MustParse("20M"),
+				},
+				Requests: k8sv1.ResourceList{
+					k8sv1.ResourceCPU:    resource.MustParse("1m"),
+					k8sv1.ResourceMemory: resource.MustParse("1M"),
+				},
+			},
+		})
+	}
+	return daemonSet
+}
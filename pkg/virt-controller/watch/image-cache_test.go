@@ -0,0 +1,142 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2020 Red Hat, Inc.
+ *
+ */
+
+package watch
+
+import (
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	k8sv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/cache"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/client-go/kubecli"
+	"kubevirt.io/client-go/log"
+	"kubevirt.io/kubevirt/pkg/testutils"
+)
+
+var _ = Describe("ContainerDiskImageCache watcher", func() {
+	log.Log.SetIOWriter(GinkgoWriter)
+
+	const namespace = "kubevirt"
+	const image = "registry:5000/kubevirt/cirros-container-disk-demo:devel"
+
+	var ctrl *gomock.Controller
+	var kubeClient *fake.Clientset
+	var imageCacheInformer cache.SharedIndexInformer
+	var daemonSetInformer cache.SharedIndexInformer
+	var controller *ImageCacheController
+
+	newImageCache := func() *v1.ContainerDiskImageCache {
+		return &v1.ContainerDiskImageCache{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "cache",
+				UID:  types.UID("cache-uid"),
+			},
+			Spec: v1.ContainerDiskImageCacheSpec{
+				Images:           []string{image},
+				NodeSelector:     map[string]string{"cache": "true"},
+				ImagePullSecrets: []k8sv1.LocalObjectReference{{Name: "pull-secret"}},
+			},
+		}
+	}
+
+	addDaemonSet := func(daemonSet *appsv1.DaemonSet) {
+		daemonSetInformer.GetIndexer().Add(daemonSet)
+		_, err := kubeClient.AppsV1().DaemonSets(namespace).Create(daemonSet)
+		Expect(err).ToNot(HaveOccurred())
+	}
+
+	getDaemonSets := func() []appsv1.DaemonSet {
+		daemonSets, err := kubeClient.AppsV1().DaemonSets(namespace).List(metav1.ListOptions{})
+		Expect(err).ToNot(HaveOccurred())
+		return daemonSets.Items
+	}
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		virtClient := kubecli.NewMockKubevirtClient(ctrl)
+		kubeClient = fake.NewSimpleClientset()
+		virtClient.EXPECT().AppsV1().Return(kubeClient.AppsV1()).AnyTimes()
+
+		imageCacheInformer, _ = testutils.NewFakeInformerFor(&v1.ContainerDiskImageCache{})
+		daemonSetInformer, _ = testutils.NewFakeInformerFor(&appsv1.DaemonSet{})
+		controller = NewImageCacheController(imageCacheInformer, daemonSetInformer, virtClient, namespace, "/var/lib/kubevirt")
+	})
+
+	AfterEach(func() {
+		ctrl.Finish()
+	})
+
+	It("should create a puller DaemonSet for a cache", func() {
+		imageCache := newImageCache()
+		imageCacheInformer.GetIndexer().Add(imageCache)
+
+		Expect(controller.execute("cache")).To(Succeed())
+
+		daemonSets := getDaemonSets()
+		Expect(daemonSets).To(HaveLen(1))
+		daemonSet := daemonSets[0]
+		Expect(daemonSet.Labels).To(HaveKeyWithValue(v1.ContainerDiskImageCacheLabel, "cache"))
+		Expect(daemonSet.OwnerReferences).To(HaveLen(1))
+		Expect(daemonSet.OwnerReferences[0].UID).To(Equal(imageCache.UID))
+		podSpec := daemonSet.Spec.Template.Spec
+		Expect(podSpec.NodeSelector).To(Equal(map[string]string{"cache": "true", v1.NodeSchedulable: "true"}))
+		Expect(podSpec.ImagePullSecrets).To(Equal(imageCache.Spec.ImagePullSecrets))
+		Expect(podSpec.Containers).To(HaveLen(1))
+		Expect(podSpec.Containers[0].Image).To(Equal(image))
+		Expect(podSpec.Containers[0].Args).To(Equal([]string{"--no-op"}))
+		Expect(podSpec.Volumes[0].HostPath.Path).To(Equal("/var/lib/kubevirt/init/usr/bin"))
+	})
+
+	It("should not touch an up-to-date puller DaemonSet", func() {
+		imageCache := newImageCache()
+		imageCacheInformer.GetIndexer().Add(imageCache)
+		addDaemonSet(controller.newPullerDaemonSet(imageCache))
+
+		Expect(controller.execute("cache")).To(Succeed())
+		Expect(kubeClient.Actions()).To(HaveLen(1))
+		Expect(kubeClient.Actions()[0].GetVerb()).To(Equal("create"))
+	})
+
+	It("should update the puller DaemonSet if the images change", func() {
+		imageCache := newImageCache()
+		addDaemonSet(controller.newPullerDaemonSet(imageCache))
+		imageCache.Spec.Images = []string{"registry:5000/kubevirt/fedora-cloud-container-disk-demo:devel"}
+		imageCacheInformer.GetIndexer().Add(imageCache)
+
+		Expect(controller.execute("cache")).To(Succeed())
+
+		daemonSets := getDaemonSets()
+		Expect(daemonSets).To(HaveLen(1))
+		Expect(daemonSets[0].Spec.Template.Spec.Containers[0].Image).To(Equal(imageCache.Spec.Images[0]))
+	})
+
+	It("should delete the puller DaemonSet of a deleted cache", func() {
+		addDaemonSet(controller.newPullerDaemonSet(newImageCache()))
+
+		Expect(controller.execute("cache")).To(Succeed())
+		Expect(getDaemonSets()).To(BeEmpty())
+	})
+})
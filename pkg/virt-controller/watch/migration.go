@@ -27,6 +27,7 @@ import (
 	"time"
 
 	k8sv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
@@ -38,6 +39,7 @@ import (
 	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
 
 	"kubevirt.io/kubevirt/pkg/util/migrations"
+	pvcutils "kubevirt.io/kubevirt/pkg/util/types"
 
 	virtv1 "kubevirt.io/client-go/api/v1"
 	"kubevirt.io/client-go/kubecli"
@@ -319,14 +321,25 @@ func (c *MigrationController) updateStatus(migration *virtv1.VirtualMachineInsta
 				return err
 			}
 
-			if canMigrate {
-				migrationCopy.Status.Phase = virtv1.MigrationPending
-			} else {
+			if !canMigrate {
 				// can not migrate because there is an active migration already
 				// in progress for this VMI.
 				migrationCopy.Status.Phase = virtv1.MigrationFailed
 				c.recorder.Eventf(migration, k8sv1.EventTypeWarning, FailedMigrationReason, "VMI is not eligible for migration because another migration job is in progress.")
 				log.Log.Object(migration).Error("Migration object ont eligible for migration because another job is in progress")
+				break
+			}
+
+			reason, err := c.validateMigratedVolumes(migration, vmi)
+			if err != nil {
+				return err
+			}
+			if reason != "" {
+				migrationCopy.Status.Phase = virtv1.MigrationFailed
+				c.recorder.Eventf(migration, k8sv1.EventTypeWarning, FailedMigrationReason, "Volumes can't be migrated: %s", reason)
+				log.Log.Object(migration).Errorf("Volumes can't be migrated: %s", reason)
+			} else {
+				migrationCopy.Status.Phase = virtv1.MigrationPending
 			}
 		case virtv1.MigrationPending:
 			if podExists {
@@ -350,6 +363,11 @@ func (c *MigrationController) updateStatus(migration *virtv1.VirtualMachineInsta
 			}
 		case virtv1.MigrationRunning:
 			if vmi.Status.MigrationState.Completed {
+				// the VMI runs on the destination PVCs now, the VMI and its VirtualMachine have to use them too
+				if err := c.updateMigratedVolumes(migration, vmi); err != nil {
+					c.recorder.Eventf(migration, k8sv1.EventTypeWarning, FailedUpdateMigratedVolumesReason, "Failed to switch to the migrated volumes: %v", err)
+					return err
+				}
 				migrationCopy.Status.Phase = virtv1.MigrationSucceeded
 				c.recorder.Eventf(migration, k8sv1.EventTypeNormal, SuccessfulMigrationReason, "Source node reported migration succeeded")
 				log.Log.Object(migration).Infof("VMI reported migration succeeded.")
@@ -368,8 +386,132 @@ func (c *MigrationController) updateStatus(migration *virtv1.VirtualMachineInsta
 	return nil
 }
 
+// validateMigratedVolumes returns why the volumes of the migration can't be migrated, or an empty string if they can.
+// The destination PVCs must be able to take over the disk images and all other PVCs must be shared.
+func (c *MigrationController) validateMigratedVolumes(migration *virtv1.VirtualMachineInstanceMigration, vmi *virtv1.VirtualMachineInstance) (string, error) {
+	if len(migration.Spec.MigratedVolumes) == 0 {
+		return "", nil
+	}
+
+	destinations := map[string]string{}
+	for _, migratedVolume := range migration.Spec.MigratedVolumes {
+		destinations[migratedVolume.VolumeName] = migratedVolume.DestinationClaimName
+	}
+
+	for i := range vmi.Spec.Volumes {
+		volume := &vmi.Spec.Volumes[i]
+		claimName, ok := migrations.GetVolumeClaimName(volume)
+		if !ok {
+			continue
+		}
+		source, exists, sourceIsBlock, err := pvcutils.IsPVCBlockFromClient(c.clientset, vmi.Namespace, claimName)
+		if err != nil {
+			return "", err
+		} else if !exists {
+			return fmt.Sprintf("persistentvolumeclaim %s of volume %s does not exist", claimName, volume.Name), nil
+		}
+
+		destinationName, migrated := destinations[volume.Name]
+		if !migrated {
+			if !pvcutils.IsPVCShared(source) {
+				return fmt.Sprintf("persistentvolumeclaim %s of volume %s is neither shared nor migrated", claimName, volume.Name), nil
+			}
+			continue
+		}
+
+		destination, exists, destinationIsBlock, err := pvcutils.IsPVCBlockFromClient(c.clientset, vmi.Namespace, destinationName)
+		if err != nil {
+			return "", err
+		} else if !exists {
+			return fmt.Sprintf("persistentvolumeclaim %s does not exist", destinationName), nil
+		}
+		if sourceIsBlock != destinationIsBlock {
+			return fmt.Sprintf("persistentvolumeclaims %s and %s have different volume modes", claimName, destinationName), nil
+		}
+		if source.Annotations[virtv1.DiskImageFormatAnnotation] != destination.Annotations[virtv1.DiskImageFormatAnnotation] {
+			return fmt.Sprintf("persistentvolumeclaims %s and %s have different disk image formats", claimName, destinationName), nil
+		}
+		sourceCapacity, destinationCapacity := claimCapacity(source), claimCapacity(destination)
+		if destinationCapacity.Cmp(sourceCapacity) < 0 {
+			return fmt.Sprintf("persistentvolumeclaim %s is smaller than %s", destinationName, claimName), nil
+		}
+	}
+	return "", nil
+}
+
+// claimCapacity returns the capacity of a bound PVC, or the requested storage of an unbound one
+func claimCapacity(pvc *k8sv1.PersistentVolumeClaim) resource.Quantity {
+	if capacity, ok := pvc.Status.Capacity[k8sv1.ResourceStorage]; ok {
+		return capacity
+	}
+	return pvc.Spec.Resources.Requests[k8sv1.ResourceStorage]
+}
+
+// updateMigratedVolumes switches the volumes of the VMI and its VirtualMachine to the PVCs they were migrated to
+func (c *MigrationController) updateMigratedVolumes(migration *virtv1.VirtualMachineInstanceMigration, vmi *virtv1.VirtualMachineInstance) error {
+	if len(migration.Spec.MigratedVolumes) == 0 {
+		return nil
+	}
+
+	volumes := migrations.ApplyMigratedVolumes(vmi.Spec.Volumes, migration.Spec.MigratedVolumes)
+	if !reflect.DeepEqual(vmi.Spec.Volumes, volumes) {
+		vmiCopy := vmi.DeepCopy()
+		vmiCopy.Spec.Volumes = volumes
+		if _, err := c.clientset.VirtualMachineInstance(vmi.Namespace).Update(vmiCopy); err != nil {
+			return err
+		}
+	}
+
+	ownerRef := v1.GetControllerOf(vmi)
+	if ownerRef == nil || ownerRef.Kind != virtv1.VirtualMachineGroupVersionKind.Kind {
+		return nil
+	}
+	vm, err := c.clientset.VirtualMachine(vmi.Namespace).Get(ownerRef.Name, &v1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	if vm.UID != ownerRef.UID || vm.Spec.Template == nil {
+		return nil
+	}
+
+	vmCopy := vm.DeepCopy()
+	vmCopy.Spec.Template.Spec.Volumes = migrations.ApplyMigratedVolumes(vm.Spec.Template.Spec.Volumes, migration.Spec.MigratedVolumes)
+	// the migrated DataVolumes are not used anymore, don't let the VirtualMachine recreate them
+	migratedDataVolumes := map[string]bool{}
+	for i := range vm.Spec.Template.Spec.Volumes {
+		volume := &vm.Spec.Template.Spec.Volumes[i]
+		if volume.DataVolume == nil {
+			continue
+		}
+		for _, migratedVolume := range migration.Spec.MigratedVolumes {
+			if migratedVolume.VolumeName == volume.Name {
+				migratedDataVolumes[volume.DataVolume.Name] = true
+			}
+		}
+	}
+	vmCopy.Spec.DataVolumeTemplates = nil
+	for _, template := range vm.Spec.DataVolumeTemplates {
+		if !migratedDataVolumes[template.Name] {
+			vmCopy.Spec.DataVolumeTemplates = append(vmCopy.Spec.DataVolumeTemplates, template)
+		}
+	}
+
+	if !reflect.DeepEqual(vm.Spec, vmCopy.Spec) {
+		if _, err := c.clientset.VirtualMachine(vm.Namespace).Update(vmCopy); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (c *MigrationController) createTargetPod(migration *virtv1.VirtualMachineInstanceMigration, vmi *virtv1.VirtualMachineInstance) error {
 
+	// the target pod mounts the destination PVCs of the migrated volumes
+	if len(migration.Spec.MigratedVolumes) > 0 {
+		vmi = vmi.DeepCopy()
+		vmi.Spec.Volumes = migrations.ApplyMigratedVolumes(vmi.Spec.Volumes, migration.Spec.MigratedVolumes)
+	}
+
 	templatePod, err := c.templateService.RenderLaunchManifest(vmi)
 	if err != nil {
 		return fmt.Errorf("failed to render launch manifest: %v", err)
@@ -530,6 +672,8 @@ func (c *MigrationController) sync(key string, migration *virtv1.VirtualMachineI
 				TargetNode:   pod.Spec.NodeName,
 				SourceNode:   vmi.Status.NodeName,
 				TargetPod:    pod.Name,
+				// virt-handler on the target node prepares the destination PVCs for these volumes
+				MigratedVolumes: migration.Spec.MigratedVolumes,
 			}
 
 			// By setting this label, virt-handler on the target node will receive
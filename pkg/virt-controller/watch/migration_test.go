@@ -28,6 +28,8 @@ import (
 	"github.com/onsi/ginkgo/extensions/table"
 	. "github.com/onsi/gomega"
 	k8sv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
@@ -696,6 +698,217 @@ var _ = Describe("Migration watcher", func() {
 			testutils.ExpectEvent(recorder, SuccessfulAbortMigrationReason)
 		})
 	})
+
+	Context("Migration with migrated volumes", func() {
+		newClaim := func(name string, size string, accessMode k8sv1.PersistentVolumeAccessMode) *k8sv1.PersistentVolumeClaim {
+			return &k8sv1.PersistentVolumeClaim{
+				ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: k8sv1.NamespaceDefault},
+				Spec: k8sv1.PersistentVolumeClaimSpec{
+					AccessModes: []k8sv1.PersistentVolumeAccessMode{accessMode},
+					Resources: k8sv1.ResourceRequirements{
+						Requests: k8sv1.ResourceList{k8sv1.ResourceStorage: resource.MustParse(size)},
+					},
+				},
+			}
+		}
+
+		newVMIWithClaims := func() *v1.VirtualMachineInstance {
+			vmi := newVirtualMachine("testvmi", v1.Running)
+			vmi.Spec.Volumes = []v1.Volume{
+				{
+					Name: "rootdisk",
+					VolumeSource: v1.VolumeSource{
+						PersistentVolumeClaim: &k8sv1.PersistentVolumeClaimVolumeSource{ClaimName: "rootclaim"},
+					},
+				},
+				{
+					Name: "shareddisk",
+					VolumeSource: v1.VolumeSource{
+						DataVolume: &v1.DataVolumeSource{Name: "shareddv"},
+					},
+				},
+			}
+			return vmi
+		}
+
+		newMigrationWithVolumes := func(vmiName string, phase v1.VirtualMachineInstanceMigrationPhase) *v1.VirtualMachineInstanceMigration {
+			migration := newMigration("testmigration", vmiName, phase)
+			migration.Spec.MigratedVolumes = []v1.StorageMigratedVolume{
+				{VolumeName: "rootdisk", DestinationClaimName: "newrootclaim"},
+			}
+			return migration
+		}
+
+		expectClaims := func(claims ...*k8sv1.PersistentVolumeClaim) {
+			kubeClient.Fake.PrependReactor("get", "persistentvolumeclaims", func(action testing.Action) (handled bool, obj runtime.Object, err error) {
+				name := action.(testing.GetAction).GetName()
+				for _, claim := range claims {
+					if claim.Name == name {
+						return true, claim, nil
+					}
+				}
+				return true, nil, errors.NewNotFound(k8sv1.Resource("persistentvolumeclaims"), name)
+			})
+		}
+
+		It("should become pending if the volumes can be migrated", func() {
+			vmi := newVMIWithClaims()
+			migration := newMigrationWithVolumes(vmi.Name, v1.MigrationPhaseUnset)
+			addMigration(migration)
+			addVirtualMachine(vmi)
+
+			expectClaims(
+				newClaim("rootclaim", "1Gi", k8sv1.ReadWriteOnce),
+				newClaim("newrootclaim", "2Gi", k8sv1.ReadWriteOnce),
+				newClaim("shareddv", "1Gi", k8sv1.ReadWriteMany),
+			)
+			migrationInterface.EXPECT().Update(gomock.Any()).Do(func(arg interface{}) {
+				Expect(arg.(*v1.VirtualMachineInstanceMigration).Status.Phase).To(Equal(v1.MigrationPending))
+			}).Return(migration, nil)
+
+			controller.Execute()
+		})
+
+		table.DescribeTable("should fail if the volumes can't be migrated", func(claims ...*k8sv1.PersistentVolumeClaim) {
+			vmi := newVMIWithClaims()
+			migration := newMigrationWithVolumes(vmi.Name, v1.MigrationPhaseUnset)
+			addMigration(migration)
+			addVirtualMachine(vmi)
+
+			expectClaims(claims...)
+			shouldExpectMigrationFailedState(migration)
+
+			controller.Execute()
+			testutils.ExpectEvent(recorder, FailedMigrationReason)
+		},
+			table.Entry("because the destination PVC does not exist",
+				newClaim("rootclaim", "1Gi", k8sv1.ReadWriteOnce),
+				newClaim("shareddv", "1Gi", k8sv1.ReadWriteMany),
+			),
+			table.Entry("because the destination PVC is too small",
+				newClaim("rootclaim", "1Gi", k8sv1.ReadWriteOnce),
+				newClaim("newrootclaim", "512Mi", k8sv1.ReadWriteOnce),
+				newClaim("shareddv", "1Gi", k8sv1.ReadWriteMany),
+			),
+			table.Entry("because the volume modes differ",
+				newClaim("rootclaim", "1Gi", k8sv1.ReadWriteOnce),
+				func() *k8sv1.PersistentVolumeClaim {
+					claim := newClaim("newrootclaim", "1Gi", k8sv1.ReadWriteOnce)
+					volumeMode := k8sv1.PersistentVolumeBlock
+					claim.Spec.VolumeMode = &volumeMode
+					return claim
+				}(),
+				newClaim("shareddv", "1Gi", k8sv1.ReadWriteMany),
+			),
+			table.Entry("because the disk image formats differ",
+				newClaim("rootclaim", "1Gi", k8sv1.ReadWriteOnce),
+				func() *k8sv1.PersistentVolumeClaim {
+					claim := newClaim("newrootclaim", "1Gi", k8sv1.ReadWriteOnce)
+					claim.Annotations = map[string]string{v1.DiskImageFormatAnnotation: string(v1.HostDiskFormatQcow2)}
+					return claim
+				}(),
+				newClaim("shareddv", "1Gi", k8sv1.ReadWriteMany),
+			),
+			table.Entry("because another PVC is not shared",
+				newClaim("rootclaim", "1Gi", k8sv1.ReadWriteOnce),
+				newClaim("newrootclaim", "1Gi", k8sv1.ReadWriteOnce),
+				newClaim("shareddv", "1Gi", k8sv1.ReadWriteOnce),
+			),
+		)
+
+		It("should create the target pod with the destination PVCs", func() {
+			vmi := newVMIWithClaims()
+			migration := newMigrationWithVolumes(vmi.Name, v1.MigrationPending)
+			pvcInformer.GetIndexer().Add(newClaim("newrootclaim", "1Gi", k8sv1.ReadWriteOnce))
+			pvcInformer.GetIndexer().Add(newClaim("shareddv", "1Gi", k8sv1.ReadWriteMany))
+
+			addMigration(migration)
+			addVirtualMachine(vmi)
+
+			kubeClient.Fake.PrependReactor("create", "pods", func(action testing.Action) (handled bool, obj runtime.Object, err error) {
+				pod := action.(testing.CreateAction).GetObject().(*k8sv1.Pod)
+				var claimNames []string
+				for _, volume := range pod.Spec.Volumes {
+					if volume.PersistentVolumeClaim != nil {
+						claimNames = append(claimNames, volume.PersistentVolumeClaim.ClaimName)
+					}
+				}
+				Expect(claimNames).To(ConsistOf("newrootclaim", "shareddv"))
+				return true, pod, nil
+			})
+
+			controller.Execute()
+			testutils.ExpectEvent(recorder, SuccessfulCreatePodReason)
+		})
+
+		It("should hand the migrated volumes over to virt-handler", func() {
+			vmi := newVMIWithClaims()
+			vmi.Status.NodeName = "node02"
+			migration := newMigrationWithVolumes(vmi.Name, v1.MigrationScheduled)
+			pod := newTargetPodForVirtualMachine(vmi, migration, k8sv1.PodPending)
+			pod.Spec.NodeName = "node01"
+
+			addMigration(migration)
+			addVirtualMachine(vmi)
+			podFeeder.Add(pod)
+
+			vmiInterface.EXPECT().Update(gomock.Any()).Do(func(arg interface{}) {
+				Expect(arg.(*v1.VirtualMachineInstance).Status.MigrationState.MigratedVolumes).To(Equal(migration.Spec.MigratedVolumes))
+				Expect(arg.(*v1.VirtualMachineInstance).IsBlockMigration()).To(BeTrue())
+			}).Return(vmi, nil)
+
+			controller.Execute()
+			testutils.ExpectEvent(recorder, SuccessfulHandOverPodReason)
+		})
+
+		It("should switch the VMI and its VirtualMachine to the destination PVCs once completed", func() {
+			vm := &v1.VirtualMachine{
+				ObjectMeta: metav1.ObjectMeta{Name: "testvmi", Namespace: k8sv1.NamespaceDefault, UID: "vm-uid"},
+				Spec: v1.VirtualMachineSpec{
+					Template: &v1.VirtualMachineInstanceTemplateSpec{},
+				},
+			}
+			vmi := newVMIWithClaims()
+			vm.Spec.Template.Spec.Volumes = vmi.Spec.Volumes
+			vmi.OwnerReferences = []metav1.OwnerReference{*metav1.NewControllerRef(vm, v1.VirtualMachineGroupVersionKind)}
+			vmi.Status.NodeName = "node01"
+			migration := newMigrationWithVolumes(vmi.Name, v1.MigrationRunning)
+			pod := newTargetPodForVirtualMachine(vmi, migration, k8sv1.PodRunning)
+			pod.Spec.NodeName = "node01"
+			vmi.Status.MigrationState = &v1.VirtualMachineInstanceMigrationState{
+				MigrationUID:    migration.UID,
+				TargetNode:      "node01",
+				SourceNode:      "node02",
+				StartTimestamp:  now(),
+				EndTimestamp:    now(),
+				Completed:       true,
+				MigratedVolumes: migration.Spec.MigratedVolumes,
+			}
+
+			addMigration(migration)
+			addVirtualMachine(vmi)
+			podFeeder.Add(pod)
+
+			expectSwitchedVolumes := func(volumes []v1.Volume) {
+				Expect(volumes).To(HaveLen(2))
+				Expect(volumes[0].PersistentVolumeClaim.ClaimName).To(Equal("newrootclaim"))
+				Expect(volumes[1].DataVolume.Name).To(Equal("shareddv"))
+			}
+			vmiInterface.EXPECT().Update(gomock.Any()).Do(func(arg interface{}) {
+				expectSwitchedVolumes(arg.(*v1.VirtualMachineInstance).Spec.Volumes)
+			}).Return(vmi, nil)
+			vmInterface := kubecli.NewMockVirtualMachineInterface(ctrl)
+			virtClient.EXPECT().VirtualMachine(k8sv1.NamespaceDefault).Return(vmInterface).AnyTimes()
+			vmInterface.EXPECT().Get(vm.Name, gomock.Any()).Return(vm, nil)
+			vmInterface.EXPECT().Update(gomock.Any()).Do(func(arg interface{}) {
+				expectSwitchedVolumes(arg.(*v1.VirtualMachine).Spec.Template.Spec.Volumes)
+			}).Return(vm, nil)
+			shouldExpectMigrationCompletedState(migration)
+
+			controller.Execute()
+			testutils.ExpectEvent(recorder, SuccessfulMigrationReason)
+		})
+	})
 })
 
 func newMigration(name string, vmiName string, phase v1.VirtualMachineInstanceMigrationPhase) *v1.VirtualMachineInstanceMigration {
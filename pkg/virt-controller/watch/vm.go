@@ -45,6 +45,8 @@ import (
 	cdiv1 "kubevirt.io/containerized-data-importer/pkg/apis/core/v1alpha1"
 	cdiclone "kubevirt.io/containerized-data-importer/pkg/clone"
 	"kubevirt.io/kubevirt/pkg/controller"
+	memorydump "kubevirt.io/kubevirt/pkg/memory-dump"
+	"kubevirt.io/kubevirt/pkg/virt-controller/services"
 )
 
 // TODO remove the dataVolume deletion retry logic once CDI fixes this issue.
@@ -58,17 +60,21 @@ const (
 
 type CloneAuthFunc func(pvcNamespace, pvcName, saNamespace, saName string) (bool, string, error)
 
-func NewVMController(vmiInformer cache.SharedIndexInformer,
+func NewVMController(templateService services.TemplateService,
+	vmiInformer cache.SharedIndexInformer,
 	vmiVMInformer cache.SharedIndexInformer,
 	dataVolumeInformer cache.SharedIndexInformer,
+	podInformer cache.SharedIndexInformer,
 	recorder record.EventRecorder,
 	clientset kubecli.KubevirtClient) *VMController {
 
 	c := &VMController{
 		Queue:                  workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter()),
+		templateService:        templateService,
 		vmiInformer:            vmiInformer,
 		vmiVMInformer:          vmiVMInformer,
 		dataVolumeInformer:     dataVolumeInformer,
+		podInformer:            podInformer,
 		recorder:               recorder,
 		clientset:              clientset,
 		expectations:           controller.NewUIDTrackingControllerExpectations(controller.NewControllerExpectations()),
@@ -96,15 +102,23 @@ func NewVMController(vmiInformer cache.SharedIndexInformer,
 		UpdateFunc: c.updateDataVolume,
 	})
 
+	c.podInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    c.addPod,
+		DeleteFunc: c.deletePod,
+		UpdateFunc: c.updatePod,
+	})
+
 	return c
 }
 
 type VMController struct {
 	clientset              kubecli.KubevirtClient
 	Queue                  workqueue.RateLimitingInterface
+	templateService        services.TemplateService
 	vmiInformer            cache.SharedIndexInformer
 	vmiVMInformer          cache.SharedIndexInformer
 	dataVolumeInformer     cache.SharedIndexInformer
+	podInformer            cache.SharedIndexInformer
 	recorder               record.EventRecorder
 	expectations           *controller.UIDTrackingControllerExpectations
 	dataVolumeExpectations *controller.UIDTrackingControllerExpectations
@@ -117,7 +131,7 @@ func (c *VMController) Run(threadiness int, stopCh <-chan struct{}) {
 	log.Log.Info("Starting VirtualMachine controller.")
 
 	// Wait for cache sync before we start the controller
-	cache.WaitForCacheSync(stopCh, c.vmiInformer.HasSynced, c.vmiVMInformer.HasSynced, c.dataVolumeInformer.HasSynced)
+	cache.WaitForCacheSync(stopCh, c.vmiInformer.HasSynced, c.vmiVMInformer.HasSynced, c.dataVolumeInformer.HasSynced, c.podInformer.HasSynced)
 
 	// Start the actual work
 	for i := 0; i < threadiness; i++ {
@@ -265,13 +279,24 @@ func (c *VMController) execute(key string) error {
 		logger.Reason(err).Error("Creating the VirtualMachine failed.")
 	}
 
+	var memoryDumpErr error
+	if vm.ObjectMeta.DeletionTimestamp == nil {
+		memoryDumpErr = c.handleMemoryDump(vm, vmi)
+		if memoryDumpErr != nil {
+			logger.Reason(memoryDumpErr).Error("Handling the memory dump of the VirtualMachine failed.")
+		}
+	}
+
 	err = c.updateStatus(vm, vmi, createErr)
 	if err != nil {
 		logger.Reason(err).Error("Updating the VirtualMachine status failed.")
 		return err
 	}
 
-	return createErr
+	if createErr != nil {
+		return createErr
+	}
+	return memoryDumpErr
 }
 
 func (c *VMController) listDataVolumesForVM(vm *virtv1.VirtualMachine) ([]*cdiv1.DataVolume, error) {
@@ -1049,6 +1074,73 @@ func (c *VMController) deleteDataVolume(obj interface{}) {
 	c.enqueueVm(vm)
 }
 
+// When a pod is created, enqueue the VirtualMachine that manages it.
+func (c *VMController) addPod(obj interface{}) {
+	pod := obj.(*k8score.Pod)
+	if pod.DeletionTimestamp != nil {
+		c.deletePod(pod)
+		return
+	}
+	controllerRef := v1.GetControllerOf(pod)
+	if controllerRef == nil {
+		return
+	}
+	vm := c.resolveControllerRef(pod.Namespace, controllerRef)
+	if vm == nil {
+		return
+	}
+	log.Log.V(4).Object(pod).Infof("Pod created")
+	c.enqueueVm(vm)
+}
+
+// When a pod is updated, enqueue the VirtualMachine that manages it.
+func (c *VMController) updatePod(old, cur interface{}) {
+	curPod := cur.(*k8score.Pod)
+	oldPod := old.(*k8score.Pod)
+	if curPod.ResourceVersion == oldPod.ResourceVersion {
+		// Periodic resync will send update events for all known pods.
+		// Two different versions of the same pod will always have different RVs.
+		return
+	}
+	controllerRef := v1.GetControllerOf(curPod)
+	if controllerRef == nil {
+		return
+	}
+	vm := c.resolveControllerRef(curPod.Namespace, controllerRef)
+	if vm == nil {
+		return
+	}
+	log.Log.V(4).Object(curPod).Infof("Pod updated")
+	c.enqueueVm(vm)
+}
+
+// When a pod is deleted, enqueue the VirtualMachine that manages it.
+// obj could be an *k8score.Pod, or a DeletionFinalStateUnknown marker item.
+func (c *VMController) deletePod(obj interface{}) {
+	pod, ok := obj.(*k8score.Pod)
+	if !ok {
+		tombstone, ok := obj.(cache.DeletedFinalStateUnknown)
+		if !ok {
+			log.Log.Reason(fmt.Errorf("couldn't get object from tombstone %+v", obj)).Error("Failed to process delete notification")
+			return
+		}
+		pod, ok = tombstone.Obj.(*k8score.Pod)
+		if !ok {
+			log.Log.Reason(fmt.Errorf("tombstone contained object that is not a pod %#v", obj)).Error("Failed to process delete notification")
+			return
+		}
+	}
+	controllerRef := v1.GetControllerOf(pod)
+	if controllerRef == nil {
+		return
+	}
+	vm := c.resolveControllerRef(pod.Namespace, controllerRef)
+	if vm == nil {
+		return
+	}
+	c.enqueueVm(vm)
+}
+
 func (c *VMController) addVm(obj interface{}) {
 	c.enqueueVm(obj)
 }
@@ -1183,6 +1275,10 @@ func (c *VMController) updateStatus(vmOrig *virtv1.VirtualMachine, vmi *virtv1.V
 		c.removeCondition(vm, virtv1.VirtualMachinePaused)
 	}
 
+	if err := c.updateMemoryDumpRequest(vm, vmi); err != nil {
+		return err
+	}
+
 	// only update if necessary
 	err = nil
 	if !reflect.DeepEqual(vm.Status, vmOrig.Status) {
@@ -1192,6 +1288,163 @@ func (c *VMController) updateStatus(vmOrig *virtv1.VirtualMachine, vmi *virtv1.V
 	return err
 }
 
+// getMemoryDumpPod returns the memory dump pod of the VirtualMachine from the cache, or nil if there is none
+func (c *VMController) getMemoryDumpPod(vm *virtv1.VirtualMachine) (*k8score.Pod, error) {
+	obj, exists, err := c.podInformer.GetStore().GetByKey(vm.Namespace + "/" + memorydump.PodName(vm))
+	if err != nil || !exists {
+		return nil, err
+	}
+	pod := obj.(*k8score.Pod)
+	if !v1.IsControlledBy(pod, vm) {
+		return nil, nil
+	}
+	return pod, nil
+}
+
+// getLauncherPod returns the running virt-launcher pod of the VMI on its current node, or nil if there is none
+func (c *VMController) getLauncherPod(vmi *virtv1.VirtualMachineInstance) (*k8score.Pod, error) {
+	objs, err := c.podInformer.GetIndexer().ByIndex(cache.NamespaceIndex, vmi.Namespace)
+	if err != nil {
+		return nil, err
+	}
+	for _, obj := range objs {
+		pod := obj.(*k8score.Pod)
+		if !controller.IsControlledBy(pod, vmi) || pod.Spec.NodeName != vmi.Status.NodeName {
+			continue
+		}
+		if pod.Status.Phase == k8score.PodRunning && pod.DeletionTimestamp == nil {
+			return pod, nil
+		}
+	}
+	return nil, nil
+}
+
+// isCurrentMemoryDumpPod returns true if the pod copies the dump the VirtualMachine currently asks for
+func isCurrentMemoryDumpPod(pod *k8score.Pod, request *virtv1.VirtualMachineMemoryDumpRequest) bool {
+	return request.FileName != nil && pod.Annotations[memorydump.FileNameAnnotation] == *request.FileName
+}
+
+// handleMemoryDump creates the memory dump pod which copies a requested dump into the PVC
+// and deletes it again once the dump is removed or superseded by a newer one.
+func (c *VMController) handleMemoryDump(vm *virtv1.VirtualMachine, vmi *virtv1.VirtualMachineInstance) error {
+	pod, err := c.getMemoryDumpPod(vm)
+	if err != nil {
+		return err
+	}
+	request := vm.Status.MemoryDumpRequest
+
+	if pod != nil {
+		if request != nil && !request.Remove && isCurrentMemoryDumpPod(pod, request) {
+			return nil
+		}
+		if pod.DeletionTimestamp != nil {
+			return nil
+		}
+		log.Log.Object(vm).Infof("Deleting memory dump pod %s", pod.Name)
+		err := c.clientset.CoreV1().Pods(pod.Namespace).Delete(pod.Name, &v1.DeleteOptions{})
+		if err != nil && !errors.IsNotFound(err) {
+			return err
+		}
+		return nil
+	}
+
+	if request == nil || request.Remove || request.Phase != virtv1.MemoryDumpInProgress {
+		return nil
+	}
+	if vmi == nil || vmi.IsFinal() {
+		// updateMemoryDumpRequest marks the dump as failed
+		return nil
+	}
+
+	launcherPod, err := c.getLauncherPod(vmi)
+	if err != nil {
+		return err
+	}
+	if launcherPod == nil {
+		return fmt.Errorf("no running virt-launcher pod of VMI %s on node %s", vmi.Name, vmi.Status.NodeName)
+	}
+	pod, err = c.templateService.RenderMemoryDumpManifest(vm, vmi, launcherPod)
+	if err != nil {
+		return err
+	}
+	log.Log.Object(vm).Infof("Creating memory dump pod %s", pod.Name)
+	_, err = c.clientset.CoreV1().Pods(vm.Namespace).Create(pod)
+	if err != nil && !errors.IsAlreadyExists(err) {
+		c.recorder.Eventf(vm, k8score.EventTypeWarning, FailedCreatePodReason, "Error creating memory dump pod: %v", err)
+		return err
+	}
+	return nil
+}
+
+// updateMemoryDumpRequest reflects the state of the memory dump pod in the memory dump request of the VirtualMachine
+func (c *VMController) updateMemoryDumpRequest(vm *virtv1.VirtualMachine, vmi *virtv1.VirtualMachineInstance) error {
+	request := vm.Status.MemoryDumpRequest
+	if request == nil {
+		return nil
+	}
+	pod, err := c.getMemoryDumpPod(vm)
+	if err != nil {
+		return err
+	}
+
+	if request.Remove {
+		if pod == nil {
+			log.Log.Object(vm).V(3).Info("Memory dump pod is gone, clearing memory dump request")
+			vm.Status.MemoryDumpRequest = nil
+		}
+		return nil
+	}
+	if request.Phase != virtv1.MemoryDumpInProgress {
+		return nil
+	}
+
+	now := v1.Now()
+	if vmi == nil || vmi.IsFinal() {
+		request.Phase = virtv1.MemoryDumpFailed
+		request.EndTimestamp = &now
+		request.Message = "VMI stopped before the memory dump completed"
+		return nil
+	}
+	if pod == nil || !isCurrentMemoryDumpPod(pod, request) {
+		return nil
+	}
+
+	switch {
+	case pod.Status.Phase == k8score.PodFailed:
+		request.Phase = virtv1.MemoryDumpFailed
+		request.EndTimestamp = &now
+		request.Message = memoryDumpPodFailureMessage(pod)
+		c.recorder.Eventf(vm, k8score.EventTypeWarning, MemoryDumpFailedReason, "Memory dump into PVC %s failed: %s", request.ClaimName, request.Message)
+	case isMemoryDumpCopied(pod):
+		request.Phase = virtv1.MemoryDumpCompleted
+		request.EndTimestamp = &now
+		c.recorder.Eventf(vm, k8score.EventTypeNormal, MemoryDumpCompletedReason, "Memory dump into PVC %s completed", request.ClaimName)
+	}
+	return nil
+}
+
+// isMemoryDumpCopied returns true once the readiness probe of the memory dump pod found the dump in the PVC
+func isMemoryDumpCopied(pod *k8score.Pod) bool {
+	for _, status := range pod.Status.ContainerStatuses {
+		if status.Name == memorydump.ContainerName {
+			return status.Ready
+		}
+	}
+	return false
+}
+
+func memoryDumpPodFailureMessage(pod *k8score.Pod) string {
+	for _, status := range pod.Status.ContainerStatuses {
+		if status.Name == memorydump.ContainerName && status.State.Terminated != nil && status.State.Terminated.Message != "" {
+			return status.State.Terminated.Message
+		}
+	}
+	if pod.Status.Message != "" {
+		return pod.Status.Message
+	}
+	return "memory dump pod failed"
+}
+
 func (c *VMController) getVirtualMachineBaseName(vm *virtv1.VirtualMachine) string {
 
 	// TODO defaulting should make sure that the right field is set, instead of doing this
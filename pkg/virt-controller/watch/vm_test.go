@@ -14,6 +14,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
 	"k8s.io/client-go/testing"
 	"k8s.io/client-go/tools/cache"
 	framework "k8s.io/client-go/tools/cache/testing"
@@ -26,6 +27,7 @@ import (
 	cdifake "kubevirt.io/containerized-data-importer/pkg/client/clientset/versioned/fake"
 	virtcontroller "kubevirt.io/kubevirt/pkg/controller"
 	"kubevirt.io/kubevirt/pkg/testutils"
+	"kubevirt.io/kubevirt/pkg/virt-controller/services"
 )
 
 var _ = Describe("VirtualMachine", func() {
@@ -41,6 +43,8 @@ var _ = Describe("VirtualMachine", func() {
 		var vmInformer cache.SharedIndexInformer
 		var dataVolumeInformer cache.SharedIndexInformer
 		var dataVolumeSource *framework.FakeControllerSource
+		var podInformer cache.SharedIndexInformer
+		var podSource *framework.FakeControllerSource
 		var stop chan struct{}
 		var controller *VMController
 		var recorder *record.FakeRecorder
@@ -48,12 +52,15 @@ var _ = Describe("VirtualMachine", func() {
 		var vmiFeeder *testutils.VirtualMachineFeeder
 		var dataVolumeFeeder *testutils.DataVolumeFeeder
 		var cdiClient *cdifake.Clientset
+		var podFeeder *testutils.PodFeeder
+		var kubeClient *fake.Clientset
 
 		syncCaches := func(stop chan struct{}) {
 			go vmiInformer.Run(stop)
 			go vmInformer.Run(stop)
 			go dataVolumeInformer.Run(stop)
-			Expect(cache.WaitForCacheSync(stop, vmiInformer.HasSynced, vmInformer.HasSynced)).To(BeTrue())
+			go podInformer.Run(stop)
+			Expect(cache.WaitForCacheSync(stop, vmiInformer.HasSynced, vmInformer.HasSynced, podInformer.HasSynced)).To(BeTrue())
 		}
 
 		BeforeEach(func() {
@@ -66,20 +73,39 @@ var _ = Describe("VirtualMachine", func() {
 			dataVolumeInformer, dataVolumeSource = testutils.NewFakeInformerFor(&cdiv1.DataVolume{})
 			vmiInformer, vmiSource = testutils.NewFakeInformerFor(&v1.VirtualMachineInstance{})
 			vmInformer, vmSource = testutils.NewFakeInformerFor(&v1.VirtualMachine{})
+			podInformer, podSource = testutils.NewFakeInformerFor(&k8sv1.Pod{})
 			recorder = record.NewFakeRecorder(100)
 
-			controller = NewVMController(vmiInformer, vmInformer, dataVolumeInformer, recorder, virtClient)
+			config, _, _ := testutils.NewFakeClusterConfig(&k8sv1.ConfigMap{})
+			pvcInformer, _ := testutils.NewFakeInformerFor(&k8sv1.PersistentVolumeClaim{})
+			controller = NewVMController(
+				services.NewTemplateService("a", "b", "c", "d", "e", "f", pvcInformer.GetStore(), virtClient, config),
+				vmiInformer,
+				vmInformer,
+				dataVolumeInformer,
+				podInformer,
+				recorder,
+				virtClient)
 			// Wrap our workqueue to have a way to detect when we are done processing updates
 			mockQueue = testutils.NewMockWorkQueue(controller.Queue)
 			controller.Queue = mockQueue
 
 			vmiFeeder = testutils.NewVirtualMachineFeeder(mockQueue, vmiSource)
 			dataVolumeFeeder = testutils.NewDataVolumeFeeder(mockQueue, dataVolumeSource)
+			podFeeder = testutils.NewPodFeeder(mockQueue, podSource)
 
 			// Set up mock client
 			virtClient.EXPECT().VirtualMachineInstance(metav1.NamespaceDefault).Return(vmiInterface).AnyTimes()
 			virtClient.EXPECT().VirtualMachine(metav1.NamespaceDefault).Return(vmInterface).AnyTimes()
 
+			kubeClient = fake.NewSimpleClientset()
+			virtClient.EXPECT().CoreV1().Return(kubeClient.CoreV1()).AnyTimes()
+			// Make sure that all unexpected calls to kubeClient will fail
+			kubeClient.Fake.PrependReactor("*", "*", func(action testing.Action) (handled bool, obj runtime.Object, err error) {
+				Expect(action).To(BeNil())
+				return true, nil, nil
+			})
+
 			cdiClient = cdifake.NewSimpleClientset()
 			virtClient.EXPECT().CdiClient().Return(cdiClient).AnyTimes()
 			cdiClient.Fake.PrependReactor("*", "*", func(action testing.Action) (handled bool, obj runtime.Object, err error) {
@@ -857,6 +883,189 @@ var _ = Describe("VirtualMachine", func() {
 			controller.Execute()
 		})
 
+		Context("memory dump", func() {
+			var fileName string
+
+			memoryDumpVirtualMachine := func(phase v1.MemoryDumpPhase) (*v1.VirtualMachine, *v1.VirtualMachineInstance) {
+				vm, vmi := DefaultVirtualMachine(true)
+				markAsReady(vmi)
+				vmi.Status.NodeName = "node01"
+				vm.Status.Created = true
+				vm.Status.Ready = true
+				fileName = "testvmi-dump-claim-20200101-000000.memory.dump"
+				vm.Status.MemoryDumpRequest = &v1.VirtualMachineMemoryDumpRequest{
+					ClaimName: "dump-claim",
+					Phase:     phase,
+					FileName:  &fileName,
+				}
+				return vm, vmi
+			}
+
+			memoryDumpPod := func(vm *v1.VirtualMachine) *k8sv1.Pod {
+				return &k8sv1.Pod{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:            "virt-memory-dump-" + vm.Name,
+						Namespace:       vm.Namespace,
+						Annotations:     map[string]string{"kubevirt.io/memory-dump-file": fileName},
+						OwnerReferences: []metav1.OwnerReference{*metav1.NewControllerRef(vm, v1.VirtualMachineGroupVersionKind)},
+					},
+				}
+			}
+
+			launcherPod := func(vmi *v1.VirtualMachineInstance, nodeName string) *k8sv1.Pod {
+				return &k8sv1.Pod{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:            "virt-launcher-" + vmi.Name + "-" + nodeName,
+						Namespace:       vmi.Namespace,
+						UID:             types.UID("launcher-" + nodeName),
+						OwnerReferences: []metav1.OwnerReference{*metav1.NewControllerRef(vmi, v1.VirtualMachineInstanceGroupVersionKind)},
+					},
+					Spec:   k8sv1.PodSpec{NodeName: nodeName},
+					Status: k8sv1.PodStatus{Phase: k8sv1.PodRunning},
+				}
+			}
+
+			It("should create the memory dump pod on the node of the VMI", func() {
+				vm, vmi := memoryDumpVirtualMachine(v1.MemoryDumpInProgress)
+				addVirtualMachine(vm)
+				vmiFeeder.Add(vmi)
+				// Launcher pods don't wake up the VirtualMachine, the one on node02 is e.g. the target of a migration
+				Expect(podInformer.GetStore().Add(launcherPod(vmi, "node02"))).To(Succeed())
+				Expect(podInformer.GetStore().Add(launcherPod(vmi, "node01"))).To(Succeed())
+
+				created := false
+				kubeClient.Fake.PrependReactor("create", "pods", func(action testing.Action) (handled bool, obj runtime.Object, err error) {
+					pod := action.(testing.CreateAction).GetObject().(*k8sv1.Pod)
+					Expect(pod.Name).To(Equal("virt-memory-dump-testvmi"))
+					Expect(pod.Spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms[0].MatchFields[0].Values).To(ConsistOf("node01"))
+					Expect(pod.Spec.Volumes[0].HostPath.Path).To(Equal("/var/lib/kubelet/pods/launcher-node01/volumes/kubernetes.io~empty-dir/memory-dump"))
+					created = true
+					return true, pod, nil
+				})
+
+				controller.Execute()
+				Expect(created).To(BeTrue())
+			})
+
+			It("should not create the memory dump pod without a running virt-launcher pod", func() {
+				vm, vmi := memoryDumpVirtualMachine(v1.MemoryDumpInProgress)
+				addVirtualMachine(vm)
+				vmiFeeder.Add(vmi)
+
+				controller.Execute()
+				Expect(mockQueue.GetRateLimitedEnqueueCount()).To(Equal(1))
+			})
+
+			It("should replace a memory dump pod of a previous dump", func() {
+				vm, vmi := memoryDumpVirtualMachine(v1.MemoryDumpInProgress)
+				addVirtualMachine(vm)
+				vmiFeeder.Add(vmi)
+				pod := memoryDumpPod(vm)
+				pod.Annotations["kubevirt.io/memory-dump-file"] = "previous.memory.dump"
+				podFeeder.Add(pod)
+
+				deleted := false
+				kubeClient.Fake.PrependReactor("delete", "pods", func(action testing.Action) (handled bool, obj runtime.Object, err error) {
+					Expect(action.(testing.DeleteAction).GetName()).To(Equal(pod.Name))
+					deleted = true
+					return true, nil, nil
+				})
+
+				controller.Execute()
+				Expect(deleted).To(BeTrue())
+			})
+
+			It("should complete the memory dump once the pod is ready", func() {
+				vm, vmi := memoryDumpVirtualMachine(v1.MemoryDumpInProgress)
+				addVirtualMachine(vm)
+				vmiFeeder.Add(vmi)
+				pod := memoryDumpPod(vm)
+				pod.Status.Phase = k8sv1.PodRunning
+				pod.Status.ContainerStatuses = []k8sv1.ContainerStatus{{Name: "memory-dump", Ready: true}}
+				podFeeder.Add(pod)
+
+				vmInterface.EXPECT().Update(gomock.Any()).Do(func(obj interface{}) {
+					request := obj.(*v1.VirtualMachine).Status.MemoryDumpRequest
+					Expect(request.Phase).To(Equal(v1.MemoryDumpCompleted))
+					Expect(request.EndTimestamp).ToNot(BeNil())
+				}).Return(vm, nil)
+
+				controller.Execute()
+				testutils.ExpectEvent(recorder, MemoryDumpCompletedReason)
+			})
+
+			It("should fail the memory dump with the termination message of the pod", func() {
+				vm, vmi := memoryDumpVirtualMachine(v1.MemoryDumpInProgress)
+				addVirtualMachine(vm)
+				vmiFeeder.Add(vmi)
+				pod := memoryDumpPod(vm)
+				pod.Status.Phase = k8sv1.PodFailed
+				pod.Status.ContainerStatuses = []k8sv1.ContainerStatus{{
+					Name: "memory-dump",
+					State: k8sv1.ContainerState{
+						Terminated: &k8sv1.ContainerStateTerminated{ExitCode: 1, Message: "failed to dump the memory: no space left"},
+					},
+				}}
+				podFeeder.Add(pod)
+
+				vmInterface.EXPECT().Update(gomock.Any()).Do(func(obj interface{}) {
+					request := obj.(*v1.VirtualMachine).Status.MemoryDumpRequest
+					Expect(request.Phase).To(Equal(v1.MemoryDumpFailed))
+					Expect(request.Message).To(Equal("failed to dump the memory: no space left"))
+				}).Return(vm, nil)
+
+				controller.Execute()
+				testutils.ExpectEvent(recorder, MemoryDumpFailedReason)
+			})
+
+			It("should fail the memory dump if the VMI is gone", func() {
+				vm, _ := memoryDumpVirtualMachine(v1.MemoryDumpInProgress)
+				running := false
+				vm.Spec.Running = &running
+				vm.Status.Created = false
+				vm.Status.Ready = false
+				addVirtualMachine(vm)
+
+				vmInterface.EXPECT().Update(gomock.Any()).Do(func(obj interface{}) {
+					request := obj.(*v1.VirtualMachine).Status.MemoryDumpRequest
+					Expect(request.Phase).To(Equal(v1.MemoryDumpFailed))
+					Expect(request.Message).To(ContainSubstring("VMI stopped"))
+				}).Return(vm, nil)
+
+				controller.Execute()
+			})
+
+			It("should delete the memory dump pod when the dump is removed", func() {
+				vm, vmi := memoryDumpVirtualMachine(v1.MemoryDumpDissociating)
+				vm.Status.MemoryDumpRequest.Remove = true
+				addVirtualMachine(vm)
+				vmiFeeder.Add(vmi)
+				podFeeder.Add(memoryDumpPod(vm))
+
+				deleted := false
+				kubeClient.Fake.PrependReactor("delete", "pods", func(action testing.Action) (handled bool, obj runtime.Object, err error) {
+					deleted = true
+					return true, nil, nil
+				})
+
+				controller.Execute()
+				Expect(deleted).To(BeTrue())
+			})
+
+			It("should clear the memory dump request once the pod is gone", func() {
+				vm, vmi := memoryDumpVirtualMachine(v1.MemoryDumpDissociating)
+				vm.Status.MemoryDumpRequest.Remove = true
+				addVirtualMachine(vm)
+				vmiFeeder.Add(vmi)
+
+				vmInterface.EXPECT().Update(gomock.Any()).Do(func(obj interface{}) {
+					Expect(obj.(*v1.VirtualMachine).Status.MemoryDumpRequest).To(BeNil())
+				}).Return(vm, nil)
+
+				controller.Execute()
+			})
+		})
+
 		It("should back off if a sync error occurs", func() {
 			vm, vmi := DefaultVirtualMachine(false)
 
@@ -23,6 +23,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"reflect"
+	"strings"
 	"time"
 
 	k8sv1 "k8s.io/api/core/v1"
@@ -97,6 +98,20 @@ const (
 	SuccessfulAbortMigrationReason = "SuccessfulAbortMigration"
 	// FailedAbortMigrationReason is added when an attempt to abort migration fails
 	FailedAbortMigrationReason = "FailedAbortMigration"
+	// FailedUpdateMigratedVolumesReason is added when the VMI or VirtualMachine could not be switched to the PVCs its volumes were migrated to
+	FailedUpdateMigratedVolumesReason = "FailedUpdateMigratedVolumes"
+	// MemoryDumpCompletedReason is added when the memory dump of a VirtualMachine was copied into its PVC
+	MemoryDumpCompletedReason = "MemoryDumpCompleted"
+	// MemoryDumpFailedReason is added when the memory dump of a VirtualMachine failed
+	MemoryDumpFailedReason = "MemoryDumpFailed"
+	// SuccessfulBackupReason is added when a VirtualMachineBackup succeeded
+	SuccessfulBackupReason = "SuccessfulBackup"
+	// FailedBackupReason is added when a VirtualMachineBackup failed
+	FailedBackupReason = "FailedBackup"
+	// ExportReadyReason is added when the exporter of a VirtualMachineExport became ready
+	ExportReadyReason = "ExportReady"
+	// FailedExportReason is added when the exporter of a VirtualMachineExport could not be created
+	FailedExportReason = "FailedExport"
 )
 
 func NewVMIController(templateService services.TemplateService,
@@ -104,7 +119,8 @@ func NewVMIController(templateService services.TemplateService,
 	podInformer cache.SharedIndexInformer,
 	recorder record.EventRecorder,
 	clientset kubecli.KubevirtClient,
-	dataVolumeInformer cache.SharedIndexInformer) *VMIController {
+	dataVolumeInformer cache.SharedIndexInformer,
+	pvcInformer cache.SharedIndexInformer) *VMIController {
 
 	c := &VMIController{
 		templateService:    templateService,
@@ -115,6 +131,7 @@ func NewVMIController(templateService services.TemplateService,
 		clientset:          clientset,
 		podExpectations:    controller.NewUIDTrackingControllerExpectations(controller.NewControllerExpectations()),
 		dataVolumeInformer: dataVolumeInformer,
+		pvcInformer:        pvcInformer,
 	}
 
 	c.vmiInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
@@ -135,6 +152,10 @@ func NewVMIController(templateService services.TemplateService,
 		UpdateFunc: c.updateDataVolume,
 	})
 
+	c.pvcInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		UpdateFunc: c.updatePVC,
+	})
+
 	return c
 }
 
@@ -165,6 +186,7 @@ type VMIController struct {
 	recorder           record.EventRecorder
 	podExpectations    *controller.UIDTrackingControllerExpectations
 	dataVolumeInformer cache.SharedIndexInformer
+	pvcInformer        cache.SharedIndexInformer
 }
 
 func (c *VMIController) Run(threadiness int, stopCh <-chan struct{}) {
@@ -173,7 +195,7 @@ func (c *VMIController) Run(threadiness int, stopCh <-chan struct{}) {
 	log.Log.Info("Starting vmi controller.")
 
 	// Wait for cache sync before we start the pod controller
-	cache.WaitForCacheSync(stopCh, c.vmiInformer.HasSynced, c.podInformer.HasSynced, c.dataVolumeInformer.HasSynced)
+	cache.WaitForCacheSync(stopCh, c.vmiInformer.HasSynced, c.podInformer.HasSynced, c.dataVolumeInformer.HasSynced, c.pvcInformer.HasSynced)
 
 	// Start the actual work
 	for i := 0; i < threadiness; i++ {
@@ -380,6 +402,10 @@ func (c *VMIController) updateStatus(vmi *virtv1.VirtualMachineInstance, pod *k8
 			conditionManager.RemoveCondition(vmiCopy, virtv1.VirtualMachineInstanceConditionType(k8sv1.PodReady))
 		}
 
+		if err := c.syncVolumeCapacities(vmi); err != nil {
+			return err
+		}
+
 		// We don't own the object anymore, so patch instead of update
 		if !reflect.DeepEqual(vmiCopy.Status.Conditions, vmi.Status.Conditions) {
 			newConditions, err := json.Marshal(vmiCopy.Status.Conditions)
@@ -794,6 +820,94 @@ func (c *VMIController) resolveControllerRef(namespace string, controllerRef *v1
 }
 
 // takes a namespace and returns all Pods from the pod cache which run in this namespace
+// syncVolumeCapacities records the capacities of the PersistentVolumeClaims of
+// a running VMI in an annotation. An expanded claim changes the annotation,
+// which makes virt-handler re-read the claims of the VMI and grow its disks.
+func (c *VMIController) syncVolumeCapacities(vmi *virtv1.VirtualMachineInstance) error {
+	capacities := c.volumeCapacities(vmi)
+	if capacities == "" || vmi.Annotations[virtv1.VolumeCapacitiesAnnotation] == capacities {
+		return nil
+	}
+
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": map[string]string{
+				virtv1.VolumeCapacitiesAnnotation: capacities,
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+	log.Log.V(3).Object(vmi).Infof("Patching VMI volume capacities to %s", capacities)
+	_, err = c.clientset.VirtualMachineInstance(vmi.Namespace).Patch(vmi.Name, types.MergePatchType, patch)
+	if err != nil {
+		return fmt.Errorf("patching vmi volume capacities failed: %v", err)
+	}
+	return nil
+}
+
+// volumeCapacities returns the known capacities of the PersistentVolumeClaims of the VMI as volume=capacity list
+func (c *VMIController) volumeCapacities(vmi *virtv1.VirtualMachineInstance) string {
+	capacities := []string{}
+	for _, volume := range vmi.Spec.Volumes {
+		if volume.PersistentVolumeClaim == nil {
+			continue
+		}
+		obj, exists, err := c.pvcInformer.GetStore().GetByKey(vmi.Namespace + "/" + volume.PersistentVolumeClaim.ClaimName)
+		if err != nil || !exists {
+			continue
+		}
+		capacity, ok := obj.(*k8sv1.PersistentVolumeClaim).Status.Capacity[k8sv1.ResourceStorage]
+		if !ok {
+			continue
+		}
+		capacities = append(capacities, fmt.Sprintf("%s=%s", volume.Name, capacity.String()))
+	}
+	return strings.Join(capacities, ",")
+}
+
+// updatePVC enqueues the running VMIs which use a PersistentVolumeClaim whose capacity increased
+func (c *VMIController) updatePVC(old, cur interface{}) {
+	curPVC := cur.(*k8sv1.PersistentVolumeClaim)
+	oldPVC := old.(*k8sv1.PersistentVolumeClaim)
+	curCapacity := curPVC.Status.Capacity[k8sv1.ResourceStorage]
+	oldCapacity := oldPVC.Status.Capacity[k8sv1.ResourceStorage]
+	if curCapacity.Cmp(oldCapacity) <= 0 {
+		return
+	}
+
+	vmis, err := c.listVMIsMatchingPVC(curPVC.Namespace, curPVC.Name)
+	if err != nil {
+		log.Log.V(4).Object(curPVC).Errorf("Error encountered during persistentvolumeclaim update: %v", err)
+		return
+	}
+	for _, vmi := range vmis {
+		if vmi.IsRunning() {
+			log.Log.V(4).Object(curPVC).Infof("PersistentVolumeClaim expanded to %s for vmi %s", curCapacity.String(), vmi.Name)
+			c.enqueueVirtualMachine(vmi)
+		}
+	}
+}
+
+func (c *VMIController) listVMIsMatchingPVC(namespace string, claimName string) ([]*virtv1.VirtualMachineInstance, error) {
+	objs, err := c.vmiInformer.GetIndexer().ByIndex(cache.NamespaceIndex, namespace)
+	if err != nil {
+		return nil, err
+	}
+	vmis := []*virtv1.VirtualMachineInstance{}
+	for _, obj := range objs {
+		vmi := obj.(*virtv1.VirtualMachineInstance)
+		for _, volume := range vmi.Spec.Volumes {
+			if volume.PersistentVolumeClaim != nil && volume.PersistentVolumeClaim.ClaimName == claimName {
+				vmis = append(vmis, vmi)
+				break
+			}
+		}
+	}
+	return vmis, nil
+}
+
 func (c *VMIController) listVMIsMatchingDataVolume(namespace string, dataVolumeName string) ([]*virtv1.VirtualMachineInstance, error) {
 	objs, err := c.vmiInformer.GetIndexer().ByIndex(cache.NamespaceIndex, namespace)
 	if err != nil {
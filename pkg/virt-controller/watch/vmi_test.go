@@ -171,7 +171,8 @@ var _ = Describe("VirtualMachineInstance watcher", func() {
 			podInformer,
 			recorder,
 			virtClient,
-			dataVolumeInformer)
+			dataVolumeInformer,
+			pvcInformer)
 		// Wrap our workqueue to have a way to detect when we are done processing updates
 		mockQueue = testutils.NewMockWorkQueue(controller.Queue)
 		controller.Queue = mockQueue
@@ -899,6 +900,77 @@ var _ = Describe("VirtualMachineInstance watcher", func() {
 			controller.Execute()
 		})
 
+		Context("with an expanded PersistentVolumeClaim", func() {
+			newClaim := func(namespace string, capacity string) *k8sv1.PersistentVolumeClaim {
+				return &k8sv1.PersistentVolumeClaim{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "testclaim",
+						Namespace: namespace,
+					},
+					Status: k8sv1.PersistentVolumeClaimStatus{
+						Capacity: k8sv1.ResourceList{
+							k8sv1.ResourceStorage: resource.MustParse(capacity),
+						},
+					},
+				}
+			}
+
+			newRunningVMIWithClaim := func() *v1.VirtualMachineInstance {
+				vmi := NewPendingVirtualMachine("testvmi")
+				vmi.Status.Phase = v1.Running
+				vmi.Spec.Volumes = []v1.Volume{
+					{
+						Name: "disk0",
+						VolumeSource: v1.VolumeSource{
+							PersistentVolumeClaim: &k8sv1.PersistentVolumeClaimVolumeSource{
+								ClaimName: "testclaim",
+							},
+						},
+					},
+				}
+				return vmi
+			}
+
+			It("should record the claim capacities on a running vmi", func() {
+				vmi := newRunningVMIWithClaim()
+				Expect(pvcInformer.GetStore().Add(newClaim(vmi.Namespace, "2Gi"))).To(Succeed())
+				addVirtualMachine(vmi)
+
+				patch := `{"metadata":{"annotations":{"kubevirt.io/volume-capacities":"disk0=2Gi"}}}`
+				vmiInterface.EXPECT().Patch(vmi.Name, types.MergePatchType, []byte(patch)).Return(vmi, nil)
+
+				controller.Execute()
+			})
+
+			It("should not patch a vmi whose claim capacities are up to date", func() {
+				vmi := newRunningVMIWithClaim()
+				vmi.Annotations[v1.VolumeCapacitiesAnnotation] = "disk0=2Gi"
+				Expect(pvcInformer.GetStore().Add(newClaim(vmi.Namespace, "2Gi"))).To(Succeed())
+				addVirtualMachine(vmi)
+
+				controller.Execute()
+			})
+
+			table.DescribeTable("on claim update", func(oldCapacity string, newCapacity string, phase v1.VirtualMachineInstancePhase, expectEnqueue bool) {
+				vmi := newRunningVMIWithClaim()
+				vmi.Status.Phase = phase
+				Expect(vmiInformer.GetStore().Add(vmi)).To(Succeed())
+
+				oldPVC := newClaim(vmi.Namespace, oldCapacity)
+				newPVC := newClaim(vmi.Namespace, newCapacity)
+				controller.updatePVC(oldPVC, newPVC)
+				if expectEnqueue {
+					Expect(mockQueue.Len()).To(Equal(1))
+				} else {
+					Expect(mockQueue.Len()).To(Equal(0))
+				}
+			},
+				table.Entry("should enqueue a running vmi if the claim was expanded", "1Gi", "2Gi", v1.Running, true),
+				table.Entry("should not enqueue a running vmi if the capacity did not increase", "2Gi", "2Gi", v1.Running, false),
+				table.Entry("should not enqueue a vmi which is not running", "1Gi", "2Gi", v1.Scheduled, false),
+			)
+		})
+
 		table.DescribeTable("should not add a ready condition if the vmi is", func(phase v1.VirtualMachineInstancePhase) {
 			vmi := NewPendingVirtualMachine("testvmi")
 			vmi.Status.Phase = phase
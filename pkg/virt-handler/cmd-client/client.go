@@ -69,10 +69,26 @@ type MigrationOptions struct {
 	AllowAutoConverge       bool
 }
 
+// BackupOptions identify a backup job and the checkpoints it works on
+type BackupOptions struct {
+	BackupName string
+	// Checkpoint is the name of the dirty bitmap which is created by the backup
+	Checkpoint string
+	// BaseCheckpoint is the dirty bitmap which is exported for incremental backups
+	BaseCheckpoint string
+	// Abort drops the checkpoint of the backup when it is finished
+	Abort bool
+}
+
 type LauncherClient interface {
 	SyncVirtualMachine(vmi *v1.VirtualMachineInstance, options *cmdv1.VirtualMachineOptions) error
 	PauseVirtualMachine(vmi *v1.VirtualMachineInstance) error
 	UnpauseVirtualMachine(vmi *v1.VirtualMachineInstance) error
+	SoftRebootVirtualMachine(vmi *v1.VirtualMachineInstance) error
+	MemoryDumpVirtualMachine(vmi *v1.VirtualMachineInstance, fileName string) error
+	ResizeVirtualMachineDisks(vmi *v1.VirtualMachineInstance) ([]string, error)
+	BackupVirtualMachine(vmi *v1.VirtualMachineInstance, options *BackupOptions) error
+	FinishVirtualMachineBackup(vmi *v1.VirtualMachineInstance, options *BackupOptions) error
 	SyncMigrationTarget(vmi *v1.VirtualMachineInstance) error
 	ShutdownVirtualMachine(vmi *v1.VirtualMachineInstance) error
 	KillVirtualMachine(vmi *v1.VirtualMachineInstance) error
@@ -81,6 +97,12 @@ type LauncherClient interface {
 	DeleteDomain(vmi *v1.VirtualMachineInstance) error
 	GetDomain() (*api.Domain, bool, error)
 	GetDomainStats() (*stats.DomainStats, bool, error)
+	GetScreenshot(vmi *v1.VirtualMachineInstance) ([]byte, error)
+	GetGuestInfo(vmi *v1.VirtualMachineInstance) (*v1.VirtualMachineInstanceGuestAgentInfo, error)
+	GetUsers(vmi *v1.VirtualMachineInstance) (*v1.VirtualMachineInstanceGuestOSUserList, error)
+	GetFilesystems(vmi *v1.VirtualMachineInstance) (*v1.VirtualMachineInstanceFileSystemList, error)
+	GuestExec(vmi *v1.VirtualMachineInstance, request *v1.VirtualMachineInstanceGuestExecRequest, timeout time.Duration) (*v1.VirtualMachineInstanceGuestExecResult, error)
+	GuestPing(vmi *v1.VirtualMachineInstance, timeout time.Duration) error
 	Ping() error
 	Close()
 }
@@ -253,6 +275,92 @@ func (c *VirtLauncherClient) UnpauseVirtualMachine(vmi *v1.VirtualMachineInstanc
 	return c.genericSendVMICmd("Unpause", c.v1client.UnpauseVirtualMachine, vmi, &cmdv1.VirtualMachineOptions{})
 }
 
+func (c *VirtLauncherClient) SoftRebootVirtualMachine(vmi *v1.VirtualMachineInstance) error {
+	return c.genericSendVMICmd("SoftReboot", c.v1client.SoftRebootVirtualMachine, vmi, &cmdv1.VirtualMachineOptions{})
+}
+
+func (c *VirtLauncherClient) MemoryDumpVirtualMachine(vmi *v1.VirtualMachineInstance, fileName string) error {
+	vmiJson, err := json.Marshal(vmi)
+	if err != nil {
+		return err
+	}
+
+	request := &cmdv1.MemoryDumpRequest{
+		Vmi: &cmdv1.VMI{
+			VmiJson: vmiJson,
+		},
+		FileName: fileName,
+	}
+
+	// The launcher only starts the memory dump, it does not wait for it to finish
+	ctx, cancel := context.WithTimeout(context.Background(), longTimeout)
+	defer cancel()
+	response, err := c.v1client.MemoryDumpVirtualMachine(ctx, request)
+
+	return handleError(err, "MemoryDump", response)
+}
+
+// ResizeVirtualMachineDisks grows the disks of a running domain and returns the names of the grown volumes
+func (c *VirtLauncherClient) ResizeVirtualMachineDisks(vmi *v1.VirtualMachineInstance) ([]string, error) {
+	request, err := newVMIRequest(vmi)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), longTimeout)
+	defer cancel()
+
+	resizeResponse, err := c.v1client.ResizeVirtualMachineDisks(ctx, request)
+	var response *cmdv1.Response
+	if resizeResponse != nil {
+		response = resizeResponse.Response
+	}
+
+	if err = handleError(err, "ResizeDisks", response); err != nil {
+		return nil, err
+	}
+	return resizeResponse.ResizedVolumes, nil
+}
+
+// BackupVirtualMachine starts a backup job which exports the disks of the domain over NBD
+func (c *VirtLauncherClient) BackupVirtualMachine(vmi *v1.VirtualMachineInstance, options *BackupOptions) error {
+	return c.sendBackupCmd("Backup", c.v1client.BackupVirtualMachine, vmi, options)
+}
+
+// FinishVirtualMachineBackup stops the NBD exports of a backup job and cleans it up
+func (c *VirtLauncherClient) FinishVirtualMachineBackup(vmi *v1.VirtualMachineInstance, options *BackupOptions) error {
+	return c.sendBackupCmd("FinishBackup", c.v1client.FinishVirtualMachineBackup, vmi, options)
+}
+
+func (c *VirtLauncherClient) sendBackupCmd(cmdName string,
+	cmdFunc func(ctx context.Context, request *cmdv1.BackupRequest, opts ...grpc.CallOption) (*cmdv1.Response, error),
+	vmi *v1.VirtualMachineInstance,
+	options *BackupOptions) error {
+
+	vmiJson, err := json.Marshal(vmi)
+	if err != nil {
+		return err
+	}
+
+	optionsJson, err := json.Marshal(options)
+	if err != nil {
+		return err
+	}
+
+	request := &cmdv1.BackupRequest{
+		Vmi: &cmdv1.VMI{
+			VmiJson: vmiJson,
+		},
+		Options: optionsJson,
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), longTimeout)
+	defer cancel()
+	response, err := cmdFunc(ctx, request)
+
+	return handleError(err, cmdName, response)
+}
+
 func (c *VirtLauncherClient) ShutdownVirtualMachine(vmi *v1.VirtualMachineInstance) error {
 	return c.genericSendVMICmd("Shutdown", c.v1client.ShutdownVirtualMachine, vmi, &cmdv1.VirtualMachineOptions{})
 }
@@ -359,6 +467,195 @@ func (c *VirtLauncherClient) GetDomainStats() (*stats.DomainStats, bool, error)
 	return stats, exists, nil
 }
 
+// GetScreenshot returns a PNG encoded screenshot of the primary display of the domain
+func (c *VirtLauncherClient) GetScreenshot(vmi *v1.VirtualMachineInstance) ([]byte, error) {
+	vmiJson, err := json.Marshal(vmi)
+	if err != nil {
+		return nil, err
+	}
+
+	request := &cmdv1.VMIRequest{
+		Vmi: &cmdv1.VMI{
+			VmiJson: vmiJson,
+		},
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), longTimeout)
+	defer cancel()
+
+	screenshotResponse, err := c.v1client.GetScreenshot(ctx, request)
+	var response *cmdv1.Response
+	if screenshotResponse != nil {
+		response = screenshotResponse.Response
+	}
+
+	if err = handleError(err, "GetScreenshot", response); err != nil {
+		return nil, err
+	}
+	return screenshotResponse.Png, nil
+}
+
+// GetGuestInfo returns the information the guest agent reports about the guest
+func (c *VirtLauncherClient) GetGuestInfo(vmi *v1.VirtualMachineInstance) (*v1.VirtualMachineInstanceGuestAgentInfo, error) {
+	request, err := newVMIRequest(vmi)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), longTimeout)
+	defer cancel()
+
+	guestInfoResponse, err := c.v1client.GetGuestInfo(ctx, request)
+	var response *cmdv1.Response
+	if guestInfoResponse != nil {
+		response = guestInfoResponse.Response
+	}
+
+	if err = handleError(err, "GetGuestInfo", response); err != nil {
+		return nil, err
+	}
+
+	guestInfo := &v1.VirtualMachineInstanceGuestAgentInfo{}
+	if err := json.Unmarshal([]byte(guestInfoResponse.GuestInfoResponse), guestInfo); err != nil {
+		log.Log.Reason(err).Error("error unmarshalling guest info")
+		return nil, err
+	}
+	return guestInfo, nil
+}
+
+// GetUsers returns the users which are logged into the guest
+func (c *VirtLauncherClient) GetUsers(vmi *v1.VirtualMachineInstance) (*v1.VirtualMachineInstanceGuestOSUserList, error) {
+	request, err := newVMIRequest(vmi)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), longTimeout)
+	defer cancel()
+
+	userListResponse, err := c.v1client.GetUsers(ctx, request)
+	var response *cmdv1.Response
+	if userListResponse != nil {
+		response = userListResponse.Response
+	}
+
+	if err = handleError(err, "GetUsers", response); err != nil {
+		return nil, err
+	}
+
+	userList := &v1.VirtualMachineInstanceGuestOSUserList{}
+	if err := json.Unmarshal([]byte(userListResponse.GuestUserListResponse), userList); err != nil {
+		log.Log.Reason(err).Error("error unmarshalling guest user list")
+		return nil, err
+	}
+	return userList, nil
+}
+
+// GetFilesystems returns the filesystems which are mounted in the guest
+func (c *VirtLauncherClient) GetFilesystems(vmi *v1.VirtualMachineInstance) (*v1.VirtualMachineInstanceFileSystemList, error) {
+	request, err := newVMIRequest(vmi)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), longTimeout)
+	defer cancel()
+
+	filesystemListResponse, err := c.v1client.GetFilesystems(ctx, request)
+	var response *cmdv1.Response
+	if filesystemListResponse != nil {
+		response = filesystemListResponse.Response
+	}
+
+	if err = handleError(err, "GetFilesystems", response); err != nil {
+		return nil, err
+	}
+
+	filesystemList := &v1.VirtualMachineInstanceFileSystemList{}
+	if err := json.Unmarshal([]byte(filesystemListResponse.GuestFilesystemListResponse), filesystemList); err != nil {
+		log.Log.Reason(err).Error("error unmarshalling guest filesystem list")
+		return nil, err
+	}
+	return filesystemList, nil
+}
+
+// GuestExecCallTimeout returns how long a GuestExec call may take, the launcher
+// waits until the command exited or it was killed after the timeout of the request
+func GuestExecCallTimeout(execRequest *v1.VirtualMachineInstanceGuestExecRequest) time.Duration {
+	execTimeout := time.Duration(execRequest.TimeoutSeconds) * time.Second
+	if execRequest.TimeoutSeconds <= 0 {
+		execTimeout = v1.GuestExecDefaultTimeoutSeconds * time.Second
+	}
+	return execTimeout + longTimeout
+}
+
+// GuestExec runs a command in the guest and returns its output once it exited.
+// The call fails after timeout, even if the command is still running in the guest.
+func (c *VirtLauncherClient) GuestExec(vmi *v1.VirtualMachineInstance, execRequest *v1.VirtualMachineInstanceGuestExecRequest, timeout time.Duration) (*v1.VirtualMachineInstanceGuestExecResult, error) {
+	vmiJson, err := json.Marshal(vmi)
+	if err != nil {
+		return nil, err
+	}
+	execRequestJson, err := json.Marshal(execRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	request := &cmdv1.GuestExecRequest{
+		Vmi: &cmdv1.VMI{
+			VmiJson: vmiJson,
+		},
+		ExecRequest: execRequestJson,
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	guestExecResponse, err := c.v1client.GuestExec(ctx, request)
+	var response *cmdv1.Response
+	if guestExecResponse != nil {
+		response = guestExecResponse.Response
+	}
+
+	if err = handleError(err, "GuestExec", response); err != nil {
+		return nil, err
+	}
+
+	result := &v1.VirtualMachineInstanceGuestExecResult{}
+	if err := json.Unmarshal([]byte(guestExecResponse.GuestExecResponse), result); err != nil {
+		log.Log.Reason(err).Error("error unmarshalling guest exec result")
+		return nil, err
+	}
+	return result, nil
+}
+
+// GuestPing checks whether the guest agent responds within timeout
+func (c *VirtLauncherClient) GuestPing(vmi *v1.VirtualMachineInstance, timeout time.Duration) error {
+	request, err := newVMIRequest(vmi)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	response, err := c.v1client.GuestPing(ctx, request)
+	return handleError(err, "GuestPing", response)
+}
+
+func newVMIRequest(vmi *v1.VirtualMachineInstance) (*cmdv1.VMIRequest, error) {
+	vmiJson, err := json.Marshal(vmi)
+	if err != nil {
+		return nil, err
+	}
+
+	return &cmdv1.VMIRequest{
+		Vmi: &cmdv1.VMI{
+			VmiJson: vmiJson,
+		},
+	}, nil
+}
+
 func (c *VirtLauncherClient) Ping() error {
 	request := &cmdv1.EmptyRequest{}
 	ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
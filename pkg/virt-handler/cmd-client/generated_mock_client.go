@@ -4,6 +4,8 @@
 package cmdclient
 
 import (
+	time "time"
+
 	gomock "github.com/golang/mock/gomock"
 
 	v1 "kubevirt.io/client-go/api/v1"
@@ -63,6 +65,57 @@ func (_mr *_MockLauncherClientRecorder) UnpauseVirtualMachine(arg0 interface{})
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "UnpauseVirtualMachine", arg0)
 }
 
+func (_m *MockLauncherClient) SoftRebootVirtualMachine(vmi *v1.VirtualMachineInstance) error {
+	ret := _m.ctrl.Call(_m, "SoftRebootVirtualMachine", vmi)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockLauncherClientRecorder) SoftRebootVirtualMachine(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "SoftRebootVirtualMachine", arg0)
+}
+
+func (_m *MockLauncherClient) MemoryDumpVirtualMachine(vmi *v1.VirtualMachineInstance, fileName string) error {
+	ret := _m.ctrl.Call(_m, "MemoryDumpVirtualMachine", vmi, fileName)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockLauncherClientRecorder) MemoryDumpVirtualMachine(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "MemoryDumpVirtualMachine", arg0, arg1)
+}
+
+func (_m *MockLauncherClient) ResizeVirtualMachineDisks(vmi *v1.VirtualMachineInstance) ([]string, error) {
+	ret := _m.ctrl.Call(_m, "ResizeVirtualMachineDisks", vmi)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockLauncherClientRecorder) ResizeVirtualMachineDisks(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "ResizeVirtualMachineDisks", arg0)
+}
+
+func (_m *MockLauncherClient) BackupVirtualMachine(vmi *v1.VirtualMachineInstance, options *BackupOptions) error {
+	ret := _m.ctrl.Call(_m, "BackupVirtualMachine", vmi, options)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockLauncherClientRecorder) BackupVirtualMachine(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "BackupVirtualMachine", arg0, arg1)
+}
+
+func (_m *MockLauncherClient) FinishVirtualMachineBackup(vmi *v1.VirtualMachineInstance, options *BackupOptions) error {
+	ret := _m.ctrl.Call(_m, "FinishVirtualMachineBackup", vmi, options)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockLauncherClientRecorder) FinishVirtualMachineBackup(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "FinishVirtualMachineBackup", arg0, arg1)
+}
+
 func (_m *MockLauncherClient) SyncMigrationTarget(vmi *v1.VirtualMachineInstance) error {
 	ret := _m.ctrl.Call(_m, "SyncMigrationTarget", vmi)
 	ret0, _ := ret[0].(error)
@@ -147,6 +200,71 @@ func (_mr *_MockLauncherClientRecorder) GetDomainStats() *gomock.Call {
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "GetDomainStats")
 }
 
+func (_m *MockLauncherClient) GetScreenshot(vmi *v1.VirtualMachineInstance) ([]byte, error) {
+	ret := _m.ctrl.Call(_m, "GetScreenshot", vmi)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockLauncherClientRecorder) GetScreenshot(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "GetScreenshot", arg0)
+}
+
+func (_m *MockLauncherClient) GetGuestInfo(vmi *v1.VirtualMachineInstance) (*v1.VirtualMachineInstanceGuestAgentInfo, error) {
+	ret := _m.ctrl.Call(_m, "GetGuestInfo", vmi)
+	ret0, _ := ret[0].(*v1.VirtualMachineInstanceGuestAgentInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockLauncherClientRecorder) GetGuestInfo(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "GetGuestInfo", arg0)
+}
+
+func (_m *MockLauncherClient) GetUsers(vmi *v1.VirtualMachineInstance) (*v1.VirtualMachineInstanceGuestOSUserList, error) {
+	ret := _m.ctrl.Call(_m, "GetUsers", vmi)
+	ret0, _ := ret[0].(*v1.VirtualMachineInstanceGuestOSUserList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockLauncherClientRecorder) GetUsers(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "GetUsers", arg0)
+}
+
+func (_m *MockLauncherClient) GetFilesystems(vmi *v1.VirtualMachineInstance) (*v1.VirtualMachineInstanceFileSystemList, error) {
+	ret := _m.ctrl.Call(_m, "GetFilesystems", vmi)
+	ret0, _ := ret[0].(*v1.VirtualMachineInstanceFileSystemList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockLauncherClientRecorder) GetFilesystems(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "GetFilesystems", arg0)
+}
+
+func (_m *MockLauncherClient) GuestExec(vmi *v1.VirtualMachineInstance, request *v1.VirtualMachineInstanceGuestExecRequest, timeout time.Duration) (*v1.VirtualMachineInstanceGuestExecResult, error) {
+	ret := _m.ctrl.Call(_m, "GuestExec", vmi, request, timeout)
+	ret0, _ := ret[0].(*v1.VirtualMachineInstanceGuestExecResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockLauncherClientRecorder) GuestExec(arg0, arg1, arg2 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "GuestExec", arg0, arg1, arg2)
+}
+
+func (_m *MockLauncherClient) GuestPing(vmi *v1.VirtualMachineInstance, timeout time.Duration) error {
+	ret := _m.ctrl.Call(_m, "GuestPing", vmi, timeout)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockLauncherClientRecorder) GuestPing(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "GuestPing", arg0, arg1)
+}
+
 func (_m *MockLauncherClient) Ping() error {
 	ret := _m.ctrl.Call(_m, "Ping")
 	ret0, _ := ret[0].(error)
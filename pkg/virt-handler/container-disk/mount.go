@@ -16,6 +16,8 @@ import (
 
 type Mounter struct {
 	PodIsolationDetector isolation.PodIsolationDetector
+	// SignatureVerifier is optional, if set the containerDisk images are verified before they get mounted
+	SignatureVerifier SignatureVerifier
 }
 
 // Mount takes a vmi and mounts all container disks of the VMI, so that they are visible for the qemu process.
@@ -24,37 +26,12 @@ func (m *Mounter) Mount(vmi *v1.VirtualMachineInstance, verify bool) error {
 	for i, volume := range vmi.Spec.Volumes {
 		if volume.ContainerDisk != nil {
 			targetFile := containerdisk.GenerateDiskTargetPathFromHostView(vmi, i)
-			nodeRes := isolation.NodeIsolationResult()
-
-			if isMounted, err := nodeRes.IsMounted(targetFile); err != nil {
-				return fmt.Errorf("failed to determine if %s is already mounted: %v", targetFile, err)
-			} else if !isMounted {
-				res, err := m.PodIsolationDetector.DetectForSocket(vmi, containerdisk.GenerateSocketPathFromHostView(vmi, i))
-				if err != nil {
-					return fmt.Errorf("failed to detect socket for containerDisk %v: %v", volume.Name, err)
-				}
-				mountInfo, err := res.MountInfoRoot()
-				if err != nil {
-					return fmt.Errorf("failed to detect root mount info of containerDisk  %v: %v", volume.Name, err)
-				}
-				nodeMountInfo, err := nodeRes.ParentMountInfoFor(mountInfo)
-				if err != nil {
-					return fmt.Errorf("failed to detect root mount point of containerDisk %v on the node: %v", volume.Name, err)
-				}
-				sourceFile, err := containerdisk.GetImage(filepath.Join(nodeRes.MountRoot(), nodeMountInfo.MountPoint), volume.ContainerDisk.Path)
-				if err != nil {
-					return fmt.Errorf("failed to find a sourceFile in containerDisk %v: %v", volume.Name, err)
-				}
-				f, err := os.Create(targetFile)
-				if err != nil {
-					return fmt.Errorf("failed to create mount point target %v: %v", targetFile, err)
-				}
-				f.Close()
-
-				out, err := exec.Command("/usr/bin/chroot", "--mount", "/proc/1/ns/mnt", "mount", "-o", "ro,bind", strings.TrimPrefix(sourceFile, nodeRes.MountRoot()), targetFile).CombinedOutput()
-				if err != nil {
-					return fmt.Errorf("failed to bindmount containerDisk %v: %v : %v", volume.Name, string(out), err)
-				}
+			socketPath := containerdisk.GenerateSocketPathFromHostView(vmi, i)
+			if err := m.verifySignature(vmi, &vmi.Spec.Volumes[i], targetFile); err != nil {
+				return err
+			}
+			if err := m.mountFromContainer(vmi, volume.Name, socketPath, volume.ContainerDisk.Path, targetFile); err != nil {
+				return err
 			}
 			if verify {
 				res, err := m.PodIsolationDetector.Detect(vmi)
@@ -72,6 +49,87 @@ func (m *Mounter) Mount(vmi *v1.VirtualMachineInstance, verify bool) error {
 			}
 		}
 	}
+	return m.mountKernelArtifacts(vmi)
+}
+
+// verifySignature verifies the image of a containerDisk volume which is not mounted yet.
+func (m *Mounter) verifySignature(vmi *v1.VirtualMachineInstance, volume *v1.Volume, targetFile string) error {
+	if m.SignatureVerifier == nil {
+		return nil
+	}
+	if isMounted, err := isolation.NodeIsolationResult().IsMounted(targetFile); err != nil {
+		return fmt.Errorf("failed to determine if %s is already mounted: %v", targetFile, err)
+	} else if isMounted {
+		return nil
+	}
+	return m.SignatureVerifier.Verify(vmi, volume)
+}
+
+// mountKernelArtifacts mounts the kernel and initrd of the kernel boot container, so that they are visible for the qemu process.
+func (m *Mounter) mountKernelArtifacts(vmi *v1.VirtualMachineInstance) error {
+	if !containerdisk.HasKernelBootContainer(vmi) {
+		return nil
+	}
+	container := vmi.Spec.Domain.Firmware.KernelBoot.Container
+	socketPath := containerdisk.GenerateKernelBootSocketPathFromHostView(vmi)
+
+	artifacts := map[string]string{
+		containerdisk.KernelBootKernel: container.KernelPath,
+		containerdisk.KernelBootInitrd: container.InitrdPath,
+	}
+	for artifact, path := range artifacts {
+		if path == "" {
+			continue
+		}
+		targetFile := containerdisk.GenerateKernelBootArtifactPathFromHostView(vmi, artifact)
+		if err := m.mountFromContainer(vmi, containerdisk.KernelBootName, socketPath, path, targetFile); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// mountFromContainer bind mounts the file at imagePath in the container behind socketPath read-only to targetFile.
+func (m *Mounter) mountFromContainer(vmi *v1.VirtualMachineInstance, name string, socketPath string, imagePath string, targetFile string) error {
+	nodeRes := isolation.NodeIsolationResult()
+
+	if isMounted, err := nodeRes.IsMounted(targetFile); err != nil {
+		return fmt.Errorf("failed to determine if %s is already mounted: %v", targetFile, err)
+	} else if isMounted {
+		return nil
+	}
+	res, err := m.PodIsolationDetector.DetectForSocket(vmi, socketPath)
+	if err != nil {
+		return fmt.Errorf("failed to detect socket for containerDisk %v: %v", name, err)
+	}
+	mountInfo, err := res.MountInfoRoot()
+	if err != nil {
+		return fmt.Errorf("failed to detect root mount info of containerDisk  %v: %v", name, err)
+	}
+	nodeMountInfo, err := nodeRes.ParentMountInfoFor(mountInfo)
+	if err != nil {
+		return fmt.Errorf("failed to detect root mount point of containerDisk %v on the node: %v", name, err)
+	}
+	root := filepath.Join(nodeRes.MountRoot(), nodeMountInfo.MountPoint)
+	sourceFile, err := containerdisk.GetImage(root, imagePath)
+	if err != nil {
+		return fmt.Errorf("failed to find a sourceFile in containerDisk %v: %v", name, err)
+	}
+	// The image path is user provided, make sure that symlinks in the image can't point outside of it
+	sourceFile, err = containerdisk.ResolveInRoot(root, strings.TrimPrefix(sourceFile, root))
+	if err != nil {
+		return fmt.Errorf("failed to resolve the sourceFile in containerDisk %v: %v", name, err)
+	}
+	f, err := os.Create(targetFile)
+	if err != nil {
+		return fmt.Errorf("failed to create mount point target %v: %v", targetFile, err)
+	}
+	f.Close()
+
+	out, err := exec.Command("/usr/bin/chroot", "--mount", "/proc/1/ns/mnt", "mount", "-o", "ro,bind", strings.TrimPrefix(sourceFile, nodeRes.MountRoot()), targetFile).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("failed to bindmount containerDisk %v: %v : %v", name, string(out), err)
+	}
 	return nil
 }
 
@@ -0,0 +1,73 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2020 Red Hat, Inc.
+ *
+ */
+
+package container_disk
+
+import (
+	"fmt"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/client-go/kubecli"
+	containerdisk "kubevirt.io/kubevirt/pkg/container-disk"
+	"kubevirt.io/kubevirt/pkg/container-disk/registry"
+	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
+)
+
+type SignatureVerifier interface {
+	// Verify checks that the image of the containerDisk volume is signed by one of the trusted keys
+	Verify(vmi *v1.VirtualMachineInstance, volume *v1.Volume) error
+}
+
+type signatureVerifier struct {
+	clientset     kubecli.KubevirtClient
+	clusterConfig *virtconfig.ClusterConfig
+}
+
+// NewSignatureVerifier returns a verifier which checks the signatures of pinned containerDisk images
+// against the public keys in the cluster config. Without configured keys every image is accepted.
+func NewSignatureVerifier(clientset kubecli.KubevirtClient, clusterConfig *virtconfig.ClusterConfig) SignatureVerifier {
+	return &signatureVerifier{
+		clientset:     clientset,
+		clusterConfig: clusterConfig,
+	}
+}
+
+func (v *signatureVerifier) Verify(vmi *v1.VirtualMachineInstance, volume *v1.Volume) error {
+	if !v.clusterConfig.ContainerDiskDigestsEnabled() {
+		return nil
+	}
+	keys := v.clusterConfig.GetContainerDiskPublicKeys()
+	if len(keys) == 0 {
+		return nil
+	}
+
+	digest, ok := containerdisk.GetImageDigest(vmi, volume)
+	if !ok {
+		return fmt.Errorf("containerDisk %s is not pinned to a digest, its signature can't be verified", volume.Name)
+	}
+	credentials, err := containerdisk.GetImagePullCredentials(v.clientset, vmi.Namespace, volume.ContainerDisk)
+	if err != nil {
+		return err
+	}
+	client := registry.NewClient(v.clusterConfig.GetInsecureRegistries())
+	if err := client.VerifySignature(volume.ContainerDisk.Image, digest, credentials, keys); err != nil {
+		return fmt.Errorf("failed to verify the signature of containerDisk %s: %v", volume.Name, err)
+	}
+	return nil
+}
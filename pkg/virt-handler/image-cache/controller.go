@@ -0,0 +1,303 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2020 Red Hat, Inc.
+ *
+ */
+
+package imagecache
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	k8sv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/client-go/kubecli"
+	"kubevirt.io/client-go/log"
+	containerdisk "kubevirt.io/kubevirt/pkg/container-disk"
+	"kubevirt.io/kubevirt/pkg/controller"
+)
+
+// Controller reports the images of all ContainerDiskImageCaches which are pulled on the
+// node of this virt-handler. virt-controller runs a puller DaemonSet for every cache, its
+// pod on this node runs one no-op container per image, so that the kubelet does not garbage
+// collect the images. The cached images are reported in the status of the cache and as node
+// labels, which the VMI pods use as scheduling hint.
+type Controller struct {
+	clientset          kubecli.KubevirtClient
+	host               string
+	Queue              workqueue.RateLimitingInterface
+	imageCacheInformer cache.SharedIndexInformer
+	pullerPodInformer  cache.SharedIndexInformer
+	recheckInterval    time.Duration
+}
+
+// NewController creates the image cache controller of the node host.
+// pullerPodInformer has to watch the puller pods which run on the node.
+func NewController(clientset kubecli.KubevirtClient, host string, imageCacheInformer cache.SharedIndexInformer, pullerPodInformer cache.SharedIndexInformer) *Controller {
+	c := &Controller{
+		clientset:          clientset,
+		host:               host,
+		Queue:              workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter()),
+		imageCacheInformer: imageCacheInformer,
+		pullerPodInformer:  pullerPodInformer,
+		recheckInterval:    1 * time.Minute,
+	}
+
+	c.imageCacheInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    c.enqueueImageCache,
+		DeleteFunc: c.enqueueImageCache,
+		UpdateFunc: func(_, curr interface{}) { c.enqueueImageCache(curr) },
+	})
+
+	c.pullerPodInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    c.enqueuePod,
+		DeleteFunc: c.enqueuePod,
+		UpdateFunc: func(_, curr interface{}) { c.enqueuePod(curr) },
+	})
+
+	return c
+}
+
+// PullerPodSelectors select the puller pods which run on the node host
+func PullerPodSelectors(host string) (fields.Selector, labels.Selector, error) {
+	labelSelector, err := labels.Parse(v1.ContainerDiskImageCacheLabel)
+	if err != nil {
+		return nil, nil, err
+	}
+	return fields.OneTermEqualSelector("spec.nodeName", host), labelSelector, nil
+}
+
+func (c *Controller) enqueueImageCache(obj interface{}) {
+	key, err := controller.KeyFunc(obj)
+	if err != nil {
+		log.Log.Reason(err).Error("Failed to extract key from ContainerDiskImageCache.")
+		return
+	}
+	c.Queue.Add(key)
+}
+
+func (c *Controller) enqueuePod(obj interface{}) {
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+	pod, ok := obj.(*k8sv1.Pod)
+	if !ok {
+		return
+	}
+	if name, exists := pod.Labels[v1.ContainerDiskImageCacheLabel]; exists {
+		c.Queue.Add(name)
+	}
+}
+
+// Run runs the image cache controller
+func (c *Controller) Run(threadiness int, stopCh <-chan struct{}) {
+	defer controller.HandlePanic()
+	defer c.Queue.ShutDown()
+	log.Log.Info("Starting containerDisk image cache controller.")
+
+	cache.WaitForCacheSync(stopCh, c.imageCacheInformer.HasSynced, c.pullerPodInformer.HasSynced)
+
+	for i := 0; i < threadiness; i++ {
+		go wait.Until(c.runWorker, time.Second, stopCh)
+	}
+
+	<-stopCh
+	log.Log.Info("Stopping containerDisk image cache controller.")
+}
+
+func (c *Controller) runWorker() {
+	for c.Execute() {
+	}
+}
+
+// Execute runs commands from the controller queue, if there is
+// an error it requeues the command. Returns false if the queue
+// is empty.
+func (c *Controller) Execute() bool {
+	key, quit := c.Queue.Get()
+	if quit {
+		return false
+	}
+	defer c.Queue.Done(key)
+	err := c.execute(key.(string))
+
+	if err != nil {
+		log.Log.Reason(err).Infof("reenqueuing ContainerDiskImageCache %v", key)
+		c.Queue.AddRateLimited(key)
+	} else {
+		log.Log.V(4).Infof("processed ContainerDiskImageCache %v", key)
+		c.Queue.Forget(key)
+	}
+	return true
+}
+
+func (c *Controller) execute(key string) error {
+	obj, exists, err := c.imageCacheInformer.GetStore().GetByKey(key)
+	if err != nil {
+		return err
+	}
+	pods := c.pullerPodsOf(key)
+
+	node, err := c.clientset.CoreV1().Nodes().Get(c.host, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to get node %s: %v", c.host, err)
+	}
+
+	if !exists {
+		return c.syncNodeLabels(node)
+	}
+	imageCache := obj.(*v1.ContainerDiskImageCache)
+
+	selected := selectsNode(imageCache, node)
+	var cachedImages []string
+	if selected && len(pods) == 1 {
+		cachedImages = getCachedImages(pods[0])
+	}
+
+	if err := c.updateStatus(imageCache, selected, cachedImages); err != nil {
+		return err
+	}
+	if err := c.syncNodeLabels(node); err != nil {
+		return err
+	}
+
+	// Node labels are not watched, check from time to time if the node is still selected
+	c.Queue.AddAfter(key, c.recheckInterval)
+	return nil
+}
+
+func selectsNode(imageCache *v1.ContainerDiskImageCache, node *k8sv1.Node) bool {
+	return labels.SelectorFromSet(imageCache.Spec.NodeSelector).Matches(labels.Set(node.Labels))
+}
+
+func (c *Controller) pullerPodsOf(name string) []*k8sv1.Pod {
+	var pods []*k8sv1.Pod
+	for _, obj := range c.pullerPodInformer.GetStore().List() {
+		pod := obj.(*k8sv1.Pod)
+		if pod.Labels[v1.ContainerDiskImageCacheLabel] == name && pod.DeletionTimestamp == nil {
+			pods = append(pods, pod)
+		}
+	}
+	return pods
+}
+
+// getCachedImages returns the images of all containers of the puller pod which the kubelet pulled
+func getCachedImages(pod *k8sv1.Pod) []string {
+	var images []string
+	for _, status := range pod.Status.ContainerStatuses {
+		if status.ImageID == "" {
+			continue
+		}
+		for _, container := range pod.Spec.Containers {
+			if container.Name == status.Name {
+				images = append(images, container.Image)
+			}
+		}
+	}
+	sort.Strings(images)
+	return images
+}
+
+// updateStatus reports the cached images of this node in the status of the cache
+func (c *Controller) updateStatus(imageCache *v1.ContainerDiskImageCache, selected bool, cachedImages []string) error {
+	nodes := []v1.ContainerDiskImageCacheNodeStatus{}
+	for _, node := range imageCache.Status.Nodes {
+		if node.NodeName != c.host {
+			nodes = append(nodes, node)
+		}
+	}
+	if selected {
+		nodes = append(nodes, v1.ContainerDiskImageCacheNodeStatus{
+			NodeName:     c.host,
+			CachedImages: cachedImages,
+		})
+		sort.Slice(nodes, func(i, j int) bool {
+			return nodes[i].NodeName < nodes[j].NodeName
+		})
+	}
+	if len(nodes) == 0 {
+		nodes = nil
+	}
+	if reflect.DeepEqual(nodes, imageCache.Status.Nodes) {
+		return nil
+	}
+
+	imageCacheCopy := imageCache.DeepCopy()
+	imageCacheCopy.Status.Nodes = nodes
+	if _, err := c.clientset.ContainerDiskImageCache().UpdateStatus(imageCacheCopy); err != nil {
+		return fmt.Errorf("failed to update the status of ContainerDiskImageCache %s: %v", imageCache.Name, err)
+	}
+	return nil
+}
+
+// syncNodeLabels labels the node with the images cached by all puller pods on the node
+func (c *Controller) syncNodeLabels(node *k8sv1.Node) error {
+	desired := map[string]string{}
+	for _, obj := range c.pullerPodInformer.GetStore().List() {
+		pod := obj.(*k8sv1.Pod)
+		if pod.DeletionTimestamp != nil {
+			continue
+		}
+		obj, exists, _ := c.imageCacheInformer.GetStore().GetByKey(pod.Labels[v1.ContainerDiskImageCacheLabel])
+		if !exists || !selectsNode(obj.(*v1.ContainerDiskImageCache), node) {
+			continue
+		}
+		for _, image := range getCachedImages(pod) {
+			desired[containerdisk.CachedImageNodeLabel(image)] = "true"
+		}
+	}
+
+	changes := map[string]interface{}{}
+	for label := range node.Labels {
+		if _, wanted := desired[label]; !wanted && strings.HasPrefix(label, v1.ContainerDiskCachedImageLabelPrefix) {
+			changes[label] = nil
+		}
+	}
+	for label, value := range desired {
+		if node.Labels[label] != value {
+			changes[label] = value
+		}
+	}
+	if len(changes) == 0 {
+		return nil
+	}
+
+	data, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"labels": changes,
+		},
+	})
+	if err != nil {
+		return err
+	}
+	if _, err := c.clientset.CoreV1().Nodes().Patch(c.host, types.MergePatchType, data); err != nil {
+		return fmt.Errorf("failed to label node %s with the cached containerDisk images: %v", c.host, err)
+	}
+	return nil
+}
@@ -0,0 +1,186 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2020 Red Hat, Inc.
+ *
+ */
+
+package imagecache
+
+import (
+	"encoding/json"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	k8sv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/cache"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/client-go/kubecli"
+	containerdisk "kubevirt.io/kubevirt/pkg/container-disk"
+	"kubevirt.io/kubevirt/pkg/testutils"
+)
+
+var _ = Describe("ContainerDiskImageCache controller", func() {
+	const host = "node01"
+	const image = "registry:5000/kubevirt/cirros-container-disk-demo:devel"
+
+	var ctrl *gomock.Controller
+	var imageCacheInterface *kubecli.MockContainerDiskImageCacheInterface
+	var kubeClient *fake.Clientset
+	var imageCacheInformer cache.SharedIndexInformer
+	var podInformer cache.SharedIndexInformer
+	var controller *Controller
+
+	newImageCache := func() *v1.ContainerDiskImageCache {
+		return &v1.ContainerDiskImageCache{
+			ObjectMeta: metav1.ObjectMeta{Name: "cache"},
+			Spec: v1.ContainerDiskImageCacheSpec{
+				Images:       []string{image},
+				NodeSelector: map[string]string{"cache": "true"},
+			},
+		}
+	}
+
+	newPullerPod := func(imageCache *v1.ContainerDiskImageCache, pulled bool) *k8sv1.Pod {
+		pod := &k8sv1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "puller",
+				Namespace: "kubevirt",
+				Labels: map[string]string{
+					v1.ContainerDiskImageCacheLabel: imageCache.Name,
+				},
+			},
+			Spec: k8sv1.PodSpec{
+				NodeName: host,
+			},
+		}
+		for _, image := range imageCache.Spec.Images {
+			pod.Spec.Containers = append(pod.Spec.Containers, k8sv1.Container{Name: "image-0", Image: image})
+		}
+		if pulled {
+			pod.Status.ContainerStatuses = []k8sv1.ContainerStatus{
+				{Name: "image-0", Image: image, ImageID: "docker-pullable://" + image},
+			}
+		}
+		return pod
+	}
+
+	expectNodePatch := func(labels map[string]interface{}) {
+		kubeClient.Fake.PrependReactor("patch", "nodes", func(action testing.Action) (handled bool, obj runtime.Object, err error) {
+			patch := action.(testing.PatchAction)
+			Expect(patch.GetName()).To(Equal(host))
+			Expect(patch.GetPatchType()).To(Equal(types.MergePatchType))
+			expected, err := json.Marshal(map[string]interface{}{"metadata": map[string]interface{}{"labels": labels}})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(patch.GetPatch()).To(MatchJSON(expected))
+			return true, nil, nil
+		})
+	}
+
+	expectStatus := func(nodes []v1.ContainerDiskImageCacheNodeStatus) {
+		imageCacheInterface.EXPECT().UpdateStatus(gomock.Any()).DoAndReturn(func(imageCache *v1.ContainerDiskImageCache) (*v1.ContainerDiskImageCache, error) {
+			Expect(imageCache.Status.Nodes).To(Equal(nodes))
+			return imageCache, nil
+		})
+	}
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		virtClient := kubecli.NewMockKubevirtClient(ctrl)
+		imageCacheInterface = kubecli.NewMockContainerDiskImageCacheInterface(ctrl)
+		virtClient.EXPECT().ContainerDiskImageCache().Return(imageCacheInterface).AnyTimes()
+
+		kubeClient = fake.NewSimpleClientset(&k8sv1.Node{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   host,
+				Labels: map[string]string{"cache": "true"},
+			},
+		})
+		virtClient.EXPECT().CoreV1().Return(kubeClient.CoreV1()).AnyTimes()
+
+		imageCacheInformer, _ = testutils.NewFakeInformerFor(&v1.ContainerDiskImageCache{})
+		podInformer, _ = testutils.NewFakeInformerFor(&k8sv1.Pod{})
+		controller = NewController(virtClient, host, imageCacheInformer, podInformer)
+	})
+
+	AfterEach(func() {
+		ctrl.Finish()
+	})
+
+	It("should report selected nodes without cached images in the status", func() {
+		imageCacheInformer.GetIndexer().Add(newImageCache())
+		expectStatus([]v1.ContainerDiskImageCacheNodeStatus{{NodeName: host}})
+
+		Expect(controller.execute("cache")).To(Succeed())
+		Expect(kubeClient.Actions()).To(HaveLen(1))
+		Expect(kubeClient.Actions()[0].GetVerb()).To(Equal("get"))
+	})
+
+	It("should report the cached images in the status and on the node", func() {
+		imageCache := newImageCache()
+		imageCacheInformer.GetIndexer().Add(imageCache)
+		podInformer.GetIndexer().Add(newPullerPod(imageCache, true))
+		expectStatus([]v1.ContainerDiskImageCacheNodeStatus{{NodeName: host, CachedImages: []string{image}}})
+		expectNodePatch(map[string]interface{}{containerdisk.CachedImageNodeLabel(image): "true"})
+
+		Expect(controller.execute("cache")).To(Succeed())
+	})
+
+	It("should not report images which are not pulled yet", func() {
+		imageCache := newImageCache()
+		imageCache.Status.Nodes = []v1.ContainerDiskImageCacheNodeStatus{{NodeName: host}}
+		imageCacheInformer.GetIndexer().Add(imageCache)
+		podInformer.GetIndexer().Add(newPullerPod(imageCache, false))
+
+		Expect(controller.execute("cache")).To(Succeed())
+		Expect(kubeClient.Actions()).To(HaveLen(1))
+		Expect(kubeClient.Actions()[0].GetVerb()).To(Equal("get"))
+	})
+
+	It("should release the images if the node is no longer selected", func() {
+		imageCache := newImageCache()
+		imageCache.Spec.NodeSelector = map[string]string{"cache": "false"}
+		imageCache.Status.Nodes = []v1.ContainerDiskImageCacheNodeStatus{
+			{NodeName: host, CachedImages: []string{image}},
+			{NodeName: "node02", CachedImages: []string{image}},
+		}
+		imageCacheInformer.GetIndexer().Add(imageCache)
+		podInformer.GetIndexer().Add(newPullerPod(imageCache, true))
+		expectStatus([]v1.ContainerDiskImageCacheNodeStatus{{NodeName: "node02", CachedImages: []string{image}}})
+
+		Expect(controller.execute("cache")).To(Succeed())
+	})
+
+	It("should remove the node labels of deleted caches", func() {
+		label := containerdisk.CachedImageNodeLabel(image)
+		node, err := kubeClient.CoreV1().Nodes().Get(host, metav1.GetOptions{})
+		Expect(err).ToNot(HaveOccurred())
+		node.Labels[label] = "true"
+		_, err = kubeClient.CoreV1().Nodes().Update(node)
+		Expect(err).ToNot(HaveOccurred())
+
+		podInformer.GetIndexer().Add(newPullerPod(newImageCache(), true))
+		expectNodePatch(map[string]interface{}{label: nil})
+
+		Expect(controller.execute("cache")).To(Succeed())
+	})
+})
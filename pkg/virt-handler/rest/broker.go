@@ -0,0 +1,201 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2019 Red Hat, Inc.
+ *
+ */
+
+package rest
+
+import (
+	"fmt"
+	"net"
+	"sync"
+)
+
+// Number of pending output chunks a client may lag behind before it gets disconnected
+const clientOutputBufferSize = 128
+
+var (
+	errBrokerClosed = fmt.Errorf("console broker is closed")
+	errNotWriter    = fmt.Errorf("console client does not hold the writer lock")
+)
+
+// consoleBroker fans out a single connection to a console socket to
+// multiple clients. Every attached client receives the console output,
+// while only the client holding the writer lock is allowed to send input.
+// The broker closes the underlying connection once the last client detaches.
+type consoleBroker struct {
+	conn    net.Conn
+	lock    sync.Mutex
+	clients map[*consoleClient]struct{}
+	writer  *consoleClient
+	closed  bool
+	onClose func()
+}
+
+// consoleClient is a single consumer of a consoleBroker
+type consoleClient struct {
+	readOnly bool
+	output   chan []byte
+	stop     chan struct{}
+	stopOnce sync.Once
+}
+
+func (c *consoleClient) close() {
+	c.stopOnce.Do(func() {
+		close(c.stop)
+	})
+}
+
+// Stopped is closed when the client got disconnected by the broker
+func (c *consoleClient) Stopped() <-chan struct{} {
+	return c.stop
+}
+
+// Output delivers the console output to the client. The channel is never closed,
+// clients have to watch Stopped() to detect the end of the stream.
+func (c *consoleClient) Output() <-chan []byte {
+	return c.output
+}
+
+func newConsoleBroker(conn net.Conn, onClose func()) *consoleBroker {
+	b := &consoleBroker{
+		conn:    conn,
+		clients: make(map[*consoleClient]struct{}),
+		onClose: onClose,
+	}
+	go b.run()
+	return b
+}
+
+func (b *consoleBroker) run() {
+	buf := make([]byte, 4096)
+	for {
+		n, err := b.conn.Read(buf)
+		if n > 0 {
+			data := make([]byte, n)
+			copy(data, buf[:n])
+			b.broadcast(data)
+		}
+		if err != nil {
+			b.close()
+			return
+		}
+	}
+}
+
+func (b *consoleBroker) broadcast(data []byte) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	for c := range b.clients {
+		select {
+		case c.output <- data:
+		default:
+			// the client can't keep up, don't let it block everyone else
+			b.remove(c)
+		}
+	}
+}
+
+// attach registers a new client. A client which is not read-only takes
+// over the writer lock, a previous writer gets disconnected.
+func (b *consoleBroker) attach(readOnly bool) (*consoleClient, error) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	if b.closed {
+		return nil, errBrokerClosed
+	}
+	c := &consoleClient{
+		readOnly: readOnly,
+		output:   make(chan []byte, clientOutputBufferSize),
+		stop:     make(chan struct{}),
+	}
+	if !readOnly {
+		if b.writer != nil {
+			b.remove(b.writer)
+		}
+		b.writer = c
+	}
+	b.clients[c] = struct{}{}
+	return c, nil
+}
+
+// detach unregisters a client and closes the broker if it was the last one
+func (b *consoleBroker) detach(c *consoleClient) {
+	b.lock.Lock()
+	b.remove(c)
+	last := len(b.clients) == 0
+	b.lock.Unlock()
+	if last {
+		b.close()
+	}
+}
+
+// remove has to be called with the lock held
+func (b *consoleBroker) remove(c *consoleClient) {
+	delete(b.clients, c)
+	if b.writer == c {
+		b.writer = nil
+	}
+	c.close()
+}
+
+func (b *consoleBroker) write(c *consoleClient, data []byte) (int, error) {
+	b.lock.Lock()
+	if b.closed {
+		b.lock.Unlock()
+		return 0, errBrokerClosed
+	}
+	if b.writer != c {
+		b.lock.Unlock()
+		return 0, errNotWriter
+	}
+	b.lock.Unlock()
+	// don't hold the lock while writing, a slow console must not block the output fan-out
+	return b.conn.Write(data)
+}
+
+func (b *consoleBroker) close() {
+	b.lock.Lock()
+	if b.closed {
+		b.lock.Unlock()
+		return
+	}
+	b.closed = true
+	for c := range b.clients {
+		b.remove(c)
+	}
+	b.lock.Unlock()
+
+	b.conn.Close()
+	if b.onClose != nil {
+		b.onClose()
+	}
+}
+
+// consoleClientWriter forwards client input to the broker. Input of read-only
+// clients is silently discarded.
+type consoleClientWriter struct {
+	broker *consoleBroker
+	client *consoleClient
+}
+
+func (w *consoleClientWriter) Write(p []byte) (int, error) {
+	if w.client.readOnly {
+		return len(p), nil
+	}
+	return w.broker.write(w.client, p)
+}
@@ -0,0 +1,132 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2019 Red Hat, Inc.
+ *
+ */
+
+package rest
+
+import (
+	"net"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Console broker", func() {
+
+	var console net.Conn
+	var broker *consoleBroker
+	var closed chan struct{}
+
+	BeforeEach(func() {
+		var conn net.Conn
+		console, conn = net.Pipe()
+		closedCh := make(chan struct{})
+		closed = closedCh
+		broker = newConsoleBroker(conn, func() {
+			close(closedCh)
+		})
+	})
+
+	AfterEach(func() {
+		console.Close()
+	})
+
+	readConsole := func(n int) string {
+		buf := make([]byte, n)
+		_, err := console.Read(buf)
+		Expect(err).ToNot(HaveOccurred())
+		return string(buf)
+	}
+
+	It("should send the console output to all clients", func() {
+		writer, err := broker.attach(false)
+		Expect(err).ToNot(HaveOccurred())
+		reader, err := broker.attach(true)
+		Expect(err).ToNot(HaveOccurred())
+
+		_, err = console.Write([]byte("login:"))
+		Expect(err).ToNot(HaveOccurred())
+
+		Eventually(writer.Output()).Should(Receive(Equal([]byte("login:"))))
+		Eventually(reader.Output()).Should(Receive(Equal([]byte("login:"))))
+	})
+
+	It("should only forward input of the writer", func() {
+		writer, err := broker.attach(false)
+		Expect(err).ToNot(HaveOccurred())
+		reader, err := broker.attach(true)
+		Expect(err).ToNot(HaveOccurred())
+
+		n, err := (&consoleClientWriter{broker: broker, client: reader}).Write([]byte("ignored"))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(n).To(Equal(7))
+
+		go (&consoleClientWriter{broker: broker, client: writer}).Write([]byte("root"))
+		Expect(readConsole(4)).To(Equal("root"))
+	})
+
+	It("should hand the writer lock over to a new writer", func() {
+		first, err := broker.attach(false)
+		Expect(err).ToNot(HaveOccurred())
+		second, err := broker.attach(false)
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(first.Stopped()).To(BeClosed())
+		Expect(second.Stopped()).ToNot(BeClosed())
+
+		_, err = broker.write(first, []byte("x"))
+		Expect(err).To(Equal(errNotWriter))
+	})
+
+	It("should not disconnect other clients when a reader attaches", func() {
+		writer, err := broker.attach(false)
+		Expect(err).ToNot(HaveOccurred())
+		_, err = broker.attach(true)
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(writer.Stopped()).ToNot(BeClosed())
+	})
+
+	It("should close the console connection when the last client detaches", func() {
+		first, err := broker.attach(false)
+		Expect(err).ToNot(HaveOccurred())
+		second, err := broker.attach(true)
+		Expect(err).ToNot(HaveOccurred())
+
+		broker.detach(first)
+		Consistently(closed).ShouldNot(BeClosed())
+		broker.detach(second)
+		Eventually(closed).Should(BeClosed())
+
+		_, err = broker.attach(true)
+		Expect(err).To(Equal(errBrokerClosed))
+	})
+
+	It("should disconnect all clients when the console goes away", func() {
+		first, err := broker.attach(false)
+		Expect(err).ToNot(HaveOccurred())
+		second, err := broker.attach(true)
+		Expect(err).ToNot(HaveOccurred())
+
+		console.Close()
+
+		Eventually(first.Stopped()).Should(BeClosed())
+		Eventually(second.Stopped()).Should(BeClosed())
+		Eventually(closed).Should(BeClosed())
+	})
+})
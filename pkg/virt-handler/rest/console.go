@@ -20,6 +20,7 @@
 package rest
 
 import (
+	"fmt"
 	"io"
 	"net"
 	"net/http"
@@ -27,37 +28,52 @@ import (
 	"path"
 	"strconv"
 	"sync"
+	"time"
 
 	"github.com/emicklei/go-restful"
+	"github.com/gorilla/websocket"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/cache"
 
 	v1 "kubevirt.io/client-go/api/v1"
 	"kubevirt.io/client-go/kubecli"
 	"kubevirt.io/client-go/log"
+	"kubevirt.io/kubevirt/pkg/usbredir"
 	"kubevirt.io/kubevirt/pkg/virt-handler/isolation"
 )
 
 type ConsoleHandler struct {
 	podIsolationDetector isolation.PodIsolationDetector
-	serialStopChans      map[types.UID](chan struct{})
+	serialBrokers        map[types.UID]*consoleBroker
 	vncStopChans         map[types.UID](chan struct{})
+	usbredirSlots        map[types.UID]map[int]bool
 	serialLock           *sync.Mutex
 	vncLock              *sync.Mutex
+	usbredirLock         *sync.Mutex
+	usedVNCTokens        map[string]time.Time
+	vncTokenLock         *sync.Mutex
 	vmiInformer          cache.SharedIndexInformer
 }
 
 func NewConsoleHandler(podIsolationDetector isolation.PodIsolationDetector, vmiInformer cache.SharedIndexInformer) *ConsoleHandler {
 	return &ConsoleHandler{
 		podIsolationDetector: podIsolationDetector,
-		serialStopChans:      make(map[types.UID](chan struct{})),
+		serialBrokers:        make(map[types.UID]*consoleBroker),
 		vncStopChans:         make(map[types.UID](chan struct{})),
+		usbredirSlots:        make(map[types.UID]map[int]bool),
 		serialLock:           &sync.Mutex{},
 		vncLock:              &sync.Mutex{},
+		usbredirLock:         &sync.Mutex{},
+		usedVNCTokens:        make(map[string]time.Time),
+		vncTokenLock:         &sync.Mutex{},
 		vmiInformer:          vmiInformer,
 	}
 }
 
+func isReadOnly(request *restful.Request) bool {
+	return request.QueryParameter("readonly") == "true"
+}
+
 func (t *ConsoleHandler) VNCHandler(request *restful.Request, response *restful.Response) {
 	vmi, code, err := getVMI(request, t.vmiInformer)
 	if err != nil {
@@ -71,12 +87,50 @@ func (t *ConsoleHandler) VNCHandler(request *restful.Request, response *restful.
 		response.WriteError(http.StatusBadRequest, err)
 		return
 	}
-	uid := vmi.GetUID()
-	stopChn := newStopChan(uid, t.vncLock, t.vncStopChans)
-	cleanup := func() {
-		deleteStopChan(uid, stopChn, t.vncLock, t.vncStopChans)
+	if err := t.consumeVNCToken(request, time.Now()); err != nil {
+		log.Log.Object(vmi).Reason(err).Error("Rejected VNC connection")
+		response.WriteError(http.StatusUnauthorized, err)
+		return
 	}
-	t.stream(vmi, request, response, unixSocketPath, stopChn, cleanup)
+	readOnly := isReadOnly(request)
+	// Only one interactive VNC client is allowed at a time, read-only viewers are never kicked
+	var stopChn chan struct{}
+	cleanup := func() {}
+	if !readOnly {
+		uid := vmi.GetUID()
+		stopChn = newStopChan(uid, t.vncLock, t.vncStopChans)
+		cleanup = func() {
+			deleteStopChan(uid, stopChn, t.vncLock, t.vncStopChans)
+		}
+	}
+	t.streamVNC(vmi, request, response, unixSocketPath, readOnly, stopChn, cleanup)
+}
+
+// consumeVNCToken makes sure that the token of a novnc connection is only used
+// once. Tokens are remembered until they expire, connections without a token
+// were authorized by virt-api for the requesting user.
+func (t *ConsoleHandler) consumeVNCToken(request *restful.Request, now time.Time) error {
+	id := request.QueryParameter("tokenid")
+	if id == "" {
+		return nil
+	}
+	expires, err := strconv.ParseInt(request.QueryParameter("tokenexpires"), 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid VNC token expiration: %v", err)
+	}
+
+	t.vncTokenLock.Lock()
+	defer t.vncTokenLock.Unlock()
+	for usedID, usedExpires := range t.usedVNCTokens {
+		if !now.Before(usedExpires) {
+			delete(t.usedVNCTokens, usedID)
+		}
+	}
+	if _, used := t.usedVNCTokens[id]; used {
+		return fmt.Errorf("VNC token was already used")
+	}
+	t.usedVNCTokens[id] = time.Unix(expires, 0)
+	return nil
 }
 
 func (t *ConsoleHandler) SerialHandler(request *restful.Request, response *restful.Response) {
@@ -92,12 +146,70 @@ func (t *ConsoleHandler) SerialHandler(request *restful.Request, response *restf
 		response.WriteError(http.StatusBadRequest, err)
 		return
 	}
+	t.streamSerial(vmi, request, response, unixSocketPath, isReadOnly(request))
+}
+
+func (t *ConsoleHandler) USBRedirHandler(request *restful.Request, response *restful.Response) {
+	vmi, code, err := getVMI(request, t.vmiInformer)
+	if err != nil {
+		log.Log.Object(vmi).Reason(err).Error("Failed to retrieve VMI")
+		response.WriteError(code, err)
+		return
+	}
+	if !usbredir.IsEnabled(vmi) {
+		err := fmt.Errorf("USB redirection is not enabled")
+		log.Log.Object(vmi).Reason(err).Error("Failed to connect USB redirection channel")
+		response.WriteError(http.StatusBadRequest, err)
+		return
+	}
 	uid := vmi.GetUID()
-	stopCh := newStopChan(uid, t.serialLock, t.serialStopChans)
-	cleanup := func() {
-		deleteStopChan(uid, stopCh, t.serialLock, t.serialStopChans)
+	slot, err := t.acquireUSBRedirSlot(uid, usbredir.Slots(vmi))
+	if err != nil {
+		log.Log.Object(vmi).Reason(err).Error("Failed to connect USB redirection channel")
+		response.WriteError(http.StatusServiceUnavailable, err)
+		return
+	}
+	defer t.releaseUSBRedirSlot(uid, slot)
+
+	unixSocketPath, err := t.getUnixSocketPath(vmi, usbredir.SocketName(slot))
+	if err != nil {
+		log.Log.Object(vmi).Reason(err).Error("Failed finding unix socket for USB redirection")
+		response.WriteError(http.StatusBadRequest, err)
+		return
+	}
+	t.streamUSBRedir(vmi, request, response, unixSocketPath)
+}
+
+// acquireUSBRedirSlot reserves a free USB redirection channel of the VMI, every
+// channel can only redirect one device at a time
+func (t *ConsoleHandler) acquireUSBRedirSlot(uid types.UID, slots int) (int, error) {
+	t.usbredirLock.Lock()
+	defer t.usbredirLock.Unlock()
+	used, exists := t.usbredirSlots[uid]
+	if !exists {
+		used = make(map[int]bool)
+		t.usbredirSlots[uid] = used
+	}
+	for slot := 0; slot < slots; slot++ {
+		if !used[slot] {
+			used[slot] = true
+			return slot, nil
+		}
+	}
+	return 0, fmt.Errorf("all %d USB redirection slots are in use", slots)
+}
+
+func (t *ConsoleHandler) releaseUSBRedirSlot(uid types.UID, slot int) {
+	t.usbredirLock.Lock()
+	defer t.usbredirLock.Unlock()
+	used, exists := t.usbredirSlots[uid]
+	if !exists {
+		return
+	}
+	delete(used, slot)
+	if len(used) == 0 {
+		delete(t.usbredirSlots, uid)
 	}
-	t.stream(vmi, request, response, unixSocketPath, stopCh, cleanup)
 }
 
 func newStopChan(uid types.UID, lock *sync.Mutex, stopChans map[types.UID](chan struct{})) chan struct{} {
@@ -123,6 +235,45 @@ func deleteStopChan(uid types.UID, stopChn chan struct{}, lock *sync.Mutex, stop
 	}
 }
 
+// attachSerialConsole connects a new client to the serial console broker of the VMI.
+// The broker, and with it the connection to the serial console socket, is created
+// by the first client and shared by all following ones.
+func (t *ConsoleHandler) attachSerialConsole(uid types.UID, unixSocketPath string, readOnly bool) (*consoleBroker, *consoleClient, error) {
+	t.serialLock.Lock()
+	defer t.serialLock.Unlock()
+	for {
+		broker, exists := t.serialBrokers[uid]
+		if !exists {
+			fd, err := net.Dial("unix", unixSocketPath)
+			if err != nil {
+				return nil, nil, err
+			}
+			broker = newConsoleBroker(fd, nil)
+			broker.onClose = t.serialBrokerCleanup(uid, broker)
+			t.serialBrokers[uid] = broker
+		}
+		client, err := broker.attach(readOnly)
+		if err == errBrokerClosed {
+			// the broker is shutting down, its cleanup may not have run yet
+			delete(t.serialBrokers, uid)
+			continue
+		} else if err != nil {
+			return nil, nil, err
+		}
+		return broker, client, nil
+	}
+}
+
+func (t *ConsoleHandler) serialBrokerCleanup(uid types.UID, broker *consoleBroker) func() {
+	return func() {
+		t.serialLock.Lock()
+		defer t.serialLock.Unlock()
+		if b, ok := t.serialBrokers[uid]; ok && b == broker {
+			delete(t.serialBrokers, uid)
+		}
+	}
+}
+
 func (t *ConsoleHandler) getUnixSocketPath(vmi *v1.VirtualMachineInstance, socketName string) (string, error) {
 	result, err := t.podIsolationDetector.Detect(vmi)
 	if err != nil {
@@ -144,7 +295,7 @@ func (t *ConsoleHandler) getUnixSocketPath(vmi *v1.VirtualMachineInstance, socke
 
 type cleanupOnError func()
 
-func (t *ConsoleHandler) stream(vmi *v1.VirtualMachineInstance, request *restful.Request, response *restful.Response, unixSocketPath string, stopCh chan struct{}, cleanup cleanupOnError) {
+func (t *ConsoleHandler) streamVNC(vmi *v1.VirtualMachineInstance, request *restful.Request, response *restful.Response, unixSocketPath string, readOnly bool, stopCh chan struct{}, cleanup cleanupOnError) {
 	var upgrader = kubecli.NewUpgrader()
 	clientSocket, err := upgrader.Upgrade(response.ResponseWriter, request.Request, nil)
 	if err != nil {
@@ -167,16 +318,26 @@ func (t *ConsoleHandler) stream(vmi *v1.VirtualMachineInstance, request *restful
 
 	log.Log.Object(vmi).Infof("Connected to %s", unixSocketPath)
 
-	errCh := make(chan error)
+	errCh := make(chan error, 3)
 	go func() {
 		_, err := kubecli.CopyTo(clientSocket, fd)
 		log.Log.Object(vmi).Reason(err).Error("error encountered reading from unix socket")
 		errCh <- err
 	}()
 
+	// Client input passes the RFB filter, which enables sharing of the VNC server
+	// and drops input events of read-only clients
+	pipeReader, pipeWriter := io.Pipe()
 	go func() {
-		_, err := kubecli.CopyFrom(fd, clientSocket)
+		_, err := kubecli.CopyFrom(pipeWriter, clientSocket)
 		log.Log.Object(vmi).Reason(err).Error("error encountered reading from client (virt-api) websocket")
+		pipeWriter.CloseWithError(err)
+		errCh <- err
+	}()
+
+	go func() {
+		err := filterRFBClientStream(fd, pipeReader, readOnly)
+		pipeReader.CloseWithError(err)
 		errCh <- err
 	}()
 
@@ -193,3 +354,106 @@ func (t *ConsoleHandler) stream(vmi *v1.VirtualMachineInstance, request *restful
 		cleanup()
 	}
 }
+
+func (t *ConsoleHandler) streamSerial(vmi *v1.VirtualMachineInstance, request *restful.Request, response *restful.Response, unixSocketPath string, readOnly bool) {
+	var upgrader = kubecli.NewUpgrader()
+	clientSocket, err := upgrader.Upgrade(response.ResponseWriter, request.Request, nil)
+	if err != nil {
+		log.Log.Object(vmi).Reason(err).Error("Failed to upgrade client websocket connection")
+		response.WriteError(http.StatusInternalServerError, err)
+		return
+	}
+	defer clientSocket.Close()
+
+	log.Log.Object(vmi).Infof("Websocket connection upgraded")
+	log.Log.Object(vmi).Infof("Connecting to %s", unixSocketPath)
+
+	broker, client, err := t.attachSerialConsole(vmi.GetUID(), unixSocketPath, readOnly)
+	if err != nil {
+		log.Log.Object(vmi).Reason(err).Errorf("failed to dial unix socket %s", unixSocketPath)
+		response.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	defer broker.detach(client)
+
+	log.Log.Object(vmi).Infof("Connected to %s, read-only: %t", unixSocketPath, readOnly)
+
+	errCh := make(chan error, 2)
+	go func() {
+		for {
+			select {
+			case <-client.Stopped():
+				return
+			case data := <-client.Output():
+				if err := clientSocket.WriteMessage(websocket.BinaryMessage, data); err != nil {
+					log.Log.Object(vmi).Reason(err).Error("error encountered writing to client (virt-api) websocket")
+					errCh <- err
+					return
+				}
+			}
+		}
+	}()
+
+	go func() {
+		_, err := kubecli.CopyFrom(&consoleClientWriter{broker: broker, client: client}, clientSocket)
+		log.Log.Object(vmi).Reason(err).Error("error encountered reading from client (virt-api) websocket")
+		errCh <- err
+	}()
+
+	select {
+	case <-client.Stopped():
+		response.WriteHeader(http.StatusOK)
+	case err := <-errCh:
+		if err != nil && err != io.EOF {
+			log.Log.Object(vmi).Reason(err).Error("Error in proxing websocket and unix socket")
+			response.WriteHeader(http.StatusInternalServerError)
+		} else {
+			response.WriteHeader(http.StatusOK)
+		}
+	}
+}
+
+func (t *ConsoleHandler) streamUSBRedir(vmi *v1.VirtualMachineInstance, request *restful.Request, response *restful.Response, unixSocketPath string) {
+	var upgrader = kubecli.NewUpgrader()
+	clientSocket, err := upgrader.Upgrade(response.ResponseWriter, request.Request, nil)
+	if err != nil {
+		log.Log.Object(vmi).Reason(err).Error("Failed to upgrade client websocket connection")
+		response.WriteError(http.StatusInternalServerError, err)
+		return
+	}
+	defer clientSocket.Close()
+
+	log.Log.Object(vmi).Infof("Websocket connection upgraded")
+	log.Log.Object(vmi).Infof("Connecting to %s", unixSocketPath)
+
+	fd, err := net.Dial("unix", unixSocketPath)
+	if err != nil {
+		log.Log.Object(vmi).Reason(err).Errorf("failed to dial unix socket %s", unixSocketPath)
+		response.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	defer fd.Close()
+
+	log.Log.Object(vmi).Infof("Connected to %s", unixSocketPath)
+
+	errCh := make(chan error, 2)
+	go func() {
+		_, err := kubecli.CopyTo(clientSocket, fd)
+		log.Log.Object(vmi).Reason(err).Error("error encountered reading from unix socket")
+		errCh <- err
+	}()
+
+	go func() {
+		_, err := kubecli.CopyFrom(fd, clientSocket)
+		log.Log.Object(vmi).Reason(err).Error("error encountered reading from client (virt-api) websocket")
+		errCh <- err
+	}()
+
+	err = <-errCh
+	if err != nil && err != io.EOF {
+		log.Log.Object(vmi).Reason(err).Error("Error in proxing websocket and unix socket")
+		response.WriteHeader(http.StatusInternalServerError)
+	} else {
+		response.WriteHeader(http.StatusOK)
+	}
+}
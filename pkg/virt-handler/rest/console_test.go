@@ -0,0 +1,75 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2019 Red Hat, Inc.
+ *
+ */
+
+package rest
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/emicklei/go-restful"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("VNC tokens", func() {
+
+	var handler *ConsoleHandler
+	var now time.Time
+
+	newRequest := func(id string, expires time.Time) *restful.Request {
+		params := url.Values{}
+		if id != "" {
+			params.Set("tokenid", id)
+			params.Set("tokenexpires", strconv.FormatInt(expires.Unix(), 10))
+		}
+		return restful.NewRequest(&http.Request{URL: &url.URL{RawQuery: params.Encode()}})
+	}
+
+	BeforeEach(func() {
+		handler = NewConsoleHandler(nil, nil)
+		now = time.Now()
+	})
+
+	It("should accept every token only once", func() {
+		Expect(handler.consumeVNCToken(newRequest("token1", now.Add(time.Minute)), now)).To(Succeed())
+		Expect(handler.consumeVNCToken(newRequest("token2", now.Add(time.Minute)), now)).To(Succeed())
+		Expect(handler.consumeVNCToken(newRequest("token1", now.Add(time.Minute)), now)).ToNot(Succeed())
+	})
+
+	It("should accept connections without a token", func() {
+		Expect(handler.consumeVNCToken(newRequest("", now), now)).To(Succeed())
+		Expect(handler.consumeVNCToken(newRequest("", now), now)).To(Succeed())
+		Expect(handler.usedVNCTokens).To(BeEmpty())
+	})
+
+	It("should reject tokens with an invalid expiration", func() {
+		request := restful.NewRequest(&http.Request{URL: &url.URL{RawQuery: "tokenid=token1&tokenexpires=never"}})
+		Expect(handler.consumeVNCToken(request, now)).ToNot(Succeed())
+	})
+
+	It("should forget expired tokens", func() {
+		Expect(handler.consumeVNCToken(newRequest("token1", now.Add(time.Minute)), now)).To(Succeed())
+		Expect(handler.consumeVNCToken(newRequest("token2", now.Add(2*time.Minute)), now.Add(time.Minute))).To(Succeed())
+		Expect(handler.usedVNCTokens).To(HaveLen(1))
+		Expect(handler.usedVNCTokens).To(HaveKey("token2"))
+	})
+})
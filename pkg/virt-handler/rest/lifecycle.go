@@ -19,12 +19,15 @@
 package rest
 
 import (
+	"encoding/json"
+	"fmt"
 	"net/http"
 
 	"github.com/emicklei/go-restful"
 
 	"k8s.io/client-go/tools/cache"
 
+	v1 "kubevirt.io/client-go/api/v1"
 	"kubevirt.io/client-go/log"
 	cmdclient "kubevirt.io/kubevirt/pkg/virt-handler/cmd-client"
 )
@@ -88,3 +91,170 @@ func (lh *LifecycleHandler) UnpauseHandler(request *restful.Request, response *r
 
 	response.WriteHeader(http.StatusAccepted)
 }
+
+func (lh *LifecycleHandler) SoftRebootHandler(request *restful.Request, response *restful.Response) {
+	vmi, client, ok := lh.getLauncherClient(request, response)
+	if !ok {
+		return
+	}
+	defer client.Close()
+
+	err := client.SoftRebootVirtualMachine(vmi)
+	if err != nil {
+		log.Log.Object(vmi).Reason(err).Error("Failed to soft reboot VMI")
+		response.WriteError(http.StatusInternalServerError, err)
+		return
+	}
+
+	response.WriteHeader(http.StatusAccepted)
+}
+
+func (lh *LifecycleHandler) MemoryDumpHandler(request *restful.Request, response *restful.Response) {
+	dumpRequest := &v1.VirtualMachineMemoryDumpRequest{}
+	if err := json.NewDecoder(request.Request.Body).Decode(dumpRequest); err != nil {
+		response.WriteError(http.StatusBadRequest, fmt.Errorf("can not unmarshal the memory dump request: %v", err))
+		return
+	}
+	if dumpRequest.FileName == nil || *dumpRequest.FileName == "" {
+		response.WriteError(http.StatusBadRequest, fmt.Errorf("the memory dump request has no file name"))
+		return
+	}
+
+	vmi, client, ok := lh.getLauncherClient(request, response)
+	if !ok {
+		return
+	}
+	defer client.Close()
+
+	err := client.MemoryDumpVirtualMachine(vmi, *dumpRequest.FileName)
+	if err != nil {
+		log.Log.Object(vmi).Reason(err).Error("Failed to dump the memory of VMI")
+		response.WriteError(http.StatusInternalServerError, err)
+		return
+	}
+
+	response.WriteHeader(http.StatusAccepted)
+}
+
+func (lh *LifecycleHandler) ScreenshotHandler(request *restful.Request, response *restful.Response) {
+	vmi, code, err := getVMI(request, lh.vmiInformer)
+	if err != nil {
+		log.Log.Object(vmi).Reason(err).Error("Failed to retrieve VMI")
+		response.WriteError(code, err)
+		return
+	}
+
+	sockFile := cmdclient.SocketFromUID(lh.virtShareDir, string(vmi.GetUID()))
+	client, err := cmdclient.NewClient(sockFile)
+	if err != nil {
+		log.Log.Object(vmi).Reason(err).Error("Failed to connect cmd client")
+		response.WriteError(http.StatusInternalServerError, err)
+		return
+	}
+	defer client.Close()
+
+	png, err := client.GetScreenshot(vmi)
+	if err != nil {
+		log.Log.Object(vmi).Reason(err).Error("Failed to take a screenshot of VMI")
+		response.WriteError(http.StatusInternalServerError, err)
+		return
+	}
+
+	response.AddHeader("Content-Type", "image/png")
+	response.WriteHeader(http.StatusOK)
+	response.Write(png)
+}
+
+func (lh *LifecycleHandler) GetGuestInfo(request *restful.Request, response *restful.Response) {
+	vmi, client, ok := lh.getLauncherClient(request, response)
+	if !ok {
+		return
+	}
+	defer client.Close()
+
+	guestInfo, err := client.GetGuestInfo(vmi)
+	if err != nil {
+		log.Log.Object(vmi).Reason(err).Error("Failed to get the guest info of VMI")
+		response.WriteError(http.StatusInternalServerError, err)
+		return
+	}
+
+	response.WriteEntity(guestInfo)
+}
+
+func (lh *LifecycleHandler) GetUsers(request *restful.Request, response *restful.Response) {
+	vmi, client, ok := lh.getLauncherClient(request, response)
+	if !ok {
+		return
+	}
+	defer client.Close()
+
+	userList, err := client.GetUsers(vmi)
+	if err != nil {
+		log.Log.Object(vmi).Reason(err).Error("Failed to get the guest users of VMI")
+		response.WriteError(http.StatusInternalServerError, err)
+		return
+	}
+
+	response.WriteEntity(userList)
+}
+
+func (lh *LifecycleHandler) GetFilesystems(request *restful.Request, response *restful.Response) {
+	vmi, client, ok := lh.getLauncherClient(request, response)
+	if !ok {
+		return
+	}
+	defer client.Close()
+
+	filesystemList, err := client.GetFilesystems(vmi)
+	if err != nil {
+		log.Log.Object(vmi).Reason(err).Error("Failed to get the guest filesystems of VMI")
+		response.WriteError(http.StatusInternalServerError, err)
+		return
+	}
+
+	response.WriteEntity(filesystemList)
+}
+
+func (lh *LifecycleHandler) GuestExecHandler(request *restful.Request, response *restful.Response) {
+	execRequest := &v1.VirtualMachineInstanceGuestExecRequest{}
+	if err := json.NewDecoder(request.Request.Body).Decode(execRequest); err != nil {
+		response.WriteError(http.StatusBadRequest, fmt.Errorf("can not unmarshal the guest exec request: %v", err))
+		return
+	}
+
+	vmi, client, ok := lh.getLauncherClient(request, response)
+	if !ok {
+		return
+	}
+	defer client.Close()
+
+	result, err := client.GuestExec(vmi, execRequest, cmdclient.GuestExecCallTimeout(execRequest))
+	if err != nil {
+		log.Log.Object(vmi).Reason(err).Error("Failed to execute a command in the guest of VMI")
+		response.WriteError(http.StatusInternalServerError, err)
+		return
+	}
+
+	response.WriteEntity(result)
+}
+
+// getLauncherClient looks up the VMI of the request and connects to its launcher.
+// Errors are written to the response.
+func (lh *LifecycleHandler) getLauncherClient(request *restful.Request, response *restful.Response) (*v1.VirtualMachineInstance, cmdclient.LauncherClient, bool) {
+	vmi, code, err := getVMI(request, lh.vmiInformer)
+	if err != nil {
+		log.Log.Object(vmi).Reason(err).Error("Failed to retrieve VMI")
+		response.WriteError(code, err)
+		return nil, nil, false
+	}
+
+	sockFile := cmdclient.SocketFromUID(lh.virtShareDir, string(vmi.GetUID()))
+	client, err := cmdclient.NewClient(sockFile)
+	if err != nil {
+		log.Log.Object(vmi).Reason(err).Error("Failed to connect cmd client")
+		response.WriteError(http.StatusInternalServerError, err)
+		return nil, nil, false
+	}
+	return vmi, client, true
+}
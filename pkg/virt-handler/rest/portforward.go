@@ -0,0 +1,210 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2019 Red Hat, Inc.
+ *
+ */
+
+package rest
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"runtime"
+	"strconv"
+	"time"
+
+	"github.com/emicklei/go-restful"
+	"golang.org/x/sys/unix"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/client-go/kubecli"
+	"kubevirt.io/client-go/log"
+	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/api"
+)
+
+const portForwardDialTimeout = 10 * time.Second
+
+func (t *ConsoleHandler) PortForwardHandler(request *restful.Request, response *restful.Response) {
+	vmi, code, err := getVMI(request, t.vmiInformer)
+	if err != nil {
+		log.Log.Object(vmi).Reason(err).Error("Failed to retrieve VMI")
+		response.WriteError(code, err)
+		return
+	}
+	port, err := strconv.Atoi(request.PathParameter("port"))
+	if err != nil || port < 1 || port > 65535 {
+		response.WriteError(http.StatusBadRequest, fmt.Errorf("invalid port %q", request.PathParameter("port")))
+		return
+	}
+	guestIP, err := getGuestIP(vmi)
+	if err != nil {
+		log.Log.Object(vmi).Reason(err).Error("Failed to find the guest address for port forwarding")
+		response.WriteError(http.StatusBadRequest, err)
+		return
+	}
+	result, err := t.podIsolationDetector.Detect(vmi)
+	if err != nil {
+		log.Log.Object(vmi).Reason(err).Error("Failed to detect the isolation of the VMI")
+		response.WriteError(http.StatusBadRequest, err)
+		return
+	}
+	t.streamPortForward(vmi, request, response, result.NetNamespace(), net.JoinHostPort(guestIP, strconv.Itoa(port)))
+}
+
+// getGuestIP returns the address under which the guest is reachable from
+// within the network namespace of its pod
+func getGuestIP(vmi *v1.VirtualMachineInstance) (string, error) {
+	var podNetwork *v1.Network
+	for i, network := range vmi.Spec.Networks {
+		if network.Pod != nil {
+			podNetwork = &vmi.Spec.Networks[i]
+			break
+		}
+	}
+	if podNetwork == nil {
+		return "", fmt.Errorf("VMI is not connected to the pod network")
+	}
+
+	for _, iface := range vmi.Spec.Domain.Devices.Interfaces {
+		if iface.Name != podNetwork.Name {
+			continue
+		}
+		if iface.Masquerade != nil {
+			// The guest uses the second address of the private VM network
+			cidr := podNetwork.Pod.VMNetworkCIDR
+			if cidr == "" {
+				cidr = api.DefaultVMCIDR
+			}
+			_, ipnet, err := net.ParseCIDR(cidr)
+			if err != nil {
+				return "", err
+			}
+			ip := ipnet.IP.To4()
+			if ip == nil {
+				return "", fmt.Errorf("unsupported VM network %s", cidr)
+			}
+			return net.IPv4(ip[0], ip[1], ip[2], ip[3]+2).String(), nil
+		}
+		if iface.Slirp != nil {
+			return "", fmt.Errorf("port forwarding is not supported for slirp interfaces")
+		}
+	}
+
+	for _, iface := range vmi.Status.Interfaces {
+		if iface.Name == podNetwork.Name && iface.IP != "" {
+			return iface.IP, nil
+		}
+	}
+	return "", fmt.Errorf("the guest did not report an address on the pod network yet")
+}
+
+// dialInNetNamespace connects to address from within the network namespace
+// found at netNamespace. Sockets keep their namespace, so the connection can
+// be used from any thread afterwards.
+func dialInNetNamespace(netNamespace string, address string) (net.Conn, error) {
+	type dialResult struct {
+		conn net.Conn
+		err  error
+	}
+	resultCh := make(chan dialResult, 1)
+
+	go func() {
+		// The thread is only given back to the runtime if it could be switched
+		// back to its original namespace, otherwise it is terminated with the goroutine
+		runtime.LockOSThread()
+
+		origNS, err := os.Open(fmt.Sprintf("/proc/%d/task/%d/ns/net", os.Getpid(), unix.Gettid()))
+		if err != nil {
+			runtime.UnlockOSThread()
+			resultCh <- dialResult{err: err}
+			return
+		}
+		defer origNS.Close()
+
+		targetNS, err := os.Open(netNamespace)
+		if err != nil {
+			runtime.UnlockOSThread()
+			resultCh <- dialResult{err: err}
+			return
+		}
+		defer targetNS.Close()
+
+		if err := unix.Setns(int(targetNS.Fd()), unix.CLONE_NEWNET); err != nil {
+			runtime.UnlockOSThread()
+			resultCh <- dialResult{err: fmt.Errorf("failed to enter network namespace %s: %v", netNamespace, err)}
+			return
+		}
+
+		conn, err := net.DialTimeout("tcp", address, portForwardDialTimeout)
+
+		if restoreErr := unix.Setns(int(origNS.Fd()), unix.CLONE_NEWNET); restoreErr != nil {
+			log.Log.Reason(restoreErr).Error("Failed to restore the network namespace of the thread")
+		} else {
+			runtime.UnlockOSThread()
+		}
+		resultCh <- dialResult{conn: conn, err: err}
+	}()
+
+	result := <-resultCh
+	return result.conn, result.err
+}
+
+func (t *ConsoleHandler) streamPortForward(vmi *v1.VirtualMachineInstance, request *restful.Request, response *restful.Response, netNamespace string, address string) {
+	log.Log.Object(vmi).Infof("Connecting to %s", address)
+
+	fd, err := dialInNetNamespace(netNamespace, address)
+	if err != nil {
+		log.Log.Object(vmi).Reason(err).Errorf("failed to connect to %s", address)
+		response.WriteError(http.StatusBadGateway, err)
+		return
+	}
+	defer fd.Close()
+
+	var upgrader = kubecli.NewUpgrader()
+	clientSocket, err := upgrader.Upgrade(response.ResponseWriter, request.Request, nil)
+	if err != nil {
+		log.Log.Object(vmi).Reason(err).Error("Failed to upgrade client websocket connection")
+		response.WriteError(http.StatusInternalServerError, err)
+		return
+	}
+	defer clientSocket.Close()
+
+	log.Log.Object(vmi).Infof("Connected to %s", address)
+
+	errCh := make(chan error, 2)
+	go func() {
+		_, err := kubecli.CopyTo(clientSocket, fd)
+		log.Log.Object(vmi).Reason(err).Error("error encountered reading from the guest")
+		errCh <- err
+	}()
+
+	go func() {
+		_, err := kubecli.CopyFrom(fd, clientSocket)
+		log.Log.Object(vmi).Reason(err).Error("error encountered reading from client (virt-api) websocket")
+		errCh <- err
+	}()
+
+	err = <-errCh
+	if err != nil && err != io.EOF {
+		log.Log.Object(vmi).Reason(err).Error("Error in proxing websocket and guest connection")
+		response.WriteHeader(http.StatusInternalServerError)
+	} else {
+		response.WriteHeader(http.StatusOK)
+	}
+}
@@ -0,0 +1,86 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2019 Red Hat, Inc.
+ *
+ */
+
+package rest
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	v1 "kubevirt.io/client-go/api/v1"
+)
+
+var _ = Describe("Port forwarding", func() {
+
+	newVMI := func(binding v1.InterfaceBindingMethod, cidr string) *v1.VirtualMachineInstance {
+		vmi := v1.NewMinimalVMI("testvmi")
+		vmi.Spec.Networks = []v1.Network{
+			{Name: "default", NetworkSource: v1.NetworkSource{Pod: &v1.PodNetwork{VMNetworkCIDR: cidr}}},
+		}
+		vmi.Spec.Domain.Devices.Interfaces = []v1.Interface{
+			{Name: "default", InterfaceBindingMethod: binding},
+		}
+		vmi.Status.Interfaces = []v1.VirtualMachineInstanceNetworkInterface{
+			{Name: "default", IP: "10.244.0.15"},
+		}
+		return vmi
+	}
+
+	It("should use the private guest address of masquerade interfaces", func() {
+		vmi := newVMI(v1.InterfaceBindingMethod{Masquerade: &v1.InterfaceMasquerade{}}, "")
+		ip, err := getGuestIP(vmi)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ip).To(Equal("10.0.2.2"))
+	})
+
+	It("should respect a custom VM network of masquerade interfaces", func() {
+		vmi := newVMI(v1.InterfaceBindingMethod{Masquerade: &v1.InterfaceMasquerade{}}, "192.168.10.0/24")
+		ip, err := getGuestIP(vmi)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ip).To(Equal("192.168.10.2"))
+	})
+
+	It("should use the reported guest address of bridge interfaces", func() {
+		vmi := newVMI(v1.InterfaceBindingMethod{Bridge: &v1.InterfaceBridge{}}, "")
+		ip, err := getGuestIP(vmi)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ip).To(Equal("10.244.0.15"))
+	})
+
+	It("should fail if the bridge interface has no reported address", func() {
+		vmi := newVMI(v1.InterfaceBindingMethod{Bridge: &v1.InterfaceBridge{}}, "")
+		vmi.Status.Interfaces = nil
+		_, err := getGuestIP(vmi)
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should fail for slirp interfaces", func() {
+		vmi := newVMI(v1.InterfaceBindingMethod{Slirp: &v1.InterfaceSlirp{}}, "")
+		_, err := getGuestIP(vmi)
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should fail without a pod network", func() {
+		vmi := newVMI(v1.InterfaceBindingMethod{Bridge: &v1.InterfaceBridge{}}, "")
+		vmi.Spec.Networks[0].Pod = nil
+		vmi.Spec.Networks[0].Multus = &v1.MultusNetwork{NetworkName: "net"}
+		_, err := getGuestIP(vmi)
+		Expect(err).To(HaveOccurred())
+	})
+})
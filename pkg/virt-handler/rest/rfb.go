@@ -0,0 +1,201 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2019 Red Hat, Inc.
+ *
+ */
+
+package rest
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"io/ioutil"
+)
+
+// RFB client to server message types, see RFC 6143 and the QEMU extensions
+const (
+	rfbSetPixelFormat           = 0
+	rfbSetEncodings             = 2
+	rfbFramebufferUpdateRequest = 3
+	rfbKeyEvent                 = 4
+	rfbPointerEvent             = 5
+	rfbClientCutText            = 6
+	rfbEnableContinuousUpdates  = 150
+	rfbClientFence              = 248
+	rfbQEMUClientMessage        = 255
+
+	rfbQEMUExtendedKeyEvent = 0
+	rfbQEMUAudio            = 1
+
+	rfbSecurityTypeVNCAuth = 2
+)
+
+// filterRFBClientStream copies the client to server part of an RFB session.
+// It forces the shared flag in the ClientInit message, so that several viewers
+// can be connected to the same VNC server at once. If readOnly is set,
+// all keyboard, pointer and clipboard events are dropped.
+func filterRFBClientStream(dst io.Writer, src io.Reader, readOnly bool) error {
+	if err := forwardRFBHandshake(dst, src); err != nil {
+		return err
+	}
+	if !readOnly {
+		_, err := io.Copy(dst, src)
+		return err
+	}
+	for {
+		msg, forward, err := readRFBClientMessage(src)
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		if !forward {
+			continue
+		}
+		if _, err := dst.Write(msg); err != nil {
+			return err
+		}
+	}
+}
+
+func forwardRFBHandshake(dst io.Writer, src io.Reader) error {
+	version, err := readN(src, 12)
+	if err != nil {
+		return err
+	}
+	var major, minor int
+	if _, err := fmt.Sscanf(string(version), "RFB %03d.%03d\n", &major, &minor); err != nil {
+		return fmt.Errorf("invalid RFB protocol version %q", string(version))
+	}
+	if _, err := dst.Write(version); err != nil {
+		return err
+	}
+
+	// With RFB 3.3 the server decides on the security type, we only support
+	// the "None" type there. Starting with 3.7 the client selects the type.
+	if major > 3 || minor >= 7 {
+		securityType, err := readN(src, 1)
+		if err != nil {
+			return err
+		}
+		if _, err := dst.Write(securityType); err != nil {
+			return err
+		}
+		if securityType[0] == rfbSecurityTypeVNCAuth {
+			challengeResponse, err := readN(src, 16)
+			if err != nil {
+				return err
+			}
+			if _, err := dst.Write(challengeResponse); err != nil {
+				return err
+			}
+		}
+	}
+
+	// ClientInit, a non-zero value asks the server to keep other viewers connected
+	if _, err := readN(src, 1); err != nil {
+		return err
+	}
+	_, err = dst.Write([]byte{1})
+	return err
+}
+
+// readRFBClientMessage reads a single client to server message and reports if
+// it may be forwarded to the server of a read-only session
+func readRFBClientMessage(src io.Reader) ([]byte, bool, error) {
+	msgType, err := readN(src, 1)
+	if err != nil {
+		return nil, false, err
+	}
+
+	switch msgType[0] {
+	case rfbSetPixelFormat:
+		return readRest(msgType, src, 19, true)
+	case rfbSetEncodings:
+		msg, _, err := readRest(msgType, src, 3, true)
+		if err != nil {
+			return nil, false, err
+		}
+		count := int(binary.BigEndian.Uint16(msg[2:4]))
+		return readRest(msg, src, 4*count, true)
+	case rfbFramebufferUpdateRequest:
+		return readRest(msgType, src, 9, true)
+	case rfbKeyEvent:
+		return readRest(msgType, src, 7, false)
+	case rfbPointerEvent:
+		return readRest(msgType, src, 5, false)
+	case rfbClientCutText:
+		msg, _, err := readRest(msgType, src, 7, false)
+		if err != nil {
+			return nil, false, err
+		}
+		// the clipboard is never forwarded, don't buffer what the client claims to send
+		length := int64(binary.BigEndian.Uint32(msg[4:8]))
+		if n, err := io.CopyN(ioutil.Discard, src, length); err != nil {
+			if err == io.EOF && n < length {
+				err = io.ErrUnexpectedEOF
+			}
+			return nil, false, err
+		}
+		return msg, false, nil
+	case rfbEnableContinuousUpdates:
+		return readRest(msgType, src, 9, true)
+	case rfbClientFence:
+		msg, _, err := readRest(msgType, src, 8, true)
+		if err != nil {
+			return nil, false, err
+		}
+		return readRest(msg, src, int(msg[8]), true)
+	case rfbQEMUClientMessage:
+		msg, _, err := readRest(msgType, src, 1, true)
+		if err != nil {
+			return nil, false, err
+		}
+		switch msg[1] {
+		case rfbQEMUExtendedKeyEvent:
+			return readRest(msg, src, 10, false)
+		case rfbQEMUAudio:
+			msg, _, err = readRest(msg, src, 2, true)
+			if err != nil {
+				return nil, false, err
+			}
+			// only "set format" carries a payload
+			if binary.BigEndian.Uint16(msg[2:4]) == 2 {
+				return readRest(msg, src, 6, true)
+			}
+			return msg, true, nil
+		}
+		return nil, false, fmt.Errorf("unsupported QEMU RFB client message subtype %d", msg[1])
+	}
+	return nil, false, fmt.Errorf("unsupported RFB client message type %d", msgType[0])
+}
+
+func readRest(msg []byte, src io.Reader, n int, forward bool) ([]byte, bool, error) {
+	rest, err := readN(src, n)
+	if err != nil {
+		return nil, false, err
+	}
+	return append(msg, rest...), forward, nil
+}
+
+func readN(src io.Reader, n int) ([]byte, error) {
+	buf := make([]byte, n)
+	if _, err := io.ReadFull(src, buf); err != nil {
+		return nil, err
+	}
+	return buf, nil
+}
@@ -0,0 +1,91 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2019 Red Hat, Inc.
+ *
+ */
+
+package rest
+
+import (
+	"bytes"
+	"io"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("RFB client stream filter", func() {
+
+	handshake := func(version string, clientInit byte) []byte {
+		msg := []byte(version)
+		if version != "RFB 003.003\n" {
+			// security type "None"
+			msg = append(msg, 1)
+		}
+		return append(msg, clientInit)
+	}
+
+	setPixelFormat := append([]byte{rfbSetPixelFormat}, make([]byte, 19)...)
+	setEncodings := []byte{rfbSetEncodings, 0, 0, 2, 0, 0, 0, 0, 0, 0, 0, 7}
+	updateRequest := []byte{rfbFramebufferUpdateRequest, 1, 0, 0, 0, 0, 3, 32, 2, 88}
+	keyEvent := []byte{rfbKeyEvent, 1, 0, 0, 0, 0, 0, 97}
+	pointerEvent := []byte{rfbPointerEvent, 1, 0, 10, 0, 10}
+	cutText := []byte{rfbClientCutText, 0, 0, 0, 0, 0, 0, 3, 'a', 'b', 'c'}
+	qemuKeyEvent := []byte{rfbQEMUClientMessage, rfbQEMUExtendedKeyEvent, 0, 1, 0, 0, 0, 97, 0, 0, 0, 30}
+
+	concat := func(msgs ...[]byte) []byte {
+		return bytes.Join(msgs, nil)
+	}
+
+	It("should force the shared flag for interactive clients", func() {
+		out := &bytes.Buffer{}
+		in := concat(handshake("RFB 003.008\n", 0), keyEvent)
+		Expect(filterRFBClientStream(out, bytes.NewReader(in), false)).To(Succeed())
+		Expect(out.Bytes()).To(Equal(concat(handshake("RFB 003.008\n", 1), keyEvent)))
+	})
+
+	It("should forward the VNC authentication response", func() {
+		out := &bytes.Buffer{}
+		response := bytes.Repeat([]byte{0xaa}, 16)
+		in := concat([]byte("RFB 003.008\n"), []byte{rfbSecurityTypeVNCAuth}, response, []byte{0})
+		Expect(filterRFBClientStream(out, bytes.NewReader(in), true)).To(Succeed())
+		Expect(out.Bytes()).To(Equal(concat([]byte("RFB 003.008\n"), []byte{rfbSecurityTypeVNCAuth}, response, []byte{1})))
+	})
+
+	It("should drop input events of read-only clients", func() {
+		out := &bytes.Buffer{}
+		in := concat(handshake("RFB 003.003\n", 0), setPixelFormat, keyEvent, setEncodings, pointerEvent, cutText, qemuKeyEvent, updateRequest)
+		Expect(filterRFBClientStream(out, bytes.NewReader(in), true)).To(Succeed())
+		Expect(out.Bytes()).To(Equal(concat(handshake("RFB 003.003\n", 1), setPixelFormat, setEncodings, updateRequest)))
+	})
+
+	It("should not buffer the clipboard of read-only clients", func() {
+		out := &bytes.Buffer{}
+		in := concat(handshake("RFB 003.008\n", 1), []byte{rfbClientCutText, 0, 0, 0, 0xff, 0xff, 0xff, 0xff, 'a'})
+		Expect(filterRFBClientStream(out, bytes.NewReader(in), true)).To(MatchError(io.ErrUnexpectedEOF))
+	})
+
+	It("should reject unknown client messages of read-only clients", func() {
+		out := &bytes.Buffer{}
+		in := concat(handshake("RFB 003.008\n", 1), []byte{42})
+		Expect(filterRFBClientStream(out, bytes.NewReader(in), true)).To(MatchError("unsupported RFB client message type 42"))
+	})
+
+	It("should reject an invalid protocol version", func() {
+		out := &bytes.Buffer{}
+		Expect(filterRFBClientStream(out, bytes.NewReader([]byte("GET / HTTP/1.1\r\n")), true)).ToNot(Succeed())
+	})
+})
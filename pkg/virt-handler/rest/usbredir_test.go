@@ -0,0 +1,72 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2019 Red Hat, Inc.
+ *
+ */
+
+package rest
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+var _ = Describe("USB redirection slots", func() {
+
+	var handler *ConsoleHandler
+
+	BeforeEach(func() {
+		handler = NewConsoleHandler(nil, nil)
+	})
+
+	It("should hand out every slot only once", func() {
+		for i := 0; i < 3; i++ {
+			slot, err := handler.acquireUSBRedirSlot(types.UID("vmi"), 3)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(slot).To(Equal(i))
+		}
+		_, err := handler.acquireUSBRedirSlot(types.UID("vmi"), 3)
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should track the slots per vmi", func() {
+		slot, err := handler.acquireUSBRedirSlot(types.UID("vmi1"), 1)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(slot).To(Equal(0))
+		slot, err = handler.acquireUSBRedirSlot(types.UID("vmi2"), 1)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(slot).To(Equal(0))
+	})
+
+	It("should reuse released slots", func() {
+		_, err := handler.acquireUSBRedirSlot(types.UID("vmi"), 2)
+		Expect(err).ToNot(HaveOccurred())
+		_, err = handler.acquireUSBRedirSlot(types.UID("vmi"), 2)
+		Expect(err).ToNot(HaveOccurred())
+		handler.releaseUSBRedirSlot(types.UID("vmi"), 0)
+		slot, err := handler.acquireUSBRedirSlot(types.UID("vmi"), 2)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(slot).To(Equal(0))
+	})
+
+	It("should forget vmis without used slots", func() {
+		slot, err := handler.acquireUSBRedirSlot(types.UID("vmi"), 1)
+		Expect(err).ToNot(HaveOccurred())
+		handler.releaseUSBRedirSlot(types.UID("vmi"), slot)
+		Expect(handler.usbredirSlots).To(BeEmpty())
+	})
+})
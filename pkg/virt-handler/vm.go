@@ -52,6 +52,7 @@ import (
 	hostdisk "kubevirt.io/kubevirt/pkg/host-disk"
 	virtutil "kubevirt.io/kubevirt/pkg/util"
 	clusterutils "kubevirt.io/kubevirt/pkg/util/cluster"
+	"kubevirt.io/kubevirt/pkg/util/migrations"
 	pvcutils "kubevirt.io/kubevirt/pkg/util/types"
 	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
 	cmdclient "kubevirt.io/kubevirt/pkg/virt-handler/cmd-client"
@@ -60,6 +61,7 @@ import (
 	migrationproxy "kubevirt.io/kubevirt/pkg/virt-handler/migration-proxy"
 	virtlauncher "kubevirt.io/kubevirt/pkg/virt-launcher"
 	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/api"
+	vmbackup "kubevirt.io/kubevirt/pkg/vm-backup"
 	"kubevirt.io/kubevirt/pkg/watchdog"
 )
 
@@ -97,8 +99,11 @@ func NewController(
 		watchdogTimeoutSeconds:   watchdogTimeoutSeconds,
 		migrationProxy:           migrationproxy.NewMigrationProxyManager(virtShareDir, tlsConfig),
 		podIsolationDetector:     podIsolationDetector,
-		containerDiskMounter:     &container_disk.Mounter{PodIsolationDetector: podIsolationDetector},
-		clusterConfig:            clusterConfig,
+		containerDiskMounter: &container_disk.Mounter{
+			PodIsolationDetector: podIsolationDetector,
+			SignatureVerifier:    container_disk.NewSignatureVerifier(clientset, clusterConfig),
+		},
+		clusterConfig: clusterConfig,
 	}
 
 	vmiSourceInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
@@ -242,7 +247,19 @@ func domainMigrated(domain *api.Domain) bool {
 	return false
 }
 
-func (d *VirtualMachineController) updateVMIStatus(vmi *v1.VirtualMachineInstance, domain *api.Domain, syncError error) (err error) {
+// diskErrorsMessage names the disks which caused the domain to pause
+func diskErrorsMessage(diskErrors []api.DiskError) string {
+	if len(diskErrors) == 0 {
+		return "VMI was paused because of I/O errors"
+	}
+	disks := []string{}
+	for _, diskError := range diskErrors {
+		disks = append(disks, fmt.Sprintf("%s (%s)", diskError.Name, diskError.Reason))
+	}
+	return fmt.Sprintf("VMI was paused because of I/O errors on disks %s", strings.Join(disks, ", "))
+}
+
+func (d *VirtualMachineController) updateVMIStatus(vmi *v1.VirtualMachineInstance, domain *api.Domain, syncError error, resizedVolumes []string) (err error) {
 	condManager := controller.NewVirtualMachineInstanceConditionManager()
 
 	// Don't update the VirtualMachineInstance if it is already in a final state
@@ -366,6 +383,39 @@ func (d *VirtualMachineController) updateVMIStatus(vmi *v1.VirtualMachineInstanc
 		}
 	}
 
+	// Record soft reboots which were reported in the domain metadata
+	if domain != nil && domain.Spec.Metadata.KubeVirt.SoftReboot != nil {
+		softRebootMetadata := domain.Spec.Metadata.KubeVirt.SoftReboot
+		if vmi.Status.SoftRebootState == nil || vmi.Status.SoftRebootState.Count < softRebootMetadata.Count {
+			d.recorder.Event(vmi, k8sv1.EventTypeNormal, v1.SoftRebooted.String(), "VirtualMachineInstance soft rebooted")
+		}
+		vmi.Status.SoftRebootState = &v1.VirtualMachineInstanceSoftRebootState{
+			LastRebootTimestamp: softRebootMetadata.LastRebootTimestamp,
+			Count:               softRebootMetadata.Count,
+		}
+	}
+
+	// Update backup progress if the domain reports the backup job the VMI asked for
+	if domain != nil && domain.Spec.Metadata.KubeVirt.Backup != nil && vmi.Status.BackupState != nil {
+		backupMetadata := domain.Spec.Metadata.KubeVirt.Backup
+		backupState := vmi.Status.BackupState
+		if backupMetadata.Checkpoint == backupState.Checkpoint {
+			if backupState.EndTimestamp == nil && backupMetadata.EndTimestamp != nil {
+				if backupMetadata.Failed {
+					d.recorder.Event(vmi, k8sv1.EventTypeWarning, v1.BackedUp.String(), fmt.Sprintf("VirtualMachineInstance backup %s failed. reason:%s", backupMetadata.Name, backupMetadata.Message))
+				} else {
+					d.recorder.Event(vmi, k8sv1.EventTypeNormal, v1.BackedUp.String(), fmt.Sprintf("VirtualMachineInstance backup %s completed.", backupMetadata.Name))
+				}
+			}
+			backupState.StartTimestamp = backupMetadata.StartTimestamp
+			backupState.EndTimestamp = backupMetadata.EndTimestamp
+			backupState.Volumes = backupMetadata.Volumes
+			backupState.Completed = backupMetadata.Completed
+			backupState.Failed = backupMetadata.Failed
+			backupState.Message = backupMetadata.Message
+		}
+	}
+
 	// handle migrations differently than normal status updates.
 	//
 	// When a successful migration is detected, we must transfer ownership of the VMI
@@ -519,6 +569,41 @@ func (d *VirtualMachineController) updateVMIStatus(vmi *v1.VirtualMachineInstanc
 		condManager.RemoveCondition(vmi, v1.VirtualMachineInstancePaused)
 	}
 
+	// Update I/O error condition in case QEMU paused the VMI because of disk errors
+	ioErrorMessage := ""
+	if domain != nil && domain.Status.Status == api.Paused && domain.Status.Reason == api.ReasonPausedIOError {
+		if !condManager.HasCondition(vmi, v1.VirtualMachineInstancePausedIOError) {
+			log.Log.Object(vmi).V(3).Info("Adding paused on I/O error condition")
+			ioErrorMessage = diskErrorsMessage(domain.Status.DiskErrors)
+			now := metav1.NewTime(time.Now())
+			vmi.Status.Conditions = append(vmi.Status.Conditions, v1.VirtualMachineInstanceCondition{
+				Type:               v1.VirtualMachineInstancePausedIOError,
+				Status:             k8sv1.ConditionTrue,
+				LastProbeTime:      now,
+				LastTransitionTime: now,
+				Reason:             v1.VirtualMachineInstanceReasonDiskIOError,
+				Message:            ioErrorMessage,
+			})
+		}
+	} else if condManager.HasCondition(vmi, v1.VirtualMachineInstancePausedIOError) {
+		log.Log.Object(vmi).V(3).Info("Removing paused on I/O error condition")
+		condManager.RemoveCondition(vmi, v1.VirtualMachineInstancePausedIOError)
+	}
+
+	// Record that disks were grown while the VMI was running
+	if len(resizedVolumes) > 0 {
+		condManager.RemoveCondition(vmi, v1.VirtualMachineInstanceVolumesResized)
+		now := metav1.NewTime(time.Now())
+		vmi.Status.Conditions = append(vmi.Status.Conditions, v1.VirtualMachineInstanceCondition{
+			Type:               v1.VirtualMachineInstanceVolumesResized,
+			Status:             k8sv1.ConditionTrue,
+			LastProbeTime:      now,
+			LastTransitionTime: now,
+			Reason:             v1.VirtualMachineInstanceReasonVolumesExpanded,
+			Message:            fmt.Sprintf("Disks of volumes %s were resized", strings.Join(resizedVolumes, ", ")),
+		})
+	}
+
 	condManager.CheckFailure(vmi, syncError, "Synchronizing with the Domain failed.")
 
 	if !reflect.DeepEqual(oldStatus, vmi.Status) {
@@ -528,6 +613,10 @@ func (d *VirtualMachineController) updateVMIStatus(vmi *v1.VirtualMachineInstanc
 		}
 	}
 
+	if ioErrorMessage != "" {
+		d.recorder.Event(vmi, k8sv1.EventTypeWarning, v1.IOError.String(), ioErrorMessage)
+	}
+
 	if oldStatus.Phase != vmi.Status.Phase {
 		switch vmi.Status.Phase {
 		case v1.Running:
@@ -736,7 +825,7 @@ func (d *VirtualMachineController) migrationTargetExecute(key string,
 		d.removeStaleClientConnections(vmi)
 
 		// prepare the POD for the migration
-		err := d.processVmUpdate(vmi)
+		_, err := d.processVmUpdate(vmi)
 		if err != nil {
 			return err
 		}
@@ -914,6 +1003,7 @@ func (d *VirtualMachineController) defaultExecute(key string,
 	}
 
 	var syncErr error
+	var resizedVolumes []string
 
 	// Process the VirtualMachineInstance update in this order.
 	// * Shutdown and Deletion due to VirtualMachineInstance deletion, process stopping, graceful shutdown trigger, etc...
@@ -933,7 +1023,7 @@ func (d *VirtualMachineController) defaultExecute(key string,
 		syncErr = d.processVmCleanup(vmi)
 	case shouldUpdate:
 		log.Log.Object(vmi).V(3).Info("Processing vmi update")
-		syncErr = d.processVmUpdate(vmi)
+		resizedVolumes, syncErr = d.processVmUpdate(vmi)
 	default:
 		log.Log.Object(vmi).V(3).Info("No update processing required")
 	}
@@ -945,7 +1035,7 @@ func (d *VirtualMachineController) defaultExecute(key string,
 
 	// Update the VirtualMachineInstance status, if the VirtualMachineInstance exists
 	if vmiExists {
-		err = d.updateVMIStatus(vmi.DeepCopy(), domain, syncErr)
+		err = d.updateVMIStatus(vmi.DeepCopy(), domain, syncErr, resizedVolumes)
 		if err != nil {
 			log.Log.Object(vmi).Reason(err).Error("Updating the VirtualMachineInstance status failed.")
 			return err
@@ -1052,6 +1142,12 @@ func (d *VirtualMachineController) processVmCleanup(vmi *v1.VirtualMachineInstan
 		return err
 	}
 
+	// Remove the scratch files and the NBD socket of backups
+	err = os.RemoveAll(vmbackup.GenerateHostDir(virtutil.VirtLibDir, vmi.UID))
+	if err != nil {
+		return err
+	}
+
 	// Watch dog file must be the last thing removed here
 	err = watchdog.WatchdogFileRemove(d.virtShareDir, vmi)
 	if err != nil {
@@ -1340,7 +1436,7 @@ func (d *VirtualMachineController) handlePostSyncMigrationProxy(vmi *v1.VirtualM
 	socketFile := fmt.Sprintf("/proc/%d/root/var/run/libvirt/libvirt-sock", res.Pid())
 	migrationTargetSockets = append(migrationTargetSockets, socketFile)
 
-	isBlockMigration := (vmi.Status.MigrationMethod == v1.BlockMigration)
+	isBlockMigration := vmi.IsBlockMigration()
 	migrationPortsRange := migrationproxy.GetMigrationPortsList(isBlockMigration)
 	for _, port := range migrationPortsRange {
 		key := migrationproxy.ConstructProxyKey(string(vmi.UID), port)
@@ -1380,36 +1476,44 @@ func (d *VirtualMachineController) handleMigrationProxy(vmi *v1.VirtualMachineIn
 	return nil
 }
 
-func (d *VirtualMachineController) processVmUpdate(origVMI *v1.VirtualMachineInstance) error {
+func (d *VirtualMachineController) processVmUpdate(origVMI *v1.VirtualMachineInstance) (resizedVolumes []string, err error) {
 	vmi := origVMI.DeepCopy()
 
 	isExpired, err := watchdog.WatchdogFileIsExpired(d.watchdogTimeoutSeconds, d.virtShareDir, vmi)
 
 	if err != nil {
-		return err
+		return nil, err
 	} else if isExpired {
-		return goerror.New(fmt.Sprintf("Can not update a VirtualMachineInstance with expired watchdog."))
+		return nil, goerror.New(fmt.Sprintf("Can not update a VirtualMachineInstance with expired watchdog."))
+	}
+
+	// The target of a migration uses the PVCs the migrated volumes are copied to
+	if vmi.Status.MigrationState != nil &&
+		vmi.Status.MigrationState.TargetNode == d.host &&
+		!vmi.Status.MigrationState.Failed &&
+		len(vmi.Status.MigrationState.MigratedVolumes) > 0 {
+		vmi.Spec.Volumes = migrations.ApplyMigratedVolumes(vmi.Spec.Volumes, vmi.Status.MigrationState.MigratedVolumes)
 	}
 
 	err = hostdisk.ReplacePVCByHostDisk(vmi, d.clientset)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	err = cloudinit.InjectCloudInitSecrets(vmi, d.clientset)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	client, err := d.getLauncherClient(vmi)
 	if err != nil {
-		return fmt.Errorf("unable to create virt-launcher client connection: %v", err)
+		return nil, fmt.Errorf("unable to create virt-launcher client connection: %v", err)
 	}
 
 	// this adds, removes, and replaces migration proxy connections as needed
 	err = d.handleMigrationProxy(vmi)
 	if err != nil {
-		return fmt.Errorf("failed to handle migration proxy: %v", err)
+		return nil, fmt.Errorf("failed to handle migration proxy: %v", err)
 	}
 
 	if d.isPreMigrationTarget(vmi) {
@@ -1417,18 +1521,18 @@ func (d *VirtualMachineController) processVmUpdate(origVMI *v1.VirtualMachineIns
 
 			// Mount container disks
 			if err := d.containerDiskMounter.Mount(vmi, false); err != nil {
-				return err
+				return nil, err
 			}
 
 			if err := client.SyncMigrationTarget(vmi); err != nil {
-				return fmt.Errorf("syncing migration target failed: %v", err)
+				return nil, fmt.Errorf("syncing migration target failed: %v", err)
 
 			}
 			d.recorder.Event(vmi, k8sv1.EventTypeNormal, v1.PreparingTarget.String(), "VirtualMachineInstance Migration Target Prepared.")
 
 			err := d.handlePostSyncMigrationProxy(vmi)
 			if err != nil {
-				return fmt.Errorf("failed to handle post sync migration proxy: %v", err)
+				return nil, fmt.Errorf("failed to handle post sync migration proxy: %v", err)
 			}
 		}
 	} else if d.isMigrationSource(vmi) {
@@ -1436,7 +1540,7 @@ func (d *VirtualMachineController) processVmUpdate(origVMI *v1.VirtualMachineIns
 			if vmi.Status.MigrationState.AbortStatus != v1.MigrationAbortInProgress {
 				err = client.CancelVirtualMachineMigration(vmi)
 				if err != nil {
-					return err
+					return nil, err
 				}
 				d.recorder.Event(vmi, k8sv1.EventTypeNormal, v1.Migrating.String(), "VirtualMachineInstance is aborting migration.")
 			}
@@ -1450,7 +1554,7 @@ func (d *VirtualMachineController) processVmUpdate(origVMI *v1.VirtualMachineIns
 			}
 			err = client.MigrateVirtualMachine(vmi, options)
 			if err != nil {
-				return err
+				return nil, err
 			}
 			d.recorder.Event(vmi, k8sv1.EventTypeNormal, v1.Migrating.String(), "VirtualMachineInstance is migrating.")
 		}
@@ -1458,13 +1562,13 @@ func (d *VirtualMachineController) processVmUpdate(origVMI *v1.VirtualMachineIns
 
 		if !vmi.IsRunning() && !vmi.IsFinal() {
 			if err := d.containerDiskMounter.Mount(vmi, true); err != nil {
-				return err
+				return nil, err
 			}
 		}
 
 		err = d.podIsolationDetector.AdjustResources(vmi)
 		if err != nil {
-			return fmt.Errorf("failed to adjust resources: %v", err)
+			return nil, fmt.Errorf("failed to adjust resources: %v", err)
 		}
 
 		options := &cmdv1.VirtualMachineOptions{
@@ -1479,12 +1583,72 @@ func (d *VirtualMachineController) processVmUpdate(origVMI *v1.VirtualMachineIns
 
 		err = client.SyncVirtualMachine(vmi, options)
 		if err != nil {
-			return err
+			return nil, err
 		}
 		d.recorder.Event(vmi, k8sv1.EventTypeNormal, v1.Created.String(), "VirtualMachineInstance defined.")
+
+		// Grow the disk images of a running domain when their capacity increased
+		if vmi.IsRunning() && hasExpandableHostDisks(vmi) {
+			resizedVolumes, err = client.ResizeVirtualMachineDisks(vmi)
+			if err != nil {
+				return nil, fmt.Errorf("failed to resize disks: %v", err)
+			}
+			if len(resizedVolumes) > 0 {
+				d.recorder.Event(vmi, k8sv1.EventTypeNormal, v1.VolumesResized.String(), fmt.Sprintf("Disks of volumes %s were resized.", strings.Join(resizedVolumes, ", ")))
+			}
+		}
+
+		if vmi.IsRunning() && vmi.Status.BackupState != nil {
+			if err := d.handleBackup(vmi, client); err != nil {
+				return nil, err
+			}
+		}
 	}
 
-	return err
+	return resizedVolumes, err
+}
+
+// handleBackup starts the backup job the backup controller handed over in the
+// BackupState of the VMI and finishes it once it is asked to.
+func (d *VirtualMachineController) handleBackup(vmi *v1.VirtualMachineInstance, client cmdclient.LauncherClient) error {
+	state := vmi.Status.BackupState
+	if state.Completed || state.Failed {
+		return nil
+	}
+	options := &cmdclient.BackupOptions{
+		BackupName:     state.BackupName,
+		Checkpoint:     state.Checkpoint,
+		BaseCheckpoint: state.BaseCheckpoint,
+	}
+
+	if state.FinishRequested || state.AbortRequested {
+		if state.StartTimestamp == nil || state.EndTimestamp != nil {
+			return nil
+		}
+		options.Abort = state.AbortRequested
+		if err := client.FinishVirtualMachineBackup(vmi, options); err != nil {
+			return fmt.Errorf("failed to finish backup %s: %v", state.BackupName, err)
+		}
+		return nil
+	}
+
+	if state.StartTimestamp != nil {
+		return nil
+	}
+	if err := client.BackupVirtualMachine(vmi, options); err != nil {
+		return fmt.Errorf("failed to start backup %s: %v", state.BackupName, err)
+	}
+	d.recorder.Event(vmi, k8sv1.EventTypeNormal, v1.BackingUp.String(), fmt.Sprintf("VirtualMachineInstance backup %s started.", state.BackupName))
+	return nil
+}
+
+func hasExpandableHostDisks(vmi *v1.VirtualMachineInstance) bool {
+	for _, volume := range vmi.Spec.Volumes {
+		if volume.HostDisk != nil && volume.HostDisk.Type == v1.HostDiskExistsOrCreate {
+			return true
+		}
+	}
+	return false
 }
 
 func (d *VirtualMachineController) setVmPhaseForStatusReason(domain *api.Domain, vmi *v1.VirtualMachineInstance) error {
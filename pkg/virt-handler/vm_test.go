@@ -512,6 +512,181 @@ var _ = Describe("VirtualMachineInstance", func() {
 			controller.Execute()
 		})
 
+		It("should resize the host disks of a running VirtualMachineInstance", func() {
+			vmi := v1.NewMinimalVMI("testvmi")
+			vmi.UID = testUUID
+			vmi.ObjectMeta.ResourceVersion = "1"
+			vmi.Status.Phase = v1.Running
+			vmi.Spec.Volumes = []v1.Volume{
+				{
+					Name: "hostdisk",
+					VolumeSource: v1.VolumeSource{
+						HostDisk: &v1.HostDisk{
+							Path:     "/var/run/kubevirt-private/vmi-disks/hostdisk/disk.img",
+							Type:     v1.HostDiskExistsOrCreate,
+							Capacity: resource.MustParse("2Gi"),
+						},
+					},
+				},
+			}
+
+			mockWatchdog.CreateFile(vmi)
+			domain := api.NewMinimalDomainWithUUID("testvmi", testUUID)
+			domain.Status.Status = api.Running
+			vmiFeeder.Add(vmi)
+			domainFeeder.Add(domain)
+
+			client.EXPECT().SyncVirtualMachine(vmi, gomock.Any())
+			client.EXPECT().ResizeVirtualMachineDisks(vmi)
+			vmiInterface.EXPECT().Update(gomock.Any()).AnyTimes()
+
+			controller.Execute()
+		})
+
+		It("should add the volumes resized condition when disks of a running VirtualMachineInstance were grown", func() {
+			vmi := v1.NewMinimalVMI("testvmi")
+			vmi.UID = testUUID
+			vmi.ObjectMeta.ResourceVersion = "1"
+			vmi.Status.Phase = v1.Running
+			vmi.Spec.Volumes = []v1.Volume{
+				{
+					Name: "hostdisk",
+					VolumeSource: v1.VolumeSource{
+						HostDisk: &v1.HostDisk{
+							Path:     "/var/run/kubevirt-private/vmi-disks/hostdisk/disk.img",
+							Type:     v1.HostDiskExistsOrCreate,
+							Capacity: resource.MustParse("2Gi"),
+						},
+					},
+				},
+			}
+
+			mockWatchdog.CreateFile(vmi)
+			domain := api.NewMinimalDomainWithUUID("testvmi", testUUID)
+			domain.Status.Status = api.Running
+			vmiFeeder.Add(vmi)
+			domainFeeder.Add(domain)
+
+			client.EXPECT().SyncVirtualMachine(vmi, gomock.Any())
+			client.EXPECT().ResizeVirtualMachineDisks(vmi).Return([]string{"hostdisk"}, nil)
+			vmiInterface.EXPECT().Update(gomock.Any()).Do(func(arg interface{}) {
+				var resizedCondition *v1.VirtualMachineInstanceCondition
+				for i, cond := range arg.(*v1.VirtualMachineInstance).Status.Conditions {
+					if cond.Type == v1.VirtualMachineInstanceVolumesResized {
+						resizedCondition = &arg.(*v1.VirtualMachineInstance).Status.Conditions[i]
+					}
+				}
+				Expect(resizedCondition).ToNot(BeNil())
+				Expect(resizedCondition.Status).To(Equal(k8sv1.ConditionTrue))
+				Expect(resizedCondition.Reason).To(Equal(v1.VirtualMachineInstanceReasonVolumesExpanded))
+				Expect(resizedCondition.Message).To(ContainSubstring("hostdisk"))
+			}).Return(vmi, nil)
+
+			controller.Execute()
+			testutils.ExpectEvents(recorder.(*record.FakeRecorder), v1.Created.String(), v1.VolumesResized.String())
+		})
+
+		It("should start the backup job which was handed over to a running VirtualMachineInstance", func() {
+			vmi := v1.NewMinimalVMI("testvmi")
+			vmi.UID = testUUID
+			vmi.ObjectMeta.ResourceVersion = "1"
+			vmi.Status.Phase = v1.Running
+			vmi.Status.BackupState = &v1.VirtualMachineInstanceBackupState{
+				BackupName:     "testbackup",
+				Checkpoint:     "checkpoint",
+				BaseCheckpoint: "base",
+			}
+
+			mockWatchdog.CreateFile(vmi)
+			domain := api.NewMinimalDomainWithUUID("testvmi", testUUID)
+			domain.Status.Status = api.Running
+			vmiFeeder.Add(vmi)
+			domainFeeder.Add(domain)
+
+			client.EXPECT().SyncVirtualMachine(vmi, gomock.Any())
+			client.EXPECT().BackupVirtualMachine(vmi, &cmdclient.BackupOptions{
+				BackupName:     "testbackup",
+				Checkpoint:     "checkpoint",
+				BaseCheckpoint: "base",
+			})
+			vmiInterface.EXPECT().Update(gomock.Any()).AnyTimes()
+
+			controller.Execute()
+			testutils.ExpectEvents(recorder.(*record.FakeRecorder), v1.Created.String(), v1.BackingUp.String())
+		})
+
+		table.DescribeTable("should finish a started backup job", func(abort bool) {
+			vmi := v1.NewMinimalVMI("testvmi")
+			vmi.UID = testUUID
+			vmi.ObjectMeta.ResourceVersion = "1"
+			vmi.Status.Phase = v1.Running
+			now := metav1.Now()
+			vmi.Status.BackupState = &v1.VirtualMachineInstanceBackupState{
+				BackupName:      "testbackup",
+				Checkpoint:      "checkpoint",
+				StartTimestamp:  &now,
+				FinishRequested: !abort,
+				AbortRequested:  abort,
+			}
+
+			mockWatchdog.CreateFile(vmi)
+			domain := api.NewMinimalDomainWithUUID("testvmi", testUUID)
+			domain.Status.Status = api.Running
+			vmiFeeder.Add(vmi)
+			domainFeeder.Add(domain)
+
+			client.EXPECT().SyncVirtualMachine(vmi, gomock.Any())
+			client.EXPECT().FinishVirtualMachineBackup(vmi, &cmdclient.BackupOptions{
+				BackupName: "testbackup",
+				Checkpoint: "checkpoint",
+				Abort:      abort,
+			})
+			vmiInterface.EXPECT().Update(gomock.Any()).AnyTimes()
+
+			controller.Execute()
+			testutils.ExpectEvent(recorder.(*record.FakeRecorder), v1.Created.String())
+		},
+			table.Entry("once the exports were read", false),
+			table.Entry("when the backup is aborted", true),
+		)
+
+		It("should record the progress of the backup job in VMI status", func() {
+			vmi := v1.NewMinimalVMI("testvmi")
+			vmi.UID = testUUID
+			vmi.ObjectMeta.ResourceVersion = "1"
+			vmi.Status.Phase = v1.Scheduled
+			vmi.Status.BackupState = &v1.VirtualMachineInstanceBackupState{
+				BackupName: "testbackup",
+				Checkpoint: "checkpoint",
+			}
+
+			mockWatchdog.CreateFile(vmi)
+			domain := api.NewMinimalDomainWithUUID("testvmi", testUUID)
+			domain.Status.Status = api.Running
+			now := metav1.Now()
+			domain.Spec.Metadata.KubeVirt.Backup = &api.BackupMetadata{
+				Name:           "testbackup",
+				Checkpoint:     "checkpoint",
+				Volumes:        []string{"rootdisk"},
+				StartTimestamp: &now,
+				EndTimestamp:   &now,
+				Completed:      true,
+			}
+
+			vmiFeeder.Add(vmi)
+			domainFeeder.Add(domain)
+
+			vmiInterface.EXPECT().Update(gomock.Any()).Do(func(arg interface{}) {
+				backupState := arg.(*v1.VirtualMachineInstance).Status.BackupState
+				Expect(backupState.Volumes).To(Equal([]string{"rootdisk"}))
+				Expect(backupState.StartTimestamp).To(Equal(&now))
+				Expect(backupState.Completed).To(BeTrue())
+			}).Return(vmi, nil)
+
+			controller.Execute()
+			testutils.ExpectEvent(recorder.(*record.FakeRecorder), v1.BackedUp.String())
+		})
+
 		It("should add and remove paused condition", func() {
 			vmi := v1.NewMinimalVMI("testvmi")
 			vmi.UID = testUUID
@@ -567,6 +742,64 @@ var _ = Describe("VirtualMachineInstance", func() {
 			controller.Execute()
 		})
 
+		It("should add and remove paused on I/O error condition", func() {
+			vmi := v1.NewMinimalVMI("testvmi")
+			vmi.UID = testUUID
+			vmi.ObjectMeta.ResourceVersion = "1"
+			vmi.Status.Phase = v1.Running
+
+			mockWatchdog.CreateFile(vmi)
+
+			domain := api.NewMinimalDomainWithUUID("testvmi", testUUID)
+
+			By("pausing domain on an I/O error")
+			domain.Status.Status = api.Paused
+			domain.Status.Reason = api.ReasonPausedIOError
+			domain.Status.DiskErrors = []api.DiskError{
+				{Name: "mydisk", Reason: api.DiskErrorNoSpace},
+			}
+
+			vmiFeeder.Add(vmi)
+			domainFeeder.Add(domain)
+
+			client.EXPECT().SyncVirtualMachine(vmi, gomock.Any())
+			vmiInterface.EXPECT().Update(gomock.Any()).Do(func(vmi *v1.VirtualMachineInstance) {
+				Expect(vmi.Status.Conditions).To(HaveLen(2))
+				Expect(vmi.Status.Conditions[1].Type).To(Equal(v1.VirtualMachineInstancePausedIOError))
+				Expect(vmi.Status.Conditions[1].Reason).To(Equal(v1.VirtualMachineInstanceReasonDiskIOError))
+				Expect(vmi.Status.Conditions[1].Message).To(Equal("VMI was paused because of I/O errors on disks mydisk (NoSpace)"))
+			})
+
+			controller.Execute()
+			testutils.ExpectEvents(recorder.(*record.FakeRecorder), v1.Created.String(), v1.IOError.String())
+
+			By("resuming domain")
+			domain.Status.Status = api.Running
+			domain.Status.Reason = ""
+			domain.Status.DiskErrors = nil
+
+			vmi.Status.Conditions = []v1.VirtualMachineInstanceCondition{
+				{
+					Type:   v1.VirtualMachineInstanceIsMigratable,
+					Status: k8sv1.ConditionTrue,
+				},
+				{
+					Type:   v1.VirtualMachineInstancePausedIOError,
+					Status: k8sv1.ConditionTrue,
+				},
+			}
+			updatedVMI := vmi.DeepCopy()
+			updatedVMI.Status.Conditions = updatedVMI.Status.Conditions[:1]
+
+			vmiFeeder.Add(vmi)
+			domainFeeder.Add(domain)
+
+			client.EXPECT().SyncVirtualMachine(vmi, gomock.Any())
+			vmiInterface.EXPECT().Update(NewVMICondMatcher(*updatedVMI))
+
+			controller.Execute()
+		})
+
 		It("should move VirtualMachineInstance from Scheduled to Failed if watchdog file is missing", func() {
 			vmi := v1.NewMinimalVMI("testvmi")
 			vmi.ObjectMeta.ResourceVersion = "1"
@@ -1273,6 +1506,35 @@ var _ = Describe("VirtualMachineInstance", func() {
 			controller.Execute()
 		})
 
+		It("should record soft reboots in VMI status", func() {
+			vmi := v1.NewMinimalVMI("testvmi")
+			vmi.UID = testUUID
+			vmi.ObjectMeta.ResourceVersion = "1"
+			vmi.Status.Phase = v1.Scheduled
+
+			mockWatchdog.CreateFile(vmi)
+			domain := api.NewMinimalDomainWithUUID("testvmi", testUUID)
+			domain.Status.Status = api.Running
+			now := metav1.Now()
+			domain.Spec.Metadata.KubeVirt.SoftReboot = &api.SoftRebootMetadata{
+				LastRebootTimestamp: &now,
+				Count:               2,
+			}
+
+			vmiFeeder.Add(vmi)
+			domainFeeder.Add(domain)
+
+			vmiInterface.EXPECT().Update(gomock.Any()).Do(func(arg interface{}) {
+				softRebootState := arg.(*v1.VirtualMachineInstance).Status.SoftRebootState
+				Expect(softRebootState).ToNot(BeNil())
+				Expect(softRebootState.Count).To(Equal(int64(2)))
+				Expect(softRebootState.LastRebootTimestamp).To(Equal(&now))
+			}).Return(vmi, nil)
+
+			controller.Execute()
+			testutils.ExpectEvent(recorder.(*record.FakeRecorder), v1.SoftRebooted.String())
+		})
+
 		It("should add new vmi interfaces for new domain interfaces", func() {
 			vmi := v1.NewMinimalVMI("testvmi")
 			vmi.UID = testUUID
@@ -177,6 +177,16 @@ func eventCallback(c cli.Connection, domain *api.Domain, libvirtEvent libvirtEve
 			domain.Spec = *spec
 		}
 
+		// Name the disks which caused QEMU to pause the domain
+		if domain.Status.Status == api.Paused && domain.Status.Reason == api.ReasonPausedIOError {
+			diskErrors, err := util.GetDiskErrors(d, spec)
+			if err != nil {
+				log.Log.Reason(err).Error("Could not fetch the disk errors of the Domain.")
+			} else {
+				domain.Status.DiskErrors = diskErrors
+			}
+		}
+
 		log.Log.Infof("kubevirt domain status: %v(%v):%v(%v)", domain.Status.Status, status, domain.Status.Reason, reason)
 	}
 
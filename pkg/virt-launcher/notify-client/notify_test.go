@@ -129,6 +129,46 @@ var _ = Describe("Notify", func() {
 			)
 		})
 
+		It("should report the disks which paused the domain on I/O errors", func() {
+			domain := api.NewMinimalDomain("test")
+			domain.Spec.Devices.Disks = []api.Disk{
+				{
+					Device: "disk",
+					Type:   "file",
+					Target: api.DiskTarget{Bus: "virtio", Device: "vda"},
+					Alias:  &api.Alias{Name: "mydisk"},
+				},
+			}
+			x, err := xml.Marshal(domain.Spec)
+			Expect(err).ToNot(HaveOccurred())
+
+			mockDomain.EXPECT().GetState().Return(libvirt.DOMAIN_PAUSED, int(libvirt.DOMAIN_PAUSED_IOERROR), nil)
+			mockDomain.EXPECT().Free()
+			mockDomain.EXPECT().GetName().Return("test", nil).AnyTimes()
+			mockDomain.EXPECT().GetXMLDesc(gomock.Eq(libvirt.DomainXMLFlags(0))).Return(string(x), nil)
+			mockDomain.EXPECT().GetMetadata(libvirt.DOMAIN_METADATA_ELEMENT, "http://kubevirt.io", libvirt.DOMAIN_AFFECT_CONFIG).Return(`<kubevirt></kubevirt>`, nil)
+			mockDomain.EXPECT().GetDiskErrors(uint32(0)).Return([]libvirt.DomainDiskError{
+				{Disk: "vda", Error: libvirt.DOMAIN_DISK_ERROR_NO_SPACE},
+			}, nil)
+
+			eventCallback(mockCon, util.NewDomainFromName("test", "1234"), libvirtEvent{Event: &libvirt.DomainEventLifecycle{Event: libvirt.DOMAIN_EVENT_SUSPENDED}}, client, deleteNotificationSent, nil, nil)
+
+			timedOut := false
+			timeout := time.After(2 * time.Second)
+			select {
+			case <-timeout:
+				timedOut = true
+			case event := <-eventChan:
+				newDomain := event.Object.(*api.Domain)
+				Expect(newDomain.Status.Status).To(Equal(api.Paused))
+				Expect(newDomain.Status.Reason).To(Equal(api.ReasonPausedIOError))
+				Expect(newDomain.Status.DiskErrors).To(Equal([]api.DiskError{
+					{Name: "mydisk", Reason: api.DiskErrorNoSpace},
+				}))
+			}
+			Expect(timedOut).To(BeFalse(), "should not time out")
+		})
+
 		It("should receive a delete event when a VirtualMachineInstance is undefined",
 			func() {
 				mockDomain.EXPECT().Free()
@@ -0,0 +1,227 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2019 Red Hat, Inc.
+ *
+ */
+
+package accesscredentials
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sync"
+	"time"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/client-go/log"
+	"kubevirt.io/kubevirt/pkg/config"
+	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/api"
+)
+
+const defaultPollInterval = 10 * time.Second
+
+// AgentCommandExecutor executes commands of the qemu guest agent of a domain
+type AgentCommandExecutor interface {
+	QemuAgentCommand(command string, domainName string) (string, error)
+}
+
+type agentCommand struct {
+	Execute   string      `json:"execute"`
+	Arguments interface{} `json:"arguments"`
+}
+
+type sshAddAuthorizedKeysArguments struct {
+	Username string   `json:"username"`
+	Keys     []string `json:"keys"`
+	Reset    bool     `json:"reset"`
+}
+
+type setUserPasswordArguments struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+	Crypted  bool   `json:"crypted"`
+}
+
+// AccessCredentialManager propagates the access credentials of a
+// VirtualMachineInstance which use the qemu guest agent. The mounted
+// Secrets are polled, so that changes reach the guest while it runs.
+type AccessCredentialManager struct {
+	agent        AgentCommandExecutor
+	pollInterval time.Duration
+
+	lock    sync.Mutex
+	stopped chan struct{}
+	done    chan struct{}
+	// applied holds the last successfully applied credentials, by type and user
+	applied map[string]interface{}
+}
+
+func NewManager(agent AgentCommandExecutor) *AccessCredentialManager {
+	return &AccessCredentialManager{
+		agent:        agent,
+		pollInterval: defaultPollInterval,
+		applied:      map[string]interface{}{},
+	}
+}
+
+func hasQemuGuestAgentCredentials(vmi *v1.VirtualMachineInstance) bool {
+	for _, credential := range vmi.Spec.AccessCredentials {
+		if credential.SSHPublicKey != nil && credential.SSHPublicKey.PropagationMethod.QemuGuestAgent != nil {
+			return true
+		}
+		if credential.UserPassword != nil && credential.UserPassword.PropagationMethod.QemuGuestAgent != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// HandleQemuAgentAccessCredentials starts propagating the access
+// credentials of the VirtualMachineInstance, if it has any which use the
+// qemu guest agent. Subsequent calls have no effect.
+func (m *AccessCredentialManager) HandleQemuAgentAccessCredentials(vmi *v1.VirtualMachineInstance) {
+	if !hasQemuGuestAgentCredentials(vmi) {
+		return
+	}
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	if m.stopped != nil {
+		return
+	}
+	m.stopped = make(chan struct{})
+	m.done = make(chan struct{})
+
+	go func(vmi *v1.VirtualMachineInstance, stopped chan struct{}, done chan struct{}) {
+		defer close(done)
+		log.Log.Object(vmi).Info("Access credential propagation started")
+		for {
+			m.sync(vmi)
+			select {
+			case <-stopped:
+				log.Log.Object(vmi).Info("Access credential propagation stopped")
+				return
+			case <-time.After(m.pollInterval):
+			}
+		}
+	}(vmi.DeepCopy(), m.stopped, m.done)
+}
+
+// Stop stops propagating access credentials and waits until a propagation
+// in progress finished, so that no command reaches the guest afterwards.
+func (m *AccessCredentialManager) Stop() {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	if m.stopped != nil {
+		close(m.stopped)
+		<-m.done
+		m.stopped = nil
+		m.done = nil
+	}
+}
+
+// sync applies all credentials which changed since they were applied last.
+// Failures are logged and retried on the next call, since the guest agent
+// is not necessarily up yet.
+func (m *AccessCredentialManager) sync(vmi *v1.VirtualMachineInstance) {
+	logger := log.Log.Object(vmi)
+	domainName := api.VMINamespaceKeyFunc(vmi)
+
+	// Keys of one user may come from several Secrets, don't replace them
+	// with an incomplete set while a Secret can't be read
+	desired, err := desiredCredentials(vmi)
+	if err != nil {
+		logger.Reason(err).Error("Failed to read the access credentials")
+		return
+	}
+
+	for id, credential := range desired {
+		if reflect.DeepEqual(m.applied[id], credential) {
+			continue
+		}
+		if err := m.apply(credential, domainName); err != nil {
+			logger.Reason(err).Errorf("Failed to propagate access credential %s", id)
+			continue
+		}
+		m.applied[id] = credential
+		logger.Infof("Propagated access credential %s", id)
+	}
+}
+
+func (m *AccessCredentialManager) apply(credential interface{}, domainName string) error {
+	var cmd agentCommand
+	switch c := credential.(type) {
+	case *sshAddAuthorizedKeysArguments:
+		cmd = agentCommand{Execute: "guest-ssh-add-authorized-keys", Arguments: c}
+	case *setUserPasswordArguments:
+		cmd = agentCommand{Execute: "guest-set-user-password", Arguments: c}
+	default:
+		return fmt.Errorf("unknown access credential type %T", credential)
+	}
+
+	data, err := json.Marshal(cmd)
+	if err != nil {
+		return err
+	}
+	_, err = m.agent.QemuAgentCommand(string(data), domainName)
+	return err
+}
+
+// desiredCredentials returns the agent command arguments for all
+// credentials which use the qemu guest agent, identified by type and user.
+// The keys of several credentials for the same user are merged, since every
+// command replaces all authorized keys of the user.
+func desiredCredentials(vmi *v1.VirtualMachineInstance) (map[string]interface{}, error) {
+	desired := map[string]interface{}{}
+
+	for i := range vmi.Spec.AccessCredentials {
+		credential := &vmi.Spec.AccessCredentials[i]
+		secretName := config.GetAccessCredentialSecretName(credential)
+
+		if credential.SSHPublicKey != nil && credential.SSHPublicKey.PropagationMethod.QemuGuestAgent != nil {
+			keys, err := config.ReadSSHPublicKeys(secretName)
+			if err != nil {
+				return nil, err
+			}
+			for _, user := range credential.SSHPublicKey.PropagationMethod.QemuGuestAgent.Users {
+				id := "ssh-public-key/" + user
+				args, exists := desired[id].(*sshAddAuthorizedKeysArguments)
+				if !exists {
+					args = &sshAddAuthorizedKeysArguments{Username: user, Keys: []string{}, Reset: true}
+					desired[id] = args
+				}
+				args.Keys = append(args.Keys, keys...)
+			}
+		}
+
+		if credential.UserPassword != nil && credential.UserPassword.PropagationMethod.QemuGuestAgent != nil {
+			passwords, err := config.ReadAccessCredentialSecret(secretName)
+			if err != nil {
+				return nil, err
+			}
+			for user, password := range passwords {
+				desired["user-password/"+user] = &setUserPasswordArguments{
+					Username: user,
+					Password: base64.StdEncoding.EncodeToString([]byte(password)),
+					Crypted:  false,
+				}
+			}
+		}
+	}
+	return desired, nil
+}
@@ -0,0 +1,213 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2019 Red Hat, Inc.
+ *
+ */
+
+package accesscredentials
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/kubevirt/pkg/config"
+)
+
+type fakeAgent struct {
+	lock     sync.Mutex
+	commands []string
+	err      error
+}
+
+func (f *fakeAgent) QemuAgentCommand(command string, domainName string) (string, error) {
+	defer GinkgoRecover()
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	Expect(domainName).To(Equal("default_testvmi"))
+	if f.err != nil {
+		return "", f.err
+	}
+	f.commands = append(f.commands, command)
+	return `{"return":{}}`, nil
+}
+
+func (f *fakeAgent) commandCount() int {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	return len(f.commands)
+}
+
+var _ = Describe("AccessCredentials", func() {
+	var agent *fakeAgent
+	var manager *AccessCredentialManager
+	var vmi *v1.VirtualMachineInstance
+	var origAccessCredentialSourceDir string
+
+	writeSecret := func(secretName string, data map[string]string) {
+		dir := filepath.Join(config.AccessCredentialSourceDir, secretName)
+		Expect(os.MkdirAll(dir, 0755)).To(Succeed())
+		for key, value := range data {
+			Expect(ioutil.WriteFile(filepath.Join(dir, key), []byte(value), 0644)).To(Succeed())
+		}
+	}
+
+	BeforeEach(func() {
+		origAccessCredentialSourceDir = config.AccessCredentialSourceDir
+		var err error
+		config.AccessCredentialSourceDir, err = ioutil.TempDir("", "access-cred")
+		Expect(err).ToNot(HaveOccurred())
+
+		agent = &fakeAgent{}
+		manager = NewManager(agent)
+		vmi = v1.NewMinimalVMI("testvmi")
+		vmi.Namespace = "default"
+	})
+
+	AfterEach(func() {
+		manager.Stop()
+		os.RemoveAll(config.AccessCredentialSourceDir)
+		config.AccessCredentialSourceDir = origAccessCredentialSourceDir
+	})
+
+	sshCredential := func(secretName string, users ...string) v1.AccessCredential {
+		return v1.AccessCredential{
+			SSHPublicKey: &v1.SSHPublicKeyAccessCredential{
+				Source: v1.AccessCredentialSource{
+					Secret: &v1.AccessCredentialSecretSource{SecretName: secretName},
+				},
+				PropagationMethod: v1.SSHPublicKeyAccessCredentialPropagationMethod{
+					QemuGuestAgent: &v1.QemuGuestAgentSSHPublicKeyAccessCredentialPropagation{Users: users},
+				},
+			},
+		}
+	}
+
+	passwordCredential := func(secretName string) v1.AccessCredential {
+		return v1.AccessCredential{
+			UserPassword: &v1.UserPasswordAccessCredential{
+				Source: v1.AccessCredentialSource{
+					Secret: &v1.AccessCredentialSecretSource{SecretName: secretName},
+				},
+				PropagationMethod: v1.UserPasswordAccessCredentialPropagationMethod{
+					QemuGuestAgent: &v1.QemuGuestAgentUserPasswordAccessCredentialPropagation{},
+				},
+			},
+		}
+	}
+
+	It("should merge the ssh public keys of a user", func() {
+		writeSecret("keys-1", map[string]string{"key": "ssh-rsa AAAA1\n"})
+		writeSecret("keys-2", map[string]string{"key": "ssh-rsa AAAA2\n"})
+		vmi.Spec.AccessCredentials = []v1.AccessCredential{
+			sshCredential("keys-1", "fedora"),
+			sshCredential("keys-2", "fedora"),
+		}
+
+		manager.sync(vmi)
+		Expect(agent.commands).To(HaveLen(1))
+		Expect(agent.commands[0]).To(MatchJSON(`{"execute": "guest-ssh-add-authorized-keys", "arguments": {"username": "fedora", "keys": ["ssh-rsa AAAA1", "ssh-rsa AAAA2"], "reset": true}}`))
+	})
+
+	It("should set the user passwords", func() {
+		writeSecret("passwords", map[string]string{"fedora": "secret"})
+		vmi.Spec.AccessCredentials = []v1.AccessCredential{passwordCredential("passwords")}
+
+		manager.sync(vmi)
+		Expect(agent.commands).To(HaveLen(1))
+		Expect(agent.commands[0]).To(MatchJSON(`{"execute": "guest-set-user-password", "arguments": {"username": "fedora", "password": "c2VjcmV0", "crypted": false}}`))
+	})
+
+	It("should only propagate changed credentials", func() {
+		writeSecret("keys", map[string]string{"key": "ssh-rsa AAAA1\n"})
+		writeSecret("passwords", map[string]string{"fedora": "secret"})
+		vmi.Spec.AccessCredentials = []v1.AccessCredential{
+			sshCredential("keys", "fedora"),
+			passwordCredential("passwords"),
+		}
+
+		manager.sync(vmi)
+		Expect(agent.commands).To(HaveLen(2))
+
+		manager.sync(vmi)
+		Expect(agent.commands).To(HaveLen(2))
+
+		writeSecret("passwords", map[string]string{"fedora": "changed"})
+		manager.sync(vmi)
+		Expect(agent.commands).To(HaveLen(3))
+		Expect(agent.commands[2]).To(ContainSubstring("guest-set-user-password"))
+	})
+
+	It("should retry failed propagations", func() {
+		writeSecret("passwords", map[string]string{"fedora": "secret"})
+		vmi.Spec.AccessCredentials = []v1.AccessCredential{passwordCredential("passwords")}
+
+		agent.err = fmt.Errorf("guest agent is not connected")
+		manager.sync(vmi)
+		Expect(agent.commands).To(BeEmpty())
+
+		agent.err = nil
+		manager.sync(vmi)
+		Expect(agent.commands).To(HaveLen(1))
+	})
+
+	It("should not propagate anything while a Secret is missing", func() {
+		writeSecret("keys-1", map[string]string{"key": "ssh-rsa AAAA1\n"})
+		vmi.Spec.AccessCredentials = []v1.AccessCredential{
+			sshCredential("keys-1", "fedora"),
+			sshCredential("keys-2", "fedora"),
+		}
+
+		manager.sync(vmi)
+		Expect(agent.commands).To(BeEmpty())
+	})
+
+	It("should stop polling the Secrets when stopped", func() {
+		writeSecret("passwords", map[string]string{"fedora": "secret"})
+		vmi.Spec.AccessCredentials = []v1.AccessCredential{passwordCredential("passwords")}
+		manager.pollInterval = 10 * time.Millisecond
+
+		manager.HandleQemuAgentAccessCredentials(vmi)
+		Eventually(agent.commandCount).Should(Equal(1))
+
+		writeSecret("passwords", map[string]string{"fedora": "changed"})
+		Eventually(agent.commandCount).Should(Equal(2))
+
+		manager.Stop()
+		Expect(manager.stopped).To(BeNil())
+		writeSecret("passwords", map[string]string{"fedora": "changed-again"})
+		Consistently(agent.commandCount, 100*time.Millisecond, 10*time.Millisecond).Should(Equal(2))
+	})
+
+	It("should ignore credentials which are propagated by cloud-init", func() {
+		credential := sshCredential("keys", "fedora")
+		credential.SSHPublicKey.PropagationMethod = v1.SSHPublicKeyAccessCredentialPropagationMethod{
+			CloudInit: &v1.CloudInitSSHPublicKeyAccessCredentialPropagation{},
+		}
+		vmi.Spec.AccessCredentials = []v1.AccessCredential{credential}
+
+		Expect(hasQemuGuestAgentCredentials(vmi)).To(BeFalse())
+		manager.HandleQemuAgentAccessCredentials(vmi)
+		Expect(manager.stopped).To(BeNil())
+	})
+})
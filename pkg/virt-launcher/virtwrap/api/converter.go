@@ -38,12 +38,15 @@ import (
 	"kubevirt.io/client-go/precond"
 	cloudinit "kubevirt.io/kubevirt/pkg/cloud-init"
 	"kubevirt.io/kubevirt/pkg/config"
+	consolelog "kubevirt.io/kubevirt/pkg/console-log"
 	containerdisk "kubevirt.io/kubevirt/pkg/container-disk"
 	"kubevirt.io/kubevirt/pkg/emptydisk"
 	ephemeraldisk "kubevirt.io/kubevirt/pkg/ephemeral-disk"
 	cmdv1 "kubevirt.io/kubevirt/pkg/handler-launcher-com/cmd/v1"
 	hostdisk "kubevirt.io/kubevirt/pkg/host-disk"
 	"kubevirt.io/kubevirt/pkg/ignition"
+	reservation "kubevirt.io/kubevirt/pkg/storage-reservation"
+	"kubevirt.io/kubevirt/pkg/usbredir"
 	"kubevirt.io/kubevirt/pkg/util"
 	"kubevirt.io/kubevirt/pkg/util/net/dns"
 )
@@ -112,8 +115,12 @@ func Convert_v1_Disk_To_api_Disk(diskDevice *v1.Disk, disk *Disk, devicePerBus m
 		}
 	}
 	disk.Driver = &DiskDriver{
-		Name:  "qemu",
-		Cache: string(diskDevice.Cache),
+		Name:        "qemu",
+		Cache:       string(diskDevice.Cache),
+		ErrorPolicy: string(diskDevice.ErrorPolicy),
+	}
+	if diskDevice.Shareable != nil && *diskDevice.Shareable {
+		disk.Shareable = &Shareable{}
 	}
 	if numQueues != nil && disk.Target.Bus == "virtio" {
 		disk.Driver.Queues = numQueues
@@ -238,7 +245,7 @@ func Convert_v1_Volume_To_api_Disk(source *v1.Volume, disk *Disk, c *ConverterCo
 	}
 
 	if source.HostDisk != nil {
-		return Convert_v1_HostDisk_To_api_Disk(source.Name, source.HostDisk.Path, disk, c)
+		return Convert_v1_HostDisk_To_api_Disk(source.Name, source.HostDisk, disk, c)
 	}
 
 	if source.PersistentVolumeClaim != nil {
@@ -325,10 +332,10 @@ func Convert_v1_BlockVolumeSource_To_api_Disk(volumeName string, disk *Disk, c *
 	return nil
 }
 
-func Convert_v1_HostDisk_To_api_Disk(volumeName string, path string, disk *Disk, c *ConverterContext) error {
+func Convert_v1_HostDisk_To_api_Disk(volumeName string, hostDisk *v1.HostDisk, disk *Disk, c *ConverterContext) error {
 	disk.Type = "file"
-	disk.Driver.Type = "raw"
-	disk.Source.File = hostdisk.GetMountedHostDiskPath(volumeName, path)
+	disk.Driver.Type = string(hostdisk.GetHostDiskFormat(hostDisk))
+	disk.Source.File = hostdisk.GetMountedHostDiskPath(volumeName, hostDisk.Path)
 	return nil
 }
 
@@ -694,6 +701,19 @@ func Convert_v1_VirtualMachine_To_api_Domain(vmi *v1.VirtualMachineInstance, dom
 		if len(vmi.Spec.Domain.Firmware.Serial) > 0 {
 			domain.Spec.SysInfo.System = append(domain.Spec.SysInfo.System, Entry{Name: "serial", Value: string(vmi.Spec.Domain.Firmware.Serial)})
 		}
+
+		// The kernel boot artifacts are mounted by virt-handler next to the containerDisks
+		if kernelBoot := vmi.Spec.Domain.Firmware.KernelBoot; kernelBoot != nil {
+			domain.Spec.OS.KernelArgs = kernelBoot.KernelArgs
+			if kernelBoot.Container != nil {
+				if kernelBoot.Container.KernelPath != "" {
+					domain.Spec.OS.Kernel = containerdisk.GenerateKernelBootArtifactPathFromLauncherView(containerdisk.KernelBootKernel)
+				}
+				if kernelBoot.Container.InitrdPath != "" {
+					domain.Spec.OS.Initrd = containerdisk.GenerateKernelBootArtifactPathFromLauncherView(containerdisk.KernelBootInitrd)
+				}
+			}
+		}
 	}
 	if c.SMBios != nil {
 		domain.Spec.SysInfo.System = append(domain.Spec.SysInfo.System,
@@ -750,7 +770,7 @@ func Convert_v1_VirtualMachine_To_api_Domain(vmi *v1.VirtualMachineInstance, dom
 		}
 	}
 
-	if domain.Spec.Memory, err = QuantityToByte(*getVirtualMemory(vmi)); err != nil {
+	if domain.Spec.Memory, err = QuantityToByte(*GetVirtualMemory(vmi)); err != nil {
 		return err
 	}
 
@@ -851,6 +871,17 @@ func Convert_v1_VirtualMachine_To_api_Domain(vmi *v1.VirtualMachineInstance, dom
 			return err
 		}
 
+		if disk.LUN != nil && disk.LUN.Reservations {
+			newDisk.Source.Reservations = &Reservations{
+				Managed: "no",
+				SourceReservations: &SourceReservations{
+					Type: "unix",
+					Path: reservation.SocketPath(),
+					Mode: "client",
+				},
+			}
+		}
+
 		if useIOThreads {
 			ioThreadId := defaultIOThread
 			dedicatedThread := false
@@ -908,9 +939,28 @@ func Convert_v1_VirtualMachine_To_api_Domain(vmi *v1.VirtualMachineInstance, dom
 		domain.Spec.Devices.Inputs = inputDevices
 	}
 
-	//usb controller is turned on, only when user specify input device with usb bus,
-	//otherwise it is turned off
-	if !isUSBDevicePresent {
+	//usb controller is turned on, only when user specify input device with usb bus
+	//or usb redirection, otherwise it is turned off
+	if usbredir.IsEnabled(vmi) {
+		// every redirected device needs a port, the default controller has too few
+		ports := uint(usbredir.ControllerPorts)
+		domain.Spec.Devices.Controllers = append(domain.Spec.Devices.Controllers, Controller{
+			Type:  "usb",
+			Index: "0",
+			Model: "qemu-xhci",
+			Ports: &ports,
+		})
+		for slot := 0; slot < usbredir.Slots(vmi); slot++ {
+			domain.Spec.Devices.Redirs = append(domain.Spec.Devices.Redirs, RedirDev{
+				Type: "unix",
+				Bus:  "usb",
+				Source: &RedirDevSource{
+					Mode: "bind",
+					Path: fmt.Sprintf("/var/run/kubevirt-private/%s/%s", vmi.ObjectMeta.UID, usbredir.SocketName(slot)),
+				},
+			})
+		}
+	} else if !isUSBDevicePresent {
 		// disable usb controller
 		domain.Spec.Devices.Controllers = append(domain.Spec.Devices.Controllers, Controller{
 			Type:  "usb",
@@ -1027,6 +1077,14 @@ func Convert_v1_VirtualMachine_To_api_Domain(vmi *v1.VirtualMachineInstance, dom
 		},
 	}
 
+	if consolelog.IsEnabled(vmi) {
+		// virtlogd writes the log and takes care of the rotation
+		domain.Spec.Devices.Serials[0].Log = &SerialLog{
+			File:   consolelog.LogFilePath(serialPort),
+			Append: "on",
+		}
+	}
+
 	if vmi.Spec.Domain.Devices.AutoattachGraphicsDevice == nil || *vmi.Spec.Domain.Devices.AutoattachGraphicsDevice == true {
 		var heads uint = 1
 		var vram uint = 16384
@@ -1232,7 +1290,8 @@ func Convert_v1_VirtualMachine_To_api_Domain(vmi *v1.VirtualMachineInstance, dom
 	return nil
 }
 
-func getVirtualMemory(vmi *v1.VirtualMachineInstance) *resource.Quantity {
+// GetVirtualMemory returns the amount of memory the guest sees
+func GetVirtualMemory(vmi *v1.VirtualMachineInstance) *resource.Quantity {
 	// In case that guest memory is explicitly set, return it
 	if vmi.Spec.Domain.Memory != nil && vmi.Spec.Domain.Memory.Guest != nil {
 		return vmi.Spec.Domain.Memory.Guest
@@ -82,6 +82,52 @@ var _ = Describe("Converter", func() {
 			Expect(xml).To(Equal(convertedDisk))
 		})
 
+		table.DescribeTable("Should set the error policy when provided", func(policy v1.DiskErrorPolicy) {
+			kubevirtDisk := &v1.Disk{
+				Name:        "mydisk",
+				ErrorPolicy: policy,
+				DiskDevice: v1.DiskDevice{
+					Disk: &v1.DiskTarget{
+						Bus: "virtio",
+					},
+				},
+			}
+			var convertedDisk = fmt.Sprintf(`<Disk device="disk" type="">
+  <source></source>
+  <target bus="virtio" dev="vda"></target>
+  <driver error_policy="%s" name="qemu" type=""></driver>
+  <alias name="ua-mydisk"></alias>
+</Disk>`, policy)
+			xml := diskToDiskXML(kubevirtDisk)
+			Expect(xml).To(Equal(convertedDisk))
+		},
+			table.Entry("stop", v1.DiskErrorPolicyStop),
+			table.Entry("report", v1.DiskErrorPolicyReport),
+			table.Entry("ignore", v1.DiskErrorPolicyIgnore),
+			table.Entry("enospace", v1.DiskErrorPolicyEnospace),
+		)
+
+		It("Should mark shareable disks as shareable", func() {
+			kubevirtDisk := &v1.Disk{
+				Name:      "mydisk",
+				Shareable: True(),
+				DiskDevice: v1.DiskDevice{
+					LUN: &v1.LunTarget{
+						Bus: "scsi",
+					},
+				},
+			}
+			var convertedDisk = `<Disk device="lun" type="">
+  <source></source>
+  <target bus="scsi" dev="sda"></target>
+  <driver name="qemu" type=""></driver>
+  <alias name="ua-mydisk"></alias>
+  <shareable></shareable>
+</Disk>`
+			xml := diskToDiskXML(kubevirtDisk)
+			Expect(xml).To(Equal(convertedDisk))
+		})
+
 	})
 
 	Context("with v1.VirtualMachineInstance", func() {
@@ -811,6 +857,45 @@ var _ = Describe("Converter", func() {
 			Expect(disabled).To(BeFalse(), "Expect controller not to be disabled")
 		})
 
+		It("should add usb redirection channels with an usb controller providing enough ports", func() {
+			v1.SetObjectDefaults_VirtualMachineInstance(vmi)
+			slots := int32(2)
+			vmi.Spec.Domain.Devices.USBRedirect = &v1.USBRedirect{Slots: &slots}
+			domain := vmiToDomain(vmi, c)
+
+			ports := uint(15)
+			Expect(domain.Spec.Devices.Controllers).To(ContainElement(Controller{
+				Type:  "usb",
+				Index: "0",
+				Model: "qemu-xhci",
+				Ports: &ports,
+			}))
+			Expect(domain.Spec.Devices.Redirs).To(Equal([]RedirDev{
+				{
+					Type: "unix",
+					Bus:  "usb",
+					Source: &RedirDevSource{
+						Mode: "bind",
+						Path: fmt.Sprintf("/var/run/kubevirt-private/%s/virt-usbredir0", vmi.UID),
+					},
+				},
+				{
+					Type: "unix",
+					Bus:  "usb",
+					Source: &RedirDevSource{
+						Mode: "bind",
+						Path: fmt.Sprintf("/var/run/kubevirt-private/%s/virt-usbredir1", vmi.UID),
+					},
+				},
+			}))
+		})
+
+		It("should not add usb redirection channels by default", func() {
+			v1.SetObjectDefaults_VirtualMachineInstance(vmi)
+			domain := vmiToDomain(vmi, c)
+			Expect(domain.Spec.Devices.Redirs).To(BeEmpty())
+		})
+
 		It("should fail when input device is set to ps2 bus", func() {
 			v1.SetObjectDefaults_VirtualMachineInstance(vmi)
 			vmi.Spec.Domain.Devices.Inputs[0].Bus = "ps2"
@@ -1526,6 +1611,76 @@ var _ = Describe("Converter", func() {
 				"expected number of queues to equal number of requested CPUs")
 		})
 	})
+	Context("SCSI persistent reservations", func() {
+		var vmi *v1.VirtualMachineInstance
+
+		BeforeEach(func() {
+			vmi = &v1.VirtualMachineInstance{
+				ObjectMeta: k8smeta.ObjectMeta{
+					Name:      "testvmi",
+					Namespace: "mynamespace",
+				},
+			}
+			v1.SetObjectDefaults_VirtualMachineInstance(vmi)
+			vmi.Spec.Domain.Devices.Disks = []v1.Disk{
+				{
+					Name: "mylun",
+					DiskDevice: v1.DiskDevice{
+						LUN: &v1.LunTarget{
+							Bus: "scsi",
+						},
+					},
+				},
+			}
+			vmi.Spec.Volumes = []v1.Volume{
+				{
+					Name: "mylun",
+					VolumeSource: v1.VolumeSource{
+						PersistentVolumeClaim: &k8sv1.PersistentVolumeClaimVolumeSource{
+							ClaimName: "myclaim",
+						},
+					},
+				},
+			}
+		})
+
+		It("should connect LUNs with reservations to the pr-helper socket", func() {
+			vmi.Spec.Domain.Devices.Disks[0].LUN.Reservations = true
+			c := &ConverterContext{UseEmulation: true, SMBios: &cmdv1.SMBios{}, IsBlockPVC: map[string]bool{"mylun": true}}
+			domain := vmiToDomain(vmi, c)
+			Expect(domain.Spec.Devices.Disks[0].Source.Reservations).To(Equal(&Reservations{
+				Managed: "no",
+				SourceReservations: &SourceReservations{
+					Type: "unix",
+					Path: "/var/run/kubevirt/daemons/pr/pr-helper.sock",
+					Mode: "client",
+				},
+			}))
+		})
+
+		It("should not configure reservations by default", func() {
+			c := &ConverterContext{UseEmulation: true, SMBios: &cmdv1.SMBios{}, IsBlockPVC: map[string]bool{"mylun": true}}
+			domain := vmiToDomain(vmi, c)
+			Expect(domain.Spec.Devices.Disks[0].Source.Reservations).To(BeNil())
+		})
+	})
+	Context("HostDisk", func() {
+		table.DescribeTable("should set the driver type of a HostDisk to its format", func(format v1.HostDiskFormat, expectedType string) {
+			hostDisk := &v1.HostDisk{
+				Path:   "/var/run/kubevirt-private/vmi-disks/myvolume/disk.img",
+				Type:   v1.HostDiskExistsOrCreate,
+				Format: format,
+			}
+			apiDisk := Disk{Driver: &DiskDriver{}}
+			Expect(Convert_v1_HostDisk_To_api_Disk("myvolume", hostDisk, &apiDisk, &ConverterContext{})).To(Succeed())
+			Expect(apiDisk.Type).To(Equal("file"))
+			Expect(apiDisk.Driver.Type).To(Equal(expectedType))
+		},
+			table.Entry("raw by default", v1.HostDiskFormat(""), "raw"),
+			table.Entry("raw", v1.HostDiskFormatRaw, "raw"),
+			table.Entry("qcow2", v1.HostDiskFormatQcow2, "qcow2"),
+		)
+	})
 	Context("Correctly handle iothreads with dedicated cpus", func() {
 		var vmi *v1.VirtualMachineInstance
 
@@ -1778,6 +1933,40 @@ var _ = Describe("Converter", func() {
 				Expect(domainSpec.OS.NVRam.NVRam).To(Equal("/tmp/mynamespace_testvmi"))
 			})
 		})
+
+		Context("when kernel boot is set", func() {
+			It("should boot the kernel and initrd of the kernel boot container", func() {
+				vmi.Spec.Domain.Firmware = &v1.Firmware{
+					KernelBoot: &v1.KernelBoot{
+						KernelArgs: "console=ttyS0",
+						Container: &v1.KernelBootContainer{
+							Image:      "kernel-image",
+							KernelPath: "/boot/vmlinuz",
+							InitrdPath: "/boot/initramfs.img",
+						},
+					},
+				}
+				domainSpec := vmiToDomainXMLToDomainSpec(vmi, c)
+				Expect(domainSpec.OS.Kernel).To(HaveSuffix("/kernel-boot_kernel"))
+				Expect(domainSpec.OS.Initrd).To(HaveSuffix("/kernel-boot_initrd"))
+				Expect(domainSpec.OS.KernelArgs).To(Equal("console=ttyS0"))
+			})
+
+			It("should not set an initrd if the kernel boot container has none", func() {
+				vmi.Spec.Domain.Firmware = &v1.Firmware{
+					KernelBoot: &v1.KernelBoot{
+						Container: &v1.KernelBootContainer{
+							Image:      "kernel-image",
+							KernelPath: "/boot/vmlinuz",
+						},
+					},
+				}
+				domainSpec := vmiToDomainXMLToDomainSpec(vmi, c)
+				Expect(domainSpec.OS.Kernel).To(HaveSuffix("/kernel-boot_kernel"))
+				Expect(domainSpec.OS.Initrd).To(BeEmpty())
+				Expect(domainSpec.OS.KernelArgs).To(BeEmpty())
+			})
+		})
 	})
 
 	Context("GPU resource request", func() {
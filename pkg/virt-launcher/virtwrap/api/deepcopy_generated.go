@@ -130,6 +130,35 @@ func (in *BackingStoreFormat) DeepCopy() *BackingStoreFormat {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupMetadata) DeepCopyInto(out *BackupMetadata) {
+	*out = *in
+	if in.Volumes != nil {
+		in, out := &in.Volumes, &out.Volumes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.StartTimestamp != nil {
+		in, out := &in.StartTimestamp, &out.StartTimestamp
+		*out = (*in).DeepCopy()
+	}
+	if in.EndTimestamp != nil {
+		in, out := &in.EndTimestamp, &out.EndTimestamp
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupMetadata.
+func (in *BackupMetadata) DeepCopy() *BackupMetadata {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupMetadata)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Ballooning) DeepCopyInto(out *Ballooning) {
 	*out = *in
@@ -512,6 +541,11 @@ func (in *ConsoleTarget) DeepCopy() *ConsoleTarget {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Controller) DeepCopyInto(out *Controller) {
 	*out = *in
+	if in.Ports != nil {
+		in, out := &in.Ports, &out.Ports
+		*out = new(uint)
+		**out = **in
+	}
 	if in.Driver != nil {
 		in, out := &in.Driver, &out.Driver
 		*out = new(ControllerDriver)
@@ -639,6 +673,13 @@ func (in *Devices) DeepCopyInto(out *Devices) {
 		*out = new(Rng)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Redirs != nil {
+		in, out := &in.Redirs, &out.Redirs
+		*out = make([]RedirDev, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -692,6 +733,11 @@ func (in *Disk) DeepCopyInto(out *Disk) {
 		*out = new(Address)
 		**out = **in
 	}
+	if in.Shareable != nil {
+		in, out := &in.Shareable, &out.Shareable
+		*out = new(Shareable)
+		**out = **in
+	}
 	return
 }
 
@@ -752,6 +798,22 @@ func (in *DiskDriver) DeepCopy() *DiskDriver {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DiskError) DeepCopyInto(out *DiskError) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DiskError.
+func (in *DiskError) DeepCopy() *DiskError {
+	if in == nil {
+		return nil
+	}
+	out := new(DiskError)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DiskSecret) DeepCopyInto(out *DiskSecret) {
 	*out = *in
@@ -776,6 +838,11 @@ func (in *DiskSource) DeepCopyInto(out *DiskSource) {
 		*out = new(DiskSourceHost)
 		**out = **in
 	}
+	if in.Reservations != nil {
+		in, out := &in.Reservations, &out.Reservations
+		*out = new(Reservations)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -960,6 +1027,11 @@ func (in *DomainStatus) DeepCopyInto(out *DomainStatus) {
 		}
 	}
 	out.OSInfo = in.OSInfo
+	if in.DiskErrors != nil {
+		in, out := &in.DiskErrors, &out.DiskErrors
+		*out = make([]DiskError, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -1566,6 +1638,16 @@ func (in *KubeVirtMetadata) DeepCopyInto(out *KubeVirtMetadata) {
 		*out = new(MigrationMetadata)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.SoftReboot != nil {
+		in, out := &in.SoftReboot, &out.SoftReboot
+		*out = new(SoftRebootMetadata)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Backup != nil {
+		in, out := &in.Backup, &out.Backup
+		*out = new(BackupMetadata)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -1832,6 +1914,69 @@ func (in *ReadOnly) DeepCopy() *ReadOnly {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RedirDev) DeepCopyInto(out *RedirDev) {
+	*out = *in
+	if in.Source != nil {
+		in, out := &in.Source, &out.Source
+		*out = new(RedirDevSource)
+		**out = **in
+	}
+	if in.Alias != nil {
+		in, out := &in.Alias, &out.Alias
+		*out = new(Alias)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RedirDev.
+func (in *RedirDev) DeepCopy() *RedirDev {
+	if in == nil {
+		return nil
+	}
+	out := new(RedirDev)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RedirDevSource) DeepCopyInto(out *RedirDevSource) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RedirDevSource.
+func (in *RedirDevSource) DeepCopy() *RedirDevSource {
+	if in == nil {
+		return nil
+	}
+	out := new(RedirDevSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Reservations) DeepCopyInto(out *Reservations) {
+	*out = *in
+	if in.SourceReservations != nil {
+		in, out := &in.SourceReservations, &out.SourceReservations
+		*out = new(SourceReservations)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Reservations.
+func (in *Reservations) DeepCopy() *Reservations {
+	if in == nil {
+		return nil
+	}
+	out := new(Reservations)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Resource) DeepCopyInto(out *Resource) {
 	*out = *in
@@ -1964,6 +2109,11 @@ func (in *Serial) DeepCopyInto(out *Serial) {
 		*out = new(SerialSource)
 		**out = **in
 	}
+	if in.Log != nil {
+		in, out := &in.Log, &out.Log
+		*out = new(SerialLog)
+		**out = **in
+	}
 	if in.Alias != nil {
 		in, out := &in.Alias, &out.Alias
 		*out = new(Alias)
@@ -1982,6 +2132,22 @@ func (in *Serial) DeepCopy() *Serial {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SerialLog) DeepCopyInto(out *SerialLog) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SerialLog.
+func (in *SerialLog) DeepCopy() *SerialLog {
+	if in == nil {
+		return nil
+	}
+	out := new(SerialLog)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SerialSource) DeepCopyInto(out *SerialSource) {
 	*out = *in
@@ -2019,6 +2185,58 @@ func (in *SerialTarget) DeepCopy() *SerialTarget {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Shareable) DeepCopyInto(out *Shareable) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Shareable.
+func (in *Shareable) DeepCopy() *Shareable {
+	if in == nil {
+		return nil
+	}
+	out := new(Shareable)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SoftRebootMetadata) DeepCopyInto(out *SoftRebootMetadata) {
+	*out = *in
+	if in.LastRebootTimestamp != nil {
+		in, out := &in.LastRebootTimestamp, &out.LastRebootTimestamp
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SoftRebootMetadata.
+func (in *SoftRebootMetadata) DeepCopy() *SoftRebootMetadata {
+	if in == nil {
+		return nil
+	}
+	out := new(SoftRebootMetadata)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SourceReservations) DeepCopyInto(out *SourceReservations) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SourceReservations.
+func (in *SourceReservations) DeepCopy() *SourceReservations {
+	if in == nil {
+		return nil
+	}
+	out := new(SourceReservations)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SysInfo) DeepCopyInto(out *SysInfo) {
 	*out = *in
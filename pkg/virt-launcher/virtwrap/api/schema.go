@@ -107,6 +107,20 @@ type DomainStatus struct {
 	Reason     StateChangeReason
 	Interfaces []InterfaceStatus
 	OSInfo     GuestOSInfo
+	DiskErrors []DiskError
+}
+
+type DiskErrorReason string
+
+const (
+	DiskErrorUnspecified DiskErrorReason = "Unspecified"
+	DiskErrorNoSpace     DiskErrorReason = "NoSpace"
+)
+
+// DiskError names a disk which caused the domain to pause
+type DiskError struct {
+	Name   string
+	Reason DiskErrorReason
 }
 
 type GuestOSInfo struct {
@@ -249,6 +263,8 @@ type KubeVirtMetadata struct {
 	UID         types.UID            `xml:"uid"`
 	GracePeriod *GracePeriodMetadata `xml:"graceperiod,omitempty"`
 	Migration   *MigrationMetadata   `xml:"migration,omitempty"`
+	SoftReboot  *SoftRebootMetadata  `xml:"softReboot,omitempty"`
+	Backup      *BackupMetadata      `xml:"backup,omitempty"`
 }
 
 type MigrationMetadata struct {
@@ -261,6 +277,24 @@ type MigrationMetadata struct {
 	AbortStatus    string       `xml:"abortStatus,omitempty"`
 }
 
+type SoftRebootMetadata struct {
+	LastRebootTimestamp *metav1.Time `xml:"lastRebootTimestamp,omitempty"`
+	Count               int64        `xml:"count,omitempty"`
+}
+
+// BackupMetadata tracks the backup job which exports the disks of the domain
+type BackupMetadata struct {
+	Name           string       `xml:"name"`
+	Checkpoint     string       `xml:"checkpoint"`
+	BaseCheckpoint string       `xml:"baseCheckpoint,omitempty"`
+	Volumes        []string     `xml:"volume,omitempty"`
+	StartTimestamp *metav1.Time `xml:"startTimestamp,omitempty"`
+	EndTimestamp   *metav1.Time `xml:"endTimestamp,omitempty"`
+	Completed      bool         `xml:"completed,omitempty"`
+	Failed         bool         `xml:"failed,omitempty"`
+	Message        string       `xml:"message,omitempty"`
+}
+
 type GracePeriodMetadata struct {
 	DeletionGracePeriodSeconds int64        `xml:"deletionGracePeriodSeconds"`
 	DeletionTimestamp          *metav1.Time `xml:"deletionTimestamp,omitempty"`
@@ -320,6 +354,7 @@ type Devices struct {
 	Consoles    []Console    `xml:"console"`
 	Watchdog    *Watchdog    `xml:"watchdog,omitempty"`
 	Rng         *Rng         `xml:"rng,omitempty"`
+	Redirs      []RedirDev   `xml:"redirdev,omitempty"`
 }
 
 // Input represents input device, e.g. tablet
@@ -352,6 +387,7 @@ type Controller struct {
 	Type   string            `xml:"type,attr"`
 	Index  string            `xml:"index,attr"`
 	Model  string            `xml:"model,attr,omitempty"`
+	Ports  *uint             `xml:"ports,attr,omitempty"`
 	Driver *ControllerDriver `xml:"driver,omitempty"`
 }
 
@@ -380,8 +416,11 @@ type Disk struct {
 	BackingStore *BackingStore `xml:"backingStore,omitempty"`
 	BootOrder    *BootOrder    `xml:"boot,omitempty"`
 	Address      *Address      `xml:"address,omitempty"`
+	Shareable    *Shareable    `xml:"shareable,omitempty"`
 }
 
+type Shareable struct{}
+
 type DiskAuth struct {
 	Username string      `xml:"username,attr"`
 	Secret   *DiskSecret `xml:"secret,omitempty"`
@@ -402,6 +441,18 @@ type DiskSource struct {
 	Protocol      string          `xml:"protocol,attr,omitempty"`
 	Name          string          `xml:"name,attr,omitempty"`
 	Host          *DiskSourceHost `xml:"host,omitempty"`
+	Reservations  *Reservations   `xml:"reservations,omitempty"`
+}
+
+type Reservations struct {
+	Managed            string              `xml:"managed,attr,omitempty"`
+	SourceReservations *SourceReservations `xml:"source,omitempty"`
+}
+
+type SourceReservations struct {
+	Type string `xml:"type,attr"`
+	Path string `xml:"path,attr,omitempty"`
+	Mode string `xml:"mode,attr,omitempty"`
 }
 
 type DiskTarget struct {
@@ -443,6 +494,7 @@ type Serial struct {
 	Type   string        `xml:"type,attr"`
 	Target *SerialTarget `xml:"target,omitempty"`
 	Source *SerialSource `xml:"source,omitempty"`
+	Log    *SerialLog    `xml:"log,omitempty"`
 	Alias  *Alias        `xml:"alias,omitempty"`
 }
 
@@ -455,8 +507,29 @@ type SerialSource struct {
 	Path string `xml:"path,attr,omitempty"`
 }
 
+type SerialLog struct {
+	File   string `xml:"file,attr,omitempty"`
+	Append string `xml:"append,attr,omitempty"`
+}
+
 // END Serial -----------------------------
 
+// BEGIN RedirDev -----------------------------
+
+type RedirDev struct {
+	Type   string          `xml:"type,attr"`
+	Bus    string          `xml:"bus,attr"`
+	Source *RedirDevSource `xml:"source,omitempty"`
+	Alias  *Alias          `xml:"alias,omitempty"`
+}
+
+type RedirDevSource struct {
+	Mode string `xml:"mode,attr,omitempty"`
+	Path string `xml:"path,attr,omitempty"`
+}
+
+// END RedirDev -----------------------------
+
 // BEGIN Console -----------------------------
 
 type Console struct {
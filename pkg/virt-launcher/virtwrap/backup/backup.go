@@ -0,0 +1,305 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2020 Red Hat, Inc.
+ *
+ */
+
+package backup
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+	"time"
+
+	"kubevirt.io/client-go/log"
+	diskutils "kubevirt.io/kubevirt/pkg/ephemeral-disk-utils"
+	vmbackup "kubevirt.io/kubevirt/pkg/vm-backup"
+)
+
+// The libvirt version in virt-launcher has no backup API yet, so backup jobs
+// are driven through QMP. A backup job runs in pull mode:
+//
+//  1. Every disk gets a qcow2 scratch overlay with the live disk as backing
+//     image, which receives the blocks the guest overwrites during the backup
+//     (blockdev-backup with sync=none).
+//  2. The checkpoint of the backup is created as dirty bitmap on every disk in
+//     the same transaction, so that it tracks all writes after the point in time
+//     of the backup. On qcow2 images the bitmap is persistent.
+//  3. For incremental backups a frozen copy of the base checkpoint is exported
+//     together with the overlay, it reports the blocks changed since the base.
+//  4. The overlays are exported read-only over a NBD server on a unix socket.
+const (
+	jobCancelTimeout  = 30 * time.Second
+	jobCancelInterval = 500 * time.Millisecond
+)
+
+// Monitor executes QMP commands in the qemu process of a domain
+type Monitor interface {
+	// Execute runs the command with the given arguments and decodes its return value into result, if result is not nil
+	Execute(command string, arguments interface{}, result interface{}) error
+}
+
+// Disk is a disk of the domain which is part of a backup job
+type Disk struct {
+	VolumeName string
+	// Size is the virtual size of the disk in bytes
+	Size int64
+	// PersistentBitmaps is true if the disk image can store dirty bitmaps (qcow2)
+	PersistentBitmaps bool
+}
+
+// Job describes a backup job of a domain
+type Job struct {
+	Name           string
+	Checkpoint     string
+	BaseCheckpoint string
+	// Dir holds the scratch images and the NBD socket
+	Dir   string
+	Disks []Disk
+}
+
+type Manager struct {
+	monitor Monitor
+	// createImage creates an empty qcow2 image of the given size
+	createImage func(path string, size int64) error
+}
+
+func NewManager(monitor Monitor) *Manager {
+	return &Manager{
+		monitor:     monitor,
+		createImage: createScratchImage,
+	}
+}
+
+func createScratchImage(path string, size int64) error {
+	cmd := exec.Command("qemu-img", "create", "-f", "qcow2", path, strconv.FormatInt(size, 10))
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("qemu-img failed with output '%s': %v", string(output), err)
+	}
+	// qemu writes the overwritten blocks into the image
+	return diskutils.DefaultOwnershipManager.SetFileOwnership(path)
+}
+
+// DriveName returns the name qemu knows the drive of a disk by
+func DriveName(volumeName string) string {
+	return "drive-ua-" + volumeName
+}
+
+func scratchNodeName(volumeName string) string {
+	return "backup-" + volumeName
+}
+
+type action struct {
+	Type string      `json:"type"`
+	Data interface{} `json:"data"`
+}
+
+type blockJob struct {
+	Device string `json:"device"`
+}
+
+// Start creates the checkpoint of the job and exports the disks over NBD.
+// If the job can't be started, everything created so far is removed again.
+func (m *Manager) Start(job *Job) error {
+	if err := m.start(job); err != nil {
+		if cleanupErr := m.Finish(job, true); cleanupErr != nil {
+			log.Log.Reason(cleanupErr).Errorf("Failed to clean up backup %s", job.Name)
+		}
+		return err
+	}
+	return nil
+}
+
+func (m *Manager) start(job *Job) error {
+	if len(job.Disks) == 0 {
+		return fmt.Errorf("no disks to back up")
+	}
+
+	for _, disk := range job.Disks {
+		scratchFile := vmbackup.ScratchFilePath(job.Dir, job.Checkpoint, disk.VolumeName)
+		if err := m.createImage(scratchFile, disk.Size); err != nil {
+			return fmt.Errorf("failed to create the scratch image of volume %s: %v", disk.VolumeName, err)
+		}
+
+		err := m.monitor.Execute("blockdev-add", map[string]interface{}{
+			"driver":    "qcow2",
+			"node-name": scratchNodeName(disk.VolumeName),
+			"file": map[string]interface{}{
+				"driver":   "file",
+				"filename": scratchFile,
+			},
+			"backing": DriveName(disk.VolumeName),
+		}, nil)
+		if err != nil {
+			return fmt.Errorf("failed to add the scratch image of volume %s: %v", disk.VolumeName, err)
+		}
+	}
+
+	// All disks have to be captured at the same point in time
+	var actions []action
+	for _, disk := range job.Disks {
+		drive := DriveName(disk.VolumeName)
+		actions = append(actions, action{
+			Type: "block-dirty-bitmap-add",
+			Data: map[string]interface{}{
+				"node":       drive,
+				"name":       job.Checkpoint,
+				"persistent": disk.PersistentBitmaps,
+			},
+		})
+		if job.BaseCheckpoint != "" {
+			actions = append(actions, action{
+				Type: "block-dirty-bitmap-add",
+				Data: map[string]interface{}{
+					"node":     drive,
+					"name":     vmbackup.ExportBitmapName(job.Checkpoint),
+					"disabled": true,
+				},
+			}, action{
+				Type: "block-dirty-bitmap-merge",
+				Data: map[string]interface{}{
+					"node":    drive,
+					"target":  vmbackup.ExportBitmapName(job.Checkpoint),
+					"bitmaps": []string{job.BaseCheckpoint},
+				},
+			})
+		}
+		actions = append(actions, action{
+			Type: "blockdev-backup",
+			Data: map[string]interface{}{
+				"job-id": scratchNodeName(disk.VolumeName),
+				"device": drive,
+				"target": scratchNodeName(disk.VolumeName),
+				"sync":   "none",
+			},
+		})
+	}
+	if err := m.monitor.Execute("transaction", map[string]interface{}{"actions": actions}, nil); err != nil {
+		return fmt.Errorf("failed to create checkpoint %s: %v", job.Checkpoint, err)
+	}
+
+	err := m.monitor.Execute("nbd-server-start", map[string]interface{}{
+		"addr": map[string]interface{}{
+			"type": "unix",
+			"data": map[string]interface{}{
+				"path": vmbackup.SocketPath(job.Dir),
+			},
+		},
+	}, nil)
+	if err != nil {
+		return fmt.Errorf("failed to start the NBD server: %v", err)
+	}
+
+	for _, disk := range job.Disks {
+		arguments := map[string]interface{}{
+			"device":   scratchNodeName(disk.VolumeName),
+			"name":     disk.VolumeName,
+			"writable": false,
+		}
+		if job.BaseCheckpoint != "" {
+			arguments["bitmap"] = vmbackup.ExportBitmapName(job.Checkpoint)
+		}
+		if err := m.monitor.Execute("nbd-server-add", arguments, nil); err != nil {
+			return fmt.Errorf("failed to export volume %s: %v", disk.VolumeName, err)
+		}
+	}
+	return nil
+}
+
+// Finish stops the NBD exports and removes the scratch images of the job.
+// With abort the checkpoint of the job is removed too, so that later
+// incremental backups can't be based on it. All steps are tried, the first
+// error is returned.
+func (m *Manager) Finish(job *Job, abort bool) error {
+	var firstErr error
+	collect := func(err error) {
+		if err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	// Fails if the server was never started, which is fine
+	m.monitor.Execute("nbd-server-stop", nil, nil)
+
+	for _, disk := range job.Disks {
+		// Fails if the job was never started, which is fine
+		m.monitor.Execute("block-job-cancel", map[string]interface{}{
+			"device": scratchNodeName(disk.VolumeName),
+			"force":  true,
+		}, nil)
+	}
+	collect(m.waitForCancelledJobs(job))
+
+	for _, disk := range job.Disks {
+		drive := DriveName(disk.VolumeName)
+		scratchFile := vmbackup.ScratchFilePath(job.Dir, job.Checkpoint, disk.VolumeName)
+		if _, err := os.Stat(scratchFile); os.IsNotExist(err) {
+			// Nothing was set up for this disk
+			continue
+		}
+
+		m.monitor.Execute("blockdev-del", map[string]interface{}{"node-name": scratchNodeName(disk.VolumeName)}, nil)
+		if job.BaseCheckpoint != "" {
+			m.monitor.Execute("block-dirty-bitmap-remove", map[string]interface{}{
+				"node": drive,
+				"name": vmbackup.ExportBitmapName(job.Checkpoint),
+			}, nil)
+		}
+		if abort {
+			m.monitor.Execute("block-dirty-bitmap-remove", map[string]interface{}{
+				"node": drive,
+				"name": job.Checkpoint,
+			}, nil)
+		}
+		if err := os.Remove(scratchFile); err != nil && !os.IsNotExist(err) {
+			collect(fmt.Errorf("failed to remove the scratch image of volume %s: %v", disk.VolumeName, err))
+		}
+	}
+	return firstErr
+}
+
+// waitForCancelledJobs waits until qemu dropped the backup block jobs, the scratch
+// images can't be removed before
+func (m *Manager) waitForCancelledJobs(job *Job) error {
+	jobNames := map[string]bool{}
+	for _, disk := range job.Disks {
+		jobNames[scratchNodeName(disk.VolumeName)] = true
+	}
+
+	deadline := time.Now().Add(jobCancelTimeout)
+	for {
+		var jobs []blockJob
+		if err := m.monitor.Execute("query-block-jobs", nil, &jobs); err != nil {
+			return fmt.Errorf("failed to query the block jobs: %v", err)
+		}
+		running := false
+		for _, j := range jobs {
+			if jobNames[j.Device] {
+				running = true
+			}
+		}
+		if !running {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("timed out waiting for the backup block jobs to be cancelled")
+		}
+		time.Sleep(jobCancelInterval)
+	}
+}
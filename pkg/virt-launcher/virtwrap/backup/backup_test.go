@@ -0,0 +1,165 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2020 Red Hat, Inc.
+ *
+ */
+
+package backup
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	vmbackup "kubevirt.io/kubevirt/pkg/vm-backup"
+)
+
+type fakeMonitor struct {
+	commands  []string
+	arguments map[string][]string
+	failOn    string
+	jobs      []blockJob
+}
+
+func (f *fakeMonitor) Execute(command string, arguments interface{}, result interface{}) error {
+	f.commands = append(f.commands, command)
+	if arguments != nil {
+		data, err := json.Marshal(arguments)
+		Expect(err).ToNot(HaveOccurred())
+		f.arguments[command] = append(f.arguments[command], string(data))
+	}
+	if command == f.failOn {
+		return fmt.Errorf("%s failed", command)
+	}
+	if command == "query-block-jobs" {
+		*result.(*[]blockJob) = f.jobs
+		f.jobs = nil
+	}
+	return nil
+}
+
+var _ = Describe("Backup", func() {
+
+	var monitor *fakeMonitor
+	var manager *Manager
+	var dir string
+	var job *Job
+
+	BeforeEach(func() {
+		var err error
+		dir, err = ioutil.TempDir("", "backup")
+		Expect(err).ToNot(HaveOccurred())
+
+		monitor = &fakeMonitor{arguments: map[string][]string{}}
+		manager = &Manager{
+			monitor: monitor,
+			createImage: func(path string, size int64) error {
+				return ioutil.WriteFile(path, []byte{}, 0644)
+			},
+		}
+		job = &Job{
+			Name:       "backup",
+			Checkpoint: "cp2",
+			Dir:        dir,
+			Disks: []Disk{
+				{VolumeName: "rootdisk", Size: 1024, PersistentBitmaps: true},
+				{VolumeName: "datadisk", Size: 2048},
+			},
+		}
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(dir)
+	})
+
+	It("should export a full backup over NBD", func() {
+		Expect(manager.Start(job)).To(Succeed())
+
+		Expect(monitor.commands).To(Equal([]string{
+			"blockdev-add", "blockdev-add", "transaction", "nbd-server-start", "nbd-server-add", "nbd-server-add",
+		}))
+		Expect(monitor.arguments["blockdev-add"][0]).To(MatchJSON(fmt.Sprintf(`{
+			"driver": "qcow2",
+			"node-name": "backup-rootdisk",
+			"file": {"driver": "file", "filename": "%s"},
+			"backing": "drive-ua-rootdisk"
+		}`, vmbackup.ScratchFilePath(dir, "cp2", "rootdisk"))))
+		Expect(monitor.arguments["transaction"][0]).To(MatchJSON(`{"actions": [
+			{"type": "block-dirty-bitmap-add", "data": {"node": "drive-ua-rootdisk", "name": "cp2", "persistent": true}},
+			{"type": "blockdev-backup", "data": {"job-id": "backup-rootdisk", "device": "drive-ua-rootdisk", "target": "backup-rootdisk", "sync": "none"}},
+			{"type": "block-dirty-bitmap-add", "data": {"node": "drive-ua-datadisk", "name": "cp2", "persistent": false}},
+			{"type": "blockdev-backup", "data": {"job-id": "backup-datadisk", "device": "drive-ua-datadisk", "target": "backup-datadisk", "sync": "none"}}
+		]}`))
+		Expect(monitor.arguments["nbd-server-start"][0]).To(MatchJSON(fmt.Sprintf(`{"addr": {"type": "unix", "data": {"path": "%s"}}}`, vmbackup.SocketPath(dir))))
+		Expect(monitor.arguments["nbd-server-add"][1]).To(MatchJSON(`{"device": "backup-datadisk", "name": "datadisk", "writable": false}`))
+	})
+
+	It("should export the changed blocks of an incremental backup", func() {
+		job.BaseCheckpoint = "cp1"
+		job.Disks = job.Disks[:1]
+		Expect(manager.Start(job)).To(Succeed())
+
+		Expect(monitor.arguments["transaction"][0]).To(MatchJSON(`{"actions": [
+			{"type": "block-dirty-bitmap-add", "data": {"node": "drive-ua-rootdisk", "name": "cp2", "persistent": true}},
+			{"type": "block-dirty-bitmap-add", "data": {"node": "drive-ua-rootdisk", "name": "backup-cp2", "disabled": true}},
+			{"type": "block-dirty-bitmap-merge", "data": {"node": "drive-ua-rootdisk", "target": "backup-cp2", "bitmaps": ["cp1"]}},
+			{"type": "blockdev-backup", "data": {"job-id": "backup-rootdisk", "device": "drive-ua-rootdisk", "target": "backup-rootdisk", "sync": "none"}}
+		]}`))
+		Expect(monitor.arguments["nbd-server-add"][0]).To(MatchJSON(`{"device": "backup-rootdisk", "name": "rootdisk", "writable": false, "bitmap": "backup-cp2"}`))
+	})
+
+	It("should clean up and drop the checkpoint if the backup can't be started", func() {
+		monitor.failOn = "nbd-server-start"
+		Expect(manager.Start(job)).To(MatchError(ContainSubstring("failed to start the NBD server")))
+
+		Expect(monitor.arguments["block-dirty-bitmap-remove"]).To(ConsistOf(
+			`{"name":"cp2","node":"drive-ua-rootdisk"}`,
+			`{"name":"cp2","node":"drive-ua-datadisk"}`,
+		))
+		Expect(vmbackup.ScratchFilePath(dir, "cp2", "rootdisk")).ToNot(BeAnExistingFile())
+		Expect(vmbackup.ScratchFilePath(dir, "cp2", "datadisk")).ToNot(BeAnExistingFile())
+	})
+
+	It("should keep the checkpoint when a backup finishes", func() {
+		job.BaseCheckpoint = "cp1"
+		Expect(manager.Start(job)).To(Succeed())
+		monitor.commands = nil
+		monitor.jobs = []blockJob{{Device: "backup-rootdisk"}}
+
+		Expect(manager.Finish(job, false)).To(Succeed())
+
+		Expect(monitor.commands).To(Equal([]string{
+			"nbd-server-stop", "block-job-cancel", "block-job-cancel", "query-block-jobs", "query-block-jobs",
+			"blockdev-del", "block-dirty-bitmap-remove", "blockdev-del", "block-dirty-bitmap-remove",
+		}))
+		Expect(monitor.arguments["block-dirty-bitmap-remove"]).To(ConsistOf(
+			`{"name":"backup-cp2","node":"drive-ua-rootdisk"}`,
+			`{"name":"backup-cp2","node":"drive-ua-datadisk"}`,
+		))
+		Expect(vmbackup.ScratchFilePath(dir, "cp2", "rootdisk")).ToNot(BeAnExistingFile())
+	})
+
+	It("should skip disks which were never set up", func() {
+		Expect(manager.Finish(job, true)).To(Succeed())
+		Expect(monitor.commands).To(Equal([]string{
+			"nbd-server-stop", "block-job-cancel", "block-job-cancel", "query-block-jobs",
+		}))
+	})
+})
@@ -336,6 +336,11 @@ type VirDomain interface {
 	Resume() error
 	DestroyFlags(flags libvirt.DomainDestroyFlags) error
 	ShutdownFlags(flags libvirt.DomainShutdownFlags) error
+	Reboot(flags libvirt.DomainRebootFlagValues) error
+	CoreDumpWithFormat(to string, format libvirt.DomainCoreDumpFormat, flags libvirt.DomainCoreDumpFlags) error
+	GetBlockInfo(disk string, flag uint) (*libvirt.DomainBlockInfo, error)
+	BlockResize(disk string, size uint64, flags libvirt.DomainBlockResizeFlags) error
+	QemuMonitorCommand(command string, flags libvirt.DomainQemuMonitorCommandFlags) (string, error)
 	UndefineFlags(flags libvirt.DomainUndefineFlagsValues) error
 	GetName() (string, error)
 	GetUUIDString() (string, error)
@@ -347,6 +352,8 @@ type VirDomain interface {
 	GetJobStats(flags libvirt.DomainGetJobStatsFlags) (*libvirt.DomainJobInfo, error)
 	GetJobInfo() (*libvirt.DomainJobInfo, error)
 	AbortJob() error
+	Screenshot(stream *libvirt.Stream, screen uint32, flags uint32) (string, error)
+	GetDiskErrors(flags uint32) ([]libvirt.DomainDiskError, error)
 	Free() error
 }
 
@@ -22,6 +22,7 @@ package cmdserver
 import (
 	"fmt"
 	"os"
+	"path/filepath"
 	"time"
 
 	"golang.org/x/net/context"
@@ -80,6 +81,20 @@ func getMigrationOptionsFromRequest(request *cmdv1.MigrationRequest) (*cmdclient
 	return options, nil
 }
 
+func getBackupOptionsFromRequest(request *cmdv1.BackupRequest) (*cmdclient.BackupOptions, error) {
+
+	if request.Options == nil {
+		return nil, fmt.Errorf("backup options object not present in command server request")
+	}
+
+	var options *cmdclient.BackupOptions
+	if err := json.Unmarshal(request.Options, &options); err != nil {
+		return nil, fmt.Errorf("no valid backup options object present in command server request: %v", err)
+	}
+
+	return options, nil
+}
+
 func getErrorMessage(err error) string {
 	if virErr := launcherErrors.FormatLibvirtError(err); virErr != "" {
 		return virErr
@@ -202,6 +217,115 @@ func (l *Launcher) UnpauseVirtualMachine(ctx context.Context, request *cmdv1.VMI
 	return response, nil
 }
 
+func (l *Launcher) SoftRebootVirtualMachine(ctx context.Context, request *cmdv1.VMIRequest) (*cmdv1.Response, error) {
+	vmi, response := getVMIFromRequest(request.Vmi)
+	if !response.Success {
+		return response, nil
+	}
+
+	if err := l.domainManager.SoftRebootVMI(vmi); err != nil {
+		log.Log.Object(vmi).Reason(err).Errorf("Failed to soft reboot vmi")
+		response.Success = false
+		response.Message = getErrorMessage(err)
+		return response, nil
+	}
+
+	log.Log.Object(vmi).Info("Soft rebooted vmi")
+	return response, nil
+}
+
+func (l *Launcher) MemoryDumpVirtualMachine(ctx context.Context, request *cmdv1.MemoryDumpRequest) (*cmdv1.Response, error) {
+	vmi, response := getVMIFromRequest(request.Vmi)
+	if !response.Success {
+		return response, nil
+	}
+
+	if request.FileName == "" || filepath.Base(request.FileName) != request.FileName || request.FileName == ".." {
+		response.Success = false
+		response.Message = "No valid memory dump file name present in command server request"
+		return response, nil
+	}
+
+	if err := l.domainManager.MemoryDumpVMI(vmi, request.FileName); err != nil {
+		log.Log.Object(vmi).Reason(err).Errorf("Failed to dump the memory of vmi")
+		response.Success = false
+		response.Message = getErrorMessage(err)
+		return response, nil
+	}
+
+	log.Log.Object(vmi).Info("Started memory dump of vmi")
+	return response, nil
+}
+
+func (l *Launcher) ResizeVirtualMachineDisks(ctx context.Context, request *cmdv1.VMIRequest) (*cmdv1.ResizeDisksResponse, error) {
+	vmi, response := getVMIFromRequest(request.Vmi)
+	resizeResponse := &cmdv1.ResizeDisksResponse{
+		Response: response,
+	}
+	if !response.Success {
+		return resizeResponse, nil
+	}
+
+	resizedVolumes, err := l.domainManager.ResizeVMIDisks(vmi)
+	resizeResponse.ResizedVolumes = resizedVolumes
+	if err != nil {
+		log.Log.Object(vmi).Reason(err).Errorf("Failed to resize the disks of vmi")
+		response.Success = false
+		response.Message = getErrorMessage(err)
+		return resizeResponse, nil
+	}
+
+	return resizeResponse, nil
+}
+
+func (l *Launcher) BackupVirtualMachine(ctx context.Context, request *cmdv1.BackupRequest) (*cmdv1.Response, error) {
+	vmi, response := getVMIFromRequest(request.Vmi)
+	if !response.Success {
+		return response, nil
+	}
+
+	options, err := getBackupOptionsFromRequest(request)
+	if err != nil {
+		response.Success = false
+		response.Message = err.Error()
+		return response, nil
+	}
+
+	if err := l.domainManager.BackupVMI(vmi, options); err != nil {
+		log.Log.Object(vmi).Reason(err).Errorf("Failed to start the backup of vmi")
+		response.Success = false
+		response.Message = getErrorMessage(err)
+		return response, nil
+	}
+
+	log.Log.Object(vmi).Infof("Started backup %s of vmi", options.BackupName)
+	return response, nil
+}
+
+func (l *Launcher) FinishVirtualMachineBackup(ctx context.Context, request *cmdv1.BackupRequest) (*cmdv1.Response, error) {
+	vmi, response := getVMIFromRequest(request.Vmi)
+	if !response.Success {
+		return response, nil
+	}
+
+	options, err := getBackupOptionsFromRequest(request)
+	if err != nil {
+		response.Success = false
+		response.Message = err.Error()
+		return response, nil
+	}
+
+	if err := l.domainManager.FinishVMIBackup(vmi, options); err != nil {
+		log.Log.Object(vmi).Reason(err).Errorf("Failed to finish the backup of vmi")
+		response.Success = false
+		response.Message = getErrorMessage(err)
+		return response, nil
+	}
+
+	log.Log.Object(vmi).Infof("Finished backup %s of vmi", options.BackupName)
+	return response, nil
+}
+
 func (l *Launcher) KillVirtualMachine(ctx context.Context, request *cmdv1.VMIRequest) (*cmdv1.Response, error) {
 
 	vmi, response := getVMIFromRequest(request.Vmi)
@@ -314,6 +438,154 @@ func (l *Launcher) GetDomainStats(ctx context.Context, request *cmdv1.EmptyReque
 	return response, nil
 }
 
+func (l *Launcher) GetScreenshot(ctx context.Context, request *cmdv1.VMIRequest) (*cmdv1.ScreenshotResponse, error) {
+	vmi, response := getVMIFromRequest(request.Vmi)
+	screenshotResponse := &cmdv1.ScreenshotResponse{
+		Response: response,
+	}
+	if !response.Success {
+		return screenshotResponse, nil
+	}
+
+	png, err := l.domainManager.GetScreenshot(vmi)
+	if err != nil {
+		log.Log.Object(vmi).Reason(err).Errorf("Failed to take a screenshot of vmi")
+		response.Success = false
+		response.Message = getErrorMessage(err)
+		return screenshotResponse, nil
+	}
+
+	screenshotResponse.Png = png
+	return screenshotResponse, nil
+}
+
+func (l *Launcher) GetGuestInfo(ctx context.Context, request *cmdv1.VMIRequest) (*cmdv1.GuestInfoResponse, error) {
+	vmi, response := getVMIFromRequest(request.Vmi)
+	guestInfoResponse := &cmdv1.GuestInfoResponse{
+		Response: response,
+	}
+	if !response.Success {
+		return guestInfoResponse, nil
+	}
+
+	result, err := l.domainManager.GetGuestInfo(vmi)
+	if err != nil {
+		log.Log.Object(vmi).Reason(err).Errorf("Failed to get the guest info of vmi")
+		response.Success = false
+		response.Message = getErrorMessage(err)
+		return guestInfoResponse, nil
+	}
+
+	if err := setJSONResponse(&guestInfoResponse.GuestInfoResponse, result, response); err != nil {
+		log.Log.Object(vmi).Reason(err).Errorf("Failed to marshal the result of GetGuestInfo")
+	}
+	return guestInfoResponse, nil
+}
+
+func (l *Launcher) GetUsers(ctx context.Context, request *cmdv1.VMIRequest) (*cmdv1.GuestUserListResponse, error) {
+	vmi, response := getVMIFromRequest(request.Vmi)
+	userListResponse := &cmdv1.GuestUserListResponse{
+		Response: response,
+	}
+	if !response.Success {
+		return userListResponse, nil
+	}
+
+	result, err := l.domainManager.GetUsers(vmi)
+	if err != nil {
+		log.Log.Object(vmi).Reason(err).Errorf("Failed to get the guest users of vmi")
+		response.Success = false
+		response.Message = getErrorMessage(err)
+		return userListResponse, nil
+	}
+
+	if err := setJSONResponse(&userListResponse.GuestUserListResponse, result, response); err != nil {
+		log.Log.Object(vmi).Reason(err).Errorf("Failed to marshal the result of GetUsers")
+	}
+	return userListResponse, nil
+}
+
+func (l *Launcher) GetFilesystems(ctx context.Context, request *cmdv1.VMIRequest) (*cmdv1.GuestFilesystemListResponse, error) {
+	vmi, response := getVMIFromRequest(request.Vmi)
+	filesystemListResponse := &cmdv1.GuestFilesystemListResponse{
+		Response: response,
+	}
+	if !response.Success {
+		return filesystemListResponse, nil
+	}
+
+	result, err := l.domainManager.GetFilesystems(vmi)
+	if err != nil {
+		log.Log.Object(vmi).Reason(err).Errorf("Failed to get the guest filesystems of vmi")
+		response.Success = false
+		response.Message = getErrorMessage(err)
+		return filesystemListResponse, nil
+	}
+
+	if err := setJSONResponse(&filesystemListResponse.GuestFilesystemListResponse, result, response); err != nil {
+		log.Log.Object(vmi).Reason(err).Errorf("Failed to marshal the result of GetFilesystems")
+	}
+	return filesystemListResponse, nil
+}
+
+func (l *Launcher) GuestExec(ctx context.Context, request *cmdv1.GuestExecRequest) (*cmdv1.GuestExecResponse, error) {
+	vmi, response := getVMIFromRequest(request.Vmi)
+	guestExecResponse := &cmdv1.GuestExecResponse{
+		Response: response,
+	}
+	if !response.Success {
+		return guestExecResponse, nil
+	}
+
+	var execRequest v1.VirtualMachineInstanceGuestExecRequest
+	if err := json.Unmarshal(request.ExecRequest, &execRequest); err != nil {
+		response.Success = false
+		response.Message = "No valid guest exec request present in command server request"
+		return guestExecResponse, nil
+	}
+
+	result, err := l.domainManager.GuestExec(vmi, &execRequest)
+	if err != nil {
+		log.Log.Object(vmi).Reason(err).Errorf("Failed to execute a command in the guest of vmi")
+		response.Success = false
+		response.Message = getErrorMessage(err)
+		return guestExecResponse, nil
+	}
+
+	if err := setJSONResponse(&guestExecResponse.GuestExecResponse, result, response); err != nil {
+		log.Log.Object(vmi).Reason(err).Errorf("Failed to marshal the result of GuestExec")
+	}
+	return guestExecResponse, nil
+}
+
+func (l *Launcher) GuestPing(ctx context.Context, request *cmdv1.VMIRequest) (*cmdv1.Response, error) {
+	vmi, response := getVMIFromRequest(request.Vmi)
+	if !response.Success {
+		return response, nil
+	}
+
+	if err := l.domainManager.GuestPing(vmi); err != nil {
+		log.Log.Object(vmi).Reason(err).V(4).Infof("Failed to ping the guest agent of vmi")
+		response.Success = false
+		response.Message = getErrorMessage(err)
+		return response, nil
+	}
+
+	return response, nil
+}
+
+// setJSONResponse stores the JSON encoded result in field, or marks the response as failed
+func setJSONResponse(field *string, result interface{}, response *cmdv1.Response) error {
+	data, err := json.Marshal(result)
+	if err != nil {
+		response.Success = false
+		response.Message = getErrorMessage(err)
+		return err
+	}
+	*field = string(data)
+	return nil
+}
+
 func RunServer(socketPath string,
 	domainManager virtwrap.DomainManager,
 	stopChan chan struct{},
@@ -20,6 +20,7 @@
 package cmdserver
 
 import (
+	"fmt"
 	"io/ioutil"
 	"os"
 	"path/filepath"
@@ -120,6 +121,34 @@ var _ = Describe("Virt remote commands", func() {
 			Expect(err).ToNot(HaveOccurred())
 		})
 
+		It("should soft reboot a vmi", func() {
+			vmi := v1.NewVMIReferenceFromName("testvmi")
+			domainManager.EXPECT().SoftRebootVMI(vmi)
+			err := client.SoftRebootVirtualMachine(vmi)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("should start a memory dump of a vmi", func() {
+			vmi := v1.NewVMIReferenceFromName("testvmi")
+			domainManager.EXPECT().MemoryDumpVMI(vmi, "testvmi.memory.dump")
+			err := client.MemoryDumpVirtualMachine(vmi, "testvmi.memory.dump")
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("should reject memory dump file names outside of the dump directory", func() {
+			vmi := v1.NewVMIReferenceFromName("testvmi")
+			err := client.MemoryDumpVirtualMachine(vmi, "../testvmi.memory.dump")
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("should resize the disks of a vmi", func() {
+			vmi := v1.NewVMIReferenceFromName("testvmi")
+			domainManager.EXPECT().ResizeVMIDisks(vmi).Return([]string{"disk0"}, nil)
+			resizedVolumes, err := client.ResizeVirtualMachineDisks(vmi)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(resizedVolumes).To(Equal([]string{"disk0"}))
+		})
+
 		It("should list domains", func() {
 			var list []*api.Domain
 			list = append(list, api.NewMinimalDomain("testvmi1"))
@@ -179,6 +208,89 @@ var _ = Describe("Virt remote commands", func() {
 			Expect(domStats.Name).To(Equal(list[0].Name))
 			Expect(domStats.UUID).To(Equal(list[0].UUID))
 		})
+
+		It("should return a screenshot", func() {
+			vmi := v1.NewVMIReferenceFromName("testvmi")
+			domainManager.EXPECT().GetScreenshot(vmi).Return([]byte("\x89PNG"), nil)
+			png, err := client.GetScreenshot(vmi)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(png).To(Equal([]byte("\x89PNG")))
+		})
+
+		It("should fail to return a screenshot if the domain manager fails", func() {
+			vmi := v1.NewVMIReferenceFromName("testvmi")
+			domainManager.EXPECT().GetScreenshot(vmi).Return(nil, fmt.Errorf("no graphics device"))
+			_, err := client.GetScreenshot(vmi)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("no graphics device"))
+		})
+
+		It("should return the guest info", func() {
+			vmi := v1.NewVMIReferenceFromName("testvmi")
+			guestInfo := &v1.VirtualMachineInstanceGuestAgentInfo{
+				GAVersion: "4.2.0",
+				Hostname:  "testvmi",
+			}
+			domainManager.EXPECT().GetGuestInfo(vmi).Return(guestInfo, nil)
+			info, err := client.GetGuestInfo(vmi)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(info).To(Equal(guestInfo))
+		})
+
+		It("should return the guest users", func() {
+			vmi := v1.NewVMIReferenceFromName("testvmi")
+			userList := &v1.VirtualMachineInstanceGuestOSUserList{
+				Items: []v1.VirtualMachineInstanceGuestOSUser{{UserName: "fedora"}},
+			}
+			domainManager.EXPECT().GetUsers(vmi).Return(userList, nil)
+			users, err := client.GetUsers(vmi)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(users).To(Equal(userList))
+		})
+
+		It("should return the guest filesystems", func() {
+			vmi := v1.NewVMIReferenceFromName("testvmi")
+			filesystemList := &v1.VirtualMachineInstanceFileSystemList{
+				Items: []v1.VirtualMachineInstanceFileSystem{{DiskName: "vda1", MountPoint: "/", UsedBytes: 1024, TotalBytes: 4096}},
+			}
+			domainManager.EXPECT().GetFilesystems(vmi).Return(filesystemList, nil)
+			filesystems, err := client.GetFilesystems(vmi)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(filesystems).To(Equal(filesystemList))
+		})
+
+		It("should fail to return the guest filesystems if the guest agent fails", func() {
+			vmi := v1.NewVMIReferenceFromName("testvmi")
+			domainManager.EXPECT().GetFilesystems(vmi).Return(nil, fmt.Errorf("guest agent is not connected"))
+			_, err := client.GetFilesystems(vmi)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("guest agent is not connected"))
+		})
+
+		It("should ping the guest agent", func() {
+			vmi := v1.NewVMIReferenceFromName("testvmi")
+			domainManager.EXPECT().GuestPing(vmi).Return(nil)
+			err := client.GuestPing(vmi, time.Second)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("should fail to ping a guest agent which does not respond", func() {
+			vmi := v1.NewVMIReferenceFromName("testvmi")
+			domainManager.EXPECT().GuestPing(vmi).Return(fmt.Errorf("guest agent is not responding"))
+			err := client.GuestPing(vmi, time.Second)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("guest agent is not responding"))
+		})
+
+		It("should execute a command in the guest", func() {
+			vmi := v1.NewVMIReferenceFromName("testvmi")
+			request := &v1.VirtualMachineInstanceGuestExecRequest{Command: "/usr/bin/hostname"}
+			result := &v1.VirtualMachineInstanceGuestExecResult{Stdout: "testvmi\n"}
+			domainManager.EXPECT().GuestExec(vmi, request).Return(result, nil)
+			execResult, err := client.GuestExec(vmi, request, time.Second)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(execResult).To(Equal(result))
+		})
 	})
 
 	Describe("Version mismatch", func() {
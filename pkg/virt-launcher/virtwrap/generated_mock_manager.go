@@ -65,6 +65,57 @@ func (_mr *_MockDomainManagerRecorder) UnpauseVMI(arg0 interface{}) *gomock.Call
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "UnpauseVMI", arg0)
 }
 
+func (_m *MockDomainManager) SoftRebootVMI(_param0 *v1.VirtualMachineInstance) error {
+	ret := _m.ctrl.Call(_m, "SoftRebootVMI", _param0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockDomainManagerRecorder) SoftRebootVMI(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "SoftRebootVMI", arg0)
+}
+
+func (_m *MockDomainManager) MemoryDumpVMI(_param0 *v1.VirtualMachineInstance, _param1 string) error {
+	ret := _m.ctrl.Call(_m, "MemoryDumpVMI", _param0, _param1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockDomainManagerRecorder) MemoryDumpVMI(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "MemoryDumpVMI", arg0, arg1)
+}
+
+func (_m *MockDomainManager) ResizeVMIDisks(_param0 *v1.VirtualMachineInstance) ([]string, error) {
+	ret := _m.ctrl.Call(_m, "ResizeVMIDisks", _param0)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockDomainManagerRecorder) ResizeVMIDisks(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "ResizeVMIDisks", arg0)
+}
+
+func (_m *MockDomainManager) BackupVMI(_param0 *v1.VirtualMachineInstance, _param1 *cmd_client.BackupOptions) error {
+	ret := _m.ctrl.Call(_m, "BackupVMI", _param0, _param1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockDomainManagerRecorder) BackupVMI(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "BackupVMI", arg0, arg1)
+}
+
+func (_m *MockDomainManager) FinishVMIBackup(_param0 *v1.VirtualMachineInstance, _param1 *cmd_client.BackupOptions) error {
+	ret := _m.ctrl.Call(_m, "FinishVMIBackup", _param0, _param1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockDomainManagerRecorder) FinishVMIBackup(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "FinishVMIBackup", arg0, arg1)
+}
+
 func (_m *MockDomainManager) KillVMI(_param0 *v1.VirtualMachineInstance) error {
 	ret := _m.ctrl.Call(_m, "KillVMI", _param0)
 	ret0, _ := ret[0].(error)
@@ -137,6 +188,71 @@ func (_mr *_MockDomainManagerRecorder) GetDomainStats() *gomock.Call {
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "GetDomainStats")
 }
 
+func (_m *MockDomainManager) GetScreenshot(_param0 *v1.VirtualMachineInstance) ([]byte, error) {
+	ret := _m.ctrl.Call(_m, "GetScreenshot", _param0)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockDomainManagerRecorder) GetScreenshot(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "GetScreenshot", arg0)
+}
+
+func (_m *MockDomainManager) GetGuestInfo(_param0 *v1.VirtualMachineInstance) (*v1.VirtualMachineInstanceGuestAgentInfo, error) {
+	ret := _m.ctrl.Call(_m, "GetGuestInfo", _param0)
+	ret0, _ := ret[0].(*v1.VirtualMachineInstanceGuestAgentInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockDomainManagerRecorder) GetGuestInfo(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "GetGuestInfo", arg0)
+}
+
+func (_m *MockDomainManager) GetUsers(_param0 *v1.VirtualMachineInstance) (*v1.VirtualMachineInstanceGuestOSUserList, error) {
+	ret := _m.ctrl.Call(_m, "GetUsers", _param0)
+	ret0, _ := ret[0].(*v1.VirtualMachineInstanceGuestOSUserList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockDomainManagerRecorder) GetUsers(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "GetUsers", arg0)
+}
+
+func (_m *MockDomainManager) GetFilesystems(_param0 *v1.VirtualMachineInstance) (*v1.VirtualMachineInstanceFileSystemList, error) {
+	ret := _m.ctrl.Call(_m, "GetFilesystems", _param0)
+	ret0, _ := ret[0].(*v1.VirtualMachineInstanceFileSystemList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockDomainManagerRecorder) GetFilesystems(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "GetFilesystems", arg0)
+}
+
+func (_m *MockDomainManager) GuestExec(_param0 *v1.VirtualMachineInstance, _param1 *v1.VirtualMachineInstanceGuestExecRequest) (*v1.VirtualMachineInstanceGuestExecResult, error) {
+	ret := _m.ctrl.Call(_m, "GuestExec", _param0, _param1)
+	ret0, _ := ret[0].(*v1.VirtualMachineInstanceGuestExecResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockDomainManagerRecorder) GuestExec(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "GuestExec", arg0, arg1)
+}
+
+func (_m *MockDomainManager) GuestPing(_param0 *v1.VirtualMachineInstance) error {
+	ret := _m.ctrl.Call(_m, "GuestPing", _param0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockDomainManagerRecorder) GuestPing(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "GuestPing", arg0)
+}
+
 func (_m *MockDomainManager) CancelVMIMigration(_param0 *v1.VirtualMachineInstance) error {
 	ret := _m.ctrl.Call(_m, "CancelVMIMigration", _param0)
 	ret0, _ := ret[0].(error)
@@ -0,0 +1,353 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2019 Red Hat, Inc.
+ *
+ */
+
+package guestagent
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/client-go/log"
+)
+
+// execStatusPollInterval is how often the status of a guest-exec command is queried
+var execStatusPollInterval = 100 * time.Millisecond
+
+// execKillTimeout is how long to wait for a killed guest-exec command to exit
+var execKillTimeout = 1 * time.Second
+
+// AgentCommandExecutor executes commands of the qemu guest agent of a domain
+type AgentCommandExecutor interface {
+	QemuAgentCommand(command string, domainName string) (string, error)
+}
+
+type agentCommand struct {
+	Execute   string      `json:"execute"`
+	Arguments interface{} `json:"arguments,omitempty"`
+}
+
+// Replies of the guest agent, see the qemu-ga QAPI schema
+
+type guestInfo struct {
+	Version           string             `json:"version"`
+	SupportedCommands []guestInfoCommand `json:"supported_commands"`
+}
+
+type guestInfoCommand struct {
+	Name    string `json:"name"`
+	Enabled bool   `json:"enabled"`
+}
+
+// supports returns true if the guest agent offers the command and it is not disabled
+func (i *guestInfo) supports(command string) bool {
+	for _, supported := range i.SupportedCommands {
+		if supported.Name == command {
+			return supported.Enabled
+		}
+	}
+	return false
+}
+
+type guestHostName struct {
+	HostName string `json:"host-name"`
+}
+
+type guestOSInfo struct {
+	Name          string `json:"name"`
+	KernelRelease string `json:"kernel-release"`
+	Version       string `json:"version"`
+	PrettyName    string `json:"pretty-name"`
+	VersionID     string `json:"version-id"`
+	KernelVersion string `json:"kernel-version"`
+	Machine       string `json:"machine"`
+	ID            string `json:"id"`
+}
+
+type guestTimezone struct {
+	Zone   string `json:"zone"`
+	Offset int    `json:"offset"`
+}
+
+type guestUser struct {
+	User      string  `json:"user"`
+	Domain    string  `json:"domain"`
+	LoginTime float64 `json:"login-time"`
+}
+
+type guestFilesystemInfo struct {
+	Name       string `json:"name"`
+	Mountpoint string `json:"mountpoint"`
+	Type       string `json:"type"`
+	UsedBytes  int64  `json:"used-bytes"`
+	TotalBytes int64  `json:"total-bytes"`
+}
+
+type guestExecArguments struct {
+	Path          string   `json:"path"`
+	Arg           []string `json:"arg,omitempty"`
+	InputData     string   `json:"input-data,omitempty"`
+	CaptureOutput bool     `json:"capture-output"`
+}
+
+type guestExec struct {
+	PID int `json:"pid"`
+}
+
+type guestExecStatusArguments struct {
+	PID int `json:"pid"`
+}
+
+type guestExecStatus struct {
+	Exited       bool   `json:"exited"`
+	ExitCode     *int32 `json:"exitcode"`
+	Signal       *int32 `json:"signal"`
+	OutData      string `json:"out-data"`
+	ErrData      string `json:"err-data"`
+	OutTruncated bool   `json:"out-truncated"`
+	ErrTruncated bool   `json:"err-truncated"`
+}
+
+// execute runs a guest agent command and unmarshals the returned value into result
+func execute(agent AgentCommandExecutor, domainName string, command string, arguments interface{}, result interface{}) error {
+	cmd, err := json.Marshal(agentCommand{Execute: command, Arguments: arguments})
+	if err != nil {
+		return err
+	}
+	reply, err := agent.QemuAgentCommand(string(cmd), domainName)
+	if err != nil {
+		return fmt.Errorf("%s failed: %v", command, err)
+	}
+	wrapper := struct {
+		Return interface{} `json:"return"`
+	}{Return: result}
+	if err := json.Unmarshal([]byte(reply), &wrapper); err != nil {
+		return fmt.Errorf("failed to parse the reply of %s: %v", command, err)
+	}
+	return nil
+}
+
+// Ping checks whether the guest agent responds
+func Ping(agent AgentCommandExecutor, domainName string) error {
+	return execute(agent, domainName, "guest-ping", nil, &struct{}{})
+}
+
+// GetGuestInfo returns everything the guest agent reports about the guest.
+// Commands which the guest agent does not support or which are disabled in
+// the guest are skipped and their part of the info is left empty. Failing
+// commands are logged and their part of the info is left empty as well.
+func GetGuestInfo(agent AgentCommandExecutor, domainName string) (*v1.VirtualMachineInstanceGuestAgentInfo, error) {
+	info := &guestInfo{}
+	if err := execute(agent, domainName, "guest-info", nil, info); err != nil {
+		return nil, err
+	}
+	guestAgentInfo := &v1.VirtualMachineInstanceGuestAgentInfo{
+		GAVersion: info.Version,
+	}
+
+	logFailure := func(command string, err error) {
+		log.Log.Reason(err).Warningf("Failed to execute %s on domain %s, leaving its part of the guest info empty", command, domainName)
+	}
+
+	if info.supports("guest-get-host-name") {
+		hostName := &guestHostName{}
+		if err := execute(agent, domainName, "guest-get-host-name", nil, hostName); err != nil {
+			logFailure("guest-get-host-name", err)
+		} else {
+			guestAgentInfo.Hostname = hostName.HostName
+		}
+	}
+	if info.supports("guest-get-osinfo") {
+		osInfo := &guestOSInfo{}
+		if err := execute(agent, domainName, "guest-get-osinfo", nil, osInfo); err != nil {
+			logFailure("guest-get-osinfo", err)
+		} else {
+			guestAgentInfo.OS = v1.VirtualMachineInstanceGuestOSInfo{
+				Name:          osInfo.Name,
+				KernelRelease: osInfo.KernelRelease,
+				Version:       osInfo.Version,
+				PrettyName:    osInfo.PrettyName,
+				VersionID:     osInfo.VersionID,
+				KernelVersion: osInfo.KernelVersion,
+				Machine:       osInfo.Machine,
+				ID:            osInfo.ID,
+			}
+		}
+	}
+	if info.supports("guest-get-timezone") {
+		timezone := &guestTimezone{}
+		if err := execute(agent, domainName, "guest-get-timezone", nil, timezone); err != nil {
+			logFailure("guest-get-timezone", err)
+		} else {
+			guestAgentInfo.Timezone = fmt.Sprintf("%s,%d", timezone.Zone, timezone.Offset)
+		}
+	}
+	if info.supports("guest-get-users") {
+		if users, err := GetUsers(agent, domainName); err != nil {
+			logFailure("guest-get-users", err)
+		} else {
+			guestAgentInfo.UserList = users.Items
+		}
+	}
+	if info.supports("guest-get-fsinfo") {
+		if filesystems, err := GetFilesystems(agent, domainName); err != nil {
+			logFailure("guest-get-fsinfo", err)
+		} else {
+			guestAgentInfo.FSInfo.Filesystems = filesystems.Items
+		}
+	}
+
+	return guestAgentInfo, nil
+}
+
+// GetUsers returns the users which are logged into the guest
+func GetUsers(agent AgentCommandExecutor, domainName string) (*v1.VirtualMachineInstanceGuestOSUserList, error) {
+	users := []guestUser{}
+	if err := execute(agent, domainName, "guest-get-users", nil, &users); err != nil {
+		return nil, err
+	}
+
+	list := &v1.VirtualMachineInstanceGuestOSUserList{
+		Items: []v1.VirtualMachineInstanceGuestOSUser{},
+	}
+	for _, user := range users {
+		list.Items = append(list.Items, v1.VirtualMachineInstanceGuestOSUser{
+			UserName:  user.User,
+			Domain:    user.Domain,
+			LoginTime: user.LoginTime,
+		})
+	}
+	return list, nil
+}
+
+// GetFilesystems returns the filesystems which are mounted in the guest
+func GetFilesystems(agent AgentCommandExecutor, domainName string) (*v1.VirtualMachineInstanceFileSystemList, error) {
+	filesystems := []guestFilesystemInfo{}
+	if err := execute(agent, domainName, "guest-get-fsinfo", nil, &filesystems); err != nil {
+		return nil, err
+	}
+
+	list := &v1.VirtualMachineInstanceFileSystemList{
+		Items: []v1.VirtualMachineInstanceFileSystem{},
+	}
+	for _, fs := range filesystems {
+		list.Items = append(list.Items, v1.VirtualMachineInstanceFileSystem{
+			DiskName:       fs.Name,
+			MountPoint:     fs.Mountpoint,
+			FileSystemType: fs.Type,
+			UsedBytes:      fs.UsedBytes,
+			TotalBytes:     fs.TotalBytes,
+		})
+	}
+	return list, nil
+}
+
+// Exec runs a command in the guest and waits until it exits
+func Exec(agent AgentCommandExecutor, domainName string, request *v1.VirtualMachineInstanceGuestExecRequest) (*v1.VirtualMachineInstanceGuestExecResult, error) {
+	timeout := time.Duration(request.TimeoutSeconds) * time.Second
+	if request.TimeoutSeconds <= 0 {
+		timeout = v1.GuestExecDefaultTimeoutSeconds * time.Second
+	}
+
+	args := guestExecArguments{
+		Path:          request.Command,
+		Arg:           request.Args,
+		CaptureOutput: true,
+	}
+	if request.Input != "" {
+		args.InputData = base64.StdEncoding.EncodeToString([]byte(request.Input))
+	}
+	process := &guestExec{}
+	if err := execute(agent, domainName, "guest-exec", args, process); err != nil {
+		return nil, err
+	}
+
+	deadline := time.Now().Add(timeout)
+	var status *guestExecStatus
+	for {
+		status = &guestExecStatus{}
+		if err := execute(agent, domainName, "guest-exec-status", guestExecStatusArguments{PID: process.PID}, status); err != nil {
+			return nil, err
+		}
+		if status.Exited {
+			break
+		}
+		if time.Now().After(deadline) {
+			killProcess(agent, domainName, process.PID)
+			return nil, fmt.Errorf("command %q did not exit within %v", request.Command, timeout)
+		}
+		time.Sleep(execStatusPollInterval)
+	}
+
+	stdout, err := base64.StdEncoding.DecodeString(status.OutData)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode the output of the command: %v", err)
+	}
+	stderr, err := base64.StdEncoding.DecodeString(status.ErrData)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode the error output of the command: %v", err)
+	}
+
+	result := &v1.VirtualMachineInstanceGuestExecResult{
+		Stdout:          string(stdout),
+		Stderr:          string(stderr),
+		OutputTruncated: status.OutTruncated || status.ErrTruncated,
+	}
+	if status.ExitCode != nil {
+		result.ExitCode = *status.ExitCode
+	} else if status.Signal != nil {
+		result.ExitCode = *status.Signal
+		result.Signaled = true
+	}
+	return result, nil
+}
+
+// killProcess kills a guest-exec command which ran into its timeout, so that
+// repeated calls do not pile up processes in the guest. The guest agent has no
+// command for that, so kill, or taskkill on Windows, is run in the guest.
+func killProcess(agent AgentCommandExecutor, domainName string, pid int) {
+	pidArg := strconv.Itoa(pid)
+	for _, args := range []guestExecArguments{
+		{Path: "kill", Arg: []string{"-KILL", pidArg}},
+		{Path: "taskkill", Arg: []string{"/F", "/T", "/PID", pidArg}},
+	} {
+		killer := &guestExec{}
+		if err := execute(agent, domainName, "guest-exec", args, killer); err != nil {
+			continue
+		}
+		// the guest agent only forgets about processes whose exit was reported
+		waitForExit(agent, domainName, killer.PID)
+		waitForExit(agent, domainName, pid)
+		return
+	}
+}
+
+func waitForExit(agent AgentCommandExecutor, domainName string, pid int) {
+	deadline := time.Now().Add(execKillTimeout)
+	for time.Now().Before(deadline) {
+		status := &guestExecStatus{}
+		if err := execute(agent, domainName, "guest-exec-status", guestExecStatusArguments{PID: pid}, status); err != nil || status.Exited {
+			return
+		}
+		time.Sleep(execStatusPollInterval)
+	}
+}
@@ -0,0 +1,235 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2019 Red Hat, Inc.
+ *
+ */
+
+package guestagent
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	v1 "kubevirt.io/client-go/api/v1"
+)
+
+// fakeAgent replies to guest agent commands with canned replies, by command name
+type fakeAgent struct {
+	replies  map[string][]string
+	commands []string
+}
+
+func (f *fakeAgent) QemuAgentCommand(command string, domainName string) (string, error) {
+	Expect(domainName).To(Equal("default_testvmi"))
+	f.commands = append(f.commands, command)
+
+	cmd := agentCommand{}
+	Expect(json.Unmarshal([]byte(command), &cmd)).To(Succeed())
+	replies := f.replies[cmd.Execute]
+	if len(replies) == 0 {
+		return "", fmt.Errorf("command %s not supported", cmd.Execute)
+	}
+	reply := replies[0]
+	if len(replies) > 1 {
+		f.replies[cmd.Execute] = replies[1:]
+	}
+	return reply, nil
+}
+
+// guestInfoReply returns a guest-info reply which lists the given commands as enabled
+func guestInfoReply(commands ...string) string {
+	supported := []guestInfoCommand{}
+	for _, command := range commands {
+		supported = append(supported, guestInfoCommand{Name: command, Enabled: true})
+	}
+	reply, err := json.Marshal(map[string]guestInfo{"return": {Version: "4.2.0", SupportedCommands: supported}})
+	Expect(err).ToNot(HaveOccurred())
+	return string(reply)
+}
+
+var _ = Describe("GuestAgent", func() {
+	var agent *fakeAgent
+
+	BeforeEach(func() {
+		agent = &fakeAgent{
+			replies: map[string][]string{
+				"guest-info":          {guestInfoReply("guest-get-host-name", "guest-get-osinfo", "guest-get-timezone", "guest-get-users", "guest-get-fsinfo")},
+				"guest-get-host-name": {`{"return": {"host-name": "testvmi.example.com"}}`},
+				"guest-get-osinfo":    {`{"return": {"name": "Fedora", "kernel-release": "5.3.7", "version": "31 (Cloud Edition)", "pretty-name": "Fedora 31 (Cloud Edition)", "version-id": "31", "kernel-version": "#1 SMP", "machine": "x86_64", "id": "fedora"}}`},
+				"guest-get-timezone":  {`{"return": {"zone": "UTC", "offset": 0}}`},
+				"guest-get-users":     {`{"return": [{"user": "fedora", "login-time": 1571666461.5}]}`},
+				"guest-get-fsinfo":    {`{"return": [{"name": "vda1", "mountpoint": "/", "type": "ext4", "used-bytes": 1024, "total-bytes": 4096, "disk": []}]}`},
+			},
+		}
+	})
+
+	It("should ping the guest agent", func() {
+		agent.replies["guest-ping"] = []string{`{"return": {}}`}
+		Expect(Ping(agent, "default_testvmi")).To(Succeed())
+		Expect(agent.commands).To(Equal([]string{`{"execute":"guest-ping"}`}))
+	})
+
+	It("should fail to ping a guest agent which does not respond", func() {
+		Expect(Ping(agent, "default_testvmi")).ToNot(Succeed())
+	})
+
+	It("should return the guest info", func() {
+		info, err := GetGuestInfo(agent, "default_testvmi")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(info.GAVersion).To(Equal("4.2.0"))
+		Expect(info.Hostname).To(Equal("testvmi.example.com"))
+		Expect(info.OS.PrettyName).To(Equal("Fedora 31 (Cloud Edition)"))
+		Expect(info.OS.VersionID).To(Equal("31"))
+		Expect(info.Timezone).To(Equal("UTC,0"))
+		Expect(info.UserList).To(HaveLen(1))
+		Expect(info.FSInfo.Filesystems).To(HaveLen(1))
+	})
+
+	It("should return the logged in users", func() {
+		users, err := GetUsers(agent, "default_testvmi")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(users.Items).To(Equal([]v1.VirtualMachineInstanceGuestOSUser{
+			{UserName: "fedora", LoginTime: 1571666461.5},
+		}))
+	})
+
+	It("should return the mounted filesystems", func() {
+		filesystems, err := GetFilesystems(agent, "default_testvmi")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(filesystems.Items).To(Equal([]v1.VirtualMachineInstanceFileSystem{
+			{DiskName: "vda1", MountPoint: "/", FileSystemType: "ext4", UsedBytes: 1024, TotalBytes: 4096},
+		}))
+	})
+
+	It("should return partial guest info if the agent does not support a command", func() {
+		agent.replies["guest-info"] = []string{guestInfoReply("guest-get-host-name", "guest-get-osinfo", "guest-get-timezone")}
+		delete(agent.replies, "guest-get-users")
+		delete(agent.replies, "guest-get-fsinfo")
+		info, err := GetGuestInfo(agent, "default_testvmi")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(info.Hostname).To(Equal("testvmi.example.com"))
+		Expect(info.OS.PrettyName).To(Equal("Fedora 31 (Cloud Edition)"))
+		Expect(info.UserList).To(BeEmpty())
+		Expect(info.FSInfo.Filesystems).To(BeEmpty())
+	})
+
+	It("should skip commands which are disabled in the guest", func() {
+		agent.replies["guest-info"] = []string{`{"return": {"version": "4.2.0", "supported_commands": [{"name": "guest-get-users", "enabled": false}]}}`}
+		info, err := GetGuestInfo(agent, "default_testvmi")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(info.GAVersion).To(Equal("4.2.0"))
+		Expect(info.UserList).To(BeEmpty())
+		Expect(agent.commands).To(Equal([]string{`{"execute":"guest-info"}`}))
+	})
+
+	It("should keep the collected guest info if a supported command fails", func() {
+		delete(agent.replies, "guest-get-users")
+		info, err := GetGuestInfo(agent, "default_testvmi")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(info.Hostname).To(Equal("testvmi.example.com"))
+		Expect(info.OS.PrettyName).To(Equal("Fedora 31 (Cloud Edition)"))
+		Expect(info.Timezone).To(Equal("UTC,0"))
+		Expect(info.UserList).To(BeEmpty())
+		Expect(info.FSInfo.Filesystems).To(HaveLen(1))
+	})
+
+	It("should fail if the guest info can't be queried", func() {
+		delete(agent.replies, "guest-info")
+		_, err := GetGuestInfo(agent, "default_testvmi")
+		Expect(err).To(HaveOccurred())
+	})
+
+	Context("executing commands", func() {
+		BeforeEach(func() {
+			execStatusPollInterval = time.Millisecond
+			execKillTimeout = 10 * time.Millisecond
+			agent.replies["guest-exec"] = []string{`{"return": {"pid": 42}}`}
+		})
+
+		It("should wait for the command to exit and return its output", func() {
+			agent.replies["guest-exec-status"] = []string{
+				`{"return": {"exited": false}}`,
+				`{"return": {"exited": true, "exitcode": 1, "out-data": "aGVsbG8K", "err-data": "ZXJyb3IK", "err-truncated": true}}`,
+			}
+
+			result, err := Exec(agent, "default_testvmi", &v1.VirtualMachineInstanceGuestExecRequest{
+				Command: "/usr/bin/cat",
+				Args:    []string{"-"},
+				Input:   "hello\n",
+			})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result.ExitCode).To(Equal(int32(1)))
+			Expect(result.Signaled).To(BeFalse())
+			Expect(result.Stdout).To(Equal("hello\n"))
+			Expect(result.Stderr).To(Equal("error\n"))
+			Expect(result.OutputTruncated).To(BeTrue())
+
+			Expect(agent.commands[0]).To(MatchJSON(`{"execute": "guest-exec", "arguments": {"path": "/usr/bin/cat", "arg": ["-"], "input-data": "aGVsbG8K", "capture-output": true}}`))
+			Expect(agent.commands[1]).To(MatchJSON(`{"execute": "guest-exec-status", "arguments": {"pid": 42}}`))
+			Expect(agent.commands).To(HaveLen(3))
+		})
+
+		It("should report the signal which terminated the command", func() {
+			agent.replies["guest-exec-status"] = []string{`{"return": {"exited": true, "signal": 9}}`}
+
+			result, err := Exec(agent, "default_testvmi", &v1.VirtualMachineInstanceGuestExecRequest{Command: "/usr/bin/sleep"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result.ExitCode).To(Equal(int32(9)))
+			Expect(result.Signaled).To(BeTrue())
+		})
+
+		It("should fail if the command does not exit in time", func() {
+			agent.replies["guest-exec-status"] = []string{`{"return": {"exited": false}}`}
+
+			_, err := Exec(agent, "default_testvmi", &v1.VirtualMachineInstanceGuestExecRequest{
+				Command:        "/usr/bin/sleep",
+				Args:           []string{"infinity"},
+				TimeoutSeconds: 1,
+			})
+			Expect(err).To(MatchError(ContainSubstring("did not exit within 1s")))
+		})
+
+		It("should kill the command if it does not exit in time", func() {
+			agent.replies["guest-exec"] = []string{`{"return": {"pid": 42}}`, `{"return": {"pid": 43}}`}
+			agent.replies["guest-exec-status"] = []string{`{"return": {"exited": false}}`}
+
+			_, err := Exec(agent, "default_testvmi", &v1.VirtualMachineInstanceGuestExecRequest{
+				Command:        "/usr/bin/sleep",
+				Args:           []string{"infinity"},
+				TimeoutSeconds: 1,
+			})
+			Expect(err).To(HaveOccurred())
+			Expect(agent.commands).To(ContainElement(MatchJSON(`{"execute": "guest-exec", "arguments": {"path": "kill", "arg": ["-KILL", "42"], "capture-output": false}}`)))
+		})
+
+		It("should fall back to taskkill if kill is not available", func() {
+			agent.replies["guest-exec"] = []string{`{"return": {"pid": 42}}`, `kill: not found`, `{"return": {"pid": 43}}`}
+			agent.replies["guest-exec-status"] = []string{`{"return": {"exited": false}}`}
+
+			_, err := Exec(agent, "default_testvmi", &v1.VirtualMachineInstanceGuestExecRequest{
+				Command:        "ping",
+				Args:           []string{"-t", "localhost"},
+				TimeoutSeconds: 1,
+			})
+			Expect(err).To(HaveOccurred())
+			Expect(agent.commands).To(ContainElement(MatchJSON(`{"execute": "guest-exec", "arguments": {"path": "taskkill", "arg": ["/F", "/T", "/PID", "42"], "capture-output": false}}`)))
+		})
+	})
+})
@@ -29,11 +29,13 @@ import (
 	"encoding/json"
 	"encoding/xml"
 	"fmt"
+	"io/ioutil"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	cmdclient "kubevirt.io/kubevirt/pkg/virt-handler/cmd-client"
@@ -57,13 +59,19 @@ import (
 	"kubevirt.io/kubevirt/pkg/hooks"
 	hostdisk "kubevirt.io/kubevirt/pkg/host-disk"
 	"kubevirt.io/kubevirt/pkg/ignition"
+	memorydump "kubevirt.io/kubevirt/pkg/memory-dump"
 	migrationproxy "kubevirt.io/kubevirt/pkg/virt-handler/migration-proxy"
+	accesscredentials "kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/access-credentials"
 	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/api"
+	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/backup"
 	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/cli"
 	domainerrors "kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/errors"
+	guestagent "kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/guest-agent"
 	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/network"
+	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/screenshot"
 	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/stats"
 	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/util"
+	vmbackup "kubevirt.io/kubevirt/pkg/vm-backup"
 )
 
 const LibvirtLocalConnectionPort = 22222
@@ -74,6 +82,11 @@ type DomainManager interface {
 	SyncVMI(*v1.VirtualMachineInstance, bool, *cmdv1.VirtualMachineOptions) (*api.DomainSpec, error)
 	PauseVMI(*v1.VirtualMachineInstance) error
 	UnpauseVMI(*v1.VirtualMachineInstance) error
+	SoftRebootVMI(*v1.VirtualMachineInstance) error
+	MemoryDumpVMI(*v1.VirtualMachineInstance, string) error
+	ResizeVMIDisks(*v1.VirtualMachineInstance) ([]string, error)
+	BackupVMI(*v1.VirtualMachineInstance, *cmdclient.BackupOptions) error
+	FinishVMIBackup(*v1.VirtualMachineInstance, *cmdclient.BackupOptions) error
 	KillVMI(*v1.VirtualMachineInstance) error
 	DeleteVMI(*v1.VirtualMachineInstance) error
 	SignalShutdownVMI(*v1.VirtualMachineInstance) error
@@ -81,6 +94,12 @@ type DomainManager interface {
 	MigrateVMI(*v1.VirtualMachineInstance, *cmdclient.MigrationOptions) error
 	PrepareMigrationTarget(*v1.VirtualMachineInstance, bool) error
 	GetDomainStats() ([]*stats.DomainStats, error)
+	GetScreenshot(*v1.VirtualMachineInstance) ([]byte, error)
+	GetGuestInfo(*v1.VirtualMachineInstance) (*v1.VirtualMachineInstanceGuestAgentInfo, error)
+	GetUsers(*v1.VirtualMachineInstance) (*v1.VirtualMachineInstanceGuestOSUserList, error)
+	GetFilesystems(*v1.VirtualMachineInstance) (*v1.VirtualMachineInstanceFileSystemList, error)
+	GuestExec(*v1.VirtualMachineInstance, *v1.VirtualMachineInstanceGuestExecRequest) (*v1.VirtualMachineInstanceGuestExecResult, error)
+	GuestPing(*v1.VirtualMachineInstance) error
 	CancelVMIMigration(*v1.VirtualMachineInstance) error
 }
 
@@ -94,6 +113,9 @@ type LibvirtDomainManager struct {
 	notifier               *eventsclient.Notifier
 	lessPVCSpaceToleration int
 	paused                 pausedVMIs
+	credManager            *accesscredentials.AccessCredentialManager
+	memoryDumpDir          string
+	memoryDumpInProgress   int32
 }
 
 type migrationDisks struct {
@@ -133,6 +155,8 @@ func NewLibvirtDomainManager(connection cli.Connection, virtShareDir string, not
 		paused: pausedVMIs{
 			paused: make(map[types.UID]bool, 0),
 		},
+		credManager:   accesscredentials.NewManager(connection),
+		memoryDumpDir: memorydump.LauncherDir,
 	}
 
 	return &manager, nil
@@ -307,14 +331,24 @@ func classifyVolumesForMigration(vmi *v1.VirtualMachineInstance) *migrationDisks
 	// live migration. It also collects all generated disks suck as cloudinit, secrets, ServiceAccount and ConfigMaps
 	// to make sure that these are being copied during migration.
 	// Persistent volume claims without ReadWriteMany access mode
-	// should be filtered out earlier in the process
+	// should be filtered out earlier in the process, unless they are
+	// migrated to other PVCs. These are copied like local disks.
 
 	disks := &migrationDisks{
 		shared:    make(map[string]bool),
 		generated: make(map[string]bool),
 	}
+	migratedVolumes := make(map[string]bool)
+	if vmi.Status.MigrationState != nil {
+		for _, migratedVolume := range vmi.Status.MigrationState.MigratedVolumes {
+			migratedVolumes[migratedVolume.VolumeName] = true
+		}
+	}
 	for _, volume := range vmi.Spec.Volumes {
 		volSrc := volume.VolumeSource
+		if migratedVolumes[volume.Name] {
+			continue
+		}
 		if volSrc.PersistentVolumeClaim != nil || volSrc.DataVolume != nil ||
 			(volSrc.HostDisk != nil && *volSrc.HostDisk.Shared) {
 			disks.shared[volume.Name] = true
@@ -377,7 +411,7 @@ func (l *LibvirtDomainManager) asyncMigrate(vmi *v1.VirtualMachineInstance, opti
 		// This also creates a tcp server for each additional direct migration connections
 		// that will be proxied to the destination pod
 
-		isBlockMigration := (vmi.Status.MigrationMethod == v1.BlockMigration)
+		isBlockMigration := vmi.IsBlockMigration()
 		migrationPortsRange := migrationproxy.GetMigrationPortsList(isBlockMigration)
 
 		// Create a tcp server for each direct connection proxy
@@ -446,6 +480,8 @@ func (l *LibvirtDomainManager) asyncMigrate(vmi *v1.VirtualMachineInstance, opti
 			return
 		}
 		log.Log.Object(vmi).Infof("Live migration succeeded.")
+		// The guest runs on the target now, which propagates the credentials
+		l.credManager.Stop()
 	}(l, vmi)
 }
 
@@ -479,7 +515,7 @@ func getVMIMigrationDataSize(vmi *v1.VirtualMachineInstance) int64 {
 	}
 
 	//get total data Size
-	if vmi.Status.MigrationMethod == v1.BlockMigration {
+	if vmi.IsBlockMigration() {
 		disksSize := getVMIEphemeralDisksTotalSize()
 		memory.Add(*disksSize)
 	}
@@ -728,7 +764,7 @@ func (l *LibvirtDomainManager) PrepareMigrationTarget(vmi *v1.VirtualMachineInst
 		return fmt.Errorf("failed to update the hosts file: %v", err)
 	}
 
-	isBlockMigration := (vmi.Status.MigrationMethod == v1.BlockMigration)
+	isBlockMigration := vmi.IsBlockMigration()
 	migrationPortsRange := migrationproxy.GetMigrationPortsList(isBlockMigration)
 	for _, port := range migrationPortsRange {
 		// Prepare the direct migration proxy
@@ -1064,6 +1100,10 @@ func (l *LibvirtDomainManager) SyncVMI(vmi *v1.VirtualMachineInstance, useEmulat
 		// Nothing to do
 	}
 
+	// Start propagating access credentials through the guest agent once the
+	// domain runs, the agent connects whenever the guest is ready
+	l.credManager.HandleQemuAgentAccessCredentials(vmi)
+
 	xmlstr, err := dom.GetXMLDesc(0)
 	if err != nil {
 		return nil, err
@@ -1196,6 +1236,146 @@ func (l *LibvirtDomainManager) UnpauseVMI(vmi *v1.VirtualMachineInstance) error
 	return nil
 }
 
+func (l *LibvirtDomainManager) SoftRebootVMI(vmi *v1.VirtualMachineInstance) error {
+	l.domainModifyLock.Lock()
+	defer l.domainModifyLock.Unlock()
+
+	logger := log.Log.Object(vmi)
+
+	domName := util.VMINamespaceKeyFunc(vmi)
+	dom, err := l.virConn.LookupDomainByName(domName)
+	if err != nil {
+		// If the VirtualMachineInstance does not exist, we are done
+		if domainerrors.IsNotFound(err) {
+			return fmt.Errorf("Domain not found.")
+		} else {
+			logger.Reason(err).Error("Getting the domain failed during soft reboot.")
+			return err
+		}
+	}
+	defer dom.Free()
+
+	domState, _, err := dom.GetState()
+	if err != nil {
+		logger.Reason(err).Error("Getting the domain state failed.")
+		return err
+	}
+	if domState != libvirt.DOMAIN_RUNNING {
+		return fmt.Errorf("domain is not running")
+	}
+
+	// Prefer the guest agent, it lets the guest reboot cleanly even if it ignores ACPI events
+	flags := libvirt.DOMAIN_REBOOT_ACPI_POWER_BTN
+	if isGuestAgentConnected(vmi) {
+		flags = libvirt.DOMAIN_REBOOT_GUEST_AGENT
+	}
+	if err := dom.Reboot(flags); err != nil {
+		logger.Reason(err).Error("Signalling soft reboot failed.")
+		return err
+	}
+	logger.Infof("Signaled soft reboot for %s", vmi.GetObjectMeta().GetName())
+
+	domainSpec, err := l.getDomainSpec(dom)
+	if err != nil {
+		return err
+	}
+	now := metav1.Now()
+	softReboot := domainSpec.Metadata.KubeVirt.SoftReboot
+	if softReboot == nil {
+		softReboot = &api.SoftRebootMetadata{}
+		domainSpec.Metadata.KubeVirt.SoftReboot = softReboot
+	}
+	softReboot.LastRebootTimestamp = &now
+	softReboot.Count++
+	_, err = l.setDomainSpecWithHooks(vmi, domainSpec)
+	return err
+}
+
+// MemoryDumpVMI starts to dump the memory of the domain into fileName. The dump
+// runs in the background, it shows up under fileName once it is finished. If it
+// fails, the reason is written next to it instead.
+func (l *LibvirtDomainManager) MemoryDumpVMI(vmi *v1.VirtualMachineInstance, fileName string) error {
+	logger := log.Log.Object(vmi)
+
+	if !atomic.CompareAndSwapInt32(&l.memoryDumpInProgress, 0, 1) {
+		return fmt.Errorf("a memory dump is already in progress")
+	}
+
+	domName := util.VMINamespaceKeyFunc(vmi)
+	dom, err := l.virConn.LookupDomainByName(domName)
+	if err != nil {
+		atomic.StoreInt32(&l.memoryDumpInProgress, 0)
+		// If the VirtualMachineInstance does not exist, we are done
+		if domainerrors.IsNotFound(err) {
+			return fmt.Errorf("Domain not found.")
+		} else {
+			logger.Reason(err).Error("Getting the domain failed during memory dump.")
+			return err
+		}
+	}
+
+	domState, _, err := dom.GetState()
+	if err != nil {
+		dom.Free()
+		atomic.StoreInt32(&l.memoryDumpInProgress, 0)
+		logger.Reason(err).Error("Getting the domain state failed.")
+		return err
+	}
+	if domState != libvirt.DOMAIN_RUNNING && domState != libvirt.DOMAIN_PAUSED {
+		dom.Free()
+		atomic.StoreInt32(&l.memoryDumpInProgress, 0)
+		return fmt.Errorf("domain is not running")
+	}
+
+	// The raw dump is as large as the guest memory, it must not fill the node
+	if err := memorydump.CheckFreeSpace(l.memoryDumpDir, api.GetVirtualMemory(vmi).Value()); err != nil {
+		dom.Free()
+		atomic.StoreInt32(&l.memoryDumpInProgress, 0)
+		logger.Reason(err).Error("Refusing to dump the memory.")
+		return err
+	}
+
+	go func() {
+		defer atomic.StoreInt32(&l.memoryDumpInProgress, 0)
+		defer dom.Free()
+		l.dumpMemory(vmi, dom, fileName)
+	}()
+	logger.Infof("Started memory dump for %s", vmi.GetObjectMeta().GetName())
+	return nil
+}
+
+func (l *LibvirtDomainManager) dumpMemory(vmi *v1.VirtualMachineInstance, dom cli.VirDomain, fileName string) {
+	logger := log.Log.Object(vmi)
+
+	partialFile := memorydump.PartialFilePath(l.memoryDumpDir, fileName)
+	err := dom.CoreDumpWithFormat(partialFile, libvirt.DOMAIN_CORE_DUMP_FORMAT_RAW, libvirt.DUMP_MEMORY_ONLY)
+	if err == nil {
+		// The memory dump pod does not run as root
+		err = os.Chmod(partialFile, memorydump.FileMode)
+	}
+	if err == nil {
+		err = os.Rename(partialFile, memorydump.FilePath(l.memoryDumpDir, fileName))
+	}
+	if err != nil {
+		logger.Reason(err).Error("Dumping the memory failed.")
+		os.Remove(partialFile)
+		if err := ioutil.WriteFile(memorydump.FailedFilePath(l.memoryDumpDir, fileName), []byte(err.Error()), 0644); err != nil {
+			logger.Reason(err).Error("Failed to record the memory dump failure.")
+		}
+		return
+	}
+	logger.Infof("Dumped the memory of %s", vmi.GetObjectMeta().GetName())
+}
+
+func isGuestAgentConnected(vmi *v1.VirtualMachineInstance) bool {
+	for _, cond := range vmi.Status.Conditions {
+		if cond.Type == v1.VirtualMachineInstanceAgentConnected && cond.Status == k8sv1.ConditionTrue {
+			return true
+		}
+	}
+	return false
+}
+
 func (l *LibvirtDomainManager) SignalShutdownVMI(vmi *v1.VirtualMachineInstance) error {
 	l.domainModifyLock.Lock()
 	defer l.domainModifyLock.Unlock()
@@ -1248,6 +1428,8 @@ func (l *LibvirtDomainManager) SignalShutdownVMI(vmi *v1.VirtualMachineInstance)
 }
 
 func (l *LibvirtDomainManager) KillVMI(vmi *v1.VirtualMachineInstance) error {
+	l.credManager.Stop()
+
 	domName := api.VMINamespaceKeyFunc(vmi)
 	dom, err := l.virConn.LookupDomainByName(domName)
 	if err != nil {
@@ -1288,6 +1470,8 @@ func (l *LibvirtDomainManager) KillVMI(vmi *v1.VirtualMachineInstance) error {
 }
 
 func (l *LibvirtDomainManager) DeleteVMI(vmi *v1.VirtualMachineInstance) error {
+	l.credManager.Stop()
+
 	domName := api.VMINamespaceKeyFunc(vmi)
 	dom, err := l.virConn.LookupDomainByName(domName)
 	if err != nil {
@@ -1366,6 +1550,330 @@ func (l *LibvirtDomainManager) GetDomainStats() ([]*stats.DomainStats, error) {
 	return l.virConn.GetDomainStats(statsTypes, flags)
 }
 
+func (l *LibvirtDomainManager) GetScreenshot(vmi *v1.VirtualMachineInstance) ([]byte, error) {
+	logger := log.Log.Object(vmi)
+
+	domName := util.VMINamespaceKeyFunc(vmi)
+	dom, err := l.virConn.LookupDomainByName(domName)
+	if err != nil {
+		if domainerrors.IsNotFound(err) {
+			return nil, fmt.Errorf("Domain not found.")
+		}
+		logger.Reason(err).Error("Getting the domain failed during screenshot.")
+		return nil, err
+	}
+	defer dom.Free()
+
+	stream, err := l.virConn.NewStream(0)
+	if err != nil {
+		logger.Reason(err).Error("Creating the screenshot stream failed.")
+		return nil, err
+	}
+	defer stream.Close()
+
+	mimeType, err := dom.Screenshot(stream.UnderlyingStream(), 0, 0)
+	if err != nil {
+		logger.Reason(err).Error("Taking the screenshot failed.")
+		return nil, err
+	}
+
+	png, err := screenshot.ToPNG(mimeType, stream)
+	if err != nil {
+		logger.Reason(err).Error("Converting the screenshot failed.")
+		return nil, err
+	}
+	return png, nil
+}
+
+func (l *LibvirtDomainManager) GetGuestInfo(vmi *v1.VirtualMachineInstance) (*v1.VirtualMachineInstanceGuestAgentInfo, error) {
+	return guestagent.GetGuestInfo(l.virConn, util.VMINamespaceKeyFunc(vmi))
+}
+
+func (l *LibvirtDomainManager) GetUsers(vmi *v1.VirtualMachineInstance) (*v1.VirtualMachineInstanceGuestOSUserList, error) {
+	return guestagent.GetUsers(l.virConn, util.VMINamespaceKeyFunc(vmi))
+}
+
+func (l *LibvirtDomainManager) GetFilesystems(vmi *v1.VirtualMachineInstance) (*v1.VirtualMachineInstanceFileSystemList, error) {
+	return guestagent.GetFilesystems(l.virConn, util.VMINamespaceKeyFunc(vmi))
+}
+
+func (l *LibvirtDomainManager) GuestExec(vmi *v1.VirtualMachineInstance, request *v1.VirtualMachineInstanceGuestExecRequest) (*v1.VirtualMachineInstanceGuestExecResult, error) {
+	return guestagent.Exec(l.virConn, util.VMINamespaceKeyFunc(vmi), request)
+}
+
+func (l *LibvirtDomainManager) GuestPing(vmi *v1.VirtualMachineInstance) error {
+	return guestagent.Ping(l.virConn, util.VMINamespaceKeyFunc(vmi))
+}
+
+// domainDiskResizer grows the disk images of a running domain through qemu
+type domainDiskResizer struct {
+	dom cli.VirDomain
+}
+
+func (r *domainDiskResizer) GetSize(diskPath string) (int64, error) {
+	info, err := r.dom.GetBlockInfo(diskPath, 0)
+	if err != nil {
+		return 0, err
+	}
+	return int64(info.Capacity), nil
+}
+
+func (r *domainDiskResizer) Resize(diskPath string, size int64) error {
+	return r.dom.BlockResize(diskPath, uint64(size), libvirt.DOMAIN_BLOCK_RESIZE_BYTES)
+}
+
+func (l *LibvirtDomainManager) ResizeVMIDisks(vmi *v1.VirtualMachineInstance) ([]string, error) {
+	l.domainModifyLock.Lock()
+	defer l.domainModifyLock.Unlock()
+
+	logger := log.Log.Object(vmi)
+
+	domName := util.VMINamespaceKeyFunc(vmi)
+	dom, err := l.virConn.LookupDomainByName(domName)
+	if err != nil {
+		if domainerrors.IsNotFound(err) {
+			return nil, fmt.Errorf("Domain not found.")
+		}
+		logger.Reason(err).Error("Getting the domain failed during disk resize.")
+		return nil, err
+	}
+	defer dom.Free()
+
+	domState, _, err := dom.GetState()
+	if err != nil {
+		logger.Reason(err).Error("Getting the domain state failed.")
+		return nil, err
+	}
+	if domState != libvirt.DOMAIN_RUNNING && domState != libvirt.DOMAIN_PAUSED {
+		return nil, fmt.Errorf("domain is not running")
+	}
+
+	// The images are in use by qemu, so let qemu grow them and notify the guest
+	hostDiskCreator := hostdisk.NewHostDiskCreator(l.notifier, l.lessPVCSpaceToleration)
+	expanded, err := hostDiskCreator.Expand(vmi, &domainDiskResizer{dom: dom})
+	for _, volumeName := range expanded {
+		logger.Infof("Expanded the disk of volume %s", volumeName)
+	}
+	if err != nil {
+		logger.Reason(err).Error("Expanding the disks failed.")
+		return expanded, err
+	}
+	return expanded, nil
+}
+
+// domainMonitor runs QMP commands in the qemu process of a domain
+type domainMonitor struct {
+	dom cli.VirDomain
+}
+
+type qmpError struct {
+	Class string `json:"class"`
+	Desc  string `json:"desc"`
+}
+
+func (m *domainMonitor) Execute(command string, arguments interface{}, result interface{}) error {
+	request := map[string]interface{}{"execute": command}
+	if arguments != nil {
+		request["arguments"] = arguments
+	}
+	data, err := json.Marshal(request)
+	if err != nil {
+		return err
+	}
+	output, err := m.dom.QemuMonitorCommand(string(data), libvirt.DOMAIN_QEMU_MONITOR_COMMAND_DEFAULT)
+	if err != nil {
+		return err
+	}
+
+	response := struct {
+		Return json.RawMessage `json:"return"`
+		Error  *qmpError       `json:"error"`
+	}{}
+	if err := json.Unmarshal([]byte(output), &response); err != nil {
+		return fmt.Errorf("failed to parse the response of %s: %v", command, err)
+	}
+	if response.Error != nil {
+		return fmt.Errorf("%s: %s", response.Error.Class, response.Error.Desc)
+	}
+	if result != nil {
+		return json.Unmarshal(response.Return, result)
+	}
+	return nil
+}
+
+// getBackupDisks returns the disks of the domain which hold the persistent volumes of the VirtualMachineInstance
+func getBackupDisks(vmi *v1.VirtualMachineInstance, dom cli.VirDomain, domainSpec *api.DomainSpec) ([]backup.Disk, error) {
+	backupVolumes := map[string]bool{}
+	for i := range vmi.Spec.Volumes {
+		if vmbackup.IsBackupVolume(&vmi.Spec.Volumes[i]) {
+			backupVolumes[vmi.Spec.Volumes[i].Name] = true
+		}
+	}
+
+	var disks []backup.Disk
+	for _, disk := range domainSpec.Devices.Disks {
+		if disk.Alias == nil || !backupVolumes[disk.Alias.Name] {
+			continue
+		}
+		info, err := dom.GetBlockInfo(disk.Target.Device, 0)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get the size of volume %s: %v", disk.Alias.Name, err)
+		}
+		disks = append(disks, backup.Disk{
+			VolumeName:        disk.Alias.Name,
+			Size:              int64(info.Capacity),
+			PersistentBitmaps: disk.Driver != nil && disk.Driver.Type == "qcow2",
+		})
+	}
+	return disks, nil
+}
+
+// BackupVMI creates the checkpoint of a backup and exports the disks of the
+// domain at that point in time over NBD. The state of the backup is kept in the
+// domain metadata, so calling it again for a started backup does nothing.
+func (l *LibvirtDomainManager) BackupVMI(vmi *v1.VirtualMachineInstance, options *cmdclient.BackupOptions) error {
+	l.domainModifyLock.Lock()
+	defer l.domainModifyLock.Unlock()
+
+	logger := log.Log.Object(vmi)
+
+	domName := util.VMINamespaceKeyFunc(vmi)
+	dom, err := l.virConn.LookupDomainByName(domName)
+	if err != nil {
+		if domainerrors.IsNotFound(err) {
+			return fmt.Errorf("Domain not found.")
+		}
+		logger.Reason(err).Error("Getting the domain failed during backup.")
+		return err
+	}
+	defer dom.Free()
+
+	domState, _, err := dom.GetState()
+	if err != nil {
+		logger.Reason(err).Error("Getting the domain state failed.")
+		return err
+	}
+	if domState != libvirt.DOMAIN_RUNNING && domState != libvirt.DOMAIN_PAUSED {
+		return fmt.Errorf("domain is not running")
+	}
+
+	domainSpec, err := l.getDomainSpec(dom)
+	if err != nil {
+		return err
+	}
+	if metadata := domainSpec.Metadata.KubeVirt.Backup; metadata != nil {
+		if metadata.Checkpoint == options.Checkpoint {
+			return nil
+		}
+		if !metadata.Completed && !metadata.Failed {
+			return fmt.Errorf("backup %s is still in progress", metadata.Name)
+		}
+	}
+
+	disks, err := getBackupDisks(vmi, dom, domainSpec)
+	if err != nil {
+		return err
+	}
+	job := &backup.Job{
+		Name:           options.BackupName,
+		Checkpoint:     options.Checkpoint,
+		BaseCheckpoint: options.BaseCheckpoint,
+		Dir:            vmbackup.LauncherDir,
+		Disks:          disks,
+	}
+
+	now := metav1.Now()
+	metadata := &api.BackupMetadata{
+		Name:           options.BackupName,
+		Checkpoint:     options.Checkpoint,
+		BaseCheckpoint: options.BaseCheckpoint,
+		StartTimestamp: &now,
+	}
+	for _, disk := range disks {
+		metadata.Volumes = append(metadata.Volumes, disk.VolumeName)
+	}
+
+	backupErr := backup.NewManager(&domainMonitor{dom: dom}).Start(job)
+	if backupErr != nil {
+		logger.Reason(backupErr).Errorf("Starting backup %s failed.", options.BackupName)
+		metadata.EndTimestamp = &now
+		metadata.Failed = true
+		metadata.Message = backupErr.Error()
+	} else {
+		logger.Infof("Started backup %s of volumes %v", options.BackupName, metadata.Volumes)
+	}
+
+	domainSpec.Metadata.KubeVirt.Backup = metadata
+	if _, err := l.setDomainSpecWithHooks(vmi, domainSpec); err != nil {
+		return err
+	}
+	return backupErr
+}
+
+// FinishVMIBackup stops the NBD exports of a backup. If the backup is aborted,
+// its checkpoint is dropped and it is recorded as failed.
+func (l *LibvirtDomainManager) FinishVMIBackup(vmi *v1.VirtualMachineInstance, options *cmdclient.BackupOptions) error {
+	l.domainModifyLock.Lock()
+	defer l.domainModifyLock.Unlock()
+
+	logger := log.Log.Object(vmi)
+
+	domName := util.VMINamespaceKeyFunc(vmi)
+	dom, err := l.virConn.LookupDomainByName(domName)
+	if err != nil {
+		if domainerrors.IsNotFound(err) {
+			return fmt.Errorf("Domain not found.")
+		}
+		logger.Reason(err).Error("Getting the domain failed during backup.")
+		return err
+	}
+	defer dom.Free()
+
+	domainSpec, err := l.getDomainSpec(dom)
+	if err != nil {
+		return err
+	}
+	metadata := domainSpec.Metadata.KubeVirt.Backup
+	if metadata == nil || metadata.Checkpoint != options.Checkpoint {
+		return fmt.Errorf("backup %s was not started", options.BackupName)
+	}
+	if metadata.Completed || metadata.Failed {
+		return nil
+	}
+
+	job := &backup.Job{
+		Name:           metadata.Name,
+		Checkpoint:     metadata.Checkpoint,
+		BaseCheckpoint: metadata.BaseCheckpoint,
+		Dir:            vmbackup.LauncherDir,
+	}
+	for _, volumeName := range metadata.Volumes {
+		job.Disks = append(job.Disks, backup.Disk{VolumeName: volumeName})
+	}
+
+	backupErr := backup.NewManager(&domainMonitor{dom: dom}).Finish(job, options.Abort)
+	now := metav1.Now()
+	metadata.EndTimestamp = &now
+	switch {
+	case backupErr != nil:
+		logger.Reason(backupErr).Errorf("Finishing backup %s failed.", metadata.Name)
+		metadata.Failed = true
+		metadata.Message = backupErr.Error()
+	case options.Abort:
+		logger.Infof("Aborted backup %s", metadata.Name)
+		metadata.Failed = true
+		metadata.Message = "backup was aborted"
+	default:
+		logger.Infof("Finished backup %s", metadata.Name)
+		metadata.Completed = true
+	}
+
+	if _, err := l.setDomainSpecWithHooks(vmi, domainSpec); err != nil {
+		return err
+	}
+	return backupErr
+}
+
 func GetImageInfo(imagePath string) (*containerdisk.DiskInfo, error) {
 
 	out, err := exec.Command(
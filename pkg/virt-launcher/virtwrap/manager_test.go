@@ -26,6 +26,7 @@ import (
 	"io/ioutil"
 	"os"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/golang/mock/gomock"
@@ -43,7 +44,10 @@ import (
 	"kubevirt.io/client-go/log"
 	cloudinit "kubevirt.io/kubevirt/pkg/cloud-init"
 	cmdv1 "kubevirt.io/kubevirt/pkg/handler-launcher-com/cmd/v1"
+	hostdisk "kubevirt.io/kubevirt/pkg/host-disk"
+	memorydump "kubevirt.io/kubevirt/pkg/memory-dump"
 	cmdclient "kubevirt.io/kubevirt/pkg/virt-handler/cmd-client"
+	accesscredentials "kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/access-credentials"
 	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/api"
 	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/cli"
 	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/network"
@@ -288,6 +292,228 @@ var _ = Describe("Manager", func() {
 			err := manager.UnpauseVMI(vmi)
 			Expect(err).To(BeNil())
 		})
+		table.DescribeTable("should soft reboot a running VirtualMachineInstance and record it", func(agentConnected bool, flags libvirt.DomainRebootFlagValues) {
+			// Make sure that we always free the domain after use
+			mockDomain.EXPECT().Free()
+			vmi := newVMI(testNamespace, testVmName)
+			if agentConnected {
+				vmi.Status.Conditions = []v1.VirtualMachineInstanceCondition{
+					{Type: v1.VirtualMachineInstanceAgentConnected, Status: k8sv1.ConditionTrue},
+				}
+			}
+			domainSpec := api.NewMinimalDomainSpec(testDomainName)
+			xml, err := xml.Marshal(domainSpec)
+			Expect(err).To(BeNil())
+
+			mockConn.EXPECT().LookupDomainByName(testDomainName).Return(mockDomain, nil)
+			mockDomain.EXPECT().GetState().AnyTimes().Return(libvirt.DOMAIN_RUNNING, 1, nil)
+			mockDomain.EXPECT().Reboot(flags).Return(nil)
+			mockDomain.EXPECT().GetXMLDesc(gomock.Any()).AnyTimes().Return(string(xml), nil)
+			mockConn.EXPECT().DomainDefineXML(gomock.Any()).DoAndReturn(func(xml string) (cli.VirDomain, error) {
+				Expect(xml).To(ContainSubstring("<count>1</count>"))
+				return mockDomain, nil
+			})
+			manager, _ := NewLibvirtDomainManager(mockConn, "fake", nil, 0)
+
+			err = manager.SoftRebootVMI(vmi)
+			Expect(err).To(BeNil())
+		},
+			table.Entry("through the guest agent if it is connected", true, libvirt.DOMAIN_REBOOT_GUEST_AGENT),
+			table.Entry("through ACPI if no guest agent is connected", false, libvirt.DOMAIN_REBOOT_ACPI_POWER_BTN),
+		)
+		It("should not soft reboot a paused VirtualMachineInstance", func() {
+			// Make sure that we always free the domain after use
+			mockDomain.EXPECT().Free()
+			vmi := newVMI(testNamespace, testVmName)
+
+			mockConn.EXPECT().LookupDomainByName(testDomainName).Return(mockDomain, nil)
+			mockDomain.EXPECT().GetState().Return(libvirt.DOMAIN_PAUSED, 1, nil)
+			manager, _ := NewLibvirtDomainManager(mockConn, "fake", nil, 0)
+			// no call to reboot
+
+			err := manager.SoftRebootVMI(vmi)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+	Context("on disk resize", func() {
+		addHostDiskVolume := func(vmi *v1.VirtualMachineInstance, capacity string) string {
+			vmi.Spec.Volumes = append(vmi.Spec.Volumes, v1.Volume{
+				Name: "hostdisk",
+				VolumeSource: v1.VolumeSource{
+					HostDisk: &v1.HostDisk{
+						Path:     "/var/run/kubevirt-private/vmi-disks/hostdisk/disk.img",
+						Type:     v1.HostDiskExistsOrCreate,
+						Capacity: resource.MustParse(capacity),
+					},
+				},
+			})
+			return hostdisk.GetMountedHostDiskPath("hostdisk", vmi.Spec.Volumes[0].HostDisk.Path)
+		}
+
+		It("should not resize disks which already have the requested capacity", func() {
+			// Make sure that we always free the domain after use
+			mockDomain.EXPECT().Free()
+			vmi := newVMI(testNamespace, testVmName)
+			diskPath := addHostDiskVolume(vmi, "1Gi")
+
+			mockConn.EXPECT().LookupDomainByName(testDomainName).Return(mockDomain, nil)
+			mockDomain.EXPECT().GetState().Return(libvirt.DOMAIN_RUNNING, 1, nil)
+			mockDomain.EXPECT().GetBlockInfo(diskPath, uint(0)).Return(&libvirt.DomainBlockInfo{Capacity: 1073741824}, nil)
+			manager, _ := NewLibvirtDomainManager(mockConn, "fake", nil, 0)
+			// no call to block resize
+
+			resizedVolumes, err := manager.ResizeVMIDisks(vmi)
+			Expect(err).To(BeNil())
+			Expect(resizedVolumes).To(BeEmpty())
+		})
+		It("should not resize the disks of a shut off VirtualMachineInstance", func() {
+			// Make sure that we always free the domain after use
+			mockDomain.EXPECT().Free()
+			vmi := newVMI(testNamespace, testVmName)
+			addHostDiskVolume(vmi, "1Gi")
+
+			mockConn.EXPECT().LookupDomainByName(testDomainName).Return(mockDomain, nil)
+			mockDomain.EXPECT().GetState().Return(libvirt.DOMAIN_SHUTOFF, 1, nil)
+			manager, _ := NewLibvirtDomainManager(mockConn, "fake", nil, 0)
+			// no call to block resize
+
+			_, err := manager.ResizeVMIDisks(vmi)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+	Context("on backup", func() {
+		backupOptions := &cmdclient.BackupOptions{BackupName: "backup", Checkpoint: "checkpoint"}
+
+		expectBackupMetadata := func(metadata *api.BackupMetadata) {
+			domainSpec := api.NewMinimalDomainSpec(testDomainName)
+			domainSpec.Metadata.KubeVirt.Backup = metadata
+			xml, err := xml.Marshal(domainSpec)
+			Expect(err).To(BeNil())
+
+			mockConn.EXPECT().LookupDomainByName(testDomainName).Return(mockDomain, nil)
+			mockDomain.EXPECT().GetState().AnyTimes().Return(libvirt.DOMAIN_RUNNING, 1, nil)
+			mockDomain.EXPECT().GetXMLDesc(gomock.Any()).AnyTimes().Return(string(xml), nil)
+		}
+
+		It("should not start a backup twice", func() {
+			// Make sure that we always free the domain after use
+			mockDomain.EXPECT().Free()
+			expectBackupMetadata(&api.BackupMetadata{Name: "backup", Checkpoint: "checkpoint"})
+			manager, _ := NewLibvirtDomainManager(mockConn, "fake", nil, 0)
+			// no call to the qemu monitor
+
+			err := manager.BackupVMI(newVMI(testNamespace, testVmName), backupOptions)
+			Expect(err).To(BeNil())
+		})
+		It("should not start a backup while another one is in progress", func() {
+			// Make sure that we always free the domain after use
+			mockDomain.EXPECT().Free()
+			expectBackupMetadata(&api.BackupMetadata{Name: "other", Checkpoint: "other"})
+			manager, _ := NewLibvirtDomainManager(mockConn, "fake", nil, 0)
+			// no call to the qemu monitor
+
+			err := manager.BackupVMI(newVMI(testNamespace, testVmName), backupOptions)
+			Expect(err).To(HaveOccurred())
+		})
+		It("should not finish a completed backup again", func() {
+			// Make sure that we always free the domain after use
+			mockDomain.EXPECT().Free()
+			expectBackupMetadata(&api.BackupMetadata{Name: "backup", Checkpoint: "checkpoint", Completed: true})
+			manager, _ := NewLibvirtDomainManager(mockConn, "fake", nil, 0)
+			// no call to the qemu monitor
+
+			err := manager.FinishVMIBackup(newVMI(testNamespace, testVmName), backupOptions)
+			Expect(err).To(BeNil())
+		})
+	})
+	Context("on memory dump", func() {
+		const fileName = "testvmi-dump-claim-20200101-000000.memory.dump"
+		var dumpDir string
+		var manager *LibvirtDomainManager
+
+		BeforeEach(func() {
+			var err error
+			dumpDir, err = ioutil.TempDir("", "memory-dump")
+			Expect(err).ToNot(HaveOccurred())
+			manager = &LibvirtDomainManager{
+				virConn:       mockConn,
+				virtShareDir:  "fake",
+				memoryDumpDir: dumpDir,
+			}
+		})
+
+		AfterEach(func() {
+			os.RemoveAll(dumpDir)
+		})
+
+		expectDumpFinished := func() {
+			Eventually(func() int32 {
+				return atomic.LoadInt32(&manager.memoryDumpInProgress)
+			}).Should(BeZero())
+		}
+
+		table.DescribeTable("should dump the memory in the background", func(state libvirt.DomainState) {
+			vmi := newVMI(testNamespace, testVmName)
+			mockConn.EXPECT().LookupDomainByName(testDomainName).Return(mockDomain, nil)
+			mockDomain.EXPECT().GetState().Return(state, 1, nil)
+			mockDomain.EXPECT().CoreDumpWithFormat(memorydump.PartialFilePath(dumpDir, fileName), libvirt.DOMAIN_CORE_DUMP_FORMAT_RAW, libvirt.DUMP_MEMORY_ONLY).DoAndReturn(
+				func(to string, format libvirt.DomainCoreDumpFormat, flags libvirt.DomainCoreDumpFlags) error {
+					return ioutil.WriteFile(to, []byte("memory"), 0644)
+				})
+			mockDomain.EXPECT().Free()
+
+			Expect(manager.MemoryDumpVMI(vmi, fileName)).To(Succeed())
+			expectDumpFinished()
+			Expect(memorydump.FilePath(dumpDir, fileName)).To(BeAnExistingFile())
+			Expect(memorydump.PartialFilePath(dumpDir, fileName)).ToNot(BeAnExistingFile())
+		},
+			table.Entry("of a running VirtualMachineInstance", libvirt.DOMAIN_RUNNING),
+			table.Entry("of a paused VirtualMachineInstance", libvirt.DOMAIN_PAUSED),
+		)
+
+		It("should record a failed memory dump", func() {
+			vmi := newVMI(testNamespace, testVmName)
+			mockConn.EXPECT().LookupDomainByName(testDomainName).Return(mockDomain, nil)
+			mockDomain.EXPECT().GetState().Return(libvirt.DOMAIN_RUNNING, 1, nil)
+			mockDomain.EXPECT().CoreDumpWithFormat(gomock.Any(), gomock.Any(), gomock.Any()).Return(fmt.Errorf("no space left on device"))
+			mockDomain.EXPECT().Free()
+
+			Expect(manager.MemoryDumpVMI(vmi, fileName)).To(Succeed())
+			expectDumpFinished()
+			reason, err := ioutil.ReadFile(memorydump.FailedFilePath(dumpDir, fileName))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(reason)).To(Equal("no space left on device"))
+			Expect(memorydump.FilePath(dumpDir, fileName)).ToNot(BeAnExistingFile())
+		})
+
+		It("should not dump the memory of a shut off VirtualMachineInstance", func() {
+			vmi := newVMI(testNamespace, testVmName)
+			mockConn.EXPECT().LookupDomainByName(testDomainName).Return(mockDomain, nil)
+			mockDomain.EXPECT().GetState().Return(libvirt.DOMAIN_SHUTOFF, 1, nil)
+			mockDomain.EXPECT().Free()
+
+			Expect(manager.MemoryDumpVMI(vmi, fileName)).ToNot(Succeed())
+			Expect(manager.memoryDumpInProgress).To(BeZero())
+		})
+
+		It("should not dump more guest memory than fits on the node", func() {
+			vmi := newVMI(testNamespace, testVmName)
+			vmi.Spec.Domain.Resources.Requests[k8sv1.ResourceMemory] = resource.MustParse("1Ei")
+			mockConn.EXPECT().LookupDomainByName(testDomainName).Return(mockDomain, nil)
+			mockDomain.EXPECT().GetState().Return(libvirt.DOMAIN_RUNNING, 1, nil)
+			mockDomain.EXPECT().Free()
+
+			Expect(manager.MemoryDumpVMI(vmi, fileName)).To(MatchError(ContainSubstring("not enough free space for the memory dump")))
+			Expect(manager.memoryDumpInProgress).To(BeZero())
+			Expect(memorydump.PartialFilePath(dumpDir, fileName)).ToNot(BeAnExistingFile())
+		})
+
+		It("should reject a memory dump while another one is in progress", func() {
+			vmi := newVMI(testNamespace, testVmName)
+			manager.memoryDumpInProgress = 1
+
+			Expect(manager.MemoryDumpVMI(vmi, fileName)).To(MatchError("a memory dump is already in progress"))
+		})
 	})
 	Context("test migration monitor", func() {
 		It("migration should be canceled if it's not progressing", func() {
@@ -319,6 +545,7 @@ var _ = Describe("Manager", func() {
 				virtShareDir:           "fake",
 				notifier:               nil,
 				lessPVCSpaceToleration: 0,
+				credManager:            accesscredentials.NewManager(mockConn),
 			}
 			mockDomain.EXPECT().GetState().Return(libvirt.DOMAIN_RUNNING, 1, nil)
 			mockConn.EXPECT().LookupDomainByName(testDomainName).Return(mockDomain, nil)
@@ -361,6 +588,7 @@ var _ = Describe("Manager", func() {
 				virtShareDir:           "fake",
 				notifier:               nil,
 				lessPVCSpaceToleration: 0,
+				credManager:            accesscredentials.NewManager(mockConn),
 			}
 			mockDomain.EXPECT().GetState().Return(libvirt.DOMAIN_RUNNING, 1, nil)
 			mockConn.EXPECT().LookupDomainByName(testDomainName).Return(mockDomain, nil)
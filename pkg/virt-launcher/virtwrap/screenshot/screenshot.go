@@ -0,0 +1,139 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2019 Red Hat, Inc.
+ *
+ */
+
+package screenshot
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"io/ioutil"
+	"strconv"
+)
+
+const (
+	MimeTypePNG = "image/png"
+	MimeTypePPM = "image/x-portable-pixmap"
+)
+
+// Refuse to allocate images larger than 8K UHD
+const maxPixels = 7680 * 4320
+
+// ToPNG converts a screenshot as returned by libvirt to PNG. QEMU produces
+// PPM images, PNG images are passed through unchanged.
+func ToPNG(mimeType string, data io.Reader) ([]byte, error) {
+	switch mimeType {
+	case MimeTypePNG:
+		return ioutil.ReadAll(data)
+	case MimeTypePPM:
+		img, err := DecodePPM(data)
+		if err != nil {
+			return nil, err
+		}
+		buf := &bytes.Buffer{}
+		if err := png.Encode(buf, img); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	}
+	return nil, fmt.Errorf("unsupported screenshot format %q", mimeType)
+}
+
+// DecodePPM decodes a binary (P6) portable pixmap
+func DecodePPM(data io.Reader) (image.Image, error) {
+	r := bufio.NewReader(data)
+
+	magic, err := readPPMToken(r)
+	if err != nil {
+		return nil, err
+	}
+	if magic != "P6" {
+		return nil, fmt.Errorf("unsupported PPM format %q", magic)
+	}
+	var header [3]int
+	for i := range header {
+		token, err := readPPMToken(r)
+		if err != nil {
+			return nil, err
+		}
+		if header[i], err = strconv.Atoi(token); err != nil || header[i] <= 0 {
+			return nil, fmt.Errorf("invalid PPM header value %q", token)
+		}
+	}
+	width, height, maxVal := header[0], header[1], header[2]
+	if maxVal > 65535 {
+		return nil, fmt.Errorf("invalid PPM maximum color value %d", maxVal)
+	}
+	if width*height > maxPixels {
+		return nil, fmt.Errorf("PPM image of %dx%d pixels is too large", width, height)
+	}
+
+	bytesPerSample := 1
+	if maxVal > 255 {
+		bytesPerSample = 2
+	}
+	row := make([]byte, width*3*bytesPerSample)
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		if _, err := io.ReadFull(r, row); err != nil {
+			return nil, fmt.Errorf("truncated PPM image: %v", err)
+		}
+		for x := 0; x < width; x++ {
+			var rgb [3]uint8
+			for c := range rgb {
+				offset := (x*3 + c) * bytesPerSample
+				sample := int(row[offset])
+				if bytesPerSample == 2 {
+					sample = sample<<8 | int(row[offset+1])
+				}
+				rgb[c] = uint8(sample * 255 / maxVal)
+			}
+			img.SetRGBA(x, y, color.RGBA{R: rgb[0], G: rgb[1], B: rgb[2], A: 255})
+		}
+	}
+	return img, nil
+}
+
+// readPPMToken reads the next whitespace separated header token, skipping comments.
+// The single whitespace character after the token is consumed as well.
+func readPPMToken(r *bufio.Reader) (string, error) {
+	token := []byte{}
+	for {
+		c, err := r.ReadByte()
+		if err != nil {
+			return "", fmt.Errorf("truncated PPM header: %v", err)
+		}
+		switch {
+		case c == '#' && len(token) == 0:
+			if _, err := r.ReadString('\n'); err != nil {
+				return "", fmt.Errorf("truncated PPM header: %v", err)
+			}
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			if len(token) > 0 {
+				return string(token), nil
+			}
+		default:
+			token = append(token, c)
+		}
+	}
+}
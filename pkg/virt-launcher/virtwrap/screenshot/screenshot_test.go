@@ -0,0 +1,84 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2019 Red Hat, Inc.
+ *
+ */
+
+package screenshot_test
+
+import (
+	"bytes"
+	"image/color"
+	"image/png"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+
+	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/screenshot"
+)
+
+var _ = Describe("Screenshot", func() {
+
+	It("should decode a PPM image with comments in the header", func() {
+		ppm := "P6\n# created by QEMU\n2 1\n255\n" + "\xff\x00\x00" + "\x00\x80\xff"
+
+		img, err := screenshot.DecodePPM(strings.NewReader(ppm))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(img.Bounds().Dx()).To(Equal(2))
+		Expect(img.Bounds().Dy()).To(Equal(1))
+		Expect(img.At(0, 0)).To(Equal(color.RGBA{R: 255, A: 255}))
+		Expect(img.At(1, 0)).To(Equal(color.RGBA{G: 128, B: 255, A: 255}))
+	})
+
+	It("should scale 16 bit samples", func() {
+		ppm := "P6 1 1 65535\n" + "\xff\xff\x00\x00\x80\x00"
+
+		img, err := screenshot.DecodePPM(strings.NewReader(ppm))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(img.At(0, 0)).To(Equal(color.RGBA{R: 255, B: 127, A: 255}))
+	})
+
+	It("should convert a PPM screenshot to PNG", func() {
+		ppm := "P6\n1 1\n255\n" + "\x01\x02\x03"
+
+		data, err := screenshot.ToPNG(screenshot.MimeTypePPM, strings.NewReader(ppm))
+		Expect(err).ToNot(HaveOccurred())
+		img, err := png.Decode(bytes.NewReader(data))
+		Expect(err).ToNot(HaveOccurred())
+		r, g, b, _ := img.At(0, 0).RGBA()
+		Expect([]uint32{r >> 8, g >> 8, b >> 8}).To(Equal([]uint32{1, 2, 3}))
+	})
+
+	It("should pass PNG screenshots through", func() {
+		data, err := screenshot.ToPNG(screenshot.MimeTypePNG, strings.NewReader("\x89PNG"))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(data)).To(Equal("\x89PNG"))
+	})
+
+	table.DescribeTable("should reject invalid images", func(mimeType string, data string) {
+		_, err := screenshot.ToPNG(mimeType, strings.NewReader(data))
+		Expect(err).To(HaveOccurred())
+	},
+		table.Entry("with an unknown mime type", "image/bmp", "BM"),
+		table.Entry("with an ASCII pixmap", screenshot.MimeTypePPM, "P3\n1 1\n255\n1 2 3\n"),
+		table.Entry("with a truncated header", screenshot.MimeTypePPM, "P6\n1 1"),
+		table.Entry("with an invalid size", screenshot.MimeTypePPM, "P6\n-1 1\n255\n"),
+		table.Entry("with truncated pixel data", screenshot.MimeTypePPM, "P6\n2 1\n255\n\x00\x00\x00"),
+		table.Entry("with a huge size", screenshot.MimeTypePPM, "P6\n100000 100000\n255\n"),
+	)
+})
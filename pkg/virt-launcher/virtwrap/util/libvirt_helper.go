@@ -89,6 +89,41 @@ func ConvReason(status libvirt.DomainState, reason int) api.StateChangeReason {
 	}
 }
 
+var DiskErrorReasonTranslationMap = map[libvirt.DomainDiskErrorCode]api.DiskErrorReason{
+	libvirt.DOMAIN_DISK_ERROR_UNSPEC:   api.DiskErrorUnspecified,
+	libvirt.DOMAIN_DISK_ERROR_NO_SPACE: api.DiskErrorNoSpace,
+}
+
+// GetDiskErrors returns the disks with I/O errors, named like the disks of the VirtualMachineInstance
+func GetDiskErrors(dom cli.VirDomain, spec *api.DomainSpec) ([]api.DiskError, error) {
+	diskErrors, err := dom.GetDiskErrors(0)
+	if err != nil {
+		return nil, err
+	}
+
+	var errors []api.DiskError
+	for _, diskError := range diskErrors {
+		if diskError.Error == libvirt.DOMAIN_DISK_ERROR_NONE {
+			continue
+		}
+		// libvirt names the disk by its target device, fall back to it if it is not in the spec
+		name := diskError.Disk
+		if spec != nil {
+			for _, disk := range spec.Devices.Disks {
+				if disk.Target.Device == diskError.Disk && disk.Alias != nil {
+					name = disk.Alias.Name
+					break
+				}
+			}
+		}
+		errors = append(errors, api.DiskError{
+			Name:   name,
+			Reason: DiskErrorReasonTranslationMap[diskError.Error],
+		})
+	}
+	return errors, nil
+}
+
 func SetDomainSpecStr(virConn cli.Connection, vmi *v1.VirtualMachineInstance, wantedSpec string) (cli.VirDomain, error) {
 	log.Log.Object(vmi).V(3).With("xml", wantedSpec).Info("Domain XML generated.")
 	dom, err := virConn.DomainDefineXML(wantedSpec)
@@ -366,5 +401,16 @@ func SetupLibvirt() error {
 		}
 	}
 
+	// Keep the serial console log small, it lives on an emptyDir of the pod
+	virtlogdConf, err := os.OpenFile("/etc/libvirt/virtlogd.conf", os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	defer virtlogdConf.Close()
+	_, err = virtlogdConf.WriteString("max_size = 1048576\nmax_backups = 2\n")
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
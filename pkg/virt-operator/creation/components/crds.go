@@ -190,6 +190,94 @@ func NewVirtualMachineInstanceMigrationCrd() *extv1beta1.CustomResourceDefinitio
 	return crd
 }
 
+func NewContainerDiskImageCacheCrd() *extv1beta1.CustomResourceDefinition {
+	crd := newBlankCrd()
+
+	crd.ObjectMeta.Name = "containerdiskimagecaches." + virtv1.ContainerDiskImageCacheGroupVersionKind.Group
+	crd.Spec = extv1beta1.CustomResourceDefinitionSpec{
+		Group:    virtv1.ContainerDiskImageCacheGroupVersionKind.Group,
+		Version:  virtv1.ApiSupportedVersions[0].Name,
+		Versions: virtv1.ApiSupportedVersions,
+		Scope:    "Cluster",
+
+		Names: extv1beta1.CustomResourceDefinitionNames{
+			Plural:     "containerdiskimagecaches",
+			Singular:   "containerdiskimagecache",
+			Kind:       virtv1.ContainerDiskImageCacheGroupVersionKind.Kind,
+			ShortNames: []string{"cdic", "cdics"},
+		},
+		AdditionalPrinterColumns: []extv1beta1.CustomResourceColumnDefinition{
+			{Name: "Age", Type: "date", JSONPath: ".metadata.creationTimestamp"},
+		},
+		// virt-handler is only allowed to update the status
+		Subresources: &extv1beta1.CustomResourceSubresources{
+			Status: &extv1beta1.CustomResourceSubresourceStatus{},
+		},
+	}
+
+	return crd
+}
+
+func NewVirtualMachineBackupCrd() *extv1beta1.CustomResourceDefinition {
+	crd := newBlankCrd()
+
+	crd.ObjectMeta.Name = "virtualmachinebackups." + virtv1.VirtualMachineBackupGroupVersionKind.Group
+	crd.Spec = extv1beta1.CustomResourceDefinitionSpec{
+		Group:    virtv1.VirtualMachineBackupGroupVersionKind.Group,
+		Version:  virtv1.ApiSupportedVersions[0].Name,
+		Versions: virtv1.ApiSupportedVersions,
+		Scope:    "Namespaced",
+
+		Names: extv1beta1.CustomResourceDefinitionNames{
+			Plural:     "virtualmachinebackups",
+			Singular:   "virtualmachinebackup",
+			Kind:       virtv1.VirtualMachineBackupGroupVersionKind.Kind,
+			ShortNames: []string{"vmbackup", "vmbackups"},
+			Categories: []string{
+				"all",
+			},
+		},
+		AdditionalPrinterColumns: []extv1beta1.CustomResourceColumnDefinition{
+			{Name: "VMI", Type: "string", JSONPath: ".spec.vmiName"},
+			{Name: "Mode", Type: "string", JSONPath: ".spec.mode"},
+			{Name: "Phase", Type: "string", JSONPath: ".status.phase"},
+			{Name: "Age", Type: "date", JSONPath: ".metadata.creationTimestamp"},
+		},
+	}
+
+	return crd
+}
+
+func NewVirtualMachineExportCrd() *extv1beta1.CustomResourceDefinition {
+	crd := newBlankCrd()
+
+	crd.ObjectMeta.Name = "virtualmachineexports." + virtv1.VirtualMachineExportGroupVersionKind.Group
+	crd.Spec = extv1beta1.CustomResourceDefinitionSpec{
+		Group:    virtv1.VirtualMachineExportGroupVersionKind.Group,
+		Version:  virtv1.ApiSupportedVersions[0].Name,
+		Versions: virtv1.ApiSupportedVersions,
+		Scope:    "Namespaced",
+
+		Names: extv1beta1.CustomResourceDefinitionNames{
+			Plural:     "virtualmachineexports",
+			Singular:   "virtualmachineexport",
+			Kind:       virtv1.VirtualMachineExportGroupVersionKind.Kind,
+			ShortNames: []string{"vmexport", "vmexports"},
+			Categories: []string{
+				"all",
+			},
+		},
+		AdditionalPrinterColumns: []extv1beta1.CustomResourceColumnDefinition{
+			{Name: "SourceKind", Type: "string", JSONPath: ".spec.source.kind"},
+			{Name: "SourceName", Type: "string", JSONPath: ".spec.source.name"},
+			{Name: "Phase", Type: "string", JSONPath: ".status.phase"},
+			{Name: "Age", Type: "date", JSONPath: ".metadata.creationTimestamp"},
+		},
+	}
+
+	return crd
+}
+
 // Used by manifest generation
 // If you change something here, you probably need to change the CSV manifest too,
 // see /manifests/release/kubevirt.VERSION.csv.yaml.in
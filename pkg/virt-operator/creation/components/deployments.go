@@ -30,6 +30,8 @@ import (
 	"k8s.io/apimachinery/pkg/util/json"
 
 	virtv1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/kubevirt/pkg/certificates/bootstrap"
+	reservation "kubevirt.io/kubevirt/pkg/storage-reservation"
 	"kubevirt.io/kubevirt/pkg/virt-operator/creation/rbac"
 	operatorutil "kubevirt.io/kubevirt/pkg/virt-operator/util"
 )
@@ -241,6 +243,13 @@ func NewApiServerDeployment(namespace string, repository string, imagePrefix str
 		InitialDelaySeconds: 15,
 		PeriodSeconds:       10,
 	}
+
+	attachCertificateSecret(pod, VirtApiCertSecretName, bootstrap.VirtApiCertDir)
+	// virt-api connects to virt-handler with the virt-handler client certificate
+	attachCertificateSecret(pod, VirtHandlerCertSecretName, bootstrap.VirtHandlerCertDir)
+	// all virt-api replicas sign VNC tokens with the same key
+	attachCertificateSecret(pod, VirtApiVNCTokenSecretName, VNCTokenKeyDir)
+
 	return deployment, nil
 }
 
@@ -307,6 +316,9 @@ func NewControllerDeployment(namespace string, repository string, imagePrefix st
 		InitialDelaySeconds: 15,
 		TimeoutSeconds:      10,
 	}
+
+	attachCertificateSecret(pod, VirtControllerCertSecretName, bootstrap.VirtControllerCertDir)
+
 	return deployment, nil
 }
 
@@ -427,10 +439,50 @@ func NewHandlerDaemonSet(namespace string, repository string, imagePrefix string
 		})
 	}
 
+	attachCertificateSecret(pod, VirtHandlerCertSecretName, bootstrap.VirtHandlerCertDir)
+	attachCertificateSecret(pod, VirtHandlerServerCertSecretName, bootstrap.VirtHandlerServerCertDir)
+
 	return daemonset, nil
 
 }
 
+// InjectPersistentReservationHelper adds the privileged qemu-pr-helper of the
+// virt-launcher image to the virt-handler DaemonSet. It handles the SCSI
+// persistent reservations of all VMIs on the node.
+func InjectPersistentReservationHelper(daemonset *appsv1.DaemonSet, repository string, imagePrefix string, launcherVersion string, pullPolicy corev1.PullPolicy) {
+	pod := &daemonset.Spec.Template.Spec
+	launcherVersion = AddVersionSeparatorPrefix(launcherVersion)
+	socketDirType := corev1.HostPathDirectoryOrCreate
+	pod.Volumes = append(pod.Volumes, corev1.Volume{
+		Name: reservation.VolumeName,
+		VolumeSource: corev1.VolumeSource{
+			HostPath: &corev1.HostPathVolumeSource{
+				Path: reservation.SocketDir,
+				Type: &socketDirType,
+			},
+		},
+	})
+	pod.Containers = append(pod.Containers, corev1.Container{
+		Name:            reservation.ContainerName,
+		Image:           fmt.Sprintf("%s/%s%s%s", repository, imagePrefix, "virt-launcher", launcherVersion),
+		ImagePullPolicy: pullPolicy,
+		Command: []string{
+			"/usr/bin/qemu-pr-helper",
+			"-k",
+			reservation.SocketPath(),
+		},
+		SecurityContext: &corev1.SecurityContext{
+			Privileged: boolPtr(true),
+		},
+		VolumeMounts: []corev1.VolumeMount{
+			{
+				Name:      reservation.VolumeName,
+				MountPath: reservation.SocketDir,
+			},
+		},
+	})
+}
+
 // Used for manifest generation only
 func NewOperatorDeployment(namespace string, repository string, imagePrefix string, version string,
 	pullPolicy corev1.PullPolicy, verbosity string,
@@ -570,6 +622,64 @@ func NewOperatorDeployment(namespace string, repository string, imagePrefix stri
 	return deployment, nil
 }
 
+// attachCertificateSecret mounts a certificate secret, which virt-operator
+// keeps up to date, into the first container of the pod
+func attachCertificateSecret(pod *corev1.PodSpec, secretName string, mountPath string) {
+	pod.Volumes = append(pod.Volumes, corev1.Volume{
+		Name: secretName,
+		VolumeSource: corev1.VolumeSource{
+			Secret: &corev1.SecretVolumeSource{
+				SecretName: secretName,
+				Optional:   boolPtr(true),
+			},
+		},
+	})
+	pod.Containers[0].VolumeMounts = append(pod.Containers[0].VolumeMounts, corev1.VolumeMount{
+		Name:      secretName,
+		ReadOnly:  true,
+		MountPath: mountPath,
+	})
+}
+
+// InjectPlacementMetadata adds the node placement of a component config to
+// the pod spec of a component. Node selectors and tolerations extend the ones
+// of the component, every kind of affinity which is set replaces the default
+// one of the component.
+func InjectPlacementMetadata(componentConfig *virtv1.ComponentConfig, pod *corev1.PodSpec) {
+	if componentConfig == nil || componentConfig.NodePlacement == nil {
+		return
+	}
+	nodePlacement := componentConfig.NodePlacement.DeepCopy()
+
+	if len(nodePlacement.NodeSelector) > 0 {
+		if pod.NodeSelector == nil {
+			pod.NodeSelector = make(map[string]string)
+		}
+		for key, value := range nodePlacement.NodeSelector {
+			if _, exists := pod.NodeSelector[key]; !exists {
+				pod.NodeSelector[key] = value
+			}
+		}
+	}
+
+	pod.Tolerations = append(pod.Tolerations, nodePlacement.Tolerations...)
+
+	if affinity := nodePlacement.Affinity; affinity != nil {
+		if pod.Affinity == nil {
+			pod.Affinity = &corev1.Affinity{}
+		}
+		if affinity.NodeAffinity != nil {
+			pod.Affinity.NodeAffinity = affinity.NodeAffinity
+		}
+		if affinity.PodAffinity != nil {
+			pod.Affinity.PodAffinity = affinity.PodAffinity
+		}
+		if affinity.PodAntiAffinity != nil {
+			pod.Affinity.PodAntiAffinity = affinity.PodAntiAffinity
+		}
+	}
+}
+
 func int32Ptr(i int32) *int32 {
 	return &i
 }
@@ -0,0 +1,297 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2020 Red Hat, Inc.
+ *
+ */
+package components
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/cert"
+
+	virtv1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/kubevirt/pkg/certificates/bootstrap"
+	"kubevirt.io/kubevirt/pkg/certificates/triple"
+)
+
+const (
+	KubeVirtCASecretName            = "kubevirt-ca"
+	VirtApiCertSecretName           = "kubevirt-virt-api-certs"
+	VirtControllerCertSecretName    = "kubevirt-controller-certs"
+	VirtHandlerCertSecretName       = "kubevirt-virt-handler-certs"
+	VirtHandlerServerCertSecretName = "kubevirt-virt-handler-server-certs"
+	VirtApiVNCTokenSecretName       = "kubevirt-virt-api-vnc-token-key"
+
+	// VNCTokenKeyDir is the directory the VNC token signing key is mounted to in virt-api
+	VNCTokenKeyDir = "/etc/virt-api/vnctoken"
+	// VNCTokenKeyKey is the secret key which holds the VNC token signing key
+	VNCTokenKeyKey = "key"
+
+	vncTokenKeySize = 32
+
+	// the CA which is published in the bundle ahead of signing certificates
+	nextCACertKey        = "next-ca.crt"
+	nextCAKeyKey         = "next-ca.key"
+	nextCAPublishedAtKey = "next-ca-published-at"
+
+	kubeVirtCACommonName          = "kubevirt.io"
+	virtHandlerClientCommonName   = "kubevirt.io:system:node:virt-handler"
+	componentCertDNSDomain        = "cluster.local"
+	componentCertCommonNameFormat = "%s.%s.pod.cluster.local"
+)
+
+func newCertSecret(namespace string, name string) *corev1.Secret {
+	return &corev1.Secret{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "v1",
+			Kind:       "Secret",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Name:      name,
+			Labels: map[string]string{
+				virtv1.AppLabel: "",
+			},
+		},
+		// not of type TLS, so that secrets which the components created
+		// themselves in the past can be taken over
+		Type: corev1.SecretTypeOpaque,
+	}
+}
+
+// NewCACertSecret returns the secret holding the KubeVirt CA and the bundle
+// of all CAs which are currently trusted
+func NewCACertSecret(namespace string) *corev1.Secret {
+	return newCertSecret(namespace, KubeVirtCASecretName)
+}
+
+// NewCertSecrets returns the secrets holding the certificates of the KubeVirt components
+func NewCertSecrets(namespace string) []*corev1.Secret {
+	return []*corev1.Secret{
+		newCertSecret(namespace, VirtApiCertSecretName),
+		newCertSecret(namespace, VirtControllerCertSecretName),
+		newCertSecret(namespace, VirtHandlerCertSecretName),
+		newCertSecret(namespace, VirtHandlerServerCertSecretName),
+	}
+}
+
+// NewVNCTokenSecret returns the secret holding the key which virt-api signs VNC tokens with
+func NewVNCTokenSecret(namespace string) *corev1.Secret {
+	return newCertSecret(namespace, VirtApiVNCTokenSecretName)
+}
+
+// HasVNCTokenKey returns true if the secret contains a VNC token signing key
+func HasVNCTokenKey(secret *corev1.Secret) bool {
+	return secret.Data != nil && len(secret.Data[VNCTokenKeyKey]) == vncTokenKeySize
+}
+
+// PopulateVNCTokenSecret generates a new random VNC token signing key into the secret
+func PopulateVNCTokenSecret(secret *corev1.Secret) error {
+	key := make([]byte, vncTokenKeySize)
+	if _, err := rand.Read(key); err != nil {
+		return err
+	}
+	secret.Data = map[string][]byte{
+		VNCTokenKeyKey: key,
+	}
+	return nil
+}
+
+// PopulateCASecret issues a new CA into the secret. CAs from the previous
+// bundle of the secret stay trusted until they expire, so that certificates
+// which were signed by them remain valid until they get rotated.
+func PopulateCASecret(secret *corev1.Secret, duration time.Duration) error {
+	ca, err := triple.NewCA(kubeVirtCACommonName, duration)
+	if err != nil {
+		return err
+	}
+
+	var previousBundle []byte
+	if secret.Data != nil {
+		previousBundle = secret.Data[bootstrap.CABundleKey]
+	}
+	caBundle, err := MergeCABundle(ca.Cert, previousBundle, time.Now())
+	if err != nil {
+		return err
+	}
+
+	secret.Data = map[string][]byte{
+		corev1.TLSCertKey:       cert.EncodeCertPEM(ca.Cert),
+		corev1.TLSPrivateKeyKey: cert.EncodePrivateKeyPEM(ca.Key),
+		bootstrap.CABundleKey:   caBundle,
+	}
+	return nil
+}
+
+// PublishNextCA issues a new CA into the secret without signing with it yet.
+// The new CA is only added to the bundle of trusted CAs, so that it reaches
+// all peers before the first certificate signed by it is handed out.
+func PublishNextCA(secret *corev1.Secret, duration time.Duration, now time.Time) error {
+	ca, err := triple.NewCA(kubeVirtCACommonName, duration)
+	if err != nil {
+		return err
+	}
+
+	caBundle, err := MergeCABundle(ca.Cert, secret.Data[bootstrap.CABundleKey], now)
+	if err != nil {
+		return err
+	}
+
+	secret.Data[bootstrap.CABundleKey] = caBundle
+	secret.Data[nextCACertKey] = cert.EncodeCertPEM(ca.Cert)
+	secret.Data[nextCAKeyKey] = cert.EncodePrivateKeyPEM(ca.Key)
+	secret.Data[nextCAPublishedAtKey] = []byte(now.UTC().Format(time.RFC3339))
+	return nil
+}
+
+// NextCAPublishedAt returns when the next CA of the secret was added to the
+// bundle, or false if no CA is waiting to be activated
+func NextCAPublishedAt(secret *corev1.Secret) (time.Time, bool) {
+	if secret.Data == nil || len(secret.Data[nextCACertKey]) == 0 {
+		return time.Time{}, false
+	}
+	publishedAt, err := time.Parse(time.RFC3339, string(secret.Data[nextCAPublishedAtKey]))
+	if err != nil {
+		// activate CAs with a broken timestamp right away
+		return time.Time{}, true
+	}
+	return publishedAt, true
+}
+
+// ActivateNextCA makes the published next CA of the secret the one which
+// signs certificates. The bundle already trusts it and is kept as it is.
+func ActivateNextCA(secret *corev1.Secret) {
+	secret.Data[corev1.TLSCertKey] = secret.Data[nextCACertKey]
+	secret.Data[corev1.TLSPrivateKeyKey] = secret.Data[nextCAKeyKey]
+	delete(secret.Data, nextCACertKey)
+	delete(secret.Data, nextCAKeyKey)
+	delete(secret.Data, nextCAPublishedAtKey)
+}
+
+// PopulateSecretWithCertificate issues a new certificate for the component
+// owning the secret, signed by the given CA
+func PopulateSecretWithCertificate(secret *corev1.Secret, ca *triple.KeyPair, caBundle []byte, duration time.Duration) error {
+	var keyPair *triple.KeyPair
+	var err error
+
+	switch secret.Name {
+	case VirtApiCertSecretName:
+		keyPair, err = newServerKeyPair(ca, "virt-api", secret.Namespace, duration)
+	case VirtControllerCertSecretName:
+		keyPair, err = newServerKeyPair(ca, "virt-controller", secret.Namespace, duration)
+	case VirtHandlerServerCertSecretName:
+		keyPair, err = newServerKeyPair(ca, "virt-handler", secret.Namespace, duration)
+	case VirtHandlerCertSecretName:
+		keyPair, err = triple.NewClientKeyPair(ca, virtHandlerClientCommonName, nil, duration)
+	default:
+		return fmt.Errorf("secret %s does not belong to any KubeVirt component", secret.Name)
+	}
+	if err != nil {
+		return err
+	}
+
+	secret.Data = map[string][]byte{
+		corev1.TLSCertKey:       cert.EncodeCertPEM(keyPair.Cert),
+		corev1.TLSPrivateKeyKey: cert.EncodePrivateKeyPEM(keyPair.Key),
+		bootstrap.CABundleKey:   caBundle,
+	}
+	return nil
+}
+
+func newServerKeyPair(ca *triple.KeyPair, name string, namespace string, duration time.Duration) (*triple.KeyPair, error) {
+	return triple.NewServerKeyPair(
+		ca,
+		fmt.Sprintf(componentCertCommonNameFormat, name, namespace),
+		name,
+		namespace,
+		componentCertDNSDomain,
+		nil,
+		nil,
+		duration,
+	)
+}
+
+// LoadCertificate parses the certificate and key stored in the secret
+func LoadCertificate(secret *corev1.Secret) (*triple.KeyPair, error) {
+	if secret.Data == nil {
+		return nil, fmt.Errorf("secret %s contains no certificate", secret.Name)
+	}
+	certs, err := cert.ParseCertsPEM(secret.Data[corev1.TLSCertKey])
+	if err != nil {
+		return nil, err
+	}
+	key, err := cert.ParsePrivateKeyPEM(secret.Data[corev1.TLSPrivateKeyKey])
+	if err != nil {
+		return nil, err
+	}
+	rsaKey, ok := key.(*rsa.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("secret %s contains no RSA private key", secret.Name)
+	}
+	return &triple.KeyPair{
+		Cert: certs[0],
+		Key:  rsaKey,
+	}, nil
+}
+
+// NextRotationDeadline returns the point in time at which the certificate in
+// the secret has to be renewed. That is renewBefore ahead of its expiry, or
+// right away if the secret contains no valid certificate. If a CA is given,
+// certificates which were not signed by it are renewed right away too.
+func NextRotationDeadline(secret *corev1.Secret, ca *triple.KeyPair, renewBefore time.Duration) time.Time {
+	keyPair, err := LoadCertificate(secret)
+	if err != nil {
+		return time.Time{}
+	}
+	if ca != nil && keyPair.Cert.CheckSignatureFrom(ca.Cert) != nil {
+		return time.Time{}
+	}
+	return keyPair.Cert.NotAfter.Add(-renewBefore)
+}
+
+// MergeCABundle puts the given CA in front of the bundle and drops all CAs
+// from it which expired at the given point in time
+func MergeCABundle(ca *x509.Certificate, bundle []byte, now time.Time) ([]byte, error) {
+	merged := cert.EncodeCertPEM(ca)
+	if len(bundle) == 0 {
+		return merged, nil
+	}
+
+	caCerts, err := cert.ParseCertsPEM(bundle)
+	if err != nil {
+		return nil, err
+	}
+	for _, caCert := range caCerts {
+		if caCert.Equal(ca) || now.After(caCert.NotAfter) {
+			continue
+		}
+		merged = append(merged, cert.EncodeCertPEM(caCert)...)
+	}
+	return merged, nil
+}
+
+// CABundleChanged returns true if the CA bundle of the secret differs from the given one
+func CABundleChanged(secret *corev1.Secret, caBundle []byte) bool {
+	return secret.Data == nil || !bytes.Equal(secret.Data[bootstrap.CABundleKey], caBundle)
+}
@@ -28,6 +28,10 @@ import (
 
 const ApiServiceAccountName = "kubevirt-apiserver"
 
+// apiServerContainerDiskDigestsName names the rbac resources which allow virt-api
+// to read image pull secrets, only deployed with the ContainerDiskDigests feature gate
+const apiServerContainerDiskDigestsName = "kubevirt-apiserver-container-disk-digests"
+
 func GetAllApiServer(namespace string) []interface{} {
 	return []interface{}{
 		newApiServerServiceAccount(namespace),
@@ -39,6 +43,15 @@ func GetAllApiServer(namespace string) []interface{} {
 	}
 }
 
+// GetAllApiServerContainerDiskDigests returns the rbac resources virt-api needs to
+// resolve containerDisk digests with the image pull secrets of a VMI
+func GetAllApiServerContainerDiskDigests(namespace string) []interface{} {
+	return []interface{}{
+		newApiServerContainerDiskDigestsClusterRole(),
+		newApiServerContainerDiskDigestsClusterRoleBinding(namespace),
+	}
+}
+
 func newApiServerServiceAccount(namespace string) *corev1.ServiceAccount {
 	return &corev1.ServiceAccount{
 		TypeMeta: metav1.TypeMeta{
@@ -102,6 +115,28 @@ func newApiServerClusterRole() *rbacv1.ClusterRole {
 					"get", "list",
 				},
 			},
+			{
+				APIGroups: []string{
+					"",
+				},
+				Resources: []string{
+					"pods/log",
+				},
+				Verbs: []string{
+					"get",
+				},
+			},
+			{
+				APIGroups: []string{
+					"",
+				},
+				Resources: []string{
+					"nodes",
+				},
+				Verbs: []string{
+					"get",
+				},
+			},
 			{
 				APIGroups: []string{
 					"kubevirt.io",
@@ -171,6 +206,19 @@ func newApiServerClusterRole() *rbacv1.ClusterRole {
 					"watch",
 				},
 			},
+			{
+				APIGroups: []string{
+					"kubevirt.io",
+				},
+				Resources: []string{
+					"kubevirts",
+				},
+				Verbs: []string{
+					"get",
+					"list",
+					"watch",
+				},
+			},
 		},
 	}
 }
@@ -296,3 +344,58 @@ func newApiServerRoleBinding(namespace string) *rbacv1.RoleBinding {
 		},
 	}
 }
+
+func newApiServerContainerDiskDigestsClusterRole() *rbacv1.ClusterRole {
+	return &rbacv1.ClusterRole{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "rbac.authorization.k8s.io/v1",
+			Kind:       "ClusterRole",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: apiServerContainerDiskDigestsName,
+			Labels: map[string]string{
+				virtv1.AppLabel: "",
+			},
+		},
+		Rules: []rbacv1.PolicyRule{
+			{
+				APIGroups: []string{
+					"",
+				},
+				Resources: []string{
+					"secrets",
+				},
+				Verbs: []string{
+					"get",
+				},
+			},
+		},
+	}
+}
+
+func newApiServerContainerDiskDigestsClusterRoleBinding(namespace string) *rbacv1.ClusterRoleBinding {
+	return &rbacv1.ClusterRoleBinding{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "rbac.authorization.k8s.io/v1",
+			Kind:       "ClusterRoleBinding",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: apiServerContainerDiskDigestsName,
+			Labels: map[string]string{
+				virtv1.AppLabel: "",
+			},
+		},
+		RoleRef: rbacv1.RoleRef{
+			APIGroup: "rbac.authorization.k8s.io",
+			Kind:     "ClusterRole",
+			Name:     apiServerContainerDiskDigestsName,
+		},
+		Subjects: []rbacv1.Subject{
+			{
+				Kind:      "ServiceAccount",
+				Namespace: namespace,
+				Name:      ApiServiceAccountName,
+			},
+		},
+	}
+}
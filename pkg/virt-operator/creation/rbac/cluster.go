@@ -63,6 +63,18 @@ func newDefaultClusterRole() *rbacv1.ClusterRole {
 					"get", "list",
 				},
 			},
+			{
+				APIGroups: []string{
+					"subresources.kubevirt.io",
+				},
+				Resources: []string{
+					// novnc connections are authorized by a single-use token from vnc/token in virt-api
+					"virtualmachineinstances/novnc",
+				},
+				Verbs: []string{
+					"get",
+				},
+			},
 		},
 	}
 }
@@ -122,14 +134,45 @@ func newAdminClusterRole() *rbacv1.ClusterRole {
 				},
 				Resources: []string{
 					"virtualmachineinstances/console",
+					"virtualmachineinstances/consolelog",
 					"virtualmachineinstances/vnc",
+					"virtualmachineinstances/screenshot",
 					"virtualmachineinstances/pause",
 					"virtualmachineinstances/unpause",
+					"virtualmachineinstances/guestosinfo",
+					"virtualmachineinstances/userlist",
+					"virtualmachineinstances/filesystemlist",
 				},
 				Verbs: []string{
 					"get",
 				},
 			},
+			{
+				APIGroups: []string{
+					"subresources.kubevirt.io",
+				},
+				Resources: []string{
+					"virtualmachineinstances/console",
+					"virtualmachineinstances/vnc",
+				},
+				Verbs: []string{
+					"view",
+				},
+			},
+			{
+				APIGroups: []string{
+					"subresources.kubevirt.io",
+				},
+				Resources: []string{
+					"virtualmachineinstances/usbredir",
+					"virtualmachineinstances/portforward",
+					"virtualmachineinstances/softreboot",
+					"virtualmachineinstances/guest-exec",
+				},
+				Verbs: []string{
+					"update",
+				},
+			},
 			{
 				APIGroups: []string{
 					"subresources.kubevirt.io",
@@ -138,6 +181,8 @@ func newAdminClusterRole() *rbacv1.ClusterRole {
 					"virtualmachines/start",
 					"virtualmachines/stop",
 					"virtualmachines/restart",
+					"virtualmachines/memorydump",
+					"virtualmachines/removememorydump",
 				},
 				Verbs: []string{
 					"update",
@@ -153,6 +198,8 @@ func newAdminClusterRole() *rbacv1.ClusterRole {
 					"virtualmachineinstancepresets",
 					"virtualmachineinstancereplicasets",
 					"virtualmachineinstancemigrations",
+					"virtualmachinebackups",
+					"virtualmachineexports",
 				},
 				Verbs: []string{
 					"get", "delete", "create", "update", "patch", "list", "watch", "deletecollection",
@@ -182,14 +229,44 @@ func newEditClusterRole() *rbacv1.ClusterRole {
 				},
 				Resources: []string{
 					"virtualmachineinstances/console",
+					"virtualmachineinstances/consolelog",
 					"virtualmachineinstances/vnc",
+					"virtualmachineinstances/screenshot",
 					"virtualmachineinstances/pause",
 					"virtualmachineinstances/unpause",
+					"virtualmachineinstances/guestosinfo",
+					"virtualmachineinstances/userlist",
+					"virtualmachineinstances/filesystemlist",
 				},
 				Verbs: []string{
 					"get",
 				},
 			},
+			{
+				APIGroups: []string{
+					"subresources.kubevirt.io",
+				},
+				Resources: []string{
+					"virtualmachineinstances/console",
+					"virtualmachineinstances/vnc",
+				},
+				Verbs: []string{
+					"view",
+				},
+			},
+			{
+				APIGroups: []string{
+					"subresources.kubevirt.io",
+				},
+				Resources: []string{
+					"virtualmachineinstances/usbredir",
+					"virtualmachineinstances/portforward",
+					"virtualmachineinstances/softreboot",
+				},
+				Verbs: []string{
+					"update",
+				},
+			},
 			{
 				APIGroups: []string{
 					"subresources.kubevirt.io",
@@ -198,6 +275,8 @@ func newEditClusterRole() *rbacv1.ClusterRole {
 					"virtualmachines/start",
 					"virtualmachines/stop",
 					"virtualmachines/restart",
+					"virtualmachines/memorydump",
+					"virtualmachines/removememorydump",
 				},
 				Verbs: []string{
 					"update",
@@ -213,6 +292,8 @@ func newEditClusterRole() *rbacv1.ClusterRole {
 					"virtualmachineinstancepresets",
 					"virtualmachineinstancereplicasets",
 					"virtualmachineinstancemigrations",
+					"virtualmachinebackups",
+					"virtualmachineexports",
 				},
 				Verbs: []string{
 					"get", "delete", "create", "update", "patch", "list", "watch",
@@ -236,6 +317,20 @@ func newViewClusterRole() *rbacv1.ClusterRole {
 			},
 		},
 		Rules: []rbacv1.PolicyRule{
+			{
+				APIGroups: []string{
+					"subresources.kubevirt.io",
+				},
+				Resources: []string{
+					"virtualmachineinstances/consolelog",
+					"virtualmachineinstances/guestosinfo",
+					"virtualmachineinstances/userlist",
+					"virtualmachineinstances/filesystemlist",
+				},
+				Verbs: []string{
+					"get",
+				},
+			},
 			{
 				APIGroups: []string{
 					"kubevirt.io",
@@ -246,6 +341,8 @@ func newViewClusterRole() *rbacv1.ClusterRole {
 					"virtualmachineinstancepresets",
 					"virtualmachineinstancereplicasets",
 					"virtualmachineinstancemigrations",
+					"virtualmachinebackups",
+					"virtualmachineexports",
 				},
 				Verbs: []string{
 					"get", "list", "watch",
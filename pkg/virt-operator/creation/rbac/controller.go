@@ -33,6 +33,8 @@ func GetAllController(namespace string) []interface{} {
 		newControllerServiceAccount(namespace),
 		newControllerClusterRole(),
 		newControllerClusterRoleBinding(namespace),
+		newControllerRole(namespace),
+		newControllerRoleBinding(namespace),
 	}
 }
 
@@ -131,6 +133,28 @@ func newControllerClusterRole() *rbacv1.ClusterRole {
 					"get", "list", "watch",
 				},
 			},
+			{
+				APIGroups: []string{
+					"",
+				},
+				Resources: []string{
+					"services", "secrets",
+				},
+				Verbs: []string{
+					"get", "create", "delete",
+				},
+			},
+			{
+				APIGroups: []string{
+					"extensions",
+				},
+				Resources: []string{
+					"ingresses",
+				},
+				Verbs: []string{
+					"get", "create", "delete",
+				},
+			},
 			{
 				APIGroups: []string{
 					"kubevirt.io",
@@ -177,6 +201,19 @@ func newControllerClusterRole() *rbacv1.ClusterRole {
 					"watch",
 				},
 			},
+			{
+				APIGroups: []string{
+					"kubevirt.io",
+				},
+				Resources: []string{
+					"kubevirts",
+				},
+				Verbs: []string{
+					"get",
+					"list",
+					"watch",
+				},
+			},
 			{
 				APIGroups: []string{
 					"authorization.k8s.io",
@@ -218,3 +255,60 @@ func newControllerClusterRoleBinding(namespace string) *rbacv1.ClusterRoleBindin
 		},
 	}
 }
+
+func newControllerRole(namespace string) *rbacv1.Role {
+	return &rbacv1.Role{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "rbac.authorization.k8s.io/v1",
+			Kind:       "Role",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Name:      ControllerServiceAccountName,
+			Labels: map[string]string{
+				virtv1.AppLabel: "",
+			},
+		},
+		Rules: []rbacv1.PolicyRule{
+			{
+				APIGroups: []string{
+					"apps",
+				},
+				Resources: []string{
+					"daemonsets",
+				},
+				Verbs: []string{
+					"get", "list", "watch", "create", "update", "delete",
+				},
+			},
+		},
+	}
+}
+
+func newControllerRoleBinding(namespace string) *rbacv1.RoleBinding {
+	return &rbacv1.RoleBinding{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "rbac.authorization.k8s.io/v1",
+			Kind:       "RoleBinding",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Name:      ControllerServiceAccountName,
+			Labels: map[string]string{
+				virtv1.AppLabel: "",
+			},
+		},
+		RoleRef: rbacv1.RoleRef{
+			APIGroup: "rbac.authorization.k8s.io",
+			Kind:     "Role",
+			Name:     ControllerServiceAccountName,
+		},
+		Subjects: []rbacv1.Subject{
+			{
+				Kind:      "ServiceAccount",
+				Namespace: namespace,
+				Name:      ControllerServiceAccountName,
+			},
+		},
+	}
+}
@@ -93,6 +93,7 @@ func NewOperatorClusterRole() *rbacv1.ClusterRole {
 					"serviceaccounts",
 					"services",
 					"endpoints",
+					"secrets",
 					// pods/exec is required for testing upgrades - that can be removed when we stop
 					// supporting upgrades from versions in which virt-api required pods/exec privileges
 					"pods/exec",
@@ -288,6 +289,8 @@ func getKubeVirtComponentsRules() []rbacv1.PolicyRule {
 
 	// namespace doesn't matter, we are only interested in the rules of both Roles and ClusterRoles
 	all := GetAllApiServer("")
+	// the operator has to be able to grant the optional rules as well
+	all = append(all, GetAllApiServerContainerDiskDigests("")...)
 	all = append(all, GetAllController("")...)
 	all = append(all, GetAllHandler("")...)
 	all = append(all, GetAllCluster("")...)
@@ -0,0 +1,328 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2020 Red Hat, Inc.
+ *
+ */
+
+package installstrategy
+
+import (
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/client-go/kubecli"
+	"kubevirt.io/client-go/log"
+	"kubevirt.io/kubevirt/pkg/certificates/bootstrap"
+	"kubevirt.io/kubevirt/pkg/certificates/triple"
+	"kubevirt.io/kubevirt/pkg/controller"
+	"kubevirt.io/kubevirt/pkg/virt-operator/creation/components"
+	"kubevirt.io/kubevirt/pkg/virt-operator/util"
+)
+
+const (
+	defaultCADuration          = 7 * 24 * time.Hour
+	defaultCARenewBefore       = defaultCADuration / 2
+	defaultCertificateDuration = 24 * time.Hour
+	defaultCertRenewBefore     = defaultCertificateDuration / 2
+
+	// caPropagationInterval is how long a new CA is only trusted before it
+	// signs certificates. It covers the kubelet sync of the mounted secrets
+	// and the resync of the certificate managers in the components.
+	caPropagationInterval = 10 * time.Minute
+)
+
+type certificateRotationConfig struct {
+	caDuration      time.Duration
+	caRenewBefore   time.Duration
+	certDuration    time.Duration
+	certRenewBefore time.Duration
+}
+
+func getCertificateRotationConfig(kv *v1.KubeVirt) (*certificateRotationConfig, error) {
+	config := &certificateRotationConfig{
+		caDuration:      defaultCADuration,
+		caRenewBefore:   defaultCARenewBefore,
+		certDuration:    defaultCertificateDuration,
+		certRenewBefore: defaultCertRenewBefore,
+	}
+
+	selfSigned := kv.Spec.CertificateRotationStrategy.SelfSigned
+	if selfSigned != nil {
+		if selfSigned.CA != nil {
+			if selfSigned.CA.Duration != nil {
+				config.caDuration = selfSigned.CA.Duration.Duration
+			}
+			if selfSigned.CA.RenewBefore != nil {
+				config.caRenewBefore = selfSigned.CA.RenewBefore.Duration
+			}
+		}
+		if selfSigned.Server != nil {
+			if selfSigned.Server.Duration != nil {
+				config.certDuration = selfSigned.Server.Duration.Duration
+			}
+			if selfSigned.Server.RenewBefore != nil {
+				config.certRenewBefore = selfSigned.Server.RenewBefore.Duration
+			}
+		}
+	}
+
+	if config.caRenewBefore >= config.caDuration {
+		return nil, fmt.Errorf("the CA renewBefore (%s) has to be shorter than its duration (%s)", config.caRenewBefore, config.caDuration)
+	}
+	if config.caRenewBefore <= caPropagationInterval {
+		return nil, fmt.Errorf("the CA renewBefore (%s) has to be longer than the CA propagation interval (%s)", config.caRenewBefore, caPropagationInterval)
+	}
+	if config.certRenewBefore >= config.certDuration {
+		return nil, fmt.Errorf("the certificate renewBefore (%s) has to be shorter than its duration (%s)", config.certRenewBefore, config.certDuration)
+	}
+	if config.certDuration > config.caDuration {
+		return nil, fmt.Errorf("the certificate duration (%s) must not exceed the CA duration (%s)", config.certDuration, config.caDuration)
+	}
+	return config, nil
+}
+
+func getCachedSecret(stores util.Stores, secret *corev1.Secret) (*corev1.Secret, bool) {
+	obj, exists, _ := stores.SecretCache.Get(secret)
+	if !exists {
+		return nil, false
+	}
+	return obj.(*corev1.Secret), true
+}
+
+func createOrUpdateSecret(kv *v1.KubeVirt,
+	secret *corev1.Secret,
+	exists bool,
+	clientset kubecli.KubevirtClient,
+	expectations *util.Expectations) error {
+
+	kvkey, err := controller.KeyFunc(kv)
+	if err != nil {
+		return err
+	}
+
+	injectOperatorMetadata(kv, &secret.ObjectMeta, kv.Status.TargetKubeVirtVersion, kv.Status.TargetKubeVirtRegistry, kv.Status.TargetDeploymentID)
+
+	if exists {
+		_, err := clientset.CoreV1().Secrets(secret.Namespace).Update(secret)
+		if err != nil {
+			return fmt.Errorf("unable to update secret %+v: %v", secret.Name, err)
+		}
+		log.Log.V(2).Infof("secret %v updated", secret.GetName())
+		return nil
+	}
+
+	expectations.Secrets.RaiseExpectations(kvkey, 1, 0)
+	_, err = clientset.CoreV1().Secrets(secret.Namespace).Create(secret)
+	if errors.IsAlreadyExists(err) {
+		// earlier versions let the components create their secrets
+		// themselves, take them over
+		expectations.Secrets.LowerExpectations(kvkey, 1, 0)
+		_, err = clientset.CoreV1().Secrets(secret.Namespace).Update(secret)
+		if err != nil {
+			return fmt.Errorf("unable to take over secret %+v: %v", secret.Name, err)
+		}
+		log.Log.V(2).Infof("secret %v taken over", secret.GetName())
+		return nil
+	} else if err != nil {
+		expectations.Secrets.LowerExpectations(kvkey, 1, 0)
+		return fmt.Errorf("unable to create secret %+v: %v", secret.Name, err)
+	}
+	log.Log.V(2).Infof("secret %v created", secret.GetName())
+	return nil
+}
+
+// syncCASecret makes sure that a valid CA exists and rotates it once it
+// reaches its renewal deadline. A rotation first only publishes the new CA in
+// the bundle and keeps signing with the old one. The new CA is activated once
+// the bundle had caPropagationInterval to reach all components. It returns
+// the current content of the CA secret.
+func syncCASecret(kv *v1.KubeVirt,
+	config *certificateRotationConfig,
+	stores util.Stores,
+	clientset kubecli.KubevirtClient,
+	expectations *util.Expectations) (*corev1.Secret, error) {
+
+	secret := components.NewCACertSecret(kv.Namespace)
+	cachedSecret, exists := getCachedSecret(stores, secret)
+	if exists {
+		if publishedAt, ok := components.NextCAPublishedAt(cachedSecret); ok {
+			if time.Now().Before(publishedAt.Add(caPropagationInterval)) {
+				return cachedSecret, nil
+			}
+			log.Log.Infof("activating the new KubeVirt CA in secret %s", secret.Name)
+			secret = cachedSecret.DeepCopy()
+			components.ActivateNextCA(secret)
+			if err := createOrUpdateSecret(kv, secret, exists, clientset, expectations); err != nil {
+				return nil, err
+			}
+			return secret, nil
+		}
+
+		if time.Now().Before(components.NextRotationDeadline(cachedSecret, nil, config.caRenewBefore)) {
+			return cachedSecret, nil
+		}
+		secret = cachedSecret.DeepCopy()
+
+		// a broken CA can't sign anything anymore, replace it right away
+		if _, err := components.LoadCertificate(cachedSecret); err == nil {
+			log.Log.Infof("publishing a new KubeVirt CA in secret %s", secret.Name)
+			if err := components.PublishNextCA(secret, config.caDuration, time.Now()); err != nil {
+				return nil, err
+			}
+			if err := createOrUpdateSecret(kv, secret, exists, clientset, expectations); err != nil {
+				return nil, err
+			}
+			return secret, nil
+		}
+		log.Log.Infof("rotating the KubeVirt CA in secret %s", secret.Name)
+	}
+
+	if err := components.PopulateCASecret(secret, config.caDuration); err != nil {
+		return nil, err
+	}
+	if err := createOrUpdateSecret(kv, secret, exists, clientset, expectations); err != nil {
+		return nil, err
+	}
+	return secret, nil
+}
+
+// syncComponentSecret issues a new certificate for a component if it has none
+// yet, if it reached its renewal deadline or if it was not signed by the active
+// CA. Otherwise only the CA bundle of the secret is kept up to date. A CA which
+// is only published in the bundle does not sign certificates yet.
+func syncComponentSecret(kv *v1.KubeVirt,
+	secret *corev1.Secret,
+	ca *triple.KeyPair,
+	caBundle []byte,
+	config *certificateRotationConfig,
+	stores util.Stores,
+	clientset kubecli.KubevirtClient,
+	expectations *util.Expectations) error {
+
+	cachedSecret, exists := getCachedSecret(stores, secret)
+	if exists {
+		if time.Now().Before(components.NextRotationDeadline(cachedSecret, ca, config.certRenewBefore)) {
+			if !components.CABundleChanged(cachedSecret, caBundle) {
+				log.Log.V(4).Infof("secret %v is up-to-date", secret.GetName())
+				return nil
+			}
+			secret = cachedSecret.DeepCopy()
+			secret.Data[bootstrap.CABundleKey] = caBundle
+			return createOrUpdateSecret(kv, secret, exists, clientset, expectations)
+		}
+		log.Log.Infof("rotating the certificate in secret %s", secret.Name)
+		secret = cachedSecret.DeepCopy()
+	}
+
+	if err := components.PopulateSecretWithCertificate(secret, ca, caBundle, config.certDuration); err != nil {
+		return err
+	}
+	return createOrUpdateSecret(kv, secret, exists, clientset, expectations)
+}
+
+// syncVNCTokenSecret makes sure that the key virt-api signs VNC tokens with
+// exists. The key is independent of any certificate and never rotated.
+func syncVNCTokenSecret(kv *v1.KubeVirt,
+	stores util.Stores,
+	clientset kubecli.KubevirtClient,
+	expectations *util.Expectations) error {
+
+	secret := components.NewVNCTokenSecret(kv.Namespace)
+	cachedSecret, exists := getCachedSecret(stores, secret)
+	if exists {
+		if components.HasVNCTokenKey(cachedSecret) {
+			return nil
+		}
+		secret = cachedSecret.DeepCopy()
+	}
+
+	if err := components.PopulateVNCTokenSecret(secret); err != nil {
+		return err
+	}
+	return createOrUpdateSecret(kv, secret, exists, clientset, expectations)
+}
+
+func createOrUpdateCertificateSecrets(kv *v1.KubeVirt,
+	stores util.Stores,
+	clientset kubecli.KubevirtClient,
+	expectations *util.Expectations) error {
+
+	config, err := getCertificateRotationConfig(kv)
+	if err != nil {
+		return err
+	}
+
+	caSecret, err := syncCASecret(kv, config, stores, clientset, expectations)
+	if err != nil {
+		return err
+	}
+	ca, err := components.LoadCertificate(caSecret)
+	if err != nil {
+		return err
+	}
+	caBundle := caSecret.Data[bootstrap.CABundleKey]
+
+	for _, secret := range components.NewCertSecrets(kv.Namespace) {
+		err := syncComponentSecret(kv, secret, ca, caBundle, config, stores, clientset, expectations)
+		if err != nil {
+			return err
+		}
+	}
+	return syncVNCTokenSecret(kv, stores, clientset, expectations)
+}
+
+// NextCertificateRotation returns the duration after which the next
+// certificate managed by virt-operator has to be rotated
+func NextCertificateRotation(kv *v1.KubeVirt, stores util.Stores) (time.Duration, bool) {
+	config, err := getCertificateRotationConfig(kv)
+	if err != nil {
+		return 0, false
+	}
+
+	caSecret, exists := getCachedSecret(stores, components.NewCACertSecret(kv.Namespace))
+	if !exists {
+		return 0, false
+	}
+	next := components.NextRotationDeadline(caSecret, nil, config.caRenewBefore)
+	if publishedAt, ok := components.NextCAPublishedAt(caSecret); ok {
+		next = publishedAt.Add(caPropagationInterval)
+	}
+
+	ca, err := components.LoadCertificate(caSecret)
+	if err != nil {
+		return 0, false
+	}
+	for _, secret := range components.NewCertSecrets(kv.Namespace) {
+		cachedSecret, exists := getCachedSecret(stores, secret)
+		if !exists {
+			return 0, false
+		}
+		deadline := components.NextRotationDeadline(cachedSecret, ca, config.certRenewBefore)
+		if deadline.Before(next) {
+			next = deadline
+		}
+	}
+
+	wait := time.Until(next)
+	if wait < 0 {
+		wait = 0
+	}
+	return wait, true
+}
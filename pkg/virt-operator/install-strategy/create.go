@@ -24,6 +24,7 @@ import (
 	"fmt"
 	"reflect"
 	"strings"
+	"time"
 
 	promv1 "github.com/coreos/prometheus-operator/pkg/apis/monitoring/v1"
 	secv1 "github.com/openshift/api/security/v1"
@@ -402,7 +403,7 @@ func createDummyWebhookValidator(targetStrategy *InstallStrategy,
 
 	// Set some fake signing cert bytes in for each rule so the k8s apiserver will
 	// allow us to create the webhook.
-	caKeyPair, _ := triple.NewCA("fake.kubevirt.io")
+	caKeyPair, _ := triple.NewCA("fake.kubevirt.io", time.Hour)
 	signingCertBytes := cert.EncodeCertPEM(caKeyPair.Cert)
 	for _, webhook := range webhooks {
 		webhook.ClientConfig.CABundle = signingCertBytes
@@ -1564,6 +1565,11 @@ func createOrUpdateSCC(kv *v1.KubeVirt,
 
 func syncPodDisruptionBudgetForDeployment(deployment *appsv1.Deployment, clientset kubecli.KubevirtClient, kv *v1.KubeVirt, expectations *util.Expectations, stores util.Stores) error {
 	podDisruptionBudget := components.NewPodDisruptionBudgetForDeployment(deployment)
+	patched, err := applyPatches(podDisruptionBudget, kv.Spec.CustomizeComponents.Patches, "PodDisruptionBudget", podDisruptionBudget.Name)
+	if err != nil {
+		return err
+	}
+	podDisruptionBudget = patched.(*policyv1beta1.PodDisruptionBudget)
 
 	imageTag := kv.Status.TargetKubeVirtVersion
 	imageRegistry := kv.Status.TargetKubeVirtRegistry
@@ -1580,12 +1586,31 @@ func syncPodDisruptionBudgetForDeployment(deployment *appsv1.Deployment, clients
 		cachedPodDisruptionBudget = obj.(*policyv1beta1.PodDisruptionBudget)
 	}
 
-	if !exists {
-		kvkey, err := controller.KeyFunc(kv)
-		if err != nil {
-			return err
+	kvkey, err := controller.KeyFunc(kv)
+	if err != nil {
+		return err
+	}
+
+	// a single replica can never be evicted while at least one has to stay
+	// available, which would block draining its node
+	if deployment.Spec.Replicas != nil && *deployment.Spec.Replicas < 2 {
+		if exists && cachedPodDisruptionBudget.DeletionTimestamp == nil {
+			key, err := controller.KeyFunc(cachedPodDisruptionBudget)
+			if err != nil {
+				return err
+			}
+			expectations.PodDisruptionBudget.AddExpectedDeletion(kvkey, key)
+			err = pdbClient.Delete(cachedPodDisruptionBudget.Name, &metav1.DeleteOptions{})
+			if err != nil {
+				expectations.PodDisruptionBudget.DeletionObserved(kvkey, key)
+				return fmt.Errorf("unable to delete poddisruptionbudget %+v: %v", cachedPodDisruptionBudget, err)
+			}
+			log.Log.V(2).Infof("poddisruptionbudget %v deleted", cachedPodDisruptionBudget.GetName())
 		}
+		return nil
+	}
 
+	if !exists {
 		expectations.PodDisruptionBudget.RaiseExpectations(kvkey, 1, 0)
 		_, err = pdbClient.Create(podDisruptionBudget)
 		if err != nil {
@@ -1640,6 +1665,12 @@ func SyncAll(kv *v1.KubeVirt,
 		GracePeriodSeconds: &gracePeriod,
 	}
 
+	// apply the patches of the KubeVirt CR before anything gets created or updated
+	targetStrategy, err = customizeInstallStrategy(targetStrategy, kv.Spec.CustomizeComponents)
+	if err != nil {
+		return false, err
+	}
+
 	targetVersion := kv.Status.TargetKubeVirtVersion
 	targetImageRegistry := kv.Status.TargetKubeVirtRegistry
 	observedVersion := kv.Status.ObservedKubeVirtVersion
@@ -1693,6 +1724,12 @@ func SyncAll(kv *v1.KubeVirt,
 		return false, err
 	}
 
+	// create/rotate the certificates of the KubeVirt components
+	err = createOrUpdateCertificateSecrets(kv, stores, clientset, expectations)
+	if err != nil {
+		return false, err
+	}
+
 	// backup any old RBAC rules that don't match current version
 	if !infrastructureRolledOver {
 		err = backupRbac(kv,
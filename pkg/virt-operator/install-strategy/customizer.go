@@ -0,0 +1,190 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2020 Red Hat, Inc.
+ *
+ */
+
+package installstrategy
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	promv1 "github.com/coreos/prometheus-operator/pkg/apis/monitoring/v1"
+	jsonpatch "github.com/evanphx/json-patch"
+	secv1 "github.com/openshift/api/security/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	extv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
+	"k8s.io/apimachinery/pkg/util/strategicpatch"
+
+	v1 "kubevirt.io/client-go/api/v1"
+)
+
+// patchesForResource returns the patches which target the resource of the given kind and name
+func patchesForResource(patches []v1.CustomizeComponentsPatch, resourceType string, resourceName string) []v1.CustomizeComponentsPatch {
+	var result []v1.CustomizeComponentsPatch
+	for _, patch := range patches {
+		if patch.ResourceType != resourceType {
+			continue
+		}
+		if patch.ResourceName == "*" || patch.ResourceName == resourceName {
+			result = append(result, patch)
+		}
+	}
+	return result
+}
+
+// applyPatch applies a single patch to the json representation of obj,
+// obj is only used to look up the strategic merge patch metadata
+func applyPatch(data []byte, patch v1.CustomizeComponentsPatch, obj interface{}) ([]byte, error) {
+	switch patch.Type {
+	case v1.JSONPatchType:
+		jsonPatch, err := jsonpatch.DecodePatch([]byte(patch.Patch))
+		if err != nil {
+			return nil, err
+		}
+		return jsonPatch.Apply(data)
+	case v1.MergePatchType:
+		return jsonpatch.MergePatch(data, []byte(patch.Patch))
+	case v1.StrategicMergePatchType:
+		return strategicpatch.StrategicMergePatch(data, []byte(patch.Patch), obj)
+	default:
+		return nil, fmt.Errorf("unknown patch type %s", patch.Type)
+	}
+}
+
+// applyPatches returns a patched copy of obj, or obj itself if no patch
+// targets it. obj has to be a pointer to a resource struct.
+func applyPatches(obj interface{}, patches []v1.CustomizeComponentsPatch, resourceType string, resourceName string) (interface{}, error) {
+	patches = patchesForResource(patches, resourceType, resourceName)
+	if len(patches) == 0 {
+		return obj, nil
+	}
+
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return nil, err
+	}
+	for _, patch := range patches {
+		data, err = applyPatch(data, patch, obj)
+		if err != nil {
+			return nil, fmt.Errorf("unable to apply %s patch to %s %s: %v", patch.Type, resourceType, resourceName, err)
+		}
+	}
+
+	patched := reflect.New(reflect.TypeOf(obj).Elem()).Interface()
+	if err := json.Unmarshal(data, patched); err != nil {
+		return nil, fmt.Errorf("unable to apply patches to %s %s: %v", resourceType, resourceName, err)
+	}
+	return patched, nil
+}
+
+// customizeInstallStrategy applies the patches of the KubeVirt CR to the
+// resources of a strategy. Install strategies are cached and shared, so the
+// patched resources end up in a copy of the strategy.
+func customizeInstallStrategy(strategy *InstallStrategy, customizations v1.CustomizeComponents) (*InstallStrategy, error) {
+	patches := customizations.Patches
+	if len(patches) == 0 {
+		return strategy, nil
+	}
+
+	customized := &InstallStrategy{
+		customSCCPrivileges: strategy.customSCCPrivileges,
+	}
+
+	for _, obj := range strategy.serviceAccounts {
+		patched, err := applyPatches(obj, patches, "ServiceAccount", obj.Name)
+		if err != nil {
+			return nil, err
+		}
+		customized.serviceAccounts = append(customized.serviceAccounts, patched.(*corev1.ServiceAccount))
+	}
+	for _, obj := range strategy.clusterRoles {
+		patched, err := applyPatches(obj, patches, "ClusterRole", obj.Name)
+		if err != nil {
+			return nil, err
+		}
+		customized.clusterRoles = append(customized.clusterRoles, patched.(*rbacv1.ClusterRole))
+	}
+	for _, obj := range strategy.clusterRoleBindings {
+		patched, err := applyPatches(obj, patches, "ClusterRoleBinding", obj.Name)
+		if err != nil {
+			return nil, err
+		}
+		customized.clusterRoleBindings = append(customized.clusterRoleBindings, patched.(*rbacv1.ClusterRoleBinding))
+	}
+	for _, obj := range strategy.roles {
+		patched, err := applyPatches(obj, patches, "Role", obj.Name)
+		if err != nil {
+			return nil, err
+		}
+		customized.roles = append(customized.roles, patched.(*rbacv1.Role))
+	}
+	for _, obj := range strategy.roleBindings {
+		patched, err := applyPatches(obj, patches, "RoleBinding", obj.Name)
+		if err != nil {
+			return nil, err
+		}
+		customized.roleBindings = append(customized.roleBindings, patched.(*rbacv1.RoleBinding))
+	}
+	for _, obj := range strategy.crds {
+		patched, err := applyPatches(obj, patches, "CustomResourceDefinition", obj.Name)
+		if err != nil {
+			return nil, err
+		}
+		customized.crds = append(customized.crds, patched.(*extv1beta1.CustomResourceDefinition))
+	}
+	for _, obj := range strategy.services {
+		patched, err := applyPatches(obj, patches, "Service", obj.Name)
+		if err != nil {
+			return nil, err
+		}
+		customized.services = append(customized.services, patched.(*corev1.Service))
+	}
+	for _, obj := range strategy.deployments {
+		patched, err := applyPatches(obj, patches, "Deployment", obj.Name)
+		if err != nil {
+			return nil, err
+		}
+		customized.deployments = append(customized.deployments, patched.(*appsv1.Deployment))
+	}
+	for _, obj := range strategy.daemonSets {
+		patched, err := applyPatches(obj, patches, "DaemonSet", obj.Name)
+		if err != nil {
+			return nil, err
+		}
+		customized.daemonSets = append(customized.daemonSets, patched.(*appsv1.DaemonSet))
+	}
+	for _, obj := range strategy.sccs {
+		patched, err := applyPatches(obj, patches, "SecurityContextConstraints", obj.Name)
+		if err != nil {
+			return nil, err
+		}
+		customized.sccs = append(customized.sccs, patched.(*secv1.SecurityContextConstraints))
+	}
+	for _, obj := range strategy.serviceMonitors {
+		patched, err := applyPatches(obj, patches, "ServiceMonitor", obj.Name)
+		if err != nil {
+			return nil, err
+		}
+		customized.serviceMonitors = append(customized.serviceMonitors, patched.(*promv1.ServiceMonitor))
+	}
+
+	return customized, nil
+}
@@ -0,0 +1,194 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2020 Red Hat, Inc.
+ *
+ */
+
+package installstrategy
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/kubevirt/pkg/virt-operator/util"
+)
+
+var _ = Describe("Customizer", func() {
+
+	var strategy *InstallStrategy
+
+	BeforeEach(func() {
+		var err error
+		strategy, err = GenerateCurrentInstallStrategy(util.GetTargetConfigFromKV(&v1.KubeVirt{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "fake-namespace",
+			},
+			Spec: v1.KubeVirtSpec{
+				ImageRegistry: "fake-registry",
+				ImageTag:      "v9.9.9",
+			},
+		}), true)
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	getDeployment := func(strategy *InstallStrategy, name string) *appsv1.Deployment {
+		for _, deployment := range strategy.deployments {
+			if deployment.Name == name {
+				return deployment
+			}
+		}
+		Fail("deployment " + name + " not found")
+		return nil
+	}
+
+	getService := func(strategy *InstallStrategy, name string) *corev1.Service {
+		for _, service := range strategy.services {
+			if service.Name == name {
+				return service
+			}
+		}
+		Fail("service " + name + " not found")
+		return nil
+	}
+
+	It("should return the strategy itself without patches", func() {
+		customized, err := customizeInstallStrategy(strategy, v1.CustomizeComponents{})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(customized).To(BeIdenticalTo(strategy))
+	})
+
+	It("should apply a json patch", func() {
+		customized, err := customizeInstallStrategy(strategy, v1.CustomizeComponents{
+			Patches: []v1.CustomizeComponentsPatch{
+				{
+					ResourceType: "Deployment",
+					ResourceName: "virt-api",
+					Type:         v1.JSONPatchType,
+					Patch:        `[{"op": "replace", "path": "/spec/replicas", "value": 5}]`,
+				},
+			},
+		})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(*getDeployment(customized, "virt-api").Spec.Replicas).To(Equal(int32(5)))
+		Expect(*getDeployment(customized, "virt-controller").Spec.Replicas).To(Equal(int32(2)))
+	})
+
+	It("should apply a merge patch", func() {
+		customized, err := customizeInstallStrategy(strategy, v1.CustomizeComponents{
+			Patches: []v1.CustomizeComponentsPatch{
+				{
+					ResourceType: "Service",
+					ResourceName: "virt-api",
+					Type:         v1.MergePatchType,
+					Patch:        `{"metadata": {"annotations": {"example.com/test": "true"}}}`,
+				},
+			},
+		})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(getService(customized, "virt-api").Annotations).To(HaveKeyWithValue("example.com/test", "true"))
+	})
+
+	It("should apply a patch to all resources of a kind", func() {
+		customized, err := customizeInstallStrategy(strategy, v1.CustomizeComponents{
+			Patches: []v1.CustomizeComponentsPatch{
+				{
+					ResourceType: "Deployment",
+					ResourceName: "*",
+					Type:         v1.StrategicMergePatchType,
+					Patch:        `{"metadata": {"labels": {"example.com/test": "true"}}}`,
+				},
+			},
+		})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(customized.deployments).To(HaveLen(len(strategy.deployments)))
+		for _, deployment := range customized.deployments {
+			Expect(deployment.Labels).To(HaveKeyWithValue("example.com/test", "true"))
+			Expect(deployment.Labels).To(HaveKeyWithValue(v1.AppLabel, deployment.Name))
+		}
+		for _, daemonSet := range customized.daemonSets {
+			Expect(daemonSet.Labels).ToNot(HaveKey("example.com/test"))
+		}
+	})
+
+	It("should merge containers by name in a strategic merge patch", func() {
+		customized, err := customizeInstallStrategy(strategy, v1.CustomizeComponents{
+			Patches: []v1.CustomizeComponentsPatch{
+				{
+					ResourceType: "DaemonSet",
+					ResourceName: "virt-handler",
+					Type:         v1.StrategicMergePatchType,
+					Patch:        `{"spec": {"template": {"spec": {"containers": [{"name": "virt-handler", "resources": {"limits": {"memory": "1Gi"}}}]}}}}`,
+				},
+			},
+		})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(customized.daemonSets).To(HaveLen(1))
+		container := customized.daemonSets[0].Spec.Template.Spec.Containers[0]
+		Expect(container.Name).To(Equal("virt-handler"))
+		Expect(container.Image).To(Equal(strategy.daemonSets[0].Spec.Template.Spec.Containers[0].Image))
+		memory := container.Resources.Limits[corev1.ResourceMemory]
+		Expect(memory.Cmp(resource.MustParse("1Gi"))).To(Equal(0))
+	})
+
+	It("should not modify the original strategy", func() {
+		_, err := customizeInstallStrategy(strategy, v1.CustomizeComponents{
+			Patches: []v1.CustomizeComponentsPatch{
+				{
+					ResourceType: "Deployment",
+					ResourceName: "virt-api",
+					Type:         v1.JSONPatchType,
+					Patch:        `[{"op": "replace", "path": "/spec/replicas", "value": 5}]`,
+				},
+			},
+		})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(*getDeployment(strategy, "virt-api").Spec.Replicas).To(Equal(int32(2)))
+	})
+
+	It("should fail on a patch which does not apply", func() {
+		_, err := customizeInstallStrategy(strategy, v1.CustomizeComponents{
+			Patches: []v1.CustomizeComponentsPatch{
+				{
+					ResourceType: "Deployment",
+					ResourceName: "virt-api",
+					Type:         v1.JSONPatchType,
+					Patch:        `[{"op": "replace", "path": "/spec/doesNotExist/value", "value": 5}]`,
+				},
+			},
+		})
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should fail on an unknown patch type", func() {
+		_, err := customizeInstallStrategy(strategy, v1.CustomizeComponents{
+			Patches: []v1.CustomizeComponentsPatch{
+				{
+					ResourceType: "Deployment",
+					ResourceName: "virt-api",
+					Type:         "unknown",
+					Patch:        `{}`,
+				},
+			},
+		})
+		Expect(err).To(HaveOccurred())
+	})
+})
@@ -217,6 +217,25 @@ func DeleteAll(kv *v1.KubeVirt,
 		}
 	}
 
+	// delete certificate secrets
+	objects = stores.SecretCache.List()
+	for _, obj := range objects {
+		if secret, ok := obj.(*corev1.Secret); ok && secret.DeletionTimestamp == nil {
+			if key, err := controller.KeyFunc(secret); err == nil {
+				expectations.Secrets.AddExpectedDeletion(kvkey, key)
+				err := clientset.CoreV1().Secrets(secret.Namespace).Delete(secret.Name, deleteOptions)
+				if err != nil {
+					expectations.Secrets.DeletionObserved(kvkey, key)
+					log.Log.Errorf("Failed to delete secret %+v: %v", secret.Name, err)
+					return err
+				}
+			}
+		} else if !ok {
+			log.Log.Errorf("Cast failed! obj: %+v", obj)
+			return nil
+		}
+	}
+
 	// delete RBAC
 	objects = stores.ClusterRoleBindingCache.List()
 	for _, obj := range objects {
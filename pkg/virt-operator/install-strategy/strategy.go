@@ -201,10 +201,16 @@ func GenerateCurrentInstallStrategy(config *operatorutil.KubeVirtDeploymentConfi
 	strategy.crds = append(strategy.crds, components.NewReplicaSetCrd())
 	strategy.crds = append(strategy.crds, components.NewVirtualMachineCrd())
 	strategy.crds = append(strategy.crds, components.NewVirtualMachineInstanceMigrationCrd())
+	strategy.crds = append(strategy.crds, components.NewContainerDiskImageCacheCrd())
+	strategy.crds = append(strategy.crds, components.NewVirtualMachineBackupCrd())
+	strategy.crds = append(strategy.crds, components.NewVirtualMachineExportCrd())
 
 	rbaclist := make([]interface{}, 0)
 	rbaclist = append(rbaclist, rbac.GetAllCluster(config.GetNamespace())...)
 	rbaclist = append(rbaclist, rbac.GetAllApiServer(config.GetNamespace())...)
+	if config.ContainerDiskDigestsEnabled() {
+		rbaclist = append(rbaclist, rbac.GetAllApiServerContainerDiskDigests(config.GetNamespace())...)
+	}
 	rbaclist = append(rbaclist, rbac.GetAllController(config.GetNamespace())...)
 	rbaclist = append(rbaclist, rbac.GetAllHandler(config.GetNamespace())...)
 
@@ -244,24 +250,39 @@ func GenerateCurrentInstallStrategy(config *operatorutil.KubeVirtDeploymentConfi
 		}
 	}
 
+	infra, err := config.GetInfraComponentConfig()
+	if err != nil {
+		return nil, err
+	}
+	workloads, err := config.GetWorkloadsComponentConfig()
+	if err != nil {
+		return nil, err
+	}
+
 	strategy.services = append(strategy.services, components.NewPrometheusService(config.GetNamespace()))
 	strategy.services = append(strategy.services, components.NewApiServerService(config.GetNamespace()))
 	apiDeployment, err := components.NewApiServerDeployment(config.GetNamespace(), config.GetImageRegistry(), config.GetImagePrefix(), config.GetApiVersion(), config.GetImagePullPolicy(), config.GetVerbosity())
 	if err != nil {
 		return nil, fmt.Errorf("error generating virt-apiserver deployment %v", err)
 	}
+	injectInfraComponentConfig(infra, apiDeployment)
 	strategy.deployments = append(strategy.deployments, apiDeployment)
 
 	controller, err := components.NewControllerDeployment(config.GetNamespace(), config.GetImageRegistry(), config.GetImagePrefix(), config.GetControllerVersion(), config.GetLauncherVersion(), config.GetImagePullPolicy(), config.GetVerbosity())
 	if err != nil {
 		return nil, fmt.Errorf("error generating virt-controller deployment %v", err)
 	}
+	injectInfraComponentConfig(infra, controller)
 	strategy.deployments = append(strategy.deployments, controller)
 
 	handler, err := components.NewHandlerDaemonSet(config.GetNamespace(), config.GetImageRegistry(), config.GetImagePrefix(), config.GetHandlerVersion(), config.GetImagePullPolicy(), config.GetVerbosity())
 	if err != nil {
 		return nil, fmt.Errorf("error generating virt-handler deployment %v", err)
 	}
+	if config.PersistentReservationEnabled() {
+		components.InjectPersistentReservationHelper(handler, config.GetImageRegistry(), config.GetImagePrefix(), config.GetLauncherVersion(), config.GetImagePullPolicy())
+	}
+	components.InjectPlacementMetadata(workloads, &handler.Spec.Template.Spec)
 	strategy.daemonSets = append(strategy.daemonSets, handler)
 
 	prefix := "system:serviceaccount"
@@ -301,6 +322,17 @@ func mostRecentConfigMap(configMaps []*corev1.ConfigMap) *corev1.ConfigMap {
 	return configMap
 }
 
+func injectInfraComponentConfig(infra *v1.ComponentConfig, deployment *appsv1.Deployment) {
+	if infra == nil {
+		return
+	}
+	components.InjectPlacementMetadata(infra, &deployment.Spec.Template.Spec)
+	if infra.Replicas != nil {
+		replicas := int32(*infra.Replicas)
+		deployment.Spec.Replicas = &replicas
+	}
+}
+
 func LoadInstallStrategyFromCache(stores util.Stores, config *operatorutil.KubeVirtDeploymentConfig) (*InstallStrategy, error) {
 	var configMap *corev1.ConfigMap
 	var matchingConfigMaps []*corev1.ConfigMap
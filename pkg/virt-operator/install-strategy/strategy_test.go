@@ -161,6 +161,136 @@ var _ = Describe("Install Strategy", func() {
 		})
 	})
 
+	Context("should apply the component placement", func() {
+
+		getStrategy := func(infra, workloads *v1.ComponentConfig) *InstallStrategy {
+			strategy, err := GenerateCurrentInstallStrategy(util.GetTargetConfigFromKV(&v1.KubeVirt{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: namespace,
+				},
+				Spec: v1.KubeVirtSpec{
+					ImageRegistry: "fake-registry",
+					ImageTag:      "v9.9.9",
+					Infra:         infra,
+					Workloads:     workloads,
+				},
+			}), true)
+			Expect(err).ToNot(HaveOccurred())
+			return strategy
+		}
+
+		placement := &v1.NodePlacement{
+			NodeSelector: map[string]string{"node-role": "test"},
+			Affinity: &corev1.Affinity{
+				NodeAffinity: &corev1.NodeAffinity{
+					RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+						NodeSelectorTerms: []corev1.NodeSelectorTerm{
+							{
+								MatchExpressions: []corev1.NodeSelectorRequirement{
+									{Key: "zone", Operator: corev1.NodeSelectorOpIn, Values: []string{"a"}},
+								},
+							},
+						},
+					},
+				},
+			},
+			Tolerations: []corev1.Toleration{{Key: "test", Operator: corev1.TolerationOpExists}},
+		}
+
+		expectPlacement := func(pod *corev1.PodSpec) {
+			Expect(pod.NodeSelector).To(Equal(placement.NodeSelector))
+			Expect(pod.Tolerations).To(Equal(placement.Tolerations))
+			Expect(pod.Affinity.NodeAffinity).To(Equal(placement.Affinity.NodeAffinity))
+		}
+
+		It("of the infra components to virt-api and virt-controller", func() {
+			replicas := uint8(3)
+			strategy := getStrategy(&v1.ComponentConfig{NodePlacement: placement, Replicas: &replicas}, nil)
+
+			Expect(strategy.deployments).To(HaveLen(2))
+			for _, deployment := range strategy.deployments {
+				expectPlacement(&deployment.Spec.Template.Spec)
+				// the default anti affinity stays in place
+				Expect(deployment.Spec.Template.Spec.Affinity.PodAntiAffinity).ToNot(BeNil())
+				Expect(*deployment.Spec.Replicas).To(Equal(int32(3)))
+			}
+			Expect(strategy.daemonSets[0].Spec.Template.Spec.NodeSelector).To(BeEmpty())
+			Expect(strategy.daemonSets[0].Spec.Template.Spec.Affinity).To(BeNil())
+		})
+
+		It("of the workload components to virt-handler", func() {
+			strategy := getStrategy(nil, &v1.ComponentConfig{NodePlacement: placement})
+
+			Expect(strategy.daemonSets).To(HaveLen(1))
+			expectPlacement(&strategy.daemonSets[0].Spec.Template.Spec)
+			for _, deployment := range strategy.deployments {
+				Expect(deployment.Spec.Template.Spec.NodeSelector).To(BeEmpty())
+				Expect(*deployment.Spec.Replicas).To(Equal(int32(2)))
+			}
+		})
+
+		It("and keep it through the byte conversion", func() {
+			strategy := getStrategy(&v1.ComponentConfig{NodePlacement: placement}, &v1.ComponentConfig{NodePlacement: placement})
+
+			newStrategy, err := loadInstallStrategyFromBytes(string(dumpInstallStrategyToBytes(strategy)))
+			Expect(err).ToNot(HaveOccurred())
+			expectPlacement(&newStrategy.daemonSets[0].Spec.Template.Spec)
+			for _, deployment := range newStrategy.deployments {
+				expectPlacement(&deployment.Spec.Template.Spec)
+			}
+		})
+	})
+
+	Context("should grant virt-api access to secrets", func() {
+
+		hasSecretsRule := func(strategy *InstallStrategy) bool {
+			for _, cr := range strategy.clusterRoles {
+				if !strings.HasPrefix(cr.Name, "kubevirt-apiserver") {
+					continue
+				}
+				for _, rule := range cr.Rules {
+					for _, resource := range rule.Resources {
+						if resource == "secrets" {
+							return true
+						}
+					}
+				}
+			}
+			return false
+		}
+
+		getStrategy := func(featureGates ...string) *InstallStrategy {
+			strategy, err := GenerateCurrentInstallStrategy(util.GetTargetConfigFromKV(&v1.KubeVirt{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: namespace,
+				},
+				Spec: v1.KubeVirtSpec{
+					Configuration: v1.KubeVirtConfiguration{
+						DeveloperConfiguration: &v1.DeveloperConfiguration{FeatureGates: featureGates},
+					},
+				},
+			}), true)
+			Expect(err).ToNot(HaveOccurred())
+			return strategy
+		}
+
+		It("only with the ContainerDiskDigests feature gate", func() {
+			Expect(hasSecretsRule(getStrategy())).To(BeFalse())
+
+			strategy := getStrategy("ContainerDiskDigests")
+			Expect(hasSecretsRule(strategy)).To(BeTrue())
+			found := false
+			for _, crb := range strategy.clusterRoleBindings {
+				if crb.RoleRef.Name == "kubevirt-apiserver-container-disk-digests" {
+					found = true
+					Expect(crb.Subjects[0].Name).To(Equal("kubevirt-apiserver"))
+					Expect(crb.Subjects[0].Namespace).To(Equal(namespace))
+				}
+			}
+			Expect(found).To(BeTrue())
+		})
+	})
+
 	Context("should calculate", func() {
 
 		table.DescribeTable("update path based on semver", func(target string, current string, expected bool) {
@@ -38,6 +38,7 @@ import (
 	"kubevirt.io/client-go/kubecli"
 	"kubevirt.io/client-go/log"
 	"kubevirt.io/kubevirt/pkg/controller"
+	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
 	"kubevirt.io/kubevirt/pkg/virt-operator/creation/components"
 	installstrategy "kubevirt.io/kubevirt/pkg/virt-operator/install-strategy"
 	"kubevirt.io/kubevirt/pkg/virt-operator/util"
@@ -88,6 +89,7 @@ func NewKubeVirtController(
 			InstallStrategyJob:       controller.NewUIDTrackingControllerExpectations(controller.NewControllerExpectationsWithName("Jobs")),
 			PodDisruptionBudget:      controller.NewUIDTrackingControllerExpectations(controller.NewControllerExpectationsWithName("PodDisruptionBudgets")),
 			ServiceMonitor:           controller.NewUIDTrackingControllerExpectations(controller.NewControllerExpectationsWithName("ServiceMonitor")),
+			Secrets:                  controller.NewUIDTrackingControllerExpectations(controller.NewControllerExpectationsWithName("Secrets")),
 		},
 		installStrategyMap: make(map[string]*installstrategy.InstallStrategy),
 		operatorNamespace:  operatorNamespace,
@@ -291,6 +293,18 @@ func NewKubeVirtController(
 		},
 	})
 
+	c.informers.Secrets.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			c.genericAddHandler(obj, c.kubeVirtExpectations.Secrets)
+		},
+		DeleteFunc: func(obj interface{}) {
+			c.genericDeleteHandler(obj, c.kubeVirtExpectations.Secrets)
+		},
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			c.genericUpdateHandler(oldObj, newObj, c.kubeVirtExpectations.Secrets)
+		},
+	})
+
 	return &c
 }
 
@@ -459,6 +473,7 @@ func (c *KubeVirtController) Run(threadiness int, stopCh <-chan struct{}) {
 	cache.WaitForCacheSync(stopCh, c.informers.InfrastructurePod.HasSynced)
 	cache.WaitForCacheSync(stopCh, c.informers.PodDisruptionBudget.HasSynced)
 	cache.WaitForCacheSync(stopCh, c.informers.ServiceMonitor.HasSynced)
+	cache.WaitForCacheSync(stopCh, c.informers.Secrets.HasSynced)
 
 	// Start the actual work
 	for i := 0; i < threadiness; i++ {
@@ -877,6 +892,15 @@ func (c *KubeVirtController) syncDeployment(kv *v1.KubeVirt) error {
 		return nil
 	}
 
+	// the components read spec.configuration themselves and stick with
+	// the last valid one, make invalid configurations visible
+	if err := virtconfig.ConfigurationError(&kv.Spec.Configuration); err != nil {
+		logger.Reason(err).Warning("Invalid KubeVirt configuration")
+		util.UpdateConditionsConfigurationInvalid(kv, err)
+	} else {
+		util.UpdateConditionsConfigurationValid(kv)
+	}
+
 	config := operatorutil.GetTargetConfigFromKV(kv)
 
 	// Record current operator version to status section
@@ -943,6 +967,13 @@ func (c *KubeVirtController) syncDeployment(kv *v1.KubeVirt) error {
 		util.UpdateConditionsCreated(kv)
 		logger.Info("All KubeVirt resources created")
 
+		// come back once the next certificate has to be rotated
+		if wait, ok := installstrategy.NextCertificateRotation(kv, c.stores); ok {
+			if kvkey, err := controller.KeyFunc(kv); err == nil {
+				c.queue.AddAfter(kvkey, wait)
+			}
+		}
+
 		// check if components are ready
 		if c.isReady(kv) {
 			logger.Info("All KubeVirt components ready")
@@ -51,11 +51,13 @@ import (
 	"k8s.io/client-go/tools/cache"
 	framework "k8s.io/client-go/tools/cache/testing"
 	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/cert"
 
 	v1 "kubevirt.io/client-go/api/v1"
 	"kubevirt.io/client-go/kubecli"
 	"kubevirt.io/client-go/log"
 	"kubevirt.io/client-go/version"
+	"kubevirt.io/kubevirt/pkg/certificates/bootstrap"
 	kubecontroller "kubevirt.io/kubevirt/pkg/controller"
 	"kubevirt.io/kubevirt/pkg/testutils"
 	"kubevirt.io/kubevirt/pkg/virt-operator/creation/components"
@@ -95,6 +97,7 @@ var _ = Describe("KubeVirt Operator", func() {
 	var infrastructurePodSource *framework.FakeControllerSource
 	var podDisruptionBudgetSource *framework.FakeControllerSource
 	var serviceMonitorSource *framework.FakeControllerSource
+	var secretsSource *framework.FakeControllerSource
 
 	var stop chan struct{}
 	var controller *KubeVirtController
@@ -131,9 +134,12 @@ var _ = Describe("KubeVirt Operator", func() {
 	var totalDeletions int
 	var resourceChanges map[string]map[string]int
 
-	resourceCount := 36
-	patchCount := 16
-	updateCount := 20
+	resourceCount := 41
+	patchCount := 19
+	updateCount := 22
+	// the certificate secrets are not part of the install strategy and
+	// therefore not updated along with the KubeVirt version
+	secretCount := 6
 
 	deleteFromCache := true
 	addToCache := true
@@ -156,6 +162,7 @@ var _ = Describe("KubeVirt Operator", func() {
 		go informers.InfrastructurePod.Run(stop)
 		go informers.PodDisruptionBudget.Run(stop)
 		go informers.ServiceMonitor.Run(stop)
+		go informers.Secrets.Run(stop)
 
 		Expect(cache.WaitForCacheSync(stop, kvInformer.HasSynced)).To(BeTrue())
 
@@ -175,6 +182,7 @@ var _ = Describe("KubeVirt Operator", func() {
 		cache.WaitForCacheSync(stop, informers.InfrastructurePod.HasSynced)
 		cache.WaitForCacheSync(stop, informers.PodDisruptionBudget.HasSynced)
 		cache.WaitForCacheSync(stop, informers.ServiceMonitor.HasSynced)
+		cache.WaitForCacheSync(stop, informers.Secrets.HasSynced)
 	}
 
 	getSCC := func() secv1.SecurityContextConstraints {
@@ -263,6 +271,9 @@ var _ = Describe("KubeVirt Operator", func() {
 		stores.ServiceMonitorCache = informers.ServiceMonitor.GetStore()
 		stores.ServiceMonitorEnabled = true
 
+		informers.Secrets, secretsSource = testutils.NewFakeInformerFor(&k8sv1.Secret{})
+		stores.SecretCache = informers.Secrets.GetStore()
+
 		controller = NewKubeVirtController(virtClient, kvInformer, recorder, stores, informers, NAMESPACE)
 
 		// Wrap our workqueue to have a way to detect when we are done processing updates
@@ -444,6 +455,12 @@ var _ = Describe("KubeVirt Operator", func() {
 		mockQueue.Wait()
 	}
 
+	addSecret := func(secret *k8sv1.Secret) {
+		mockQueue.ExpectAdds(1)
+		secretsSource.Add(secret)
+		mockQueue.Wait()
+	}
+
 	addResource := func(obj runtime.Object, config *util.KubeVirtDeploymentConfig) {
 		switch resource := obj.(type) {
 		case *k8sv1.ServiceAccount:
@@ -494,6 +511,9 @@ var _ = Describe("KubeVirt Operator", func() {
 		case *promv1.ServiceMonitor:
 			injectMetadata(&obj.(*promv1.ServiceMonitor).ObjectMeta, config)
 			addServiceMonitor(resource)
+		case *k8sv1.Secret:
+			injectMetadata(&obj.(*k8sv1.Secret).ObjectMeta, config)
+			addSecret(resource)
 		default:
 			Fail("unknown resource type")
 		}
@@ -740,6 +760,9 @@ var _ = Describe("KubeVirt Operator", func() {
 		all = append(all, components.NewReplicaSetCrd())
 		all = append(all, components.NewVirtualMachineCrd())
 		all = append(all, components.NewVirtualMachineInstanceMigrationCrd())
+		all = append(all, components.NewContainerDiskImageCacheCrd())
+		all = append(all, components.NewVirtualMachineBackupCrd())
+		all = append(all, components.NewVirtualMachineExportCrd())
 		// sccs
 		all = append(all, components.NewKubeVirtControllerSCC(NAMESPACE))
 		all = append(all, components.NewKubeVirtHandlerSCC(NAMESPACE))
@@ -756,6 +779,20 @@ var _ = Describe("KubeVirt Operator", func() {
 		all = append(all, rbac.GetAllServiceMonitor(NAMESPACE, config.GetMonitorNamespace(), config.GetMonitorServiceAccount())...)
 		all = append(all, components.NewServiceMonitorCR(NAMESPACE, config.GetMonitorNamespace(), true))
 
+		// certificates
+		caSecret := components.NewCACertSecret(NAMESPACE)
+		Expect(components.PopulateCASecret(caSecret, 7*24*time.Hour)).To(Succeed())
+		ca, err := components.LoadCertificate(caSecret)
+		Expect(err).ToNot(HaveOccurred())
+		all = append(all, caSecret)
+		for _, secret := range components.NewCertSecrets(NAMESPACE) {
+			Expect(components.PopulateSecretWithCertificate(secret, ca, caSecret.Data[bootstrap.CABundleKey], 24*time.Hour)).To(Succeed())
+			all = append(all, secret)
+		}
+		vncTokenSecret := components.NewVNCTokenSecret(NAMESPACE)
+		Expect(components.PopulateVNCTokenSecret(vncTokenSecret)).To(Succeed())
+		all = append(all, vncTokenSecret)
+
 		for _, obj := range all {
 			if resource, ok := obj.(runtime.Object); ok {
 				addResource(resource, config)
@@ -954,6 +991,14 @@ var _ = Describe("KubeVirt Operator", func() {
 		mockQueue.Wait()
 	}
 
+	deleteSecret := func(key string) {
+		mockQueue.ExpectAdds(1)
+		if obj, exists, _ := informers.Secrets.GetStore().GetByKey(key); exists {
+			secretsSource.Delete(obj.(runtime.Object))
+		}
+		mockQueue.Wait()
+	}
+
 	deleteResource := func(resource string, key string) {
 		switch resource {
 		case "serviceaccounts":
@@ -986,6 +1031,8 @@ var _ = Describe("KubeVirt Operator", func() {
 			deleteSCC(key)
 		case "servicemonitors":
 			deleteServiceMonitor(key)
+		case "secrets":
+			deleteSecret(key)
 		default:
 			Fail(fmt.Sprintf("unknown resource type %+v", resource))
 		}
@@ -1092,6 +1139,7 @@ var _ = Describe("KubeVirt Operator", func() {
 		kubeClient.Fake.PrependReactor("delete", "poddisruptionbudgets", genericDeleteFunc)
 		secClient.Fake.PrependReactor("delete", "securitycontextconstraints", genericDeleteFunc)
 		promClient.Fake.PrependReactor("delete", "servicemonitors", genericDeleteFunc)
+		kubeClient.Fake.PrependReactor("delete", "secrets", genericDeleteFunc)
 	}
 
 	shouldExpectJobDeletion := func() {
@@ -1146,6 +1194,7 @@ var _ = Describe("KubeVirt Operator", func() {
 		kubeClient.Fake.PrependReactor("create", "poddisruptionbudgets", genericCreateFunc)
 		secClient.Fake.PrependReactor("create", "securitycontextconstraints", genericCreateFunc)
 		promClient.Fake.PrependReactor("create", "servicemonitors", genericCreateFunc)
+		kubeClient.Fake.PrependReactor("create", "secrets", genericCreateFunc)
 	}
 
 	shouldExpectKubeVirtUpdate := func(times int) {
@@ -1341,6 +1390,175 @@ var _ = Describe("KubeVirt Operator", func() {
 
 		}, 15)
 
+		It("should rotate certificates which were not signed by the current CA", func(done Done) {
+			defer close(done)
+
+			kv := &v1.KubeVirt{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:       "test-install",
+					Namespace:  NAMESPACE,
+					Finalizers: []string{util.KubeVirtFinalizer},
+				},
+				Status: v1.KubeVirtStatus{
+					Phase:           v1.KubeVirtPhaseDeployed,
+					OperatorVersion: version.Get().String(),
+				},
+			}
+			defaultConfig.SetTargetDeploymentConfig(kv)
+			defaultConfig.SetObservedDeploymentConfig(kv)
+			util.UpdateConditionsCreated(kv)
+			util.UpdateConditionsAvailable(kv)
+
+			// create all resources which should already exist
+			kubecontroller.SetLatestApiVersionAnnotation(kv)
+			addKubeVirt(kv)
+			addInstallStrategy(defaultConfig)
+			addAll(defaultConfig)
+			addPodsAndPodDisruptionBudgets(defaultConfig)
+			makeApiAndControllerReady()
+			makeHandlerReady()
+
+			// replace the virt-handler server certificate with one of a foreign CA
+			foreignCASecret := components.NewCACertSecret(NAMESPACE)
+			Expect(components.PopulateCASecret(foreignCASecret, time.Hour)).To(Succeed())
+			foreignCA, err := components.LoadCertificate(foreignCASecret)
+			Expect(err).ToNot(HaveOccurred())
+			obj, exists, _ := stores.SecretCache.GetByKey(NAMESPACE + "/" + components.VirtHandlerServerCertSecretName)
+			Expect(exists).To(BeTrue())
+			secret := obj.(*k8sv1.Secret).DeepCopy()
+			Expect(components.PopulateSecretWithCertificate(secret, foreignCA, foreignCASecret.Data[bootstrap.CABundleKey], time.Hour)).To(Succeed())
+			mockQueue.ExpectAdds(1)
+			secretsSource.Modify(secret)
+			mockQueue.Wait()
+
+			obj, exists, _ = stores.SecretCache.GetByKey(NAMESPACE + "/" + components.KubeVirtCASecretName)
+			Expect(exists).To(BeTrue())
+			ca, err := components.LoadCertificate(obj.(*k8sv1.Secret))
+			Expect(err).ToNot(HaveOccurred())
+
+			kubeClient.Fake.PrependReactor("update", "secrets", func(action testing.Action) (handled bool, obj runtime.Object, err error) {
+				update, ok := action.(testing.UpdateAction)
+				Expect(ok).To(BeTrue())
+				updated := update.GetObject().(*k8sv1.Secret)
+				Expect(updated.Name).To(Equal(components.VirtHandlerServerCertSecretName))
+				Expect(components.NextRotationDeadline(updated, ca, time.Hour).After(time.Now())).To(BeTrue())
+				totalUpdates++
+				return true, updated, nil
+			})
+
+			controller.Execute()
+			Expect(totalUpdates).To(Equal(1))
+		}, 15)
+
+		Context("when the CA reaches its renewal deadline", func() {
+
+			var kv *v1.KubeVirt
+
+			BeforeEach(func() {
+				kv = &v1.KubeVirt{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:       "test-install",
+						Namespace:  NAMESPACE,
+						Finalizers: []string{util.KubeVirtFinalizer},
+					},
+					Status: v1.KubeVirtStatus{
+						Phase:           v1.KubeVirtPhaseDeployed,
+						OperatorVersion: version.Get().String(),
+					},
+				}
+				defaultConfig.SetTargetDeploymentConfig(kv)
+				defaultConfig.SetObservedDeploymentConfig(kv)
+				util.UpdateConditionsCreated(kv)
+				util.UpdateConditionsAvailable(kv)
+
+				// create all resources which should already exist
+				kubecontroller.SetLatestApiVersionAnnotation(kv)
+				addKubeVirt(kv)
+				addInstallStrategy(defaultConfig)
+				addAll(defaultConfig)
+				addPodsAndPodDisruptionBudgets(defaultConfig)
+				makeApiAndControllerReady()
+				makeHandlerReady()
+			})
+
+			replaceCASecret := func(caSecret *k8sv1.Secret) {
+				mockQueue.ExpectAdds(1)
+				secretsSource.Modify(caSecret)
+				mockQueue.Wait()
+			}
+
+			expectSignedBy := func(secret *k8sv1.Secret, ca *k8sv1.Secret) {
+				caKeyPair, err := components.LoadCertificate(ca)
+				Expect(err).ToNot(HaveOccurred())
+				keyPair, err := components.LoadCertificate(secret)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(keyPair.Cert.CheckSignatureFrom(caKeyPair.Cert)).To(Succeed())
+			}
+
+			It("should publish the new CA in the bundle but keep signing with the old one", func(done Done) {
+				defer close(done)
+
+				// a CA which expires within its renewal window
+				oldCASecret := components.NewCACertSecret(NAMESPACE)
+				Expect(components.PopulateCASecret(oldCASecret, time.Hour)).To(Succeed())
+				replaceCASecret(oldCASecret)
+
+				kubeClient.Fake.PrependReactor("update", "secrets", func(action testing.Action) (handled bool, obj runtime.Object, err error) {
+					update, ok := action.(testing.UpdateAction)
+					Expect(ok).To(BeTrue())
+					updated := update.GetObject().(*k8sv1.Secret)
+					if updated.Name == components.KubeVirtCASecretName {
+						Expect(updated.Data[k8sv1.TLSCertKey]).To(Equal(oldCASecret.Data[k8sv1.TLSCertKey]))
+						_, published := components.NextCAPublishedAt(updated)
+						Expect(published).To(BeTrue())
+						bundle, err := cert.ParseCertsPEM(updated.Data[bootstrap.CABundleKey])
+						Expect(err).ToNot(HaveOccurred())
+						Expect(bundle).To(HaveLen(2))
+					} else {
+						expectSignedBy(updated, oldCASecret)
+					}
+					totalUpdates++
+					return true, updated, nil
+				})
+
+				controller.Execute()
+				// the CA and the certificates of all four components
+				Expect(totalUpdates).To(Equal(5))
+			}, 15)
+
+			It("should sign with the new CA once it was published for the propagation interval", func(done Done) {
+				defer close(done)
+
+				obj, exists, _ := stores.SecretCache.GetByKey(NAMESPACE + "/" + components.KubeVirtCASecretName)
+				Expect(exists).To(BeTrue())
+				caSecret := obj.(*k8sv1.Secret).DeepCopy()
+				Expect(components.PublishNextCA(caSecret, 7*24*time.Hour, time.Now().Add(-time.Hour))).To(Succeed())
+				replaceCASecret(caSecret)
+
+				var activatedCASecret *k8sv1.Secret
+				kubeClient.Fake.PrependReactor("update", "secrets", func(action testing.Action) (handled bool, obj runtime.Object, err error) {
+					update, ok := action.(testing.UpdateAction)
+					Expect(ok).To(BeTrue())
+					updated := update.GetObject().(*k8sv1.Secret)
+					if updated.Name == components.KubeVirtCASecretName {
+						Expect(updated.Data[k8sv1.TLSCertKey]).ToNot(Equal(caSecret.Data[k8sv1.TLSCertKey]))
+						Expect(updated.Data[bootstrap.CABundleKey]).To(Equal(caSecret.Data[bootstrap.CABundleKey]))
+						_, published := components.NextCAPublishedAt(updated)
+						Expect(published).To(BeFalse())
+						activatedCASecret = updated
+					} else {
+						Expect(activatedCASecret).ToNot(BeNil())
+						expectSignedBy(updated, activatedCASecret)
+					}
+					totalUpdates++
+					return true, updated, nil
+				})
+
+				controller.Execute()
+				Expect(totalUpdates).To(Equal(5))
+			}, 15)
+		})
+
 		It("should delete operator managed resources not in the deployed installstrategy", func() {
 			defer GinkgoRecover()
 			kv := &v1.KubeVirt{
@@ -1572,14 +1790,14 @@ var _ = Describe("KubeVirt Operator", func() {
 			// 1 because a temporary validation webhook is created to block new CRDs until api server is deployed
 			expectedTemporaryResources := 1
 
-			Expect(totalAdds).To(Equal(resourceCount - expectedUncreatedResources + expectedTemporaryResources))
+			Expect(totalAdds).To(Equal(resourceCount + secretCount - expectedUncreatedResources + expectedTemporaryResources))
 
 			Expect(len(controller.stores.ServiceAccountCache.List())).To(Equal(3))
 			Expect(len(controller.stores.ClusterRoleCache.List())).To(Equal(7))
 			Expect(len(controller.stores.ClusterRoleBindingCache.List())).To(Equal(5))
-			Expect(len(controller.stores.RoleCache.List())).To(Equal(3))
-			Expect(len(controller.stores.RoleBindingCache.List())).To(Equal(3))
-			Expect(len(controller.stores.CrdCache.List())).To(Equal(5))
+			Expect(len(controller.stores.RoleCache.List())).To(Equal(4))
+			Expect(len(controller.stores.RoleBindingCache.List())).To(Equal(4))
+			Expect(len(controller.stores.CrdCache.List())).To(Equal(8))
 			Expect(len(controller.stores.ServiceCache.List())).To(Equal(2))
 			Expect(len(controller.stores.DeploymentCache.List())).To(Equal(1))
 			Expect(len(controller.stores.DaemonSetCache.List())).To(Equal(0))
@@ -1587,6 +1805,7 @@ var _ = Describe("KubeVirt Operator", func() {
 			Expect(len(controller.stores.PodDisruptionBudgetCache.List())).To(Equal(1))
 			Expect(len(controller.stores.SCCCache.List())).To(Equal(3))
 			Expect(len(controller.stores.ServiceMonitorCache.List())).To(Equal(1))
+			Expect(len(controller.stores.SecretCache.List())).To(Equal(secretCount))
 
 			Expect(resourceChanges["poddisruptionbudgets"][Added]).To(Equal(1))
 
@@ -1623,8 +1842,8 @@ var _ = Describe("KubeVirt Operator", func() {
 
 				controller.Execute()
 
-				Expect(len(controller.stores.RoleCache.List())).To(Equal(2))
-				Expect(len(controller.stores.RoleBindingCache.List())).To(Equal(2))
+				Expect(len(controller.stores.RoleCache.List())).To(Equal(3))
+				Expect(len(controller.stores.RoleBindingCache.List())).To(Equal(3))
 				Expect(len(controller.stores.ServiceMonitorCache.List())).To(Equal(0))
 			}, 15)
 		})
@@ -1950,7 +2169,7 @@ var _ = Describe("KubeVirt Operator", func() {
 			// Note: in real life during the first execution loop very probably only CRDs are deleted,
 			// because that takes some time (see the check that the crd store is empty before going on with deletions)
 			// But in this test the deletion succeeds immediately, so everything is deleted on first try
-			Expect(totalDeletions).To(Equal(resourceCount))
+			Expect(totalDeletions).To(Equal(resourceCount + secretCount))
 
 			kv = getLatestKubeVirt(kv)
 			Expect(kv.Status.Phase).To(Equal(v1.KubeVirtPhaseDeleted))
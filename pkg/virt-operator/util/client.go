@@ -45,6 +45,7 @@ const (
 	ConditionReasonDeploying                = "DeploymentInProgress"
 	ConditionReasonUpdating                 = "UpdateInProgress"
 	ConditionReasonDeleting                 = "DeletionInProgress"
+	ConditionReasonConfigurationInvalid     = "InvalidConfiguration"
 )
 
 func UpdateConditionsDeploying(kv *virtv1.KubeVirt) {
@@ -107,6 +108,15 @@ func UpdateConditionsDeletionFailed(kv *virtv1.KubeVirt, err error) {
 	updateCondition(kv, virtv1.KubeVirtConditionSynchronized, k8sv1.ConditionFalse, ConditionReasonDeletionFailedError, fmt.Sprintf("An error occurred during deletion: %v", err))
 }
 
+func UpdateConditionsConfigurationInvalid(kv *virtv1.KubeVirt, err error) {
+	msg := fmt.Sprintf("The components keep using the last valid configuration: %v", err)
+	updateCondition(kv, virtv1.KubeVirtConditionConfigurationValid, k8sv1.ConditionFalse, ConditionReasonConfigurationInvalid, msg)
+}
+
+func UpdateConditionsConfigurationValid(kv *virtv1.KubeVirt) {
+	removeCondition(kv, virtv1.KubeVirtConditionConfigurationValid)
+}
+
 func updateCondition(kv *virtv1.KubeVirt, conditionType virtv1.KubeVirtConditionType, status k8sv1.ConditionStatus, reason string, message string) {
 	condition, isNew := getCondition(kv, conditionType)
 	condition.Status = status
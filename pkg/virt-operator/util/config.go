@@ -34,6 +34,7 @@ import (
 
 	v1 "kubevirt.io/client-go/api/v1"
 	clientutil "kubevirt.io/client-go/util"
+	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
 )
 
 const (
@@ -53,6 +54,15 @@ const (
 
 	// these names need to match field names from KubeVirt Spec if they are set from there
 	AdditionalPropertiesNamePullPolicy = "ImagePullPolicy"
+	AdditionalPropertiesNameInfra      = "Infra"
+	AdditionalPropertiesNameWorkloads  = "Workloads"
+	AdditionalPropertiesNamePatches    = "CustomizeComponents"
+
+	// lookup key in AdditionalProperties, only set if the PersistentReservation feature gate is enabled
+	AdditionalPropertiesPersistentReservation = "PersistentReservation"
+
+	// lookup key in AdditionalProperties, only set if the ContainerDiskDigests feature gate is enabled
+	AdditionalPropertiesContainerDiskDigests = "ContainerDiskDigests"
 
 	// lookup key in AdditionalProperties
 	AdditionalPropertiesMonitorNamespace = "monitorNamespace"
@@ -140,12 +150,64 @@ func getKVMapFromSpec(spec v1.KubeVirtSpec) map[string]string {
 			// these are handled in the root deployment config already
 			continue
 		}
+		if name == AdditionalPropertiesNameInfra || name == AdditionalPropertiesNameWorkloads {
+			// the placement changes the generated install strategy, so its
+			// content has to be part of the deployment id
+			kvMap[name] = componentConfigToString(v.Field(i).Interface().(*v1.ComponentConfig))
+			continue
+		}
+		if name == AdditionalPropertiesNamePatches {
+			// the patches are applied when syncing the install strategy, only
+			// track them in the deployment id so that changes get reconciled
+			kvMap[name] = customizeComponentsHash(spec.CustomizeComponents)
+			continue
+		}
 		value := v.Field(i).String()
 		kvMap[name] = value
 	}
+	if featureGateEnabled(spec, virtconfig.PersistentReservationGate) {
+		// the privileged qemu-pr-helper is only deployed on request
+		kvMap[AdditionalPropertiesPersistentReservation] = "true"
+	}
+	if featureGateEnabled(spec, virtconfig.ContainerDiskDigestsGate) {
+		// virt-api only gets access to pull secrets on request
+		kvMap[AdditionalPropertiesContainerDiskDigests] = "true"
+	}
 	return kvMap
 }
 
+func featureGateEnabled(spec v1.KubeVirtSpec, featureGate string) bool {
+	if spec.Configuration.DeveloperConfiguration == nil {
+		return false
+	}
+	for _, fg := range spec.Configuration.DeveloperConfiguration.FeatureGates {
+		if fg == featureGate {
+			return true
+		}
+	}
+	return false
+}
+
+func customizeComponentsHash(customizations v1.CustomizeComponents) string {
+	if len(customizations.Patches) == 0 {
+		return ""
+	}
+	value, _ := json.Marshal(customizations.Patches)
+	hasher := sha1.New()
+	hasher.Write(value)
+	return hex.EncodeToString(hasher.Sum(nil))
+}
+
+func componentConfigToString(componentConfig *v1.ComponentConfig) string {
+	if componentConfig == nil {
+		return ""
+	}
+	// marshalling a struct of maps, slices and plain values never fails,
+	// and map keys are sorted, so the result is stable
+	value, _ := json.Marshal(componentConfig)
+	return string(value)
+}
+
 func getConfig(registry, tag, namespace string, additionalProperties map[string]string) *KubeVirtDeploymentConfig {
 
 	// get registry and tag/shasum from operator image
@@ -339,6 +401,36 @@ func (c *KubeVirtDeploymentConfig) GetImagePullPolicy() k8sv1.PullPolicy {
 	return k8sv1.PullIfNotPresent
 }
 
+func (c *KubeVirtDeploymentConfig) GetInfraComponentConfig() (*v1.ComponentConfig, error) {
+	return c.getComponentConfig(AdditionalPropertiesNameInfra)
+}
+
+func (c *KubeVirtDeploymentConfig) GetWorkloadsComponentConfig() (*v1.ComponentConfig, error) {
+	return c.getComponentConfig(AdditionalPropertiesNameWorkloads)
+}
+
+func (c *KubeVirtDeploymentConfig) getComponentConfig(name string) (*v1.ComponentConfig, error) {
+	value := c.AdditionalProperties[name]
+	if value == "" {
+		return nil, nil
+	}
+	componentConfig := &v1.ComponentConfig{}
+	if err := json.Unmarshal([]byte(value), componentConfig); err != nil {
+		return nil, fmt.Errorf("unable to parse %s component config: %v", name, err)
+	}
+	return componentConfig, nil
+}
+
+// PersistentReservationEnabled returns whether the virt-handler DaemonSet has to run the qemu-pr-helper
+func (c *KubeVirtDeploymentConfig) PersistentReservationEnabled() bool {
+	return c.AdditionalProperties[AdditionalPropertiesPersistentReservation] == "true"
+}
+
+// ContainerDiskDigestsEnabled returns whether virt-api has to be allowed to read image pull secrets
+func (c *KubeVirtDeploymentConfig) ContainerDiskDigestsEnabled() bool {
+	return c.AdditionalProperties[AdditionalPropertiesContainerDiskDigests] == "true"
+}
+
 func (c *KubeVirtDeploymentConfig) GetMonitorNamespace() string {
 	p, ok := c.AdditionalProperties[AdditionalPropertiesMonitorNamespace]
 	if !ok {
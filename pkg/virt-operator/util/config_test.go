@@ -26,6 +26,9 @@ import (
 	. "github.com/onsi/gomega"
 
 	k8sv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "kubevirt.io/client-go/api/v1"
 )
 
 var _ = Describe("Operator Config", func() {
@@ -147,4 +150,136 @@ var _ = Describe("Operator Config", func() {
 		})
 	})
 
+	Describe("Component placement from the KubeVirt CR", func() {
+
+		newKubeVirt := func() *v1.KubeVirt {
+			return &v1.KubeVirt{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "kubevirt",
+					Namespace: "kubevirt",
+				},
+			}
+		}
+
+		It("should not be set if the CR has no placement", func() {
+			config := GetTargetConfigFromKV(newKubeVirt())
+			infra, err := config.GetInfraComponentConfig()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(infra).To(BeNil())
+			workloads, err := config.GetWorkloadsComponentConfig()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(workloads).To(BeNil())
+		})
+
+		It("should survive the round trip through the config json", func() {
+			replicas := uint8(3)
+			kv := newKubeVirt()
+			kv.Spec.Infra = &v1.ComponentConfig{
+				NodePlacement: &v1.NodePlacement{
+					NodeSelector: map[string]string{"node-role": "infra"},
+				},
+				Replicas: &replicas,
+			}
+			kv.Spec.Workloads = &v1.ComponentConfig{
+				NodePlacement: &v1.NodePlacement{
+					Tolerations: []k8sv1.Toleration{{Key: "virtualization", Operator: k8sv1.TolerationOpExists}},
+				},
+			}
+
+			json, err := GetTargetConfigFromKV(kv).GetJson()
+			Expect(err).ToNot(HaveOccurred())
+			os.Setenv(TargetDeploymentConfig, json)
+			parsedConfig, err := GetConfigFromEnv()
+			Expect(err).ToNot(HaveOccurred())
+
+			infra, err := parsedConfig.GetInfraComponentConfig()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(infra).To(Equal(kv.Spec.Infra))
+			workloads, err := parsedConfig.GetWorkloadsComponentConfig()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(workloads).To(Equal(kv.Spec.Workloads))
+		})
+
+		It("should change the deployment id", func() {
+			kv := newKubeVirt()
+			id := GetTargetConfigFromKV(kv).GetDeploymentID()
+
+			kv.Spec.Workloads = &v1.ComponentConfig{
+				NodePlacement: &v1.NodePlacement{
+					NodeSelector: map[string]string{"node-role": "virtualization"},
+				},
+			}
+			Expect(GetTargetConfigFromKV(kv).GetDeploymentID()).ToNot(Equal(id))
+		})
+	})
+
+	Describe("Component patches from the KubeVirt CR", func() {
+
+		It("should change the deployment id", func() {
+			kv := &v1.KubeVirt{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "kubevirt",
+					Namespace: "kubevirt",
+				},
+			}
+			id := GetTargetConfigFromKV(kv).GetDeploymentID()
+
+			kv.Spec.CustomizeComponents.Patches = []v1.CustomizeComponentsPatch{
+				{
+					ResourceType: "Deployment",
+					ResourceName: "virt-api",
+					Type:         v1.MergePatchType,
+					Patch:        `{"metadata": {"annotations": {"test": "true"}}}`,
+				},
+			}
+			patchedID := GetTargetConfigFromKV(kv).GetDeploymentID()
+			Expect(patchedID).ToNot(Equal(id))
+
+			kv.Spec.CustomizeComponents.Patches[0].Patch = `{"metadata": {"annotations": {"test": "false"}}}`
+			Expect(GetTargetConfigFromKV(kv).GetDeploymentID()).ToNot(Equal(patchedID))
+		})
+	})
+
+	Describe("Persistent reservations from the KubeVirt CR", func() {
+
+		It("should only be enabled with the feature gate and change the deployment id", func() {
+			kv := &v1.KubeVirt{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "kubevirt",
+					Namespace: "kubevirt",
+				},
+			}
+			config := GetTargetConfigFromKV(kv)
+			Expect(config.PersistentReservationEnabled()).To(BeFalse())
+
+			kv.Spec.Configuration.DeveloperConfiguration = &v1.DeveloperConfiguration{
+				FeatureGates: []string{"PersistentReservation"},
+			}
+			enabledConfig := GetTargetConfigFromKV(kv)
+			Expect(enabledConfig.PersistentReservationEnabled()).To(BeTrue())
+			Expect(enabledConfig.GetDeploymentID()).ToNot(Equal(config.GetDeploymentID()))
+		})
+	})
+
+	Describe("ContainerDisk digests from the KubeVirt CR", func() {
+
+		It("should only be enabled with the feature gate and change the deployment id", func() {
+			kv := &v1.KubeVirt{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "kubevirt",
+					Namespace: "kubevirt",
+				},
+			}
+			config := GetTargetConfigFromKV(kv)
+			Expect(config.ContainerDiskDigestsEnabled()).To(BeFalse())
+
+			kv.Spec.Configuration.DeveloperConfiguration = &v1.DeveloperConfiguration{
+				FeatureGates: []string{"ContainerDiskDigests"},
+			}
+			enabledConfig := GetTargetConfigFromKV(kv)
+			Expect(enabledConfig.ContainerDiskDigestsEnabled()).To(BeTrue())
+			Expect(enabledConfig.GetDeploymentID()).ToNot(Equal(config.GetDeploymentID()))
+		})
+	})
+
 })
@@ -43,6 +43,7 @@ type Stores struct {
 	InfrastructurePodCache        cache.Store
 	PodDisruptionBudgetCache      cache.Store
 	ServiceMonitorCache           cache.Store
+	SecretCache                   cache.Store
 	IsOnOpenshift                 bool
 	ServiceMonitorEnabled         bool
 }
@@ -60,7 +61,8 @@ func (s *Stores) AllEmpty() bool {
 		IsStoreEmpty(s.ValidationWebhookCache) &&
 		IsStoreEmpty(s.PodDisruptionBudgetCache) &&
 		IsSCCStoreEmpty(s.SCCCache) &&
-		IsStoreEmpty(s.ServiceMonitorCache)
+		IsStoreEmpty(s.ServiceMonitorCache) &&
+		IsStoreEmpty(s.SecretCache)
 
 	// Don't add InstallStrategyConfigMapCache to this list. The install
 	// strategies persist even after deletion and updates.
@@ -103,6 +105,7 @@ type Expectations struct {
 	InstallStrategyJob       *controller.UIDTrackingControllerExpectations
 	PodDisruptionBudget      *controller.UIDTrackingControllerExpectations
 	ServiceMonitor           *controller.UIDTrackingControllerExpectations
+	Secrets                  *controller.UIDTrackingControllerExpectations
 }
 
 type Informers struct {
@@ -122,6 +125,7 @@ type Informers struct {
 	InfrastructurePod        cache.SharedIndexInformer
 	PodDisruptionBudget      cache.SharedIndexInformer
 	ServiceMonitor           cache.SharedIndexInformer
+	Secrets                  cache.SharedIndexInformer
 }
 
 func (e *Expectations) DeleteExpectations(key string) {
@@ -140,6 +144,7 @@ func (e *Expectations) DeleteExpectations(key string) {
 	e.InstallStrategyJob.DeleteExpectations(key)
 	e.PodDisruptionBudget.DeleteExpectations(key)
 	e.ServiceMonitor.DeleteExpectations(key)
+	e.Secrets.DeleteExpectations(key)
 }
 
 func (e *Expectations) ResetExpectations(key string) {
@@ -158,6 +163,7 @@ func (e *Expectations) ResetExpectations(key string) {
 	e.InstallStrategyJob.SetExpectations(key, 0, 0)
 	e.PodDisruptionBudget.SetExpectations(key, 0, 0)
 	e.ServiceMonitor.SetExpectations(key, 0, 0)
+	e.Secrets.SetExpectations(key, 0, 0)
 }
 
 func (e *Expectations) SatisfiedExpectations(key string) bool {
@@ -175,5 +181,6 @@ func (e *Expectations) SatisfiedExpectations(key string) bool {
 		e.InstallStrategyConfigMap.SatisfiedExpectations(key) &&
 		e.InstallStrategyJob.SatisfiedExpectations(key) &&
 		e.PodDisruptionBudget.SatisfiedExpectations(key) &&
-		e.ServiceMonitor.SatisfiedExpectations(key)
+		e.ServiceMonitor.SatisfiedExpectations(key) &&
+		e.Secrets.SatisfiedExpectations(key)
 }
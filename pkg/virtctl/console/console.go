@@ -36,6 +36,7 @@ import (
 )
 
 var timeout int
+var readOnly bool
 
 func NewCommand(clientConfig clientcmd.ClientConfig) *cobra.Command {
 	cmd := &cobra.Command{
@@ -50,6 +51,7 @@ func NewCommand(clientConfig clientcmd.ClientConfig) *cobra.Command {
 	}
 
 	cmd.Flags().IntVar(&timeout, "timeout", 5, "The number of minutes to wait for the virtual machine instance to be ready.")
+	cmd.Flags().BoolVar(&readOnly, "readonly", false, "Only watch the console output, without disconnecting other users. Input is discarded.")
 	cmd.SetUsageTemplate(templates.UsageTemplate())
 	return cmd
 }
@@ -62,7 +64,9 @@ func usage() string {
 	usage := `  # Connect to the console on VirtualMachineInstance 'myvmi':
   {{ProgramName}} console myvmi
   # Configure one minute timeout (default 5 minutes)
-  {{ProgramName}} console --timeout=1 myvmi`
+  {{ProgramName}} console --timeout=1 myvmi
+  # Watch the console of VirtualMachineInstance 'myvmi' while someone else is using it:
+  {{ProgramName}} console --readonly myvmi`
 
 	return usage
 }
@@ -97,7 +101,12 @@ func (c *Console) Run(cmd *cobra.Command, args []string) error {
 	signal.Notify(waitInterrupt, os.Interrupt)
 
 	go func() {
-		con, err := virtCli.VirtualMachineInstance(namespace).SerialConsole(vmi, time.Duration(timeout)*time.Minute)
+		var con kubecli.StreamInterface
+		if readOnly {
+			con, err = virtCli.VirtualMachineInstance(namespace).SerialConsoleReadOnly(vmi, time.Duration(timeout)*time.Minute)
+		} else {
+			con, err = virtCli.VirtualMachineInstance(namespace).SerialConsole(vmi, time.Duration(timeout)*time.Minute)
+		}
 		runningChan <- err
 
 		if err != nil {
@@ -125,7 +134,11 @@ func (c *Console) Run(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return fmt.Errorf("Make raw terminal failed: %s", err)
 	}
-	fmt.Fprint(os.Stderr, "Successfully connected to ", vmi, " console. The escape sequence is ^]\n")
+	if readOnly {
+		fmt.Fprint(os.Stderr, "Successfully connected to ", vmi, " console in read-only mode. The escape sequence is ^]\n")
+	} else {
+		fmt.Fprint(os.Stderr, "Successfully connected to ", vmi, " console. The escape sequence is ^]\n")
+	}
 
 	in := os.Stdin
 	out := os.Stdout
@@ -160,6 +173,10 @@ func (c *Console) Run(cmd *cobra.Command, args []string) error {
 			if buf[0] == 29 {
 				return
 			}
+			// input of read-only connections is dropped anyway
+			if readOnly {
+				continue
+			}
 			// Writing out to the console connection
 			_, err = stdinWriter.Write(buf[0:n])
 			if err == io.EOF {
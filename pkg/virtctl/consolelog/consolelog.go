@@ -0,0 +1,104 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2019 Red Hat, Inc.
+ *
+ */
+
+package consolelog
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/spf13/cobra"
+	"k8s.io/client-go/tools/clientcmd"
+
+	"kubevirt.io/client-go/kubecli"
+	"kubevirt.io/kubevirt/pkg/virtctl/templates"
+)
+
+const COMMAND_CONSOLELOG = "consolelog"
+
+var since time.Duration
+var tail int64
+
+func NewCommand(clientConfig clientcmd.ClientConfig) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "consolelog (VMI)",
+		Short:   "Print the serial console log of a virtual machine instance.",
+		Example: usage(),
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c := ConsoleLog{clientConfig: clientConfig}
+			return c.Run(cmd, args)
+		},
+	}
+
+	cmd.Flags().DurationVar(&since, "since", 0, "Only return the output newer than a relative duration like 5s, 2m, or 3h. Defaults to all output.")
+	cmd.Flags().Int64Var(&tail, "tail", -1, "Lines of recent output to display. Defaults to -1, showing all output.")
+	cmd.SetUsageTemplate(templates.UsageTemplate())
+	return cmd
+}
+
+type ConsoleLog struct {
+	clientConfig clientcmd.ClientConfig
+}
+
+func usage() string {
+	usage := `  # Print the serial console log of VirtualMachineInstance 'myvmi':
+  {{ProgramName}} consolelog myvmi
+  # Print the last 20 lines of the serial console output of the last hour:
+  {{ProgramName}} consolelog --since=1h --tail=20 myvmi`
+
+	return usage
+}
+
+func (c *ConsoleLog) Run(cmd *cobra.Command, args []string) error {
+	namespace, _, err := c.clientConfig.Namespace()
+	if err != nil {
+		return err
+	}
+
+	vmi := args[0]
+
+	virtClient, err := kubecli.GetKubevirtClientFromClientConfig(c.clientConfig)
+	if err != nil {
+		return fmt.Errorf("Cannot obtain KubeVirt client: %v", err)
+	}
+
+	options := &kubecli.ConsoleLogOptions{}
+	if since < 0 {
+		return fmt.Errorf("--since must be a positive duration")
+	} else if since > 0 {
+		// the log API only accepts full seconds, round up to not cut off output
+		sinceSeconds := int64((since + time.Second - 1) / time.Second)
+		options.SinceSeconds = &sinceSeconds
+	}
+	if tail >= 0 {
+		tailLines := tail
+		options.TailLines = &tailLines
+	}
+
+	logs, err := virtClient.VirtualMachineInstance(namespace).ConsoleLog(vmi, options)
+	if err != nil {
+		return fmt.Errorf("Can't access the serial console log of VMI %s: %v", vmi, err)
+	}
+	defer logs.Close()
+
+	_, err = io.Copy(cmd.OutOrStdout(), logs)
+	return err
+}
@@ -0,0 +1,68 @@
+package consolelog_test
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	k8smetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"kubevirt.io/client-go/kubecli"
+	"kubevirt.io/kubevirt/tests"
+)
+
+var _ = Describe("ConsoleLog", func() {
+
+	const vmiName = "testvmi"
+	var vmiInterface *kubecli.MockVirtualMachineInstanceInterface
+	var ctrl *gomock.Controller
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		kubecli.GetKubevirtClientFromClientConfig = kubecli.GetMockKubevirtClientFromClientConfig
+		kubecli.MockKubevirtClientInstance = kubecli.NewMockKubevirtClient(ctrl)
+		vmiInterface = kubecli.NewMockVirtualMachineInstanceInterface(ctrl)
+	})
+
+	It("should print the serial console log", func() {
+		kubecli.MockKubevirtClientInstance.EXPECT().VirtualMachineInstance(k8smetav1.NamespaceDefault).Return(vmiInterface).Times(1)
+		vmiInterface.EXPECT().ConsoleLog(vmiName, &kubecli.ConsoleLogOptions{}).
+			Return(ioutil.NopCloser(strings.NewReader("login:")), nil).Times(1)
+
+		out := &bytes.Buffer{}
+		cmd := tests.NewVirtctlCommand("consolelog", vmiName)
+		cmd.SetOutput(out)
+		Expect(cmd.Execute()).To(Succeed())
+		Expect(out.String()).To(Equal("login:"))
+	})
+
+	It("should pass since and tail on to the API", func() {
+		sinceSeconds := int64(91)
+		tailLines := int64(20)
+		kubecli.MockKubevirtClientInstance.EXPECT().VirtualMachineInstance(k8smetav1.NamespaceDefault).Return(vmiInterface).Times(1)
+		vmiInterface.EXPECT().ConsoleLog(vmiName, &kubecli.ConsoleLogOptions{SinceSeconds: &sinceSeconds, TailLines: &tailLines}).
+			Return(ioutil.NopCloser(strings.NewReader("")), nil).Times(1)
+
+		cmd := tests.NewVirtctlCommand("consolelog", "--since=90500ms", "--tail=20", vmiName)
+		cmd.SetOutput(&bytes.Buffer{})
+		Expect(cmd.Execute()).To(Succeed())
+	})
+
+	It("should fail if the log is not accessible", func() {
+		kubecli.MockKubevirtClientInstance.EXPECT().VirtualMachineInstance(k8smetav1.NamespaceDefault).Return(vmiInterface).Times(1)
+		vmiInterface.EXPECT().ConsoleLog(vmiName, gomock.Any()).Return(nil, fmt.Errorf("logging disabled")).Times(1)
+
+		cmd := tests.NewVirtctlCommand("consolelog", "--since=0", "--tail=-1", vmiName)
+		cmd.SetOutput(&bytes.Buffer{})
+		Expect(cmd.Execute()).ToNot(Succeed())
+	})
+
+	AfterEach(func() {
+		ctrl.Finish()
+	})
+})
@@ -0,0 +1,218 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2020 Red Hat, Inc.
+ *
+ */
+
+package memorydump
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	k8sv1 "k8s.io/api/core/v1"
+	k8smetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/client-go/tools/remotecommand"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/client-go/kubecli"
+	memorydump "kubevirt.io/kubevirt/pkg/memory-dump"
+	"kubevirt.io/kubevirt/pkg/virtctl/templates"
+)
+
+const (
+	COMMAND_MEMORYDUMP = "memory-dump"
+	ACTION_GET         = "get"
+	ACTION_REMOVE      = "remove"
+
+	pollInterval = 2 * time.Second
+)
+
+var (
+	claimName  string
+	outputFile string
+)
+
+func NewCommand(clientConfig clientcmd.ClientConfig) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "memory-dump get|remove (VM)",
+		Short: "Dump the memory of a running virtual machine into a PVC.",
+		Long: `Dumps the memory of a running virtual machine into a PVC, for example for forensic analysis.
+First argument is the action, 'get' dumps the memory into the PVC given with --claim-name and optionally downloads it, 'remove' dissociates the PVC from the virtual machine.
+Second argument is the name of the virtual machine.`,
+		Example: usage(),
+		Args:    cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c := MemoryDump{clientConfig: clientConfig}
+			return c.Run(cmd, args)
+		},
+	}
+
+	cmd.Flags().StringVar(&claimName, "claim-name", "", "Name of the PVC to dump the memory into. It has to be at least as large as the memory of the guest.")
+	cmd.Flags().StringVar(&outputFile, "output", "", "Wait for the memory dump to complete and download it to the given file.")
+	cmd.SetUsageTemplate(templates.UsageTemplate())
+	return cmd
+}
+
+type MemoryDump struct {
+	clientConfig clientcmd.ClientConfig
+}
+
+func usage() string {
+	usage := `  # Dump the memory of VirtualMachine 'myvm' into PVC 'mypvc':
+  {{ProgramName}} memory-dump get myvm --claim-name=mypvc
+  # Dump the memory of VirtualMachine 'myvm' into PVC 'mypvc' and download it to 'myvm.memory.dump':
+  {{ProgramName}} memory-dump get myvm --claim-name=mypvc --output=myvm.memory.dump
+  # Dissociate the memory dump PVC from VirtualMachine 'myvm':
+  {{ProgramName}} memory-dump remove myvm`
+
+	return usage
+}
+
+func (c *MemoryDump) Run(cmd *cobra.Command, args []string) error {
+	action := strings.ToLower(args[0])
+	vmName := args[1]
+
+	namespace, _, err := c.clientConfig.Namespace()
+	if err != nil {
+		return err
+	}
+
+	switch action {
+	case ACTION_GET:
+		if claimName == "" {
+			return fmt.Errorf("--claim-name is required to dump the memory")
+		}
+	case ACTION_REMOVE:
+		if claimName != "" || outputFile != "" {
+			return fmt.Errorf("--claim-name and --output are only supported for %s", ACTION_GET)
+		}
+	default:
+		return fmt.Errorf("unknown action %s, must be one of %s or %s", args[0], ACTION_GET, ACTION_REMOVE)
+	}
+
+	virtClient, err := kubecli.GetKubevirtClientFromClientConfig(c.clientConfig)
+	if err != nil {
+		return fmt.Errorf("Cannot obtain KubeVirt client: %v", err)
+	}
+
+	if action == ACTION_REMOVE {
+		if err := virtClient.VirtualMachine(namespace).RemoveMemoryDump(vmName); err != nil {
+			return fmt.Errorf("Error removing the memory dump of VirtualMachine %s: %v", vmName, err)
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "Memory dump of VM %s was scheduled for removal\n", vmName)
+		return nil
+	}
+
+	err = virtClient.VirtualMachine(namespace).MemoryDump(vmName, &v1.VirtualMachineMemoryDumpRequest{ClaimName: claimName})
+	if err != nil {
+		return fmt.Errorf("Error dumping the memory of VirtualMachine %s: %v", vmName, err)
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "Memory dump of VM %s into PVC %s was scheduled\n", vmName, claimName)
+
+	if outputFile == "" {
+		return nil
+	}
+	vm, err := waitForMemoryDump(virtClient, namespace, vmName)
+	if err != nil {
+		return err
+	}
+	if err := c.download(virtClient, vm, outputFile); err != nil {
+		return fmt.Errorf("Can't download the memory dump of VirtualMachine %s: %v", vmName, err)
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "Memory dump of VM %s saved to %s\n", vmName, outputFile)
+	return nil
+}
+
+// waitForMemoryDump returns the VirtualMachine once its memory dump completed
+func waitForMemoryDump(virtClient kubecli.KubevirtClient, namespace string, vmName string) (*v1.VirtualMachine, error) {
+	var vm *v1.VirtualMachine
+	err := wait.PollImmediateInfinite(pollInterval, func() (bool, error) {
+		var err error
+		vm, err = virtClient.VirtualMachine(namespace).Get(vmName, &k8smetav1.GetOptions{})
+		if err != nil {
+			return false, fmt.Errorf("Error getting VirtualMachine %s: %v", vmName, err)
+		}
+		request := vm.Status.MemoryDumpRequest
+		if request == nil || request.Remove {
+			return false, fmt.Errorf("Memory dump of VirtualMachine %s was removed", vmName)
+		}
+		switch request.Phase {
+		case v1.MemoryDumpCompleted:
+			return true, nil
+		case v1.MemoryDumpFailed:
+			return false, fmt.Errorf("Memory dump of VirtualMachine %s failed: %s", vmName, request.Message)
+		}
+		return false, nil
+	})
+	return vm, err
+}
+
+// download streams the memory dump out of the memory dump pod, which has the PVC mounted
+func (c *MemoryDump) download(virtClient kubecli.KubevirtClient, vm *v1.VirtualMachine, target string) error {
+	request := vm.Status.MemoryDumpRequest
+	if request.FileName == nil {
+		return fmt.Errorf("the memory dump has no file name")
+	}
+
+	config, err := c.clientConfig.ClientConfig()
+	if err != nil {
+		return err
+	}
+
+	req := virtClient.CoreV1().RESTClient().Post().
+		Resource("pods").
+		Name(memorydump.PodName(vm)).
+		Namespace(vm.Namespace).
+		SubResource("exec").
+		Param("container", memorydump.ContainerName)
+	req.VersionedParams(&k8sv1.PodExecOptions{
+		Container: memorydump.ContainerName,
+		Command:   []string{"/usr/bin/cat", filepath.Join(memorydump.ClaimDir, *request.FileName)},
+		Stdout:    true,
+		Stderr:    true,
+	}, scheme.ParameterCodec)
+
+	exec, err := remotecommand.NewSPDYExecutor(config, "POST", req.URL())
+	if err != nil {
+		return err
+	}
+
+	out, err := os.Create(target)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	var stderr bytes.Buffer
+	err = exec.Stream(remotecommand.StreamOptions{
+		Stdout: out,
+		Stderr: &stderr,
+	})
+	if err != nil {
+		os.Remove(target)
+		return fmt.Errorf("%v: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	return nil
+}
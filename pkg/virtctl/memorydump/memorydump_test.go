@@ -0,0 +1,89 @@
+package memorydump_test
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+
+	k8smetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/client-go/kubecli"
+	"kubevirt.io/kubevirt/tests"
+)
+
+var _ = Describe("MemoryDump", func() {
+
+	const vmName = "testvm"
+	var vmInterface *kubecli.MockVirtualMachineInterface
+	var ctrl *gomock.Controller
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		kubecli.GetKubevirtClientFromClientConfig = kubecli.GetMockKubevirtClientFromClientConfig
+		kubecli.MockKubevirtClientInstance = kubecli.NewMockKubevirtClient(ctrl)
+		vmInterface = kubecli.NewMockVirtualMachineInterface(ctrl)
+	})
+
+	It("should dump the memory into the given claim", func() {
+		kubecli.MockKubevirtClientInstance.EXPECT().VirtualMachine(k8smetav1.NamespaceDefault).Return(vmInterface).Times(1)
+		vmInterface.EXPECT().MemoryDump(vmName, &v1.VirtualMachineMemoryDumpRequest{ClaimName: "dump-claim"}).Return(nil).Times(1)
+
+		cmd := tests.NewVirtctlCommand("memory-dump", "get", vmName, "--claim-name", "dump-claim")
+		cmd.SetOutput(&bytes.Buffer{})
+		Expect(cmd.Execute()).To(Succeed())
+	})
+
+	It("should fail if the memory can't be dumped", func() {
+		kubecli.MockKubevirtClientInstance.EXPECT().VirtualMachine(k8smetav1.NamespaceDefault).Return(vmInterface).Times(1)
+		vmInterface.EXPECT().MemoryDump(vmName, gomock.Any()).Return(fmt.Errorf("VM is not running")).Times(1)
+
+		cmd := tests.NewVirtctlCommand("memory-dump", "get", vmName, "--claim-name", "dump-claim")
+		cmd.SetOutput(&bytes.Buffer{})
+		Expect(cmd.Execute()).To(MatchError(ContainSubstring("VM is not running")))
+	})
+
+	It("should stop waiting for the download if the memory dump failed", func() {
+		vm := kubecli.NewMinimalVM(vmName)
+		vm.Status.MemoryDumpRequest = &v1.VirtualMachineMemoryDumpRequest{
+			ClaimName: "dump-claim",
+			Phase:     v1.MemoryDumpFailed,
+			Message:   "no space left on device",
+		}
+
+		kubecli.MockKubevirtClientInstance.EXPECT().VirtualMachine(k8smetav1.NamespaceDefault).Return(vmInterface).Times(2)
+		vmInterface.EXPECT().MemoryDump(vmName, gomock.Any()).Return(nil).Times(1)
+		vmInterface.EXPECT().Get(vmName, gomock.Any()).Return(vm, nil).Times(1)
+
+		cmd := tests.NewVirtctlCommand("memory-dump", "get", vmName, "--claim-name", "dump-claim", "--output", "/tmp/never-written.memory.dump")
+		cmd.SetOutput(&bytes.Buffer{})
+		Expect(cmd.Execute()).To(MatchError(ContainSubstring("no space left on device")))
+	})
+
+	It("should remove the memory dump", func() {
+		kubecli.MockKubevirtClientInstance.EXPECT().VirtualMachine(k8smetav1.NamespaceDefault).Return(vmInterface).Times(1)
+		vmInterface.EXPECT().RemoveMemoryDump(vmName).Return(nil).Times(1)
+
+		cmd := tests.NewVirtctlCommand("memory-dump", "remove", vmName)
+		cmd.SetOutput(&bytes.Buffer{})
+		Expect(cmd.Execute()).To(Succeed())
+	})
+
+	table.DescribeTable("should reject invalid arguments", func(message string, args ...string) {
+		cmd := tests.NewVirtctlCommand(append([]string{"memory-dump"}, args...)...)
+		cmd.SetOutput(&bytes.Buffer{})
+		Expect(cmd.Execute()).To(MatchError(ContainSubstring(message)))
+	},
+		table.Entry("without a claim name", "--claim-name is required", "get", vmName),
+		table.Entry("with a claim name for remove", "only supported for get", "remove", vmName, "--claim-name", "dump-claim"),
+		table.Entry("with an unknown action", "unknown action", "list", vmName),
+	)
+
+	AfterEach(func() {
+		ctrl.Finish()
+	})
+})
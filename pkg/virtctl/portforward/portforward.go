@@ -0,0 +1,238 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2019 Red Hat, Inc.
+ *
+ */
+
+package portforward
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+
+	"github.com/golang/glog"
+	"github.com/spf13/cobra"
+	"k8s.io/client-go/tools/clientcmd"
+
+	"kubevirt.io/client-go/kubecli"
+	"kubevirt.io/kubevirt/pkg/virtctl/templates"
+)
+
+const (
+	COMMAND_PORTFORWARD = "port-forward"
+
+	ADDRESS_FLAG = "address"
+	STDIO_FLAG   = "stdio"
+)
+
+var (
+	address string
+	stdio   bool
+)
+
+func NewCommand(clientConfig clientcmd.ClientConfig) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "port-forward TYPE/NAME[.NAMESPACE] [LOCAL_PORT:]REMOTE_PORT...",
+		Short:   "Forward local ports to a virtual machine or virtual machine instance.",
+		Long:    usageLong(),
+		Example: usage(),
+		Args:    cobra.MinimumNArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c := PortForward{clientConfig: clientConfig}
+			return c.Run(cmd, args)
+		},
+	}
+	cmd.Flags().StringVar(&address, ADDRESS_FLAG, "127.0.0.1", "The local address to listen on.")
+	cmd.Flags().BoolVar(&stdio, STDIO_FLAG, false, "Forward a single port over stdin and stdout instead of listening locally, e.g. to be used as ssh ProxyCommand.")
+	cmd.SetUsageTemplate(templates.UsageTemplate())
+	return cmd
+}
+
+func usageLong() string {
+	return `Forward local ports to a virtual machine or virtual machine instance.
+The connections are tunneled through the KubeVirt API and virt-handler, no
+Service needs to exist for the target. TYPE is either vm or vmi, a vm is
+reached through its running vmi.`
+}
+
+func usage() string {
+	return `  # Forward the local port 8080 to the port 80 of the vmi 'testvmi':
+  {{ProgramName}} port-forward vmi/testvmi 8080:80
+
+  # Forward the port 22 of the vm 'testvm' in the namespace 'mynamespace' to the same local port:
+  {{ProgramName}} port-forward vm/testvm.mynamespace 22
+
+  # Use port-forward as ssh ProxyCommand:
+  ssh -o 'ProxyCommand={{ProgramName}} port-forward --stdio vmi/testvmi 22' user@testvmi`
+}
+
+type PortForward struct {
+	clientConfig clientcmd.ClientConfig
+}
+
+type forwardedPort struct {
+	local  int
+	remote int
+}
+
+// ParseTarget splits a target of the form TYPE/NAME[.NAMESPACE] into its
+// parts. The namespace is empty if the target does not name one.
+func ParseTarget(target string) (kind string, namespace string, name string, err error) {
+	parts := strings.SplitN(target, "/", 2)
+	if len(parts) != 2 {
+		return "", "", "", fmt.Errorf("target must be of the form TYPE/NAME[.NAMESPACE], got %q", target)
+	}
+	kind = parts[0]
+	if kind != "vm" && kind != "vmi" {
+		return "", "", "", fmt.Errorf("unsupported target type %q, only vm and vmi are supported", kind)
+	}
+	name = parts[1]
+	if i := strings.Index(name, "."); i >= 0 {
+		namespace = name[i+1:]
+		name = name[:i]
+	}
+	if name == "" {
+		return "", "", "", fmt.Errorf("target %q has no name", target)
+	}
+	return kind, namespace, name, nil
+}
+
+func parsePort(port string) (int, error) {
+	p, err := strconv.Atoi(port)
+	if err != nil || p < 0 || p > 65535 {
+		return 0, fmt.Errorf("invalid port %q", port)
+	}
+	return p, nil
+}
+
+func parsePorts(args []string) ([]forwardedPort, error) {
+	ports := []forwardedPort{}
+	for _, arg := range args {
+		parts := strings.Split(arg, ":")
+		if len(parts) > 2 {
+			return nil, fmt.Errorf("invalid port mapping %q", arg)
+		}
+		remote, err := parsePort(parts[len(parts)-1])
+		if err != nil {
+			return nil, err
+		}
+		if remote == 0 {
+			return nil, fmt.Errorf("invalid remote port in %q", arg)
+		}
+		local := remote
+		if len(parts) == 2 {
+			if local, err = parsePort(parts[0]); err != nil {
+				return nil, err
+			}
+		}
+		ports = append(ports, forwardedPort{local: local, remote: remote})
+	}
+	return ports, nil
+}
+
+func (o *PortForward) Run(cmd *cobra.Command, args []string) error {
+	// A vm is forwarded through its vmi, which has the same name
+	_, namespace, name, err := ParseTarget(args[0])
+	if err != nil {
+		return err
+	}
+	ports, err := parsePorts(args[1:])
+	if err != nil {
+		return err
+	}
+	if stdio && len(ports) != 1 {
+		return fmt.Errorf("exactly one port can be forwarded with --%s", STDIO_FLAG)
+	}
+
+	if namespace == "" {
+		namespace, _, err = o.clientConfig.Namespace()
+		if err != nil {
+			return err
+		}
+	}
+
+	virtCli, err := kubecli.GetKubevirtClientFromClientConfig(o.clientConfig)
+	if err != nil {
+		return err
+	}
+	vmiInterface := virtCli.VirtualMachineInstance(namespace)
+
+	if stdio {
+		stream, err := vmiInterface.PortForward(name, ports[0].remote)
+		if err != nil {
+			return fmt.Errorf("Can't access VMI %s: %s", name, err.Error())
+		}
+		return stream.Stream(kubecli.StreamOptions{
+			In:  os.Stdin,
+			Out: os.Stdout,
+		})
+	}
+
+	errChan := make(chan error, len(ports))
+	for _, port := range ports {
+		ln, err := net.Listen("tcp", net.JoinHostPort(address, strconv.Itoa(port.local)))
+		if err != nil {
+			return fmt.Errorf("Can't listen on port %d: %s", port.local, err.Error())
+		}
+		defer ln.Close()
+		fmt.Fprintf(cmd.OutOrStdout(), "Forwarding from %s -> %d\n", ln.Addr().String(), port.remote)
+		go func(ln net.Listener, remote int) {
+			errChan <- forward(vmiInterface, name, ln, remote)
+		}(ln, port.remote)
+	}
+
+	interrupt := make(chan os.Signal, 1)
+	signal.Notify(interrupt, os.Interrupt)
+
+	select {
+	case <-interrupt:
+		return nil
+	case err = <-errChan:
+		return fmt.Errorf("Error encountered: %s", err.Error())
+	}
+}
+
+// forward accepts connections on ln and tunnels each of them through its own
+// portforward stream to the remote port of the vmi
+func forward(vmiInterface kubecli.VirtualMachineInstanceInterface, name string, ln net.Listener, remote int) error {
+	for {
+		fd, err := ln.Accept()
+		if err != nil {
+			return err
+		}
+		glog.V(2).Infof("Handling connection from %s for port %d", fd.RemoteAddr().String(), remote)
+
+		go func(fd net.Conn) {
+			defer fd.Close()
+			stream, err := vmiInterface.PortForward(name, remote)
+			if err != nil {
+				glog.Errorf("Can't access VMI %s: %s", name, err.Error())
+				return
+			}
+			err = stream.Stream(kubecli.StreamOptions{
+				In:  fd,
+				Out: fd,
+			})
+			if err != nil {
+				glog.V(2).Infof("Connection to port %d closed: %s", remote, err.Error())
+			}
+		}(fd)
+	}
+}
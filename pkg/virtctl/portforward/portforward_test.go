@@ -0,0 +1,87 @@
+package portforward_test
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	k8smetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"kubevirt.io/client-go/kubecli"
+	"kubevirt.io/kubevirt/pkg/virtctl/portforward"
+	"kubevirt.io/kubevirt/tests"
+)
+
+var _ = Describe("Port forward", func() {
+
+	const vmiName = "testvmi"
+	var vmiInterface *kubecli.MockVirtualMachineInstanceInterface
+	var ctrl *gomock.Controller
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		kubecli.GetKubevirtClientFromClientConfig = kubecli.GetMockKubevirtClientFromClientConfig
+		kubecli.MockKubevirtClientInstance = kubecli.NewMockKubevirtClient(ctrl)
+		vmiInterface = kubecli.NewMockVirtualMachineInstanceInterface(ctrl)
+	})
+
+	table.DescribeTable("should parse targets", func(arg, kind, namespace, name string) {
+		k, ns, n, err := portforward.ParseTarget(arg)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(k).To(Equal(kind))
+		Expect(ns).To(Equal(namespace))
+		Expect(n).To(Equal(name))
+	},
+		table.Entry("vmi without namespace", "vmi/testvmi", "vmi", "", "testvmi"),
+		table.Entry("vmi with namespace", "vmi/testvmi.mynamespace", "vmi", "mynamespace", "testvmi"),
+		table.Entry("vm with namespace", "vm/testvm.mynamespace", "vm", "mynamespace", "testvm"),
+	)
+
+	table.DescribeTable("should reject invalid targets", func(arg string) {
+		_, _, _, err := portforward.ParseTarget(arg)
+		Expect(err).To(HaveOccurred())
+	},
+		table.Entry("without type", "testvmi"),
+		table.Entry("with unknown type", "pod/testvmi"),
+		table.Entry("without name", "vmi/.mynamespace"),
+	)
+
+	table.DescribeTable("should reject invalid ports", func(port string) {
+		cmd := tests.NewVirtctlCommand("port-forward", "vmi/"+vmiName, port)
+		cmd.SetOutput(&bytes.Buffer{})
+		err := cmd.Execute()
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring(port))
+	},
+		table.Entry("not a number", "ssh"),
+		table.Entry("out of range", "65536"),
+		table.Entry("zero remote port", "8080:0"),
+		table.Entry("too many parts", "1:2:3"),
+	)
+
+	It("should only forward a single port over stdio", func() {
+		cmd := tests.NewVirtctlCommand("port-forward", "--stdio", "vmi/"+vmiName, "22", "80")
+		cmd.SetOutput(&bytes.Buffer{})
+		err := cmd.Execute()
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("exactly one port"))
+	})
+
+	It("should fail if the vmi can't be accessed", func() {
+		kubecli.MockKubevirtClientInstance.EXPECT().VirtualMachineInstance(k8smetav1.NamespaceDefault).Return(vmiInterface).Times(1)
+		vmiInterface.EXPECT().PortForward(vmiName, 22).Return(nil, fmt.Errorf("VMI is not running")).Times(1)
+
+		cmd := tests.NewVirtctlCommand("port-forward", "--stdio", "vm/"+vmiName, "22")
+		cmd.SetOutput(&bytes.Buffer{})
+		err := cmd.Execute()
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("VMI is not running"))
+	})
+
+	AfterEach(func() {
+		ctrl.Finish()
+	})
+})
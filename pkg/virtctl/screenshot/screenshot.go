@@ -0,0 +1,95 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2019 Red Hat, Inc.
+ *
+ */
+
+package screenshot
+
+import (
+	"fmt"
+	"io/ioutil"
+
+	"github.com/spf13/cobra"
+	"k8s.io/client-go/tools/clientcmd"
+
+	"kubevirt.io/client-go/kubecli"
+	"kubevirt.io/kubevirt/pkg/virtctl/templates"
+)
+
+const COMMAND_SCREENSHOT = "screenshot"
+
+var file string
+
+func NewCommand(clientConfig clientcmd.ClientConfig) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "screenshot (VMI)",
+		Short:   "Save a PNG screenshot of the graphical console of a virtual machine instance.",
+		Example: usage(),
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c := Screenshot{clientConfig: clientConfig}
+			return c.Run(cmd, args)
+		},
+	}
+
+	cmd.Flags().StringVarP(&file, "file", "f", "", "File to save the screenshot to. Defaults to '<vmi>.png'.")
+	cmd.SetUsageTemplate(templates.UsageTemplate())
+	return cmd
+}
+
+type Screenshot struct {
+	clientConfig clientcmd.ClientConfig
+}
+
+func usage() string {
+	usage := `  # Save a screenshot of VirtualMachineInstance 'myvmi' to 'myvmi.png':
+  {{ProgramName}} screenshot myvmi
+  # Save a screenshot of VirtualMachineInstance 'myvmi' to '/tmp/screen.png':
+  {{ProgramName}} screenshot --file=/tmp/screen.png myvmi`
+
+	return usage
+}
+
+func (s *Screenshot) Run(cmd *cobra.Command, args []string) error {
+	namespace, _, err := s.clientConfig.Namespace()
+	if err != nil {
+		return err
+	}
+
+	vmi := args[0]
+
+	virtClient, err := kubecli.GetKubevirtClientFromClientConfig(s.clientConfig)
+	if err != nil {
+		return fmt.Errorf("Cannot obtain KubeVirt client: %v", err)
+	}
+
+	png, err := virtClient.VirtualMachineInstance(namespace).Screenshot(vmi)
+	if err != nil {
+		return fmt.Errorf("Can't take a screenshot of VMI %s: %v", vmi, err)
+	}
+
+	target := file
+	if target == "" {
+		target = vmi + ".png"
+	}
+	if err := ioutil.WriteFile(target, png, 0644); err != nil {
+		return fmt.Errorf("Can't save the screenshot of VMI %s: %v", vmi, err)
+	}
+
+	fmt.Fprintf(cmd.OutOrStdout(), "Screenshot of VMI %s saved to %s\n", vmi, target)
+	return nil
+}
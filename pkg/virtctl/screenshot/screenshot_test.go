@@ -0,0 +1,67 @@
+package screenshot_test
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	k8smetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"kubevirt.io/client-go/kubecli"
+	"kubevirt.io/kubevirt/tests"
+)
+
+var _ = Describe("Screenshot", func() {
+
+	const vmiName = "testvmi"
+	var vmiInterface *kubecli.MockVirtualMachineInstanceInterface
+	var ctrl *gomock.Controller
+	var tmpDir string
+
+	BeforeEach(func() {
+		var err error
+		tmpDir, err = ioutil.TempDir("", "screenshot")
+		Expect(err).ToNot(HaveOccurred())
+
+		ctrl = gomock.NewController(GinkgoT())
+		kubecli.GetKubevirtClientFromClientConfig = kubecli.GetMockKubevirtClientFromClientConfig
+		kubecli.MockKubevirtClientInstance = kubecli.NewMockKubevirtClient(ctrl)
+		vmiInterface = kubecli.NewMockVirtualMachineInstanceInterface(ctrl)
+	})
+
+	It("should save the screenshot to the given file", func() {
+		target := filepath.Join(tmpDir, "screen.png")
+		kubecli.MockKubevirtClientInstance.EXPECT().VirtualMachineInstance(k8smetav1.NamespaceDefault).Return(vmiInterface).Times(1)
+		vmiInterface.EXPECT().Screenshot(vmiName).Return([]byte("\x89PNG"), nil).Times(1)
+
+		cmd := tests.NewVirtctlCommand("screenshot", "--file", target, vmiName)
+		cmd.SetOutput(&bytes.Buffer{})
+		Expect(cmd.Execute()).To(Succeed())
+
+		data, err := ioutil.ReadFile(target)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(data).To(Equal([]byte("\x89PNG")))
+	})
+
+	It("should fail if the screenshot can't be taken", func() {
+		target := filepath.Join(tmpDir, "screen.png")
+		kubecli.MockKubevirtClientInstance.EXPECT().VirtualMachineInstance(k8smetav1.NamespaceDefault).Return(vmiInterface).Times(1)
+		vmiInterface.EXPECT().Screenshot(vmiName).Return(nil, fmt.Errorf("no graphics device")).Times(1)
+
+		cmd := tests.NewVirtctlCommand("screenshot", "-f", target, vmiName)
+		cmd.SetOutput(&bytes.Buffer{})
+		Expect(cmd.Execute()).ToNot(Succeed())
+		Expect(target).ToNot(BeAnExistingFile())
+	})
+
+	AfterEach(func() {
+		ctrl.Finish()
+		os.RemoveAll(tmpDir)
+	})
+})
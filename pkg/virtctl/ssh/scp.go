@@ -0,0 +1,128 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2019 Red Hat, Inc.
+ *
+ */
+
+package ssh
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"k8s.io/client-go/tools/clientcmd"
+
+	"kubevirt.io/kubevirt/pkg/virtctl/templates"
+)
+
+const RECURSIVE_FLAG = "recursive"
+
+var (
+	scpOptions options
+	recursive  bool
+)
+
+func NewSCPCommand(clientConfig clientcmd.ClientConfig) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "scp SOURCE DESTINATION",
+		Short:   "Copy files from and to a virtual machine or virtual machine instance.",
+		Long:    scpUsageLong(),
+		Example: scpUsage(),
+		Args:    cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c := SCPCommand{clientConfig: clientConfig, options: scpOptions}
+			return c.Run(cmd, args)
+		},
+	}
+	// -p is taken by scp itself to preserve file times
+	addCommonFlags(cmd.Flags(), &scpOptions, "P")
+	cmd.Flags().BoolVarP(&recursive, RECURSIVE_FLAG, "r", false, "Recursively copy entire directories.")
+	cmd.SetUsageTemplate(templates.UsageTemplate())
+	return cmd
+}
+
+func scpUsageLong() string {
+	return `Copy files from and to a virtual machine or virtual machine instance.
+Exactly one of SOURCE and DESTINATION refers to the guest, in the form
+[USER@]TYPE/NAME[.NAMESPACE]:PATH. The local scp client is used, the
+connection is tunneled through the portforward subresource.`
+}
+
+func scpUsage() string {
+	return `  # Copy 'file.txt' to the home directory of 'fedora' in the vmi 'testvmi':
+  {{ProgramName}} scp file.txt fedora@vmi/testvmi:
+
+  # Copy the directory '/var/log' of the vm 'testvm' in the namespace 'mynamespace' to the local machine:
+  {{ProgramName}} scp -r fedora@vm/testvm.mynamespace:/var/log .`
+}
+
+type SCPCommand struct {
+	clientConfig clientcmd.ClientConfig
+	options      options
+}
+
+// parseRemotePath returns the target and path of an argument of the form
+// [USER@]TYPE/NAME[.NAMESPACE]:PATH, or nil if the argument is a local path
+func parseRemotePath(arg string, defaultUsername string) (*target, string) {
+	i := strings.Index(arg, ":")
+	if i < 0 {
+		return nil, ""
+	}
+	t, err := parseTarget(arg[:i], defaultUsername)
+	if err != nil {
+		return nil, ""
+	}
+	return t, arg[i+1:]
+}
+
+func (o *SCPCommand) Run(cmd *cobra.Command, args []string) error {
+	source, destination := args[0], args[1]
+	srcTarget, srcPath := parseRemotePath(source, o.options.username)
+	dstTarget, dstPath := parseRemotePath(destination, o.options.username)
+
+	var t *target
+	switch {
+	case srcTarget != nil && dstTarget != nil:
+		return fmt.Errorf("only one of source and destination can refer to a virtual machine")
+	case srcTarget != nil:
+		t = srcTarget
+	case dstTarget != nil:
+		t = dstTarget
+	default:
+		return fmt.Errorf("either source or destination must be of the form [USER@]TYPE/NAME[.NAMESPACE]:PATH")
+	}
+	if err := t.complete(o.clientConfig); err != nil {
+		return err
+	}
+
+	if srcTarget != nil {
+		source = t.host() + ":" + srcPath
+	} else {
+		destination = t.host() + ":" + dstPath
+	}
+
+	clientArgs, err := o.options.clientArgs(cmd, t)
+	if err != nil {
+		return err
+	}
+	if recursive {
+		clientArgs = append(clientArgs, "-r")
+	}
+	clientArgs = append(clientArgs, source, destination)
+
+	return runLocalClient(SCP, clientArgs)
+}
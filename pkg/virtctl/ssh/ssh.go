@@ -0,0 +1,218 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2019 Red Hat, Inc.
+ *
+ */
+
+package ssh
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/golang/glog"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"k8s.io/client-go/tools/clientcmd"
+
+	"kubevirt.io/kubevirt/pkg/virtctl/portforward"
+	"kubevirt.io/kubevirt/pkg/virtctl/templates"
+)
+
+const (
+	SSH = "ssh"
+	SCP = "scp"
+
+	PORT_FLAG           = "port"
+	USERNAME_FLAG       = "username"
+	IDENTITY_FILE_FLAG  = "identity-file"
+	LOCAL_SSH_OPTS_FLAG = "local-ssh-opts"
+)
+
+type options struct {
+	port         int
+	username     string
+	identityFile string
+	localOpts    []string
+}
+
+var sshOptions options
+
+func NewCommand(clientConfig clientcmd.ClientConfig) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "ssh [USER@]TYPE/NAME[.NAMESPACE] [COMMAND...]",
+		Short:   "Open a SSH connection to a virtual machine or virtual machine instance.",
+		Long:    usageLong(),
+		Example: usage(),
+		Args:    cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c := SSHCommand{clientConfig: clientConfig, options: sshOptions}
+			return c.Run(cmd, args)
+		},
+	}
+	addCommonFlags(cmd.Flags(), &sshOptions, "p")
+	cmd.SetUsageTemplate(templates.UsageTemplate())
+	return cmd
+}
+
+func addCommonFlags(flags *pflag.FlagSet, o *options, portShorthand string) {
+	flags.IntVarP(&o.port, PORT_FLAG, portShorthand, 22, "The port of the SSH server in the guest.")
+	flags.StringVarP(&o.username, USERNAME_FLAG, "l", "", "The user to log in as, if the target does not contain one.")
+	flags.StringVarP(&o.identityFile, IDENTITY_FILE_FLAG, "i", "", "The file from which the private key for authentication is read.")
+	flags.StringArrayVarP(&o.localOpts, LOCAL_SSH_OPTS_FLAG, "t", []string{}, "Additional options passed to the local client, e.g. --local-ssh-opts=\"-o StrictHostKeyChecking=no\".")
+}
+
+func usageLong() string {
+	return `Open a SSH connection to a virtual machine or virtual machine instance.
+The local ssh client is used, the connection is tunneled through the
+portforward subresource, so the guest needs no Service or external address.
+TYPE is either vm or vmi.`
+}
+
+func usage() string {
+	return `  # Connect as 'fedora' to the vmi 'testvmi':
+  {{ProgramName}} ssh fedora@vmi/testvmi
+
+  # Run a command as 'fedora' on the vm 'testvm' in the namespace 'mynamespace':
+  {{ProgramName}} ssh -i ~/.ssh/id_rsa fedora@vm/testvm.mynamespace uptime`
+}
+
+type SSHCommand struct {
+	clientConfig clientcmd.ClientConfig
+	options      options
+}
+
+// target is a parsed [USER@]TYPE/NAME[.NAMESPACE] argument
+type target struct {
+	username  string
+	kind      string
+	namespace string
+	name      string
+}
+
+func parseTarget(arg string, defaultUsername string) (*target, error) {
+	t := &target{username: defaultUsername}
+	if i := strings.Index(arg, "@"); i >= 0 {
+		t.username = arg[:i]
+		arg = arg[i+1:]
+	}
+	kind, namespace, name, err := portforward.ParseTarget(arg)
+	if err != nil {
+		return nil, err
+	}
+	t.kind, t.namespace, t.name = kind, namespace, name
+	return t, nil
+}
+
+// host is the name under which the local client knows the target. It must
+// not contain a slash, which scp would take for a local path.
+func (t *target) host() string {
+	host := fmt.Sprintf("%s.%s.%s", t.kind, t.name, t.namespace)
+	if t.username != "" {
+		host = t.username + "@" + host
+	}
+	return host
+}
+
+func (t *target) complete(clientConfig clientcmd.ClientConfig) error {
+	if t.namespace != "" {
+		return nil
+	}
+	namespace, _, err := clientConfig.Namespace()
+	if err != nil {
+		return err
+	}
+	t.namespace = namespace
+	return nil
+}
+
+// proxyCommand returns the ssh ProxyCommand which tunnels the connection
+// through "port-forward --stdio" of this binary, preserving the global
+// flags which were given on the command line
+func proxyCommand(cmd *cobra.Command, t *target, port int) (string, error) {
+	self, err := os.Executable()
+	if err != nil {
+		return "", fmt.Errorf("Can't determine the path of the %s binary: %s", cmd.Root().Name(), err.Error())
+	}
+	args := []string{self}
+	cmd.InheritedFlags().Visit(func(f *pflag.Flag) {
+		args = append(args, fmt.Sprintf("--%s=%s", f.Name, f.Value.String()))
+	})
+	args = append(args,
+		portforward.COMMAND_PORTFORWARD,
+		fmt.Sprintf("--%s=true", portforward.STDIO_FLAG),
+		fmt.Sprintf("%s/%s.%s", t.kind, t.name, t.namespace),
+		fmt.Sprintf("%d", port),
+	)
+	for i, arg := range args {
+		args[i] = shellQuote(arg)
+	}
+	return strings.Join(args, " "), nil
+}
+
+// shellQuote quotes arg for the shell which ssh runs the ProxyCommand in
+func shellQuote(arg string) string {
+	return "'" + strings.Replace(arg, "'", `'\''`, -1) + "'"
+}
+
+func (o *options) clientArgs(cmd *cobra.Command, t *target) ([]string, error) {
+	proxy, err := proxyCommand(cmd, t, o.port)
+	if err != nil {
+		return nil, err
+	}
+	args := []string{"-o", "ProxyCommand=" + proxy}
+	if o.identityFile != "" {
+		args = append(args, "-i", o.identityFile)
+	}
+	for _, opt := range o.localOpts {
+		args = append(args, strings.Fields(opt)...)
+	}
+	return args, nil
+}
+
+func runLocalClient(client string, args []string) error {
+	if _, err := exec.LookPath(client); err != nil {
+		return fmt.Errorf("could not find the %s binary in $PATH", client)
+	}
+	glog.V(4).Infof("Executing commandline: '%s %v'", client, args)
+
+	c := exec.Command(client, args...)
+	c.Stdin = os.Stdin
+	c.Stdout = os.Stdout
+	c.Stderr = os.Stderr
+	return c.Run()
+}
+
+func (o *SSHCommand) Run(cmd *cobra.Command, args []string) error {
+	t, err := parseTarget(args[0], o.options.username)
+	if err != nil {
+		return err
+	}
+	if err := t.complete(o.clientConfig); err != nil {
+		return err
+	}
+
+	clientArgs, err := o.options.clientArgs(cmd, t)
+	if err != nil {
+		return err
+	}
+	clientArgs = append(clientArgs, t.host())
+	clientArgs = append(clientArgs, args[1:]...)
+
+	return runLocalClient(SSH, clientArgs)
+}
@@ -0,0 +1,124 @@
+package ssh_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"kubevirt.io/kubevirt/tests"
+)
+
+var _ = Describe("SSH", func() {
+
+	var binDir string
+	var oldPath string
+
+	BeforeEach(func() {
+		var err error
+		binDir, err = ioutil.TempDir("", "ssh")
+		Expect(err).ToNot(HaveOccurred())
+		oldPath = os.Getenv("PATH")
+		os.Setenv("PATH", binDir)
+	})
+
+	// fakeClient installs a local client which records its arguments
+	fakeClient := func(name string) {
+		script := "#!/bin/sh\nfor arg in \"$@\"; do echo \"$arg\"; done > " + filepath.Join(binDir, "args") + "\n"
+		err := ioutil.WriteFile(filepath.Join(binDir, name), []byte(script), 0755)
+		Expect(err).ToNot(HaveOccurred())
+	}
+
+	clientArgs := func() []string {
+		out, err := ioutil.ReadFile(filepath.Join(binDir, "args"))
+		Expect(err).ToNot(HaveOccurred())
+		return strings.Split(strings.TrimSpace(string(out)), "\n")
+	}
+
+	Context("ssh", func() {
+
+		It("should fail if ssh is not installed", func() {
+			cmd := tests.NewVirtctlCommand("ssh", "fedora@vmi/testvmi")
+			cmd.SetOutput(&bytes.Buffer{})
+			err := cmd.Execute()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("ssh"))
+		})
+
+		It("should reject invalid targets", func() {
+			fakeClient("ssh")
+			cmd := tests.NewVirtctlCommand("ssh", "fedora@testvmi")
+			cmd.SetOutput(&bytes.Buffer{})
+			Expect(cmd.Execute()).ToNot(Succeed())
+		})
+
+		It("should tunnel the connection through port-forward", func() {
+			fakeClient("ssh")
+			cmd := tests.NewVirtctlCommand("ssh", "-i", "id_test", "--port", "2222", "fedora@vm/testvm.mynamespace", "uptime")
+			Expect(cmd.Execute()).To(Succeed())
+
+			args := clientArgs()
+			Expect(args).To(HaveLen(6))
+			Expect(args[0]).To(Equal("-o"))
+			Expect(args[1]).To(HavePrefix("ProxyCommand="))
+			Expect(args[1]).To(HaveSuffix("'port-forward' '--stdio=true' 'vm/testvm.mynamespace' '2222'"))
+			Expect(args[2:]).To(Equal([]string{"-i", "id_test", "fedora@vm.testvm.mynamespace", "uptime"}))
+		})
+
+		It("should use the default namespace and the username flag", func() {
+			fakeClient("ssh")
+			cmd := tests.NewVirtctlCommand("ssh", "-l", "fedora", "--local-ssh-opts", "-o StrictHostKeyChecking=no", "vmi/testvmi")
+			Expect(cmd.Execute()).To(Succeed())
+
+			args := clientArgs()
+			Expect(args[1]).To(HaveSuffix("'vmi/testvmi.default' '22'"))
+			Expect(args[2:]).To(Equal([]string{"-o", "StrictHostKeyChecking=no", "fedora@vmi.testvmi.default"}))
+		})
+	})
+
+	Context("scp", func() {
+
+		It("should require a remote path", func() {
+			fakeClient("scp")
+			cmd := tests.NewVirtctlCommand("scp", "file.txt", "/tmp/")
+			cmd.SetOutput(&bytes.Buffer{})
+			Expect(cmd.Execute()).ToNot(Succeed())
+		})
+
+		It("should reject two remote paths", func() {
+			fakeClient("scp")
+			cmd := tests.NewVirtctlCommand("scp", "vmi/testvmi:file.txt", "vmi/othervmi:/tmp/")
+			cmd.SetOutput(&bytes.Buffer{})
+			Expect(cmd.Execute()).ToNot(Succeed())
+		})
+
+		It("should copy to the vmi", func() {
+			fakeClient("scp")
+			cmd := tests.NewVirtctlCommand("scp", "file.txt", "fedora@vmi/testvmi:/tmp/")
+			Expect(cmd.Execute()).To(Succeed())
+
+			args := clientArgs()
+			Expect(args[1]).To(HaveSuffix("'vmi/testvmi.default' '22'"))
+			Expect(args[2:]).To(Equal([]string{"file.txt", "fedora@vmi.testvmi.default:/tmp/"}))
+		})
+
+		It("should recursively copy from the vmi", func() {
+			fakeClient("scp")
+			cmd := tests.NewVirtctlCommand("scp", "-r", "-P", "2222", "fedora@vmi/testvmi.mynamespace:/var/log", ".")
+			Expect(cmd.Execute()).To(Succeed())
+
+			args := clientArgs()
+			Expect(args[1]).To(HaveSuffix("'vmi/testvmi.mynamespace' '2222'"))
+			Expect(args[2:]).To(Equal([]string{"-r", "fedora@vmi.testvmi.mynamespace:/var/log", "."}))
+		})
+	})
+
+	AfterEach(func() {
+		os.Setenv("PATH", oldPath)
+		os.RemoveAll(binDir)
+	})
+})
@@ -0,0 +1,171 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2019 Red Hat, Inc.
+ *
+ */
+
+package usbredir
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"os/exec"
+	"os/signal"
+	"regexp"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/spf13/cobra"
+	"k8s.io/client-go/tools/clientcmd"
+
+	"kubevirt.io/client-go/kubecli"
+	"kubevirt.io/kubevirt/pkg/virtctl/templates"
+)
+
+const (
+	LISTEN_TIMEOUT = 60 * time.Second
+
+	USBREDIRECT = "usbredirect"
+)
+
+var deviceRegexp = regexp.MustCompile(`^[0-9a-fA-F]{4}:[0-9a-fA-F]{4}$`)
+
+func NewCommand(clientConfig clientcmd.ClientConfig) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "usbredir (vendor:product) (VMI)",
+		Short:   "Redirect a local USB device to a virtual machine instance.",
+		Example: usage(),
+		Args:    cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c := USBRedir{clientConfig: clientConfig}
+			return c.Run(cmd, args)
+		},
+	}
+	cmd.SetUsageTemplate(templates.UsageTemplate())
+	return cmd
+}
+
+type USBRedir struct {
+	clientConfig clientcmd.ClientConfig
+}
+
+func usage() string {
+	return `  # Redirect the local USB device with vendor 0951 and product 1666 to 'testvmi':
+  {{ProgramName}} usbredir 0951:1666 testvmi`
+}
+
+func (o *USBRedir) Run(cmd *cobra.Command, args []string) error {
+	device := args[0]
+	vmi := args[1]
+
+	if !deviceRegexp.MatchString(device) {
+		return fmt.Errorf("Invalid USB device %q, expected the format vendor:product with hexadecimal ids, e.g. 0951:1666", device)
+	}
+
+	if _, err := exec.LookPath(USBREDIRECT); err != nil {
+		return fmt.Errorf("could not find the %s binary in $PATH", USBREDIRECT)
+	}
+
+	namespace, _, err := o.clientConfig.Namespace()
+	if err != nil {
+		return err
+	}
+
+	virtCli, err := kubecli.GetKubevirtClientFromClientConfig(o.clientConfig)
+	if err != nil {
+		return err
+	}
+
+	lnAddr, err := net.ResolveTCPAddr("tcp", "127.0.0.1:0")
+	if err != nil {
+		return fmt.Errorf("Can't resolve the address: %s", err.Error())
+	}
+
+	// The local tcp server is used to proxy the usbredir websocket connection to usbredirect
+	ln, err := net.ListenTCP("tcp", lnAddr)
+	if err != nil {
+		return fmt.Errorf("Can't listen on tcp socket: %s", err.Error())
+	}
+	defer ln.Close()
+
+	// setup connection with VM
+	usbredir, err := virtCli.VirtualMachineInstance(namespace).USBRedir(vmi)
+	if err != nil {
+		return fmt.Errorf("Can't access VMI %s: %s", vmi, err.Error())
+	}
+
+	k8ResChan := make(chan error, 1)
+	listenResChan := make(chan error, 1)
+	redirResChan := make(chan error, 1)
+	stopChan := make(chan struct{}, 1)
+
+	// wait for usbredirect to connect to our local proxy server, then
+	// transfer data from/to the VM
+	go func() {
+		ln.SetDeadline(time.Now().Add(LISTEN_TIMEOUT))
+		fd, err := ln.Accept()
+		if err != nil {
+			glog.V(2).Infof("Failed to accept tcp connection. %s", err.Error())
+			listenResChan <- err
+			return
+		}
+		defer fd.Close()
+
+		k8ResChan <- usbredir.Stream(kubecli.StreamOptions{
+			In:  fd,
+			Out: fd,
+		})
+	}()
+
+	// execute usbredirect
+	go func() {
+		port := ln.Addr().(*net.TCPAddr).Port
+		args := []string{"--device", device, "--to", fmt.Sprintf("127.0.0.1:%d", port)}
+		if glog.V(4) {
+			args = append(args, "--verbose", "5")
+			glog.Infof("Executing commandline: '%s %v'", USBREDIRECT, args)
+		}
+		output, err := exec.Command(USBREDIRECT, args...).CombinedOutput()
+		if err != nil {
+			glog.Errorf("%s execution failed: %v, output: %v", USBREDIRECT, err, string(output))
+		} else {
+			glog.V(2).Infof("%s output: %v", USBREDIRECT, string(output))
+		}
+		redirResChan <- err
+	}()
+
+	go func() {
+		defer close(stopChan)
+		interrupt := make(chan os.Signal, 1)
+		signal.Notify(interrupt, os.Interrupt)
+		<-interrupt
+	}()
+
+	fmt.Fprintf(cmd.OutOrStdout(), "Redirecting USB device %s to VMI %s, press Ctrl+C to stop\n", device, vmi)
+
+	select {
+	case <-stopChan:
+	case err = <-k8ResChan:
+	case err = <-redirResChan:
+	case err = <-listenResChan:
+	}
+
+	if err != nil {
+		return fmt.Errorf("Error encountered: %s", err.Error())
+	}
+	return nil
+}
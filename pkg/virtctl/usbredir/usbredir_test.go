@@ -0,0 +1,91 @@
+package usbredir_test
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	k8smetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"kubevirt.io/client-go/kubecli"
+	"kubevirt.io/kubevirt/tests"
+)
+
+var _ = Describe("USBRedir", func() {
+
+	const vmiName = "testvmi"
+	var vmiInterface *kubecli.MockVirtualMachineInstanceInterface
+	var ctrl *gomock.Controller
+	var binDir string
+	var oldPath string
+
+	BeforeEach(func() {
+		var err error
+		binDir, err = ioutil.TempDir("", "usbredir")
+		Expect(err).ToNot(HaveOccurred())
+		oldPath = os.Getenv("PATH")
+		os.Setenv("PATH", binDir)
+
+		ctrl = gomock.NewController(GinkgoT())
+		kubecli.GetKubevirtClientFromClientConfig = kubecli.GetMockKubevirtClientFromClientConfig
+		kubecli.MockKubevirtClientInstance = kubecli.NewMockKubevirtClient(ctrl)
+		vmiInterface = kubecli.NewMockVirtualMachineInstanceInterface(ctrl)
+	})
+
+	fakeUSBRedirect := func() {
+		err := ioutil.WriteFile(filepath.Join(binDir, "usbredirect"), []byte("#!/bin/sh\nexit 0\n"), 0755)
+		Expect(err).ToNot(HaveOccurred())
+	}
+
+	It("should require a device and a vmi", func() {
+		cmd := tests.NewVirtctlCommand("usbredir", vmiName)
+		cmd.SetOutput(&bytes.Buffer{})
+		Expect(cmd.Execute()).ToNot(Succeed())
+	})
+
+	table.DescribeTable("should reject invalid devices", func(device string) {
+		fakeUSBRedirect()
+		cmd := tests.NewVirtctlCommand("usbredir", device, vmiName)
+		cmd.SetOutput(&bytes.Buffer{})
+		err := cmd.Execute()
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("Invalid USB device"))
+	},
+		table.Entry("without product", "0951"),
+		table.Entry("with short ids", "951:1666"),
+		table.Entry("with non hexadecimal ids", "0951:16xz"),
+		table.Entry("with a bus address", "1-1.2"),
+	)
+
+	It("should fail if usbredirect is not installed", func() {
+		cmd := tests.NewVirtctlCommand("usbredir", "0951:1666", vmiName)
+		cmd.SetOutput(&bytes.Buffer{})
+		err := cmd.Execute()
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("usbredirect"))
+	})
+
+	It("should fail if the vmi can't be accessed", func() {
+		fakeUSBRedirect()
+		kubecli.MockKubevirtClientInstance.EXPECT().VirtualMachineInstance(k8smetav1.NamespaceDefault).Return(vmiInterface).Times(1)
+		vmiInterface.EXPECT().USBRedir(vmiName).Return(nil, fmt.Errorf("USB redirection is not enabled")).Times(1)
+
+		cmd := tests.NewVirtctlCommand("usbredir", "0951:1666", vmiName)
+		cmd.SetOutput(&bytes.Buffer{})
+		err := cmd.Execute()
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("USB redirection is not enabled"))
+	})
+
+	AfterEach(func() {
+		ctrl.Finish()
+		os.Setenv("PATH", oldPath)
+		os.RemoveAll(binDir)
+	})
+})
@@ -26,6 +26,7 @@ import (
 	"github.com/spf13/cobra"
 	"k8s.io/client-go/tools/clientcmd"
 
+	v1 "kubevirt.io/client-go/api/v1"
 	"kubevirt.io/client-go/kubecli"
 	"kubevirt.io/kubevirt/pkg/virtctl/templates"
 )
@@ -35,8 +36,12 @@ const (
 	COMMAND_STOP    = "stop"
 	COMMAND_RESTART = "restart"
 	COMMAND_MIGRATE = "migrate"
+
+	COMMAND_SOFT_REBOOT = "soft-reboot"
 )
 
+var migratedVolumes []string
+
 func NewStartCommand(clientConfig clientcmd.ClientConfig) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:     "start (VM)",
@@ -86,13 +91,31 @@ func NewMigrateCommand(clientConfig clientcmd.ClientConfig) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:     "migrate (VM)",
 		Short:   "Migrate a virtual machine.",
-		Example: usage(COMMAND_MIGRATE),
+		Example: usage(COMMAND_MIGRATE) + migrateVolumesUsage(),
 		Args:    cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			c := Command{command: COMMAND_MIGRATE, clientConfig: clientConfig}
 			return c.Run(cmd, args)
 		},
 	}
+	cmd.Flags().StringArrayVar(&migratedVolumes, "volume", nil, "Copy a volume to another PVC during the migration, in the form VOLUME=CLAIM. Can be repeated.")
+	cmd.SetUsageTemplate(templates.UsageTemplate())
+	return cmd
+}
+
+func NewSoftRebootCommand(clientConfig clientcmd.ClientConfig) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "soft-reboot (VMI)",
+		Short: "Soft reboot a virtual machine instance.",
+		Long: `Soft reboots a virtual machine instance through the guest agent, or ACPI if no guest agent is connected.
+The virtual machine instance keeps running in the same pod on the same node.`,
+		Example: usage(COMMAND_SOFT_REBOOT),
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c := Command{command: COMMAND_SOFT_REBOOT, clientConfig: clientConfig}
+			return c.Run(cmd, args)
+		},
+	}
 	cmd.SetUsageTemplate(templates.UsageTemplate())
 	return cmd
 }
@@ -108,6 +131,25 @@ func usage(cmd string) string {
 	return usage
 }
 
+func migrateVolumesUsage() string {
+	usage := "\n  # Migrate a virtual machine called 'myvm' while copying its volume 'rootdisk' to the PVC 'newclaim':\n"
+	usage += "  {{ProgramName}} migrate myvm --volume=rootdisk=newclaim"
+	return usage
+}
+
+// parseMigratedVolumes parses the VOLUME=CLAIM pairs of the --volume flag
+func parseMigratedVolumes(values []string) ([]v1.StorageMigratedVolume, error) {
+	var volumes []v1.StorageMigratedVolume
+	for _, value := range values {
+		parts := strings.SplitN(value, "=", 2)
+		if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			return nil, fmt.Errorf("invalid volume %s, must be in the form VOLUME=CLAIM", value)
+		}
+		volumes = append(volumes, v1.StorageMigratedVolume{VolumeName: parts[0], DestinationClaimName: parts[1]})
+	}
+	return volumes, nil
+}
+
 func (o *Command) Run(cmd *cobra.Command, args []string) error {
 
 	vmiName := args[0]
@@ -139,10 +181,28 @@ func (o *Command) Run(cmd *cobra.Command, args []string) error {
 			return fmt.Errorf("Error restarting VirtualMachine %v", err)
 		}
 	case COMMAND_MIGRATE:
+		if len(migratedVolumes) > 0 {
+			volumes, err := parseMigratedVolumes(migratedVolumes)
+			if err != nil {
+				return err
+			}
+			err = virtClient.VirtualMachine(namespace).MigrateVolumes(vmiName, &v1.VirtualMachineMigrateVolumesRequest{MigratedVolumes: volumes})
+			if err != nil {
+				return fmt.Errorf("Error migrating VirtualMachine %v", err)
+			}
+			break
+		}
 		err = virtClient.VirtualMachine(namespace).Migrate(vmiName)
 		if err != nil {
 			return fmt.Errorf("Error migrating VirtualMachine %v", err)
 		}
+	case COMMAND_SOFT_REBOOT:
+		err = virtClient.VirtualMachineInstance(namespace).SoftReboot(vmiName)
+		if err != nil {
+			return fmt.Errorf("Error soft rebooting VirtualMachineInstance %v", err)
+		}
+		fmt.Printf("VMI %s was scheduled to %s\n", vmiName, o.command)
+		return nil
 	}
 
 	fmt.Printf("VM %s was scheduled to %s\n", vmiName, o.command)
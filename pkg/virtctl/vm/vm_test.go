@@ -113,6 +113,28 @@ var _ = Describe("VirtualMachine", func() {
 			cmd := tests.NewVirtctlCommand("migrate", vmName)
 			Expect(cmd.Execute()).To(BeNil())
 		})
+
+		It("should migrate vm volumes to other PVCs", func() {
+			vm := kubecli.NewMinimalVM(vmName)
+
+			kubecli.MockKubevirtClientInstance.EXPECT().VirtualMachine(k8smetav1.NamespaceDefault).Return(vmInterface).Times(1)
+			vmInterface.EXPECT().MigrateVolumes(vm.Name, &v1.VirtualMachineMigrateVolumesRequest{
+				MigratedVolumes: []v1.StorageMigratedVolume{
+					{VolumeName: "rootdisk", DestinationClaimName: "newrootclaim"},
+					{VolumeName: "datadisk", DestinationClaimName: "newdataclaim"},
+				},
+			}).Return(nil).Times(1)
+
+			cmd := tests.NewVirtctlCommand("migrate", vmName, "--volume", "rootdisk=newrootclaim", "--volume", "datadisk=newdataclaim")
+			Expect(cmd.Execute()).To(BeNil())
+		})
+
+		It("should reject an invalid volume", func() {
+			kubecli.MockKubevirtClientInstance.EXPECT().VirtualMachine(k8smetav1.NamespaceDefault).Times(0)
+
+			cmd := tests.NewVirtctlCommand("migrate", vmName, "--volume", "rootdisk")
+			Expect(cmd.Execute()).To(MatchError(ContainSubstring("must be in the form VOLUME=CLAIM")))
+		})
 	})
 
 	Context("with restart VM cmd", func() {
@@ -140,6 +162,18 @@ var _ = Describe("VirtualMachine", func() {
 		})
 	})
 
+	Context("with soft-reboot VMI cmd", func() {
+		It("should soft reboot vmi", func() {
+			vmiInterface := kubecli.NewMockVirtualMachineInstanceInterface(ctrl)
+
+			kubecli.MockKubevirtClientInstance.EXPECT().VirtualMachineInstance(k8smetav1.NamespaceDefault).Return(vmiInterface).Times(1)
+			vmiInterface.EXPECT().SoftReboot(vmName).Return(nil).Times(1)
+
+			cmd := tests.NewVirtctlCommand("soft-reboot", vmName)
+			Expect(cmd.Execute()).To(BeNil())
+		})
+	})
+
 	AfterEach(func() {
 		ctrl.Finish()
 	})
@@ -0,0 +1,233 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2020 Red Hat, Inc.
+ *
+ */
+
+package vmexport
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	k8smetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/clientcmd"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/client-go/kubecli"
+	"kubevirt.io/kubevirt/pkg/virtctl/templates"
+	vmexport "kubevirt.io/kubevirt/pkg/vm-export"
+)
+
+const (
+	COMMAND_VMEXPORT = "vmexport"
+	ACTION_DOWNLOAD  = "download"
+)
+
+var (
+	outputFile string
+	volumeName string
+	format     string
+	manifest   bool
+	internal   bool
+	insecure   bool
+)
+
+func NewCommand(clientConfig clientcmd.ClientConfig) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "vmexport download (VMEXPORT)",
+		Short: "Download a volume or the manifest of a VirtualMachineExport.",
+		Long: `Downloads a volume or the virtual machine manifest served by a ready VirtualMachineExport.
+First argument is the action, only 'download' is supported.
+Second argument is the name of the VirtualMachineExport.`,
+		Example: usage(),
+		Args:    cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c := VMExport{clientConfig: clientConfig}
+			return c.Run(cmd, args)
+		},
+	}
+
+	cmd.Flags().StringVar(&outputFile, "output", "", "File to download to.")
+	cmd.Flags().StringVar(&volumeName, "volume", "", "Volume to download. Can be omitted if the export has only one volume.")
+	cmd.Flags().StringVar(&format, "format", string(v1.ExportVolumeFormatRaw), "Format to download the volume in, raw or gzip.")
+	cmd.Flags().BoolVar(&manifest, "manifest", false, "Download the manifest of the exported virtual machine instead of a volume.")
+	cmd.Flags().BoolVar(&internal, "internal", false, "Use the cluster internal link, even if the export is reachable from outside of the cluster.")
+	cmd.Flags().BoolVar(&insecure, "insecure", false, "Don't verify the certificate of the export server.")
+	cmd.SetUsageTemplate(templates.UsageTemplate())
+	return cmd
+}
+
+type VMExport struct {
+	clientConfig clientcmd.ClientConfig
+}
+
+func usage() string {
+	usage := `  # Download the only volume of VirtualMachineExport 'myexport' to 'disk.img':
+  {{ProgramName}} vmexport download myexport --output=disk.img
+  # Download volume 'rootdisk' of VirtualMachineExport 'myexport' gzip compressed to 'rootdisk.img.gz':
+  {{ProgramName}} vmexport download myexport --volume=rootdisk --format=gzip --output=rootdisk.img.gz
+  # Download the manifest of the virtual machine exported by 'myexport' to 'vm.yaml':
+  {{ProgramName}} vmexport download myexport --manifest --output=vm.yaml`
+
+	return usage
+}
+
+func (c *VMExport) Run(cmd *cobra.Command, args []string) error {
+	action := strings.ToLower(args[0])
+	exportName := args[1]
+
+	if action != ACTION_DOWNLOAD {
+		return fmt.Errorf("unknown action %s, must be %s", args[0], ACTION_DOWNLOAD)
+	}
+	if outputFile == "" {
+		return fmt.Errorf("--output is required")
+	}
+	if format != string(v1.ExportVolumeFormatRaw) && format != string(v1.ExportVolumeFormatGzip) {
+		return fmt.Errorf("unknown format %s, must be one of %s or %s", format, v1.ExportVolumeFormatRaw, v1.ExportVolumeFormatGzip)
+	}
+
+	namespace, _, err := c.clientConfig.Namespace()
+	if err != nil {
+		return err
+	}
+
+	virtClient, err := kubecli.GetKubevirtClientFromClientConfig(c.clientConfig)
+	if err != nil {
+		return fmt.Errorf("Cannot obtain KubeVirt client: %v", err)
+	}
+
+	export, err := virtClient.VirtualMachineExport(namespace).Get(exportName, &k8smetav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("Error getting VirtualMachineExport %s: %v", exportName, err)
+	}
+	if export.Status.Phase != v1.ExportReady || export.Status.Links == nil {
+		return fmt.Errorf("VirtualMachineExport %s is not ready: %s", exportName, export.Status.Message)
+	}
+
+	link := export.Status.Links.External
+	if link == nil || internal {
+		link = export.Status.Links.Internal
+	}
+	if link == nil {
+		return fmt.Errorf("VirtualMachineExport %s has no links", exportName)
+	}
+
+	url, err := downloadURL(link)
+	if err != nil {
+		return err
+	}
+
+	secret, err := virtClient.CoreV1().Secrets(namespace).Get(export.Spec.TokenSecretRef, k8smetav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("Error getting the token of VirtualMachineExport %s: %v", exportName, err)
+	}
+
+	if err := download(url, string(secret.Data[vmexport.TokenSecretKey]), link.Cert, outputFile); err != nil {
+		return fmt.Errorf("Can't download from VirtualMachineExport %s: %v", exportName, err)
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "Downloaded %s to %s\n", url, outputFile)
+	return nil
+}
+
+// downloadURL picks the URL of the manifest or the volume in the requested format from the link
+func downloadURL(link *v1.VirtualMachineExportLink) (string, error) {
+	if manifest {
+		for _, m := range link.Manifests {
+			if m.Type == v1.ExportManifestTypeAll {
+				return m.Url, nil
+			}
+		}
+		return "", fmt.Errorf("the export has no manifest, only VirtualMachine exports have one")
+	}
+
+	var volume *v1.VirtualMachineExportVolume
+	switch {
+	case volumeName != "":
+		for i := range link.Volumes {
+			if link.Volumes[i].Name == volumeName {
+				volume = &link.Volumes[i]
+			}
+		}
+		if volume == nil {
+			return "", fmt.Errorf("the export has no volume %s", volumeName)
+		}
+	case len(link.Volumes) == 1:
+		volume = &link.Volumes[0]
+	default:
+		var names []string
+		for _, v := range link.Volumes {
+			names = append(names, v.Name)
+		}
+		return "", fmt.Errorf("--volume is required, the export has the volumes %s", strings.Join(names, ", "))
+	}
+
+	for _, f := range volume.Formats {
+		if string(f.Format) == format {
+			return f.Url, nil
+		}
+	}
+	return "", fmt.Errorf("volume %s is not available in format %s", volume.Name, format)
+}
+
+func download(url string, token string, cert string, target string) error {
+	tlsConfig := &tls.Config{InsecureSkipVerify: insecure}
+	if cert != "" && !insecure {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM([]byte(cert)) {
+			return fmt.Errorf("the certificate of the export is invalid")
+		}
+		tlsConfig.RootCAs = pool
+	}
+	client := &http.Client{
+		Transport: &http.Transport{
+			Proxy:           http.ProxyFromEnvironment,
+			TLSClientConfig: tlsConfig,
+		},
+	}
+
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set(vmexport.TokenHeader, token)
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected response %s", resp.Status)
+	}
+
+	out, err := os.Create(target)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	if _, err := io.Copy(out, resp.Body); err != nil {
+		os.Remove(target)
+		return err
+	}
+	return nil
+}
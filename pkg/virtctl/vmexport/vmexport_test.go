@@ -0,0 +1,156 @@
+package vmexport_test
+
+import (
+	"bytes"
+	"encoding/pem"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+
+	k8sv1 "k8s.io/api/core/v1"
+	k8smetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/client-go/kubecli"
+	vmexport "kubevirt.io/kubevirt/pkg/vm-export"
+	"kubevirt.io/kubevirt/tests"
+)
+
+var _ = Describe("VMExport", func() {
+
+	const exportName = "testexport"
+	var exportInterface *kubecli.MockVirtualMachineExportInterface
+	var ctrl *gomock.Controller
+	var server *httptest.Server
+	var tmpDir string
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		kubecli.GetKubevirtClientFromClientConfig = kubecli.GetMockKubevirtClientFromClientConfig
+		kubecli.MockKubevirtClientInstance = kubecli.NewMockKubevirtClient(ctrl)
+		exportInterface = kubecli.NewMockVirtualMachineExportInterface(ctrl)
+
+		server = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get(vmexport.TokenHeader) != "secret-token" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			w.Write([]byte(r.URL.Path))
+		}))
+
+		var err error
+		tmpDir, err = ioutil.TempDir("", "vmexport")
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		server.Close()
+		os.RemoveAll(tmpDir)
+		ctrl.Finish()
+	})
+
+	newReadyExport := func(volumes ...string) *v1.VirtualMachineExport {
+		export := kubecli.NewMinimalExport(exportName)
+		export.Spec.TokenSecretRef = "token"
+		export.Status.Phase = v1.ExportReady
+		cert := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+		link := &v1.VirtualMachineExportLink{Cert: string(cert)}
+		for _, name := range volumes {
+			link.Volumes = append(link.Volumes, v1.VirtualMachineExportVolume{
+				Name: name,
+				Formats: []v1.VirtualMachineExportVolumeFormat{
+					{Format: v1.ExportVolumeFormatRaw, Url: server.URL + "/volumes/" + name + "/disk.img"},
+					{Format: v1.ExportVolumeFormatGzip, Url: server.URL + "/volumes/" + name + "/disk.img.gz"},
+				},
+			})
+		}
+		export.Status.Links = &v1.VirtualMachineExportLinks{Internal: link}
+		return export
+	}
+
+	expectExport := func(export *v1.VirtualMachineExport) {
+		kubecli.MockKubevirtClientInstance.EXPECT().VirtualMachineExport(k8smetav1.NamespaceDefault).Return(exportInterface).Times(1)
+		exportInterface.EXPECT().Get(exportName, gomock.Any()).Return(export, nil).Times(1)
+	}
+
+	expectToken := func() {
+		kubeClient := fake.NewSimpleClientset(&k8sv1.Secret{
+			ObjectMeta: k8smetav1.ObjectMeta{Name: "token", Namespace: k8smetav1.NamespaceDefault},
+			Data:       map[string][]byte{vmexport.TokenSecretKey: []byte("secret-token")},
+		})
+		kubecli.MockKubevirtClientInstance.EXPECT().CoreV1().Return(kubeClient.CoreV1()).Times(1)
+	}
+
+	It("should download the only volume of the export", func() {
+		expectExport(newReadyExport("disk"))
+		expectToken()
+
+		output := filepath.Join(tmpDir, "disk.img")
+		cmd := tests.NewVirtctlCommand("vmexport", "download", exportName, "--output", output)
+		cmd.SetOutput(&bytes.Buffer{})
+		Expect(cmd.Execute()).To(Succeed())
+
+		content, err := ioutil.ReadFile(output)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(content)).To(Equal("/volumes/disk/disk.img"))
+	})
+
+	It("should download the selected volume in the selected format", func() {
+		expectExport(newReadyExport("rootdisk", "datadisk"))
+		expectToken()
+
+		output := filepath.Join(tmpDir, "datadisk.img.gz")
+		cmd := tests.NewVirtctlCommand("vmexport", "download", exportName, "--output", output, "--volume", "datadisk", "--format", "gzip")
+		cmd.SetOutput(&bytes.Buffer{})
+		Expect(cmd.Execute()).To(Succeed())
+
+		content, err := ioutil.ReadFile(output)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(content)).To(Equal("/volumes/datadisk/disk.img.gz"))
+	})
+
+	It("should require a volume if the export has several", func() {
+		expectExport(newReadyExport("rootdisk", "datadisk"))
+
+		cmd := tests.NewVirtctlCommand("vmexport", "download", exportName, "--output", filepath.Join(tmpDir, "disk.img"))
+		cmd.SetOutput(&bytes.Buffer{})
+		Expect(cmd.Execute()).To(MatchError(ContainSubstring("the export has the volumes rootdisk, datadisk")))
+	})
+
+	It("should fail if the export is not ready", func() {
+		export := kubecli.NewMinimalExport(exportName)
+		export.Status.Phase = v1.ExportPending
+		export.Status.Message = "VirtualMachine testvm is running"
+		expectExport(export)
+
+		cmd := tests.NewVirtctlCommand("vmexport", "download", exportName, "--output", filepath.Join(tmpDir, "disk.img"))
+		cmd.SetOutput(&bytes.Buffer{})
+		Expect(cmd.Execute()).To(MatchError(ContainSubstring("VirtualMachine testvm is running")))
+	})
+
+	It("should fail if the export has no manifest", func() {
+		expectExport(newReadyExport("disk"))
+
+		cmd := tests.NewVirtctlCommand("vmexport", "download", exportName, "--manifest", "--output", filepath.Join(tmpDir, "vm.yaml"))
+		cmd.SetOutput(&bytes.Buffer{})
+		Expect(cmd.Execute()).To(MatchError(ContainSubstring("the export has no manifest")))
+	})
+
+	table.DescribeTable("should reject invalid arguments", func(message string, args ...string) {
+		cmd := tests.NewVirtctlCommand(append([]string{"vmexport"}, args...)...)
+		cmd.SetOutput(&bytes.Buffer{})
+		Expect(cmd.Execute()).To(MatchError(ContainSubstring(message)))
+	},
+		table.Entry("without an output file", "--output is required", "download", exportName),
+		table.Entry("with an unknown format", "unknown format", "download", exportName, "--output", "disk.img", "--format", "qcow2"),
+		table.Entry("with an unknown action", "unknown action", "upload", exportName),
+	)
+})
@@ -58,6 +58,8 @@ const (
 	REMOTE_VIEWER = "remote-viewer"
 )
 
+var readOnly bool
+
 func NewCommand(clientConfig clientcmd.ClientConfig) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:     "vnc (VMI)",
@@ -69,6 +71,7 @@ func NewCommand(clientConfig clientcmd.ClientConfig) *cobra.Command {
 			return c.Run(cmd, args)
 		},
 	}
+	cmd.Flags().BoolVar(&readOnly, "readonly", false, "Only watch the screen, without disconnecting other users. Keyboard and mouse input is discarded.")
 	cmd.SetUsageTemplate(templates.UsageTemplate())
 	return cmd
 }
@@ -91,7 +94,12 @@ func (o *VNC) Run(cmd *cobra.Command, args []string) error {
 	}
 
 	// setup connection with VM
-	vnc, err := virtCli.VirtualMachineInstance(namespace).VNC(vmi)
+	var vnc kubecli.StreamInterface
+	if readOnly {
+		vnc, err = virtCli.VirtualMachineInstance(namespace).VNCReadOnly(vmi)
+	} else {
+		vnc, err = virtCli.VirtualMachineInstance(namespace).VNC(vmi)
+	}
 	if err != nil {
 		return fmt.Errorf("Can't access VMI %s: %s", vmi, err.Error())
 	}
@@ -291,5 +299,7 @@ func remoteViewerArgs(port int) (args []string) {
 
 func usage() string {
 	return `  # Connect to 'testvmi' via remote-viewer:\n"
-  {{ProgramName}} vnc testvmi`
+  {{ProgramName}} vnc testvmi
+  # Watch the screen of 'testvmi' without taking it over:
+  {{ProgramName}} vnc --readonly testvmi`
 }
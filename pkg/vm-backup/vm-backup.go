@@ -0,0 +1,94 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2020 Red Hat, Inc.
+ *
+ */
+
+package vmbackup
+
+import (
+	"path/filepath"
+
+	"k8s.io/apimachinery/pkg/types"
+
+	v1 "kubevirt.io/client-go/api/v1"
+)
+
+// A backup job runs inside the virt-launcher pod. qemu writes the blocks which
+// are overwritten by the guest into scratch images before they change and
+// exports a point-in-time view of every disk over a NBD server. The NBD server
+// listens on a unix socket in a directory on the node, where backup
+// applications or a backup pod on the same node read the exports.
+const (
+	// VolumeName is the name of the pod volume holding the node directory of the backup jobs
+	VolumeName = "backup"
+	// LauncherDir is the mount path of the node directory in the virt-launcher and the backup pod
+	LauncherDir = "/var/run/kubevirt-backup"
+	// ClaimVolumeName is the name of the backup pod volume for the target PVC
+	ClaimVolumeName = "backup-claim"
+	// ClaimDir is the mount path of the target PVC in the backup pod
+	ClaimDir = "/backup"
+	// PodNamePrefix is prepended to the VirtualMachineBackup name to form the backup pod name
+	PodNamePrefix = "virt-backup-"
+	// BackupLabel carries the name of the VirtualMachineBackup on its backup pod
+	BackupLabel = "kubevirt.io/backup"
+
+	hostBaseDirName          = "backups"
+	socketName               = "nbd.sock"
+	scratchSuffix            = ".scratch.qcow2"
+	exportBitmapPrefix       = "backup-"
+	dirtyBitmapContextPrefix = "qemu:dirty-bitmap:"
+)
+
+// GenerateHostDir returns the node directory which holds the backup jobs of a VirtualMachineInstance
+func GenerateHostDir(virtLibDir string, uid types.UID) string {
+	return filepath.Join(virtLibDir, hostBaseDirName, string(uid))
+}
+
+// SocketPath returns the path of the NBD server socket in dir
+func SocketPath(dir string) string {
+	return filepath.Join(dir, socketName)
+}
+
+// ScratchFilePath returns the path of the image which holds the overwritten blocks of a volume during a backup job
+func ScratchFilePath(dir string, checkpoint string, volumeName string) string {
+	return filepath.Join(dir, checkpoint+"-"+volumeName+scratchSuffix)
+}
+
+// ExportBitmapName returns the name of the frozen copy of the base checkpoint which is exported by an incremental backup job
+func ExportBitmapName(checkpoint string) string {
+	return exportBitmapPrefix + checkpoint
+}
+
+// DirtyBitmapContext returns the NBD meta context under which the changed blocks of an incremental backup are reported
+func DirtyBitmapContext(checkpoint string) string {
+	return dirtyBitmapContextPrefix + ExportBitmapName(checkpoint)
+}
+
+// PodName returns the name of the backup pod of a VirtualMachineBackup
+func PodName(backup *v1.VirtualMachineBackup) string {
+	return PodNamePrefix + backup.Name
+}
+
+// TargetFileName returns the name of the image a volume is written to in the target PVC
+func TargetFileName(backup *v1.VirtualMachineBackup, volumeName string) string {
+	return backup.Name + "-" + volumeName + ".qcow2"
+}
+
+// IsBackupVolume returns true if the volume holds persistent data which is part of backups
+func IsBackupVolume(volume *v1.Volume) bool {
+	return volume.PersistentVolumeClaim != nil || volume.DataVolume != nil || volume.HostDisk != nil
+}
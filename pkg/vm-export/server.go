@@ -0,0 +1,125 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2020 Red Hat, Inc.
+ *
+ */
+
+package vmexport
+
+import (
+	"compress/gzip"
+	"crypto/subtle"
+	"io"
+	"net/http"
+	"os"
+	"time"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/client-go/log"
+)
+
+// Server serves the volumes and the manifest of an export
+type Server struct {
+	// Token clients have to present
+	Token string
+	// PathPrefix all paths are served under
+	PathPrefix string
+	// Volumes maps the exported volume names to their raw images, files or block devices
+	Volumes map[string]string
+	// ManifestFile holds the manifest of the exported VirtualMachine, empty for other sources
+	ManifestFile string
+}
+
+// Handler returns the handler serving the export
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	for name, imagePath := range s.Volumes {
+		mux.Handle(VolumeURLPath(s.PathPrefix, name, v1.ExportVolumeFormatRaw), rawHandler(imagePath))
+		mux.Handle(VolumeURLPath(s.PathPrefix, name, v1.ExportVolumeFormatGzip), gzipHandler(imagePath))
+	}
+	if s.ManifestFile != "" {
+		mux.Handle(ManifestURLPath(s.PathPrefix, v1.ExportManifestTypeAll), manifestHandler(s.ManifestFile))
+	}
+	return s.authorize(mux)
+}
+
+func (s *Server) authorize(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token := r.Header.Get(TokenHeader)
+		if token == "" {
+			token = r.URL.Query().Get(TokenHeader)
+		}
+		if subtle.ConstantTimeCompare([]byte(token), []byte(s.Token)) != 1 {
+			http.Error(w, "invalid export token", http.StatusUnauthorized)
+			return
+		}
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// rawHandler serves the image as is, which supports range requests to resume downloads
+func rawHandler(imagePath string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		image, err := os.Open(imagePath)
+		if err != nil {
+			log.Log.Reason(err).Errorf("Failed to open %s", imagePath)
+			http.Error(w, "failed to open the volume", http.StatusInternalServerError)
+			return
+		}
+		defer image.Close()
+
+		w.Header().Set("Content-Type", "application/octet-stream")
+		// ServeContent determines the size by seeking, which works for block devices too
+		http.ServeContent(w, r, diskImageName, time.Time{}, image)
+	})
+}
+
+func gzipHandler(imagePath string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		image, err := os.Open(imagePath)
+		if err != nil {
+			log.Log.Reason(err).Errorf("Failed to open %s", imagePath)
+			http.Error(w, "failed to open the volume", http.StatusInternalServerError)
+			return
+		}
+		defer image.Close()
+
+		w.Header().Set("Content-Type", "application/gzip")
+		if r.Method == http.MethodHead {
+			return
+		}
+		gzipWriter := gzip.NewWriter(w)
+		if _, err := io.Copy(gzipWriter, image); err != nil {
+			// The status is sent already, the client sees a truncated stream
+			log.Log.Reason(err).Errorf("Failed to send %s", imagePath)
+			return
+		}
+		if err := gzipWriter.Close(); err != nil {
+			log.Log.Reason(err).Errorf("Failed to send %s", imagePath)
+		}
+	})
+}
+
+func manifestHandler(manifestFile string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/yaml")
+		http.ServeFile(w, r, manifestFile)
+	})
+}
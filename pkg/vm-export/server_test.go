@@ -0,0 +1,131 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2020 Red Hat, Inc.
+ *
+ */
+package vmexport
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Server", func() {
+
+	const prefix = "/export/default/testexport"
+	var tmpDir string
+	var server *httptest.Server
+	content := bytes.Repeat([]byte("0123456789"), 1000)
+
+	BeforeEach(func() {
+		var err error
+		tmpDir, err = ioutil.TempDir("", "vmexport")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ioutil.WriteFile(filepath.Join(tmpDir, "disk.img"), content, 0644)).To(Succeed())
+		Expect(ioutil.WriteFile(filepath.Join(tmpDir, "manifest.yaml"), []byte("kind: VirtualMachine\n"), 0644)).To(Succeed())
+
+		s := &Server{
+			Token:        "secret",
+			PathPrefix:   prefix,
+			Volumes:      map[string]string{"rootdisk": filepath.Join(tmpDir, "disk.img")},
+			ManifestFile: filepath.Join(tmpDir, "manifest.yaml"),
+		}
+		server = httptest.NewServer(s.Handler())
+	})
+
+	AfterEach(func() {
+		server.Close()
+		os.RemoveAll(tmpDir)
+	})
+
+	get := func(path string, header http.Header) *http.Response {
+		req, err := http.NewRequest(http.MethodGet, server.URL+path, nil)
+		Expect(err).ToNot(HaveOccurred())
+		for key, values := range header {
+			req.Header[key] = values
+		}
+		resp, err := http.DefaultClient.Do(req)
+		Expect(err).ToNot(HaveOccurred())
+		return resp
+	}
+
+	withToken := http.Header{TokenHeader: []string{"secret"}}
+
+	It("should reject requests without a valid token", func() {
+		resp := get(prefix+"/volumes/rootdisk/disk.img", nil)
+		resp.Body.Close()
+		Expect(resp.StatusCode).To(Equal(http.StatusUnauthorized))
+
+		resp = get(prefix+"/volumes/rootdisk/disk.img", http.Header{TokenHeader: []string{"wrong"}})
+		resp.Body.Close()
+		Expect(resp.StatusCode).To(Equal(http.StatusUnauthorized))
+	})
+
+	It("should accept the token as query parameter", func() {
+		resp := get(prefix+"/volumes/rootdisk/disk.img?"+TokenHeader+"=secret", nil)
+		defer resp.Body.Close()
+		Expect(resp.StatusCode).To(Equal(http.StatusOK))
+	})
+
+	It("should serve the raw image with range support", func() {
+		resp := get(prefix+"/volumes/rootdisk/disk.img", withToken)
+		body, err := ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(body).To(Equal(content))
+
+		header := http.Header{TokenHeader: []string{"secret"}, "Range": []string{"bytes=10-19"}}
+		resp = get(prefix+"/volumes/rootdisk/disk.img", header)
+		body, err = ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(resp.StatusCode).To(Equal(http.StatusPartialContent))
+		Expect(body).To(Equal(content[10:20]))
+	})
+
+	It("should serve the gzip compressed image", func() {
+		resp := get(prefix+"/volumes/rootdisk/disk.img.gz", withToken)
+		defer resp.Body.Close()
+		Expect(resp.StatusCode).To(Equal(http.StatusOK))
+		reader, err := gzip.NewReader(resp.Body)
+		Expect(err).ToNot(HaveOccurred())
+		body, err := ioutil.ReadAll(reader)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(body).To(Equal(content))
+	})
+
+	It("should serve the manifest", func() {
+		resp := get(prefix+"/manifests/all", withToken)
+		body, err := ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(body)).To(Equal("kind: VirtualMachine\n"))
+	})
+
+	It("should not serve unknown volumes", func() {
+		resp := get(prefix+"/volumes/other/disk.img", withToken)
+		resp.Body.Close()
+		Expect(resp.StatusCode).To(Equal(http.StatusNotFound))
+	})
+})
@@ -0,0 +1,150 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2020 Red Hat, Inc.
+ *
+ */
+
+package vmexport
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+
+	v1 "kubevirt.io/client-go/api/v1"
+)
+
+// An exporter pod mounts the volumes of an export read-only and serves them
+// over HTTPS behind a service of the same name. Clients authenticate with the
+// token from the token secret of the export. The TLS certificate and, for
+// VirtualMachine sources, the manifest of the VirtualMachine are handed to the
+// exporter in a secret of the same name as well.
+const (
+	// NamePrefix is prepended to the VirtualMachineExport name to form the name of its pod, service, secret and ingress
+	NamePrefix = "virt-export-"
+	// ExportLabel carries the name of the VirtualMachineExport on its exporter pod and service
+	ExportLabel = "kubevirt.io/export"
+	// ContainerName is the name of the exporter pod container
+	ContainerName = "exportserver"
+	// TokenHeader is the header, and the query parameter, clients pass the token in
+	TokenHeader = "x-kubevirt-export-token"
+	// TokenSecretKey is the key of the token in the token secret of an export
+	TokenSecretKey = "token"
+	// TokenEnvVar passes the token to the exporter
+	TokenEnvVar = "EXPORT_TOKEN"
+	// SecretVolumeName is the name of the exporter pod volume for the secret of the export
+	SecretVolumeName = "export-secret"
+	// SecretDir is the mount path of the secret of the export in the exporter pod
+	SecretDir = "/etc/virt-exportserver"
+	// CertKey is the key of the server certificate in the secret of an export
+	CertKey = "tls.crt"
+	// KeyKey is the key of the server key in the secret of an export
+	KeyKey = "tls.key"
+	// CAKey is the key of the CA certificate in the secret of an export
+	CAKey = "ca.crt"
+	// ManifestKey is the key of the VirtualMachine manifest in the secret of an export
+	ManifestKey = "manifest.yaml"
+	// VolumesDir holds the mounted volumes in the exporter pod
+	VolumesDir = "/export-volumes"
+	// Port the exporter listens on
+	Port = 8443
+	// ServicePort is the port of the exporter service
+	ServicePort = 443
+
+	diskImageName = "disk.img"
+	gzipSuffix    = ".gz"
+)
+
+// Volume is a volume of an export
+type Volume struct {
+	// Name is the name the volume is exported as
+	Name string
+	// ClaimName is the PVC holding the volume
+	ClaimName string
+}
+
+// Name returns the name of the pod, service, secret and ingress of a VirtualMachineExport
+func Name(export *v1.VirtualMachineExport) string {
+	return NamePrefix + export.Name
+}
+
+// ServiceHost returns the cluster internal host name of the exporter service
+func ServiceHost(export *v1.VirtualMachineExport) string {
+	return fmt.Sprintf("%s.%s.svc", Name(export), export.Namespace)
+}
+
+// PathPrefix returns the path all links of an export start with. The prefix
+// is unique per export, so that a shared ingress host can route to the exporters.
+func PathPrefix(export *v1.VirtualMachineExport) string {
+	return path.Join("/export", export.Namespace, export.Name)
+}
+
+// VolumeURLPath returns the path a volume is served at in the given format
+func VolumeURLPath(prefix string, volumeName string, format v1.ExportVolumeFormat) string {
+	p := path.Join(prefix, "volumes", volumeName, diskImageName)
+	if format == v1.ExportVolumeFormatGzip {
+		p += gzipSuffix
+	}
+	return p
+}
+
+// ManifestURLPath returns the path a manifest is served at
+func ManifestURLPath(prefix string, manifestType v1.ExportManifestType) string {
+	return path.Join(prefix, "manifests", string(manifestType))
+}
+
+// VolumeImagePath returns the path of the raw image of a volume in the exporter pod
+func VolumeImagePath(volumeName string, block bool) string {
+	if block {
+		return filepath.Join(VolumesDir, volumeName+".img")
+	}
+	return filepath.Join(VolumesDir, volumeName, diskImageName)
+}
+
+// VolumeMountPath returns the directory a filesystem volume is mounted at in the exporter pod
+func VolumeMountPath(volumeName string) string {
+	return filepath.Join(VolumesDir, volumeName)
+}
+
+// GenerateLink returns the links of an export for the given base URL
+func GenerateLink(export *v1.VirtualMachineExport, baseURL string, cert string, volumes []Volume, withManifest bool) *v1.VirtualMachineExportLink {
+	prefix := PathPrefix(export)
+	link := &v1.VirtualMachineExportLink{
+		Cert: cert,
+	}
+	for _, volume := range volumes {
+		link.Volumes = append(link.Volumes, v1.VirtualMachineExportVolume{
+			Name: volume.Name,
+			Formats: []v1.VirtualMachineExportVolumeFormat{
+				{
+					Format: v1.ExportVolumeFormatRaw,
+					Url:    baseURL + VolumeURLPath(prefix, volume.Name, v1.ExportVolumeFormatRaw),
+				},
+				{
+					Format: v1.ExportVolumeFormatGzip,
+					Url:    baseURL + VolumeURLPath(prefix, volume.Name, v1.ExportVolumeFormatGzip),
+				},
+			},
+		})
+	}
+	if withManifest {
+		link.Manifests = append(link.Manifests, v1.VirtualMachineExportManifest{
+			Type: v1.ExportManifestTypeAll,
+			Url:  baseURL + ManifestURLPath(prefix, v1.ExportManifestTypeAll),
+		})
+	}
+	return link
+}
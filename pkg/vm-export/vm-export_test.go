@@ -0,0 +1,65 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2020 Red Hat, Inc.
+ *
+ */
+package vmexport
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "kubevirt.io/client-go/api/v1"
+)
+
+var _ = Describe("VMExport", func() {
+
+	export := &v1.VirtualMachineExport{ObjectMeta: metav1.ObjectMeta{Name: "testexport", Namespace: "default"}}
+
+	It("should derive the names from the export", func() {
+		Expect(Name(export)).To(Equal("virt-export-testexport"))
+		Expect(ServiceHost(export)).To(Equal("virt-export-testexport.default.svc"))
+		Expect(PathPrefix(export)).To(Equal("/export/default/testexport"))
+	})
+
+	It("should place filesystem and block volumes apart", func() {
+		Expect(VolumeImagePath("rootdisk", false)).To(Equal("/export-volumes/rootdisk/disk.img"))
+		Expect(VolumeImagePath("rootdisk", true)).To(Equal("/export-volumes/rootdisk.img"))
+	})
+
+	It("should generate links for all volumes and formats", func() {
+		link := GenerateLink(export, "https://example.com", "CERT", []Volume{{Name: "rootdisk", ClaimName: "rootdisk-pvc"}}, true)
+		Expect(link.Cert).To(Equal("CERT"))
+		Expect(link.Volumes).To(Equal([]v1.VirtualMachineExportVolume{
+			{
+				Name: "rootdisk",
+				Formats: []v1.VirtualMachineExportVolumeFormat{
+					{Format: v1.ExportVolumeFormatRaw, Url: "https://example.com/export/default/testexport/volumes/rootdisk/disk.img"},
+					{Format: v1.ExportVolumeFormatGzip, Url: "https://example.com/export/default/testexport/volumes/rootdisk/disk.img.gz"},
+				},
+			},
+		}))
+		Expect(link.Manifests).To(Equal([]v1.VirtualMachineExportManifest{
+			{Type: v1.ExportManifestTypeAll, Url: "https://example.com/export/default/testexport/manifests/all"},
+		}))
+	})
+
+	It("should not link a manifest for PVC sources", func() {
+		link := GenerateLink(export, "https://example.com", "", []Volume{{Name: "data", ClaimName: "data"}}, false)
+		Expect(link.Manifests).To(BeEmpty())
+	})
+})
@@ -29,6 +29,69 @@ import (
 	v1alpha1 "kubevirt.io/containerized-data-importer/pkg/apis/core/v1alpha1"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AccessCredential) DeepCopyInto(out *AccessCredential) {
+	*out = *in
+	if in.SSHPublicKey != nil {
+		in, out := &in.SSHPublicKey, &out.SSHPublicKey
+		*out = new(SSHPublicKeyAccessCredential)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.UserPassword != nil {
+		in, out := &in.UserPassword, &out.UserPassword
+		*out = new(UserPasswordAccessCredential)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AccessCredential.
+func (in *AccessCredential) DeepCopy() *AccessCredential {
+	if in == nil {
+		return nil
+	}
+	out := new(AccessCredential)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AccessCredentialSecretSource) DeepCopyInto(out *AccessCredentialSecretSource) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AccessCredentialSecretSource.
+func (in *AccessCredentialSecretSource) DeepCopy() *AccessCredentialSecretSource {
+	if in == nil {
+		return nil
+	}
+	out := new(AccessCredentialSecretSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AccessCredentialSource) DeepCopyInto(out *AccessCredentialSource) {
+	*out = *in
+	if in.Secret != nil {
+		in, out := &in.Secret, &out.Secret
+		*out = new(AccessCredentialSecretSource)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AccessCredentialSource.
+func (in *AccessCredentialSource) DeepCopy() *AccessCredentialSource {
+	if in == nil {
+		return nil
+	}
+	out := new(AccessCredentialSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BIOS) DeepCopyInto(out *BIOS) {
 	*out = *in
@@ -129,6 +192,32 @@ func (in *CPUFeature) DeepCopy() *CPUFeature {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CertConfig) DeepCopyInto(out *CertConfig) {
+	*out = *in
+	if in.Duration != nil {
+		in, out := &in.Duration, &out.Duration
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.RenewBefore != nil {
+		in, out := &in.RenewBefore, &out.RenewBefore
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertConfig.
+func (in *CertConfig) DeepCopy() *CertConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(CertConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Chassis) DeepCopyInto(out *Chassis) {
 	*out = *in
@@ -266,6 +355,48 @@ func (in *CloudInitNoCloudSource) DeepCopy() *CloudInitNoCloudSource {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloudInitSSHPublicKeyAccessCredentialPropagation) DeepCopyInto(out *CloudInitSSHPublicKeyAccessCredentialPropagation) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudInitSSHPublicKeyAccessCredentialPropagation.
+func (in *CloudInitSSHPublicKeyAccessCredentialPropagation) DeepCopy() *CloudInitSSHPublicKeyAccessCredentialPropagation {
+	if in == nil {
+		return nil
+	}
+	out := new(CloudInitSSHPublicKeyAccessCredentialPropagation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ComponentConfig) DeepCopyInto(out *ComponentConfig) {
+	*out = *in
+	if in.NodePlacement != nil {
+		in, out := &in.NodePlacement, &out.NodePlacement
+		*out = new(NodePlacement)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(uint8)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComponentConfig.
+func (in *ComponentConfig) DeepCopy() *ComponentConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ComponentConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ConfigMapVolumeSource) DeepCopyInto(out *ConfigMapVolumeSource) {
 	*out = *in
@@ -288,6 +419,144 @@ func (in *ConfigMapVolumeSource) DeepCopy() *ConfigMapVolumeSource {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ContainerDiskImageCache) DeepCopyInto(out *ContainerDiskImageCache) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ContainerDiskImageCache.
+func (in *ContainerDiskImageCache) DeepCopy() *ContainerDiskImageCache {
+	if in == nil {
+		return nil
+	}
+	out := new(ContainerDiskImageCache)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ContainerDiskImageCache) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ContainerDiskImageCacheList) DeepCopyInto(out *ContainerDiskImageCacheList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ContainerDiskImageCache, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ContainerDiskImageCacheList.
+func (in *ContainerDiskImageCacheList) DeepCopy() *ContainerDiskImageCacheList {
+	if in == nil {
+		return nil
+	}
+	out := new(ContainerDiskImageCacheList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ContainerDiskImageCacheList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ContainerDiskImageCacheNodeStatus) DeepCopyInto(out *ContainerDiskImageCacheNodeStatus) {
+	*out = *in
+	if in.CachedImages != nil {
+		in, out := &in.CachedImages, &out.CachedImages
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ContainerDiskImageCacheNodeStatus.
+func (in *ContainerDiskImageCacheNodeStatus) DeepCopy() *ContainerDiskImageCacheNodeStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ContainerDiskImageCacheNodeStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ContainerDiskImageCacheSpec) DeepCopyInto(out *ContainerDiskImageCacheSpec) {
+	*out = *in
+	if in.Images != nil {
+		in, out := &in.Images, &out.Images
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.ImagePullSecrets != nil {
+		in, out := &in.ImagePullSecrets, &out.ImagePullSecrets
+		*out = make([]corev1.LocalObjectReference, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ContainerDiskImageCacheSpec.
+func (in *ContainerDiskImageCacheSpec) DeepCopy() *ContainerDiskImageCacheSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ContainerDiskImageCacheSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ContainerDiskImageCacheStatus) DeepCopyInto(out *ContainerDiskImageCacheStatus) {
+	*out = *in
+	if in.Nodes != nil {
+		in, out := &in.Nodes, &out.Nodes
+		*out = make([]ContainerDiskImageCacheNodeStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ContainerDiskImageCacheStatus.
+func (in *ContainerDiskImageCacheStatus) DeepCopy() *ContainerDiskImageCacheStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ContainerDiskImageCacheStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ContainerDiskSource) DeepCopyInto(out *ContainerDiskSource) {
 	*out = *in
@@ -304,6 +573,59 @@ func (in *ContainerDiskSource) DeepCopy() *ContainerDiskSource {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ContainerDiskStatus) DeepCopyInto(out *ContainerDiskStatus) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ContainerDiskStatus.
+func (in *ContainerDiskStatus) DeepCopy() *ContainerDiskStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ContainerDiskStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CustomizeComponents) DeepCopyInto(out *CustomizeComponents) {
+	*out = *in
+	if in.Patches != nil {
+		in, out := &in.Patches, &out.Patches
+		*out = make([]CustomizeComponentsPatch, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CustomizeComponents.
+func (in *CustomizeComponents) DeepCopy() *CustomizeComponents {
+	if in == nil {
+		return nil
+	}
+	out := new(CustomizeComponents)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CustomizeComponentsPatch) DeepCopyInto(out *CustomizeComponentsPatch) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CustomizeComponentsPatch.
+func (in *CustomizeComponentsPatch) DeepCopy() *CustomizeComponentsPatch {
+	if in == nil {
+		return nil
+	}
+	out := new(CustomizeComponentsPatch)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DHCPOptions) DeepCopyInto(out *DHCPOptions) {
 	*out = *in
@@ -362,6 +684,34 @@ func (in *DataVolumeSource) DeepCopy() *DataVolumeSource {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeveloperConfiguration) DeepCopyInto(out *DeveloperConfiguration) {
+	*out = *in
+	if in.FeatureGates != nil {
+		in, out := &in.FeatureGates, &out.FeatureGates
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.NodeSelectors != nil {
+		in, out := &in.NodeSelectors, &out.NodeSelectors
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeveloperConfiguration.
+func (in *DeveloperConfiguration) DeepCopy() *DeveloperConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(DeveloperConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Devices) DeepCopyInto(out *Devices) {
 	*out = *in
@@ -399,6 +749,11 @@ func (in *Devices) DeepCopyInto(out *Devices) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.LogSerialConsole != nil {
+		in, out := &in.LogSerialConsole, &out.LogSerialConsole
+		*out = new(bool)
+		**out = **in
+	}
 	if in.Rng != nil {
 		in, out := &in.Rng, &out.Rng
 		*out = new(Rng)
@@ -419,6 +774,11 @@ func (in *Devices) DeepCopyInto(out *Devices) {
 		*out = make([]GPU, len(*in))
 		copy(*out, *in)
 	}
+	if in.USBRedirect != nil {
+		in, out := &in.USBRedirect, &out.USBRedirect
+		*out = new(USBRedirect)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -446,6 +806,11 @@ func (in *Disk) DeepCopyInto(out *Disk) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.Shareable != nil {
+		in, out := &in.Shareable, &out.Shareable
+		*out = new(bool)
+		**out = **in
+	}
 	return
 }
 
@@ -834,6 +1199,11 @@ func (in *Firmware) DeepCopyInto(out *Firmware) {
 		*out = new(Bootloader)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.KernelBoot != nil {
+		in, out := &in.KernelBoot, &out.KernelBoot
+		*out = new(KernelBoot)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -895,6 +1265,22 @@ func (in *GenieNetwork) DeepCopy() *GenieNetwork {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GuestAgentPing) DeepCopyInto(out *GuestAgentPing) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GuestAgentPing.
+func (in *GuestAgentPing) DeepCopy() *GuestAgentPing {
+	if in == nil {
+		return nil
+	}
+	out := new(GuestAgentPing)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HPETTimer) DeepCopyInto(out *HPETTimer) {
 	*out = *in
@@ -929,6 +1315,16 @@ func (in *Handler) DeepCopyInto(out *Handler) {
 		*out = new(corev1.TCPSocketAction)
 		**out = **in
 	}
+	if in.Exec != nil {
+		in, out := &in.Exec, &out.Exec
+		*out = new(corev1.ExecAction)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.GuestAgentPing != nil {
+		in, out := &in.GuestAgentPing, &out.GuestAgentPing
+		*out = new(GuestAgentPing)
+		**out = **in
+	}
 	return
 }
 
@@ -1088,6 +1484,11 @@ func (in *InterfaceBindingMethod) DeepCopyInto(out *InterfaceBindingMethod) {
 		*out = new(InterfaceSRIOV)
 		**out = **in
 	}
+	if in.Macvtap != nil {
+		in, out := &in.Macvtap, &out.Macvtap
+		*out = new(InterfaceMacvtap)
+		**out = **in
+	}
 	return
 }
 
@@ -1117,6 +1518,22 @@ func (in *InterfaceBridge) DeepCopy() *InterfaceBridge {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InterfaceMacvtap) DeepCopyInto(out *InterfaceMacvtap) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InterfaceMacvtap.
+func (in *InterfaceMacvtap) DeepCopy() *InterfaceMacvtap {
+	if in == nil {
+		return nil
+	}
+	out := new(InterfaceMacvtap)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *InterfaceMasquerade) DeepCopyInto(out *InterfaceMasquerade) {
 	*out = *in
@@ -1186,12 +1603,49 @@ func (in *KVMTimer) DeepCopy() *KVMTimer {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KernelBoot) DeepCopyInto(out *KernelBoot) {
+	*out = *in
+	if in.Container != nil {
+		in, out := &in.Container, &out.Container
+		*out = new(KernelBootContainer)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KernelBoot.
+func (in *KernelBoot) DeepCopy() *KernelBoot {
+	if in == nil {
+		return nil
+	}
+	out := new(KernelBoot)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KernelBootContainer) DeepCopyInto(out *KernelBootContainer) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KernelBootContainer.
+func (in *KernelBootContainer) DeepCopy() *KernelBootContainer {
+	if in == nil {
+		return nil
+	}
+	out := new(KernelBootContainer)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KubeVirt) DeepCopyInto(out *KubeVirt) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
+	in.Spec.DeepCopyInto(&out.Spec)
 	in.Status.DeepCopyInto(&out.Status)
 	return
 }
@@ -1214,6 +1668,27 @@ func (in *KubeVirt) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubeVirtCertificateRotateStrategy) DeepCopyInto(out *KubeVirtCertificateRotateStrategy) {
+	*out = *in
+	if in.SelfSigned != nil {
+		in, out := &in.SelfSigned, &out.SelfSigned
+		*out = new(KubeVirtSelfSignConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeVirtCertificateRotateStrategy.
+func (in *KubeVirtCertificateRotateStrategy) DeepCopy() *KubeVirtCertificateRotateStrategy {
+	if in == nil {
+		return nil
+	}
+	out := new(KubeVirtCertificateRotateStrategy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KubeVirtCondition) DeepCopyInto(out *KubeVirtCondition) {
 	*out = *in
@@ -1232,6 +1707,52 @@ func (in *KubeVirtCondition) DeepCopy() *KubeVirtCondition {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubeVirtConfiguration) DeepCopyInto(out *KubeVirtConfiguration) {
+	*out = *in
+	if in.CPURequest != nil {
+		in, out := &in.CPURequest, &out.CPURequest
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.EmulatedMachines != nil {
+		in, out := &in.EmulatedMachines, &out.EmulatedMachines
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.MigrationConfiguration != nil {
+		in, out := &in.MigrationConfiguration, &out.MigrationConfiguration
+		*out = new(MigrationConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NetworkConfiguration != nil {
+		in, out := &in.NetworkConfiguration, &out.NetworkConfiguration
+		*out = new(NetworkConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SMBIOSConfig != nil {
+		in, out := &in.SMBIOSConfig, &out.SMBIOSConfig
+		*out = new(SMBiosConfiguration)
+		**out = **in
+	}
+	if in.DeveloperConfiguration != nil {
+		in, out := &in.DeveloperConfiguration, &out.DeveloperConfiguration
+		*out = new(DeveloperConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeVirtConfiguration.
+func (in *KubeVirtConfiguration) DeepCopy() *KubeVirtConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(KubeVirtConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KubeVirtList) DeepCopyInto(out *KubeVirtList) {
 	*out = *in
@@ -1265,9 +1786,48 @@ func (in *KubeVirtList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubeVirtSelfSignConfiguration) DeepCopyInto(out *KubeVirtSelfSignConfiguration) {
+	*out = *in
+	if in.CA != nil {
+		in, out := &in.CA, &out.CA
+		*out = new(CertConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Server != nil {
+		in, out := &in.Server, &out.Server
+		*out = new(CertConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeVirtSelfSignConfiguration.
+func (in *KubeVirtSelfSignConfiguration) DeepCopy() *KubeVirtSelfSignConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(KubeVirtSelfSignConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KubeVirtSpec) DeepCopyInto(out *KubeVirtSpec) {
 	*out = *in
+	in.CertificateRotationStrategy.DeepCopyInto(&out.CertificateRotationStrategy)
+	in.Configuration.DeepCopyInto(&out.Configuration)
+	if in.Infra != nil {
+		in, out := &in.Infra, &out.Infra
+		*out = new(ComponentConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Workloads != nil {
+		in, out := &in.Workloads, &out.Workloads
+		*out = new(ComponentConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	in.CustomizeComponents.DeepCopyInto(&out.CustomizeComponents)
 	return
 }
 
@@ -1362,6 +1922,62 @@ func (in *Memory) DeepCopy() *Memory {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MigrationConfiguration) DeepCopyInto(out *MigrationConfiguration) {
+	*out = *in
+	if in.ParallelOutboundMigrationsPerNode != nil {
+		in, out := &in.ParallelOutboundMigrationsPerNode, &out.ParallelOutboundMigrationsPerNode
+		*out = new(uint32)
+		**out = **in
+	}
+	if in.ParallelMigrationsPerCluster != nil {
+		in, out := &in.ParallelMigrationsPerCluster, &out.ParallelMigrationsPerCluster
+		*out = new(uint32)
+		**out = **in
+	}
+	if in.BandwidthPerMigration != nil {
+		in, out := &in.BandwidthPerMigration, &out.BandwidthPerMigration
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.NodeDrainTaintKey != nil {
+		in, out := &in.NodeDrainTaintKey, &out.NodeDrainTaintKey
+		*out = new(string)
+		**out = **in
+	}
+	if in.ProgressTimeout != nil {
+		in, out := &in.ProgressTimeout, &out.ProgressTimeout
+		*out = new(int64)
+		**out = **in
+	}
+	if in.CompletionTimeoutPerGiB != nil {
+		in, out := &in.CompletionTimeoutPerGiB, &out.CompletionTimeoutPerGiB
+		*out = new(int64)
+		**out = **in
+	}
+	if in.UnsafeMigrationOverride != nil {
+		in, out := &in.UnsafeMigrationOverride, &out.UnsafeMigrationOverride
+		*out = new(bool)
+		**out = **in
+	}
+	if in.AllowAutoConverge != nil {
+		in, out := &in.AllowAutoConverge, &out.AllowAutoConverge
+		*out = new(bool)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MigrationConfiguration.
+func (in *MigrationConfiguration) DeepCopy() *MigrationConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(MigrationConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MultusNetwork) DeepCopyInto(out *MultusNetwork) {
 	*out = *in
@@ -1378,6 +1994,43 @@ func (in *MultusNetwork) DeepCopy() *MultusNetwork {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NBDBackupStatus) DeepCopyInto(out *NBDBackupStatus) {
+	*out = *in
+	if in.Exports != nil {
+		in, out := &in.Exports, &out.Exports
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NBDBackupStatus.
+func (in *NBDBackupStatus) DeepCopy() *NBDBackupStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(NBDBackupStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NBDBackupTarget) DeepCopyInto(out *NBDBackupTarget) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NBDBackupTarget.
+func (in *NBDBackupTarget) DeepCopy() *NBDBackupTarget {
+	if in == nil {
+		return nil
+	}
+	out := new(NBDBackupTarget)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Network) DeepCopyInto(out *Network) {
 	*out = *in
@@ -1395,6 +2048,32 @@ func (in *Network) DeepCopy() *Network {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkConfiguration) DeepCopyInto(out *NetworkConfiguration) {
+	*out = *in
+	if in.PermitSlirpInterface != nil {
+		in, out := &in.PermitSlirpInterface, &out.PermitSlirpInterface
+		*out = new(bool)
+		**out = **in
+	}
+	if in.PermitBridgeInterfaceOnPodNetwork != nil {
+		in, out := &in.PermitBridgeInterfaceOnPodNetwork, &out.PermitBridgeInterfaceOnPodNetwork
+		*out = new(bool)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkConfiguration.
+func (in *NetworkConfiguration) DeepCopy() *NetworkConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NetworkSource) DeepCopyInto(out *NetworkSource) {
 	*out = *in
@@ -1426,6 +2105,41 @@ func (in *NetworkSource) DeepCopy() *NetworkSource {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodePlacement) DeepCopyInto(out *NodePlacement) {
+	*out = *in
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Affinity != nil {
+		in, out := &in.Affinity, &out.Affinity
+		*out = new(corev1.Affinity)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]corev1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodePlacement.
+func (in *NodePlacement) DeepCopy() *NodePlacement {
+	if in == nil {
+		return nil
+	}
+	out := new(NodePlacement)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PITTimer) DeepCopyInto(out *PITTimer) {
 	*out = *in
@@ -1469,298 +2183,1017 @@ func (in *Port) DeepCopyInto(out *Port) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Port.
-func (in *Port) DeepCopy() *Port {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Port.
+func (in *Port) DeepCopy() *Port {
+	if in == nil {
+		return nil
+	}
+	out := new(Port)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Probe) DeepCopyInto(out *Probe) {
+	*out = *in
+	in.Handler.DeepCopyInto(&out.Handler)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Probe.
+func (in *Probe) DeepCopy() *Probe {
+	if in == nil {
+		return nil
+	}
+	out := new(Probe)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *QemuGuestAgentSSHPublicKeyAccessCredentialPropagation) DeepCopyInto(out *QemuGuestAgentSSHPublicKeyAccessCredentialPropagation) {
+	*out = *in
+	if in.Users != nil {
+		in, out := &in.Users, &out.Users
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QemuGuestAgentSSHPublicKeyAccessCredentialPropagation.
+func (in *QemuGuestAgentSSHPublicKeyAccessCredentialPropagation) DeepCopy() *QemuGuestAgentSSHPublicKeyAccessCredentialPropagation {
+	if in == nil {
+		return nil
+	}
+	out := new(QemuGuestAgentSSHPublicKeyAccessCredentialPropagation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *QemuGuestAgentUserPasswordAccessCredentialPropagation) DeepCopyInto(out *QemuGuestAgentUserPasswordAccessCredentialPropagation) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QemuGuestAgentUserPasswordAccessCredentialPropagation.
+func (in *QemuGuestAgentUserPasswordAccessCredentialPropagation) DeepCopy() *QemuGuestAgentUserPasswordAccessCredentialPropagation {
+	if in == nil {
+		return nil
+	}
+	out := new(QemuGuestAgentUserPasswordAccessCredentialPropagation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RTCTimer) DeepCopyInto(out *RTCTimer) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RTCTimer.
+func (in *RTCTimer) DeepCopy() *RTCTimer {
+	if in == nil {
+		return nil
+	}
+	out := new(RTCTimer)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceRequirements) DeepCopyInto(out *ResourceRequirements) {
+	*out = *in
+	if in.Requests != nil {
+		in, out := &in.Requests, &out.Requests
+		*out = make(corev1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
+	if in.Limits != nil {
+		in, out := &in.Limits, &out.Limits
+		*out = make(corev1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceRequirements.
+func (in *ResourceRequirements) DeepCopy() *ResourceRequirements {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceRequirements)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Rng) DeepCopyInto(out *Rng) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Rng.
+func (in *Rng) DeepCopy() *Rng {
+	if in == nil {
+		return nil
+	}
+	out := new(Rng)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SMBiosConfiguration) DeepCopyInto(out *SMBiosConfiguration) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SMBiosConfiguration.
+func (in *SMBiosConfiguration) DeepCopy() *SMBiosConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(SMBiosConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SSHPublicKeyAccessCredential) DeepCopyInto(out *SSHPublicKeyAccessCredential) {
+	*out = *in
+	in.Source.DeepCopyInto(&out.Source)
+	in.PropagationMethod.DeepCopyInto(&out.PropagationMethod)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SSHPublicKeyAccessCredential.
+func (in *SSHPublicKeyAccessCredential) DeepCopy() *SSHPublicKeyAccessCredential {
+	if in == nil {
+		return nil
+	}
+	out := new(SSHPublicKeyAccessCredential)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SSHPublicKeyAccessCredentialPropagationMethod) DeepCopyInto(out *SSHPublicKeyAccessCredentialPropagationMethod) {
+	*out = *in
+	if in.CloudInit != nil {
+		in, out := &in.CloudInit, &out.CloudInit
+		*out = new(CloudInitSSHPublicKeyAccessCredentialPropagation)
+		**out = **in
+	}
+	if in.QemuGuestAgent != nil {
+		in, out := &in.QemuGuestAgent, &out.QemuGuestAgent
+		*out = new(QemuGuestAgentSSHPublicKeyAccessCredentialPropagation)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SSHPublicKeyAccessCredentialPropagationMethod.
+func (in *SSHPublicKeyAccessCredentialPropagationMethod) DeepCopy() *SSHPublicKeyAccessCredentialPropagationMethod {
+	if in == nil {
+		return nil
+	}
+	out := new(SSHPublicKeyAccessCredentialPropagationMethod)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecretVolumeSource) DeepCopyInto(out *SecretVolumeSource) {
+	*out = *in
+	if in.Optional != nil {
+		in, out := &in.Optional, &out.Optional
+		*out = new(bool)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretVolumeSource.
+func (in *SecretVolumeSource) DeepCopy() *SecretVolumeSource {
+	if in == nil {
+		return nil
+	}
+	out := new(SecretVolumeSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceAccountVolumeSource) DeepCopyInto(out *ServiceAccountVolumeSource) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceAccountVolumeSource.
+func (in *ServiceAccountVolumeSource) DeepCopy() *ServiceAccountVolumeSource {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceAccountVolumeSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StorageMigratedVolume) DeepCopyInto(out *StorageMigratedVolume) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StorageMigratedVolume.
+func (in *StorageMigratedVolume) DeepCopy() *StorageMigratedVolume {
+	if in == nil {
+		return nil
+	}
+	out := new(StorageMigratedVolume)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Timer) DeepCopyInto(out *Timer) {
+	*out = *in
+	if in.HPET != nil {
+		in, out := &in.HPET, &out.HPET
+		*out = new(HPETTimer)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.KVM != nil {
+		in, out := &in.KVM, &out.KVM
+		*out = new(KVMTimer)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PIT != nil {
+		in, out := &in.PIT, &out.PIT
+		*out = new(PITTimer)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RTC != nil {
+		in, out := &in.RTC, &out.RTC
+		*out = new(RTCTimer)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Hyperv != nil {
+		in, out := &in.Hyperv, &out.Hyperv
+		*out = new(HypervTimer)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Timer.
+func (in *Timer) DeepCopy() *Timer {
+	if in == nil {
+		return nil
+	}
+	out := new(Timer)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *USBRedirect) DeepCopyInto(out *USBRedirect) {
+	*out = *in
+	if in.Slots != nil {
+		in, out := &in.Slots, &out.Slots
+		*out = new(int32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new USBRedirect.
+func (in *USBRedirect) DeepCopy() *USBRedirect {
+	if in == nil {
+		return nil
+	}
+	out := new(USBRedirect)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UserPasswordAccessCredential) DeepCopyInto(out *UserPasswordAccessCredential) {
+	*out = *in
+	in.Source.DeepCopyInto(&out.Source)
+	in.PropagationMethod.DeepCopyInto(&out.PropagationMethod)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UserPasswordAccessCredential.
+func (in *UserPasswordAccessCredential) DeepCopy() *UserPasswordAccessCredential {
+	if in == nil {
+		return nil
+	}
+	out := new(UserPasswordAccessCredential)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UserPasswordAccessCredentialPropagationMethod) DeepCopyInto(out *UserPasswordAccessCredentialPropagationMethod) {
+	*out = *in
+	if in.QemuGuestAgent != nil {
+		in, out := &in.QemuGuestAgent, &out.QemuGuestAgent
+		*out = new(QemuGuestAgentUserPasswordAccessCredentialPropagation)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UserPasswordAccessCredentialPropagationMethod.
+func (in *UserPasswordAccessCredentialPropagationMethod) DeepCopy() *UserPasswordAccessCredentialPropagationMethod {
+	if in == nil {
+		return nil
+	}
+	out := new(UserPasswordAccessCredentialPropagationMethod)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VMISelector) DeepCopyInto(out *VMISelector) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMISelector.
+func (in *VMISelector) DeepCopy() *VMISelector {
+	if in == nil {
+		return nil
+	}
+	out := new(VMISelector)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VNCToken) DeepCopyInto(out *VNCToken) {
+	*out = *in
+	in.ExpirationTimestamp.DeepCopyInto(&out.ExpirationTimestamp)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VNCToken.
+func (in *VNCToken) DeepCopy() *VNCToken {
+	if in == nil {
+		return nil
+	}
+	out := new(VNCToken)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachine) DeepCopyInto(out *VirtualMachine) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachine.
+func (in *VirtualMachine) DeepCopy() *VirtualMachine {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachine)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VirtualMachine) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineBackup) DeepCopyInto(out *VirtualMachineBackup) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineBackup.
+func (in *VirtualMachineBackup) DeepCopy() *VirtualMachineBackup {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineBackup)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VirtualMachineBackup) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineBackupList) DeepCopyInto(out *VirtualMachineBackupList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]VirtualMachineBackup, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineBackupList.
+func (in *VirtualMachineBackupList) DeepCopy() *VirtualMachineBackupList {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineBackupList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VirtualMachineBackupList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineBackupSpec) DeepCopyInto(out *VirtualMachineBackupSpec) {
+	*out = *in
+	in.Target.DeepCopyInto(&out.Target)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineBackupSpec.
+func (in *VirtualMachineBackupSpec) DeepCopy() *VirtualMachineBackupSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineBackupSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineBackupStatus) DeepCopyInto(out *VirtualMachineBackupStatus) {
+	*out = *in
+	if in.NBD != nil {
+		in, out := &in.NBD, &out.NBD
+		*out = new(NBDBackupStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.StartTimestamp != nil {
+		in, out := &in.StartTimestamp, &out.StartTimestamp
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletionTimestamp != nil {
+		in, out := &in.CompletionTimestamp, &out.CompletionTimestamp
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineBackupStatus.
+func (in *VirtualMachineBackupStatus) DeepCopy() *VirtualMachineBackupStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineBackupStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineBackupTarget) DeepCopyInto(out *VirtualMachineBackupTarget) {
+	*out = *in
+	if in.NBD != nil {
+		in, out := &in.NBD, &out.NBD
+		*out = new(NBDBackupTarget)
+		**out = **in
+	}
+	if in.PersistentVolumeClaim != nil {
+		in, out := &in.PersistentVolumeClaim, &out.PersistentVolumeClaim
+		*out = new(corev1.PersistentVolumeClaimVolumeSource)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineBackupTarget.
+func (in *VirtualMachineBackupTarget) DeepCopy() *VirtualMachineBackupTarget {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineBackupTarget)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineCondition) DeepCopyInto(out *VirtualMachineCondition) {
+	*out = *in
+	in.LastProbeTime.DeepCopyInto(&out.LastProbeTime)
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineCondition.
+func (in *VirtualMachineCondition) DeepCopy() *VirtualMachineCondition {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineCondition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineExport) DeepCopyInto(out *VirtualMachineExport) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineExport.
+func (in *VirtualMachineExport) DeepCopy() *VirtualMachineExport {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineExport)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VirtualMachineExport) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineExportLink) DeepCopyInto(out *VirtualMachineExportLink) {
+	*out = *in
+	if in.Volumes != nil {
+		in, out := &in.Volumes, &out.Volumes
+		*out = make([]VirtualMachineExportVolume, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Manifests != nil {
+		in, out := &in.Manifests, &out.Manifests
+		*out = make([]VirtualMachineExportManifest, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineExportLink.
+func (in *VirtualMachineExportLink) DeepCopy() *VirtualMachineExportLink {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineExportLink)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineExportLinks) DeepCopyInto(out *VirtualMachineExportLinks) {
+	*out = *in
+	if in.Internal != nil {
+		in, out := &in.Internal, &out.Internal
+		*out = new(VirtualMachineExportLink)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.External != nil {
+		in, out := &in.External, &out.External
+		*out = new(VirtualMachineExportLink)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineExportLinks.
+func (in *VirtualMachineExportLinks) DeepCopy() *VirtualMachineExportLinks {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineExportLinks)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineExportList) DeepCopyInto(out *VirtualMachineExportList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]VirtualMachineExport, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineExportList.
+func (in *VirtualMachineExportList) DeepCopy() *VirtualMachineExportList {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineExportList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VirtualMachineExportList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineExportManifest) DeepCopyInto(out *VirtualMachineExportManifest) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineExportManifest.
+func (in *VirtualMachineExportManifest) DeepCopy() *VirtualMachineExportManifest {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineExportManifest)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineExportSpec) DeepCopyInto(out *VirtualMachineExportSpec) {
+	*out = *in
+	in.Source.DeepCopyInto(&out.Source)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineExportSpec.
+func (in *VirtualMachineExportSpec) DeepCopy() *VirtualMachineExportSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineExportSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineExportStatus) DeepCopyInto(out *VirtualMachineExportStatus) {
+	*out = *in
+	if in.Links != nil {
+		in, out := &in.Links, &out.Links
+		*out = new(VirtualMachineExportLinks)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineExportStatus.
+func (in *VirtualMachineExportStatus) DeepCopy() *VirtualMachineExportStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineExportStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineExportVolume) DeepCopyInto(out *VirtualMachineExportVolume) {
+	*out = *in
+	if in.Formats != nil {
+		in, out := &in.Formats, &out.Formats
+		*out = make([]VirtualMachineExportVolumeFormat, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineExportVolume.
+func (in *VirtualMachineExportVolume) DeepCopy() *VirtualMachineExportVolume {
 	if in == nil {
 		return nil
 	}
-	out := new(Port)
+	out := new(VirtualMachineExportVolume)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Probe) DeepCopyInto(out *Probe) {
+func (in *VirtualMachineExportVolumeFormat) DeepCopyInto(out *VirtualMachineExportVolumeFormat) {
 	*out = *in
-	in.Handler.DeepCopyInto(&out.Handler)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Probe.
-func (in *Probe) DeepCopy() *Probe {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineExportVolumeFormat.
+func (in *VirtualMachineExportVolumeFormat) DeepCopy() *VirtualMachineExportVolumeFormat {
 	if in == nil {
 		return nil
 	}
-	out := new(Probe)
+	out := new(VirtualMachineExportVolumeFormat)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RTCTimer) DeepCopyInto(out *RTCTimer) {
+func (in *VirtualMachineInstance) DeepCopyInto(out *VirtualMachineInstance) {
 	*out = *in
-	if in.Enabled != nil {
-		in, out := &in.Enabled, &out.Enabled
-		*out = new(bool)
-		**out = **in
-	}
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RTCTimer.
-func (in *RTCTimer) DeepCopy() *RTCTimer {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineInstance.
+func (in *VirtualMachineInstance) DeepCopy() *VirtualMachineInstance {
 	if in == nil {
 		return nil
 	}
-	out := new(RTCTimer)
+	out := new(VirtualMachineInstance)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VirtualMachineInstance) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ResourceRequirements) DeepCopyInto(out *ResourceRequirements) {
+func (in *VirtualMachineInstanceBackupState) DeepCopyInto(out *VirtualMachineInstanceBackupState) {
 	*out = *in
-	if in.Requests != nil {
-		in, out := &in.Requests, &out.Requests
-		*out = make(corev1.ResourceList, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val.DeepCopy()
-		}
+	if in.Volumes != nil {
+		in, out := &in.Volumes, &out.Volumes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
-	if in.Limits != nil {
-		in, out := &in.Limits, &out.Limits
-		*out = make(corev1.ResourceList, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val.DeepCopy()
-		}
+	if in.StartTimestamp != nil {
+		in, out := &in.StartTimestamp, &out.StartTimestamp
+		*out = (*in).DeepCopy()
+	}
+	if in.EndTimestamp != nil {
+		in, out := &in.EndTimestamp, &out.EndTimestamp
+		*out = (*in).DeepCopy()
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceRequirements.
-func (in *ResourceRequirements) DeepCopy() *ResourceRequirements {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineInstanceBackupState.
+func (in *VirtualMachineInstanceBackupState) DeepCopy() *VirtualMachineInstanceBackupState {
 	if in == nil {
 		return nil
 	}
-	out := new(ResourceRequirements)
+	out := new(VirtualMachineInstanceBackupState)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Rng) DeepCopyInto(out *Rng) {
+func (in *VirtualMachineInstanceCondition) DeepCopyInto(out *VirtualMachineInstanceCondition) {
 	*out = *in
+	in.LastProbeTime.DeepCopyInto(&out.LastProbeTime)
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Rng.
-func (in *Rng) DeepCopy() *Rng {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineInstanceCondition.
+func (in *VirtualMachineInstanceCondition) DeepCopy() *VirtualMachineInstanceCondition {
 	if in == nil {
 		return nil
 	}
-	out := new(Rng)
+	out := new(VirtualMachineInstanceCondition)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SecretVolumeSource) DeepCopyInto(out *SecretVolumeSource) {
+func (in *VirtualMachineInstanceFileSystem) DeepCopyInto(out *VirtualMachineInstanceFileSystem) {
 	*out = *in
-	if in.Optional != nil {
-		in, out := &in.Optional, &out.Optional
-		*out = new(bool)
-		**out = **in
-	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretVolumeSource.
-func (in *SecretVolumeSource) DeepCopy() *SecretVolumeSource {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineInstanceFileSystem.
+func (in *VirtualMachineInstanceFileSystem) DeepCopy() *VirtualMachineInstanceFileSystem {
 	if in == nil {
 		return nil
 	}
-	out := new(SecretVolumeSource)
+	out := new(VirtualMachineInstanceFileSystem)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ServiceAccountVolumeSource) DeepCopyInto(out *ServiceAccountVolumeSource) {
+func (in *VirtualMachineInstanceFileSystemInfo) DeepCopyInto(out *VirtualMachineInstanceFileSystemInfo) {
 	*out = *in
+	if in.Filesystems != nil {
+		in, out := &in.Filesystems, &out.Filesystems
+		*out = make([]VirtualMachineInstanceFileSystem, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceAccountVolumeSource.
-func (in *ServiceAccountVolumeSource) DeepCopy() *ServiceAccountVolumeSource {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineInstanceFileSystemInfo.
+func (in *VirtualMachineInstanceFileSystemInfo) DeepCopy() *VirtualMachineInstanceFileSystemInfo {
 	if in == nil {
 		return nil
 	}
-	out := new(ServiceAccountVolumeSource)
+	out := new(VirtualMachineInstanceFileSystemInfo)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Timer) DeepCopyInto(out *Timer) {
+func (in *VirtualMachineInstanceFileSystemList) DeepCopyInto(out *VirtualMachineInstanceFileSystemList) {
 	*out = *in
-	if in.HPET != nil {
-		in, out := &in.HPET, &out.HPET
-		*out = new(HPETTimer)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.KVM != nil {
-		in, out := &in.KVM, &out.KVM
-		*out = new(KVMTimer)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.PIT != nil {
-		in, out := &in.PIT, &out.PIT
-		*out = new(PITTimer)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.RTC != nil {
-		in, out := &in.RTC, &out.RTC
-		*out = new(RTCTimer)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.Hyperv != nil {
-		in, out := &in.Hyperv, &out.Hyperv
-		*out = new(HypervTimer)
-		(*in).DeepCopyInto(*out)
+	out.TypeMeta = in.TypeMeta
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]VirtualMachineInstanceFileSystem, len(*in))
+		copy(*out, *in)
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Timer.
-func (in *Timer) DeepCopy() *Timer {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineInstanceFileSystemList.
+func (in *VirtualMachineInstanceFileSystemList) DeepCopy() *VirtualMachineInstanceFileSystemList {
 	if in == nil {
 		return nil
 	}
-	out := new(Timer)
+	out := new(VirtualMachineInstanceFileSystemList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VMISelector) DeepCopyInto(out *VMISelector) {
+func (in *VirtualMachineInstanceGuestAgentInfo) DeepCopyInto(out *VirtualMachineInstanceGuestAgentInfo) {
 	*out = *in
+	out.TypeMeta = in.TypeMeta
+	out.OS = in.OS
+	if in.UserList != nil {
+		in, out := &in.UserList, &out.UserList
+		*out = make([]VirtualMachineInstanceGuestOSUser, len(*in))
+		copy(*out, *in)
+	}
+	in.FSInfo.DeepCopyInto(&out.FSInfo)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMISelector.
-func (in *VMISelector) DeepCopy() *VMISelector {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineInstanceGuestAgentInfo.
+func (in *VirtualMachineInstanceGuestAgentInfo) DeepCopy() *VirtualMachineInstanceGuestAgentInfo {
 	if in == nil {
 		return nil
 	}
-	out := new(VMISelector)
+	out := new(VirtualMachineInstanceGuestAgentInfo)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VirtualMachine) DeepCopyInto(out *VirtualMachine) {
+func (in *VirtualMachineInstanceGuestExecRequest) DeepCopyInto(out *VirtualMachineInstanceGuestExecRequest) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
-	in.Status.DeepCopyInto(&out.Status)
+	if in.Args != nil {
+		in, out := &in.Args, &out.Args
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachine.
-func (in *VirtualMachine) DeepCopy() *VirtualMachine {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineInstanceGuestExecRequest.
+func (in *VirtualMachineInstanceGuestExecRequest) DeepCopy() *VirtualMachineInstanceGuestExecRequest {
 	if in == nil {
 		return nil
 	}
-	out := new(VirtualMachine)
+	out := new(VirtualMachineInstanceGuestExecRequest)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *VirtualMachine) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VirtualMachineCondition) DeepCopyInto(out *VirtualMachineCondition) {
+func (in *VirtualMachineInstanceGuestExecResult) DeepCopyInto(out *VirtualMachineInstanceGuestExecResult) {
 	*out = *in
-	in.LastProbeTime.DeepCopyInto(&out.LastProbeTime)
-	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+	out.TypeMeta = in.TypeMeta
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineCondition.
-func (in *VirtualMachineCondition) DeepCopy() *VirtualMachineCondition {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineInstanceGuestExecResult.
+func (in *VirtualMachineInstanceGuestExecResult) DeepCopy() *VirtualMachineInstanceGuestExecResult {
 	if in == nil {
 		return nil
 	}
-	out := new(VirtualMachineCondition)
+	out := new(VirtualMachineInstanceGuestExecResult)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VirtualMachineInstance) DeepCopyInto(out *VirtualMachineInstance) {
+func (in *VirtualMachineInstanceGuestOSInfo) DeepCopyInto(out *VirtualMachineInstanceGuestOSInfo) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
-	in.Status.DeepCopyInto(&out.Status)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineInstance.
-func (in *VirtualMachineInstance) DeepCopy() *VirtualMachineInstance {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineInstanceGuestOSInfo.
+func (in *VirtualMachineInstanceGuestOSInfo) DeepCopy() *VirtualMachineInstanceGuestOSInfo {
 	if in == nil {
 		return nil
 	}
-	out := new(VirtualMachineInstance)
+	out := new(VirtualMachineInstanceGuestOSInfo)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *VirtualMachineInstance) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VirtualMachineInstanceCondition) DeepCopyInto(out *VirtualMachineInstanceCondition) {
+func (in *VirtualMachineInstanceGuestOSUser) DeepCopyInto(out *VirtualMachineInstanceGuestOSUser) {
 	*out = *in
-	in.LastProbeTime.DeepCopyInto(&out.LastProbeTime)
-	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineInstanceCondition.
-func (in *VirtualMachineInstanceCondition) DeepCopy() *VirtualMachineInstanceCondition {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineInstanceGuestOSUser.
+func (in *VirtualMachineInstanceGuestOSUser) DeepCopy() *VirtualMachineInstanceGuestOSUser {
 	if in == nil {
 		return nil
 	}
-	out := new(VirtualMachineInstanceCondition)
+	out := new(VirtualMachineInstanceGuestOSUser)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VirtualMachineInstanceGuestOSInfo) DeepCopyInto(out *VirtualMachineInstanceGuestOSInfo) {
+func (in *VirtualMachineInstanceGuestOSUserList) DeepCopyInto(out *VirtualMachineInstanceGuestOSUserList) {
 	*out = *in
+	out.TypeMeta = in.TypeMeta
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]VirtualMachineInstanceGuestOSUser, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineInstanceGuestOSInfo.
-func (in *VirtualMachineInstanceGuestOSInfo) DeepCopy() *VirtualMachineInstanceGuestOSInfo {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineInstanceGuestOSUserList.
+func (in *VirtualMachineInstanceGuestOSUserList) DeepCopy() *VirtualMachineInstanceGuestOSUserList {
 	if in == nil {
 		return nil
 	}
-	out := new(VirtualMachineInstanceGuestOSInfo)
+	out := new(VirtualMachineInstanceGuestOSUserList)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -1803,7 +3236,7 @@ func (in *VirtualMachineInstanceMigration) DeepCopyInto(out *VirtualMachineInsta
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
+	in.Spec.DeepCopyInto(&out.Spec)
 	in.Status.DeepCopyInto(&out.Status)
 	return
 }
@@ -1880,6 +3313,11 @@ func (in *VirtualMachineInstanceMigrationList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VirtualMachineInstanceMigrationSpec) DeepCopyInto(out *VirtualMachineInstanceMigrationSpec) {
 	*out = *in
+	if in.MigratedVolumes != nil {
+		in, out := &in.MigratedVolumes, &out.MigratedVolumes
+		*out = make([]StorageMigratedVolume, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -1911,6 +3349,11 @@ func (in *VirtualMachineInstanceMigrationState) DeepCopyInto(out *VirtualMachine
 			(*out)[key] = val
 		}
 	}
+	if in.MigratedVolumes != nil {
+		in, out := &in.MigratedVolumes, &out.MigratedVolumes
+		*out = make([]StorageMigratedVolume, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -2183,6 +3626,26 @@ func (in *VirtualMachineInstanceReplicaSetStatus) DeepCopy() *VirtualMachineInst
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineInstanceSoftRebootState) DeepCopyInto(out *VirtualMachineInstanceSoftRebootState) {
+	*out = *in
+	if in.LastRebootTimestamp != nil {
+		in, out := &in.LastRebootTimestamp, &out.LastRebootTimestamp
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineInstanceSoftRebootState.
+func (in *VirtualMachineInstanceSoftRebootState) DeepCopy() *VirtualMachineInstanceSoftRebootState {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineInstanceSoftRebootState)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VirtualMachineInstanceSpec) DeepCopyInto(out *VirtualMachineInstanceSpec) {
 	*out = *in
@@ -2245,6 +3708,13 @@ func (in *VirtualMachineInstanceSpec) DeepCopyInto(out *VirtualMachineInstanceSp
 		*out = new(corev1.PodDNSConfig)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.AccessCredentials != nil {
+		in, out := &in.AccessCredentials, &out.AccessCredentials
+		*out = make([]AccessCredential, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -2286,6 +3756,21 @@ func (in *VirtualMachineInstanceStatus) DeepCopyInto(out *VirtualMachineInstance
 		*out = new(corev1.PodQOSClass)
 		**out = **in
 	}
+	if in.SoftRebootState != nil {
+		in, out := &in.SoftRebootState, &out.SoftRebootState
+		*out = new(VirtualMachineInstanceSoftRebootState)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ContainerDiskStatuses != nil {
+		in, out := &in.ContainerDiskStatuses, &out.ContainerDiskStatuses
+		*out = make([]ContainerDiskStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.BackupState != nil {
+		in, out := &in.BackupState, &out.BackupState
+		*out = new(VirtualMachineInstanceBackupState)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -2350,6 +3835,56 @@ func (in *VirtualMachineList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineMemoryDumpRequest) DeepCopyInto(out *VirtualMachineMemoryDumpRequest) {
+	*out = *in
+	if in.StartTimestamp != nil {
+		in, out := &in.StartTimestamp, &out.StartTimestamp
+		*out = (*in).DeepCopy()
+	}
+	if in.EndTimestamp != nil {
+		in, out := &in.EndTimestamp, &out.EndTimestamp
+		*out = (*in).DeepCopy()
+	}
+	if in.FileName != nil {
+		in, out := &in.FileName, &out.FileName
+		*out = new(string)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineMemoryDumpRequest.
+func (in *VirtualMachineMemoryDumpRequest) DeepCopy() *VirtualMachineMemoryDumpRequest {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineMemoryDumpRequest)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineMigrateVolumesRequest) DeepCopyInto(out *VirtualMachineMigrateVolumesRequest) {
+	*out = *in
+	if in.MigratedVolumes != nil {
+		in, out := &in.MigratedVolumes, &out.MigratedVolumes
+		*out = make([]StorageMigratedVolume, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineMigrateVolumesRequest.
+func (in *VirtualMachineMigrateVolumesRequest) DeepCopy() *VirtualMachineMigrateVolumesRequest {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineMigrateVolumesRequest)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VirtualMachineSpec) DeepCopyInto(out *VirtualMachineSpec) {
 	*out = *in
@@ -2426,6 +3961,11 @@ func (in *VirtualMachineStatus) DeepCopyInto(out *VirtualMachineStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.MemoryDumpRequest != nil {
+		in, out := &in.MemoryDumpRequest, &out.MemoryDumpRequest
+		*out = new(VirtualMachineMemoryDumpRequest)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
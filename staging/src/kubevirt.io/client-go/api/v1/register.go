@@ -71,6 +71,9 @@ var (
 	VirtualMachineGroupVersionKind                   = schema.GroupVersionKind{Group: GroupName, Version: GroupVersion.Version, Kind: "VirtualMachine"}
 	VirtualMachineInstanceMigrationGroupVersionKind  = schema.GroupVersionKind{Group: GroupName, Version: GroupVersion.Version, Kind: "VirtualMachineInstanceMigration"}
 	KubeVirtGroupVersionKind                         = schema.GroupVersionKind{Group: GroupName, Version: GroupVersion.Version, Kind: "KubeVirt"}
+	ContainerDiskImageCacheGroupVersionKind          = schema.GroupVersionKind{Group: GroupName, Version: GroupVersion.Version, Kind: "ContainerDiskImageCache"}
+	VirtualMachineBackupGroupVersionKind             = schema.GroupVersionKind{Group: GroupName, Version: GroupVersion.Version, Kind: "VirtualMachineBackup"}
+	VirtualMachineExportGroupVersionKind             = schema.GroupVersionKind{Group: GroupName, Version: GroupVersion.Version, Kind: "VirtualMachineExport"}
 )
 
 var (
@@ -103,6 +106,12 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 			&VirtualMachineList{},
 			&KubeVirt{},
 			&KubeVirtList{},
+			&ContainerDiskImageCache{},
+			&ContainerDiskImageCacheList{},
+			&VirtualMachineBackup{},
+			&VirtualMachineBackupList{},
+			&VirtualMachineExport{},
+			&VirtualMachineExportList{},
 		)
 		metav1.AddToGroupVersion(scheme, groupVersion)
 	}
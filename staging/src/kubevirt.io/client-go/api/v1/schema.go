@@ -50,11 +50,20 @@ type HostDisk struct {
 	// Contains information if disk.img exists or should be created
 	// allowed options are 'Disk' and 'DiskOrCreate'
 	Type HostDiskType `json:"type"`
-	// Capacity of the sparse disk
+	// Capacity of the disk image. The disk image is grown when the capacity increases
 	// +optional
 	Capacity resource.Quantity `json:"capacity,omitempty"`
 	// Shared indicate whether the path is shared between nodes
 	Shared *bool `json:"shared,omitempty"`
+	// Format of the disk image, allowed options are 'raw' and 'qcow2'.
+	// Defaults to 'raw'
+	// +optional
+	Format HostDiskFormat `json:"format,omitempty"`
+	// Preallocation mode used when the disk image is created or grown,
+	// allowed options are 'off', 'metadata', 'falloc' and 'full'.
+	// 'metadata' is only allowed with the 'qcow2' format. Defaults to 'off'
+	// +optional
+	Preallocation HostDiskPreallocation `json:"preallocation,omitempty"`
 }
 
 // ConfigMapVolumeSource adapts a ConfigMap into a volume.
@@ -211,6 +220,43 @@ type BIOS struct {
 type EFI struct {
 }
 
+// Represents the firmware blob used to assist in the kernel boot process.
+// Used for setting the kernel, initrd and command line arguments.
+// ---
+// +k8s:openapi-gen=true
+type KernelBoot struct {
+	// Arguments to be passed to the kernel at boot time.
+	// +optional
+	KernelArgs string `json:"kernelArgs,omitempty"`
+	// Container defines the container image which holds the kernel artifacts.
+	Container *KernelBootContainer `json:"container,omitempty"`
+}
+
+// The kernel and initrd are taken from the container image the same way a
+// containerDisk is, so the image needs no entrypoint of its own.
+// ---
+// +k8s:openapi-gen=true
+type KernelBootContainer struct {
+	// Image is the name of the image which holds the kernel and initrd files.
+	Image string `json:"image"`
+	// ImagePullSecret is the name of the Docker registry secret required to pull the image. The secret must already exist.
+	// +optional
+	ImagePullSecret string `json:"imagePullSecret,omitempty"`
+	// Image pull policy.
+	// One of Always, Never, IfNotPresent.
+	// Defaults to Always if :latest tag is specified, or IfNotPresent otherwise.
+	// Cannot be updated.
+	// More info: https://kubernetes.io/docs/concepts/containers/images#updating-images
+	// +optional
+	ImagePullPolicy v1.PullPolicy `json:"imagePullPolicy,omitempty"`
+	// The absolute path to the kernel image in the container image.
+	// +optional
+	KernelPath string `json:"kernelPath,omitempty"`
+	// The absolute path to the initrd image in the container image.
+	// +optional
+	InitrdPath string `json:"initrdPath,omitempty"`
+}
+
 // ---
 // +k8s:openapi-gen=true
 type ResourceRequirements struct {
@@ -314,6 +360,9 @@ type Firmware struct {
 	Bootloader *Bootloader `json:"bootloader,omitempty"`
 	// The system-serial-number in SMBIOS
 	Serial string `json:"serial,omitempty"`
+	// Settings to boot a kernel and initrd directly, without a bootloader.
+	// +optional
+	KernelBoot *KernelBoot `json:"kernelBoot,omitempty"`
 }
 
 // ---
@@ -332,6 +381,10 @@ type Devices struct {
 	// Whether to attach the default graphics device or not.
 	// VNC will not be available if set to false. Defaults to true.
 	AutoattachGraphicsDevice *bool `json:"autoattachGraphicsDevice,omitempty"`
+	// Whether to log the serial console output to the guest-console-log container
+	// of the virt-launcher pod. Defaults to false.
+	// +optional
+	LogSerialConsole *bool `json:"logSerialConsole,omitempty"`
 	// Whether to have random number generator from host
 	// +optional
 	Rng *Rng `json:"rng,omitempty"`
@@ -344,6 +397,20 @@ type Devices struct {
 	//Whether to attach a GPU device to the vmi.
 	// +optional
 	GPUs []GPU `json:"gpus,omitempty"`
+	// Whether to attach USB redirection channels, which allow clients to pass
+	// local USB devices through to the vmi with virtctl usbredir.
+	// +optional
+	USBRedirect *USBRedirect `json:"usbRedirect,omitempty"`
+}
+
+// USBRedirect configures the USB redirection channels of the vmi.
+// ---
+// +k8s:openapi-gen=true
+type USBRedirect struct {
+	// Number of USB devices which can be redirected at the same time.
+	// Defaults to 4, at most 8 are supported.
+	// +optional
+	Slots *int32 `json:"slots,omitempty"`
 }
 
 // ---
@@ -392,6 +459,15 @@ type Disk struct {
 	// Cache specifies which kvm disk cache mode should be used.
 	// +optional
 	Cache DriverCache `json:"cache,omitempty"`
+	// If specified the disk can be shared between multiple VirtualMachineInstances.
+	// Shareable disks must not set a cache mode other than none.
+	// +optional
+	Shareable *bool `json:"shareable,omitempty"`
+	// ErrorPolicy specifies how the hypervisor reacts to I/O errors of the disk.
+	// Supported values are: stop, report, ignore, enospace.
+	// Defaults to the hypervisor default, which pauses the guest when the disk runs out of space.
+	// +optional
+	ErrorPolicy DiskErrorPolicy `json:"errorPolicy,omitempty"`
 }
 
 // Represents the target of a volume to mount.
@@ -432,6 +508,11 @@ type LunTarget struct {
 	// ReadOnly.
 	// Defaults to false.
 	ReadOnly bool `json:"readonly,omitempty"`
+	// Reservations indicates if the LUN supports SCSI persistent reservations.
+	// The reservations are handled by the qemu-pr-helper running on the node.
+	// Requires the PersistentReservation feature gate and the scsi bus.
+	// +optional
+	Reservations bool `json:"reservations,omitempty"`
 }
 
 // ---
@@ -1116,3 +1197,103 @@ type MultusNetwork struct {
 	// multus-cni.io/default-network annotation.
 	Default bool `json:"default,omitempty"`
 }
+
+// AccessCredential represents a credential source that can be used to
+// authorize remote access to the vm guest.
+// Only one of its members may be specified.
+// ---
+// +k8s:openapi-gen=true
+type AccessCredential struct {
+	// SSHPublicKey represents the source and method of applying ssh public
+	// keys to the guest.
+	// +optional
+	SSHPublicKey *SSHPublicKeyAccessCredential `json:"sshPublicKey,omitempty"`
+	// UserPassword represents the source and method of applying passwords
+	// of guest users.
+	// +optional
+	UserPassword *UserPasswordAccessCredential `json:"userPassword,omitempty"`
+}
+
+// AccessCredentialSource represents where access credentials are read from.
+// Only one of its members may be specified.
+// ---
+// +k8s:openapi-gen=true
+type AccessCredentialSource struct {
+	// Secret means that the access credentials are read from a Secret in
+	// the namespace of the vmi.
+	// +optional
+	Secret *AccessCredentialSecretSource `json:"secret,omitempty"`
+}
+
+// ---
+// +k8s:openapi-gen=true
+type AccessCredentialSecretSource struct {
+	// SecretName represents the name of the Secret in the namespace of the vmi.
+	SecretName string `json:"secretName"`
+}
+
+// SSHPublicKeyAccessCredential applies ssh public keys to the guest.
+// Every value of the source Secret holds one or more public keys in the
+// authorized_keys format.
+// ---
+// +k8s:openapi-gen=true
+type SSHPublicKeyAccessCredential struct {
+	// Source represents where the public keys are read from.
+	Source AccessCredentialSource `json:"source"`
+	// PropagationMethod represents how the public keys are applied to the guest.
+	PropagationMethod SSHPublicKeyAccessCredentialPropagationMethod `json:"propagationMethod"`
+}
+
+// SSHPublicKeyAccessCredentialPropagationMethod represents how ssh public keys
+// are applied to the guest.
+// Only one of its members may be specified.
+// ---
+// +k8s:openapi-gen=true
+type SSHPublicKeyAccessCredentialPropagationMethod struct {
+	// CloudInit adds the public keys to the metadata of the cloud-init
+	// NoCloud or ConfigDrive volume of the vmi at boot.
+	// +optional
+	CloudInit *CloudInitSSHPublicKeyAccessCredentialPropagation `json:"cloudInit,omitempty"`
+	// QemuGuestAgent replaces the authorized keys of guest users through the
+	// guest agent at runtime, whenever the source changes.
+	// +optional
+	QemuGuestAgent *QemuGuestAgentSSHPublicKeyAccessCredentialPropagation `json:"qemuGuestAgent,omitempty"`
+}
+
+// ---
+// +k8s:openapi-gen=true
+type CloudInitSSHPublicKeyAccessCredentialPropagation struct{}
+
+// ---
+// +k8s:openapi-gen=true
+type QemuGuestAgentSSHPublicKeyAccessCredentialPropagation struct {
+	// Users represents the guest users whose authorized keys are managed.
+	Users []string `json:"users"`
+}
+
+// UserPasswordAccessCredential applies passwords of guest users. Every key of
+// the source Secret is a user name, its value is the password of the user.
+// ---
+// +k8s:openapi-gen=true
+type UserPasswordAccessCredential struct {
+	// Source represents where the passwords are read from.
+	Source AccessCredentialSource `json:"source"`
+	// PropagationMethod represents how the passwords are applied to the guest.
+	PropagationMethod UserPasswordAccessCredentialPropagationMethod `json:"propagationMethod"`
+}
+
+// UserPasswordAccessCredentialPropagationMethod represents how passwords are
+// applied to the guest.
+// Only one of its members may be specified.
+// ---
+// +k8s:openapi-gen=true
+type UserPasswordAccessCredentialPropagationMethod struct {
+	// QemuGuestAgent sets the passwords through the guest agent at runtime,
+	// whenever the source changes.
+	// +optional
+	QemuGuestAgent *QemuGuestAgentUserPasswordAccessCredentialPropagation `json:"qemuGuestAgent,omitempty"`
+}
+
+// ---
+// +k8s:openapi-gen=true
+type QemuGuestAgentUserPasswordAccessCredentialPropagation struct{}
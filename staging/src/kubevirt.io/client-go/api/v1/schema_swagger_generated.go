@@ -4,11 +4,13 @@ package v1
 
 func (HostDisk) SwaggerDoc() map[string]string {
 	return map[string]string{
-		"":         "Represents a disk created on the cluster level",
-		"path":     "The path to HostDisk image located on the cluster",
-		"type":     "Contains information if disk.img exists or should be created\nallowed options are 'Disk' and 'DiskOrCreate'",
-		"capacity": "Capacity of the sparse disk\n+optional",
-		"shared":   "Shared indicate whether the path is shared between nodes",
+		"":              "Represents a disk created on the cluster level",
+		"path":          "The path to HostDisk image located on the cluster",
+		"type":          "Contains information if disk.img exists or should be created\nallowed options are 'Disk' and 'DiskOrCreate'",
+		"capacity":      "Capacity of the disk image. The disk image is grown when the capacity increases\n+optional",
+		"shared":        "Shared indicate whether the path is shared between nodes",
+		"format":        "Format of the disk image, allowed options are 'raw' and 'qcow2'.\nDefaults to 'raw'\n+optional",
+		"preallocation": "Preallocation mode used when the disk image is created or grown,\nallowed options are 'off', 'metadata', 'falloc' and 'full'.\n'metadata' is only allowed with the 'qcow2' format. Defaults to 'off'\n+optional",
 	}
 }
 
@@ -99,6 +101,25 @@ func (EFI) SwaggerDoc() map[string]string {
 	}
 }
 
+func (KernelBoot) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":           "Represents the firmware blob used to assist in the kernel boot process.\nUsed for setting the kernel, initrd and command line arguments.",
+		"kernelArgs": "Arguments to be passed to the kernel at boot time.\n+optional",
+		"container":  "Container defines the container image which holds the kernel artifacts.",
+	}
+}
+
+func (KernelBootContainer) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":                "The kernel and initrd are taken from the container image the same way a\ncontainerDisk is, so the image needs no entrypoint of its own.",
+		"image":           "Image is the name of the image which holds the kernel and initrd files.",
+		"imagePullSecret": "ImagePullSecret is the name of the Docker registry secret required to pull the image. The secret must already exist.\n+optional",
+		"imagePullPolicy": "Image pull policy.\nOne of Always, Never, IfNotPresent.\nDefaults to Always if :latest tag is specified, or IfNotPresent otherwise.\nCannot be updated.\nMore info: https://kubernetes.io/docs/concepts/containers/images#updating-images\n+optional",
+		"kernelPath":      "The absolute path to the kernel image in the container image.\n+optional",
+		"initrdPath":      "The absolute path to the initrd image in the container image.\n+optional",
+	}
+}
+
 func (ResourceRequirements) SwaggerDoc() map[string]string {
 	return map[string]string{
 		"requests":                "Requests is a description of the initial vmi resources.\nValid resource keys are \"memory\" and \"cpu\".\n+optional",
@@ -153,6 +174,7 @@ func (Firmware) SwaggerDoc() map[string]string {
 		"uuid":       "UUID reported by the vmi bios.\nDefaults to a random generated uid.",
 		"bootloader": "Settings to control the bootloader that is used.\n+optional",
 		"serial":     "The system-serial-number in SMBIOS",
+		"kernelBoot": "Settings to boot a kernel and initrd directly, without a bootloader.\n+optional",
 	}
 }
 
@@ -164,10 +186,19 @@ func (Devices) SwaggerDoc() map[string]string {
 		"inputs":                     "Inputs describe input devices",
 		"autoattachPodInterface":     "Whether to attach a pod network interface. Defaults to true.",
 		"autoattachGraphicsDevice":   "Whether to attach the default graphics device or not.\nVNC will not be available if set to false. Defaults to true.",
+		"logSerialConsole":           "Whether to log the serial console output to the guest-console-log container\nof the virt-launcher pod. Defaults to false.\n+optional",
 		"rng":                        "Whether to have random number generator from host\n+optional",
 		"blockMultiQueue":            "Whether or not to enable virtio multi-queue for block devices\n+optional",
 		"networkInterfaceMultiqueue": "If specified, virtual network interfaces configured with a virtio bus will also enable the vhost multiqueue feature\n+optional",
 		"gpus":                       "Whether to attach a GPU device to the vmi.\n+optional",
+		"usbRedirect":                "Whether to attach USB redirection channels, which allow clients to pass\nlocal USB devices through to the vmi with virtctl usbredir.\n+optional",
+	}
+}
+
+func (USBRedirect) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":      "USBRedirect configures the USB redirection channels of the vmi.",
+		"slots": "Number of USB devices which can be redirected at the same time.\nDefaults to 4, at most 8 are supported.\n+optional",
 	}
 }
 
@@ -192,6 +223,8 @@ func (Disk) SwaggerDoc() map[string]string {
 		"serial":            "Serial provides the ability to specify a serial number for the disk device.\n+optional",
 		"dedicatedIOThread": "dedicatedIOThread indicates this disk should have an exclusive IO Thread.\nEnabling this implies useIOThreads = true.\nDefaults to false.\n+optional",
 		"cache":             "Cache specifies which kvm disk cache mode should be used.\n+optional",
+		"shareable":         "If specified the disk can be shared between multiple VirtualMachineInstances.\nShareable disks must not set a cache mode other than none.\n+optional",
+		"errorPolicy":       "ErrorPolicy specifies how the hypervisor reacts to I/O errors of the disk.\nSupported values are: stop, report, ignore, enospace.\nDefaults to the hypervisor default, which pauses the guest when the disk runs out of space.\n+optional",
 	}
 }
 
@@ -215,8 +248,9 @@ func (DiskTarget) SwaggerDoc() map[string]string {
 
 func (LunTarget) SwaggerDoc() map[string]string {
 	return map[string]string{
-		"bus":      "Bus indicates the type of disk device to emulate.\nsupported values: virtio, sata, scsi.",
-		"readonly": "ReadOnly.\nDefaults to false.",
+		"bus":          "Bus indicates the type of disk device to emulate.\nsupported values: virtio, sata, scsi.",
+		"readonly":     "ReadOnly.\nDefaults to false.",
+		"reservations": "Reservations indicates if the LUN supports SCSI persistent reservations.\nThe reservations are handled by the qemu-pr-helper running on the node.\nRequires the PersistentReservation feature gate and the scsi bus.\n+optional",
 	}
 }
 
@@ -485,6 +519,12 @@ func (InterfaceSRIOV) SwaggerDoc() map[string]string {
 	return map[string]string{}
 }
 
+func (InterfaceMacvtap) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"mode": "+optional",
+	}
+}
+
 func (Port) SwaggerDoc() map[string]string {
 	return map[string]string{
 		"":         "Port repesents a port to expose from the virtual machine.\nDefault protocol TCP.\nThe port field is mandatory",
@@ -534,3 +574,75 @@ func (MultusNetwork) SwaggerDoc() map[string]string {
 		"default":     "Select the default network and add it to the\nmultus-cni.io/default-network annotation.",
 	}
 }
+
+func (AccessCredential) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":             "AccessCredential represents a credential source that can be used to\nauthorize remote access to the vm guest.\nOnly one of its members may be specified.",
+		"sshPublicKey": "SSHPublicKey represents the source and method of applying ssh public\nkeys to the guest.\n+optional",
+		"userPassword": "UserPassword represents the source and method of applying passwords\nof guest users.\n+optional",
+	}
+}
+
+func (AccessCredentialSource) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":       "AccessCredentialSource represents where access credentials are read from.\nOnly one of its members may be specified.",
+		"secret": "Secret means that the access credentials are read from a Secret in\nthe namespace of the vmi.\n+optional",
+	}
+}
+
+func (AccessCredentialSecretSource) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":           "",
+		"secretName": "SecretName represents the name of the Secret in the namespace of the vmi.",
+	}
+}
+
+func (SSHPublicKeyAccessCredential) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":                  "SSHPublicKeyAccessCredential applies ssh public keys to the guest.\nEvery value of the source Secret holds one or more public keys in the\nauthorized_keys format.",
+		"source":            "Source represents where the public keys are read from.",
+		"propagationMethod": "PropagationMethod represents how the public keys are applied to the guest.",
+	}
+}
+
+func (SSHPublicKeyAccessCredentialPropagationMethod) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":               "SSHPublicKeyAccessCredentialPropagationMethod represents how ssh public keys\nare applied to the guest.\nOnly one of its members may be specified.",
+		"cloudInit":      "CloudInit adds the public keys to the metadata of the cloud-init\nNoCloud or ConfigDrive volume of the vmi at boot.\n+optional",
+		"qemuGuestAgent": "QemuGuestAgent replaces the authorized keys of guest users through the\nguest agent at runtime, whenever the source changes.\n+optional",
+	}
+}
+
+func (CloudInitSSHPublicKeyAccessCredentialPropagation) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"": "",
+	}
+}
+
+func (QemuGuestAgentSSHPublicKeyAccessCredentialPropagation) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":      "",
+		"users": "Users represents the guest users whose authorized keys are managed.",
+	}
+}
+
+func (UserPasswordAccessCredential) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":                  "UserPasswordAccessCredential applies passwords of guest users. Every key of\nthe source Secret is a user name, its value is the password of the user.",
+		"source":            "Source represents where the passwords are read from.",
+		"propagationMethod": "PropagationMethod represents how the passwords are applied to the guest.",
+	}
+}
+
+func (UserPasswordAccessCredentialPropagationMethod) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":               "UserPasswordAccessCredentialPropagationMethod represents how passwords are\napplied to the guest.\nOnly one of its members may be specified.",
+		"qemuGuestAgent": "QemuGuestAgent sets the passwords through the guest agent at runtime,\nwhenever the source changes.\n+optional",
+	}
+}
+
+func (QemuGuestAgentUserPasswordAccessCredentialPropagation) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"": "",
+	}
+}
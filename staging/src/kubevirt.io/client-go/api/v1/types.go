@@ -33,6 +33,7 @@ import (
 	"fmt"
 
 	k8sv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
@@ -139,6 +140,11 @@ type VirtualMachineInstanceSpec struct {
 	// configuration based on DNSPolicy.
 	// +optional
 	DNSConfig *k8sv1.PodDNSConfig `json:"dnsConfig,omitempty" protobuf:"bytes,26,opt,name=dnsConfig"`
+	// Specifies ssh public keys and user passwords which are applied to the guest.
+	// Changes of the referenced Secrets are propagated to running guests
+	// if the guest agent is used.
+	// +optional
+	AccessCredentials []AccessCredential `json:"accessCredentials,omitempty"`
 }
 
 // VirtualMachineInstanceStatus represents information about the status of a VirtualMachineInstance. Status may trail the actual
@@ -168,6 +174,15 @@ type VirtualMachineInstanceStatus struct {
 	// More info: https://git.k8s.io/community/contributors/design-proposals/node/resource-qos.md
 	// +optional
 	QOSClass *k8sv1.PodQOSClass `json:"qosClass,omitempty"`
+	// Represents the soft reboots of the guest which happened in the current pod
+	// +optional
+	SoftRebootState *VirtualMachineInstanceSoftRebootState `json:"softRebootState,omitempty"`
+	// ContainerDiskStatuses records the digests the containerDisk images were pinned to at creation time
+	// +optional
+	ContainerDiskStatuses []ContainerDiskStatus `json:"containerDiskStatuses,omitempty"`
+	// BackupState tracks the backup job which runs for the VirtualMachineInstance
+	// +optional
+	BackupState *VirtualMachineInstanceBackupState `json:"backupState,omitempty"`
 }
 
 func (v *VirtualMachineInstance) IsScheduling() bool {
@@ -194,6 +209,13 @@ func (v *VirtualMachineInstance) IsUnprocessed() bool {
 	return v.Status.Phase == Pending || v.Status.Phase == VmPhaseUnset
 }
 
+// Checks if disks are copied during the live migration, either because the VMI
+// has local disks or because volumes are migrated to other PVCs
+func (v *VirtualMachineInstance) IsBlockMigration() bool {
+	return v.Status.MigrationMethod == BlockMigration ||
+		(v.Status.MigrationState != nil && len(v.Status.MigrationState.MigratedVolumes) > 0)
+}
+
 // Checks if CPU pinning has been requested
 func (v *VirtualMachineInstance) IsCPUDedicated() bool {
 	return v.Spec.Domain.CPU != nil && v.Spec.Domain.CPU.DedicatedCPUPlacement
@@ -224,6 +246,11 @@ const (
 	// If the VMI was paused by the user, this is reported as true.
 	VirtualMachineInstancePaused VirtualMachineInstanceConditionType = "Paused"
 
+	// If the VMI was paused because of I/O errors on its disks, this is reported as true.
+	VirtualMachineInstancePausedIOError VirtualMachineInstanceConditionType = "PausedIOError"
+	// Reason means that the VMI was paused because of I/O errors on its disks
+	VirtualMachineInstanceReasonDiskIOError = "DiskIOError"
+
 	// Reflects whether the QEMU guest agent is connected through the channel
 	VirtualMachineInstanceAgentConnected VirtualMachineInstanceConditionType = "AgentConnected"
 
@@ -233,6 +260,11 @@ const (
 	VirtualMachineInstanceReasonDisksNotMigratable = "DisksNotLiveMigratable"
 	// Reason means that VMI is not live migratioable because of it's network interfaces collection
 	VirtualMachineInstanceReasonInterfaceNotMigratable = "InterfaceNotLiveMigratable"
+
+	// Reflects the last time disks of the running VMI were grown to the capacity of their volumes
+	VirtualMachineInstanceVolumesResized VirtualMachineInstanceConditionType = "VolumesResized"
+	// Reason means that the disk of an expanded PersistentVolumeClaim was grown while the VMI was running
+	VirtualMachineInstanceReasonVolumesExpanded = "VolumesExpanded"
 )
 
 // +k8s:openapi-gen=true
@@ -328,6 +360,50 @@ type VirtualMachineInstanceGuestOSInfo struct {
 	ID string `json:"id,omitempty"`
 }
 
+// VirtualMachineInstanceSoftRebootState records the soft reboots of a VirtualMachineInstance
+type VirtualMachineInstanceSoftRebootState struct {
+	// The time the last soft reboot was requested
+	LastRebootTimestamp *metav1.Time `json:"lastRebootTimestamp,omitempty"`
+	// The number of soft reboots requested since the VirtualMachineInstance started
+	Count int64 `json:"count,omitempty"`
+}
+
+// ContainerDiskStatus records the image digest a containerDisk volume is pinned to
+type ContainerDiskStatus struct {
+	// Name of the containerDisk volume
+	Name string `json:"name"`
+	// The image as specified in the containerDisk volume
+	Image string `json:"image"`
+	// The digest of the image manifest, all pods of the VirtualMachineInstance run the image with this digest
+	ImageDigest string `json:"imageDigest"`
+}
+
+// VirtualMachineInstanceBackupState tracks the backup job of a VirtualMachineInstance
+type VirtualMachineInstanceBackupState struct {
+	// The name of the VirtualMachineBackup
+	BackupName string `json:"backupName"`
+	// The checkpoint which is created by the backup
+	Checkpoint string `json:"checkpoint"`
+	// The checkpoint the changed blocks are tracked against for an incremental backup
+	BaseCheckpoint string `json:"baseCheckpoint,omitempty"`
+	// The volumes which are exported by the backup job
+	Volumes []string `json:"volumes,omitempty"`
+	// The time the backup job began
+	StartTimestamp *metav1.Time `json:"startTimestamp,omitempty"`
+	// The time the backup job ended
+	EndTimestamp *metav1.Time `json:"endTimestamp,omitempty"`
+	// Indicates that the exports were read and the backup job should be finished
+	FinishRequested bool `json:"finishRequested,omitempty"`
+	// Indicates that the backup job should be aborted and its checkpoint dropped
+	AbortRequested bool `json:"abortRequested,omitempty"`
+	// Indicates the backup job completed
+	Completed bool `json:"completed,omitempty"`
+	// Indicates that the backup job failed
+	Failed bool `json:"failed,omitempty"`
+	// A human readable message about the outcome of the backup job
+	Message string `json:"message,omitempty"`
+}
+
 type VirtualMachineInstanceMigrationState struct {
 	// The time the migration action began
 	StartTimestamp *metav1.Time `json:"startTimestamp,omitempty"`
@@ -355,6 +431,8 @@ type VirtualMachineInstanceMigrationState struct {
 	AbortStatus MigrationAbortStatus `json:"abortStatus,omitempty"`
 	// The VirtualMachineInstanceMigration object associated with this migration
 	MigrationUID types.UID `json:"migrationUid,omitempty"`
+	// The volumes which are copied to other PVCs during the migration
+	MigratedVolumes []StorageMigratedVolume `json:"migratedVolumes,omitempty"`
 }
 
 // ---
@@ -442,6 +520,25 @@ const (
 	// if a particular node is alive and hence should be available for new
 	// virtual machine instance scheduling. Used on Node.
 	VirtHandlerHeartbeat string = "kubevirt.io/heartbeat"
+	// This label prefix marks the containerDisk images which are cached on
+	// a node, followed by a hash of the image. Used on Node.
+	ContainerDiskCachedImageLabelPrefix string = "containerdisk.kubevirt.io/"
+	// This label marks the pods which pull and keep the images of a
+	// ContainerDiskImageCache on a node. Used on Pod.
+	ContainerDiskImageCacheLabel string = "kubevirt.io/containerDiskImageCache"
+	// This annotation selects the format, 'raw' or 'qcow2', of the disk.img
+	// which is created on a filesystem PersistentVolumeClaim. Used on
+	// PersistentVolumeClaim.
+	DiskImageFormatAnnotation string = "kubevirt.io/disk-image-format"
+	// This annotation selects the preallocation mode of the disk.img which
+	// is created on a filesystem PersistentVolumeClaim. Used on
+	// PersistentVolumeClaim.
+	DiskImagePreallocationAnnotation string = "kubevirt.io/disk-image-preallocation"
+	// This annotation records the capacities of the PersistentVolumeClaims of
+	// a running virtual machine instance. virt-controller updates it when a
+	// claim is expanded, which makes virt-handler grow the disks. Used on
+	// VirtualMachineInstance.
+	VolumeCapacitiesAnnotation string = "kubevirt.io/volume-capacities"
 	// This label will be set on all resources created by the operator
 	ManagedByLabel              = "app.kubernetes.io/managed-by"
 	ManagedByLabelOperatorValue = "kubevirt-operator"
@@ -498,6 +595,11 @@ const (
 	Migrated        SyncEvent = "Migrated"
 	SyncFailed      SyncEvent = "SyncFailed"
 	Resumed         SyncEvent = "Resumed"
+	SoftRebooted    SyncEvent = "SoftRebooted"
+	VolumesResized  SyncEvent = "VolumesResized"
+	BackingUp       SyncEvent = "BackingUp"
+	BackedUp        SyncEvent = "BackedUp"
+	IOError         SyncEvent = "IOError"
 )
 
 func (s SyncEvent) String() string {
@@ -701,6 +803,29 @@ type VirtualMachineInstanceMigrationList struct {
 type VirtualMachineInstanceMigrationSpec struct {
 	// The name of the VMI to perform the migration on. VMI must exist in the migration objects namespace
 	VMIName string `json:"vmiName,omitempty" valid:"required"`
+	// The volumes to copy to other PVCs during the migration. Once the migration
+	// succeeded, the VMI and its VirtualMachine use the new PVCs.
+	MigratedVolumes []StorageMigratedVolume `json:"migratedVolumes,omitempty"`
+}
+
+// StorageMigratedVolume describes a volume which is copied to another PVC during a live migration
+// ---
+// +k8s:openapi-gen=true
+type StorageMigratedVolume struct {
+	// The name of the volume of the VMI
+	VolumeName string `json:"volumeName"`
+	// The name of the PVC the volume is copied to. It must have the same volume mode
+	// and disk image format as the PVC of the volume, and at least its capacity.
+	DestinationClaimName string `json:"destinationClaimName"`
+}
+
+// VirtualMachineMigrateVolumesRequest is sent to the migratevolumes subresource of a
+// VirtualMachine to live migrate it while copying volumes to other PVCs.
+// ---
+// +k8s:openapi-gen=true
+type VirtualMachineMigrateVolumesRequest struct {
+	// The volumes to copy to other PVCs during the migration
+	MigratedVolumes []StorageMigratedVolume `json:"migratedVolumes"`
 }
 
 // VirtualMachineInstanceMigration reprents information pertaining to a VMI's migration.
@@ -899,6 +1024,8 @@ type VirtualMachineStatus struct {
 	// StateChangeRequests indicates a list of actions that should be taken on a VMI
 	// e.g. stop a specific VMI then start a new one.
 	StateChangeRequests []VirtualMachineStateChangeRequest `json:"stateChangeRequests,omitempty" optional:"true"`
+	// MemoryDumpRequest tracks the memory dump of the VirtualMachineInstance into a PVC
+	MemoryDumpRequest *VirtualMachineMemoryDumpRequest `json:"memoryDumpRequest,omitempty" optional:"true"`
 }
 
 type VirtualMachineStateChangeRequest struct {
@@ -908,6 +1035,49 @@ type VirtualMachineStateChangeRequest struct {
 	UID *types.UID `json:"uid,omitempty" optional:"true" protobuf:"bytes,5,opt,name=uid,casttype=k8s.io/kubernetes/pkg/types.UID"`
 }
 
+// VirtualMachineMemoryDumpRequest represents a memory dump of a VirtualMachine
+// into a PVC. It is sent to the memorydump subresource and tracked in the
+// VirtualMachine status.
+// ---
+// +k8s:openapi-gen=true
+type VirtualMachineMemoryDumpRequest struct {
+	// ClaimName is the name of the PVC the memory is dumped to
+	ClaimName string `json:"claimName"`
+	// Phase represents the progress of the memory dump
+	// +optional
+	Phase MemoryDumpPhase `json:"phase,omitempty"`
+	// Remove indicates that the dump PVC should be dissociated from the VirtualMachine
+	// +optional
+	Remove bool `json:"remove,omitempty"`
+	// StartTimestamp is the time the memory dump started
+	// +optional
+	StartTimestamp *metav1.Time `json:"startTimestamp,omitempty"`
+	// EndTimestamp is the time the memory dump completed or failed
+	// +optional
+	EndTimestamp *metav1.Time `json:"endTimestamp,omitempty"`
+	// FileName is the name of the dump file on the PVC
+	// +optional
+	FileName *string `json:"fileName,omitempty"`
+	// Message is a detailed message about a failed memory dump
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// ---
+// +k8s:openapi-gen=true
+type MemoryDumpPhase string
+
+const (
+	// MemoryDumpInProgress means the memory is being dumped and copied to the PVC
+	MemoryDumpInProgress MemoryDumpPhase = "InProgress"
+	// MemoryDumpCompleted means the dump was written to the PVC
+	MemoryDumpCompleted MemoryDumpPhase = "Completed"
+	// MemoryDumpFailed means the memory dump failed
+	MemoryDumpFailed MemoryDumpPhase = "Failed"
+	// MemoryDumpDissociating means the dump PVC is being dissociated from the VirtualMachine
+	MemoryDumpDissociating MemoryDumpPhase = "Dissociating"
+)
+
 // VirtualMachineCondition represents the state of VirtualMachine
 // ---
 // +k8s:openapi-gen=true
@@ -947,6 +1117,32 @@ const (
 	HostDiskExists HostDiskType = "Disk"
 )
 
+// ---
+// +k8s:openapi-gen=true
+type HostDiskFormat string
+
+const (
+	// the disk image is a plain raw file
+	HostDiskFormatRaw HostDiskFormat = "raw"
+	// the disk image is a qcow2 file
+	HostDiskFormatQcow2 HostDiskFormat = "qcow2"
+)
+
+// ---
+// +k8s:openapi-gen=true
+type HostDiskPreallocation string
+
+const (
+	// no space is preallocated for the disk image
+	HostDiskPreallocationOff HostDiskPreallocation = "off"
+	// only the qcow2 metadata is preallocated
+	HostDiskPreallocationMetadata HostDiskPreallocation = "metadata"
+	// the space of the disk image is reserved with fallocate
+	HostDiskPreallocationFalloc HostDiskPreallocation = "falloc"
+	// the space of the disk image is reserved by writing zeroes to it
+	HostDiskPreallocationFull HostDiskPreallocation = "full"
+)
+
 // ---
 // +k8s:openapi-gen=true
 type NetworkInterfaceType string
@@ -971,6 +1167,21 @@ const (
 	CacheWriteThrough DriverCache = "writethrough"
 )
 
+// ---
+// +k8s:openapi-gen=true
+type DiskErrorPolicy string
+
+const (
+	// DiskErrorPolicyStop - the guest is paused on I/O errors.
+	DiskErrorPolicyStop DiskErrorPolicy = "stop"
+	// DiskErrorPolicyReport - I/O errors are reported to the guest.
+	DiskErrorPolicyReport DiskErrorPolicy = "report"
+	// DiskErrorPolicyIgnore - I/O errors are ignored.
+	DiskErrorPolicyIgnore DiskErrorPolicy = "ignore"
+	// DiskErrorPolicyEnospace - the guest is paused when the disk runs out of space, other I/O errors are reported to the guest.
+	DiskErrorPolicyEnospace DiskErrorPolicy = "enospace"
+)
+
 // Handler defines a specific action that should be taken
 // TODO: pass structured data to these actions, and document that data here.
 type Handler struct {
@@ -982,6 +1193,18 @@ type Handler struct {
 	// TODO: implement a realistic TCP lifecycle hook
 	// +optional
 	TCPSocket *k8sv1.TCPSocketAction `json:"tcpSocket,omitempty"`
+	// Exec specifies a command to run in the guest through the qemu guest agent.
+	// The command is considered healthy if it exits with status 0.
+	// +optional
+	Exec *k8sv1.ExecAction `json:"exec,omitempty"`
+	// GuestAgentPing contacts the qemu guest agent in the guest.
+	// The guest is considered healthy if the agent responds.
+	// +optional
+	GuestAgentPing *GuestAgentPing `json:"guestAgentPing,omitempty"`
+}
+
+// GuestAgentPing configures the guest agent based ping probe
+type GuestAgentPing struct {
 }
 
 // Probe describes a health check to be performed against a VirtualMachineInstance to determine whether it is
@@ -1054,8 +1277,219 @@ type KubeVirtSpec struct {
 	// The name of the Prometheus service account that needs read-access to KubeVirt endpoints
 	// Defaults to prometheus-k8s
 	MonitorAccount string `json:"monitorAccount,omitempty"`
+
+	// The strategy used to issue and rotate the certificates of the KubeVirt components
+	CertificateRotationStrategy KubeVirtCertificateRotateStrategy `json:"certificateRotateStrategy,omitempty"`
+
+	// The cluster-wide configuration of KubeVirt, applied by all components without restarting them.
+	// Settings take precedence over the deprecated kubevirt-config ConfigMap.
+	Configuration KubeVirtConfiguration `json:"configuration,omitempty"`
+
+	// The placement and replica count of the KubeVirt infrastructure components, like virt-api and virt-controller
+	Infra *ComponentConfig `json:"infra,omitempty"`
+
+	// The placement of the KubeVirt workload components, like virt-handler.
+	// It limits the nodes on which virtual machines can be scheduled.
+	// Changes which exclude nodes with running virtual machines are rejected.
+	Workloads *ComponentConfig `json:"workloads,omitempty"`
+
+	// Patches virt-operator applies to the resources it generates, before creating or updating them
+	CustomizeComponents CustomizeComponents `json:"customizeComponents,omitempty"`
+}
+
+// KubeVirtCertificateRotateStrategy describes how the certificates of the KubeVirt components are issued and rotated
+// ---
+// +k8s:openapi-gen=true
+type KubeVirtCertificateRotateStrategy struct {
+	// Let virt-operator issue and rotate a self-signed CA and the component certificates
+	SelfSigned *KubeVirtSelfSignConfiguration `json:"selfSigned,omitempty"`
 }
 
+// KubeVirtSelfSignConfiguration configures the self-signed CA and the certificates issued from it
+// ---
+// +k8s:openapi-gen=true
+type KubeVirtSelfSignConfiguration struct {
+	// The validity and renewal period of the KubeVirt CA
+	// Defaults to a duration of 168h and a renewal 84h before expiry
+	CA *CertConfig `json:"ca,omitempty"`
+	// The validity and renewal period of the component certificates
+	// Defaults to a duration of 24h and a renewal 12h before expiry
+	Server *CertConfig `json:"server,omitempty"`
+}
+
+// CertConfig contains the tunables for TLS certificates
+// ---
+// +k8s:openapi-gen=true
+type CertConfig struct {
+	// The requested lifetime of the certificate
+	Duration *metav1.Duration `json:"duration,omitempty"`
+	// The amount of time before the certificate expires at which it is renewed
+	RenewBefore *metav1.Duration `json:"renewBefore,omitempty"`
+}
+
+// KubeVirtConfiguration holds the cluster-wide configuration of KubeVirt
+// ---
+// +k8s:openapi-gen=true
+type KubeVirtConfiguration struct {
+	// The CPU model used for VirtualMachineInstances which do not request one
+	CPUModel string `json:"cpuModel,omitempty"`
+	// The CPU request of VirtualMachineInstances which do not request CPU resources
+	// Defaults to 100m
+	CPURequest *resource.Quantity `json:"cpuRequest,omitempty"`
+	// The machine type used for VirtualMachineInstances which do not request one
+	// Defaults to q35
+	MachineType string `json:"machineType,omitempty"`
+	// Glob patterns of the machine types VirtualMachineInstances may use
+	// Defaults to q35* and pc-q35*
+	EmulatedMachines []string `json:"emulatedMachines,omitempty"`
+	// The pull policy of the images of the KubeVirt pods started for VirtualMachineInstances
+	ImagePullPolicy k8sv1.PullPolicy `json:"imagePullPolicy,omitempty"`
+	// Tunables of live migrations
+	MigrationConfiguration *MigrationConfiguration `json:"migrations,omitempty"`
+	// Defaults and restrictions of the pod network interfaces
+	NetworkConfiguration *NetworkConfiguration `json:"network,omitempty"`
+	// The SMBIOS values reported to the guests
+	SMBIOSConfig *SMBiosConfiguration `json:"smbios,omitempty"`
+	// Feature gates and settings for development and testing
+	DeveloperConfiguration *DeveloperConfiguration `json:"developerConfiguration,omitempty"`
+}
+
+// MigrationConfiguration holds the tunables of live migrations
+// ---
+// +k8s:openapi-gen=true
+type MigrationConfiguration struct {
+	// The number of migrations which may run in parallel from a single node
+	// Defaults to 2
+	ParallelOutboundMigrationsPerNode *uint32 `json:"parallelOutboundMigrationsPerNode,omitempty"`
+	// The number of migrations which may run in parallel in the cluster
+	// Defaults to 5
+	ParallelMigrationsPerCluster *uint32 `json:"parallelMigrationsPerCluster,omitempty"`
+	// The bandwidth limit of each migration
+	// Defaults to 64Mi
+	BandwidthPerMigration *resource.Quantity `json:"bandwidthPerMigration,omitempty"`
+	// The taint key which makes virt-controller migrate VirtualMachineInstances away from a node
+	// Defaults to kubevirt.io/drain
+	NodeDrainTaintKey *string `json:"nodeDrainTaintKey,omitempty"`
+	// The number of seconds a migration may make no progress before it is aborted
+	// Defaults to 150
+	ProgressTimeout *int64 `json:"progressTimeout,omitempty"`
+	// The number of seconds per GiB of guest memory a migration may take before it is aborted
+	// Defaults to 800
+	CompletionTimeoutPerGiB *int64 `json:"completionTimeoutPerGiB,omitempty"`
+	// Allow migrations which may corrupt the guest disks
+	// Defaults to false
+	UnsafeMigrationOverride *bool `json:"unsafeMigrationOverride,omitempty"`
+	// Let the hypervisor throttle the guest CPUs if a migration does not converge
+	// Defaults to false
+	AllowAutoConverge *bool `json:"allowAutoConverge,omitempty"`
+}
+
+// NetworkConfiguration holds the defaults and restrictions of the pod network interfaces
+// ---
+// +k8s:openapi-gen=true
+type NetworkConfiguration struct {
+	// The binding method of the pod network interface if none is requested, one of bridge, masquerade and slirp
+	// Defaults to bridge
+	NetworkInterface string `json:"defaultNetworkInterface,omitempty"`
+	// Whether the slirp binding method may be used
+	// Defaults to false
+	PermitSlirpInterface *bool `json:"permitSlirpInterface,omitempty"`
+	// Whether the bridge binding method may be used on the pod network
+	// Defaults to true
+	PermitBridgeInterfaceOnPodNetwork *bool `json:"permitBridgeInterfaceOnPodNetwork,omitempty"`
+}
+
+// SMBiosConfiguration holds the SMBIOS values reported to the guests
+// ---
+// +k8s:openapi-gen=true
+type SMBiosConfiguration struct {
+	// Defaults to KubeVirt
+	Manufacturer string `json:"manufacturer,omitempty"`
+	// Defaults to None
+	Product string `json:"product,omitempty"`
+	Version string `json:"version,omitempty"`
+	Sku     string `json:"sku,omitempty"`
+	// Defaults to KubeVirt
+	Family string `json:"family,omitempty"`
+}
+
+// DeveloperConfiguration holds feature gates and settings for development and testing
+// ---
+// +k8s:openapi-gen=true
+type DeveloperConfiguration struct {
+	// The feature gates to enable, each entry names exactly one feature gate
+	FeatureGates []string `json:"featureGates,omitempty"`
+	// Use software emulation if hardware virtualization is not available
+	UseEmulation bool `json:"useEmulation,omitempty"`
+	// The percentage of the guest memory which is requested for the virt-launcher pod
+	// Defaults to 100
+	MemoryOvercommit int `json:"memoryOvercommit,omitempty"`
+	// The percentage by which a PersistentVolumeClaim may be smaller than requested for a disk image
+	// Defaults to 10
+	LessPVCSpaceToleration int `json:"pvcTolerateLessSpaceUpToPercent,omitempty"`
+	// Node labels which are added to the node selector of all virt-launcher pods
+	NodeSelectors map[string]string `json:"nodeSelectors,omitempty"`
+}
+
+// ComponentConfig describes the placement and scaling of a group of KubeVirt components
+// ---
+// +k8s:openapi-gen=true
+type ComponentConfig struct {
+	// The scheduling constraints of the components
+	NodePlacement *NodePlacement `json:"nodePlacement,omitempty"`
+	// The number of replicas of every infrastructure component
+	// Defaults to 2, ignored for the workload components
+	Replicas *uint8 `json:"replicas,omitempty"`
+}
+
+// NodePlacement describes the node scheduling constraints of KubeVirt components
+// ---
+// +k8s:openapi-gen=true
+type NodePlacement struct {
+	// The node labels the components are scheduled on, in addition to the ones KubeVirt requires
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+	// The affinity of the components, each kind of affinity replaces the default one of KubeVirt
+	Affinity *k8sv1.Affinity `json:"affinity,omitempty"`
+	// The tolerations of the components, in addition to the ones KubeVirt requires
+	Tolerations []k8sv1.Toleration `json:"tolerations,omitempty"`
+}
+
+// CustomizeComponents describes the patches applied to the resources generated by virt-operator
+// ---
+// +k8s:openapi-gen=true
+type CustomizeComponents struct {
+	// The patches, applied in the order they are listed
+	Patches []CustomizeComponentsPatch `json:"patches,omitempty"`
+}
+
+// CustomizeComponentsPatch describes a patch of a resource generated by virt-operator
+// ---
+// +k8s:openapi-gen=true
+type CustomizeComponentsPatch struct {
+	// The kind of the patched resource, e.g. Deployment
+	ResourceType string `json:"resourceType"`
+	// The name of the patched resource, "*" patches all resources of the kind
+	ResourceName string `json:"resourceName"`
+	// The patch body
+	Patch string `json:"patch"`
+	// The type of the patch, one of json, merge or strategic
+	Type PatchType `json:"type"`
+}
+
+// PatchType is the type of a patch of a resource generated by virt-operator
+// ---
+// +k8s:openapi-gen=true
+type PatchType string
+
+const (
+	// A JSON patch as defined by RFC 6902
+	JSONPatchType PatchType = "json"
+	// A JSON merge patch as defined by RFC 7386
+	MergePatchType PatchType = "merge"
+	// A Kubernetes strategic merge patch
+	StrategicMergePatchType PatchType = "strategic"
+)
+
 // KubeVirtStatus represents information pertaining to a KubeVirt deployment.
 // ---
 // +k8s:openapi-gen=true
@@ -1120,8 +1554,468 @@ const (
 	KubeVirtConditionProgressing KubeVirtConditionType = "Progressing"
 	// Whether KubeVirt is not functioning completely
 	KubeVirtConditionDegraded KubeVirtConditionType = "Degraded"
+	// Whether spec.configuration is valid (only used if false)
+	KubeVirtConditionConfigurationValid KubeVirtConditionType = "ConfigurationValid"
+)
+
+// ContainerDiskImageCache lists containerDisk images which are pulled in
+// advance to all nodes matching the node selector, so that VirtualMachineInstances
+// using them start without waiting for the image pull.
+// ---
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +k8s:openapi-gen=true
+type ContainerDiskImageCache struct {
+	metav1.TypeMeta `json:",inline"`
+	// +k8s:openapi-gen=false
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              ContainerDiskImageCacheSpec   `json:"spec" valid:"required"`
+	Status            ContainerDiskImageCacheStatus `json:"status,omitempty"`
+}
+
+// ContainerDiskImageCacheList is a list of ContainerDiskImageCaches
+// ---
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +k8s:openapi-gen=true
+type ContainerDiskImageCacheList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ContainerDiskImageCache `json:"items"`
+}
+
+// ---
+// +k8s:openapi-gen=true
+type ContainerDiskImageCacheSpec struct {
+	// Images are the containerDisk images to pull
+	Images []string `json:"images"`
+	// NodeSelector selects the nodes the images are pulled to.
+	// Defaults to all nodes which run virt-handler.
+	// +optional
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+	// ImagePullSecrets in the KubeVirt install namespace which are used to pull the images
+	// +optional
+	ImagePullSecrets []k8sv1.LocalObjectReference `json:"imagePullSecrets,omitempty"`
+}
+
+// ContainerDiskImageCacheStatus reports which images are cached on which nodes
+// ---
+// +k8s:openapi-gen=true
+type ContainerDiskImageCacheStatus struct {
+	// Nodes lists the cached images of every node matching the node selector
+	// +optional
+	Nodes []ContainerDiskImageCacheNodeStatus `json:"nodes,omitempty"`
+}
+
+// ---
+// +k8s:openapi-gen=true
+type ContainerDiskImageCacheNodeStatus struct {
+	// NodeName is the name of the node
+	NodeName string `json:"nodeName"`
+	// CachedImages are the images which are pulled to the node
+	// +optional
+	CachedImages []string `json:"cachedImages,omitempty"`
+}
+
+// VirtualMachineBackup creates a full or an incremental backup of the disks of
+// a running VirtualMachineInstance. Incremental backups only contain the blocks
+// which changed since the checkpoint of their base backup.
+// Backups require the VMBackup feature gate. The libvirt version in
+// virt-launcher has no backup API, so the backup jobs are driven through QMP
+// and are not tracked by libvirt.
+// ---
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +k8s:openapi-gen=true
+type VirtualMachineBackup struct {
+	metav1.TypeMeta `json:",inline"`
+	// +k8s:openapi-gen=false
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              VirtualMachineBackupSpec   `json:"spec" valid:"required"`
+	Status            VirtualMachineBackupStatus `json:"status,omitempty"`
+}
+
+// The backup phase indicates that the backup has completed
+func (b *VirtualMachineBackup) IsFinal() bool {
+	return b.Status.Phase == BackupFailed || b.Status.Phase == BackupSucceeded
+}
+
+// VirtualMachineBackupList is a list of VirtualMachineBackups
+// ---
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +k8s:openapi-gen=true
+type VirtualMachineBackupList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []VirtualMachineBackup `json:"items"`
+}
+
+// ---
+// +k8s:openapi-gen=true
+type VirtualMachineBackupSpec struct {
+	// The name of the VMI to back up. The VMI must exist in the namespace of the backup
+	VMIName string `json:"vmiName,omitempty" valid:"required"`
+	// Mode is either Full or Incremental. Defaults to Full.
+	// +optional
+	Mode VirtualMachineBackupMode `json:"mode,omitempty"`
+	// BaseBackupName is the backup an incremental backup is based on.
+	// Defaults to the latest succeeded backup of the VMI.
+	// +optional
+	BaseBackupName string `json:"baseBackupName,omitempty"`
+	// Target the backup is delivered to
+	Target VirtualMachineBackupTarget `json:"target"`
+}
+
+// VirtualMachineBackupTarget defines where the backup is delivered to.
+// Exactly one target has to be set.
+// ---
+// +k8s:openapi-gen=true
+type VirtualMachineBackupTarget struct {
+	// NBD exports the backup over NBD for a backup application to pull it.
+	// The NBD server only listens on a unix socket in a host directory on the
+	// node of the VMI, so the backup application has to run on that node.
+	// +optional
+	NBD *NBDBackupTarget `json:"nbd,omitempty"`
+	// PersistentVolumeClaim the backup is written to as one qcow2 image per disk
+	// +optional
+	PersistentVolumeClaim *k8sv1.PersistentVolumeClaimVolumeSource `json:"persistentVolumeClaim,omitempty"`
+}
+
+// ---
+// +k8s:openapi-gen=true
+type NBDBackupTarget struct {
+	// Done is set by the backup application once it read the exports, which finishes the backup
+	// +optional
+	Done bool `json:"done,omitempty"`
+}
+
+// ---
+// +k8s:openapi-gen=true
+type VirtualMachineBackupMode string
+
+const (
+	// BackupModeFull copies all blocks of the disks
+	BackupModeFull VirtualMachineBackupMode = "Full"
+	// BackupModeIncremental copies the blocks which changed since the checkpoint of the base backup
+	BackupModeIncremental VirtualMachineBackupMode = "Incremental"
+)
+
+// ---
+// +k8s:openapi-gen=true
+type VirtualMachineBackupStatus struct {
+	Phase VirtualMachineBackupPhase `json:"phase,omitempty"`
+	// Checkpoint created by this backup, later incremental backups are based on it
+	// +optional
+	Checkpoint string `json:"checkpoint,omitempty"`
+	// BaseCheckpoint is the checkpoint an incremental backup is based on
+	// +optional
+	BaseCheckpoint string `json:"baseCheckpoint,omitempty"`
+	// NBD describes the exports of a backup with a NBD target
+	// +optional
+	NBD *NBDBackupStatus `json:"nbd,omitempty"`
+	// The time the backup started
+	// +optional
+	StartTimestamp *metav1.Time `json:"startTimestamp,omitempty"`
+	// The time the backup completed or failed
+	// +optional
+	CompletionTimestamp *metav1.Time `json:"completionTimestamp,omitempty"`
+	// A human readable message indicating why the backup is in this phase
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// NBDBackupStatus describes how the exports of a backup can be read
+// ---
+// +k8s:openapi-gen=true
+type NBDBackupStatus struct {
+	// Node the NBD server is running on
+	Node string `json:"node"`
+	// Socket is the path of the unix socket of the NBD server on the node
+	Socket string `json:"socket"`
+	// Exports are the names of the NBD exports, one per backed up volume
+	Exports []string `json:"exports"`
+	// DirtyBitmapContext is the NBD meta context which reports the changed blocks of an incremental backup
+	// +optional
+	DirtyBitmapContext string `json:"dirtyBitmapContext,omitempty"`
+}
+
+// ---
+// +k8s:openapi-gen=true
+type VirtualMachineBackupPhase string
+
+// These are the valid backup phases
+const (
+	BackupPhaseUnset VirtualMachineBackupPhase = ""
+	// The backup is waiting for the VMI and for earlier backups of the VMI
+	BackupPending VirtualMachineBackupPhase = "Pending"
+	// The backup job is running in the virt-launcher pod
+	BackupRunning VirtualMachineBackupPhase = "Running"
+	// The backup passed
+	BackupSucceeded VirtualMachineBackupPhase = "Succeeded"
+	// The backup failed
+	BackupFailed VirtualMachineBackupPhase = "Failed"
+)
+
+// VirtualMachineExport serves the disks of a stopped VirtualMachine or of a
+// PersistentVolumeClaim over HTTPS, e.g. to copy them into another cluster.
+// ---
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +k8s:openapi-gen=true
+type VirtualMachineExport struct {
+	metav1.TypeMeta `json:",inline"`
+	// +k8s:openapi-gen=false
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              VirtualMachineExportSpec   `json:"spec" valid:"required"`
+	Status            VirtualMachineExportStatus `json:"status,omitempty"`
+}
+
+// VirtualMachineExportList is a list of VirtualMachineExports
+// ---
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +k8s:openapi-gen=true
+type VirtualMachineExportList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []VirtualMachineExport `json:"items"`
+}
+
+// ---
+// +k8s:openapi-gen=true
+type VirtualMachineExportSpec struct {
+	// Source is the VirtualMachine or PersistentVolumeClaim to export.
+	// It must exist in the namespace of the export.
+	Source k8sv1.TypedLocalObjectReference `json:"source"`
+	// TokenSecretRef is the name of the secret holding the token which clients
+	// have to present in the x-kubevirt-export-token header or query parameter.
+	// The token is read from the "token" key of the secret.
+	TokenSecretRef string `json:"tokenSecretRef"`
+}
+
+// ---
+// +k8s:openapi-gen=true
+type VirtualMachineExportStatus struct {
+	Phase VirtualMachineExportPhase `json:"phase,omitempty"`
+	// ServiceName is the name of the service in front of the exporter pod
+	// +optional
+	ServiceName string `json:"serviceName,omitempty"`
+	// Links to download the exported volumes and manifests from
+	// +optional
+	Links *VirtualMachineExportLinks `json:"links,omitempty"`
+	// A human readable message indicating why the export is in this phase
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// ---
+// +k8s:openapi-gen=true
+type VirtualMachineExportLinks struct {
+	// Internal links are reachable from within the cluster
+	// +optional
+	Internal *VirtualMachineExportLink `json:"internal,omitempty"`
+	// External links are reachable through an ingress, they are only
+	// available if an export ingress host is configured
+	// +optional
+	External *VirtualMachineExportLink `json:"external,omitempty"`
+}
+
+// ---
+// +k8s:openapi-gen=true
+type VirtualMachineExportLink struct {
+	// Cert is the PEM encoded CA certificate the links are served with.
+	// It is empty if the links are served with the certificate of an ingress.
+	// +optional
+	Cert string `json:"cert,omitempty"`
+	// Volumes lists the exported volumes and the formats they are available in
+	// +optional
+	Volumes []VirtualMachineExportVolume `json:"volumes,omitempty"`
+	// Manifests lists the exported manifests of a VirtualMachine source
+	// +optional
+	Manifests []VirtualMachineExportManifest `json:"manifests,omitempty"`
+}
+
+// ---
+// +k8s:openapi-gen=true
+type VirtualMachineExportVolume struct {
+	// Name of the volume, the name of the PersistentVolumeClaim for PersistentVolumeClaim sources
+	Name    string                             `json:"name"`
+	Formats []VirtualMachineExportVolumeFormat `json:"formats"`
+}
+
+// ---
+// +k8s:openapi-gen=true
+type VirtualMachineExportVolumeFormat struct {
+	Format ExportVolumeFormat `json:"format"`
+	Url    string             `json:"url"`
+}
+
+// ---
+// +k8s:openapi-gen=true
+type ExportVolumeFormat string
+
+const (
+	// ExportVolumeFormatRaw is the raw disk image
+	ExportVolumeFormatRaw ExportVolumeFormat = "raw"
+	// ExportVolumeFormatGzip is the gzip compressed raw disk image
+	ExportVolumeFormatGzip ExportVolumeFormat = "gzip"
+)
+
+// ---
+// +k8s:openapi-gen=true
+type VirtualMachineExportManifest struct {
+	Type ExportManifestType `json:"type"`
+	Url  string             `json:"url"`
+}
+
+// ---
+// +k8s:openapi-gen=true
+type ExportManifestType string
+
+const (
+	// ExportManifestTypeAll is the YAML manifest of the VirtualMachine, without status and cluster specific metadata
+	ExportManifestTypeAll ExportManifestType = "all"
+)
+
+// ---
+// +k8s:openapi-gen=true
+type VirtualMachineExportPhase string
+
+// These are the valid export phases
+const (
+	ExportPhaseUnset VirtualMachineExportPhase = ""
+	// The export waits for its source or for the exporter pod
+	ExportPending VirtualMachineExportPhase = "Pending"
+	// The exporter pod serves the links
+	ExportReady VirtualMachineExportPhase = "Ready"
 )
 
 const (
 	EvictionStrategyLiveMigrate EvictionStrategy = "LiveMigrate"
 )
+
+// VNCToken grants access to the VNC console of a VirtualMachineInstance
+// without a Kubernetes bearer token, e.g. for embedded noVNC clients.
+// ---
+// +k8s:openapi-gen=true
+type VNCToken struct {
+	// Token is passed to the novnc subresource as websocket subprotocol
+	// vnc-token.kubevirt.io.<token>, it can only be used once
+	Token string `json:"token"`
+	// ExpirationTimestamp after which the token is no longer accepted
+	ExpirationTimestamp metav1.Time `json:"expirationTimestamp"`
+}
+
+// VirtualMachineInstanceGuestAgentInfo represents information reported by
+// the guest agent of a VirtualMachineInstance
+// ---
+// +k8s:openapi-gen=true
+type VirtualMachineInstanceGuestAgentInfo struct {
+	metav1.TypeMeta `json:",inline"`
+	// GAVersion is the version of the installed guest agent
+	GAVersion string `json:"guestAgentVersion,omitempty"`
+	// Hostname is the FQDN of the guest
+	Hostname string `json:"hostname,omitempty"`
+	// OS contains the guest operating system information
+	OS VirtualMachineInstanceGuestOSInfo `json:"os,omitempty"`
+	// Timezone of the guest, in the form "name,offset in seconds"
+	Timezone string `json:"timezone,omitempty"`
+	// UserList contains the users which are logged into the guest
+	UserList []VirtualMachineInstanceGuestOSUser `json:"userList,omitempty"`
+	// FSInfo contains the mounted filesystems of the guest and their usage
+	FSInfo VirtualMachineInstanceFileSystemInfo `json:"fsInfo,omitempty"`
+}
+
+// VirtualMachineInstanceGuestOSUserList is a list of the users which are
+// logged into the guest
+// ---
+// +k8s:openapi-gen=true
+type VirtualMachineInstanceGuestOSUserList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []VirtualMachineInstanceGuestOSUser `json:"items"`
+}
+
+// VirtualMachineInstanceGuestOSUser is a user which is logged into the guest
+// ---
+// +k8s:openapi-gen=true
+type VirtualMachineInstanceGuestOSUser struct {
+	// UserName is the login name of the user
+	UserName string `json:"userName"`
+	// Domain of the user, only reported by Windows guests
+	Domain string `json:"domain,omitempty"`
+	// LoginTime is the time of the login in seconds since the epoch
+	LoginTime float64 `json:"loginTime,omitempty"`
+}
+
+// VirtualMachineInstanceFileSystemInfo contains the mounted filesystems of the guest
+// ---
+// +k8s:openapi-gen=true
+type VirtualMachineInstanceFileSystemInfo struct {
+	Filesystems []VirtualMachineInstanceFileSystem `json:"disks"`
+}
+
+// VirtualMachineInstanceFileSystemList is a list of the mounted filesystems
+// of the guest
+// ---
+// +k8s:openapi-gen=true
+type VirtualMachineInstanceFileSystemList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []VirtualMachineInstanceFileSystem `json:"items"`
+}
+
+// VirtualMachineInstanceFileSystem is a filesystem mounted in the guest
+// ---
+// +k8s:openapi-gen=true
+type VirtualMachineInstanceFileSystem struct {
+	// DiskName is the name of the device in the guest, e.g. "vda1"
+	DiskName string `json:"diskName"`
+	// MountPoint is the path the filesystem is mounted at
+	MountPoint string `json:"mountPoint"`
+	// FileSystemType is the type of the filesystem, e.g. "xfs"
+	FileSystemType string `json:"fileSystemType"`
+	// UsedBytes is the space used on the filesystem
+	UsedBytes int64 `json:"usedBytes"`
+	// TotalBytes is the size of the filesystem
+	TotalBytes int64 `json:"totalBytes"`
+}
+
+// VirtualMachineInstanceGuestExecRequest describes a command which is run
+// in the guest by the guest agent
+// ---
+// +k8s:openapi-gen=true
+type VirtualMachineInstanceGuestExecRequest struct {
+	// Command is the path of the executable in the guest
+	Command string `json:"command"`
+	// Args are passed to the command
+	// +optional
+	Args []string `json:"args,omitempty"`
+	// Input is written to the standard input of the command
+	// +optional
+	Input string `json:"input,omitempty"`
+	// TimeoutSeconds is the time the command may run, defaults to 30 seconds
+	// and may be at most 300 seconds
+	// +optional
+	TimeoutSeconds int32 `json:"timeoutSeconds,omitempty"`
+}
+
+// VirtualMachineInstanceGuestExecResult is the result of a command which
+// was run in the guest by the guest agent
+// ---
+// +k8s:openapi-gen=true
+type VirtualMachineInstanceGuestExecResult struct {
+	metav1.TypeMeta `json:",inline"`
+	// ExitCode of the command, or the number of the signal which terminated it
+	ExitCode int32 `json:"exitCode"`
+	// Signaled is set if the command was terminated by a signal
+	Signaled bool `json:"signaled,omitempty"`
+	// Stdout is the standard output of the command
+	Stdout string `json:"stdout,omitempty"`
+	// Stderr is the standard error output of the command
+	Stderr string `json:"stderr,omitempty"`
+	// OutputTruncated is set if the guest agent truncated stdout or stderr
+	OutputTruncated bool `json:"outputTruncated,omitempty"`
+}
+
+const (
+	// GuestExecDefaultTimeoutSeconds is the time a guest-exec command may run if no timeout is requested
+	GuestExecDefaultTimeoutSeconds = 30
+	// GuestExecMaxTimeoutSeconds is the longest time a guest-exec command may run
+	GuestExecMaxTimeoutSeconds = 300
+)
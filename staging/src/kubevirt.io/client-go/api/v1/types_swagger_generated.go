@@ -33,21 +33,25 @@ func (VirtualMachineInstanceSpec) SwaggerDoc() map[string]string {
 		"networks":                      "List of networks that can be attached to a vm's virtual interface.",
 		"dnsPolicy":                     "Set DNS policy for the pod.\nDefaults to \"ClusterFirst\".\nValid values are 'ClusterFirstWithHostNet', 'ClusterFirst', 'Default' or 'None'.\nDNS parameters given in DNSConfig will be merged with the policy selected with DNSPolicy.\nTo have DNS options set along with hostNetwork, you have to specify DNS policy\nexplicitly to 'ClusterFirstWithHostNet'.\n+optional",
 		"dnsConfig":                     "Specifies the DNS parameters of a pod.\nParameters specified here will be merged to the generated DNS\nconfiguration based on DNSPolicy.\n+optional",
+		"accessCredentials":             "Specifies ssh public keys and user passwords which are applied to the guest.\nChanges of the referenced Secrets are propagated to running guests\nif the guest agent is used.\n+optional",
 	}
 }
 
 func (VirtualMachineInstanceStatus) SwaggerDoc() map[string]string {
 	return map[string]string{
-		"":                "VirtualMachineInstanceStatus represents information about the status of a VirtualMachineInstance. Status may trail the actual\nstate of a system.",
-		"nodeName":        "NodeName is the name where the VirtualMachineInstance is currently running.",
-		"reason":          "A brief CamelCase message indicating details about why the VMI is in this state. e.g. 'NodeUnresponsive'\n+optional",
-		"conditions":      "Conditions are specific points in VirtualMachineInstance's pod runtime.",
-		"phase":           "Phase is the status of the VirtualMachineInstance in kubernetes world. It is not the VirtualMachineInstance status, but partially correlates to it.",
-		"interfaces":      "Interfaces represent the details of available network interfaces.",
-		"guestOSInfo":     "Guest OS Information",
-		"migrationState":  "Represents the status of a live migration",
-		"migrationMethod": "Represents the method using which the vmi can be migrated: live migration or block migration",
-		"qosClass":        "The Quality of Service (QOS) classification assigned to the virtual machine instance based on resource requirements\nSee PodQOSClass type for available QOS classes\nMore info: https://git.k8s.io/community/contributors/design-proposals/node/resource-qos.md\n+optional",
+		"":                      "VirtualMachineInstanceStatus represents information about the status of a VirtualMachineInstance. Status may trail the actual\nstate of a system.",
+		"nodeName":              "NodeName is the name where the VirtualMachineInstance is currently running.",
+		"reason":                "A brief CamelCase message indicating details about why the VMI is in this state. e.g. 'NodeUnresponsive'\n+optional",
+		"conditions":            "Conditions are specific points in VirtualMachineInstance's pod runtime.",
+		"phase":                 "Phase is the status of the VirtualMachineInstance in kubernetes world. It is not the VirtualMachineInstance status, but partially correlates to it.",
+		"interfaces":            "Interfaces represent the details of available network interfaces.",
+		"guestOSInfo":           "Guest OS Information",
+		"migrationState":        "Represents the status of a live migration",
+		"migrationMethod":       "Represents the method using which the vmi can be migrated: live migration or block migration",
+		"qosClass":              "The Quality of Service (QOS) classification assigned to the virtual machine instance based on resource requirements\nSee PodQOSClass type for available QOS classes\nMore info: https://git.k8s.io/community/contributors/design-proposals/node/resource-qos.md\n+optional",
+		"softRebootState":       "Represents the soft reboots of the guest which happened in the current pod\n+optional",
+		"containerDiskStatuses": "ContainerDiskStatuses records the digests the containerDisk images were pinned to at creation time\n+optional",
+		"backupState":           "BackupState tracks the backup job which runs for the VirtualMachineInstance\n+optional",
 	}
 }
 
@@ -82,6 +86,40 @@ func (VirtualMachineInstanceGuestOSInfo) SwaggerDoc() map[string]string {
 	}
 }
 
+func (VirtualMachineInstanceSoftRebootState) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":                    "VirtualMachineInstanceSoftRebootState records the soft reboots of a VirtualMachineInstance",
+		"lastRebootTimestamp": "The time the last soft reboot was requested",
+		"count":               "The number of soft reboots requested since the VirtualMachineInstance started",
+	}
+}
+
+func (ContainerDiskStatus) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":            "ContainerDiskStatus records the image digest a containerDisk volume is pinned to",
+		"name":        "Name of the containerDisk volume",
+		"image":       "The image as specified in the containerDisk volume",
+		"imageDigest": "The digest of the image manifest, all pods of the VirtualMachineInstance run the image with this digest",
+	}
+}
+
+func (VirtualMachineInstanceBackupState) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":                "VirtualMachineInstanceBackupState tracks the backup job of a VirtualMachineInstance",
+		"backupName":      "The name of the VirtualMachineBackup",
+		"checkpoint":      "The checkpoint which is created by the backup",
+		"baseCheckpoint":  "The checkpoint the changed blocks are tracked against for an incremental backup",
+		"volumes":         "The volumes which are exported by the backup job",
+		"startTimestamp":  "The time the backup job began",
+		"endTimestamp":    "The time the backup job ended",
+		"finishRequested": "Indicates that the exports were read and the backup job should be finished",
+		"abortRequested":  "Indicates that the backup job should be aborted and its checkpoint dropped",
+		"completed":       "Indicates the backup job completed",
+		"failed":          "Indicates that the backup job failed",
+		"message":         "A human readable message about the outcome of the backup job",
+	}
+}
+
 func (VirtualMachineInstanceMigrationState) SwaggerDoc() map[string]string {
 	return map[string]string{
 		"startTimestamp":                 "The time the migration action began",
@@ -97,6 +135,7 @@ func (VirtualMachineInstanceMigrationState) SwaggerDoc() map[string]string {
 		"abortRequested":                 "Indicates that the migration has been requested to abort",
 		"abortStatus":                    "Indicates the final status of the live migration abortion",
 		"migrationUid":                   "The VirtualMachineInstanceMigration object associated with this migration",
+		"migratedVolumes":                "The volumes which are copied to other PVCs during the migration",
 	}
 }
 
@@ -161,7 +200,23 @@ func (VirtualMachineInstanceMigrationList) SwaggerDoc() map[string]string {
 
 func (VirtualMachineInstanceMigrationSpec) SwaggerDoc() map[string]string {
 	return map[string]string{
-		"vmiName": "The name of the VMI to perform the migration on. VMI must exist in the migration objects namespace",
+		"vmiName":         "The name of the VMI to perform the migration on. VMI must exist in the migration objects namespace",
+		"migratedVolumes": "The volumes to copy to other PVCs during the migration. Once the migration succeeded, the VMI and its VirtualMachine use the new PVCs.",
+	}
+}
+
+func (StorageMigratedVolume) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":                     "StorageMigratedVolume describes a volume which is copied to another PVC during a live migration",
+		"volumeName":           "The name of the volume of the VMI",
+		"destinationClaimName": "The name of the PVC the volume is copied to. It must have the same volume mode\nand disk image format as the PVC of the volume, and at least its capacity.",
+	}
+}
+
+func (VirtualMachineMigrateVolumesRequest) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":                "VirtualMachineMigrateVolumesRequest is sent to the migratevolumes subresource of a\nVirtualMachine to live migrate it while copying volumes to other PVCs.",
+		"migratedVolumes": "The volumes to copy to other PVCs during the migration",
 	}
 }
 
@@ -221,6 +276,7 @@ func (VirtualMachineStatus) SwaggerDoc() map[string]string {
 		"ready":               "Ready indicates if the virtual machine is running and ready",
 		"conditions":          "Hold the state information of the VirtualMachine and its VirtualMachineInstance",
 		"stateChangeRequests": "StateChangeRequests indicates a list of actions that should be taken on a VMI\ne.g. stop a specific VMI then start a new one.",
+		"memoryDumpRequest":   "MemoryDumpRequest tracks the memory dump of the VirtualMachineInstance into a PVC",
 	}
 }
 
@@ -231,6 +287,19 @@ func (VirtualMachineStateChangeRequest) SwaggerDoc() map[string]string {
 	}
 }
 
+func (VirtualMachineMemoryDumpRequest) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":               "VirtualMachineMemoryDumpRequest represents a memory dump of a VirtualMachine\ninto a PVC. It is sent to the memorydump subresource and tracked in the\nVirtualMachine status.",
+		"claimName":      "ClaimName is the name of the PVC the memory is dumped to",
+		"phase":          "Phase represents the progress of the memory dump\n+optional",
+		"remove":         "Remove indicates that the dump PVC should be dissociated from the VirtualMachine\n+optional",
+		"startTimestamp": "StartTimestamp is the time the memory dump started\n+optional",
+		"endTimestamp":   "EndTimestamp is the time the memory dump completed or failed\n+optional",
+		"fileName":       "FileName is the name of the dump file on the PVC\n+optional",
+		"message":        "Message is a detailed message about a failed memory dump\n+optional",
+	}
+}
+
 func (VirtualMachineCondition) SwaggerDoc() map[string]string {
 	return map[string]string{
 		"": "VirtualMachineCondition represents the state of VirtualMachine",
@@ -239,9 +308,17 @@ func (VirtualMachineCondition) SwaggerDoc() map[string]string {
 
 func (Handler) SwaggerDoc() map[string]string {
 	return map[string]string{
-		"":          "Handler defines a specific action that should be taken",
-		"httpGet":   "HTTPGet specifies the http request to perform.\n+optional",
-		"tcpSocket": "TCPSocket specifies an action involving a TCP port.\nTCP hooks not yet supported\n+optional",
+		"":               "Handler defines a specific action that should be taken",
+		"httpGet":        "HTTPGet specifies the http request to perform.\n+optional",
+		"tcpSocket":      "TCPSocket specifies an action involving a TCP port.\nTCP hooks not yet supported\n+optional",
+		"exec":           "Exec specifies a command to run in the guest through the qemu guest agent.\nThe command is considered healthy if it exits with status 0.\n+optional",
+		"guestAgentPing": "GuestAgentPing contacts the qemu guest agent in the guest.\nThe guest is considered healthy if the agent responds.\n+optional",
+	}
+}
+
+func (GuestAgentPing) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"": "GuestAgentPing configures the guest agent based ping probe",
 	}
 }
 
@@ -270,11 +347,16 @@ func (KubeVirtList) SwaggerDoc() map[string]string {
 
 func (KubeVirtSpec) SwaggerDoc() map[string]string {
 	return map[string]string{
-		"imageTag":         "The image tag to use for the continer images installed.\nDefaults to the same tag as the operator's container image.",
-		"imageRegistry":    "The image registry to pull the container images from\nDefaults to the same registry the operator's container image is pulled from.",
-		"imagePullPolicy":  "The ImagePullPolicy to use.",
-		"monitorNamespace": "The namespace Prometheus is deployed in\nDefaults to openshift-monitor",
-		"monitorAccount":   "The name of the Prometheus service account that needs read-access to KubeVirt endpoints\nDefaults to prometheus-k8s",
+		"imageTag":                  "The image tag to use for the continer images installed.\nDefaults to the same tag as the operator's container image.",
+		"imageRegistry":             "The image registry to pull the container images from\nDefaults to the same registry the operator's container image is pulled from.",
+		"imagePullPolicy":           "The ImagePullPolicy to use.",
+		"monitorNamespace":          "The namespace Prometheus is deployed in\nDefaults to openshift-monitor",
+		"monitorAccount":            "The name of the Prometheus service account that needs read-access to KubeVirt endpoints\nDefaults to prometheus-k8s",
+		"certificateRotateStrategy": "The strategy used to issue and rotate the certificates of the KubeVirt components",
+		"configuration":             "The cluster-wide configuration of KubeVirt, applied by all components without restarting them.\nSettings take precedence over the deprecated kubevirt-config ConfigMap.",
+		"infra":                     "The placement and replica count of the KubeVirt infrastructure components, like virt-api and virt-controller",
+		"workloads":                 "The placement of the KubeVirt workload components, like virt-handler.\nIt limits the nodes on which virtual machines can be scheduled.\nChanges which exclude nodes with running virtual machines are rejected.",
+		"customizeComponents":       "Patches virt-operator applies to the resources it generates, before creating or updating them",
 	}
 }
 
@@ -289,3 +371,352 @@ func (KubeVirtCondition) SwaggerDoc() map[string]string {
 		"": "KubeVirtCondition represents a condition of a KubeVirt deployment",
 	}
 }
+
+func (ContainerDiskImageCache) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"": "ContainerDiskImageCache lists containerDisk images which are pulled in\nadvance to all nodes matching the node selector, so that VirtualMachineInstances\nusing them start without waiting for the image pull.",
+	}
+}
+
+func (ContainerDiskImageCacheList) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"": "ContainerDiskImageCacheList is a list of ContainerDiskImageCaches",
+	}
+}
+
+func (ContainerDiskImageCacheSpec) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"images":           "Images are the containerDisk images to pull",
+		"nodeSelector":     "NodeSelector selects the nodes the images are pulled to.\nDefaults to all nodes which run virt-handler.\n+optional",
+		"imagePullSecrets": "ImagePullSecrets in the KubeVirt install namespace which are used to pull the images\n+optional",
+	}
+}
+
+func (ContainerDiskImageCacheStatus) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":      "ContainerDiskImageCacheStatus reports which images are cached on which nodes",
+		"nodes": "Nodes lists the cached images of every node matching the node selector\n+optional",
+	}
+}
+
+func (ContainerDiskImageCacheNodeStatus) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"nodeName":     "NodeName is the name of the node",
+		"cachedImages": "CachedImages are the images which are pulled to the node\n+optional",
+	}
+}
+
+func (VirtualMachineBackup) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"": "VirtualMachineBackup creates a full or an incremental backup of the disks of\na running VirtualMachineInstance. Incremental backups only contain the blocks\nwhich changed since the checkpoint of their base backup.\nBackups require the VMBackup feature gate. The libvirt version in\nvirt-launcher has no backup API, so the backup jobs are driven through QMP\nand are not tracked by libvirt.",
+	}
+}
+
+func (VirtualMachineBackupList) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"": "VirtualMachineBackupList is a list of VirtualMachineBackups",
+	}
+}
+
+func (VirtualMachineBackupSpec) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"vmiName":        "The name of the VMI to back up. The VMI must exist in the namespace of the backup",
+		"mode":           "Mode is either Full or Incremental. Defaults to Full.\n+optional",
+		"baseBackupName": "BaseBackupName is the backup an incremental backup is based on.\nDefaults to the latest succeeded backup of the VMI.\n+optional",
+		"target":         "Target the backup is delivered to",
+	}
+}
+
+func (VirtualMachineBackupTarget) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":                      "VirtualMachineBackupTarget defines where the backup is delivered to.\nExactly one target has to be set.",
+		"nbd":                   "NBD exports the backup over NBD for a backup application to pull it.\nThe NBD server only listens on a unix socket in a host directory on the\nnode of the VMI, so the backup application has to run on that node.\n+optional",
+		"persistentVolumeClaim": "PersistentVolumeClaim the backup is written to as one qcow2 image per disk\n+optional",
+	}
+}
+
+func (NBDBackupTarget) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"done": "Done is set by the backup application once it read the exports, which finishes the backup\n+optional",
+	}
+}
+
+func (VirtualMachineBackupStatus) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"checkpoint":          "Checkpoint created by this backup, later incremental backups are based on it\n+optional",
+		"baseCheckpoint":      "BaseCheckpoint is the checkpoint an incremental backup is based on\n+optional",
+		"nbd":                 "NBD describes the exports of a backup with a NBD target\n+optional",
+		"startTimestamp":      "The time the backup started\n+optional",
+		"completionTimestamp": "The time the backup completed or failed\n+optional",
+		"message":             "A human readable message indicating why the backup is in this phase\n+optional",
+	}
+}
+
+func (NBDBackupStatus) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":                   "NBDBackupStatus describes how the exports of a backup can be read",
+		"node":               "Node the NBD server is running on",
+		"socket":             "Socket is the path of the unix socket of the NBD server on the node",
+		"exports":            "Exports are the names of the NBD exports, one per backed up volume",
+		"dirtyBitmapContext": "DirtyBitmapContext is the NBD meta context which reports the changed blocks of an incremental backup\n+optional",
+	}
+}
+
+func (VNCToken) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":                    "VNCToken grants access to the VNC console of a VirtualMachineInstance\nwithout a Kubernetes bearer token, e.g. for embedded noVNC clients.",
+		"token":               "Token is passed to the novnc subresource as websocket subprotocol\nvnc-token.kubevirt.io.<token>, it can only be used once",
+		"expirationTimestamp": "ExpirationTimestamp after which the token is no longer accepted",
+	}
+}
+
+func (VirtualMachineInstanceGuestAgentInfo) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":                  "VirtualMachineInstanceGuestAgentInfo represents information reported by\nthe guest agent of a VirtualMachineInstance",
+		"guestAgentVersion": "GAVersion is the version of the installed guest agent",
+		"hostname":          "Hostname is the FQDN of the guest",
+		"os":                "OS contains the guest operating system information",
+		"timezone":          "Timezone of the guest, in the form \"name,offset in seconds\"",
+		"userList":          "UserList contains the users which are logged into the guest",
+		"fsInfo":            "FSInfo contains the mounted filesystems of the guest and their usage",
+	}
+}
+
+func (VirtualMachineInstanceGuestOSUserList) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"": "VirtualMachineInstanceGuestOSUserList is a list of the users which are\nlogged into the guest",
+	}
+}
+
+func (VirtualMachineInstanceGuestOSUser) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":          "VirtualMachineInstanceGuestOSUser is a user which is logged into the guest",
+		"userName":  "UserName is the login name of the user",
+		"domain":    "Domain of the user, only reported by Windows guests",
+		"loginTime": "LoginTime is the time of the login in seconds since the epoch",
+	}
+}
+
+func (VirtualMachineInstanceFileSystemInfo) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"": "VirtualMachineInstanceFileSystemInfo contains the mounted filesystems of the guest",
+	}
+}
+
+func (VirtualMachineInstanceFileSystemList) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"": "VirtualMachineInstanceFileSystemList is a list of the mounted filesystems\nof the guest",
+	}
+}
+
+func (VirtualMachineInstanceFileSystem) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":               "VirtualMachineInstanceFileSystem is a filesystem mounted in the guest",
+		"diskName":       "DiskName is the name of the device in the guest, e.g. \"vda1\"",
+		"mountPoint":     "MountPoint is the path the filesystem is mounted at",
+		"fileSystemType": "FileSystemType is the type of the filesystem, e.g. \"xfs\"",
+		"usedBytes":      "UsedBytes is the space used on the filesystem",
+		"totalBytes":     "TotalBytes is the size of the filesystem",
+	}
+}
+
+func (VirtualMachineInstanceGuestExecRequest) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":               "VirtualMachineInstanceGuestExecRequest describes a command which is run\nin the guest by the guest agent",
+		"command":        "Command is the path of the executable in the guest",
+		"args":           "Args are passed to the command\n+optional",
+		"input":          "Input is written to the standard input of the command\n+optional",
+		"timeoutSeconds": "TimeoutSeconds is the time the command may run, defaults to 30 seconds\nand may be at most 300 seconds\n+optional",
+	}
+}
+
+func (VirtualMachineInstanceGuestExecResult) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":                "VirtualMachineInstanceGuestExecResult is the result of a command which\nwas run in the guest by the guest agent",
+		"exitCode":        "ExitCode of the command, or the number of the signal which terminated it",
+		"signaled":        "Signaled is set if the command was terminated by a signal",
+		"stdout":          "Stdout is the standard output of the command",
+		"stderr":          "Stderr is the standard error output of the command",
+		"outputTruncated": "OutputTruncated is set if the guest agent truncated stdout or stderr",
+	}
+}
+
+func (VirtualMachineExport) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"": "VirtualMachineExport serves the disks of a stopped VirtualMachine or of a\nPersistentVolumeClaim over HTTPS, e.g. to copy them into another cluster.",
+	}
+}
+
+func (VirtualMachineExportList) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"": "VirtualMachineExportList is a list of VirtualMachineExports",
+	}
+}
+
+func (VirtualMachineExportSpec) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":               "",
+		"source":         "Source is the VirtualMachine or PersistentVolumeClaim to export.\nIt must exist in the namespace of the export.",
+		"tokenSecretRef": "TokenSecretRef is the name of the secret holding the token which clients\nhave to present in the x-kubevirt-export-token header or query parameter.\nThe token is read from the \"token\" key of the secret.",
+	}
+}
+
+func (VirtualMachineExportStatus) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":            "",
+		"serviceName": "ServiceName is the name of the service in front of the exporter pod\n+optional",
+		"links":       "Links to download the exported volumes and manifests from\n+optional",
+		"message":     "A human readable message indicating why the export is in this phase\n+optional",
+	}
+}
+
+func (VirtualMachineExportLinks) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":         "",
+		"internal": "Internal links are reachable from within the cluster\n+optional",
+		"external": "External links are reachable through an ingress, they are only\navailable if an export ingress host is configured\n+optional",
+	}
+}
+
+func (VirtualMachineExportLink) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":          "",
+		"cert":      "Cert is the PEM encoded CA certificate the links are served with.\nIt is empty if the links are served with the certificate of an ingress.\n+optional",
+		"volumes":   "Volumes lists the exported volumes and the formats they are available in\n+optional",
+		"manifests": "Manifests lists the exported manifests of a VirtualMachine source\n+optional",
+	}
+}
+
+func (VirtualMachineExportVolume) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":     "",
+		"name": "Name of the volume, the name of the PersistentVolumeClaim for PersistentVolumeClaim sources",
+	}
+}
+
+func (VirtualMachineExportVolumeFormat) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"": "",
+	}
+}
+
+func (VirtualMachineExportManifest) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"": "",
+	}
+}
+
+func (KubeVirtCertificateRotateStrategy) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":           "KubeVirtCertificateRotateStrategy describes how the certificates of the KubeVirt components are issued and rotated",
+		"selfSigned": "Let virt-operator issue and rotate a self-signed CA and the component certificates",
+	}
+}
+
+func (KubeVirtSelfSignConfiguration) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":       "KubeVirtSelfSignConfiguration configures the self-signed CA and the certificates issued from it",
+		"ca":     "The validity and renewal period of the KubeVirt CA\nDefaults to a duration of 168h and a renewal 84h before expiry",
+		"server": "The validity and renewal period of the component certificates\nDefaults to a duration of 24h and a renewal 12h before expiry",
+	}
+}
+
+func (CertConfig) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":            "CertConfig contains the tunables for TLS certificates",
+		"duration":    "The requested lifetime of the certificate",
+		"renewBefore": "The amount of time before the certificate expires at which it is renewed",
+	}
+}
+
+func (KubeVirtConfiguration) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":                       "KubeVirtConfiguration holds the cluster-wide configuration of KubeVirt",
+		"cpuModel":               "The CPU model used for VirtualMachineInstances which do not request one",
+		"cpuRequest":             "The CPU request of VirtualMachineInstances which do not request CPU resources\nDefaults to 100m",
+		"machineType":            "The machine type used for VirtualMachineInstances which do not request one\nDefaults to q35",
+		"emulatedMachines":       "Glob patterns of the machine types VirtualMachineInstances may use\nDefaults to q35* and pc-q35*",
+		"imagePullPolicy":        "The pull policy of the images of the KubeVirt pods started for VirtualMachineInstances",
+		"migrations":             "Tunables of live migrations",
+		"network":                "Defaults and restrictions of the pod network interfaces",
+		"smbios":                 "The SMBIOS values reported to the guests",
+		"developerConfiguration": "Feature gates and settings for development and testing",
+	}
+}
+
+func (MigrationConfiguration) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":                                  "MigrationConfiguration holds the tunables of live migrations",
+		"parallelOutboundMigrationsPerNode": "The number of migrations which may run in parallel from a single node\nDefaults to 2",
+		"parallelMigrationsPerCluster":      "The number of migrations which may run in parallel in the cluster\nDefaults to 5",
+		"bandwidthPerMigration":             "The bandwidth limit of each migration\nDefaults to 64Mi",
+		"nodeDrainTaintKey":                 "The taint key which makes virt-controller migrate VirtualMachineInstances away from a node\nDefaults to kubevirt.io/drain",
+		"progressTimeout":                   "The number of seconds a migration may make no progress before it is aborted\nDefaults to 150",
+		"completionTimeoutPerGiB":           "The number of seconds per GiB of guest memory a migration may take before it is aborted\nDefaults to 800",
+		"unsafeMigrationOverride":           "Allow migrations which may corrupt the guest disks\nDefaults to false",
+		"allowAutoConverge":                 "Let the hypervisor throttle the guest CPUs if a migration does not converge\nDefaults to false",
+	}
+}
+
+func (NetworkConfiguration) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":                                  "NetworkConfiguration holds the defaults and restrictions of the pod network interfaces",
+		"defaultNetworkInterface":           "The binding method of the pod network interface if none is requested, one of bridge, masquerade and slirp\nDefaults to bridge",
+		"permitSlirpInterface":              "Whether the slirp binding method may be used\nDefaults to false",
+		"permitBridgeInterfaceOnPodNetwork": "Whether the bridge binding method may be used on the pod network\nDefaults to true",
+	}
+}
+
+func (SMBiosConfiguration) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":             "SMBiosConfiguration holds the SMBIOS values reported to the guests",
+		"manufacturer": "Defaults to KubeVirt",
+		"product":      "Defaults to None",
+		"family":       "Defaults to KubeVirt",
+	}
+}
+
+func (DeveloperConfiguration) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":                                "DeveloperConfiguration holds feature gates and settings for development and testing",
+		"featureGates":                    "The feature gates to enable, each entry names exactly one feature gate",
+		"useEmulation":                    "Use software emulation if hardware virtualization is not available",
+		"memoryOvercommit":                "The percentage of the guest memory which is requested for the virt-launcher pod\nDefaults to 100",
+		"pvcTolerateLessSpaceUpToPercent": "The percentage by which a PersistentVolumeClaim may be smaller than requested for a disk image\nDefaults to 10",
+		"nodeSelectors":                   "Node labels which are added to the node selector of all virt-launcher pods",
+	}
+}
+
+func (ComponentConfig) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":              "ComponentConfig describes the placement and scaling of a group of KubeVirt components",
+		"nodePlacement": "The scheduling constraints of the components",
+		"replicas":      "The number of replicas of every infrastructure component\nDefaults to 2, ignored for the workload components",
+	}
+}
+
+func (NodePlacement) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":             "NodePlacement describes the node scheduling constraints of KubeVirt components",
+		"nodeSelector": "The node labels the components are scheduled on, in addition to the ones KubeVirt requires",
+		"affinity":     "The affinity of the components, each kind of affinity replaces the default one of KubeVirt",
+		"tolerations":  "The tolerations of the components, in addition to the ones KubeVirt requires",
+	}
+}
+
+func (CustomizeComponents) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":        "CustomizeComponents describes the patches applied to the resources generated by virt-operator",
+		"patches": "The patches, applied in the order they are listed",
+	}
+}
+
+func (CustomizeComponentsPatch) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":             "CustomizeComponentsPatch describes a patch of a resource generated by virt-operator",
+		"resourceType": "The kind of the patched resource, e.g. Deployment",
+		"resourceName": "The name of the patched resource, \"*\" patches all resources of the kind",
+		"patch":        "The patch body",
+		"type":         "The type of the patch, one of json, merge or strategic",
+	}
+}
@@ -0,0 +1,145 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2020 Red Hat, Inc.
+ *
+ */
+
+package kubecli
+
+import (
+	"net/http"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+	k8sv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	k8smetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+var _ = Describe("Kubevirt Backup Client", func() {
+
+	var server *ghttp.Server
+	var client KubevirtClient
+	basePath := "/apis/kubevirt.io/v1alpha3/namespaces/default/virtualmachinebackups"
+	backupPath := basePath + "/testbackup"
+
+	BeforeEach(func() {
+		var err error
+		server = ghttp.NewServer()
+		client, err = GetKubevirtClientFromFlags(server.URL(), "")
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("should fetch a Backup", func() {
+		backup := NewMinimalBackup("testbackup")
+		server.AppendHandlers(ghttp.CombineHandlers(
+			ghttp.VerifyRequest("GET", backupPath),
+			ghttp.RespondWithJSONEncoded(http.StatusOK, backup),
+		))
+		fetchedBackup, err := client.VirtualMachineBackup(k8sv1.NamespaceDefault).Get("testbackup", &k8smetav1.GetOptions{})
+
+		Expect(server.ReceivedRequests()).To(HaveLen(1))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(fetchedBackup).To(Equal(backup))
+	})
+
+	It("should detect non existent Backups", func() {
+		server.AppendHandlers(ghttp.CombineHandlers(
+			ghttp.VerifyRequest("GET", backupPath),
+			ghttp.RespondWithJSONEncoded(http.StatusNotFound, errors.NewNotFound(schema.GroupResource{}, "testbackup")),
+		))
+		_, err := client.VirtualMachineBackup(k8sv1.NamespaceDefault).Get("testbackup", &k8smetav1.GetOptions{})
+
+		Expect(server.ReceivedRequests()).To(HaveLen(1))
+		Expect(err).To(HaveOccurred())
+		Expect(errors.IsNotFound(err)).To(BeTrue())
+	})
+
+	It("should fetch a Backup list", func() {
+		backup := NewMinimalBackup("testbackup")
+		server.AppendHandlers(ghttp.CombineHandlers(
+			ghttp.VerifyRequest("GET", basePath),
+			ghttp.RespondWithJSONEncoded(http.StatusOK, NewBackupList(*backup)),
+		))
+		fetchedBackupList, err := client.VirtualMachineBackup(k8sv1.NamespaceDefault).List(&k8smetav1.ListOptions{})
+
+		Expect(server.ReceivedRequests()).To(HaveLen(1))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(fetchedBackupList.Items).To(HaveLen(1))
+		Expect(fetchedBackupList.Items[0]).To(Equal(*backup))
+	})
+
+	It("should create a Backup", func() {
+		backup := NewMinimalBackup("testbackup")
+		server.AppendHandlers(ghttp.CombineHandlers(
+			ghttp.VerifyRequest("POST", basePath),
+			ghttp.RespondWithJSONEncoded(http.StatusCreated, backup),
+		))
+		createdBackup, err := client.VirtualMachineBackup(k8sv1.NamespaceDefault).Create(backup)
+
+		Expect(server.ReceivedRequests()).To(HaveLen(1))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(createdBackup).To(Equal(backup))
+	})
+
+	It("should update a Backup", func() {
+		backup := NewMinimalBackup("testbackup")
+		server.AppendHandlers(ghttp.CombineHandlers(
+			ghttp.VerifyRequest("PUT", backupPath),
+			ghttp.RespondWithJSONEncoded(http.StatusOK, backup),
+		))
+		updatedBackup, err := client.VirtualMachineBackup(k8sv1.NamespaceDefault).Update(backup)
+
+		Expect(server.ReceivedRequests()).To(HaveLen(1))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(updatedBackup).To(Equal(backup))
+	})
+
+	It("should patch a Backup", func() {
+		backup := NewMinimalBackup("testbackup")
+		backup.Spec.VMIName = "somethingelse"
+
+		server.AppendHandlers(ghttp.CombineHandlers(
+			ghttp.VerifyRequest("PATCH", backupPath),
+			ghttp.VerifyBody([]byte("{\"spec\":{\"vmiName\":something}}")),
+			ghttp.RespondWithJSONEncoded(http.StatusOK, backup),
+		))
+
+		_, err := client.VirtualMachineBackup(k8sv1.NamespaceDefault).Patch(backup.Name, types.MergePatchType,
+			[]byte("{\"spec\":{\"vmiName\":something}}"))
+
+		Expect(server.ReceivedRequests()).To(HaveLen(1))
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("should delete a Backup", func() {
+		server.AppendHandlers(ghttp.CombineHandlers(
+			ghttp.VerifyRequest("DELETE", backupPath),
+			ghttp.RespondWithJSONEncoded(http.StatusOK, nil),
+		))
+		err := client.VirtualMachineBackup(k8sv1.NamespaceDefault).Delete("testbackup", &k8smetav1.DeleteOptions{})
+
+		Expect(server.ReceivedRequests()).To(HaveLen(1))
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+})
@@ -0,0 +1,139 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2020 Red Hat, Inc.
+ *
+ */
+
+package kubecli
+
+import (
+	k8smetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+
+	v1 "kubevirt.io/client-go/api/v1"
+)
+
+func (k *kubevirt) ContainerDiskImageCache() ContainerDiskImageCacheInterface {
+	return &containerDiskImageCache{
+		restClient: k.restClient,
+		resource:   "containerdiskimagecaches",
+	}
+}
+
+type containerDiskImageCache struct {
+	restClient *rest.RESTClient
+	resource   string
+}
+
+// Create a new ContainerDiskImageCache in the cluster
+func (o *containerDiskImageCache) Create(imageCache *v1.ContainerDiskImageCache) (*v1.ContainerDiskImageCache, error) {
+	newImageCache := &v1.ContainerDiskImageCache{}
+	err := o.restClient.Post().
+		Resource(o.resource).
+		Body(imageCache).
+		Do().
+		Into(newImageCache)
+
+	newImageCache.SetGroupVersionKind(v1.ContainerDiskImageCacheGroupVersionKind)
+
+	return newImageCache, err
+}
+
+// Get the ContainerDiskImageCache from the cluster by its name
+func (o *containerDiskImageCache) Get(name string, options *k8smetav1.GetOptions) (*v1.ContainerDiskImageCache, error) {
+	newImageCache := &v1.ContainerDiskImageCache{}
+	err := o.restClient.Get().
+		Resource(o.resource).
+		Name(name).
+		VersionedParams(options, scheme.ParameterCodec).
+		Do().
+		Into(newImageCache)
+
+	newImageCache.SetGroupVersionKind(v1.ContainerDiskImageCacheGroupVersionKind)
+
+	return newImageCache, err
+}
+
+// Update the ContainerDiskImageCache in the cluster
+func (o *containerDiskImageCache) Update(imageCache *v1.ContainerDiskImageCache) (*v1.ContainerDiskImageCache, error) {
+	updatedImageCache := &v1.ContainerDiskImageCache{}
+	err := o.restClient.Put().
+		Resource(o.resource).
+		Name(imageCache.Name).
+		Body(imageCache).
+		Do().
+		Into(updatedImageCache)
+
+	updatedImageCache.SetGroupVersionKind(v1.ContainerDiskImageCacheGroupVersionKind)
+
+	return updatedImageCache, err
+}
+
+// UpdateStatus updates only the status of the ContainerDiskImageCache in the cluster
+func (o *containerDiskImageCache) UpdateStatus(imageCache *v1.ContainerDiskImageCache) (*v1.ContainerDiskImageCache, error) {
+	updatedImageCache := &v1.ContainerDiskImageCache{}
+	err := o.restClient.Put().
+		Resource(o.resource).
+		Name(imageCache.Name).
+		SubResource("status").
+		Body(imageCache).
+		Do().
+		Into(updatedImageCache)
+
+	updatedImageCache.SetGroupVersionKind(v1.ContainerDiskImageCacheGroupVersionKind)
+
+	return updatedImageCache, err
+}
+
+// Delete the ContainerDiskImageCache from the cluster
+func (o *containerDiskImageCache) Delete(name string, options *k8smetav1.DeleteOptions) error {
+	return o.restClient.Delete().
+		Resource(o.resource).
+		Name(name).
+		Body(options).
+		Do().
+		Error()
+}
+
+// List all ContainerDiskImageCaches in the cluster
+func (o *containerDiskImageCache) List(options *k8smetav1.ListOptions) (*v1.ContainerDiskImageCacheList, error) {
+	newImageCacheList := &v1.ContainerDiskImageCacheList{}
+	err := o.restClient.Get().
+		Resource(o.resource).
+		VersionedParams(options, scheme.ParameterCodec).
+		Do().
+		Into(newImageCacheList)
+
+	for i := range newImageCacheList.Items {
+		newImageCacheList.Items[i].SetGroupVersionKind(v1.ContainerDiskImageCacheGroupVersionKind)
+	}
+
+	return newImageCacheList, err
+}
+
+func (o *containerDiskImageCache) Patch(name string, pt types.PatchType, data []byte, subresources ...string) (result *v1.ContainerDiskImageCache, err error) {
+	result = &v1.ContainerDiskImageCache{}
+	err = o.restClient.Patch(pt).
+		Resource(o.resource).
+		SubResource(subresources...).
+		Name(name).
+		Body(data).
+		Do().
+		Into(result)
+	return result, err
+}
@@ -0,0 +1,154 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2020 Red Hat, Inc.
+ *
+ */
+
+package kubecli
+
+import (
+	"net/http"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+	"k8s.io/apimachinery/pkg/api/errors"
+	k8smetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	v1 "kubevirt.io/client-go/api/v1"
+)
+
+var _ = Describe("Kubevirt ContainerDiskImageCache Client", func() {
+
+	var server *ghttp.Server
+	var client KubevirtClient
+	basePath := "/apis/kubevirt.io/v1alpha3/containerdiskimagecaches"
+	imageCachePath := basePath + "/testcache"
+
+	newImageCache := func() *v1.ContainerDiskImageCache {
+		return &v1.ContainerDiskImageCache{
+			TypeMeta: k8smetav1.TypeMeta{
+				APIVersion: v1.GroupVersion.String(),
+				Kind:       v1.ContainerDiskImageCacheGroupVersionKind.Kind,
+			},
+			ObjectMeta: k8smetav1.ObjectMeta{Name: "testcache"},
+			Spec: v1.ContainerDiskImageCacheSpec{
+				Images: []string{"kubevirt/cirros-container-disk-demo:latest"},
+			},
+		}
+	}
+
+	BeforeEach(func() {
+		var err error
+		server = ghttp.NewServer()
+		client, err = GetKubevirtClientFromFlags(server.URL(), "")
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("should fetch a ContainerDiskImageCache", func() {
+		imageCache := newImageCache()
+		server.AppendHandlers(ghttp.CombineHandlers(
+			ghttp.VerifyRequest("GET", imageCachePath),
+			ghttp.RespondWithJSONEncoded(http.StatusOK, imageCache),
+		))
+		fetchedImageCache, err := client.ContainerDiskImageCache().Get("testcache", &k8smetav1.GetOptions{})
+
+		Expect(server.ReceivedRequests()).To(HaveLen(1))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(fetchedImageCache).To(Equal(imageCache))
+	})
+
+	It("should detect non existent ContainerDiskImageCaches", func() {
+		server.AppendHandlers(ghttp.CombineHandlers(
+			ghttp.VerifyRequest("GET", imageCachePath),
+			ghttp.RespondWithJSONEncoded(http.StatusNotFound, errors.NewNotFound(schema.GroupResource{}, "testcache")),
+		))
+		_, err := client.ContainerDiskImageCache().Get("testcache", &k8smetav1.GetOptions{})
+
+		Expect(server.ReceivedRequests()).To(HaveLen(1))
+		Expect(err).To(HaveOccurred())
+		Expect(errors.IsNotFound(err)).To(BeTrue())
+	})
+
+	It("should fetch a ContainerDiskImageCache list", func() {
+		imageCache := newImageCache()
+		server.AppendHandlers(ghttp.CombineHandlers(
+			ghttp.VerifyRequest("GET", basePath),
+			ghttp.RespondWithJSONEncoded(http.StatusOK, &v1.ContainerDiskImageCacheList{Items: []v1.ContainerDiskImageCache{*imageCache}}),
+		))
+		fetchedList, err := client.ContainerDiskImageCache().List(&k8smetav1.ListOptions{})
+
+		Expect(server.ReceivedRequests()).To(HaveLen(1))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(fetchedList.Items).To(HaveLen(1))
+		Expect(fetchedList.Items[0]).To(Equal(*imageCache))
+	})
+
+	It("should create a ContainerDiskImageCache", func() {
+		imageCache := newImageCache()
+		server.AppendHandlers(ghttp.CombineHandlers(
+			ghttp.VerifyRequest("POST", basePath),
+			ghttp.RespondWithJSONEncoded(http.StatusCreated, imageCache),
+		))
+		createdImageCache, err := client.ContainerDiskImageCache().Create(imageCache)
+
+		Expect(server.ReceivedRequests()).To(HaveLen(1))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(createdImageCache).To(Equal(imageCache))
+	})
+
+	It("should update a ContainerDiskImageCache", func() {
+		imageCache := newImageCache()
+		server.AppendHandlers(ghttp.CombineHandlers(
+			ghttp.VerifyRequest("PUT", imageCachePath),
+			ghttp.RespondWithJSONEncoded(http.StatusOK, imageCache),
+		))
+		updatedImageCache, err := client.ContainerDiskImageCache().Update(imageCache)
+
+		Expect(server.ReceivedRequests()).To(HaveLen(1))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(updatedImageCache).To(Equal(imageCache))
+	})
+
+	It("should update the status of a ContainerDiskImageCache", func() {
+		imageCache := newImageCache()
+		server.AppendHandlers(ghttp.CombineHandlers(
+			ghttp.VerifyRequest("PUT", imageCachePath+"/status"),
+			ghttp.RespondWithJSONEncoded(http.StatusOK, imageCache),
+		))
+		updatedImageCache, err := client.ContainerDiskImageCache().UpdateStatus(imageCache)
+
+		Expect(server.ReceivedRequests()).To(HaveLen(1))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(updatedImageCache).To(Equal(imageCache))
+	})
+
+	It("should delete a ContainerDiskImageCache", func() {
+		server.AppendHandlers(ghttp.CombineHandlers(
+			ghttp.VerifyRequest("DELETE", imageCachePath),
+			ghttp.RespondWithJSONEncoded(http.StatusOK, nil),
+		))
+		err := client.ContainerDiskImageCache().Delete("testcache", &k8smetav1.DeleteOptions{})
+
+		Expect(server.ReceivedRequests()).To(HaveLen(1))
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+})
@@ -0,0 +1,133 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2020 Red Hat, Inc.
+ *
+ */
+
+package kubecli
+
+import (
+	k8smetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+
+	v1 "kubevirt.io/client-go/api/v1"
+)
+
+func (k *kubevirt) VirtualMachineExport(namespace string) VirtualMachineExportInterface {
+	return &export{
+		restClient: k.restClient,
+		namespace:  namespace,
+		resource:   "virtualmachineexports",
+	}
+}
+
+type export struct {
+	restClient *rest.RESTClient
+	namespace  string
+	resource   string
+}
+
+// Create new VirtualMachineExport in the cluster to specified namespace
+func (o *export) Create(newExport *v1.VirtualMachineExport) (*v1.VirtualMachineExport, error) {
+	newExportResult := &v1.VirtualMachineExport{}
+	err := o.restClient.Post().
+		Resource(o.resource).
+		Namespace(o.namespace).
+		Body(newExport).
+		Do().
+		Into(newExportResult)
+
+	newExportResult.SetGroupVersionKind(v1.VirtualMachineExportGroupVersionKind)
+
+	return newExportResult, err
+}
+
+// Get the VirtualMachineExport from the cluster by its name and namespace
+func (o *export) Get(name string, options *k8smetav1.GetOptions) (*v1.VirtualMachineExport, error) {
+	newExport := &v1.VirtualMachineExport{}
+	err := o.restClient.Get().
+		Resource(o.resource).
+		Namespace(o.namespace).
+		Name(name).
+		VersionedParams(options, scheme.ParameterCodec).
+		Do().
+		Into(newExport)
+
+	newExport.SetGroupVersionKind(v1.VirtualMachineExportGroupVersionKind)
+
+	return newExport, err
+}
+
+// Update the VirtualMachineExport instance in the cluster in given namespace
+func (o *export) Update(export *v1.VirtualMachineExport) (*v1.VirtualMachineExport, error) {
+	updatedExport := &v1.VirtualMachineExport{}
+	err := o.restClient.Put().
+		Resource(o.resource).
+		Namespace(o.namespace).
+		Name(export.Name).
+		Body(export).
+		Do().
+		Into(updatedExport)
+
+	updatedExport.SetGroupVersionKind(v1.VirtualMachineExportGroupVersionKind)
+
+	return updatedExport, err
+}
+
+// Delete the defined VirtualMachineExport in the cluster in defined namespace
+func (o *export) Delete(name string, options *k8smetav1.DeleteOptions) error {
+	err := o.restClient.Delete().
+		Resource(o.resource).
+		Namespace(o.namespace).
+		Name(name).
+		Body(options).
+		Do().
+		Error()
+
+	return err
+}
+
+// List all VirtualMachineExports in given namespace
+func (o *export) List(options *k8smetav1.ListOptions) (*v1.VirtualMachineExportList, error) {
+	newExportList := &v1.VirtualMachineExportList{}
+	err := o.restClient.Get().
+		Resource(o.resource).
+		Namespace(o.namespace).
+		VersionedParams(options, scheme.ParameterCodec).
+		Do().
+		Into(newExportList)
+
+	for _, export := range newExportList.Items {
+		export.SetGroupVersionKind(v1.VirtualMachineExportGroupVersionKind)
+	}
+
+	return newExportList, err
+}
+
+func (v *export) Patch(name string, pt types.PatchType, data []byte, subresources ...string) (result *v1.VirtualMachineExport, err error) {
+	result = &v1.VirtualMachineExport{}
+	err = v.restClient.Patch(pt).
+		Namespace(v.namespace).
+		Resource(v.resource).
+		SubResource(subresources...).
+		Name(name).
+		Body(data).
+		Do().
+		Into(result)
+	return result, err
+}
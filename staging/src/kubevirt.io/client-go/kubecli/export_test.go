@@ -0,0 +1,145 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2020 Red Hat, Inc.
+ *
+ */
+
+package kubecli
+
+import (
+	"net/http"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+	k8sv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	k8smetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+var _ = Describe("Kubevirt Export Client", func() {
+
+	var server *ghttp.Server
+	var client KubevirtClient
+	basePath := "/apis/kubevirt.io/v1alpha3/namespaces/default/virtualmachineexports"
+	exportPath := basePath + "/testexport"
+
+	BeforeEach(func() {
+		var err error
+		server = ghttp.NewServer()
+		client, err = GetKubevirtClientFromFlags(server.URL(), "")
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("should fetch an Export", func() {
+		export := NewMinimalExport("testexport")
+		server.AppendHandlers(ghttp.CombineHandlers(
+			ghttp.VerifyRequest("GET", exportPath),
+			ghttp.RespondWithJSONEncoded(http.StatusOK, export),
+		))
+		fetchedExport, err := client.VirtualMachineExport(k8sv1.NamespaceDefault).Get("testexport", &k8smetav1.GetOptions{})
+
+		Expect(server.ReceivedRequests()).To(HaveLen(1))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(fetchedExport).To(Equal(export))
+	})
+
+	It("should detect non existent Exports", func() {
+		server.AppendHandlers(ghttp.CombineHandlers(
+			ghttp.VerifyRequest("GET", exportPath),
+			ghttp.RespondWithJSONEncoded(http.StatusNotFound, errors.NewNotFound(schema.GroupResource{}, "testexport")),
+		))
+		_, err := client.VirtualMachineExport(k8sv1.NamespaceDefault).Get("testexport", &k8smetav1.GetOptions{})
+
+		Expect(server.ReceivedRequests()).To(HaveLen(1))
+		Expect(err).To(HaveOccurred())
+		Expect(errors.IsNotFound(err)).To(BeTrue())
+	})
+
+	It("should fetch an Export list", func() {
+		export := NewMinimalExport("testexport")
+		server.AppendHandlers(ghttp.CombineHandlers(
+			ghttp.VerifyRequest("GET", basePath),
+			ghttp.RespondWithJSONEncoded(http.StatusOK, NewExportList(*export)),
+		))
+		fetchedExportList, err := client.VirtualMachineExport(k8sv1.NamespaceDefault).List(&k8smetav1.ListOptions{})
+
+		Expect(server.ReceivedRequests()).To(HaveLen(1))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(fetchedExportList.Items).To(HaveLen(1))
+		Expect(fetchedExportList.Items[0]).To(Equal(*export))
+	})
+
+	It("should create an Export", func() {
+		export := NewMinimalExport("testexport")
+		server.AppendHandlers(ghttp.CombineHandlers(
+			ghttp.VerifyRequest("POST", basePath),
+			ghttp.RespondWithJSONEncoded(http.StatusCreated, export),
+		))
+		createdExport, err := client.VirtualMachineExport(k8sv1.NamespaceDefault).Create(export)
+
+		Expect(server.ReceivedRequests()).To(HaveLen(1))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(createdExport).To(Equal(export))
+	})
+
+	It("should update an Export", func() {
+		export := NewMinimalExport("testexport")
+		server.AppendHandlers(ghttp.CombineHandlers(
+			ghttp.VerifyRequest("PUT", exportPath),
+			ghttp.RespondWithJSONEncoded(http.StatusOK, export),
+		))
+		updatedExport, err := client.VirtualMachineExport(k8sv1.NamespaceDefault).Update(export)
+
+		Expect(server.ReceivedRequests()).To(HaveLen(1))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(updatedExport).To(Equal(export))
+	})
+
+	It("should patch an Export", func() {
+		export := NewMinimalExport("testexport")
+		export.Spec.TokenSecretRef = "somethingelse"
+
+		server.AppendHandlers(ghttp.CombineHandlers(
+			ghttp.VerifyRequest("PATCH", exportPath),
+			ghttp.VerifyBody([]byte("{\"spec\":{\"tokenSecretRef\":something}}")),
+			ghttp.RespondWithJSONEncoded(http.StatusOK, export),
+		))
+
+		_, err := client.VirtualMachineExport(k8sv1.NamespaceDefault).Patch(export.Name, types.MergePatchType,
+			[]byte("{\"spec\":{\"tokenSecretRef\":something}}"))
+
+		Expect(server.ReceivedRequests()).To(HaveLen(1))
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("should delete an Export", func() {
+		server.AppendHandlers(ghttp.CombineHandlers(
+			ghttp.VerifyRequest("DELETE", exportPath),
+			ghttp.RespondWithJSONEncoded(http.StatusOK, nil),
+		))
+		err := client.VirtualMachineExport(k8sv1.NamespaceDefault).Delete("testexport", &k8smetav1.DeleteOptions{})
+
+		Expect(server.ReceivedRequests()).To(HaveLen(1))
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+})
@@ -4,6 +4,7 @@
 package kubecli
 
 import (
+	io "io"
 	time "time"
 
 	versioned "github.com/coreos/prometheus-operator/pkg/client/versioned"
@@ -124,6 +125,36 @@ func (_mr *_MockKubevirtClientRecorder) KubeVirt(arg0 interface{}) *gomock.Call
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "KubeVirt", arg0)
 }
 
+func (_m *MockKubevirtClient) ContainerDiskImageCache() ContainerDiskImageCacheInterface {
+	ret := _m.ctrl.Call(_m, "ContainerDiskImageCache")
+	ret0, _ := ret[0].(ContainerDiskImageCacheInterface)
+	return ret0
+}
+
+func (_mr *_MockKubevirtClientRecorder) ContainerDiskImageCache() *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "ContainerDiskImageCache")
+}
+
+func (_m *MockKubevirtClient) VirtualMachineBackup(namespace string) VirtualMachineBackupInterface {
+	ret := _m.ctrl.Call(_m, "VirtualMachineBackup", namespace)
+	ret0, _ := ret[0].(VirtualMachineBackupInterface)
+	return ret0
+}
+
+func (_mr *_MockKubevirtClientRecorder) VirtualMachineBackup(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "VirtualMachineBackup", arg0)
+}
+
+func (_m *MockKubevirtClient) VirtualMachineExport(namespace string) VirtualMachineExportInterface {
+	ret := _m.ctrl.Call(_m, "VirtualMachineExport", namespace)
+	ret0, _ := ret[0].(VirtualMachineExportInterface)
+	return ret0
+}
+
+func (_mr *_MockKubevirtClientRecorder) VirtualMachineExport(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "VirtualMachineExport", arg0)
+}
+
 func (_m *MockKubevirtClient) VirtualMachineInstancePreset(namespace string) VirtualMachineInstancePresetInterface {
 	ret := _m.ctrl.Call(_m, "VirtualMachineInstancePreset", namespace)
 	ret0, _ := ret[0].(VirtualMachineInstancePresetInterface)
@@ -867,6 +898,17 @@ func (_mr *_MockVirtualMachineInstanceInterfaceRecorder) SerialConsole(arg0, arg
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "SerialConsole", arg0, arg1)
 }
 
+func (_m *MockVirtualMachineInstanceInterface) SerialConsoleReadOnly(name string, timeout time.Duration) (StreamInterface, error) {
+	ret := _m.ctrl.Call(_m, "SerialConsoleReadOnly", name, timeout)
+	ret0, _ := ret[0].(StreamInterface)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockVirtualMachineInstanceInterfaceRecorder) SerialConsoleReadOnly(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "SerialConsoleReadOnly", arg0, arg1)
+}
+
 func (_m *MockVirtualMachineInstanceInterface) VNC(name string) (StreamInterface, error) {
 	ret := _m.ctrl.Call(_m, "VNC", name)
 	ret0, _ := ret[0].(StreamInterface)
@@ -878,6 +920,116 @@ func (_mr *_MockVirtualMachineInstanceInterfaceRecorder) VNC(arg0 interface{}) *
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "VNC", arg0)
 }
 
+func (_m *MockVirtualMachineInstanceInterface) VNCReadOnly(name string) (StreamInterface, error) {
+	ret := _m.ctrl.Call(_m, "VNCReadOnly", name)
+	ret0, _ := ret[0].(StreamInterface)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockVirtualMachineInstanceInterfaceRecorder) VNCReadOnly(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "VNCReadOnly", arg0)
+}
+
+func (_m *MockVirtualMachineInstanceInterface) VNCToken(name string, readOnly bool) (*v111.VNCToken, error) {
+	ret := _m.ctrl.Call(_m, "VNCToken", name, readOnly)
+	ret0, _ := ret[0].(*v111.VNCToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockVirtualMachineInstanceInterfaceRecorder) VNCToken(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "VNCToken", arg0, arg1)
+}
+
+func (_m *MockVirtualMachineInstanceInterface) USBRedir(name string) (StreamInterface, error) {
+	ret := _m.ctrl.Call(_m, "USBRedir", name)
+	ret0, _ := ret[0].(StreamInterface)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockVirtualMachineInstanceInterfaceRecorder) USBRedir(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "USBRedir", arg0)
+}
+
+func (_m *MockVirtualMachineInstanceInterface) PortForward(name string, port int) (StreamInterface, error) {
+	ret := _m.ctrl.Call(_m, "PortForward", name, port)
+	ret0, _ := ret[0].(StreamInterface)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockVirtualMachineInstanceInterfaceRecorder) PortForward(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "PortForward", arg0, arg1)
+}
+
+func (_m *MockVirtualMachineInstanceInterface) ConsoleLog(name string, options *ConsoleLogOptions) (io.ReadCloser, error) {
+	ret := _m.ctrl.Call(_m, "ConsoleLog", name, options)
+	ret0, _ := ret[0].(io.ReadCloser)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockVirtualMachineInstanceInterfaceRecorder) ConsoleLog(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "ConsoleLog", arg0, arg1)
+}
+
+func (_m *MockVirtualMachineInstanceInterface) Screenshot(name string) ([]byte, error) {
+	ret := _m.ctrl.Call(_m, "Screenshot", name)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockVirtualMachineInstanceInterfaceRecorder) Screenshot(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "Screenshot", arg0)
+}
+
+func (_m *MockVirtualMachineInstanceInterface) GuestOsInfo(name string) (v111.VirtualMachineInstanceGuestAgentInfo, error) {
+	ret := _m.ctrl.Call(_m, "GuestOsInfo", name)
+	ret0, _ := ret[0].(v111.VirtualMachineInstanceGuestAgentInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockVirtualMachineInstanceInterfaceRecorder) GuestOsInfo(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "GuestOsInfo", arg0)
+}
+
+func (_m *MockVirtualMachineInstanceInterface) UserList(name string) (v111.VirtualMachineInstanceGuestOSUserList, error) {
+	ret := _m.ctrl.Call(_m, "UserList", name)
+	ret0, _ := ret[0].(v111.VirtualMachineInstanceGuestOSUserList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockVirtualMachineInstanceInterfaceRecorder) UserList(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "UserList", arg0)
+}
+
+func (_m *MockVirtualMachineInstanceInterface) FilesystemList(name string) (v111.VirtualMachineInstanceFileSystemList, error) {
+	ret := _m.ctrl.Call(_m, "FilesystemList", name)
+	ret0, _ := ret[0].(v111.VirtualMachineInstanceFileSystemList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockVirtualMachineInstanceInterfaceRecorder) FilesystemList(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "FilesystemList", arg0)
+}
+
+func (_m *MockVirtualMachineInstanceInterface) GuestExec(name string, request *v111.VirtualMachineInstanceGuestExecRequest) (*v111.VirtualMachineInstanceGuestExecResult, error) {
+	ret := _m.ctrl.Call(_m, "GuestExec", name, request)
+	ret0, _ := ret[0].(*v111.VirtualMachineInstanceGuestExecResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockVirtualMachineInstanceInterfaceRecorder) GuestExec(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "GuestExec", arg0, arg1)
+}
+
 func (_m *MockVirtualMachineInstanceInterface) Pause(name string) error {
 	ret := _m.ctrl.Call(_m, "Pause", name)
 	ret0, _ := ret[0].(error)
@@ -898,6 +1050,16 @@ func (_mr *_MockVirtualMachineInstanceInterfaceRecorder) Unpause(arg0 interface{
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "Unpause", arg0)
 }
 
+func (_m *MockVirtualMachineInstanceInterface) SoftReboot(name string) error {
+	ret := _m.ctrl.Call(_m, "SoftReboot", name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockVirtualMachineInstanceInterfaceRecorder) SoftReboot(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "SoftReboot", arg0)
+}
+
 // Mock of ReplicaSetInterface interface
 type MockReplicaSetInterface struct {
 	ctrl     *gomock.Controller
@@ -1233,6 +1395,36 @@ func (_mr *_MockVirtualMachineInterfaceRecorder) Migrate(arg0 interface{}) *gomo
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "Migrate", arg0)
 }
 
+func (_m *MockVirtualMachineInterface) MigrateVolumes(name string, request *v111.VirtualMachineMigrateVolumesRequest) error {
+	ret := _m.ctrl.Call(_m, "MigrateVolumes", name, request)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockVirtualMachineInterfaceRecorder) MigrateVolumes(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "MigrateVolumes", arg0, arg1)
+}
+
+func (_m *MockVirtualMachineInterface) MemoryDump(name string, request *v111.VirtualMachineMemoryDumpRequest) error {
+	ret := _m.ctrl.Call(_m, "MemoryDump", name, request)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockVirtualMachineInterfaceRecorder) MemoryDump(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "MemoryDump", arg0, arg1)
+}
+
+func (_m *MockVirtualMachineInterface) RemoveMemoryDump(name string) error {
+	ret := _m.ctrl.Call(_m, "RemoveMemoryDump", name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockVirtualMachineInterfaceRecorder) RemoveMemoryDump(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "RemoveMemoryDump", arg0)
+}
+
 // Mock of VirtualMachineInstanceMigrationInterface interface
 type MockVirtualMachineInstanceMigrationInterface struct {
 	ctrl     *gomock.Controller
@@ -1324,6 +1516,188 @@ func (_mr *_MockVirtualMachineInstanceMigrationInterfaceRecorder) Patch(arg0, ar
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "Patch", _s...)
 }
 
+// Mock of VirtualMachineBackupInterface interface
+type MockVirtualMachineBackupInterface struct {
+	ctrl     *gomock.Controller
+	recorder *_MockVirtualMachineBackupInterfaceRecorder
+}
+
+// Recorder for MockVirtualMachineBackupInterface (not exported)
+type _MockVirtualMachineBackupInterfaceRecorder struct {
+	mock *MockVirtualMachineBackupInterface
+}
+
+func NewMockVirtualMachineBackupInterface(ctrl *gomock.Controller) *MockVirtualMachineBackupInterface {
+	mock := &MockVirtualMachineBackupInterface{ctrl: ctrl}
+	mock.recorder = &_MockVirtualMachineBackupInterfaceRecorder{mock}
+	return mock
+}
+
+func (_m *MockVirtualMachineBackupInterface) EXPECT() *_MockVirtualMachineBackupInterfaceRecorder {
+	return _m.recorder
+}
+
+func (_m *MockVirtualMachineBackupInterface) Get(name string, options *v11.GetOptions) (*v111.VirtualMachineBackup, error) {
+	ret := _m.ctrl.Call(_m, "Get", name, options)
+	ret0, _ := ret[0].(*v111.VirtualMachineBackup)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockVirtualMachineBackupInterfaceRecorder) Get(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "Get", arg0, arg1)
+}
+
+func (_m *MockVirtualMachineBackupInterface) List(opts *v11.ListOptions) (*v111.VirtualMachineBackupList, error) {
+	ret := _m.ctrl.Call(_m, "List", opts)
+	ret0, _ := ret[0].(*v111.VirtualMachineBackupList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockVirtualMachineBackupInterfaceRecorder) List(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "List", arg0)
+}
+
+func (_m *MockVirtualMachineBackupInterface) Create(_param0 *v111.VirtualMachineBackup) (*v111.VirtualMachineBackup, error) {
+	ret := _m.ctrl.Call(_m, "Create", _param0)
+	ret0, _ := ret[0].(*v111.VirtualMachineBackup)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockVirtualMachineBackupInterfaceRecorder) Create(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "Create", arg0)
+}
+
+func (_m *MockVirtualMachineBackupInterface) Update(_param0 *v111.VirtualMachineBackup) (*v111.VirtualMachineBackup, error) {
+	ret := _m.ctrl.Call(_m, "Update", _param0)
+	ret0, _ := ret[0].(*v111.VirtualMachineBackup)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockVirtualMachineBackupInterfaceRecorder) Update(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "Update", arg0)
+}
+
+func (_m *MockVirtualMachineBackupInterface) Delete(name string, options *v11.DeleteOptions) error {
+	ret := _m.ctrl.Call(_m, "Delete", name, options)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockVirtualMachineBackupInterfaceRecorder) Delete(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "Delete", arg0, arg1)
+}
+
+func (_m *MockVirtualMachineBackupInterface) Patch(name string, pt types.PatchType, data []byte, subresources ...string) (*v111.VirtualMachineBackup, error) {
+	_s := []interface{}{name, pt, data}
+	for _, _x := range subresources {
+		_s = append(_s, _x)
+	}
+	ret := _m.ctrl.Call(_m, "Patch", _s...)
+	ret0, _ := ret[0].(*v111.VirtualMachineBackup)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockVirtualMachineBackupInterfaceRecorder) Patch(arg0, arg1, arg2 interface{}, arg3 ...interface{}) *gomock.Call {
+	_s := append([]interface{}{arg0, arg1, arg2}, arg3...)
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "Patch", _s...)
+}
+
+// Mock of VirtualMachineExportInterface interface
+type MockVirtualMachineExportInterface struct {
+	ctrl     *gomock.Controller
+	recorder *_MockVirtualMachineExportInterfaceRecorder
+}
+
+// Recorder for MockVirtualMachineExportInterface (not exported)
+type _MockVirtualMachineExportInterfaceRecorder struct {
+	mock *MockVirtualMachineExportInterface
+}
+
+func NewMockVirtualMachineExportInterface(ctrl *gomock.Controller) *MockVirtualMachineExportInterface {
+	mock := &MockVirtualMachineExportInterface{ctrl: ctrl}
+	mock.recorder = &_MockVirtualMachineExportInterfaceRecorder{mock}
+	return mock
+}
+
+func (_m *MockVirtualMachineExportInterface) EXPECT() *_MockVirtualMachineExportInterfaceRecorder {
+	return _m.recorder
+}
+
+func (_m *MockVirtualMachineExportInterface) Get(name string, options *v11.GetOptions) (*v111.VirtualMachineExport, error) {
+	ret := _m.ctrl.Call(_m, "Get", name, options)
+	ret0, _ := ret[0].(*v111.VirtualMachineExport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockVirtualMachineExportInterfaceRecorder) Get(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "Get", arg0, arg1)
+}
+
+func (_m *MockVirtualMachineExportInterface) List(opts *v11.ListOptions) (*v111.VirtualMachineExportList, error) {
+	ret := _m.ctrl.Call(_m, "List", opts)
+	ret0, _ := ret[0].(*v111.VirtualMachineExportList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockVirtualMachineExportInterfaceRecorder) List(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "List", arg0)
+}
+
+func (_m *MockVirtualMachineExportInterface) Create(_param0 *v111.VirtualMachineExport) (*v111.VirtualMachineExport, error) {
+	ret := _m.ctrl.Call(_m, "Create", _param0)
+	ret0, _ := ret[0].(*v111.VirtualMachineExport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockVirtualMachineExportInterfaceRecorder) Create(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "Create", arg0)
+}
+
+func (_m *MockVirtualMachineExportInterface) Update(_param0 *v111.VirtualMachineExport) (*v111.VirtualMachineExport, error) {
+	ret := _m.ctrl.Call(_m, "Update", _param0)
+	ret0, _ := ret[0].(*v111.VirtualMachineExport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockVirtualMachineExportInterfaceRecorder) Update(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "Update", arg0)
+}
+
+func (_m *MockVirtualMachineExportInterface) Delete(name string, options *v11.DeleteOptions) error {
+	ret := _m.ctrl.Call(_m, "Delete", name, options)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockVirtualMachineExportInterfaceRecorder) Delete(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "Delete", arg0, arg1)
+}
+
+func (_m *MockVirtualMachineExportInterface) Patch(name string, pt types.PatchType, data []byte, subresources ...string) (*v111.VirtualMachineExport, error) {
+	_s := []interface{}{name, pt, data}
+	for _, _x := range subresources {
+		_s = append(_s, _x)
+	}
+	ret := _m.ctrl.Call(_m, "Patch", _s...)
+	ret0, _ := ret[0].(*v111.VirtualMachineExport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockVirtualMachineExportInterfaceRecorder) Patch(arg0, arg1, arg2 interface{}, arg3 ...interface{}) *gomock.Call {
+	_s := append([]interface{}{arg0, arg1, arg2}, arg3...)
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "Patch", _s...)
+}
+
 // Mock of KubeVirtInterface interface
 type MockKubeVirtInterface struct {
 	ctrl     *gomock.Controller
@@ -1414,3 +1788,105 @@ func (_mr *_MockKubeVirtInterfaceRecorder) Patch(arg0, arg1, arg2 interface{}, a
 	_s := append([]interface{}{arg0, arg1, arg2}, arg3...)
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "Patch", _s...)
 }
+
+// Mock of ContainerDiskImageCacheInterface interface
+type MockContainerDiskImageCacheInterface struct {
+	ctrl     *gomock.Controller
+	recorder *_MockContainerDiskImageCacheInterfaceRecorder
+}
+
+// Recorder for MockContainerDiskImageCacheInterface (not exported)
+type _MockContainerDiskImageCacheInterfaceRecorder struct {
+	mock *MockContainerDiskImageCacheInterface
+}
+
+func NewMockContainerDiskImageCacheInterface(ctrl *gomock.Controller) *MockContainerDiskImageCacheInterface {
+	mock := &MockContainerDiskImageCacheInterface{ctrl: ctrl}
+	mock.recorder = &_MockContainerDiskImageCacheInterfaceRecorder{mock}
+	return mock
+}
+
+func (_m *MockContainerDiskImageCacheInterface) EXPECT() *_MockContainerDiskImageCacheInterfaceRecorder {
+	return _m.recorder
+}
+
+func (_m *MockContainerDiskImageCacheInterface) Get(name string, options *v11.GetOptions) (*v111.ContainerDiskImageCache, error) {
+	ret := _m.ctrl.Call(_m, "Get", name, options)
+	ret0, _ := ret[0].(*v111.ContainerDiskImageCache)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockContainerDiskImageCacheInterfaceRecorder) Get(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "Get", arg0, arg1)
+}
+
+func (_m *MockContainerDiskImageCacheInterface) List(opts *v11.ListOptions) (*v111.ContainerDiskImageCacheList, error) {
+	ret := _m.ctrl.Call(_m, "List", opts)
+	ret0, _ := ret[0].(*v111.ContainerDiskImageCacheList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockContainerDiskImageCacheInterfaceRecorder) List(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "List", arg0)
+}
+
+func (_m *MockContainerDiskImageCacheInterface) Create(instance *v111.ContainerDiskImageCache) (*v111.ContainerDiskImageCache, error) {
+	ret := _m.ctrl.Call(_m, "Create", instance)
+	ret0, _ := ret[0].(*v111.ContainerDiskImageCache)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockContainerDiskImageCacheInterfaceRecorder) Create(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "Create", arg0)
+}
+
+func (_m *MockContainerDiskImageCacheInterface) Update(_param0 *v111.ContainerDiskImageCache) (*v111.ContainerDiskImageCache, error) {
+	ret := _m.ctrl.Call(_m, "Update", _param0)
+	ret0, _ := ret[0].(*v111.ContainerDiskImageCache)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockContainerDiskImageCacheInterfaceRecorder) Update(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "Update", arg0)
+}
+
+func (_m *MockContainerDiskImageCacheInterface) UpdateStatus(_param0 *v111.ContainerDiskImageCache) (*v111.ContainerDiskImageCache, error) {
+	ret := _m.ctrl.Call(_m, "UpdateStatus", _param0)
+	ret0, _ := ret[0].(*v111.ContainerDiskImageCache)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockContainerDiskImageCacheInterfaceRecorder) UpdateStatus(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "UpdateStatus", arg0)
+}
+
+func (_m *MockContainerDiskImageCacheInterface) Delete(name string, options *v11.DeleteOptions) error {
+	ret := _m.ctrl.Call(_m, "Delete", name, options)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockContainerDiskImageCacheInterfaceRecorder) Delete(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "Delete", arg0, arg1)
+}
+
+func (_m *MockContainerDiskImageCacheInterface) Patch(name string, pt types.PatchType, data []byte, subresources ...string) (*v111.ContainerDiskImageCache, error) {
+	_s := []interface{}{name, pt, data}
+	for _, _x := range subresources {
+		_s = append(_s, _x)
+	}
+	ret := _m.ctrl.Call(_m, "Patch", _s...)
+	ret0, _ := ret[0].(*v111.ContainerDiskImageCache)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockContainerDiskImageCacheInterfaceRecorder) Patch(arg0, arg1, arg2 interface{}, arg3 ...interface{}) *gomock.Call {
+	_s := append([]interface{}{arg0, arg1, arg2}, arg3...)
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "Patch", _s...)
+}
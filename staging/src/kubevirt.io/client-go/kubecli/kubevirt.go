@@ -53,6 +53,9 @@ type KubevirtClient interface {
 	VirtualMachine(namespace string) VirtualMachineInterface
 	KubeVirt(namespace string) KubeVirtInterface
 	VirtualMachineInstancePreset(namespace string) VirtualMachineInstancePresetInterface
+	ContainerDiskImageCache() ContainerDiskImageCacheInterface
+	VirtualMachineBackup(namespace string) VirtualMachineBackupInterface
+	VirtualMachineExport(namespace string) VirtualMachineExportInterface
 	ServerVersion() *ServerVersion
 	RestClient() *rest.RESTClient
 	CdiClient() cdiclient.Interface
@@ -120,6 +123,14 @@ type StreamInterface interface {
 	Stream(options StreamOptions) error
 }
 
+// ConsoleLogOptions limits the returned serial console log
+type ConsoleLogOptions struct {
+	// Only return the output of the last seconds
+	SinceSeconds *int64
+	// Only return the last lines
+	TailLines *int64
+}
+
 type VirtualMachineInstanceInterface interface {
 	Get(name string, options *k8smetav1.GetOptions) (*v1.VirtualMachineInstance, error)
 	List(opts *k8smetav1.ListOptions) (*v1.VirtualMachineInstanceList, error)
@@ -128,9 +139,21 @@ type VirtualMachineInstanceInterface interface {
 	Delete(name string, options *k8smetav1.DeleteOptions) error
 	Patch(name string, pt types.PatchType, data []byte, subresources ...string) (result *v1.VirtualMachineInstance, err error)
 	SerialConsole(name string, timeout time.Duration) (StreamInterface, error)
+	SerialConsoleReadOnly(name string, timeout time.Duration) (StreamInterface, error)
 	VNC(name string) (StreamInterface, error)
+	VNCReadOnly(name string) (StreamInterface, error)
+	VNCToken(name string, readOnly bool) (*v1.VNCToken, error)
+	USBRedir(name string) (StreamInterface, error)
+	PortForward(name string, port int) (StreamInterface, error)
+	ConsoleLog(name string, options *ConsoleLogOptions) (io.ReadCloser, error)
+	Screenshot(name string) ([]byte, error)
+	GuestOsInfo(name string) (v1.VirtualMachineInstanceGuestAgentInfo, error)
+	UserList(name string) (v1.VirtualMachineInstanceGuestOSUserList, error)
+	FilesystemList(name string) (v1.VirtualMachineInstanceFileSystemList, error)
+	GuestExec(name string, request *v1.VirtualMachineInstanceGuestExecRequest) (*v1.VirtualMachineInstanceGuestExecResult, error)
 	Pause(name string) error
 	Unpause(name string) error
+	SoftReboot(name string) error
 }
 
 type ReplicaSetInterface interface {
@@ -166,6 +189,9 @@ type VirtualMachineInterface interface {
 	Start(name string) error
 	Stop(name string) error
 	Migrate(name string) error
+	MigrateVolumes(name string, request *v1.VirtualMachineMigrateVolumesRequest) error
+	MemoryDump(name string, request *v1.VirtualMachineMemoryDumpRequest) error
+	RemoveMemoryDump(name string) error
 }
 
 type VirtualMachineInstanceMigrationInterface interface {
@@ -177,6 +203,24 @@ type VirtualMachineInstanceMigrationInterface interface {
 	Patch(name string, pt types.PatchType, data []byte, subresources ...string) (result *v1.VirtualMachineInstanceMigration, err error)
 }
 
+type VirtualMachineBackupInterface interface {
+	Get(name string, options *k8smetav1.GetOptions) (*v1.VirtualMachineBackup, error)
+	List(opts *k8smetav1.ListOptions) (*v1.VirtualMachineBackupList, error)
+	Create(*v1.VirtualMachineBackup) (*v1.VirtualMachineBackup, error)
+	Update(*v1.VirtualMachineBackup) (*v1.VirtualMachineBackup, error)
+	Delete(name string, options *k8smetav1.DeleteOptions) error
+	Patch(name string, pt types.PatchType, data []byte, subresources ...string) (result *v1.VirtualMachineBackup, err error)
+}
+
+type VirtualMachineExportInterface interface {
+	Get(name string, options *k8smetav1.GetOptions) (*v1.VirtualMachineExport, error)
+	List(opts *k8smetav1.ListOptions) (*v1.VirtualMachineExportList, error)
+	Create(*v1.VirtualMachineExport) (*v1.VirtualMachineExport, error)
+	Update(*v1.VirtualMachineExport) (*v1.VirtualMachineExport, error)
+	Delete(name string, options *k8smetav1.DeleteOptions) error
+	Patch(name string, pt types.PatchType, data []byte, subresources ...string) (result *v1.VirtualMachineExport, err error)
+}
+
 type KubeVirtInterface interface {
 	Get(name string, options *k8smetav1.GetOptions) (*v1.KubeVirt, error)
 	List(opts *k8smetav1.ListOptions) (*v1.KubeVirtList, error)
@@ -185,3 +229,15 @@ type KubeVirtInterface interface {
 	Delete(name string, options *k8smetav1.DeleteOptions) error
 	Patch(name string, pt types.PatchType, data []byte, subresources ...string) (result *v1.KubeVirt, err error)
 }
+
+// ContainerDiskImageCacheInterface provides convenience methods to work with
+// the cluster scoped containerDisk image caches
+type ContainerDiskImageCacheInterface interface {
+	Get(name string, options *k8smetav1.GetOptions) (*v1.ContainerDiskImageCache, error)
+	List(opts *k8smetav1.ListOptions) (*v1.ContainerDiskImageCacheList, error)
+	Create(*v1.ContainerDiskImageCache) (*v1.ContainerDiskImageCache, error)
+	Update(*v1.ContainerDiskImageCache) (*v1.ContainerDiskImageCache, error)
+	UpdateStatus(*v1.ContainerDiskImageCache) (*v1.ContainerDiskImageCache, error)
+	Delete(name string, options *k8smetav1.DeleteOptions) error
+	Patch(name string, pt types.PatchType, data []byte, subresources ...string) (result *v1.ContainerDiskImageCache, err error)
+}
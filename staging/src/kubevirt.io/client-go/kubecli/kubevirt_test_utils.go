@@ -71,3 +71,19 @@ func NewVirtualMachineInstancePresetList(rss ...v1.VirtualMachineInstancePreset)
 func NewMinimalVirtualMachineInstancePreset(name string) *v1.VirtualMachineInstancePreset {
 	return &v1.VirtualMachineInstancePreset{TypeMeta: k8smetav1.TypeMeta{APIVersion: v1.GroupVersion.String(), Kind: "VirtualMachineInstancePreset"}, ObjectMeta: k8smetav1.ObjectMeta{Name: name}}
 }
+
+func NewMinimalBackup(name string) *v1.VirtualMachineBackup {
+	return &v1.VirtualMachineBackup{TypeMeta: k8smetav1.TypeMeta{APIVersion: v1.GroupVersion.String(), Kind: "VirtualMachineBackup"}, ObjectMeta: k8smetav1.ObjectMeta{Name: name}}
+}
+
+func NewBackupList(backups ...v1.VirtualMachineBackup) *v1.VirtualMachineBackupList {
+	return &v1.VirtualMachineBackupList{TypeMeta: k8smetav1.TypeMeta{APIVersion: v1.GroupVersion.String(), Kind: "VirtualMachineBackupList"}, Items: backups}
+}
+
+func NewMinimalExport(name string) *v1.VirtualMachineExport {
+	return &v1.VirtualMachineExport{TypeMeta: k8smetav1.TypeMeta{APIVersion: v1.GroupVersion.String(), Kind: "VirtualMachineExport"}, ObjectMeta: k8smetav1.ObjectMeta{Name: name}}
+}
+
+func NewExportList(exports ...v1.VirtualMachineExport) *v1.VirtualMachineExportList {
+	return &v1.VirtualMachineExportList{TypeMeta: k8smetav1.TypeMeta{APIVersion: v1.GroupVersion.String(), Kind: "VirtualMachineExportList"}, Items: exports}
+}
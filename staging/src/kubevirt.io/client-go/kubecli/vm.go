@@ -20,6 +20,7 @@
 package kubecli
 
 import (
+	"encoding/json"
 	"fmt"
 
 	k8smetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -155,3 +156,26 @@ func (v *vm) Migrate(name string) error {
 	uri := fmt.Sprintf(vmSubresourceURL, v1.ApiStorageVersion, v.namespace, name, "migrate")
 	return v.restClient.Put().RequestURI(uri).Do().Error()
 }
+
+func (v *vm) MigrateVolumes(name string, request *v1.VirtualMachineMigrateVolumesRequest) error {
+	data, err := json.Marshal(request)
+	if err != nil {
+		return err
+	}
+	uri := fmt.Sprintf(vmSubresourceURL, v1.ApiStorageVersion, v.namespace, name, "migratevolumes")
+	return v.restClient.Put().RequestURI(uri).Body(data).Do().Error()
+}
+
+func (v *vm) MemoryDump(name string, request *v1.VirtualMachineMemoryDumpRequest) error {
+	data, err := json.Marshal(request)
+	if err != nil {
+		return err
+	}
+	uri := fmt.Sprintf(vmSubresourceURL, v1.ApiStorageVersion, v.namespace, name, "memorydump")
+	return v.restClient.Put().RequestURI(uri).Body(data).Do().Error()
+}
+
+func (v *vm) RemoveMemoryDump(name string) error {
+	uri := fmt.Sprintf(vmSubresourceURL, v1.ApiStorageVersion, v.namespace, name, "removememorydump")
+	return v.restClient.Put().RequestURI(uri).Do().Error()
+}
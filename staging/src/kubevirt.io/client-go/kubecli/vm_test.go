@@ -187,6 +187,45 @@ var _ = Describe("Kubevirt VirtualMachine Client", func() {
 		Expect(err).ToNot(HaveOccurred())
 	})
 
+	It("should migrate the volumes of a VirtualMachine", func() {
+		request := &virtv1.VirtualMachineMigrateVolumesRequest{
+			MigratedVolumes: []virtv1.StorageMigratedVolume{{VolumeName: "rootdisk", DestinationClaimName: "newclaim"}},
+		}
+		server.AppendHandlers(ghttp.CombineHandlers(
+			ghttp.VerifyRequest("PUT", subVMIPath+"/migratevolumes"),
+			ghttp.VerifyBody([]byte("{\"migratedVolumes\":[{\"volumeName\":\"rootdisk\",\"destinationClaimName\":\"newclaim\"}]}")),
+			ghttp.RespondWithJSONEncoded(http.StatusOK, nil),
+		))
+		err := client.VirtualMachine(k8sv1.NamespaceDefault).MigrateVolumes("testvm", request)
+
+		Expect(server.ReceivedRequests()).To(HaveLen(1))
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("should request a memory dump of a VirtualMachine", func() {
+		request := &virtv1.VirtualMachineMemoryDumpRequest{ClaimName: "dump-claim"}
+		server.AppendHandlers(ghttp.CombineHandlers(
+			ghttp.VerifyRequest("PUT", subVMIPath+"/memorydump"),
+			ghttp.VerifyBody([]byte("{\"claimName\":\"dump-claim\"}")),
+			ghttp.RespondWithJSONEncoded(http.StatusOK, nil),
+		))
+		err := client.VirtualMachine(k8sv1.NamespaceDefault).MemoryDump("testvm", request)
+
+		Expect(server.ReceivedRequests()).To(HaveLen(1))
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("should remove the memory dump of a VirtualMachine", func() {
+		server.AppendHandlers(ghttp.CombineHandlers(
+			ghttp.VerifyRequest("PUT", subVMIPath+"/removememorydump"),
+			ghttp.RespondWithJSONEncoded(http.StatusOK, nil),
+		))
+		err := client.VirtualMachine(k8sv1.NamespaceDefault).RemoveMemoryDump("testvm")
+
+		Expect(server.ReceivedRequests()).To(HaveLen(1))
+		Expect(err).ToNot(HaveOccurred())
+	})
+
 	AfterEach(func() {
 		server.Close()
 	})
@@ -21,9 +21,12 @@ package kubecli
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
 	"net/url"
+	"strconv"
 	"time"
 
 	"github.com/gorilla/websocket"
@@ -175,7 +178,46 @@ func (ws *wsStreamer) Stream(options StreamOptions) error {
 }
 
 func (v *vmis) VNC(name string) (StreamInterface, error) {
-	return v.asyncSubresourceHelper(name, "vnc")
+	return v.asyncSubresourceHelper(name, "vnc", nil)
+}
+
+func (v *vmis) VNCReadOnly(name string) (StreamInterface, error) {
+	return v.asyncSubresourceHelper(name, "vnc", readOnlyQuery())
+}
+
+// USBRedir connects to a free USB redirection channel of the vmi. The stream
+// carries the usbredir protocol for a single redirected device.
+func (v *vmis) USBRedir(name string) (StreamInterface, error) {
+	return v.asyncSubresourceHelper(name, "usbredir", nil)
+}
+
+// PortForward opens a tcp connection to the given port of the guest, the
+// stream carries the raw payload of the connection
+func (v *vmis) PortForward(name string, port int) (StreamInterface, error) {
+	return v.asyncSubresourceHelper(name, fmt.Sprintf("portforward/%d", port), nil)
+}
+
+// VNCToken returns a short-lived token for the novnc subresource, which accepts
+// websocket connections from noVNC clients without further credentials
+func (v *vmis) VNCToken(name string, readOnly bool) (*v1.VNCToken, error) {
+	uri := fmt.Sprintf(vmiSubresourceURL, v1.ApiStorageVersion, v.namespace, name, "vnc/token")
+	req := v.restClient.Get().RequestURI(uri)
+	if readOnly {
+		req = req.Param("readonly", "true")
+	}
+	body, err := req.DoRaw()
+	if err != nil {
+		return nil, err
+	}
+	token := &v1.VNCToken{}
+	if err := json.Unmarshal(body, token); err != nil {
+		return nil, err
+	}
+	return token, nil
+}
+
+func readOnlyQuery() url.Values {
+	return url.Values{"readonly": []string{"true"}}
 }
 
 type connectionStruct struct {
@@ -184,6 +226,14 @@ type connectionStruct struct {
 }
 
 func (v *vmis) SerialConsole(name string, timeout time.Duration) (StreamInterface, error) {
+	return v.serialConsole(name, timeout, nil)
+}
+
+func (v *vmis) SerialConsoleReadOnly(name string, timeout time.Duration) (StreamInterface, error) {
+	return v.serialConsole(name, timeout, readOnlyQuery())
+}
+
+func (v *vmis) serialConsole(name string, timeout time.Duration, query url.Values) (StreamInterface, error) {
 	timeoutChan := time.Tick(timeout)
 	connectionChan := make(chan connectionStruct)
 
@@ -200,7 +250,7 @@ func (v *vmis) SerialConsole(name string, timeout time.Duration) (StreamInterfac
 			default:
 			}
 
-			con, err := v.asyncSubresourceHelper(name, "console")
+			con, err := v.asyncSubresourceHelper(name, "console", query)
 			if err != nil {
 				asyncSubresourceError, ok := err.(*AsyncSubresourceError)
 				// return if response status code does not equal to 400
@@ -235,7 +285,7 @@ func (a *AsyncSubresourceError) GetStatusCode() int {
 	return a.StatusCode
 }
 
-func (v *vmis) asyncSubresourceHelper(name string, resource string) (StreamInterface, error) {
+func (v *vmis) asyncSubresourceHelper(name string, resource string, query url.Values) (StreamInterface, error) {
 
 	done := make(chan struct{})
 
@@ -254,6 +304,7 @@ func (v *vmis) asyncSubresourceHelper(name string, resource string) (StreamInter
 	if err != nil {
 		return nil, fmt.Errorf("unable to create request for remote execution: %v", err)
 	}
+	req.URL.RawQuery = query.Encode()
 
 	errChan := make(chan error, 1)
 
@@ -297,6 +348,83 @@ func (v *vmis) asyncSubresourceHelper(name string, resource string) (StreamInter
 	}
 }
 
+func (v *vmis) ConsoleLog(name string, options *ConsoleLogOptions) (io.ReadCloser, error) {
+	uri := fmt.Sprintf(vmiSubresourceURL, v1.ApiStorageVersion, v.namespace, name, "consolelog")
+	req := v.restClient.Get().RequestURI(uri)
+	if options != nil {
+		if options.SinceSeconds != nil {
+			req = req.Param("sinceSeconds", strconv.FormatInt(*options.SinceSeconds, 10))
+		}
+		if options.TailLines != nil {
+			req = req.Param("tailLines", strconv.FormatInt(*options.TailLines, 10))
+		}
+	}
+	return req.Stream()
+}
+
+func (v *vmis) Screenshot(name string) ([]byte, error) {
+	uri := fmt.Sprintf(vmiSubresourceURL, v1.ApiStorageVersion, v.namespace, name, "screenshot")
+	return v.restClient.Get().RequestURI(uri).DoRaw()
+}
+
+func (v *vmis) GuestOsInfo(name string) (v1.VirtualMachineInstanceGuestAgentInfo, error) {
+	guestInfo := v1.VirtualMachineInstanceGuestAgentInfo{}
+	uri := fmt.Sprintf(vmiSubresourceURL, v1.ApiStorageVersion, v.namespace, name, "guestosinfo")
+	body, err := v.restClient.Get().RequestURI(uri).DoRaw()
+	if err != nil {
+		return guestInfo, err
+	}
+	err = json.Unmarshal(body, &guestInfo)
+	return guestInfo, err
+}
+
+func (v *vmis) UserList(name string) (v1.VirtualMachineInstanceGuestOSUserList, error) {
+	userList := v1.VirtualMachineInstanceGuestOSUserList{}
+	uri := fmt.Sprintf(vmiSubresourceURL, v1.ApiStorageVersion, v.namespace, name, "userlist")
+	body, err := v.restClient.Get().RequestURI(uri).DoRaw()
+	if err != nil {
+		return userList, err
+	}
+	err = json.Unmarshal(body, &userList)
+	return userList, err
+}
+
+func (v *vmis) FilesystemList(name string) (v1.VirtualMachineInstanceFileSystemList, error) {
+	filesystemList := v1.VirtualMachineInstanceFileSystemList{}
+	uri := fmt.Sprintf(vmiSubresourceURL, v1.ApiStorageVersion, v.namespace, name, "filesystemlist")
+	body, err := v.restClient.Get().RequestURI(uri).DoRaw()
+	if err != nil {
+		return filesystemList, err
+	}
+	err = json.Unmarshal(body, &filesystemList)
+	return filesystemList, err
+}
+
+func (v *vmis) GuestExec(name string, request *v1.VirtualMachineInstanceGuestExecRequest) (*v1.VirtualMachineInstanceGuestExecResult, error) {
+	data, err := json.Marshal(request)
+	if err != nil {
+		return nil, err
+	}
+
+	// The request returns once the command exited in the guest
+	timeout := time.Duration(request.TimeoutSeconds) * time.Second
+	if request.TimeoutSeconds <= 0 {
+		timeout = v1.GuestExecDefaultTimeoutSeconds * time.Second
+	}
+
+	uri := fmt.Sprintf(vmiSubresourceURL, v1.ApiStorageVersion, v.namespace, name, "guest-exec")
+	body, err := v.restClient.Put().RequestURI(uri).Body(data).Timeout(timeout + time.Minute).DoRaw()
+	if err != nil {
+		return nil, err
+	}
+
+	result := &v1.VirtualMachineInstanceGuestExecResult{}
+	if err := json.Unmarshal(body, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
 func (v *vmis) Pause(name string) error {
 	uri := fmt.Sprintf(vmiSubresourceURL, v1.ApiStorageVersion, v.namespace, name, "pause")
 	return v.restClient.Put().RequestURI(uri).Do().Error()
@@ -307,6 +435,11 @@ func (v *vmis) Unpause(name string) error {
 	return v.restClient.Put().RequestURI(uri).Do().Error()
 }
 
+func (v *vmis) SoftReboot(name string) error {
+	uri := fmt.Sprintf(vmiSubresourceURL, v1.ApiStorageVersion, v.namespace, name, "softreboot")
+	return v.restClient.Put().RequestURI(uri).Do().Error()
+}
+
 func (v *vmis) Get(name string, options *k8smetav1.GetOptions) (vmi *v1.VirtualMachineInstance, err error) {
 	vmi = &v1.VirtualMachineInstance{}
 	err = v.restClient.Get().
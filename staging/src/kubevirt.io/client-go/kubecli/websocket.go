@@ -40,7 +40,7 @@ func NewUpgrader() *websocket.Upgrader {
 		CheckOrigin: func(_ *http.Request) bool {
 			return true
 		},
-		Subprotocols: []string{subresources.PlainStreamProtocolName},
+		Subprotocols: []string{subresources.PlainStreamProtocolName, subresources.BinaryStreamProtocolName},
 	}
 }
 
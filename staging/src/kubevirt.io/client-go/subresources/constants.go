@@ -23,3 +23,12 @@ package subresources
 // Mostly useful for browser connections which need to use the websocket subprotocol
 // field to pass credentials. As a consequence they need to get a subprotocol back.
 const PlainStreamProtocolName = "plain.kubevirt.io"
+
+// BinaryStreamProtocolName is the subprotocol requested by noVNC clients.
+// The stream consists of binary messages, like a plain stream.
+const BinaryStreamProtocolName = "binary"
+
+// VNCTokenProtocolPrefix prefixes the token from the vnc/token subresource in the
+// subprotocols of novnc connections. Browsers can't set headers on websockets and
+// tokens in the query would end up in the logs of the apiserver and of proxies.
+const VNCTokenProtocolPrefix = "vnc-token.kubevirt.io."
@@ -20,6 +20,8 @@
 package tests_test
 
 import (
+	"io"
+	"io/ioutil"
 	"time"
 
 	expect "github.com/google/goexpect"
@@ -172,6 +174,83 @@ var _ = Describe("[rfe_id:127][posneg:negative][crit:medium][vendor:cnv-qe@redha
 				ExpectConsoleOutput(vmi, "login")
 			}, 220)
 
+			It("should share the console output with read-only connections", func() {
+				vmi := tests.NewRandomVMIWithEphemeralDisk(tests.ContainerDiskFor(tests.ContainerDiskAlpine))
+
+				RunVMIAndWaitForStart(vmi)
+
+				By("opening an interactive console connection")
+				expecter, errChan := OpenConsole(vmi)
+				defer expecter.Close()
+
+				By("opening a read-only console connection")
+				stream, err := virtClient.VirtualMachineInstance(vmi.Namespace).SerialConsoleReadOnly(vmi.Name, 30*time.Second)
+				Expect(err).ToNot(HaveOccurred())
+				inReader, inWriter := io.Pipe()
+				defer inWriter.Close()
+				outReader, outWriter := io.Pipe()
+				defer outReader.Close()
+				go stream.Stream(kubecli.StreamOptions{In: inReader, Out: outWriter})
+
+				By("expecting the output of the interactive connection on the read-only one")
+				_, err = expecter.ExpectBatch([]expect.Batcher{
+					&expect.BSnd{S: "\n"},
+					&expect.BExp{R: "login"},
+				}, 120*time.Second)
+				Expect(err).ToNot(HaveOccurred())
+				output := make(chan string, 100)
+				go func() {
+					buf := make([]byte, 1024)
+					for {
+						n, err := outReader.Read(buf)
+						if err != nil {
+							close(output)
+							return
+						}
+						output <- string(buf[:n])
+					}
+				}()
+				received := ""
+				Eventually(func() string {
+					select {
+					case chunk := <-output:
+						received += chunk
+					default:
+					}
+					return received
+				}, 60*time.Second).Should(ContainSubstring("login"))
+
+				By("checking that the interactive connection is still open")
+				Consistently(errChan, 5*time.Second).ShouldNot(Receive())
+			})
+
+			It("should log the boot output without an open console connection", func() {
+				vmi := tests.NewRandomVMIWithEphemeralDisk(tests.ContainerDiskFor(tests.ContainerDiskCirros))
+				logSerialConsole := true
+				vmi.Spec.Domain.Devices.LogSerialConsole = &logSerialConsole
+
+				RunVMIAndWaitForStart(vmi)
+
+				By("reading the serial console log through the subresource")
+				Eventually(func() string {
+					logs, err := virtClient.VirtualMachineInstance(vmi.Namespace).ConsoleLog(vmi.Name, &kubecli.ConsoleLogOptions{})
+					if err != nil {
+						return ""
+					}
+					defer logs.Close()
+					output, _ := ioutil.ReadAll(logs)
+					return string(output)
+				}, 180*time.Second, 2*time.Second).Should(ContainSubstring("login as 'cirros' user"))
+
+				By("reading the serial console log from the guest-console-log container")
+				pod := tests.GetRunningPodByVirtualMachineInstance(vmi, vmi.Namespace)
+				logs, err := virtClient.CoreV1().Pods(pod.Namespace).GetLogs(pod.Name, &k8sv1.PodLogOptions{
+					Container: "guest-console-log",
+				}).DoRaw()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(string(logs)).To(ContainSubstring("login as 'cirros' user"))
+			})
+
 			It("[test_id:1592]should wait until the virtual machine is in running state and return a stream interface", func() {
 				vmi := tests.NewRandomVMIWithEphemeralDisk(tests.ContainerDiskFor(tests.ContainerDiskAlpine))
 				By("Creating a new VirtualMachineInstance")
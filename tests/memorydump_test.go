@@ -0,0 +1,123 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2020 Red Hat, Inc.
+ *
+ */
+
+package tests_test
+
+import (
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	v12 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/client-go/kubecli"
+	memorydump "kubevirt.io/kubevirt/pkg/memory-dump"
+	"kubevirt.io/kubevirt/tests"
+)
+
+var _ = Describe("Memory dump", func() {
+
+	const pvName = "memory-dump"
+	const claimName = "disk-memory-dump"
+
+	tests.FlagParse()
+
+	virtClient, err := kubecli.GetKubevirtClient()
+	tests.PanicOnError(err)
+
+	var vm *v1.VirtualMachine
+
+	BeforeEach(func() {
+		tests.BeforeTestCleanup()
+
+		tests.CreateHostPathPv(pvName, filepath.Join(tests.HostPathBase, pvName))
+		tests.CreateHostPathPVC(pvName, "1Gi")
+
+		// The hostPath PV only exists on one node, the memory dump pod runs on the node of the VMI
+		vmi := tests.NewRandomVMIWithEphemeralDisk(tests.ContainerDiskFor(tests.ContainerDiskCirros))
+		vmi.Spec.NodeSelector = map[string]string{
+			"kubernetes.io/hostname": tests.GetAllSchedulableNodes(virtClient).Items[0].Name,
+		}
+		vm = tests.NewRandomVirtualMachine(vmi, false)
+		vm, err = virtClient.VirtualMachine(tests.NamespaceTestDefault).Create(vm)
+		Expect(err).ToNot(HaveOccurred())
+		vm = tests.StartVirtualMachine(vm)
+	})
+
+	AfterEach(func() {
+		tests.DeletePVC(pvName)
+		tests.DeletePV(pvName)
+	})
+
+	waitForMemoryDumpPhase := func(phase v1.MemoryDumpPhase) *v1.VirtualMachineMemoryDumpRequest {
+		var request *v1.VirtualMachineMemoryDumpRequest
+		Eventually(func() v1.MemoryDumpPhase {
+			vm, err = virtClient.VirtualMachine(vm.Namespace).Get(vm.Name, &v12.GetOptions{})
+			Expect(err).ToNot(HaveOccurred())
+			request = vm.Status.MemoryDumpRequest
+			if request == nil {
+				return ""
+			}
+			return request.Phase
+		}, 180, 2).Should(Equal(phase))
+		return request
+	}
+
+	It("should dump the memory of a running VM into a PVC and dissociate it again", func() {
+		By("Requesting a memory dump via virtctl")
+		memoryDump := tests.NewRepeatableVirtctlCommand("memory-dump", "get", "--namespace", vm.Namespace, vm.Name, "--claim-name", claimName)
+		Expect(memoryDump()).To(Succeed())
+
+		By("Waiting for the memory dump to complete")
+		request := waitForMemoryDumpPhase(v1.MemoryDumpCompleted)
+		Expect(request.ClaimName).To(Equal(claimName))
+		Expect(request.EndTimestamp).ToNot(BeNil())
+
+		By("Checking that the dump is in the PVC")
+		pod, err := virtClient.CoreV1().Pods(vm.Namespace).Get(memorydump.PodName(vm), v12.GetOptions{})
+		Expect(err).ToNot(HaveOccurred())
+		_, err = tests.ExecuteCommandOnPod(virtClient, pod, memorydump.ContainerName,
+			[]string{"/usr/bin/test", "-s", filepath.Join(memorydump.ClaimDir, *request.FileName)})
+		Expect(err).ToNot(HaveOccurred())
+
+		By("Refusing a memory dump into another PVC while the first one is associated")
+		err = virtClient.VirtualMachine(vm.Namespace).MemoryDump(vm.Name, &v1.VirtualMachineMemoryDumpRequest{ClaimName: "other-claim"})
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("remove the memory dump first"))
+
+		By("Dissociating the PVC from the VM")
+		Expect(tests.NewRepeatableVirtctlCommand("memory-dump", "remove", "--namespace", vm.Namespace, vm.Name)()).To(Succeed())
+		Eventually(func() *v1.VirtualMachineMemoryDumpRequest {
+			vm, err = virtClient.VirtualMachine(vm.Namespace).Get(vm.Name, &v12.GetOptions{})
+			Expect(err).ToNot(HaveOccurred())
+			return vm.Status.MemoryDumpRequest
+		}, 120, 2).Should(BeNil())
+		_, err = virtClient.CoreV1().Pods(vm.Namespace).Get(memorydump.PodName(vm), v12.GetOptions{})
+		Expect(errors.IsNotFound(err)).To(BeTrue())
+	})
+
+	It("should refuse to dump the memory into a missing PVC", func() {
+		err := virtClient.VirtualMachine(vm.Namespace).MemoryDump(vm.Name, &v1.VirtualMachineMemoryDumpRequest{ClaimName: "missing-claim"})
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("not found"))
+	})
+})
@@ -872,11 +872,12 @@ var _ = Describe("[rfe_id:393][crit:high[vendor:cnv-qe@redhat.com][level:system]
 
 				By("checking if we fail to connect with our own cert")
 				// Generate new certs if secret doesn't already exist
-				caKeyPair, _ := triple.NewCA("kubevirt.io")
+				caKeyPair, _ := triple.NewCA("kubevirt.io", time.Hour)
 
 				clientKeyPair, _ := triple.NewClientKeyPair(caKeyPair,
 					"kubevirt.io:system:node:virt-handler",
 					nil,
+					time.Hour,
 				)
 
 				certPEM := cert.EncodeCertPEM(clientKeyPair.Cert)
@@ -1026,6 +1027,47 @@ var _ = Describe("[rfe_id:393][crit:high[vendor:cnv-qe@redhat.com][level:system]
 				By("Waiting for VMI to disappear")
 				tests.WaitForVirtualMachineToDisappearWithTimeout(vmi, 120)
 			})
+
+			It("should migrate a non-shared PVC to another PVC", func() {
+				By("Creating an empty destination iSCSI PV and PVC")
+				destinationIP := tests.CreateISCSITargetPOD(tests.ContainerDiskEmpty)
+				destinationName := "test-iscsi-dest" + rand.String(48)
+				tests.CreateISCSIPvAndPvc(destinationName, "1Gi", destinationIP, k8sv1.ReadWriteOnce, k8sv1.PersistentVolumeBlock)
+				defer tests.DeletePvAndPvc(destinationName)
+
+				vmi := tests.NewRandomVMIWithPVC(pvName)
+				tests.AddUserData(vmi, "cloud-init", "#!/bin/bash\necho 'hello'\n")
+				vmi.Spec.Hostname = fmt.Sprintf("%s", tests.ContainerDiskCirros)
+				vmi = runVMIAndExpectLaunch(vmi, 180)
+
+				By("Checking that the VirtualMachineInstance console has expected output")
+				expecter, err := tests.LoggedInCirrosExpecter(vmi)
+				Expect(err).ToNot(HaveOccurred())
+				expecter.Close()
+
+				migration := tests.NewRandomMigration(vmi.Name, vmi.Namespace)
+				migration.Spec.MigratedVolumes = []v1.StorageMigratedVolume{
+					{VolumeName: vmi.Spec.Volumes[0].Name, DestinationClaimName: destinationName},
+				}
+				migrationUID := runMigrationAndExpectCompletion(migration, migrationWaitTime)
+				confirmVMIPostMigration(vmi, migrationUID)
+
+				By("Checking that the VirtualMachineInstance uses the destination PVC")
+				vmi, err = virtClient.VirtualMachineInstance(vmi.Namespace).Get(vmi.Name, &metav1.GetOptions{})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(vmi.Spec.Volumes[0].PersistentVolumeClaim.ClaimName).To(Equal(destinationName))
+
+				By("Checking that the VirtualMachineInstance console is still responsive")
+				expecter, err = tests.LoggedInCirrosExpecter(vmi)
+				Expect(err).ToNot(HaveOccurred())
+				expecter.Close()
+
+				By("Deleting the VMI")
+				Expect(virtClient.VirtualMachineInstance(vmi.Namespace).Delete(vmi.Name, &metav1.DeleteOptions{})).To(Succeed())
+
+				By("Waiting for VMI to disappear")
+				tests.WaitForVirtualMachineToDisappearWithTimeout(vmi, 120)
+			})
 		})
 		Context("live migration cancelation", func() {
 			type vmiBuilder func() (*v1.VirtualMachineInstance, *cdiv1.DataVolume)
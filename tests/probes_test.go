@@ -100,6 +100,32 @@ var _ = Describe("[ref_id:1182]Probes", func() {
 			table.Entry("[test_id:1220][posneg:negative]with working TCP probe and no running server", tcpProbe),
 			table.Entry("[test_id:1219][posneg:negative]with working HTTP probe and no running server", httpProbe),
 		)
+
+		It("should succeed with a guest agent ping probe once the agent is connected", func() {
+			By("Specifying a VMI with a guest agent ping readiness probe")
+			vmi := tests.NewRandomFedoraVMIWitGuestAgent()
+			vmi.Spec.ReadinessProbe = &v12.Probe{
+				PeriodSeconds:       5,
+				InitialDelaySeconds: 5,
+				Handler: v12.Handler{
+					GuestAgentPing: &v12.GuestAgentPing{},
+				},
+			}
+			vmi, err = virtClient.VirtualMachineInstance(tests.NamespaceTestDefault).Create(vmi)
+			Expect(err).ToNot(HaveOccurred())
+			tests.WaitForSuccessfulVMIStartIgnoreWarnings(vmi)
+
+			By("Waiting for the guest agent to connect")
+			tests.WaitAgentConnected(virtClient, vmi)
+
+			By("Checking that the VMI and the pod will be marked as ready to receive traffic")
+			Eventually(func() v1.ConditionStatus {
+				vmi, err = virtClient.VirtualMachineInstance(vmi.Namespace).Get(vmi.Name, &v13.GetOptions{})
+				Expect(err).ToNot(HaveOccurred())
+				return vmiReady(vmi)
+			}, 120, 1).Should(Equal(v1.ConditionTrue))
+			Expect(tests.PodReady(tests.GetRunningPodByVirtualMachineInstance(vmi, tests.NamespaceTestDefault))).To(Equal(v1.ConditionTrue))
+		})
 	})
 
 	Context("for liveness", func() {
@@ -166,6 +192,13 @@ var _ = Describe("[ref_id:1182]Probes", func() {
 		},
 			table.Entry("[test_id:1217][posneg:negative]with working TCP probe and no running server", tcpProbe),
 			table.Entry("[test_id:1218][posneg:negative]with working HTTP probe and no running server", httpProbe),
+			table.Entry("with a failing exec probe", &v12.Probe{
+				PeriodSeconds:       5,
+				InitialDelaySeconds: 90,
+				Handler: v12.Handler{
+					Exec: &v1.ExecAction{Command: []string{"/bin/false"}},
+				},
+			}),
 		)
 	})
 })
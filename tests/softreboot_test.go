@@ -0,0 +1,94 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2020 Red Hat, Inc.
+ *
+ */
+
+package tests_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	v12 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/client-go/kubecli"
+	"kubevirt.io/kubevirt/tests"
+)
+
+var _ = Describe("Soft reboot", func() {
+
+	tests.FlagParse()
+
+	virtClient, err := kubecli.GetKubevirtClient()
+	tests.PanicOnError(err)
+
+	BeforeEach(func() {
+		tests.BeforeTestCleanup()
+	})
+
+	expectSoftRebootInSamePod := func(vmi *v1.VirtualMachineInstance, softReboot func() error) {
+		podName := tests.GetRunningPodByVirtualMachineInstance(vmi, tests.NamespaceTestDefault).Name
+
+		By("Soft rebooting the VMI")
+		Expect(softReboot()).To(Succeed())
+
+		By("Checking that the soft reboot is recorded in the VMI status")
+		Eventually(func() int64 {
+			vmi, err = virtClient.VirtualMachineInstance(vmi.Namespace).Get(vmi.Name, &v12.GetOptions{})
+			Expect(err).ToNot(HaveOccurred())
+			if vmi.Status.SoftRebootState == nil {
+				return 0
+			}
+			return vmi.Status.SoftRebootState.Count
+		}, 60, 1).Should(Equal(int64(1)))
+
+		By("Checking that the VMI is still running in the same pod")
+		Expect(vmi.Status.Phase).To(Equal(v1.Running))
+		Expect(tests.GetRunningPodByVirtualMachineInstance(vmi, tests.NamespaceTestDefault).Name).To(Equal(podName))
+	}
+
+	It("should soft reboot a VMI through the guest agent via virtctl", func() {
+		vmi := tests.NewRandomFedoraVMIWitGuestAgent()
+		vmi = tests.RunVMIAndExpectLaunch(vmi, 180)
+		tests.WaitAgentConnected(virtClient, vmi)
+
+		expectSoftRebootInSamePod(vmi, tests.NewRepeatableVirtctlCommand("soft-reboot", "--namespace", tests.NamespaceTestDefault, vmi.Name))
+
+		By("Waiting for the guest agent to connect again")
+		tests.WaitAgentConnected(virtClient, vmi)
+	})
+
+	It("should soft reboot a VMI through ACPI via the API", func() {
+		vmi := tests.NewRandomVMIWithEphemeralDisk(tests.ContainerDiskFor(tests.ContainerDiskCirros))
+		vmi.Spec.Domain.Features = &v1.Features{}
+		vmi = tests.RunVMIAndExpectLaunch(vmi, 90)
+
+		expectSoftRebootInSamePod(vmi, func() error {
+			return virtClient.VirtualMachineInstance(vmi.Namespace).SoftReboot(vmi.Name)
+		})
+	})
+
+	It("should reject soft reboots of a VMI without guest agent and ACPI", func() {
+		vmi := tests.NewRandomVMIWithEphemeralDisk(tests.ContainerDiskFor(tests.ContainerDiskCirros))
+		vmi = tests.RunVMIAndExpectLaunch(vmi, 90)
+
+		err := virtClient.VirtualMachineInstance(vmi.Namespace).SoftReboot(vmi.Name)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("VMI neither has the guest agent connected nor the ACPI feature enabled"))
+	})
+})
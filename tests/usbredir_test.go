@@ -0,0 +1,78 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2019 Red Hat, Inc.
+ *
+ */
+
+package tests_test
+
+import (
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/client-go/kubecli"
+	"kubevirt.io/kubevirt/tests"
+)
+
+var _ = Describe("USB Redirection", func() {
+
+	tests.FlagParse()
+
+	virtClient, err := kubecli.GetKubevirtClient()
+	tests.PanicOnError(err)
+
+	BeforeEach(func() {
+		tests.BeforeTestCleanup()
+	})
+
+	Context("A VirtualMachineInstance without USB redirection", func() {
+		It("should reject usbredir connections", func() {
+			vmi := tests.RunVMIAndExpectLaunch(tests.NewRandomVMI(), 90)
+
+			_, err := virtClient.VirtualMachineInstance(vmi.Namespace).USBRedir(vmi.Name)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("400"))
+		})
+	})
+
+	Context("A VirtualMachineInstance with USB redirection", func() {
+		It("should have a redirection channel per slot", func() {
+			slots := int32(2)
+			vmi := tests.NewRandomVMI()
+			vmi.Spec.Domain.Devices.USBRedirect = &v1.USBRedirect{Slots: &slots}
+			vmi = tests.RunVMIAndExpectLaunch(vmi, 90)
+
+			domXML, err := tests.GetRunningVirtualMachineInstanceDomainXML(virtClient, vmi)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(strings.Count(domXML, "<redirdev")).To(Equal(2))
+			Expect(domXML).To(ContainSubstring("virt-usbredir0"))
+			Expect(domXML).To(ContainSubstring("virt-usbredir1"))
+		})
+
+		It("should accept usbredir connections", func() {
+			vmi := tests.NewRandomVMI()
+			vmi.Spec.Domain.Devices.USBRedirect = &v1.USBRedirect{}
+			vmi = tests.RunVMIAndExpectLaunch(vmi, 90)
+
+			stream, err := virtClient.VirtualMachineInstance(vmi.Namespace).USBRedir(vmi.Name)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(stream).ToNot(BeNil())
+		})
+	})
+})
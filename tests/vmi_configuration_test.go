@@ -1107,6 +1107,46 @@ var _ = Describe("Configurations", func() {
 							Fields{"Type": Equal(v1.VirtualMachineInstanceAgentConnected)})),
 					"Agent condition should be gone")
 			})
+
+			It("should return guest information, users, filesystems and command output through the subresources", func() {
+				agentVMI := tests.NewRandomFedoraVMIWitGuestAgent()
+
+				By("Starting a VirtualMachineInstance")
+				agentVMI, err = virtClient.VirtualMachineInstance(tests.NamespaceTestDefault).Create(agentVMI)
+				Expect(err).ToNot(HaveOccurred(), "Should create VMI successfully")
+				tests.WaitForSuccessfulVMIStart(agentVMI)
+				tests.WaitAgentConnected(virtClient, agentVMI)
+
+				By("Fetching the guest OS information")
+				guestInfo, err := virtClient.VirtualMachineInstance(tests.NamespaceTestDefault).GuestOsInfo(agentVMI.Name)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(guestInfo.GAVersion).ToNot(BeEmpty())
+				Expect(guestInfo.OS.Name).To(Equal("Fedora"))
+
+				By("Fetching the mounted filesystems")
+				filesystems, err := virtClient.VirtualMachineInstance(tests.NamespaceTestDefault).FilesystemList(agentVMI.Name)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(filesystems.Items).To(ContainElement(MatchFields(IgnoreExtras, Fields{"MountPoint": Equal("/")})))
+
+				By("Logging in and fetching the logged in users")
+				expecter, err := tests.LoggedInFedoraExpecter(agentVMI)
+				Expect(err).ToNot(HaveOccurred())
+				defer expecter.Close()
+				Eventually(func() []v1.VirtualMachineInstanceGuestOSUser {
+					users, err := virtClient.VirtualMachineInstance(tests.NamespaceTestDefault).UserList(agentVMI.Name)
+					Expect(err).ToNot(HaveOccurred())
+					return users.Items
+				}, 60*time.Second, 2).ShouldNot(BeEmpty())
+
+				By("Executing a command in the guest")
+				result, err := virtClient.VirtualMachineInstance(tests.NamespaceTestDefault).GuestExec(agentVMI.Name, &v1.VirtualMachineInstanceGuestExecRequest{
+					Command: "/usr/bin/cat",
+					Input:   "hello from the host",
+				})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(result.ExitCode).To(BeZero())
+				Expect(result.Stdout).To(Equal("hello from the host"))
+			})
 		})
 
 		Context("[rfe_id:140][crit:medium][vendor:cnv-qe@redhat.com][level:component]with serial-number", func() {
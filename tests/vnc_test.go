@@ -20,9 +20,12 @@
 package tests_test
 
 import (
+	"bytes"
 	"fmt"
+	"image/png"
 	"io"
 	"net/http"
+	"net/url"
 	"time"
 
 	"github.com/gorilla/websocket"
@@ -155,6 +158,47 @@ var _ = Describe("[rfe_id:127][crit:medium][vendor:cnv-qe@redhat.com][level:comp
 			_, err = wrappedRoundTripper.RoundTrip(req)
 			Expect(err).ToNot(HaveOccurred())
 		})
+
+		It("should accept noVNC connections with a VNC token", func() {
+			token, err := virtClient.VirtualMachineInstance(vmi.Namespace).VNCToken(vmi.Name, false)
+			Expect(err).ToNot(HaveOccurred())
+
+			u, dialer := noVNCDialerFor(vmi, token.Token)
+
+			conn, resp, err := dialer.Dial(u, nil)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(resp.Header.Get("Sec-Websocket-Protocol")).To(Equal(subresources.BinaryStreamProtocolName))
+
+			msgType, data, err := conn.ReadMessage()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(msgType).To(Equal(websocket.BinaryMessage))
+			Expect(string(data)).To(HavePrefix("RFB "))
+			conn.Close()
+
+			By("reusing the token")
+			_, resp, err = dialer.Dial(u, nil)
+			Expect(err).To(HaveOccurred())
+			Expect(resp).ToNot(BeNil())
+			Expect(resp.StatusCode).To(Equal(http.StatusUnauthorized))
+		})
+
+		It("should reject noVNC connections with an invalid VNC token", func() {
+			u, dialer := noVNCDialerFor(vmi, "invalid")
+
+			_, resp, err := dialer.Dial(u, nil)
+			Expect(err).To(HaveOccurred())
+			Expect(resp).ToNot(BeNil())
+			Expect(resp.StatusCode).To(Equal(http.StatusUnauthorized))
+		})
+
+		It("should return a PNG screenshot of the graphical console", func() {
+			data, err := virtClient.VirtualMachineInstance(vmi.Namespace).Screenshot(vmi.Name)
+			Expect(err).ToNot(HaveOccurred())
+			img, err := png.Decode(bytes.NewReader(data))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(img.Bounds().Dx()).To(BeNumerically(">", 0))
+			Expect(img.Bounds().Dy()).To(BeNumerically(">", 0))
+		})
 	})
 })
 
@@ -196,3 +240,23 @@ func upgradeCheckRoundTripperFromConfig(config *rest.Config, subprotocols []stri
 		Dialer: dialer,
 	}, nil
 }
+
+// noVNCDialerFor returns the novnc URL of the VMI and a dialer which, like a
+// browser, only offers the noVNC subprotocol and the token. The dialer has no
+// Kubernetes credentials, the token alone authorizes the connection.
+func noVNCDialerFor(vmi *v1.VirtualMachineInstance, token string) (string, *websocket.Dialer) {
+	config, err := kubecli.GetKubevirtClientConfig()
+	Expect(err).ToNot(HaveOccurred())
+	tlsConfig, err := rest.TLSConfigFor(rest.AnonymousClientConfig(config))
+	Expect(err).ToNot(HaveOccurred())
+	dialer := &websocket.Dialer{
+		TLSClientConfig: tlsConfig,
+		Subprotocols:    []string{subresources.BinaryStreamProtocolName, subresources.VNCTokenProtocolPrefix + token},
+	}
+
+	u, err := url.Parse(config.Host)
+	Expect(err).ToNot(HaveOccurred())
+	u.Scheme = "wss"
+	u.Path = fmt.Sprintf("/apis/subresources.kubevirt.io/%s/namespaces/%s/virtualmachineinstances/%s/novnc", v1.ApiStorageVersion, vmi.Namespace, vmi.Name)
+	return u.String(), dialer
+}
@@ -58,6 +58,12 @@ func main() {
 		util.MarshallObject(components.NewVirtualMachineCrd(), os.Stdout)
 	case "vmim":
 		util.MarshallObject(components.NewVirtualMachineInstanceMigrationCrd(), os.Stdout)
+	case "cdic":
+		util.MarshallObject(components.NewContainerDiskImageCacheCrd(), os.Stdout)
+	case "vmbackup":
+		util.MarshallObject(components.NewVirtualMachineBackupCrd(), os.Stdout)
+	case "vmexport":
+		util.MarshallObject(components.NewVirtualMachineExportCrd(), os.Stdout)
 	case "kv":
 		util.MarshallObject(components.NewKubeVirtCrd(), os.Stdout)
 	case "kv-cr":